@@ -3,7 +3,9 @@ package app
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/git"
 )
 
@@ -18,8 +20,37 @@ var (
 	GetRepoName          = git.GetRepoName
 	WorktreeExists       = git.WorktreeExists
 	CheckCurrentWorktree = git.CheckCurrentWorktree
+	UndoLast             = git.UndoLast
+	DropLastUndo         = git.DropLastUndo
 )
 
+// undoLastGitOp reverses the most recent discard, amend, reset, force push
+// or stash drop sidecar recorded in workDir.
+func undoLastGitOp(workDir string) tea.Cmd {
+	return func() tea.Msg {
+		point, err := UndoLast(workDir)
+		if point == nil && err != nil {
+			return ToastMsg{Message: err.Error(), Duration: 2 * time.Second}
+		}
+		if err != nil {
+			return ToastMsg{Message: "Undo failed: " + err.Error() + " · alt+y to drop it", Duration: 3 * time.Second, IsError: true}
+		}
+		return ToastMsg{Message: "Undid " + point.Summary, Duration: 2 * time.Second}
+	}
+}
+
+// dropLastUndoPoint forgets the most recent undo point without reversing
+// it, so ctrl+y moves on to the one before.
+func dropLastUndoPoint(workDir string) tea.Cmd {
+	return func() tea.Msg {
+		point, err := DropLastUndo(workDir)
+		if err != nil {
+			return ToastMsg{Message: err.Error(), Duration: 2 * time.Second}
+		}
+		return ToastMsg{Message: "Dropped undo point: " + point.Summary, Duration: 2 * time.Second}
+	}
+}
+
 // normalizePath wraps git.normalizePath for internal app package use.
 func normalizePath(path string) (string, error) {
 	absPath, err := filepath.Abs(path)
@@ -1,6 +1,9 @@
 package app
 
-import "testing"
+import (
+	"os/exec"
+	"testing"
+)
 
 func TestParseWorktreeList(t *testing.T) {
 	tests := []struct {
@@ -169,3 +172,25 @@ func TestParseRepoNameFromURL(t *testing.T) {
 		})
 	}
 }
+
+func TestUndoLastGitOp_NothingRecorded(t *testing.T) {
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	msg, ok := undoLastGitOp(dir)().(ToastMsg)
+	if !ok || msg.Message != "Nothing to undo" || msg.IsError {
+		t.Errorf("toast = %+v", msg)
+	}
+}
+
+func TestDropLastUndoPoint_NothingRecorded(t *testing.T) {
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	msg, ok := dropLastUndoPoint(dir)().(ToastMsg)
+	if !ok || msg.Message != "Nothing to undo" || msg.IsError {
+		t.Errorf("toast = %+v", msg)
+	}
+}
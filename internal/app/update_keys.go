@@ -35,7 +35,7 @@ func (m *Model) handlePluginSwitchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool
 	return m, nil, false
 }
 
-// handleToggleKeys handles toggle shortcuts (?, !, @, W, #, i, r).
+// handleToggleKeys handles toggle shortcuts (?, !, @, W, #, i, r), ctrl+y undo and alt+y drop.
 // Returns (model, cmd, handled). If handled is false, the key should fall through.
 // Extracted from handleKeyMsg to reduce update.go complexity.
 func (m *Model) handleToggleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
@@ -125,6 +125,12 @@ func (m *Model) handleToggleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 			return m, nil, false
 		}
 		return m, Refresh(), true
+	case "ctrl+y":
+		// Undo the last risky git operation, then refresh every plugin
+		return m, tea.Sequence(undoLastGitOp(m.ui.WorkDir), Refresh()), true
+	case "alt+y":
+		// Forget an undo point that keeps failing
+		return m, dropLastUndoPoint(m.ui.WorkDir), true
 	}
 	return m, nil, false
 }
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Config is the root configuration structure.
 type Config struct {
@@ -9,6 +12,7 @@ type Config struct {
 	Keymap   KeymapConfig   `json:"keymap"`
 	UI       UIConfig       `json:"ui"`
 	Features FeaturesConfig `json:"features"`
+	Forges   ForgesConfig   `json:"forges"`
 }
 
 // FeaturesConfig holds feature flag settings.
@@ -16,6 +20,13 @@ type FeaturesConfig struct {
 	Flags map[string]bool `json:"flags"`
 }
 
+// ForgesConfig tells self-hosted forges apart.
+type ForgesConfig struct {
+	// Hosts maps a remote hostname to its forge: "github", "gitlab",
+	// "gitea", "forgejo" or "bitbucket".
+	Hosts map[string]string `json:"hosts"`
+}
+
 // ProjectsConfig configures project detection and layout.
 type ProjectsConfig struct {
 	Mode string          `json:"mode"` // "single" for now
@@ -41,8 +52,11 @@ type PluginsConfig struct {
 
 // GitStatusPluginConfig configures the git status plugin.
 type GitStatusPluginConfig struct {
-	Enabled         bool          `json:"enabled"`
-	RefreshInterval time.Duration `json:"refreshInterval"`
+	Enabled             bool          `json:"enabled"`
+	RefreshInterval     time.Duration `json:"refreshInterval"`
+	CommitSubjectLength int           `json:"commitSubjectLength"` // Longest commit header before lint warns
+	BisectCommand       string        `json:"bisectCommand"`       // Default test command for bisect run
+	LargeFileWarnMB     int           `json:"largeFileWarnMB"`     // Warn before staging larger files not in Git LFS; 0 disables
 }
 
 // TDMonitorPluginConfig configures the TD monitor plugin.
@@ -75,6 +89,26 @@ type WorkspacePluginConfig struct {
 	InteractiveCopyKey string `json:"interactiveCopyKey,omitempty"`
 	// InteractivePasteKey is the keybinding to paste clipboard in interactive mode. Default: "alt+v".
 	InteractivePasteKey string `json:"interactivePasteKey,omitempty"`
+	// Agents adds coding agents, or changes built-in ones with the same ID.
+	Agents []AgentConfig `json:"agents,omitempty"`
+}
+
+// AgentConfig defines a coding agent for workspaces and shells. For a
+// built-in ID only the fields that are set replace the built-in values.
+type AgentConfig struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name,omitempty"`            // Shown in agent pickers
+	Abbrev          string   `json:"abbrev,omitempty"`          // Short label for shell entries
+	Command         string   `json:"command,omitempty"`         // Launch command, e.g. "claude --model opus"
+	SkipPermissions string   `json:"skipPermissions,omitempty"` // Flag that auto-approves all actions
+	PromptStyle     string   `json:"promptStyle,omitempty"`     // "arg", "stdin" or "file"
+	PromptArgs      string   `json:"promptArgs,omitempty"`      // Arguments with {{prompt}}, e.g. "run {{prompt}}"
+	ResumeCommand   string   `json:"resumeCommand,omitempty"`   // With {{session}}, e.g. "claude --resume {{session}}"
+	WaitingPatterns []string `json:"waitingPatterns,omitempty"` // Output that means the agent needs input
+	DonePatterns    []string `json:"donePatterns,omitempty"`    // Output that means the agent finished
+	ErrorPatterns   []string `json:"errorPatterns,omitempty"`   // Output that means the agent failed
+	AdapterID       string   `json:"adapterId,omitempty"`       // Conversation adapter for its sessions, e.g. "claude-code"
+	Hidden          *bool    `json:"hidden,omitempty"`          // Launchable but left out of agent pickers
 }
 
 // NotesPluginConfig configures the notes plugin.
@@ -113,8 +147,10 @@ func Default() *Config {
 		},
 		Plugins: PluginsConfig{
 			GitStatus: GitStatusPluginConfig{
-				Enabled:         true,
-				RefreshInterval: time.Second,
+				Enabled:             true,
+				RefreshInterval:     time.Second,
+				CommitSubjectLength: 72,
+				LargeFileWarnMB:     10,
 			},
 			TDMonitor: TDMonitorPluginConfig{
 				Enabled:         true,
@@ -143,6 +179,9 @@ func Default() *Config {
 		Features: FeaturesConfig{
 			Flags: make(map[string]bool),
 		},
+		Forges: ForgesConfig{
+			Hosts: make(map[string]string),
+		},
 	}
 }
 
@@ -151,11 +190,32 @@ func (c *Config) Validate() error {
 	if c.Plugins.GitStatus.RefreshInterval < 0 {
 		c.Plugins.GitStatus.RefreshInterval = time.Second
 	}
+	if c.Plugins.GitStatus.CommitSubjectLength <= 0 {
+		c.Plugins.GitStatus.CommitSubjectLength = 72
+	}
+	if c.Plugins.GitStatus.LargeFileWarnMB < 0 {
+		c.Plugins.GitStatus.LargeFileWarnMB = 0
+	}
 	if c.Plugins.TDMonitor.RefreshInterval < 0 {
 		c.Plugins.TDMonitor.RefreshInterval = 2 * time.Second
 	}
 	if c.Plugins.Workspace.TmuxCaptureMaxBytes <= 0 {
 		c.Plugins.Workspace.TmuxCaptureMaxBytes = 2 * 1024 * 1024
 	}
+	// Agents need an ID, and an unknown prompt style falls back to "arg"
+	agents := c.Plugins.Workspace.Agents[:0]
+	for _, a := range c.Plugins.Workspace.Agents {
+		a.ID = strings.TrimSpace(a.ID)
+		if a.ID == "" {
+			continue
+		}
+		switch a.PromptStyle {
+		case "", "arg", "stdin", "file":
+		default:
+			a.PromptStyle = "arg"
+		}
+		agents = append(agents, a)
+	}
+	c.Plugins.Workspace.Agents = agents
 	return nil
 }
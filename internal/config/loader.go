@@ -35,6 +35,7 @@ type rawConfig struct {
 	Keymap   KeymapConfig      `json:"keymap"`
 	UI       rawUIConfig       `json:"ui"`
 	Features FeaturesConfig    `json:"features"`
+	Forges   ForgesConfig      `json:"forges"`
 }
 
 type rawUIConfig struct {
@@ -63,17 +64,21 @@ type rawPluginsConfig struct {
 }
 
 type rawWorkspaceConfig struct {
-	DirPrefix            *bool  `json:"dirPrefix"`
-	TmuxCaptureMaxBytes  *int   `json:"tmuxCaptureMaxBytes"`
-	InteractiveExitKey   string `json:"interactiveExitKey"`
-	InteractiveAttachKey string `json:"interactiveAttachKey"`
-	InteractiveCopyKey   string `json:"interactiveCopyKey"`
-	InteractivePasteKey  string `json:"interactivePasteKey"`
+	DirPrefix            *bool         `json:"dirPrefix"`
+	TmuxCaptureMaxBytes  *int          `json:"tmuxCaptureMaxBytes"`
+	InteractiveExitKey   string        `json:"interactiveExitKey"`
+	InteractiveAttachKey string        `json:"interactiveAttachKey"`
+	InteractiveCopyKey   string        `json:"interactiveCopyKey"`
+	InteractivePasteKey  string        `json:"interactivePasteKey"`
+	Agents               []AgentConfig `json:"agents"`
 }
 
 type rawGitStatusConfig struct {
-	Enabled         *bool  `json:"enabled"`
-	RefreshInterval string `json:"refreshInterval"`
+	Enabled             *bool  `json:"enabled"`
+	RefreshInterval     string `json:"refreshInterval"`
+	CommitSubjectLength *int   `json:"commitSubjectLength"`
+	BisectCommand       string `json:"bisectCommand"`
+	LargeFileWarnMB     *int   `json:"largeFileWarnMB"`
 }
 
 type rawTDMonitorConfig struct {
@@ -176,6 +181,15 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 			cfg.Plugins.GitStatus.RefreshInterval = d
 		}
 	}
+	if raw.Plugins.GitStatus.CommitSubjectLength != nil {
+		cfg.Plugins.GitStatus.CommitSubjectLength = *raw.Plugins.GitStatus.CommitSubjectLength
+	}
+	if raw.Plugins.GitStatus.BisectCommand != "" {
+		cfg.Plugins.GitStatus.BisectCommand = raw.Plugins.GitStatus.BisectCommand
+	}
+	if raw.Plugins.GitStatus.LargeFileWarnMB != nil {
+		cfg.Plugins.GitStatus.LargeFileWarnMB = *raw.Plugins.GitStatus.LargeFileWarnMB
+	}
 
 	// TD Monitor
 	if raw.Plugins.TDMonitor.Enabled != nil {
@@ -217,6 +231,9 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 	if raw.Plugins.Workspace.InteractivePasteKey != "" {
 		cfg.Plugins.Workspace.InteractivePasteKey = raw.Plugins.Workspace.InteractivePasteKey
 	}
+	if raw.Plugins.Workspace.Agents != nil {
+		cfg.Plugins.Workspace.Agents = raw.Plugins.Workspace.Agents
+	}
 
 	// Keymap
 	if raw.Keymap.Overrides != nil {
@@ -252,6 +269,11 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 			cfg.Features.Flags[k] = v
 		}
 	}
+
+	// Forges
+	for k, v := range raw.Forges.Hosts {
+		cfg.Forges.Hosts[k] = v
+	}
 }
 
 // ExpandPath expands ~ to home directory.
@@ -184,3 +184,77 @@ func TestLoadFrom_EmptyProjectsList(t *testing.T) {
 		t.Errorf("got %d projects, want 0", len(cfg.Projects.List))
 	}
 }
+
+func TestLoadFrom_ForgeHosts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{
+		"forges": {
+			"hosts": {"git.example.com": "forgejo", "code.example.com": "gitlab"}
+		}
+	}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if got := cfg.Forges.Hosts["git.example.com"]; got != "forgejo" {
+		t.Errorf("git.example.com = %q, want forgejo", got)
+	}
+	if got := cfg.Forges.Hosts["code.example.com"]; got != "gitlab" {
+		t.Errorf("code.example.com = %q, want gitlab", got)
+	}
+}
+
+func TestLoadFrom_LargeFileWarn(t *testing.T) {
+	if got := Default().Plugins.GitStatus.LargeFileWarnMB; got != 10 {
+		t.Errorf("default = %d, want 10", got)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := []byte(`{"plugins": {"git-status": {"largeFileWarnMB": 0}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if got := cfg.Plugins.GitStatus.LargeFileWarnMB; got != 0 {
+		t.Errorf("largeFileWarnMB = %d, want 0 to turn the warning off", got)
+	}
+}
+
+func TestLoadFrom_WorkspaceAgents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := []byte(`{"plugins": {"workspace": {"agents": [
+		{"id": "claude", "command": "claude --model opus"},
+		{"id": " goose ", "name": "Goose", "command": "goose session", "promptStyle": "pipe", "waitingPatterns": ["( O)>"]},
+		{"name": "No ID"}
+	]}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	agents := cfg.Plugins.Workspace.Agents
+	if len(agents) != 2 {
+		t.Fatalf("agents = %+v, want the two with an ID", agents)
+	}
+	if agents[0].ID != "claude" || agents[0].Command != "claude --model opus" {
+		t.Errorf("override = %+v", agents[0])
+	}
+	if agents[1].ID != "goose" || agents[1].PromptStyle != "arg" || len(agents[1].WaitingPatterns) != 1 {
+		t.Errorf("custom agent = %+v, want trimmed ID and unknown prompt style reset to arg", agents[1])
+	}
+}
@@ -15,6 +15,7 @@ type saveConfig struct {
 	Keymap   KeymapConfig       `json:"keymap"`
 	UI       UIConfig           `json:"ui"`
 	Features FeaturesConfig     `json:"features,omitempty"`
+	Forges   ForgesConfig       `json:"forges,omitempty"`
 }
 
 type saveProjectsConfig struct {
@@ -31,8 +32,11 @@ type savePluginsConfig struct {
 }
 
 type saveGitStatusConfig struct {
-	Enabled         *bool  `json:"enabled,omitempty"`
-	RefreshInterval string `json:"refreshInterval,omitempty"`
+	Enabled             *bool  `json:"enabled,omitempty"`
+	RefreshInterval     string `json:"refreshInterval,omitempty"`
+	CommitSubjectLength int    `json:"commitSubjectLength,omitempty"`
+	BisectCommand       string `json:"bisectCommand,omitempty"`
+	LargeFileWarnMB     *int   `json:"largeFileWarnMB,omitempty"`
 }
 
 type saveTDMonitorConfig struct {
@@ -47,12 +51,13 @@ type saveConversationsConfig struct {
 }
 
 type saveWorkspaceConfig struct {
-	DirPrefix            *bool  `json:"dirPrefix,omitempty"`
-	TmuxCaptureMaxBytes  *int   `json:"tmuxCaptureMaxBytes,omitempty"`
-	InteractiveExitKey   string `json:"interactiveExitKey,omitempty"`
-	InteractiveAttachKey string `json:"interactiveAttachKey,omitempty"`
-	InteractiveCopyKey   string `json:"interactiveCopyKey,omitempty"`
-	InteractivePasteKey  string `json:"interactivePasteKey,omitempty"`
+	DirPrefix            *bool         `json:"dirPrefix,omitempty"`
+	TmuxCaptureMaxBytes  *int          `json:"tmuxCaptureMaxBytes,omitempty"`
+	InteractiveExitKey   string        `json:"interactiveExitKey,omitempty"`
+	InteractiveAttachKey string        `json:"interactiveAttachKey,omitempty"`
+	InteractiveCopyKey   string        `json:"interactiveCopyKey,omitempty"`
+	InteractivePasteKey  string        `json:"interactivePasteKey,omitempty"`
+	Agents               []AgentConfig `json:"agents,omitempty"`
 }
 
 // toSaveConfig converts Config to the JSON-serializable format.
@@ -65,8 +70,11 @@ func toSaveConfig(cfg *Config) saveConfig {
 		},
 		Plugins: savePluginsConfig{
 			GitStatus: saveGitStatusConfig{
-				Enabled:         &cfg.Plugins.GitStatus.Enabled,
-				RefreshInterval: cfg.Plugins.GitStatus.RefreshInterval.String(),
+				Enabled:             &cfg.Plugins.GitStatus.Enabled,
+				RefreshInterval:     cfg.Plugins.GitStatus.RefreshInterval.String(),
+				CommitSubjectLength: cfg.Plugins.GitStatus.CommitSubjectLength,
+				BisectCommand:       cfg.Plugins.GitStatus.BisectCommand,
+				LargeFileWarnMB:     &cfg.Plugins.GitStatus.LargeFileWarnMB,
 			},
 			TDMonitor: saveTDMonitorConfig{
 				Enabled:         &cfg.Plugins.TDMonitor.Enabled,
@@ -84,11 +92,13 @@ func toSaveConfig(cfg *Config) saveConfig {
 				InteractiveAttachKey: cfg.Plugins.Workspace.InteractiveAttachKey,
 				InteractiveCopyKey:   cfg.Plugins.Workspace.InteractiveCopyKey,
 				InteractivePasteKey:  cfg.Plugins.Workspace.InteractivePasteKey,
+				Agents:               cfg.Plugins.Workspace.Agents,
 			},
 		},
 		Keymap:   cfg.Keymap,
 		UI:       cfg.UI,
 		Features: cfg.Features,
+		Forges:   cfg.Forges,
 	}
 }
 
@@ -124,6 +134,9 @@ func Save(cfg *Config) error {
 	if len(sc.Features.Flags) > 0 {
 		fields["features"] = sc.Features
 	}
+	if len(sc.Forges.Hosts) > 0 {
+		fields["forges"] = sc.Forges
+	}
 	for key, val := range fields {
 		b, err := json.Marshal(val)
 		if err != nil {
@@ -0,0 +1,47 @@
+package forge
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// bitbucket links to Bitbucket Cloud. There is no official CLI, so merge
+// request operations are not supported.
+type bitbucket struct {
+	repo Repo
+}
+
+func (f *bitbucket) Repo() Repo   { return f.repo }
+func (f *bitbucket) Name() string { return "Bitbucket" }
+func (f *bitbucket) CLI() string  { return "" }
+
+func (f *bitbucket) CommitURL(hash string) string {
+	return f.repo.WebURL() + "/commits/" + hash
+}
+
+func (f *bitbucket) BranchURL(branch string) string {
+	return f.repo.WebURL() + "/branch/" + escapePath(branch)
+}
+
+func (f *bitbucket) FileURL(ref, path string, line int) string {
+	u := f.repo.WebURL() + "/src/" + escapePath(ref) + "/" + escapePath(path)
+	if line > 0 {
+		u += "#lines-" + strconv.Itoa(line)
+	}
+	return u
+}
+
+// errBitbucketCLI is returned for every merge request operation.
+var errBitbucketCLI = fmt.Errorf("bitbucket pull requests: %w", ErrUnsupported)
+
+func (f *bitbucket) ListMergeRequests(dir string, limit int) ([]MergeRequest, error) {
+	return nil, errBitbucketCLI
+}
+
+func (f *bitbucket) CreateMergeRequest(dir string, req NewMergeRequest) (string, error) {
+	return "", errBitbucketCLI
+}
+
+func (f *bitbucket) MergeRequestState(dir string) (MergeState, error) {
+	return "", errBitbucketCLI
+}
@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+// runCLI runs a forge CLI in dir and returns its stdout. On failure the
+// error is a *CLIError carrying stderr, or stdout if stderr is empty.
+func runCLI(dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(string(output))
+		}
+		return output, &CLIError{Command: commandName(name, args), Output: msg, Err: err}
+	}
+	return output, nil
+}
+
+// commandName names a CLI invocation by its program and subcommands, such
+// as "gh pr create".
+func commandName(name string, args []string) string {
+	parts := []string{name}
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") || len(parts) == 3 {
+			break
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
+
+// lastURL returns the last http(s) URL on its own line of output, which is
+// where the CLIs print the merge request they created.
+func lastURL(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		for _, field := range strings.Fields(lines[i]) {
+			if strings.HasPrefix(field, "https://") || strings.HasPrefix(field, "http://") {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// currentBranch returns the branch checked out in dir.
+func currentBranch(dir string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// isDraftTitle reports whether title marks a work-in-progress merge
+// request, for forges that draft by title prefix.
+func isDraftTitle(title string) bool {
+	upper := strings.ToUpper(title)
+	for _, prefix := range []string{"WIP:", "[WIP]", "DRAFT:", "[DRAFT]"} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
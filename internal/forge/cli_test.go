@@ -0,0 +1,213 @@
+package forge
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeCLI installs a shell script named name first on PATH. It logs its
+// arguments, one per line, to the returned file before running body.
+func fakeCLI(t *testing.T, name, body string) (argsFile string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLIs are shell scripts")
+	}
+	bin := t.TempDir()
+	argsFile = filepath.Join(bin, name+".args")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+// readArgs returns the arguments a fake CLI was last run with.
+func readArgs(t *testing.T, argsFile string) []string {
+	t.Helper()
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("CLI was not run: %v", err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// gitRepoOnBranch creates a repository with branch checked out.
+func gitRepoOnBranch(t *testing.T, branch string) string {
+	t.Helper()
+	dir := t.TempDir()
+	cmd := exec.Command("git", "init", "-q", "-b", branch)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	return dir
+}
+
+func hasArgs(args []string, want ...string) bool {
+	for i := 0; i+len(want) <= len(args); i++ {
+		match := true
+		for j, w := range want {
+			if args[i+j] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGitHub_ListMergeRequests(t *testing.T) {
+	argsFile := fakeCLI(t, "gh", `echo '[{"number":7,"title":"Add x","headRefName":"feat/x","url":"https://github.com/o/r/pull/7","isDraft":true,"createdAt":"2026-01-02T03:04:05Z","author":{"login":"sam"}}]'`)
+	mrs, err := New(Repo{Kind: KindGitHub}).ListMergeRequests(t.TempDir(), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MergeRequest{Number: 7, Title: "Add x", Branch: "feat/x", Author: "sam", URL: "https://github.com/o/r/pull/7", CreatedAt: "2026-01-02T03:04:05Z", Draft: true}
+	if len(mrs) != 1 || mrs[0] != want {
+		t.Errorf("mrs = %+v, want [%+v]", mrs, want)
+	}
+	if args := readArgs(t, argsFile); !hasArgs(args, "pr", "list") || !hasArgs(args, "--limit", "30") {
+		t.Errorf("args = %q", args)
+	}
+}
+
+func TestGitHub_CreateMergeRequestExists(t *testing.T) {
+	fakeCLI(t, "gh", `echo 'a pull request for branch "x" into branch "main" already exists:' >&2
+echo 'https://github.com/o/r/pull/3' >&2
+exit 1`)
+	_, err := New(Repo{Kind: KindGitHub}).CreateMergeRequest(t.TempDir(), NewMergeRequest{Title: "t", Base: "main"})
+	var exists *ExistsError
+	if !errors.As(err, &exists) || exists.URL != "https://github.com/o/r/pull/3" {
+		t.Errorf("err = %v, want ExistsError for pull/3", err)
+	}
+}
+
+func TestGitLab_CreateMergeRequest(t *testing.T) {
+	argsFile := fakeCLI(t, "glab", `echo 'Creating merge request for feat into main in team/repo'
+echo
+echo '!12 Add feature (feat)'
+echo ' https://gitlab.example.com/team/repo/-/merge_requests/12'`)
+	got, err := New(Repo{Kind: KindGitLab}).CreateMergeRequest(t.TempDir(), NewMergeRequest{Title: "Add feature", Body: "Why", Base: "main", Branch: "feat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "https://gitlab.example.com/team/repo/-/merge_requests/12" {
+		t.Errorf("URL = %q", got)
+	}
+	args := readArgs(t, argsFile)
+	for _, want := range [][]string{{"mr", "create"}, {"--description", "Why"}, {"--target-branch", "main"}, {"--source-branch", "feat"}, {"--yes"}} {
+		if !hasArgs(args, want...) {
+			t.Errorf("args %q missing %q", args, want)
+		}
+	}
+}
+
+func TestGitLab_CreateMergeRequestExists(t *testing.T) {
+	fakeCLI(t, "glab", `if [ "$2" = view ]; then
+  echo '{"iid":4,"state":"opened","web_url":"https://gitlab.example.com/team/repo/-/merge_requests/4"}'
+  exit 0
+fi
+echo 'failed to create merge request. 409 Conflict {message: [Another open merge request already exists for this source branch: !4]}' >&2
+exit 1`)
+	_, err := New(Repo{Kind: KindGitLab}).CreateMergeRequest(t.TempDir(), NewMergeRequest{Title: "t", Base: "main", Branch: "feat"})
+	var exists *ExistsError
+	if !errors.As(err, &exists) || exists.URL != "https://gitlab.example.com/team/repo/-/merge_requests/4" {
+		t.Errorf("err = %v, want ExistsError for !4", err)
+	}
+}
+
+func TestGitLab_MergeRequestState(t *testing.T) {
+	tests := []struct {
+		json string
+		want MergeState
+	}{
+		{`{"state":"opened"}`, MergeStateOpen},
+		{`{"state":"merged","merged_at":"2026-01-02T03:04:05Z"}`, MergeStateMerged},
+		{`{"state":"closed"}`, MergeStateClosed},
+	}
+	for _, tt := range tests {
+		fakeCLI(t, "glab", "echo '"+tt.json+"'")
+		got, err := New(Repo{Kind: KindGitLab}).MergeRequestState(t.TempDir())
+		if err != nil || got != tt.want {
+			t.Errorf("state for %s = %q, %v; want %q", tt.json, got, err, tt.want)
+		}
+	}
+}
+
+func TestGitLab_ListMergeRequests(t *testing.T) {
+	fakeCLI(t, "glab", `echo '[{"iid":5,"title":"Draft: y","source_branch":"y","web_url":"https://gitlab.com/g/r/-/merge_requests/5","created_at":"2026-01-02T03:04:05Z","draft":true,"author":{"username":"kim"}}]'`)
+	mrs, err := New(Repo{Kind: KindGitLab}).ListMergeRequests(t.TempDir(), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mrs) != 1 || mrs[0].Number != 5 || mrs[0].Branch != "y" || mrs[0].Author != "kim" || !mrs[0].Draft {
+		t.Errorf("mrs = %+v", mrs)
+	}
+}
+
+func TestGitea_ListAndState(t *testing.T) {
+	argsFile := fakeCLI(t, "tea", `echo '[{"index":"9","title":"WIP: z","head":"feat/z","url":"https://git.example.com/o/r/pulls/9","author":"lee","created":"2026-01-02 03:04","state":"merged"}]'`)
+	f := New(Repo{Kind: KindGitea})
+
+	mrs, err := f.ListMergeRequests(t.TempDir(), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mrs) != 1 || mrs[0].Number != 9 || mrs[0].Branch != "feat/z" || mrs[0].Author != "lee" || !mrs[0].Draft {
+		t.Errorf("mrs = %+v", mrs)
+	}
+	if args := readArgs(t, argsFile); !hasArgs(args, "--state", "open") || !hasArgs(args, "--output", "json") {
+		t.Errorf("args = %q", args)
+	}
+
+	state, err := f.MergeRequestState(gitRepoOnBranch(t, "feat/z"))
+	if err != nil || state != MergeStateMerged {
+		t.Errorf("state = %q, %v; want merged", state, err)
+	}
+	if _, err := f.MergeRequestState(gitRepoOnBranch(t, "other")); err == nil {
+		t.Error("expected an error for a branch without a pull request")
+	}
+}
+
+func TestGitea_CreateMergeRequest(t *testing.T) {
+	argsFile := fakeCLI(t, "tea", `echo '#10 Add z (feat/z -> main)'
+echo 'https://git.example.com/o/r/pulls/10'`)
+	got, err := New(Repo{Kind: KindGitea}).CreateMergeRequest(gitRepoOnBranch(t, "feat/z"), NewMergeRequest{Title: "Add z", Base: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "https://git.example.com/o/r/pulls/10" {
+		t.Errorf("URL = %q", got)
+	}
+	if args := readArgs(t, argsFile); !hasArgs(args, "--head", "feat/z") || !hasArgs(args, "--base", "main") {
+		t.Errorf("args = %q", args)
+	}
+}
+
+func TestCLIError(t *testing.T) {
+	fakeCLI(t, "gh", "echo 'gh: not logged in' >&2; exit 4")
+	_, err := New(Repo{Kind: KindGitHub}).MergeRequestState(t.TempDir())
+	var cliErr *CLIError
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("err = %v, want *CLIError", err)
+	}
+	if got := err.Error(); got != "gh pr view: gh: not logged in" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestBitbucket_Unsupported(t *testing.T) {
+	_, err := New(Repo{Kind: KindBitbucket}).ListMergeRequests(t.TempDir(), 30)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("err = %v, want ErrUnsupported", err)
+	}
+}
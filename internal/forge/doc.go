@@ -0,0 +1,8 @@
+// Package forge knows the code hosting services a repository's remote can
+// live on: GitHub, GitLab, Gitea/Forgejo and Bitbucket. It parses remote
+// URLs into repositories, builds web URLs for commits, branches and files,
+// and lists, creates and checks merge requests through each forge's CLI
+// (gh, glab and tea).
+//
+// This package has no UI dependencies.
+package forge
@@ -0,0 +1,260 @@
+package forge
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Kind identifies a forge.
+type Kind string
+
+const (
+	KindUnknown   Kind = ""
+	KindGitHub    Kind = "github"
+	KindGitLab    Kind = "gitlab"
+	KindGitea     Kind = "gitea" // Also Forgejo
+	KindBitbucket Kind = "bitbucket"
+)
+
+// knownHosts are the public instances of each forge.
+var knownHosts = map[string]Kind{
+	"github.com":    KindGitHub,
+	"gitlab.com":    KindGitLab,
+	"codeberg.org":  KindGitea,
+	"gitea.com":     KindGitea,
+	"bitbucket.org": KindBitbucket,
+}
+
+// ParseKind reads a forge name as written in config. "forgejo" is Gitea.
+func ParseKind(name string) Kind {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "github":
+		return KindGitHub
+	case "gitlab":
+		return KindGitLab
+	case "gitea", "forgejo":
+		return KindGitea
+	case "bitbucket":
+		return KindBitbucket
+	}
+	return KindUnknown
+}
+
+// KindForHost returns the forge serving host. hosts maps custom hostnames
+// to forge names and wins over the built-in guesses, which go by the public
+// instances and then by the hostname itself ("gitlab.example.com").
+func KindForHost(host string, hosts map[string]string) Kind {
+	host = strings.ToLower(host)
+	for h, name := range hosts {
+		if strings.EqualFold(h, host) {
+			if k := ParseKind(name); k != KindUnknown {
+				return k
+			}
+		}
+	}
+	if k, ok := knownHosts[host]; ok {
+		return k
+	}
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return KindGitLab
+	case strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"):
+		return KindGitea
+	case strings.Contains(host, "bitbucket"):
+		return KindBitbucket
+	case strings.Contains(host, "github"):
+		return KindGitHub
+	}
+	return KindUnknown
+}
+
+// Repo is a repository on a forge.
+type Repo struct {
+	Kind   Kind
+	Scheme string // Scheme of the web UI: https unless the remote is http
+	Host   string // Host of the web UI, with port for http(s) remotes
+	Owner  string // User or group; GitLab subgroups are joined with "/"
+	Name   string
+}
+
+// WebURL returns the repository's home page.
+func (r Repo) WebURL() string {
+	return r.Scheme + "://" + r.Host + "/" + r.Owner + "/" + r.Name
+}
+
+// ParseRemote parses a git remote URL: scp-like ssh (git@host:owner/repo),
+// ssh://, git:// and http(s)://. ok is false when remote names no owner and
+// repository. Kind is resolved with KindForHost.
+func ParseRemote(remote string, hosts map[string]string) (r Repo, ok bool) {
+	remote = strings.TrimSpace(remote)
+	var path string
+	r.Scheme = "https"
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil || u.Host == "" {
+			return Repo{}, false
+		}
+		r.Host = u.Hostname()
+		if u.Scheme == "http" || u.Scheme == "https" {
+			// The web UI shares the port of an http remote, not of ssh
+			r.Scheme = u.Scheme
+			r.Host = u.Host
+		}
+		path = u.Path
+	} else {
+		// scp-like: [user@]host:path
+		hostPart, p, found := strings.Cut(remote, ":")
+		if !found || strings.Contains(hostPart, "/") {
+			return Repo{}, false
+		}
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		r.Host = hostPart
+		path = p
+	}
+
+	path = strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	i := strings.LastIndex(path, "/")
+	if r.Host == "" || i <= 0 || i == len(path)-1 {
+		return Repo{}, false
+	}
+	r.Owner, r.Name = path[:i], path[i+1:]
+	r.Kind = KindForHost(stripPort(r.Host), hosts)
+	return r, true
+}
+
+// stripPort removes a :port suffix from host.
+func stripPort(host string) string {
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		return host[:i]
+	}
+	return host
+}
+
+// MergeRequest is an open pull or merge request.
+type MergeRequest struct {
+	Number    int
+	Title     string
+	Branch    string // Source branch
+	Author    string
+	URL       string
+	CreatedAt string
+	Draft     bool
+}
+
+// NewMergeRequest describes a merge request to open.
+type NewMergeRequest struct {
+	Title  string
+	Body   string
+	Base   string // Target branch
+	Branch string // Source branch; the checked-out branch when empty
+}
+
+// MergeState is the state of a merge request.
+type MergeState string
+
+const (
+	MergeStateOpen   MergeState = "open"
+	MergeStateMerged MergeState = "merged"
+	MergeStateClosed MergeState = "closed"
+)
+
+// Forge is a repository on a code hosting service. Merge request operations
+// run the forge's CLI in a checkout of the repository.
+type Forge interface {
+	// Repo returns the repository the forge was made for.
+	Repo() Repo
+	// Name returns the forge's display name.
+	Name() string
+	// CLI returns the command merge request operations run.
+	CLI() string
+
+	CommitURL(hash string) string
+	BranchURL(branch string) string
+	// FileURL links to path at ref, at line when it is positive.
+	FileURL(ref, path string, line int) string
+
+	// ListMergeRequests returns up to limit open merge requests.
+	ListMergeRequests(dir string, limit int) ([]MergeRequest, error)
+	// CreateMergeRequest opens a merge request and returns its URL. If one
+	// is already open for the branch it returns an *ExistsError.
+	CreateMergeRequest(dir string, req NewMergeRequest) (string, error)
+	// MergeRequestState reports the merge request of the checked-out
+	// branch.
+	MergeRequestState(dir string) (MergeState, error)
+}
+
+// New returns the forge for r. Repositories of unknown kind are treated as
+// GitHub, whose CLI also serves GitHub Enterprise.
+func New(r Repo) Forge {
+	switch r.Kind {
+	case KindGitLab:
+		return &gitLab{repo: r}
+	case KindGitea:
+		return &gitea{repo: r}
+	case KindBitbucket:
+		return &bitbucket{repo: r}
+	}
+	return &gitHub{repo: r}
+}
+
+// Detect returns the forge of the origin remote of the repository at dir.
+func Detect(dir string, hosts map[string]string) (Forge, error) {
+	remote := RemoteURL(dir)
+	if remote == "" {
+		return nil, ErrNoRemote
+	}
+	r, ok := ParseRemote(remote, hosts)
+	if !ok {
+		return nil, fmt.Errorf("unrecognized remote %q", remote)
+	}
+	return New(r), nil
+}
+
+// RemoteURL returns the URL of the origin remote, or "" if there is none.
+func RemoteURL(dir string) string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// ErrNoRemote is returned by Detect for a repository without origin.
+var ErrNoRemote = errors.New("no remote configured")
+
+// ErrUnsupported is returned for operations a forge has no CLI for.
+var ErrUnsupported = errors.New("not supported")
+
+// ExistsError reports that a merge request is already open for the branch.
+type ExistsError struct {
+	URL string
+}
+
+func (e *ExistsError) Error() string {
+	return "merge request already exists: " + e.URL
+}
+
+// CLIError reports a forge CLI command that failed.
+type CLIError struct {
+	Command string // Such as "glab mr create"
+	Output  string
+	Err     error
+}
+
+func (e *CLIError) Error() string {
+	if e.Output == "" {
+		return e.Command + ": " + e.Err.Error()
+	}
+	return e.Command + ": " + e.Output
+}
+
+func (e *CLIError) Unwrap() error {
+	return e.Err
+}
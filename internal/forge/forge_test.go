@@ -0,0 +1,160 @@
+package forge
+
+import "testing"
+
+func TestParseRemote(t *testing.T) {
+	hosts := map[string]string{"git.corp.example": "forgejo"}
+	tests := []struct {
+		remote string
+		want   Repo
+	}{
+		{"git@github.com:owner/repo.git", Repo{KindGitHub, "https", "github.com", "owner", "repo"}},
+		{"https://github.com/owner/repo", Repo{KindGitHub, "https", "github.com", "owner", "repo"}},
+		{"https://gitlab.com/group/sub/repo.git", Repo{KindGitLab, "https", "gitlab.com", "group/sub", "repo"}},
+		{"ssh://git@gitlab.example.com:2222/team/repo.git", Repo{KindGitLab, "https", "gitlab.example.com", "team", "repo"}},
+		{"http://git.corp.example:3000/team/repo.git/", Repo{KindGitea, "http", "git.corp.example:3000", "team", "repo"}},
+		{"git@git.corp.example:team/repo.git", Repo{KindGitea, "https", "git.corp.example", "team", "repo"}},
+		{"git@codeberg.org:owner/repo.git", Repo{KindGitea, "https", "codeberg.org", "owner", "repo"}},
+		{"https://user@bitbucket.org/owner/repo.git", Repo{KindBitbucket, "https", "bitbucket.org", "owner", "repo"}},
+		{"git@scm.example.net:owner/repo.git", Repo{KindUnknown, "https", "scm.example.net", "owner", "repo"}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRemote(tt.remote, hosts)
+		if !ok || got != tt.want {
+			t.Errorf("ParseRemote(%q) = %+v, %v; want %+v", tt.remote, got, ok, tt.want)
+		}
+	}
+
+	for _, remote := range []string{"", "/srv/git/repo.git", "https://github.com/repo", "git@github.com:"} {
+		if got, ok := ParseRemote(remote, nil); ok {
+			t.Errorf("ParseRemote(%q) = %+v, want not ok", remote, got)
+		}
+	}
+}
+
+func TestKindForHost_ConfigWins(t *testing.T) {
+	hosts := map[string]string{"GitHub.Example.com": "gitlab"}
+	if got := KindForHost("github.example.com", hosts); got != KindGitLab {
+		t.Errorf("KindForHost = %q, want %q", got, KindGitLab)
+	}
+	if got := KindForHost("github.example.com", nil); got != KindGitHub {
+		t.Errorf("KindForHost without config = %q, want %q", got, KindGitHub)
+	}
+}
+
+func TestWebURLs(t *testing.T) {
+	tests := []struct {
+		remote             string
+		commit, branch     string
+		fileAtCommit, file string
+	}{
+		{
+			"git@github.com:o/r.git",
+			"https://github.com/o/r/commit/abc1234",
+			"https://github.com/o/r/tree/feature/x",
+			"https://github.com/o/r/blob/abc1234/src/a%20b.go#L12",
+			"https://github.com/o/r/blob/main/README.md",
+		},
+		{
+			"git@gitlab.com:g/s/r.git",
+			"https://gitlab.com/g/s/r/-/commit/abc1234",
+			"https://gitlab.com/g/s/r/-/tree/feature/x",
+			"https://gitlab.com/g/s/r/-/blob/abc1234/src/a%20b.go#L12",
+			"https://gitlab.com/g/s/r/-/blob/main/README.md",
+		},
+		{
+			"https://codeberg.org/o/r.git",
+			"https://codeberg.org/o/r/commit/abc1234",
+			"https://codeberg.org/o/r/src/branch/feature/x",
+			"https://codeberg.org/o/r/src/commit/abc1234/src/a%20b.go#L12",
+			"https://codeberg.org/o/r/src/branch/main/README.md",
+		},
+		{
+			"git@bitbucket.org:o/r.git",
+			"https://bitbucket.org/o/r/commits/abc1234",
+			"https://bitbucket.org/o/r/branch/feature/x",
+			"https://bitbucket.org/o/r/src/abc1234/src/a%20b.go#lines-12",
+			"https://bitbucket.org/o/r/src/main/README.md",
+		},
+	}
+	for _, tt := range tests {
+		r, _ := ParseRemote(tt.remote, nil)
+		f := New(r)
+		if got := f.CommitURL("abc1234"); got != tt.commit {
+			t.Errorf("%s CommitURL = %q, want %q", f.Name(), got, tt.commit)
+		}
+		if got := f.BranchURL("feature/x"); got != tt.branch {
+			t.Errorf("%s BranchURL = %q, want %q", f.Name(), got, tt.branch)
+		}
+		if got := f.FileURL("abc1234", "src/a b.go", 12); got != tt.fileAtCommit {
+			t.Errorf("%s FileURL = %q, want %q", f.Name(), got, tt.fileAtCommit)
+		}
+		if got := f.FileURL("main", "README.md", 0); got != tt.file {
+			t.Errorf("%s FileURL = %q, want %q", f.Name(), got, tt.file)
+		}
+	}
+}
+
+func TestNew_UnknownIsGitHub(t *testing.T) {
+	if got := New(Repo{Host: "git.example.com"}).CLI(); got != "gh" {
+		t.Errorf("CLI = %q, want gh", got)
+	}
+}
+
+func TestParseExistingPRURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantURL   string
+		wantFound bool
+	}{
+		{
+			name:      "standard error with PR URL",
+			output:    `a pull request for branch "workspace-improvements" into branch "main" already exists: https://github.com/guyghost/sidecar/pull/30: exit status 1`,
+			wantURL:   "https://github.com/guyghost/sidecar/pull/30",
+			wantFound: true,
+		},
+		{
+			name:      "error without exit status suffix",
+			output:    `a pull request for branch "feature" into branch "main" already exists: https://github.com/owner/repo/pull/123`,
+			wantURL:   "https://github.com/owner/repo/pull/123",
+			wantFound: true,
+		},
+		{
+			name:      "different error message",
+			output:    `GraphQL: Could not resolve to a Repository with the name 'owner/repo'.`,
+			wantURL:   "",
+			wantFound: false,
+		},
+		{
+			name:      "empty output",
+			output:    ``,
+			wantURL:   "",
+			wantFound: false,
+		},
+		{
+			name:      "already exists but no URL",
+			output:    `a pull request already exists: `,
+			wantURL:   "",
+			wantFound: false,
+		},
+		{
+			name:      "URL with trailing newline",
+			output:    "a pull request already exists: https://github.com/o/r/pull/1\n",
+			wantURL:   "https://github.com/o/r/pull/1",
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotFound := parseExistingPRURL(tt.output)
+			if gotURL != tt.wantURL {
+				t.Errorf("parseExistingPRURL() url = %q, want %q", gotURL, tt.wantURL)
+			}
+			if gotFound != tt.wantFound {
+				t.Errorf("parseExistingPRURL() found = %v, want %v", gotFound, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gitea drives Gitea and Forgejo through tea.
+type gitea struct {
+	repo Repo
+}
+
+func (f *gitea) Repo() Repo   { return f.repo }
+func (f *gitea) Name() string { return "Gitea" }
+func (f *gitea) CLI() string  { return "tea" }
+
+func (f *gitea) CommitURL(hash string) string {
+	return f.repo.WebURL() + "/commit/" + hash
+}
+
+func (f *gitea) BranchURL(branch string) string {
+	return f.repo.WebURL() + "/src/branch/" + escapePath(branch)
+}
+
+func (f *gitea) FileURL(ref, path string, line int) string {
+	kind := "branch"
+	if isCommitHash(ref) {
+		kind = "commit"
+	}
+	u := f.repo.WebURL() + "/src/" + kind + "/" + escapePath(ref) + "/" + escapePath(path)
+	if line > 0 {
+		u += "#L" + strconv.Itoa(line)
+	}
+	return u
+}
+
+// teaPullFields are the columns asked of tea pulls list. tea prints every
+// field as a string.
+const teaPullFields = "index,title,head,url,author,created,state"
+
+func (f *gitea) ListMergeRequests(dir string, limit int) ([]MergeRequest, error) {
+	pulls, err := f.list(dir, "open", limit)
+	if err != nil {
+		return nil, err
+	}
+	mrs := make([]MergeRequest, len(pulls))
+	for i, pr := range pulls {
+		number, _ := strconv.Atoi(pr["index"])
+		mrs[i] = MergeRequest{
+			Number:    number,
+			Title:     pr["title"],
+			Branch:    pr["head"],
+			Author:    pr["author"],
+			URL:       pr["url"],
+			CreatedAt: pr["created"],
+			Draft:     isDraftTitle(pr["title"]),
+		}
+	}
+	return mrs, nil
+}
+
+func (f *gitea) CreateMergeRequest(dir string, req NewMergeRequest) (string, error) {
+	branch := req.Branch
+	if branch == "" {
+		var err error
+		if branch, err = currentBranch(dir); err != nil {
+			return "", err
+		}
+	}
+	output, err := runCLI(dir, "tea", "pulls", "create",
+		"--title", req.Title,
+		"--description", req.Body,
+		"--base", req.Base,
+		"--head", branch,
+	)
+	if err != nil {
+		var cliErr *CLIError
+		if errors.As(err, &cliErr) && strings.Contains(cliErr.Output, "already exists") {
+			if pr, found, listErr := f.find(dir, "open", branch); listErr == nil && found {
+				return "", &ExistsError{URL: pr["url"]}
+			}
+		}
+		return "", err
+	}
+	return lastURL(string(output)), nil
+}
+
+func (f *gitea) MergeRequestState(dir string) (MergeState, error) {
+	branch, err := currentBranch(dir)
+	if err != nil {
+		return "", err
+	}
+	pr, found, err := f.find(dir, "all", branch)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("no pull request found for branch %s", branch)
+	}
+	switch pr["state"] {
+	case "merged":
+		return MergeStateMerged, nil
+	case "closed":
+		return MergeStateClosed, nil
+	}
+	return MergeStateOpen, nil
+}
+
+// list returns up to limit pull requests in state (open, closed or all).
+func (f *gitea) list(dir, state string, limit int) ([]map[string]string, error) {
+	output, err := runCLI(dir, "tea", "pulls", "list",
+		"--output", "json",
+		"--fields", teaPullFields,
+		"--state", state,
+		"--limit", strconv.Itoa(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var pulls []map[string]string
+	if err := json.Unmarshal(output, &pulls); err != nil {
+		return nil, fmt.Errorf("parse tea pulls list: %w", err)
+	}
+	return pulls, nil
+}
+
+// find returns the newest pull request in state from branch; tea has no
+// lookup by branch.
+func (f *gitea) find(dir, state, branch string) (map[string]string, bool, error) {
+	pulls, err := f.list(dir, state, 50)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, pr := range pulls {
+		if pr["head"] == branch {
+			return pr, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// isCommitHash reports whether ref looks like an abbreviated or full commit
+// hash rather than a branch name.
+func isCommitHash(ref string) bool {
+	if len(ref) < 7 || len(ref) > 64 {
+		return false
+	}
+	for _, c := range ref {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
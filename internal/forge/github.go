@@ -0,0 +1,159 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// gitHub drives GitHub and GitHub Enterprise through gh.
+type gitHub struct {
+	repo Repo
+}
+
+func (f *gitHub) Repo() Repo   { return f.repo }
+func (f *gitHub) Name() string { return "GitHub" }
+func (f *gitHub) CLI() string  { return "gh" }
+
+func (f *gitHub) CommitURL(hash string) string {
+	return f.repo.WebURL() + "/commit/" + hash
+}
+
+func (f *gitHub) BranchURL(branch string) string {
+	return f.repo.WebURL() + "/tree/" + escapePath(branch)
+}
+
+func (f *gitHub) FileURL(ref, path string, line int) string {
+	u := f.repo.WebURL() + "/blob/" + escapePath(ref) + "/" + escapePath(path)
+	if line > 0 {
+		u += "#L" + strconv.Itoa(line)
+	}
+	return u
+}
+
+func (f *gitHub) ListMergeRequests(dir string, limit int) ([]MergeRequest, error) {
+	output, err := runCLI(dir, "gh", "pr", "list",
+		"--json", "number,title,headRefName,url,isDraft,createdAt,author",
+		"--limit", strconv.Itoa(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var prs []struct {
+		Number      int    `json:"number"`
+		Title       string `json:"title"`
+		HeadRefName string `json:"headRefName"`
+		URL         string `json:"url"`
+		IsDraft     bool   `json:"isDraft"`
+		CreatedAt   string `json:"createdAt"`
+		Author      struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	}
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("parse gh pr list: %w", err)
+	}
+	mrs := make([]MergeRequest, len(prs))
+	for i, pr := range prs {
+		mrs[i] = MergeRequest{
+			Number:    pr.Number,
+			Title:     pr.Title,
+			Branch:    pr.HeadRefName,
+			Author:    pr.Author.Login,
+			URL:       pr.URL,
+			CreatedAt: pr.CreatedAt,
+			Draft:     pr.IsDraft,
+		}
+	}
+	return mrs, nil
+}
+
+func (f *gitHub) CreateMergeRequest(dir string, req NewMergeRequest) (string, error) {
+	args := []string{"pr", "create",
+		"--title", req.Title,
+		"--body", req.Body,
+		"--base", req.Base,
+	}
+	if req.Branch != "" {
+		args = append(args, "--head", req.Branch)
+	}
+	output, err := runCLI(dir, "gh", args...)
+	if err != nil {
+		var cliErr *CLIError
+		if errors.As(err, &cliErr) {
+			if existing, found := parseExistingPRURL(cliErr.Output); found {
+				return "", &ExistsError{URL: existing}
+			}
+		}
+		return "", err
+	}
+	return lastURL(string(output)), nil
+}
+
+func (f *gitHub) MergeRequestState(dir string) (MergeState, error) {
+	output, err := runCLI(dir, "gh", "pr", "view", "--json", "state,mergedAt")
+	if err != nil {
+		return "", err
+	}
+	var pr struct {
+		State    string `json:"state"`
+		MergedAt string `json:"mergedAt"`
+	}
+	if err := json.Unmarshal(output, &pr); err != nil {
+		return "", fmt.Errorf("parse gh pr view: %w", err)
+	}
+	switch {
+	case pr.MergedAt != "" || pr.State == "MERGED":
+		return MergeStateMerged, nil
+	case pr.State == "CLOSED":
+		return MergeStateClosed, nil
+	}
+	return MergeStateOpen, nil
+}
+
+// parseExistingPRURL extracts the PR URL from a "PR already exists" error message.
+// Returns the URL and true if found, empty string and false otherwise.
+func parseExistingPRURL(output string) (string, bool) {
+	// Error format: "a pull request for branch X into branch Y already exists: <URL>: exit status 1"
+	const marker = "already exists:"
+	idx := strings.Index(output, marker)
+	if idx == -1 {
+		return "", false
+	}
+
+	// Extract URL after marker
+	rest := strings.TrimSpace(output[idx+len(marker):])
+
+	// Find the URL - it starts with http and ends before ": exit" or end of string
+	if !strings.HasPrefix(rest, "http") {
+		return "", false
+	}
+
+	// Find where URL ends - look for ": exit" pattern which follows the URL
+	endIdx := strings.Index(rest, ": exit")
+	if endIdx == -1 {
+		// No ": exit" suffix, URL goes to end (trim whitespace)
+		endIdx = strings.IndexAny(rest, " \t\n")
+		if endIdx == -1 {
+			endIdx = len(rest)
+		}
+	}
+
+	prURL := strings.TrimSpace(rest[:endIdx])
+	if prURL == "" {
+		return "", false
+	}
+	return prURL, true
+}
+
+// escapePath escapes each segment of a slash-separated path for a URL.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,130 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gitLab drives GitLab through glab.
+type gitLab struct {
+	repo Repo
+}
+
+func (f *gitLab) Repo() Repo   { return f.repo }
+func (f *gitLab) Name() string { return "GitLab" }
+func (f *gitLab) CLI() string  { return "glab" }
+
+func (f *gitLab) CommitURL(hash string) string {
+	return f.repo.WebURL() + "/-/commit/" + hash
+}
+
+func (f *gitLab) BranchURL(branch string) string {
+	return f.repo.WebURL() + "/-/tree/" + escapePath(branch)
+}
+
+func (f *gitLab) FileURL(ref, path string, line int) string {
+	u := f.repo.WebURL() + "/-/blob/" + escapePath(ref) + "/" + escapePath(path)
+	if line > 0 {
+		u += "#L" + strconv.Itoa(line)
+	}
+	return u
+}
+
+// glabMR is a merge request as printed by glab --output json.
+type glabMR struct {
+	IID            int    `json:"iid"`
+	Title          string `json:"title"`
+	SourceBranch   string `json:"source_branch"`
+	WebURL         string `json:"web_url"`
+	State          string `json:"state"`
+	MergedAt       string `json:"merged_at"`
+	CreatedAt      string `json:"created_at"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+	Author         struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (f *gitLab) ListMergeRequests(dir string, limit int) ([]MergeRequest, error) {
+	output, err := runCLI(dir, "glab", "mr", "list", "--output", "json", "--per-page", strconv.Itoa(limit))
+	if err != nil {
+		return nil, err
+	}
+	var list []glabMR
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("parse glab mr list: %w", err)
+	}
+	mrs := make([]MergeRequest, len(list))
+	for i, mr := range list {
+		mrs[i] = MergeRequest{
+			Number:    mr.IID,
+			Title:     mr.Title,
+			Branch:    mr.SourceBranch,
+			Author:    mr.Author.Username,
+			URL:       mr.WebURL,
+			CreatedAt: mr.CreatedAt,
+			Draft:     mr.Draft || mr.WorkInProgress,
+		}
+	}
+	return mrs, nil
+}
+
+func (f *gitLab) CreateMergeRequest(dir string, req NewMergeRequest) (string, error) {
+	args := []string{"mr", "create",
+		"--title", req.Title,
+		"--description", req.Body,
+		"--target-branch", req.Base,
+		"--yes",
+	}
+	if req.Branch != "" {
+		args = append(args, "--source-branch", req.Branch)
+	}
+	output, err := runCLI(dir, "glab", args...)
+	if err != nil {
+		var cliErr *CLIError
+		if errors.As(err, &cliErr) && strings.Contains(cliErr.Output, "already exists") {
+			// GitLab names the existing request only by number
+			if mr, viewErr := f.view(dir, req.Branch); viewErr == nil {
+				return "", &ExistsError{URL: mr.WebURL}
+			}
+		}
+		return "", err
+	}
+	return lastURL(string(output)), nil
+}
+
+func (f *gitLab) MergeRequestState(dir string) (MergeState, error) {
+	mr, err := f.view(dir, "")
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case mr.MergedAt != "" || mr.State == "merged":
+		return MergeStateMerged, nil
+	case mr.State == "closed":
+		return MergeStateClosed, nil
+	}
+	return MergeStateOpen, nil
+}
+
+// view returns the merge request of branch, or of the checked-out branch
+// when branch is empty.
+func (f *gitLab) view(dir, branch string) (glabMR, error) {
+	args := []string{"mr", "view", "--output", "json"}
+	if branch != "" {
+		args = append(args, branch)
+	}
+	var mr glabMR
+	output, err := runCLI(dir, "glab", args...)
+	if err != nil {
+		return mr, err
+	}
+	if err := json.Unmarshal(output, &mr); err != nil {
+		return mr, fmt.Errorf("parse glab mr view: %w", err)
+	}
+	return mr, nil
+}
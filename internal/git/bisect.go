@@ -0,0 +1,206 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BisectMark is a verdict given to the commit under test.
+type BisectMark string
+
+const (
+	BisectGood BisectMark = "good"
+	BisectBad  BisectMark = "bad"
+	BisectSkip BisectMark = "skip"
+)
+
+// firstBadRegex matches the line git bisect log ends with once the culprit
+// is known.
+var firstBadRegex = regexp.MustCompile(`^# first bad commit: \[([0-9a-f]+)\]`)
+
+// BisectState describes the bisect session of a worktree. Bisect refs are
+// per worktree, so linked worktrees each have their own session.
+type BisectState struct {
+	Active     bool
+	Bad        string   // Newest known bad commit
+	Good       []string // Commits marked good
+	Skipped    []string // Commits marked skip
+	Current    string   // Commit checked out for testing
+	Remaining  int      // Commits still suspected
+	Steps      int      // Rough number of steps left
+	Candidates map[string]bool
+	Culprit    string // First bad commit, once found
+}
+
+// Done reports whether the bisect has found the first bad commit.
+func (s *BisectState) Done() bool {
+	return s != nil && s.Culprit != ""
+}
+
+// BisectError wraps a git bisect error with its output.
+type BisectError struct {
+	Output string
+	Err    error
+}
+
+func (e *BisectError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *BisectError) Unwrap() error {
+	return e.Err
+}
+
+// IsBisectInProgress reports whether a bisect session is active in workDir.
+func IsBisectInProgress(workDir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "BISECT_START")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(gitPathAbs(workDir, string(output)))
+	return err == nil
+}
+
+// GetBisectState reads the bisect session of workDir. It returns an
+// inactive state when no bisect is running.
+func GetBisectState(workDir string) (*BisectState, error) {
+	state := &BisectState{}
+	if !IsBisectInProgress(workDir) {
+		return state, nil
+	}
+	state.Active = true
+
+	cmd := exec.Command("git", "for-each-ref", "--format=%(objectname) %(refname)", "refs/bisect/")
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &BisectError{Output: string(output), Err: err}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		hash, ref, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		switch {
+		case ref == "refs/bisect/bad":
+			state.Bad = hash
+		case strings.HasPrefix(ref, "refs/bisect/good-"):
+			state.Good = append(state.Good, hash)
+		case strings.HasPrefix(ref, "refs/bisect/skip-"):
+			state.Skipped = append(state.Skipped, hash)
+		}
+	}
+
+	cmd = exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = workDir
+	if output, err := cmd.Output(); err == nil {
+		state.Current = strings.TrimSpace(string(output))
+	}
+
+	cmd = exec.Command("git", "bisect", "log")
+	cmd.Dir = workDir
+	if output, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			if m := firstBadRegex.FindStringSubmatch(line); m != nil {
+				state.Culprit = m[1]
+			}
+		}
+	}
+
+	// The range is only known once both ends are marked
+	if state.Bad == "" || len(state.Good) == 0 {
+		return state, nil
+	}
+	revs := append([]string{state.Bad, "--not"}, state.Good...)
+	cmd = exec.Command("git", append([]string{"rev-list"}, revs...)...)
+	cmd.Dir = workDir
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		return nil, &BisectError{Output: string(output), Err: err}
+	}
+	state.Candidates = make(map[string]bool)
+	for _, hash := range strings.Fields(string(output)) {
+		state.Candidates[hash] = true
+	}
+
+	cmd = exec.Command("git", append([]string{"rev-list", "--bisect-vars"}, revs...)...)
+	cmd.Dir = workDir
+	if output, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			key, value, _ := strings.Cut(line, "=")
+			switch key {
+			case "bisect_all":
+				state.Remaining, _ = strconv.Atoi(value)
+			case "bisect_steps":
+				state.Steps, _ = strconv.Atoi(value)
+			}
+		}
+	}
+	if state.Culprit != "" {
+		state.Remaining, state.Steps = 0, 0
+	}
+	return state, nil
+}
+
+// StartBisect starts a bisect between a bad and a good commit and checks
+// out the first commit to test.
+func StartBisect(workDir, bad, good string) (string, error) {
+	return runBisect(workDir, "start", bad, good, "--")
+}
+
+// MarkBisect marks the checked-out commit and returns git's report of the
+// next step, or of the first bad commit.
+func MarkBisect(workDir string, mark BisectMark) (string, error) {
+	return runBisect(workDir, string(mark))
+}
+
+// ResetBisect ends the bisect and returns to the original branch.
+func ResetBisect(workDir string) error {
+	_, err := runBisect(workDir, "reset")
+	return err
+}
+
+// RunBisect automates the bisect with `git bisect run`, using command's
+// exit status as the verdict for each step. Output of git and the command
+// is streamed to out. Cancelling ctx stops the run, killing the test
+// command on Unix, and leaves the session at the step it reached.
+func RunBisect(ctx context.Context, workDir, command string, out io.Writer) error {
+	if strings.TrimSpace(command) == "" {
+		return errors.New("no test command")
+	}
+	cmd := exec.CommandContext(ctx, "git", "bisect", "run", "sh", "-c", command)
+	cmd.Dir = workDir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	// Cancelling kills the test command with git, on Unix, so it stops
+	// changing the worktree; elsewhere it may outlive git, so don't wait
+	// on its pipes
+	killGroupOnCancel(cmd)
+	cmd.WaitDelay = 2 * time.Second
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &BisectError{Output: "bisect run failed: " + err.Error(), Err: err}
+	}
+	return nil
+}
+
+func runBisect(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"bisect"}, args...)...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &BisectError{Output: string(output), Err: err}
+	}
+	return string(output), nil
+}
@@ -0,0 +1,167 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBisectRepo creates a repo with commits c1..c8 where c5 introduces
+// "bug" into state.txt. It returns the dir and the hashes, oldest first.
+func newBisectRepo(t *testing.T) (string, []string) {
+	t.Helper()
+	dir := newTestRepo(t, map[string]string{"state.txt": "ok\n"})
+	var hashes []string
+	for i := 1; i <= 8; i++ {
+		state := "ok\n"
+		if i >= 5 {
+			state = "bug\n"
+		}
+		commitFiles(t, dir, "c"+itoa(i), map[string]string{"state.txt": state, "n.txt": itoa(i) + "\n"})
+		hashes = append(hashes, strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")))
+	}
+	return dir, hashes
+}
+
+// markUntilDone marks each checked-out commit by the content of state.txt.
+func markUntilDone(t *testing.T, dir string) *BisectState {
+	t.Helper()
+	for i := 0; i < 10; i++ {
+		state, err := GetBisectState(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Done() {
+			return state
+		}
+		mark := BisectGood
+		if readFile(t, dir, "state.txt") == "bug\n" {
+			mark = BisectBad
+		}
+		if _, err := MarkBisect(dir, mark); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Fatal("bisect did not converge")
+	return nil
+}
+
+func TestBisectManual(t *testing.T) {
+	dir, hashes := newBisectRepo(t)
+	if state, err := GetBisectState(dir); err != nil || state.Active {
+		t.Fatalf("state = %+v, %v", state, err)
+	}
+
+	if _, err := StartBisect(dir, hashes[7], hashes[0]); err != nil {
+		t.Fatal(err)
+	}
+	state, err := GetBisectState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Active || state.Bad != hashes[7] || len(state.Good) != 1 || state.Good[0] != hashes[0] {
+		t.Fatalf("state = %+v", state)
+	}
+	if state.Remaining != 7 || len(state.Candidates) != 7 || state.Candidates[hashes[0]] || !state.Candidates[hashes[7]] {
+		t.Errorf("remaining = %d, candidates = %d", state.Remaining, len(state.Candidates))
+	}
+	if !state.Candidates[state.Current] {
+		t.Errorf("current %s should be a candidate", state.Current)
+	}
+
+	state = markUntilDone(t, dir)
+	if state.Culprit != hashes[4] || state.Remaining != 0 {
+		t.Errorf("culprit = %s, want %s (remaining %d)", state.Culprit, hashes[4], state.Remaining)
+	}
+
+	if err := ResetBisect(dir); err != nil {
+		t.Fatal(err)
+	}
+	if IsBisectInProgress(dir) {
+		t.Error("reset should end the bisect")
+	}
+	if head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")); head != hashes[7] {
+		t.Errorf("HEAD = %s, want the original branch tip", head)
+	}
+}
+
+func TestBisectLinkedWorktree(t *testing.T) {
+	dir, hashes := newBisectRepo(t)
+	wt := filepath.Join(t.TempDir(), "wt")
+	runGit(t, dir, "worktree", "add", "-q", wt, "HEAD")
+
+	if _, err := StartBisect(wt, hashes[7], hashes[0]); err != nil {
+		t.Fatal(err)
+	}
+	if IsBisectInProgress(dir) {
+		t.Error("the main worktree should not see the linked worktree's bisect")
+	}
+	if state := markUntilDone(t, wt); state.Culprit != hashes[4] {
+		t.Errorf("culprit = %s, want %s", state.Culprit, hashes[4])
+	}
+}
+
+func TestRunBisect(t *testing.T) {
+	dir, hashes := newBisectRepo(t)
+	if _, err := StartBisect(dir, hashes[7], hashes[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := RunBisect(context.Background(), dir, "grep -q ok state.txt", &out); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "is the first bad commit") {
+		t.Errorf("output:\n%s", out.String())
+	}
+	state, err := GetBisectState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Culprit != hashes[4] {
+		t.Errorf("culprit = %s, want %s", state.Culprit, hashes[4])
+	}
+
+	_, err = MarkBisect(t.TempDir(), BisectGood)
+	var be *BisectError
+	if !errors.As(err, &be) {
+		t.Errorf("err = %v, want *BisectError", err)
+	}
+}
+
+func TestRunBisect_Cancel(t *testing.T) {
+	dir, hashes := newBisectRepo(t)
+	if _, err := StartBisect(dir, hashes[7], hashes[0]); err != nil {
+		t.Fatal(err)
+	}
+	marker := filepath.Join(t.TempDir(), "test-survived")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &lockedBuffer{}
+	go func() {
+		for !strings.Contains(out.String(), "started") {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+	start := time.Now()
+	err := RunBisect(ctx, dir, "echo started; sleep 1; touch "+marker, out)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v\n%s", err, out.String())
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Error("cancel should not wait for the test command")
+	}
+	if runtime.GOOS != "windows" {
+		// The test command is killed with git rather than left running
+		time.Sleep(1500 * time.Millisecond)
+		if _, err := os.Stat(marker); err == nil {
+			t.Error("the test command kept running after cancel")
+		}
+	}
+}
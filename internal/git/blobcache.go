@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheBlob writes the contents of path at rev into dir and returns the
+// file's path. An empty rev means the index. Files are named by blob hash,
+// keeping path's extension, so each version is written once and shared
+// between callers. dir is created private to the user and should not be
+// shared with others: a cached file is reused if it has the blob's size.
+func CacheBlob(workDir, dir, rev, path string) (string, error) {
+	spec := rev + ":" + path
+	if rev == "" {
+		// The shared reader refuses index paths, which it would resolve
+		// against the index as it was when its process started
+		cmd := exec.Command("git", "rev-parse", "--verify", "-q", ":"+path)
+		cmd.Dir = workDir
+		output, err := cmd.Output()
+		if err != nil {
+			return "", &ObjectError{Output: "Not in the index: " + path, Err: ErrObjectNotFound}
+		}
+		spec = strings.TrimSpace(string(output))
+	}
+
+	r := ObjectReaderFor(workDir)
+	info, err := r.Info(spec)
+	if err != nil {
+		return "", err
+	}
+	if info.Type != "blob" {
+		return "", &ObjectError{Output: fmt.Sprintf("%s:%s is a %s", rev, path, info.Type)}
+	}
+
+	cached := filepath.Join(dir, info.Hash+filepath.Ext(path))
+	if fi, err := os.Lstat(cached); err == nil && fi.Mode().IsRegular() && fi.Size() == info.Size {
+		// Mark it recently used for PruneBlobCache
+		now := time.Now()
+		_ = os.Chtimes(cached, now, now)
+		return cached, nil
+	}
+	obj, err := r.Read(info.Hash)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	// Write under a temporary name so a reader never sees a partial file
+	tmp, err := os.CreateTemp(dir, ".blob-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(obj.Data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), cached); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+	return cached, nil
+}
+
+// PruneBlobCache removes the least recently used files CacheBlob wrote to
+// dir until they take at most maxBytes.
+func PruneBlobCache(dir string, maxBytes int64) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var files []os.FileInfo
+	var total int64
+	for _, entry := range entries {
+		// Temporary files belong to writes in progress
+		if strings.HasPrefix(entry.Name(), ".") || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, f.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= f.Size()
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheBlob(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"img/logo.png": "v1"})
+	defer CloseObjectReader(dir)
+	writeFile(t, dir, "img/logo.png", "v2")
+	runGit(t, dir, "add", "img/logo.png")
+	cache := filepath.Join(t.TempDir(), "cache")
+
+	head, err := CacheBlob(dir, cache, "HEAD", "img/logo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := CacheBlob(dir, cache, "", "img/logo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{head: "v1", index: "v2"} {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != want || filepath.Ext(path) != ".png" {
+			t.Errorf("%s = %q, %v; want %q", path, data, err, want)
+		}
+	}
+	if want := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD:img/logo.png")); filepath.Base(head) != want+".png" {
+		t.Errorf("cached file %s should be named by blob hash %s", head, want)
+	}
+
+	again, err := CacheBlob(dir, cache, "HEAD", "img/logo.png")
+	if err != nil || again != head {
+		t.Errorf("second call = %s, %v; want %s", again, err, head)
+	}
+	if entries, _ := os.ReadDir(cache); len(entries) != 2 {
+		t.Errorf("cache should hold one file per version, got %d", len(entries))
+	}
+
+	if fi, err := os.Stat(cache); err != nil || fi.Mode().Perm() != 0700 {
+		t.Errorf("cache dir mode = %v, %v; want private", fi.Mode().Perm(), err)
+	}
+
+	// A file left under the blob's name with other contents is replaced
+	if err := os.WriteFile(head, []byte("planted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if again, err = CacheBlob(dir, cache, "HEAD", "img/logo.png"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(again); string(data) != "v1" {
+		t.Errorf("cached file = %q, want the blob's contents", data)
+	}
+
+	if _, err := CacheBlob(dir, cache, "HEAD", "missing.png"); err == nil {
+		t.Error("expected an error for a path not in HEAD")
+	}
+	if _, err := CacheBlob(dir, cache, "HEAD", "img"); err == nil {
+		t.Error("expected an error for a tree")
+	}
+}
+
+func TestPruneBlobCache(t *testing.T) {
+	cache := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"old.png", "mid.png", "new.png"} {
+		path := filepath.Join(cache, name)
+		writeFile(t, cache, name, strings.Repeat("x", 10))
+		when := now.Add(time.Duration(i-3) * time.Minute)
+		if err := os.Chtimes(path, when, when); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, cache, ".blob-123", strings.Repeat("x", 100))
+
+	if err := PruneBlobCache(cache, 20); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	entries, _ := os.ReadDir(cache)
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if got := strings.Join(names, " "); got != ".blob-123 mid.png new.png" {
+		t.Errorf("after pruning: %s, want the oldest file removed and writes in progress kept", got)
+	}
+	if err := PruneBlobCache(filepath.Join(cache, "missing"), 0); err != nil {
+		t.Errorf("missing cache: %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// catFileTimeout bounds a single request. A process that does not answer in
+// time is killed and restarted on the next request.
+const catFileTimeout = 10 * time.Second
+
+// ErrObjectNotFound is wrapped by ObjectError when a revision does not name
+// an object.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo is the type and size of a git object.
+type ObjectInfo struct {
+	Hash string
+	Type string // blob, tree, commit or tag
+	Size int64
+}
+
+// Object is a git object with its raw contents.
+type Object struct {
+	ObjectInfo
+	Data []byte
+}
+
+// Signature is the author or committer line of a commit.
+type Signature struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+// CommitObject is a parsed commit object.
+type CommitObject struct {
+	Hash      string
+	Tree      string
+	Parents   []string
+	Author    Signature
+	Committer Signature
+	Message   string
+}
+
+// Subject returns the first paragraph of the message on one line, like %s.
+func (c *CommitObject) Subject() string {
+	subject, _, _ := strings.Cut(strings.TrimLeft(c.Message, "\n"), "\n\n")
+	return strings.Join(strings.Fields(subject), " ")
+}
+
+// Body returns the message after the subject paragraph, like %b.
+func (c *CommitObject) Body() string {
+	_, body, _ := strings.Cut(strings.TrimLeft(c.Message, "\n"), "\n\n")
+	return strings.TrimSpace(body)
+}
+
+// ObjectError wraps a failure to read an object.
+type ObjectError struct {
+	Output string
+	Err    error
+}
+
+func (e *ObjectError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *ObjectError) Unwrap() error {
+	return e.Err
+}
+
+// ObjectReader reads objects through long-lived `git cat-file --batch` and
+// `--batch-check` processes instead of starting git for every read. It is
+// safe for concurrent use: callers share the processes and their requests
+// are answered in turn. Processes start on first use and are restarted if
+// they exit or stop responding.
+type ObjectReader struct {
+	batch *catFileProc // Contents
+	check *catFileProc // Type and size only
+}
+
+// NewObjectReader returns a reader for the repository at workDir.
+func NewObjectReader(workDir string) *ObjectReader {
+	return &ObjectReader{
+		batch: &catFileProc{workDir: workDir, mode: "--batch"},
+		check: &catFileProc{workDir: workDir, mode: "--batch-check"},
+	}
+}
+
+var (
+	objectReadersMu sync.Mutex
+	objectReaders   = make(map[string]*ObjectReader)
+)
+
+// ObjectReaderFor returns the shared reader for workDir. Each worktree gets
+// its own reader since revisions like HEAD resolve per worktree.
+func ObjectReaderFor(workDir string) *ObjectReader {
+	key := filepath.Clean(workDir)
+	objectReadersMu.Lock()
+	defer objectReadersMu.Unlock()
+	r, ok := objectReaders[key]
+	if !ok {
+		r = NewObjectReader(key)
+		objectReaders[key] = r
+	}
+	return r
+}
+
+// CloseObjectReader stops the shared reader for workDir, if any.
+func CloseObjectReader(workDir string) {
+	key := filepath.Clean(workDir)
+	objectReadersMu.Lock()
+	r := objectReaders[key]
+	delete(objectReaders, key)
+	objectReadersMu.Unlock()
+	if r != nil {
+		r.Close()
+	}
+}
+
+// CloseObjectReaders stops every shared reader, including those opened for
+// other worktrees and submodules.
+func CloseObjectReaders() {
+	objectReadersMu.Lock()
+	readers := objectReaders
+	objectReaders = make(map[string]*ObjectReader)
+	objectReadersMu.Unlock()
+	for _, r := range readers {
+		r.Close()
+	}
+}
+
+// Info returns the type and size of the object rev names.
+func (r *ObjectReader) Info(rev string) (*ObjectInfo, error) {
+	var info *ObjectInfo
+	err := r.check.request(rev, func(header *ObjectInfo, _ *bufio.Reader) error {
+		info = header
+		return nil
+	})
+	return info, err
+}
+
+// Read returns the object rev names with its contents.
+func (r *ObjectReader) Read(rev string) (*Object, error) {
+	var obj *Object
+	err := r.batch.request(rev, func(header *ObjectInfo, out *bufio.Reader) error {
+		// Contents are followed by a newline
+		data := make([]byte, header.Size+1)
+		if _, err := io.ReadFull(out, data); err != nil {
+			return err
+		}
+		obj = &Object{ObjectInfo: *header, Data: data[:header.Size]}
+		return nil
+	})
+	return obj, err
+}
+
+// ReadCommit reads and parses the commit rev names.
+func (r *ObjectReader) ReadCommit(rev string) (*CommitObject, error) {
+	obj, err := r.Read(rev + "^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitObject(obj.Hash, obj.Data), nil
+}
+
+// ReadFile returns the contents of path at rev.
+func (r *ObjectReader) ReadFile(rev, path string) ([]byte, error) {
+	obj, err := r.Read(rev + ":" + path)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Type != "blob" {
+		return nil, &ObjectError{Output: fmt.Sprintf("%s:%s is a %s", rev, path, obj.Type)}
+	}
+	return obj.Data, nil
+}
+
+// BlobSize returns the size in bytes of path at rev without reading it.
+func (r *ObjectReader) BlobSize(rev, path string) (int64, error) {
+	info, err := r.Info(rev + ":" + path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// Ping checks that the reader can answer requests, restarting its processes
+// if needed.
+func (r *ObjectReader) Ping() error {
+	// An answer, even "missing" in an empty repository, proves the process works
+	_, err := r.Info("HEAD")
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Close stops the reader's processes. It may be used again afterwards,
+// starting new ones.
+func (r *ObjectReader) Close() {
+	r.batch.close()
+	r.check.close()
+}
+
+// catFileProc is one cat-file process and the lock serializing its requests.
+type catFileProc struct {
+	workDir string
+	mode    string
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	exited chan struct{} // Closed when the process exits
+}
+
+// request sends rev and hands the answer's header and the output stream to
+// read, which must consume the rest of the answer. A request that fails on
+// I/O is retried once with a fresh process.
+func (c *catFileProc) request(rev string, read func(*ObjectInfo, *bufio.Reader) error) error {
+	// The protocol is line based, and index paths would be read from the
+	// index as it was when the process started
+	if rev == "" || strings.ContainsAny(rev, "\n\r") || strings.HasPrefix(rev, ":") {
+		return &ObjectError{Output: fmt.Sprintf("Invalid object name: %q", rev)}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = c.ensureRunning(); err != nil {
+			continue
+		}
+		var objErr *ObjectError
+		if err = c.roundTrip(rev, read); err == nil || errors.As(err, &objErr) {
+			return err
+		}
+		// The stream may be out of step with our requests; start over
+		c.stopLocked()
+	}
+	return &ObjectError{Output: "git cat-file failed: " + err.Error(), Err: err}
+}
+
+// roundTrip writes one request and reads its answer, killing the process if
+// it does not answer in time.
+func (c *catFileProc) roundTrip(rev string, read func(*ObjectInfo, *bufio.Reader) error) error {
+	proc := c.cmd.Process
+	timer := time.AfterFunc(catFileTimeout, func() { _ = proc.Kill() })
+	defer timer.Stop()
+
+	if _, err := io.WriteString(c.stdin, rev+"\n"); err != nil {
+		return err
+	}
+	line, err := c.stdout.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	header, err := parseCatFileHeader(rev, strings.TrimSuffix(line, "\n"))
+	if err != nil {
+		return err
+	}
+	return read(header, c.stdout)
+}
+
+// parseCatFileHeader parses "<hash> <type> <size>" or "<rev> missing".
+func parseCatFileHeader(rev, line string) (*ObjectInfo, error) {
+	if strings.HasSuffix(line, " missing") || strings.HasSuffix(line, " ambiguous") {
+		return nil, &ObjectError{Output: "Unknown object: " + rev, Err: ErrObjectNotFound}
+	}
+	f := strings.Fields(line)
+	if len(f) != 3 {
+		return nil, fmt.Errorf("unexpected cat-file output %q", line)
+	}
+	size, err := strconv.ParseInt(f[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected cat-file output %q", line)
+	}
+	return &ObjectInfo{Hash: f[0], Type: f[1], Size: size}, nil
+}
+
+// ensureRunning starts the process unless a live one exists.
+func (c *catFileProc) ensureRunning() error {
+	if c.cmd != nil {
+		select {
+		case <-c.exited:
+			c.stopLocked()
+		default:
+			return nil
+		}
+	}
+
+	cmd := exec.Command("git", "cat-file", c.mode)
+	cmd.Dir = c.workDir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	c.cmd, c.stdin, c.stdout, c.exited = cmd, stdin, bufio.NewReader(stdout), exited
+	return nil
+}
+
+// stopLocked stops the process. c.mu must be held.
+func (c *catFileProc) stopLocked() {
+	if c.cmd == nil {
+		return
+	}
+	_ = c.stdin.Close()
+	select {
+	case <-c.exited:
+	case <-time.After(time.Second):
+		_ = c.cmd.Process.Kill()
+		<-c.exited
+	}
+	c.cmd, c.stdin, c.stdout, c.exited = nil, nil, nil, nil
+}
+
+func (c *catFileProc) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopLocked()
+}
+
+// parseCommitObject parses raw commit data.
+func parseCommitObject(hash string, data []byte) *CommitObject {
+	c := &CommitObject{Hash: hash}
+	headers, message, _ := strings.Cut(string(data), "\n\n")
+	c.Message = message
+	for _, line := range strings.Split(headers, "\n") {
+		// Continuation lines of multi-line headers such as gpgsig start with a space
+		key, value, ok := strings.Cut(line, " ")
+		if !ok || key == "" {
+			continue
+		}
+		switch key {
+		case "tree":
+			c.Tree = value
+		case "parent":
+			c.Parents = append(c.Parents, value)
+		case "author":
+			c.Author = parseSignature(value)
+		case "committer":
+			c.Committer = parseSignature(value)
+		}
+	}
+	return c
+}
+
+// parseSignature parses "Name <email> 1700000000 +0100".
+func parseSignature(s string) Signature {
+	var sig Signature
+	open := strings.LastIndex(s, " <")
+	closing := strings.LastIndex(s, "> ")
+	if open < 0 || closing < open {
+		sig.Name = s
+		return sig
+	}
+	sig.Name = s[:open]
+	sig.Email = s[open+2 : closing]
+	f := strings.Fields(s[closing+2:])
+	if len(f) == 0 {
+		return sig
+	}
+	sig.When = parseUnix(f[0])
+	if len(f) > 1 {
+		if t, err := time.Parse("-0700", f[1]); err == nil {
+			sig.When = sig.When.In(t.Location())
+		}
+	}
+	return sig
+}
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// newBenchRepo creates a repo with a short history touching a few files.
+func newBenchRepo(b *testing.B) string {
+	b.Helper()
+	dir := newTestRepo(b, map[string]string{"README.md": "bench\n"})
+	for i := 0; i < 20; i++ {
+		commitFiles(b, dir, fmt.Sprintf("change %d", i), map[string]string{
+			fmt.Sprintf("pkg/file%d.go", i%5): strings.Repeat(fmt.Sprintf("line %d\n", i), 50),
+		})
+	}
+	return dir
+}
+
+// BenchmarkReadCommit_ObjectReader measures commit metadata reads through
+// the shared cat-file process.
+func BenchmarkReadCommit_ObjectReader(b *testing.B) {
+	dir := newBenchRepo(b)
+	r := NewObjectReader(dir)
+	defer r.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.ReadCommit("HEAD~3"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReadCommit_Subprocess measures the same read with one git
+// process per call, as before the object reader.
+func BenchmarkReadCommit_Subprocess(b *testing.B) {
+	dir := newBenchRepo(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cmd := exec.Command("git", "show", "-s", "--format=%H%n%h%n%an%n%ae%n%at%n%P%n%s%n%b", "HEAD~3")
+		cmd.Dir = dir
+		if _, err := cmd.Output(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBlobSize_ObjectReader measures size lookups through the shared
+// cat-file --batch-check process.
+func BenchmarkBlobSize_ObjectReader(b *testing.B) {
+	dir := newBenchRepo(b)
+	r := NewObjectReader(dir)
+	defer r.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.BlobSize("HEAD", "pkg/file1.go"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetPushStatus measures the push status lookup run on every
+// history refresh.
+func BenchmarkGetPushStatus(b *testing.B) {
+	remote := b.TempDir()
+	runGit(b, remote, "init", "-q", "--bare")
+	dir := newBenchRepo(b)
+	runGit(b, dir, "remote", "add", "origin", remote)
+	runGit(b, dir, "push", "-q", "-u", "origin", "HEAD")
+	commitFiles(b, dir, "unpushed", map[string]string{"new.txt": "new\n"})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ps := GetPushStatus(dir); ps.Ahead != 1 {
+			b.Fatalf("ahead = %d", ps.Ahead)
+		}
+	}
+}
+
+// BenchmarkGetLatestTagDistance measures the tag decoration lookup run on
+// every history refresh.
+func BenchmarkGetLatestTagDistance(b *testing.B) {
+	dir := newBenchRepo(b)
+	runGit(b, dir, "tag", "v1.0.0", "HEAD~5")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if tag, since := GetLatestTagDistance(dir); tag != "v1.0.0" || since != 5 {
+			b.Fatalf("got %q, %d", tag, since)
+		}
+	}
+}
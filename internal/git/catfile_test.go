@@ -0,0 +1,152 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestObjectReader_ReadsObjects(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "hello\n", "dir/b.txt": "nested\n"})
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "Second subject\nwraps here\n\nBody line one.\n\nBody line two.")
+	head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	parent := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD~1"))
+
+	r := NewObjectReader(dir)
+	defer r.Close()
+
+	c, err := r.ReadCommit("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Hash != head || len(c.Parents) != 1 || c.Parents[0] != parent {
+		t.Errorf("commit = %s parents %v, want %s parent %s", c.Hash, c.Parents, head, parent)
+	}
+	if c.Author.Name != "test" || c.Author.Email != "test@test" || c.Author.When.IsZero() {
+		t.Errorf("author = %+v", c.Author)
+	}
+	if c.Subject() != "Second subject wraps here" {
+		t.Errorf("subject = %q", c.Subject())
+	}
+	if c.Body() != "Body line one.\n\nBody line two." {
+		t.Errorf("body = %q", c.Body())
+	}
+
+	data, err := r.ReadFile("HEAD", "dir/b.txt")
+	if err != nil || string(data) != "nested\n" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+	size, err := r.BlobSize("HEAD", "a.txt")
+	if err != nil || size != 6 {
+		t.Errorf("BlobSize = %d, %v", size, err)
+	}
+	if _, err := r.ReadFile("HEAD", "dir"); err == nil {
+		t.Error("reading a tree as a file should fail")
+	}
+
+	_, err = r.Read("HEAD:nope.txt")
+	if !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("missing object error = %v", err)
+	}
+	// The stream stays usable after a miss
+	if _, err := r.ReadFile("HEAD", "a.txt"); err != nil {
+		t.Errorf("read after miss: %v", err)
+	}
+	if _, err := r.Read(":a.txt"); err == nil {
+		t.Error("index paths should be rejected")
+	}
+}
+
+func TestObjectReader_ConcurrentRequests(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "alpha\n", "b.txt": "beta\n"})
+	r := NewObjectReader(dir)
+	defer r.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if data, err := r.ReadFile("HEAD", "a.txt"); err != nil || string(data) != "alpha\n" {
+				errs <- errors.New("a.txt = " + string(data))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if size, err := r.BlobSize("HEAD", "b.txt"); err != nil || size != 5 {
+				errs <- errors.New("bad b.txt size")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestObjectReader_RestartsDeadProcess(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "alpha\n"})
+	r := NewObjectReader(dir)
+	defer r.Close()
+
+	if err := r.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	first := r.batch
+	if _, err := r.Read("HEAD"); err != nil {
+		t.Fatal(err)
+	}
+	_ = first.cmd.Process.Kill()
+	<-first.exited
+
+	if data, err := r.ReadFile("HEAD", "a.txt"); err != nil || string(data) != "alpha\n" {
+		t.Errorf("read after the process died = %q, %v", data, err)
+	}
+}
+
+func TestObjectReader_PingEmptyRepo(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	r := NewObjectReader(dir)
+	defer r.Close()
+	if err := r.Ping(); err != nil {
+		t.Errorf("Ping on a repo without commits: %v", err)
+	}
+}
+
+func TestObjectReaderFor_SharedPerWorkDir(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "alpha\n"})
+	defer CloseObjectReader(dir)
+	if ObjectReaderFor(dir) != ObjectReaderFor(dir+"/") {
+		t.Error("readers for the same directory should be shared")
+	}
+}
+
+func TestCloseObjectReaders(t *testing.T) {
+	dirs := []string{
+		newTestRepo(t, map[string]string{"a.txt": "alpha\n"}),
+		newTestRepo(t, map[string]string{"b.txt": "beta\n"}),
+	}
+	var readers []*ObjectReader
+	for _, dir := range dirs {
+		r := ObjectReaderFor(dir)
+		if err := r.Ping(); err != nil {
+			t.Fatal(err)
+		}
+		readers = append(readers, r)
+	}
+
+	CloseObjectReaders()
+	for i, r := range readers {
+		if r.check.cmd != nil {
+			t.Errorf("reader for %s still has a running process", dirs[i])
+		}
+		if ObjectReaderFor(dirs[i]) == r {
+			t.Errorf("reader for %s should be dropped from the shared set", dirs[i])
+		}
+	}
+	CloseObjectReaders()
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CherryPickError wraps a git cherry-pick error with its output.
+type CherryPickError struct {
+	Output string
+	Err    error
+}
+
+func (e *CherryPickError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *CherryPickError) Unwrap() error {
+	return e.Err
+}
+
+// RevertError wraps a git revert error with its output.
+type RevertError struct {
+	Output string
+	Err    error
+}
+
+func (e *RevertError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// CherryPick applies the given commits, in order, onto the branch checked
+// out in workDir. Pass commits oldest first to preserve their history order.
+func CherryPick(workDir string, hashes ...string) (string, error) {
+	output, err := runSequencer(workDir, append([]string{"cherry-pick"}, hashes...))
+	if err != nil {
+		return "", &CherryPickError{Output: output, Err: err}
+	}
+	return output, nil
+}
+
+// Revert creates commits undoing the given commits, in order. Pass commits
+// newest first so later changes are undone before the ones they build on.
+func Revert(workDir string, hashes ...string) (string, error) {
+	output, err := runSequencer(workDir, append([]string{"revert", "--no-edit"}, hashes...))
+	if err != nil {
+		return "", &RevertError{Output: output, Err: err}
+	}
+	return output, nil
+}
+
+// ContinueCherryPick runs git cherry-pick --continue, keeping commit messages.
+func ContinueCherryPick(workDir string) (string, error) {
+	output, err := runSequencer(workDir, []string{"cherry-pick", "--continue"})
+	if err != nil {
+		return "", &CherryPickError{Output: output, Err: err}
+	}
+	return output, nil
+}
+
+// ContinueRevert runs git revert --continue, keeping commit messages.
+func ContinueRevert(workDir string) (string, error) {
+	output, err := runSequencer(workDir, []string{"revert", "--continue"})
+	if err != nil {
+		return "", &RevertError{Output: output, Err: err}
+	}
+	return output, nil
+}
+
+// AbortCherryPick runs git cherry-pick --abort.
+func AbortCherryPick(workDir string) error {
+	cmd := exec.Command("git", "cherry-pick", "--abort")
+	cmd.Dir = workDir
+	_, err := cmd.CombinedOutput()
+	return err
+}
+
+// AbortRevert runs git revert --abort.
+func AbortRevert(workDir string) error {
+	cmd := exec.Command("git", "revert", "--abort")
+	cmd.Dir = workDir
+	_, err := cmd.CombinedOutput()
+	return err
+}
+
+// IsCherryPickInProgress checks if a cherry-pick is stopped on conflicts.
+func IsCherryPickInProgress(workDir string) bool {
+	return gitPathExists(workDir, "CHERRY_PICK_HEAD")
+}
+
+// IsRevertInProgress checks if a revert is stopped on conflicts.
+func IsRevertInProgress(workDir string) bool {
+	return gitPathExists(workDir, "REVERT_HEAD")
+}
+
+// runSequencer runs a cherry-pick or revert command without opening an editor.
+func runSequencer(workDir string, args []string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+// gitPathExists reports whether a file under the git dir exists.
+func gitPathExists(workDir, name string) bool {
+	cmd := exec.Command("git", "rev-parse", "--git-path", name)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(gitPathAbs(workDir, string(output)))
+	return err == nil
+}
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCherryPick_OntoWorktreeBranch(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+	wt := filepath.Join(t.TempDir(), "feature")
+	runGit(t, dir, "worktree", "add", "-q", "-b", "feature", wt)
+
+	// Agent commits land on main by mistake
+	commitFiles(t, dir, "one", map[string]string{"one.txt": "1\n"})
+	commitFiles(t, dir, "two", map[string]string{"two.txt": "2\n"})
+	one := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD~1"))
+	two := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+	if _, err := CherryPick(wt, one, two); err != nil {
+		t.Fatalf("CherryPick: %v", err)
+	}
+	if got := logSubjects(t, wt); strings.Join(got, ",") != "two,one,init" {
+		t.Errorf("feature log = %v", got)
+	}
+}
+
+func TestCherryPick_ConflictAbort(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	commitFiles(t, dir, "side", map[string]string{"f.txt": "side\n"})
+	side := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFiles(t, dir, "main", map[string]string{"f.txt": "main\n"})
+
+	_, err := CherryPick(dir, side)
+	var cpErr *CherryPickError
+	if !errors.As(err, &cpErr) {
+		t.Fatalf("expected *CherryPickError, got %T %v", err, err)
+	}
+	if !IsConflictError(err) || !IsCherryPickInProgress(dir) {
+		t.Fatal("expected cherry-pick stopped on conflict")
+	}
+	if files := GetConflictedFiles(dir); len(files) != 1 || files[0] != "f.txt" {
+		t.Errorf("conflicted files = %v", files)
+	}
+
+	if err := AbortCherryPick(dir); err != nil {
+		t.Fatal(err)
+	}
+	if IsCherryPickInProgress(dir) {
+		t.Error("cherry-pick should be aborted")
+	}
+}
+
+func TestCherryPick_ConflictContinue(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	commitFiles(t, dir, "side", map[string]string{"f.txt": "side\n"})
+	side := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFiles(t, dir, "main", map[string]string{"f.txt": "main\n"})
+
+	if _, err := CherryPick(dir, side); !IsConflictError(err) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	writeFile(t, dir, "f.txt", "resolved\n")
+	runGit(t, dir, "add", "f.txt")
+	if _, err := ContinueCherryPick(dir); err != nil {
+		t.Fatalf("ContinueCherryPick: %v", err)
+	}
+	if got := logSubjects(t, dir); got[0] != "side" {
+		t.Errorf("log = %v", got)
+	}
+}
+
+func TestRevert(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+	commitFiles(t, dir, "one", map[string]string{"one.txt": "1\n"})
+	commitFiles(t, dir, "two", map[string]string{"two.txt": "2\n"})
+	one := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD~1"))
+	two := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+	if _, err := Revert(dir, two, one); err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	got := logSubjects(t, dir)
+	if len(got) != 5 || !strings.HasPrefix(got[0], `Revert "one"`) || !strings.HasPrefix(got[1], `Revert "two"`) {
+		t.Errorf("log = %v", got)
+	}
+	if files := strings.TrimSpace(runGit(t, dir, "ls-files")); files != "base.txt" {
+		t.Errorf("files after revert = %q", files)
+	}
+}
+
+func TestRevert_ConflictAbort(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	commitFiles(t, dir, "one", map[string]string{"f.txt": "b\n"})
+	one := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	commitFiles(t, dir, "two", map[string]string{"f.txt": "c\n"})
+
+	_, err := Revert(dir, one)
+	var rErr *RevertError
+	if !errors.As(err, &rErr) || !IsConflictError(err) || !IsRevertInProgress(dir) {
+		t.Fatalf("expected revert conflict, got %v", err)
+	}
+	if err := AbortRevert(dir); err != nil {
+		t.Fatal(err)
+	}
+	if IsRevertInProgress(dir) || readFile(t, dir, "f.txt") != "c\n" {
+		t.Error("abort should restore the branch")
+	}
+}
+
+func TestGetResetPreview(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+	base := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	commitFiles(t, dir, "one", map[string]string{"one.txt": "1\n"})
+	commitFiles(t, dir, "two", map[string]string{"two.txt": "2\n"})
+	writeFile(t, dir, "base.txt", "dirty\n")
+	writeFile(t, dir, "new.txt", "untracked\n")
+
+	preview, err := GetResetPreview(dir, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Commits) != 2 || !strings.HasSuffix(preview.Commits[0], " two") {
+		t.Errorf("commits = %v", preview.Commits)
+	}
+	if len(preview.ChangedFiles) != 1 || preview.ChangedFiles[0] != "base.txt" {
+		t.Errorf("changed files = %v", preview.ChangedFiles)
+	}
+}
+
+func TestReset_Modes(t *testing.T) {
+	tests := []struct {
+		mode       ResetMode
+		wantStaged string
+		wantFile   bool
+	}{
+		{ResetSoft, "one.txt", true},
+		{ResetMixed, "", true},
+		{ResetHard, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+			base := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+			commitFiles(t, dir, "one", map[string]string{"one.txt": "1\n"})
+
+			if err := Reset(dir, tt.mode, base); err != nil {
+				t.Fatal(err)
+			}
+			if head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")); head != base {
+				t.Errorf("HEAD = %s, want %s", head, base)
+			}
+			if staged := strings.TrimSpace(runGit(t, dir, "diff", "--cached", "--name-only")); staged != tt.wantStaged {
+				t.Errorf("staged = %q, want %q", staged, tt.wantStaged)
+			}
+			if exists := fileExists(filepath.Join(dir, "one.txt")); exists != tt.wantFile {
+				t.Errorf("one.txt exists = %v, want %v", exists, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestReset_Errors(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+	if err := Reset(dir, "keep-all", "HEAD"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	var resetErr *ResetError
+	if err := Reset(dir, ResetHard, "nope"); !errors.As(err, &resetErr) {
+		t.Errorf("expected *ResetError, got %T", err)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
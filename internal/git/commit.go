@@ -8,41 +8,64 @@ import (
 	"strings"
 )
 
+// commitHashRegex matches the summary line of git commit output, including
+// "[main (root-commit) abc1234]" and "[detached HEAD abc1234]".
+var commitHashRegex = regexp.MustCompile(`^\[[^\]]* ([a-f0-9]{7,})\]`)
+
 // parseCommitHash extracts the commit hash from git commit output.
-// Format: "[branch hash] message"
+// Format: "[branch hash] message", after any output of the hooks.
 func parseCommitHash(output string) string {
-	lines := strings.Split(output, "\n")
-	if len(lines) > 0 {
-		re := regexp.MustCompile(`\[[\w/-]+ ([a-f0-9]+)\]`)
-		matches := re.FindStringSubmatch(lines[0])
-		if len(matches) > 1 {
+	for _, line := range strings.Split(output, "\n") {
+		if matches := commitHashRegex.FindStringSubmatch(line); matches != nil {
 			return matches[1]
 		}
 	}
 	return ""
 }
 
-// ExecuteCommit executes a git commit with the given message.
-// Returns the commit hash on success or an error with git output on failure.
-func ExecuteCommit(workDir, message string) (string, error) {
-	cmd := exec.Command("git", "commit", "-m", message)
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", &CommitError{Output: string(output), Err: err}
-	}
-	return parseCommitHash(string(output)), nil
+// ExecuteCommit executes a git commit with the given message, signing it
+// with -S when sign is set; configured signing applies either way.
+// Returns the commit hash on success or an error with git output on failure,
+// a *SigningError if the commit could not be signed.
+func ExecuteCommit(workDir, message string, sign bool) (string, error) {
+	return StreamCommit(nil, workDir, message, CommitOptions{Sign: sign})
 }
 
 // ExecuteAmend executes a git commit --amend with the given message.
-func ExecuteAmend(workDir, message string) (string, error) {
-	cmd := exec.Command("git", "commit", "--amend", "-m", message)
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
+func ExecuteAmend(workDir, message string, sign bool) (string, error) {
+	return StreamCommit(nil, workDir, message, CommitOptions{Amend: true, Sign: sign})
+}
+
+// CommitOptions controls how StreamCommit commits.
+type CommitOptions struct {
+	Amend    bool // Amend HEAD instead of adding a commit
+	Sign     bool // Sign with -S
+	NoVerify bool // Skip the pre-commit and commit-msg hooks
+}
+
+// StreamCommit commits like ExecuteCommit, streaming the output of git and
+// its hooks to s when s is not nil. A cancelled commit returns the
+// context's error.
+func StreamCommit(s *Stream, workDir, message string, opts CommitOptions) (string, error) {
+	args := []string{"commit"}
+	if opts.Sign {
+		args = append(args, "-S")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	if opts.Amend {
+		args = append(args, "--amend")
+	}
+	args = append(args, "-m", message)
+	output, err := runStreamed(s, workDir, args...)
 	if err != nil {
-		return "", &CommitError{Output: string(output), Err: err}
+		if s != nil && s.Ctx != nil && err == s.Ctx.Err() {
+			return "", err
+		}
+		return "", commitError(workDir, output, err)
 	}
-	return parseCommitHash(string(output)), nil
+	return parseCommitHash(output), nil
 }
 
 // GetLastCommitMessage returns the message of the most recent commit.
@@ -56,6 +79,17 @@ func GetLastCommitMessage(workDir string) string {
 	return strings.TrimRight(string(output), "\n")
 }
 
+// GetCommitMessage returns the full message of the given commit.
+func GetCommitMessage(workDir, hash string) string {
+	cmd := exec.Command("git", "log", "-1", "--format=%B", hash)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(output), "\n")
+}
+
 // CommitError wraps a git commit error with its output.
 type CommitError struct {
 	Output string
@@ -66,6 +100,10 @@ func (e *CommitError) Error() string {
 	return strings.TrimSpace(e.Output)
 }
 
+func (e *CommitError) Unwrap() error {
+	return e.Err
+}
+
 // DiscardModified discards unstaged changes to a modified file.
 func DiscardModified(workDir, path string) error {
 	cmd := exec.Command("git", "restore", path)
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GetCommitTemplate returns the contents of the commit.template file, or ""
+// when none is configured. A relative path is taken from the repository
+// root and ~ from the home directory, as git does.
+func GetCommitTemplate(workDir string) (string, error) {
+	cmd := exec.Command("git", "config", "--path", "commit.template")
+	cmd.Dir = workDir
+	out, err := cmd.Output()
+	if err != nil {
+		// Not configured
+		return "", nil
+	}
+	path := strings.TrimSpace(string(out))
+	if path == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// StripCommitComments removes the lines git treats as comments in a commit
+// message, using core.commentChar, and trims surrounding blank lines.
+func StripCommitComments(workDir, message string) string {
+	comment := "#"
+	cmd := exec.Command("git", "config", "core.commentChar")
+	cmd.Dir = workDir
+	if out, err := cmd.Output(); err == nil {
+		if c := strings.TrimSpace(string(out)); c != "" && c != "auto" {
+			comment = c
+		}
+	}
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, comment) {
+			lines = append(lines, strings.TrimRight(line, " \t"))
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
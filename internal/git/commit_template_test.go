@@ -0,0 +1,47 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommitTemplateAndPrepareHook(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if got, err := GetCommitTemplate(dir); err != nil || got != "" {
+		t.Fatalf("unconfigured template = %q, %v", got, err)
+	}
+
+	writeFile(t, dir, ".gitmessage", "feat: \n\n# Explain why\n")
+	runGit(t, dir, "config", "commit.template", ".gitmessage")
+	template, err := GetCommitTemplate(dir)
+	if err != nil || template != "feat: \n\n# Explain why\n" {
+		t.Fatalf("template = %q, %v", template, err)
+	}
+
+	// The prepare-commit-msg hook is left to git, so one that appends runs
+	// once on the final message
+	hook := "#!/bin/sh\nprintf 'Source: %s\\n' \"$2\" >> \"$1\"\n"
+	hooks := filepath.Join(dir, ".git", "hooks")
+	writeFile(t, hooks, "prepare-commit-msg", hook)
+	if err := os.Chmod(filepath.Join(hooks, "prepare-commit-msg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	message := StripCommitComments(dir, template)
+	if message != "feat:" {
+		t.Fatalf("pre-filled message = %q", message)
+	}
+	writeFile(t, dir, "a.txt", "a2\n")
+	runGit(t, dir, "add", "a.txt")
+	if _, err := ExecuteCommit(dir, message+" change a", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetLastCommitMessage(dir); got != "feat: change a\nSource: message" {
+		t.Errorf("committed message = %q, want the hook applied once", got)
+	}
+
+	runGit(t, dir, "config", "core.commentChar", ";")
+	if got := StripCommitComments(dir, "fix: x\n; note\n# kept"); got != "fix: x\n# kept" {
+		t.Errorf("custom comment char = %q", got)
+	}
+}
@@ -0,0 +1,275 @@
+package git
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CompareRefKind describes where a comparable ref comes from.
+type CompareRefKind string
+
+const (
+	CompareRefBranch   CompareRefKind = "branch"   // Local branch
+	CompareRefWorktree CompareRefKind = "worktree" // Local branch checked out in a worktree
+	CompareRefRemote   CompareRefKind = "remote"   // Remote-tracking branch
+	CompareRefTag      CompareRefKind = "tag"
+)
+
+// CompareRef is a named ref offered when picking the sides of a comparison.
+type CompareRef struct {
+	Name string
+	Kind CompareRefKind
+}
+
+// CompareCommit is a commit that is only on one side of a comparison.
+type CompareCommit struct {
+	Hash    string
+	Subject string
+	Author  string
+	Date    time.Time
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c *CompareCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// CompareFile is the change to one file between the merge base and head.
+type CompareFile struct {
+	Path      string
+	OldPath   string // Set for renames and copies
+	Status    string // A, M, D, R, C or T
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+// Comparison is the difference between two refs.
+type Comparison struct {
+	Base      string
+	Head      string
+	MergeBase string
+	HeadOnly  []*CompareCommit // Commits in base..head, newest first
+	BaseOnly  []*CompareCommit // Commits in head..base, newest first
+	Files     []CompareFile
+	Diff      string // Unified diff of base...head
+}
+
+// Additions returns the lines added across all files.
+func (c *Comparison) Additions() int {
+	n := 0
+	for _, f := range c.Files {
+		n += f.Additions
+	}
+	return n
+}
+
+// Deletions returns the lines deleted across all files.
+func (c *Comparison) Deletions() int {
+	n := 0
+	for _, f := range c.Files {
+		n += f.Deletions
+	}
+	return n
+}
+
+// CompareError wraps a git error raised while comparing refs.
+type CompareError struct {
+	Output string
+	Err    error
+}
+
+func (e *CompareError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *CompareError) Unwrap() error {
+	return e.Err
+}
+
+// ListCompareRefs returns local branches, remote-tracking branches and tags.
+// Branches checked out in a linked worktree are marked as such.
+func ListCompareRefs(workDir string) []CompareRef {
+	inWorktree := make(map[string]bool)
+	for _, wt := range GetWorktrees(workDir) {
+		if !wt.IsMain && wt.Branch != "" {
+			inWorktree[wt.Branch] = true
+		}
+	}
+
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes", "refs/tags")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var refs []CompareRef
+	for _, ref := range splitNonEmptyLines(string(output)) {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			name := strings.TrimPrefix(ref, "refs/heads/")
+			kind := CompareRefBranch
+			if inWorktree[name] {
+				kind = CompareRefWorktree
+			}
+			refs = append(refs, CompareRef{Name: name, Kind: kind})
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			name := strings.TrimPrefix(ref, "refs/remotes/")
+			if strings.HasSuffix(name, "/HEAD") {
+				continue
+			}
+			refs = append(refs, CompareRef{Name: name, Kind: CompareRefRemote})
+		case strings.HasPrefix(ref, "refs/tags/"):
+			refs = append(refs, CompareRef{Name: strings.TrimPrefix(ref, "refs/tags/"), Kind: CompareRefTag})
+		}
+	}
+	return refs
+}
+
+// GetDefaultCompareBase returns the branch most comparisons start from: the
+// remote's default branch, else main or master, else HEAD.
+func GetDefaultCompareBase(workDir string) string {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = workDir
+	if output, err := cmd.Output(); err == nil {
+		remote := strings.TrimSpace(string(output))
+		if local := strings.TrimPrefix(remote, "origin/"); refExists(workDir, "refs/heads/"+local) {
+			return local
+		}
+		return remote
+	}
+	for _, branch := range []string{"main", "master"} {
+		if refExists(workDir, "refs/heads/"+branch) {
+			return branch
+		}
+	}
+	return "HEAD"
+}
+
+// refExists reports whether ref resolves to a commit.
+func refExists(workDir, ref string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "-q", ref+"^{commit}")
+	cmd.Dir = workDir
+	return cmd.Run() == nil
+}
+
+// CompareRefs compares head against base: the commits unique to each side,
+// and the files and diff head changed since the merge base.
+func CompareRefs(workDir, base, head string) (*Comparison, error) {
+	for _, ref := range []string{base, head} {
+		if strings.TrimSpace(ref) == "" || strings.HasPrefix(ref, "-") || !refExists(workDir, ref) {
+			return nil, &CompareError{Output: "Unknown ref: " + ref}
+		}
+	}
+	c := &Comparison{Base: base, Head: head}
+
+	mergeBase, err := compareGitOutput(workDir, "merge-base", base, head)
+	if err != nil {
+		return nil, &CompareError{Output: "No common history between " + base + " and " + head, Err: err}
+	}
+	c.MergeBase = strings.TrimSpace(mergeBase)
+
+	output, err := compareGitOutput(workDir, "log", "--left-right", "--format=%m%x00%H%x00%s%x00%an%x00%at", base+"..."+head, "--")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range splitNonEmptyLines(output) {
+		f := strings.SplitN(line, "\x00", 5)
+		if len(f) < 5 {
+			continue
+		}
+		commit := &CompareCommit{Hash: f[1], Subject: f[2], Author: f[3], Date: parseUnix(f[4])}
+		if f[0] == "<" {
+			c.BaseOnly = append(c.BaseOnly, commit)
+		} else {
+			c.HeadOnly = append(c.HeadOnly, commit)
+		}
+	}
+
+	rng := base + "..." + head
+	nameStatus, err := compareGitOutput(workDir, "diff", "-M", "-z", "--name-status", rng, "--")
+	if err != nil {
+		return nil, err
+	}
+	numstat, err := compareGitOutput(workDir, "diff", "-M", "-z", "--numstat", rng, "--")
+	if err != nil {
+		return nil, err
+	}
+	c.Files = parseCompareFiles(nameStatus, numstat)
+
+	if c.Diff, err = compareGitOutput(workDir, "diff", "-M", rng, "--"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseCompareFiles joins -z --name-status and -z --numstat output by path.
+func parseCompareFiles(nameStatus, numstat string) []CompareFile {
+	var files []CompareFile
+	index := make(map[string]int)
+	fields := strings.Split(nameStatus, "\x00")
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" || i+1 >= len(fields) {
+			continue
+		}
+		file := CompareFile{Status: status[:1]}
+		if file.Status == "R" || file.Status == "C" {
+			if i+2 >= len(fields) {
+				break
+			}
+			file.OldPath, file.Path = fields[i+1], fields[i+2]
+			i += 2
+		} else {
+			file.Path = fields[i+1]
+			i++
+		}
+		index[file.Path] = len(files)
+		files = append(files, file)
+	}
+
+	// numstat records are "add\tdel\tpath" or, for renames, "add\tdel\t" followed
+	// by the old and new paths as separate fields
+	fields = strings.Split(numstat, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		path := parts[2]
+		if path == "" && i+2 < len(fields) {
+			path = fields[i+2]
+			i += 2
+		}
+		idx, ok := index[path]
+		if !ok {
+			continue
+		}
+		if parts[0] == "-" {
+			files[idx].Binary = true
+			continue
+		}
+		files[idx].Additions, _ = strconv.Atoi(parts[0])
+		files[idx].Deletions, _ = strconv.Atoi(parts[1])
+	}
+	return files
+}
+
+// compareGitOutput runs git and returns its stdout, wrapping failures in
+// CompareError.
+func compareGitOutput(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &CompareError{Output: stderr.String(), Err: err}
+	}
+	return string(output), nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+// newCompareRepo creates a repo where main and feature diverge after base.
+func newCompareRepo(t *testing.T) string {
+	t.Helper()
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "old.txt": "same\ncontent\nhere\n"})
+	runGit(t, dir, "branch", "-M", "main")
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	commitFiles(t, dir, "add b", map[string]string{"b.txt": "b\nb\n"})
+	runGit(t, dir, "mv", "old.txt", "new.txt")
+	commitFiles(t, dir, "rename old", map[string]string{"a.txt": "a2\n"})
+	runGit(t, dir, "checkout", "-q", "main")
+	commitFiles(t, dir, "main only", map[string]string{"c.txt": "c\n"})
+	runGit(t, dir, "tag", "v1")
+	return dir
+}
+
+func TestCompareRefs(t *testing.T) {
+	dir := newCompareRepo(t)
+	c, err := CompareRefs(dir, "main", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.HeadOnly) != 2 || c.HeadOnly[0].Subject != "rename old" || c.HeadOnly[1].Subject != "add b" {
+		t.Errorf("head-only commits = %+v", c.HeadOnly)
+	}
+	if len(c.BaseOnly) != 1 || c.BaseOnly[0].Subject != "main only" {
+		t.Errorf("base-only commits = %+v", c.BaseOnly)
+	}
+	if c.MergeBase == "" {
+		t.Error("merge base should be set")
+	}
+
+	// The three-dot diff ignores c.txt, which only main changed
+	want := map[string]CompareFile{
+		"a.txt":   {Path: "a.txt", Status: "M", Additions: 1, Deletions: 1},
+		"b.txt":   {Path: "b.txt", Status: "A", Additions: 2},
+		"new.txt": {Path: "new.txt", OldPath: "old.txt", Status: "R"},
+	}
+	if len(c.Files) != len(want) {
+		t.Fatalf("files = %+v", c.Files)
+	}
+	for _, f := range c.Files {
+		if f != want[f.Path] {
+			t.Errorf("file %s = %+v, want %+v", f.Path, f, want[f.Path])
+		}
+	}
+	if c.Additions() != 3 || c.Deletions() != 1 {
+		t.Errorf("totals = +%d -%d", c.Additions(), c.Deletions())
+	}
+	if mfd := ParseMultiFileDiff(c.Diff); len(mfd.Files) != 3 {
+		t.Errorf("diff parsed into %d files", len(mfd.Files))
+	}
+}
+
+func TestCompareRefs_UnknownRef(t *testing.T) {
+	dir := newCompareRepo(t)
+	var compareErr *CompareError
+	if _, err := CompareRefs(dir, "main", "nope"); !errors.As(err, &compareErr) || compareErr.Error() != "Unknown ref: nope" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestListCompareRefs(t *testing.T) {
+	dir := newCompareRepo(t)
+	wt := t.TempDir()
+	runGit(t, dir, "worktree", "add", "-q", wt, "feature")
+
+	kinds := make(map[string]CompareRefKind)
+	for _, ref := range ListCompareRefs(dir) {
+		kinds[ref.Name] = ref.Kind
+	}
+	if kinds["main"] != CompareRefBranch || kinds["feature"] != CompareRefWorktree || kinds["v1"] != CompareRefTag {
+		t.Errorf("refs = %v", kinds)
+	}
+	if got := GetDefaultCompareBase(dir); got != "main" {
+		t.Errorf("default base = %q", got)
+	}
+}
@@ -0,0 +1,515 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConflictOp identifies the operation that left conflicts in the working tree.
+type ConflictOp string
+
+const (
+	ConflictOpNone       ConflictOp = ""
+	ConflictOpMerge      ConflictOp = "merge"
+	ConflictOpRebase     ConflictOp = "rebase"
+	ConflictOpCherryPick ConflictOp = "cherry-pick"
+	ConflictOpRevert     ConflictOp = "revert"
+)
+
+// ConflictChoice is how a conflicted hunk is resolved.
+type ConflictChoice string
+
+const (
+	ChoiceNone       ConflictChoice = ""            // Unresolved; keeps conflict markers
+	ChoiceOurs       ConflictChoice = "ours"        // Keep our side
+	ChoiceTheirs     ConflictChoice = "theirs"      // Keep their side
+	ChoiceBoth       ConflictChoice = "both"        // Ours followed by theirs
+	ChoiceBothTheirs ConflictChoice = "both-theirs" // Theirs followed by ours
+)
+
+// Conflict marker prefixes written by git merge-file --diff3.
+const (
+	markerOurs   = "<<<<<<<"
+	markerBase   = "|||||||"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>>"
+)
+
+// ConflictError wraps a git error raised while resolving conflicts.
+type ConflictError struct {
+	Output string
+	Err    error
+}
+
+func (e *ConflictError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictHunk is one conflicted region with the three versions of its lines.
+// Lines keep their line terminators.
+type ConflictHunk struct {
+	Ours   []string
+	Base   []string
+	Theirs []string
+	Choice ConflictChoice
+}
+
+// Lines returns the hunk content for its choice, or nil if unresolved.
+func (h *ConflictHunk) Lines() []string {
+	switch h.Choice {
+	case ChoiceOurs:
+		return h.Ours
+	case ChoiceTheirs:
+		return h.Theirs
+	case ChoiceBoth:
+		return concatLines(h.Ours, h.Theirs)
+	case ChoiceBothTheirs:
+		return concatLines(h.Theirs, h.Ours)
+	}
+	return nil
+}
+
+// concatLines joins two sides, terminating the first so lines don't merge.
+func concatLines(first, second []string) []string {
+	lines := append([]string{}, first...)
+	if n := len(lines); n > 0 && len(second) > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines[n-1] += "\n"
+	}
+	return append(lines, second...)
+}
+
+// ConflictChunk is either unconflicted context lines or a conflicted hunk.
+type ConflictChunk struct {
+	Lines []string      // Context lines (nil for a hunk)
+	Hunk  *ConflictHunk // Conflicted hunk (nil for context)
+}
+
+// ConflictFile is a conflicted file split into context and hunks, built from
+// the base (:1:), ours (:2:) and theirs (:3:) index stages.
+type ConflictFile struct {
+	Path          string
+	Chunks        []ConflictChunk
+	Binary        bool           // A stage is binary; only whole-file choices apply
+	OursDeleted   bool           // Our side deleted the file
+	TheirsDeleted bool           // Their side deleted the file
+	FileChoice    ConflictChoice // Whole-file choice for binary and delete conflicts
+	Edited        bool           // The working tree file has no conflict markers left
+}
+
+// Hunks returns the conflicted hunks in file order.
+func (f *ConflictFile) Hunks() []*ConflictHunk {
+	var hunks []*ConflictHunk
+	for _, c := range f.Chunks {
+		if c.Hunk != nil {
+			hunks = append(hunks, c.Hunk)
+		}
+	}
+	return hunks
+}
+
+// WholeFile reports whether the conflict can only be resolved by picking a side.
+func (f *ConflictFile) WholeFile() bool {
+	return f.Binary || f.OursDeleted || f.TheirsDeleted
+}
+
+// Resolved reports whether every hunk (or the whole file) has a choice, or
+// the file was resolved by hand.
+func (f *ConflictFile) Resolved() bool {
+	if f.Edited {
+		return true
+	}
+	if f.WholeFile() {
+		return f.FileChoice == ChoiceOurs || f.FileChoice == ChoiceTheirs
+	}
+	for _, h := range f.Hunks() {
+		if h.Choice == ChoiceNone {
+			return false
+		}
+	}
+	return true
+}
+
+// Content returns the merged file with each hunk's choice applied. Unresolved
+// hunks are written back with diff3 conflict markers.
+func (f *ConflictFile) Content() string {
+	var sb strings.Builder
+	for _, c := range f.Chunks {
+		if c.Hunk == nil {
+			writeLines(&sb, c.Lines)
+			continue
+		}
+		if c.Hunk.Choice != ChoiceNone {
+			writeLines(&sb, c.Hunk.Lines())
+			continue
+		}
+		sb.WriteString(markerOurs + " ours\n")
+		writeLines(&sb, c.Hunk.Ours)
+		sb.WriteString(markerBase + " base\n")
+		writeLines(&sb, c.Hunk.Base)
+		sb.WriteString(markerSep + "\n")
+		writeLines(&sb, c.Hunk.Theirs)
+		sb.WriteString(markerTheirs + " theirs\n")
+	}
+	return sb.String()
+}
+
+// HunkLine returns the 0-indexed line where hunk idx starts in Content().
+func (f *ConflictFile) HunkLine(idx int) int {
+	line, n := 0, 0
+	for _, c := range f.Chunks {
+		if c.Hunk == nil {
+			line += len(c.Lines)
+			continue
+		}
+		if n == idx {
+			return line
+		}
+		n++
+		if c.Hunk.Choice != ChoiceNone {
+			line += len(c.Hunk.Lines())
+		} else {
+			line += len(c.Hunk.Ours) + len(c.Hunk.Base) + len(c.Hunk.Theirs) + 4
+		}
+	}
+	return line
+}
+
+// writeLines writes lines, terminating the last one if it lacks a newline so
+// following markers start on their own line.
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
+
+// GetConflictOp returns the operation that is stopped on conflicts in workDir.
+func GetConflictOp(workDir string) ConflictOp {
+	switch {
+	case IsRebaseInProgress(workDir):
+		return ConflictOpRebase
+	case IsCherryPickInProgress(workDir):
+		return ConflictOpCherryPick
+	case IsRevertInProgress(workDir):
+		return ConflictOpRevert
+	case gitPathExists(workDir, "MERGE_HEAD"):
+		return ConflictOpMerge
+	}
+	return ConflictOpNone
+}
+
+// LoadConflictFile builds the three-way view of a conflicted file from its
+// index stages. Text conflicts are re-merged with git merge-file --diff3 so
+// every hunk has its base, independent of merge.conflictStyle.
+func LoadConflictFile(workDir, path string) (*ConflictFile, error) {
+	stages, err := unmergedStages(workDir, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, &ConflictError{Output: path + " is not conflicted"}
+	}
+	base, _, err := showStage(workDir, stages, 1)
+	if err != nil {
+		return nil, err
+	}
+	ours, hasOurs, err := showStage(workDir, stages, 2)
+	if err != nil {
+		return nil, err
+	}
+	theirs, hasTheirs, err := showStage(workDir, stages, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &ConflictFile{
+		Path:          path,
+		Binary:        isBinary(base) || isBinary(ours) || isBinary(theirs),
+		OursDeleted:   !hasOurs,
+		TheirsDeleted: !hasTheirs,
+	}
+	if f.WholeFile() {
+		return f, nil
+	}
+	if worktree, err := os.ReadFile(filepath.Join(workDir, path)); err == nil {
+		content := string(worktree)
+		f.Edited = !HasConflictMarkers(content)
+		// A diff3-style file was written by the resolver or by hand; keep
+		// its partial resolutions instead of re-merging from the stages.
+		if !f.Edited && isDiff3(content) {
+			f.Chunks = ParseConflictMarkers(content)
+			return f, nil
+		}
+	}
+
+	merged, err := mergeStages(workDir, base, ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+	f.Chunks = ParseConflictMarkers(merged)
+	return f, nil
+}
+
+// unmergedStages returns the blob of each index stage path has, keyed by
+// stage number. A path that is not conflicted has none.
+func unmergedStages(workDir, path string) (map[int]string, error) {
+	cmd := exec.Command("git", "--literal-pathspecs", "ls-files", "-u", "-z", "--", path)
+	cmd.Dir = workDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &ConflictError{Output: stderr.String(), Err: err}
+	}
+	stages := make(map[int]string)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> <blob> <stage>\t<path>
+		info, entryPath, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if !ok || entryPath != path || len(fields) != 3 {
+			continue
+		}
+		if stage, err := strconv.Atoi(fields[2]); err == nil {
+			stages[stage] = fields[1]
+		}
+	}
+	return stages, nil
+}
+
+// showStage returns the content of an index stage from stages. A missing
+// stage (the file was added or deleted on one side) is reported with ok=false.
+func showStage(workDir string, stages map[int]string, stage int) (content []byte, ok bool, err error) {
+	blob, ok := stages[stage]
+	if !ok {
+		return nil, false, nil
+	}
+	obj, err := ObjectReaderFor(workDir).Read(blob)
+	if err != nil {
+		return nil, false, &ConflictError{Output: err.Error(), Err: err}
+	}
+	return obj.Data, true, nil
+}
+
+// mergeStages runs git merge-file --diff3 on the three stages.
+func mergeStages(workDir string, base, ours, theirs []byte) (string, error) {
+	dir, err := os.MkdirTemp("", "sidecar-conflict-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	paths := make([]string, 3)
+	for i, content := range [][]byte{ours, base, theirs} {
+		paths[i] = filepath.Join(dir, []string{"ours", "base", "theirs"}[i])
+		if err := os.WriteFile(paths[i], content, 0o600); err != nil {
+			return "", err
+		}
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p", "--diff3",
+		"-L", "ours", "-L", "base", "-L", "theirs",
+		paths[0], paths[1], paths[2])
+	cmd.Dir = workDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		// A positive exit status is the number of conflicts
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() <= 0 || exitErr.ExitCode() >= 128 {
+			return "", &ConflictError{Output: stderr.String(), Err: err}
+		}
+	}
+	return string(output), nil
+}
+
+// ParseConflictMarkers splits diff3-style merged content into context and
+// conflicted hunks. Hunks without a base section get an empty base.
+func ParseConflictMarkers(content string) []ConflictChunk {
+	const (
+		stateContext = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+	var chunks []ConflictChunk
+	var context []string
+	var hunk *ConflictHunk
+	state := stateContext
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		trimmed := strings.TrimRight(line, "\r\n")
+		switch {
+		case state == stateContext && isMarker(trimmed, markerOurs):
+			if len(context) > 0 {
+				chunks = append(chunks, ConflictChunk{Lines: context})
+				context = nil
+			}
+			hunk = &ConflictHunk{}
+			state = stateOurs
+		case state == stateOurs && isMarker(trimmed, markerBase):
+			state = stateBase
+		case (state == stateOurs || state == stateBase) && trimmed == markerSep:
+			state = stateTheirs
+		case state == stateTheirs && isMarker(trimmed, markerTheirs):
+			chunks = append(chunks, ConflictChunk{Hunk: hunk})
+			hunk = nil
+			state = stateContext
+		case state == stateOurs:
+			hunk.Ours = append(hunk.Ours, line)
+		case state == stateBase:
+			hunk.Base = append(hunk.Base, line)
+		case state == stateTheirs:
+			hunk.Theirs = append(hunk.Theirs, line)
+		default:
+			context = append(context, line)
+		}
+	}
+	if len(context) > 0 {
+		chunks = append(chunks, ConflictChunk{Lines: context})
+	}
+	return chunks
+}
+
+// HasConflictMarkers reports whether content still contains conflict markers.
+func HasConflictMarkers(content string) bool {
+	hasOurs, hasTheirs := false, false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		hasOurs = hasOurs || isMarker(line, markerOurs)
+		hasTheirs = hasTheirs || isMarker(line, markerTheirs)
+	}
+	return hasOurs && hasTheirs
+}
+
+// isDiff3 reports whether every conflict in content has a base section.
+func isDiff3(content string) bool {
+	inOurs := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case isMarker(line, markerOurs):
+			inOurs = true
+		case inOurs && isMarker(line, markerBase):
+			inOurs = false
+		case inOurs && line == markerSep:
+			return false
+		}
+	}
+	return true
+}
+
+// isMarker reports whether line is the given marker, alone or followed by a label.
+func isMarker(line, marker string) bool {
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+// isBinary reports whether content looks binary, using git's NUL heuristic.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// ResolveConflictFile writes the resolved file and stages it. Whole-file
+// choices check out or remove the chosen side.
+func ResolveConflictFile(workDir string, f *ConflictFile) error {
+	if !f.Resolved() {
+		return fmt.Errorf("%s has unresolved conflicts", f.Path)
+	}
+	switch {
+	case f.Edited:
+		// Resolved by hand; stage as is
+	case f.WholeFile():
+		deleted := (f.FileChoice == ChoiceOurs && f.OursDeleted) ||
+			(f.FileChoice == ChoiceTheirs && f.TheirsDeleted)
+		if deleted {
+			return runConflictGit(workDir, "rm", "--quiet", "--", f.Path)
+		}
+		if err := runConflictGit(workDir, "checkout", "--"+string(f.FileChoice), "--", f.Path); err != nil {
+			return err
+		}
+	default:
+		fullPath := filepath.Join(workDir, f.Path)
+		mode := os.FileMode(0o644)
+		if info, err := os.Stat(fullPath); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(fullPath, []byte(f.Content()), mode); err != nil {
+			return err
+		}
+	}
+	return runConflictGit(workDir, "add", "--", f.Path)
+}
+
+// ContinueConflictOp commits the resolution and continues op. It returns the
+// files that conflict next when a rebase, cherry-pick or revert stops again.
+func ContinueConflictOp(workDir string, op ConflictOp) ([]string, error) {
+	var err error
+	switch op {
+	case ConflictOpRebase:
+		var result *RebaseResult
+		result, err = ContinueRebase(workDir)
+		if err == nil && result.Stopped {
+			return result.Conflicts, nil
+		}
+	case ConflictOpCherryPick:
+		_, err = ContinueCherryPick(workDir)
+	case ConflictOpRevert:
+		_, err = ContinueRevert(workDir)
+	case ConflictOpMerge:
+		output, runErr := runSequencer(workDir, []string{"commit", "--no-edit"})
+		if runErr != nil {
+			err = &ConflictError{Output: output, Err: runErr}
+		}
+	default:
+		return nil, fmt.Errorf("no merge, rebase, cherry-pick or revert in progress")
+	}
+	if err != nil && IsConflictError(err) {
+		if files := GetConflictedFiles(workDir); len(files) > 0 {
+			return files, nil
+		}
+	}
+	return nil, err
+}
+
+// AbortConflictOp aborts op, restoring the branch to where it started.
+func AbortConflictOp(workDir string, op ConflictOp) error {
+	switch op {
+	case ConflictOpRebase:
+		return AbortRebase(workDir)
+	case ConflictOpCherryPick:
+		return AbortCherryPick(workDir)
+	case ConflictOpRevert:
+		return AbortRevert(workDir)
+	default:
+		return AbortMerge(workDir)
+	}
+}
+
+// runConflictGit runs a git command, wrapping failures in a ConflictError.
+func runConflictGit(workDir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &ConflictError{Output: string(output), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// newConflictRepo creates a repo where merging "side" into the default branch
+// conflicts in f.txt, and leaves the merge stopped.
+func newConflictRepo(t *testing.T, base, ours, theirs string) string {
+	t.Helper()
+	dir := newTestRepo(t, map[string]string{"f.txt": base})
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	commitFiles(t, dir, "theirs", map[string]string{"f.txt": theirs})
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFiles(t, dir, "ours", map[string]string{"f.txt": ours})
+
+	cmd := exec.Command("git", "merge", "side")
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected merge to conflict")
+	}
+	return dir
+}
+
+func TestParseConflictMarkers(t *testing.T) {
+	content := "a\n<<<<<<< ours\nb1\n||||||| base\nb\n=======\nb2\n>>>>>>> theirs\nc\n<<<<<<< HEAD\nd1\n=======\nd2\n>>>>>>> side\n"
+	chunks := ParseConflictMarkers(content)
+	if len(chunks) != 4 {
+		t.Fatalf("got %d chunks, want 4", len(chunks))
+	}
+	h := chunks[1].Hunk
+	if h == nil || strings.Join(h.Ours, "") != "b1\n" || strings.Join(h.Base, "") != "b\n" || strings.Join(h.Theirs, "") != "b2\n" {
+		t.Errorf("diff3 hunk = %+v", h)
+	}
+	if h := chunks[3].Hunk; h == nil || len(h.Base) != 0 || strings.Join(h.Theirs, "") != "d2\n" {
+		t.Errorf("merge-style hunk = %+v", h)
+	}
+
+	f := &ConflictFile{Chunks: chunks}
+	if f.Resolved() {
+		t.Error("file with unresolved hunks should not be resolved")
+	}
+	f.Hunks()[0].Choice = ChoiceOurs
+	f.Hunks()[1].Choice = ChoiceBothTheirs
+	if got := f.Content(); got != "a\nb1\nc\nd2\nd1\n" {
+		t.Errorf("content = %q", got)
+	}
+	if got := f.HunkLine(1); got != 3 {
+		t.Errorf("HunkLine(1) = %d, want 3", got)
+	}
+}
+
+func TestLoadConflictFile_ResolveAndContinueMerge(t *testing.T) {
+	dir := newConflictRepo(t, "one\ntwo\nthree\n", "one\nOURS\nthree\n", "one\nTHEIRS\nthree\n")
+	if op := GetConflictOp(dir); op != ConflictOpMerge {
+		t.Fatalf("GetConflictOp = %q", op)
+	}
+
+	f, err := LoadConflictFile(dir, "f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hunks := f.Hunks()
+	if len(hunks) != 1 || f.Edited || f.WholeFile() {
+		t.Fatalf("unexpected conflict file: %+v", f)
+	}
+	h := hunks[0]
+	if strings.Join(h.Base, "") != "two\n" || strings.Join(h.Ours, "") != "OURS\n" || strings.Join(h.Theirs, "") != "THEIRS\n" {
+		t.Errorf("hunk = %+v", h)
+	}
+	if err := ResolveConflictFile(dir, f); err == nil {
+		t.Error("resolving with unresolved hunks should fail")
+	}
+
+	h.Choice = ChoiceBoth
+	if err := ResolveConflictFile(dir, f); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "f.txt"); got != "one\nOURS\nTHEIRS\nthree\n" {
+		t.Errorf("resolved file = %q", got)
+	}
+	if files := GetConflictedFiles(dir); len(files) != 0 {
+		t.Errorf("file should be staged, conflicts = %v", files)
+	}
+
+	next, err := ContinueConflictOp(dir, ConflictOpMerge)
+	if err != nil || len(next) != 0 {
+		t.Fatalf("ContinueConflictOp = %v, %v", next, err)
+	}
+	if GetConflictOp(dir) != ConflictOpNone {
+		t.Error("merge should be concluded")
+	}
+	if parents := strings.Fields(runGit(t, dir, "log", "-1", "--format=%P")); len(parents) != 2 {
+		t.Errorf("expected a merge commit, parents = %v", parents)
+	}
+}
+
+func TestLoadConflictFile_EditedByHand(t *testing.T) {
+	dir := newConflictRepo(t, "a\n", "b\n", "c\n")
+	writeFile(t, dir, "f.txt", "hand merged\n")
+
+	f, err := LoadConflictFile(dir, "f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Edited || !f.Resolved() {
+		t.Fatal("a file without markers should count as resolved by hand")
+	}
+	if err := ResolveConflictFile(dir, f); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "f.txt"); got != "hand merged\n" {
+		t.Errorf("hand edit should be staged as is, got %q", got)
+	}
+}
+
+func TestLoadConflictFile_DeletedByThem(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	runGit(t, dir, "rm", "-q", "f.txt")
+	runGit(t, dir, "commit", "-q", "-m", "delete")
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFiles(t, dir, "edit", map[string]string{"f.txt": "b\n"})
+	cmd := exec.Command("git", "merge", "side")
+	cmd.Dir = dir
+	_ = cmd.Run()
+
+	f, err := LoadConflictFile(dir, "f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.TheirsDeleted || f.OursDeleted || !f.WholeFile() {
+		t.Fatalf("unexpected conflict file: %+v", f)
+	}
+	f.FileChoice = ChoiceTheirs
+	if err := ResolveConflictFile(dir, f); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(dir + "/f.txt") {
+		t.Error("taking their deletion should remove the file")
+	}
+	if files := GetConflictedFiles(dir); len(files) != 0 {
+		t.Errorf("conflicts = %v", files)
+	}
+}
+
+func TestContinueConflictOp_RebaseStopsAgain(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n", "g.txt": "a\n"})
+	runGit(t, dir, "checkout", "-q", "-b", "topic")
+	commitFiles(t, dir, "topic f", map[string]string{"f.txt": "topic\n"})
+	commitFiles(t, dir, "topic g", map[string]string{"g.txt": "topic\n"})
+	runGit(t, dir, "checkout", "-q", "-")
+	commitFiles(t, dir, "main", map[string]string{"f.txt": "main\n", "g.txt": "main\n"})
+	runGit(t, dir, "checkout", "-q", "topic")
+
+	cmd := exec.Command("git", "rebase", "-")
+	cmd.Dir = dir
+	_ = cmd.Run()
+	if GetConflictOp(dir) != ConflictOpRebase {
+		t.Fatal("expected rebase to stop")
+	}
+
+	f, err := LoadConflictFile(dir, "f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Hunks()[0].Choice = ChoiceTheirs
+	if err := ResolveConflictFile(dir, f); err != nil {
+		t.Fatal(err)
+	}
+	next, err := ContinueConflictOp(dir, ConflictOpRebase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next) != 1 || next[0] != "g.txt" {
+		t.Errorf("next conflicts = %v, want [g.txt]", next)
+	}
+	if err := AbortConflictOp(dir, ConflictOpRebase); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConflictFile_KeepsPartialDiff3Edits(t *testing.T) {
+	dir := newConflictRepo(t, "a\n", "b\n", "c\n")
+	writeFile(t, dir, "f.txt", "<<<<<<< ours\nb edited\n||||||| base\na\n=======\nc\n>>>>>>> theirs\n")
+
+	f, err := LoadConflictFile(dir, "f.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hunks := f.Hunks(); len(hunks) != 1 || strings.Join(hunks[0].Ours, "") != "b edited\n" {
+		t.Errorf("diff3 edits in the working tree should be kept, got %+v", f.Chunks)
+	}
+}
+
+func TestLoadConflictFile_NotConflicted(t *testing.T) {
+	dir := newConflictRepo(t, "a\n", "b\n", "c\n")
+	// Stages are matched by exact path, not as a pathspec
+	for _, path := range []string{"missing.txt", "f.*", "f"} {
+		_, err := LoadConflictFile(dir, path)
+		var conflictErr *ConflictError
+		if !errors.As(err, &conflictErr) || !strings.Contains(err.Error(), "is not conflicted") {
+			t.Errorf("LoadConflictFile(%q) = %v, want not conflicted", path, err)
+		}
+	}
+}
@@ -13,8 +13,20 @@ const (
 	LineContext LineType = iota
 	LineAdd
 	LineRemove
+	LineMovedAdd    // Added as part of a block moved from elsewhere
+	LineMovedRemove // Removed as part of a block moved elsewhere
 )
 
+// IsAdd reports whether the line exists only on the new side.
+func (t LineType) IsAdd() bool {
+	return t == LineAdd || t == LineMovedAdd
+}
+
+// IsRemove reports whether the line exists only on the old side.
+func (t LineType) IsRemove() bool {
+	return t == LineRemove || t == LineMovedRemove
+}
+
 // WordSegment represents a segment of text with word-level diff highlighting.
 type WordSegment struct {
 	Text     string
@@ -28,6 +40,7 @@ type DiffLine struct {
 	NewLineNo int // 0 means not applicable
 	Content   string
 	WordDiff  []WordSegment
+	NoNewline bool // Followed by "\ No newline at end of file"
 }
 
 // Hunk represents a diff hunk.
@@ -38,6 +51,7 @@ type Hunk struct {
 	NewCount int
 	Header   string
 	Lines    []DiffLine
+	Match    bool // Set when the hunk matches the active history search
 }
 
 // ParsedDiff represents a fully parsed diff.
@@ -46,6 +60,7 @@ type ParsedDiff struct {
 	NewFile string
 	Binary  bool
 	Hunks   []Hunk
+	LFS     *LFSChange // Set when the diff is between Git LFS pointers
 }
 
 // FileDiffInfo holds a parsed diff with rendering position info.
@@ -79,10 +94,10 @@ func ParseMultiFileDiff(diff string) *MultiFileDiff {
 		additions, deletions := 0, 0
 		for _, hunk := range parsed.Hunks {
 			for _, line := range hunk.Lines {
-				switch line.Type {
-				case LineAdd:
+				switch {
+				case line.Type.IsAdd():
 					additions++
-				case LineRemove:
+				case line.Type.IsRemove():
 					deletions++
 				}
 			}
@@ -108,7 +123,7 @@ func splitIntoFileDiffs(diff string) []string {
 		line := lines[i]
 
 		// Check for start of new file diff
-		if strings.HasPrefix(line, "diff --git ") {
+		if plain, _ := decolorDiffLine(line); strings.HasPrefix(plain, "diff --git ") {
 			// Save previous file diff if exists
 			if current.Len() > 0 {
 				fileDiffs = append(fileDiffs, current.String())
@@ -161,7 +176,9 @@ var (
 	hunkHeaderRegex = regexp.MustCompile(`^@@\s*-(\d+)(?:,(\d+))?\s*\+(\d+)(?:,(\d+))?\s*@@(.*)$`)
 )
 
-// ParseUnifiedDiff parses a unified diff format string.
+// ParseUnifiedDiff parses a unified diff format string. Colored output from
+// DiffOptions.ColorMoved is accepted, with moved blocks parsed as
+// LineMovedAdd and LineMovedRemove.
 func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 	lines := strings.Split(diff, "\n")
 	parsed := &ParsedDiff{}
@@ -171,6 +188,7 @@ func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 	newLineNo := 0
 
 	for _, line := range lines {
+		line, moved := decolorDiffLine(line)
 		switch {
 		case strings.HasPrefix(line, "Binary files"):
 			parsed.Binary = true
@@ -240,6 +258,9 @@ func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 					NewLineNo: newLineNo,
 					Content:   content,
 				}
+				if moved {
+					diffLine.Type = LineMovedAdd
+				}
 				currentHunk.Lines = append(currentHunk.Lines, diffLine)
 				newLineNo++
 
@@ -250,6 +271,9 @@ func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 					NewLineNo: 0,
 					Content:   content,
 				}
+				if moved {
+					diffLine.Type = LineMovedRemove
+				}
 				currentHunk.Lines = append(currentHunk.Lines, diffLine)
 				oldLineNo++
 
@@ -265,7 +289,10 @@ func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 				newLineNo++
 
 			case '\\':
-				// "\ No newline at end of file" - skip
+				// "\ No newline at end of file" - applies to the preceding line
+				if n := len(currentHunk.Lines); n > 0 {
+					currentHunk.Lines[n-1].NoNewline = true
+				}
 
 			default:
 				// Treat as context if unrecognized
@@ -286,6 +313,7 @@ func ParseUnifiedDiff(diff string) (*ParsedDiff, error) {
 	for i := range parsed.Hunks {
 		computeWordDiffs(&parsed.Hunks[i])
 	}
+	parsed.LFS = parseLFSChange(parsed)
 
 	return parsed, nil
 }
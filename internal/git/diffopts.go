@@ -0,0 +1,168 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WhitespaceMode selects which whitespace changes a diff ignores.
+type WhitespaceMode string
+
+const (
+	WhitespaceShow    WhitespaceMode = ""        // Show all whitespace changes
+	WhitespaceAll     WhitespaceMode = "all"     // -w
+	WhitespaceChanges WhitespaceMode = "changes" // -b
+	WhitespaceEOL     WhitespaceMode = "eol"     // --ignore-space-at-eol
+)
+
+// DiffAlgorithm selects git's diff algorithm.
+type DiffAlgorithm string
+
+const (
+	AlgorithmDefault   DiffAlgorithm = ""
+	AlgorithmPatience  DiffAlgorithm = "patience"
+	AlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+// DiffOptions controls how git computes a diff. The zero value matches a
+// plain `git diff`.
+type DiffOptions struct {
+	Whitespace       WhitespaceMode `json:"whitespace,omitempty"`
+	IgnoreBlankLines bool           `json:"ignoreBlankLines,omitempty"`
+	Context          int            `json:"context,omitempty"` // Lines of context; 0 uses git's default
+	Algorithm        DiffAlgorithm  `json:"algorithm,omitempty"`
+	RenameThreshold  int            `json:"renameThreshold,omitempty"` // Similarity percent; 0 uses git's default
+	FindCopies       bool           `json:"findCopies,omitempty"`
+	ColorMoved       bool           `json:"colorMoved,omitempty"` // Mark moved blocks as LineMovedAdd/LineMovedRemove
+}
+
+// IsDefault reports whether the options leave git's behavior unchanged.
+func (o DiffOptions) IsDefault() bool {
+	return o == DiffOptions{}
+}
+
+// Patchable reports whether hunks of a diff made with the options can be
+// applied back to the index. Ignoring whitespace or blank lines drops changes
+// the patch would need.
+func (o DiffOptions) Patchable() bool {
+	return o.Whitespace == WhitespaceShow && !o.IgnoreBlankLines
+}
+
+// Args returns the diff flags for the options.
+func (o DiffOptions) Args() []string {
+	var args []string
+	switch o.Whitespace {
+	case WhitespaceAll:
+		args = append(args, "-w")
+	case WhitespaceChanges:
+		args = append(args, "-b")
+	case WhitespaceEOL:
+		args = append(args, "--ignore-space-at-eol")
+	}
+	if o.IgnoreBlankLines {
+		args = append(args, "--ignore-blank-lines")
+	}
+	if o.Context > 0 {
+		args = append(args, "-U"+strconv.Itoa(o.Context))
+	}
+	if o.Algorithm != AlgorithmDefault {
+		args = append(args, "--diff-algorithm="+string(o.Algorithm))
+	}
+	flag := "-M"
+	if o.FindCopies {
+		flag = "-C"
+	}
+	if o.RenameThreshold > 0 {
+		args = append(args, flag+strconv.Itoa(o.RenameThreshold)+"%")
+	} else if o.FindCopies {
+		args = append(args, flag)
+	}
+	if o.ColorMoved {
+		args = append(args, "--color=always", "--color-moved=plain")
+	}
+	return args
+}
+
+// Command returns the git arguments for subcommand (diff or show) with the
+// options applied, followed by args.
+func (o DiffOptions) Command(subcommand string, args ...string) []string {
+	var cmd []string
+	if o.ColorMoved {
+		// Fixed colors so moved lines can be told apart regardless of the
+		// user's color config
+		cmd = append(cmd,
+			"-c", "color.diff.old=red",
+			"-c", "color.diff.new=green",
+			"-c", "color.diff.frag=cyan",
+			"-c", "color.diff.oldMoved="+movedRemoveColor,
+			"-c", "color.diff.newMoved="+movedAddColor,
+		)
+	}
+	cmd = append(cmd, subcommand)
+	cmd = append(cmd, o.Args()...)
+	return append(cmd, args...)
+}
+
+// Summary describes the non-default options briefly, e.g. "-w, U10,
+// patience". It is empty for the default options.
+func (o DiffOptions) Summary() string {
+	var parts []string
+	switch o.Whitespace {
+	case WhitespaceAll:
+		parts = append(parts, "-w")
+	case WhitespaceChanges:
+		parts = append(parts, "-b")
+	case WhitespaceEOL:
+		parts = append(parts, "eol ws")
+	}
+	if o.IgnoreBlankLines {
+		parts = append(parts, "no blank")
+	}
+	if o.Context > 0 {
+		parts = append(parts, "U"+strconv.Itoa(o.Context))
+	}
+	if o.Algorithm != AlgorithmDefault {
+		parts = append(parts, string(o.Algorithm))
+	}
+	if o.RenameThreshold > 0 {
+		parts = append(parts, "M"+strconv.Itoa(o.RenameThreshold)+"%")
+	}
+	if o.FindCopies {
+		parts = append(parts, "copies")
+	}
+	if o.ColorMoved {
+		parts = append(parts, "moved")
+	}
+	return strings.Join(parts, ", ")
+}
+
+const (
+	movedRemoveColor = "magenta"
+	movedAddColor    = "blue"
+)
+
+var (
+	sgrRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	movedRemoveSGR = "\x1b[35m"
+	movedAddSGR    = "\x1b[34m"
+)
+
+// decolorDiffLine strips the colors from a line of `--color-moved` output
+// and reports whether git colored it as part of a moved block.
+func decolorDiffLine(line string) (string, bool) {
+	if !strings.Contains(line, "\x1b[") {
+		return line, false
+	}
+	moved := strings.HasPrefix(line, movedRemoveSGR) || strings.HasPrefix(line, movedAddSGR)
+	return sgrRegex.ReplaceAllString(line, ""), moved
+}
+
+// StripDiffColor removes colors from diff output produced with ColorMoved.
+func StripDiffColor(diff string) string {
+	if !strings.Contains(diff, "\x1b[") {
+		return diff
+	}
+	return sgrRegex.ReplaceAllString(diff, "")
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffOptions_Args(t *testing.T) {
+	tests := []struct {
+		opts DiffOptions
+		want []string
+	}{
+		{DiffOptions{}, nil},
+		{DiffOptions{Whitespace: WhitespaceAll, IgnoreBlankLines: true}, []string{"-w", "--ignore-blank-lines"}},
+		{DiffOptions{Whitespace: WhitespaceEOL, Context: 10}, []string{"--ignore-space-at-eol", "-U10"}},
+		{DiffOptions{Algorithm: AlgorithmHistogram, RenameThreshold: 75}, []string{"--diff-algorithm=histogram", "-M75%"}},
+		{DiffOptions{FindCopies: true}, []string{"-C"}},
+		{DiffOptions{FindCopies: true, RenameThreshold: 30}, []string{"-C30%"}},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.Args(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.Args() = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+
+	args := DiffOptions{ColorMoved: true}.Command("diff", "--", "a.txt")
+	if args[0] != "-c" || !strings.HasSuffix(strings.Join(args, " "), "diff --color=always --color-moved=plain -- a.txt") {
+		t.Errorf("Command() = %v", args)
+	}
+	if (DiffOptions{Context: 5}).Patchable() != true || (DiffOptions{Whitespace: WhitespaceChanges}).Patchable() {
+		t.Error("only whitespace and blank line options should make diffs unpatchable")
+	}
+}
+
+func TestParseUnifiedDiff_MovedLines(t *testing.T) {
+	block := "func moved() {\n\tfirst := 1\n\tsecond := 2\n\treturn first + second\n}\n"
+	filler := strings.Repeat("// filler line\n", 3)
+	dir := newTestRepo(t, map[string]string{"a.go": block + filler + "// tail\n"})
+	writeFile(t, dir, "a.go", filler+"// tail\n"+block+"// changed\n")
+
+	raw := runGit(t, dir, DiffOptions{ColorMoved: true}.Command("diff")...)
+	if !strings.Contains(raw, "\x1b[") {
+		t.Fatalf("expected colored output, got %q", raw)
+	}
+	mfd := ParseMultiFileDiff(raw)
+	if len(mfd.Files) != 1 || mfd.Files[0].Diff.NewFile != "a.go" {
+		t.Fatalf("files = %+v", mfd.Files)
+	}
+
+	counts := map[LineType]int{}
+	for _, h := range mfd.Files[0].Diff.Hunks {
+		for _, l := range h.Lines {
+			counts[l.Type]++
+			if strings.Contains(l.Content, "\x1b") {
+				t.Errorf("line still colored: %q", l.Content)
+			}
+		}
+	}
+	// Git picks which side of the swap counts as moved; either way both ends
+	// of the move are marked and only the real edit stays a plain addition
+	moved := counts[LineMovedAdd]
+	if moved < 3 || counts[LineMovedRemove] != moved {
+		t.Errorf("moved lines = -%d +%d", counts[LineMovedRemove], moved)
+	}
+	if counts[LineAdd] != 1 || counts[LineRemove] != 0 {
+		t.Errorf("plain lines = -%d +%d, want -0 +1", counts[LineRemove], counts[LineAdd])
+	}
+	if f := mfd.Files[0]; f.Additions != moved+1 || f.Deletions != moved {
+		t.Errorf("stats = %s, moved %d", f.ChangeStats(), moved)
+	}
+}
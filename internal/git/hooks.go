@@ -0,0 +1,140 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hooks run by a commit and by a push.
+var (
+	CommitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+	PushHooks   = []string{"pre-push"}
+)
+
+// Stream receives the output of a git command, and of the hooks it runs,
+// as it is printed. Cancelling Ctx kills git and, on Unix, the hook it is
+// running.
+type Stream struct {
+	Ctx context.Context
+	Out io.Writer
+}
+
+// HasHook reports whether any of the named hooks is installed and
+// executable in workDir's repository, honouring core.hooksPath.
+func HasHook(workDir string, names ...string) bool {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	dir := gitPathAbs(workDir, string(output))
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// runStreamed runs git with args in workDir and returns its combined
+// output. With a stream, the output is also copied to s.Out as it arrives
+// and the command is killed when s.Ctx is cancelled, in which case the
+// context's error is returned.
+func runStreamed(s *Stream, workDir string, args ...string) (string, error) {
+	ctx := context.Background()
+	if s != nil && s.Ctx != nil {
+		ctx = s.Ctx
+	}
+	var buf bytes.Buffer
+	var out io.Writer = &buf
+	if s != nil && s.Out != nil {
+		out = io.MultiWriter(&buf, s.Out)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = workDir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	killGroupOnCancel(cmd)
+	// Where a hook can outlive a killed git, don't wait on its pipes
+	cmd.WaitDelay = 2 * time.Second
+	err := cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		return buf.String(), ctx.Err()
+	}
+	return buf.String(), err
+}
+
+// FileLocation is a file and line mentioned in command output, such as a
+// linter finding printed by a hook.
+type FileLocation struct {
+	Path   string // Relative to the repository root
+	Line   int    // 1-indexed
+	Column int    // 1-indexed, 0 when not given
+	Output int    // Index of the output line it was found on
+	Text   string // The output line, trimmed
+}
+
+var (
+	// fileLineRegex matches "path:line" and "path:line:col", as printed by
+	// compilers and most linters.
+	fileLineRegex = regexp.MustCompile(`(?:^|[\s("'])((?:\.{0,2}/)?[\w@.+\-/\\]*\w\.\w+):(\d+)(?::(\d+))?`)
+	// pythonLineRegex matches Python tracebacks: File "path", line N.
+	pythonLineRegex = regexp.MustCompile(`File "([^"]+)", line (\d+)`)
+)
+
+// FindFileLocations returns the file:line locations in output lines that
+// point at files of the repository in workDir, in order and without
+// repeats. Paths may be relative to workDir or absolute inside it.
+func FindFileLocations(workDir string, lines []string) []FileLocation {
+	var locs []FileLocation
+	seen := make(map[string]bool)
+	add := func(i int, path, line, col string) {
+		rel, ok := repoRelativePath(workDir, path)
+		if !ok {
+			return
+		}
+		n, _ := strconv.Atoi(line)
+		c, _ := strconv.Atoi(col)
+		key := rel + ":" + line
+		if n < 1 || seen[key] {
+			return
+		}
+		seen[key] = true
+		locs = append(locs, FileLocation{Path: rel, Line: n, Column: c, Output: i, Text: strings.TrimSpace(lines[i])})
+	}
+	for i, line := range lines {
+		for _, m := range fileLineRegex.FindAllStringSubmatch(line, -1) {
+			add(i, m[1], m[2], m[3])
+		}
+		for _, m := range pythonLineRegex.FindAllStringSubmatch(line, -1) {
+			add(i, m[1], m[2], "")
+		}
+	}
+	return locs
+}
+
+// repoRelativePath returns path relative to workDir if it names a file
+// inside it.
+func repoRelativePath(workDir, path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	rel, err := filepath.Rel(workDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
@@ -0,0 +1,150 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// installHook writes an executable hook script into dir's repository.
+func installHook(t *testing.T, dir, name, script string) {
+	t.Helper()
+	path := filepath.Join(dir, ".git", "hooks", name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// lockedBuffer collects streamed output from the command's goroutines.
+type lockedBuffer struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.String()
+}
+
+func TestHasHook(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if HasHook(dir, CommitHooks...) {
+		t.Fatal("a new repo has only sample hooks")
+	}
+	installHook(t, dir, "pre-commit", "exit 0\n")
+	if !HasHook(dir, CommitHooks...) || HasHook(dir, PushHooks...) {
+		t.Error("pre-commit should count as a commit hook only")
+	}
+
+	// core.hooksPath replaces the hooks directory
+	writeFile(t, dir, "hooks/pre-push", "#!/bin/sh\n")
+	if err := os.Chmod(filepath.Join(dir, "hooks", "pre-push"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "config", "core.hooksPath", "hooks")
+	if !HasHook(dir, PushHooks...) || HasHook(dir, CommitHooks...) {
+		t.Error("hooks should be looked up in core.hooksPath")
+	}
+}
+
+func TestStreamCommit_HookFailureAndNoVerify(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	installHook(t, dir, "pre-commit", "echo 'a.txt:1: trailing whitespace'\nexit 1\n")
+	writeFile(t, dir, "a.txt", "a \n")
+	runGit(t, dir, "add", "a.txt")
+
+	out := &lockedBuffer{}
+	s := &Stream{Ctx: context.Background(), Out: out}
+	_, err := StreamCommit(s, dir, "lint me", CommitOptions{})
+	var commitErr *CommitError
+	if !errors.As(err, &commitErr) || !strings.Contains(err.Error(), "trailing whitespace") {
+		t.Fatalf("err = %v", err)
+	}
+	if !strings.Contains(out.String(), "a.txt:1: trailing whitespace") {
+		t.Errorf("hook output should be streamed, got %q", out.String())
+	}
+
+	hash, err := StreamCommit(s, dir, "skip hooks", CommitOptions{NoVerify: true})
+	if err != nil || hash == "" {
+		t.Fatalf("no-verify commit: %q, %v", hash, err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%s")); got != "skip hooks" {
+		t.Errorf("subject = %q", got)
+	}
+}
+
+func TestStreamCommit_Cancel(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	installHook(t, dir, "pre-commit", "echo started\nsleep 1\ntouch hook-survived\n")
+	writeFile(t, dir, "a.txt", "b\n")
+	runGit(t, dir, "add", "a.txt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &lockedBuffer{}
+	go func() {
+		for !strings.Contains(out.String(), "started") {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+	start := time.Now()
+	_, err := StreamCommit(&Stream{Ctx: ctx, Out: out}, dir, "slow", CommitOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Error("cancel should not wait for the hook")
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%s")); got != "init" {
+		t.Errorf("cancelled commit landed: %q", got)
+	}
+	if runtime.GOOS != "windows" {
+		// The hook is killed with git rather than left running
+		time.Sleep(1500 * time.Millisecond)
+		if _, err := os.Stat(filepath.Join(dir, "hook-survived")); err == nil {
+			t.Error("the hook kept running after cancel")
+		}
+	}
+}
+
+func TestParseCommitHash_AfterHookOutput(t *testing.T) {
+	output := "lint ok\n[main (root-commit) 1a2b3c4] first\n 1 file changed\n"
+	if got := parseCommitHash(output); got != "1a2b3c4" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFindFileLocations(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"main.go": "package main\n", "pkg/util.py": "x\n"})
+	lines := []string{
+		"main.go:12:5: undefined: foo",
+		"./main.go:12:9: same line again",
+		"  File \"" + filepath.Join(dir, "pkg", "util.py") + "\", line 3, in <module>",
+		"missing.go:1: not in the repo",
+		"see https://example.com:8080/docs",
+		"ok",
+	}
+	locs := FindFileLocations(dir, lines)
+	if len(locs) != 2 {
+		t.Fatalf("locations = %+v", locs)
+	}
+	if l := locs[0]; l.Path != "main.go" || l.Line != 12 || l.Column != 5 || l.Output != 0 {
+		t.Errorf("first = %+v", l)
+	}
+	if l := locs[1]; l.Path != "pkg/util.py" || l.Line != 3 || l.Output != 2 {
+		t.Errorf("second = %+v", l)
+	}
+}
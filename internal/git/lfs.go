@@ -0,0 +1,195 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lfsPointerVersion is the first line of every Git LFS pointer file.
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// lfsPointerMaxDiffLines bounds the diff of two pointers, which are a few
+// short lines each, so ordinary diffs are not scanned for one.
+const lfsPointerMaxDiffLines = 16
+
+// LFSPointer is what Git LFS stores in the repository in place of a file's
+// content.
+type LFSPointer struct {
+	OID  string // "sha256:<hex>"
+	Size int64  // Size of the real content in bytes
+}
+
+// ShortOID returns the pointer's object ID without its hash prefix,
+// shortened for display.
+func (p *LFSPointer) ShortOID() string {
+	_, hex, _ := strings.Cut(p.OID, ":")
+	return hex[:min(12, len(hex))]
+}
+
+// LFSChange is a diff between two LFS pointers. Old is nil for a new
+// file, New for a deleted one.
+type LFSChange struct {
+	Old *LFSPointer
+	New *LFSPointer
+}
+
+// ParseLFSPointer parses the content of a pointer file. It returns nil
+// when text is not a pointer.
+func ParseLFSPointer(text string) *LFSPointer {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) < 3 || lines[0] != lfsPointerVersion {
+		return nil
+	}
+	ptr := &LFSPointer{Size: -1}
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil
+		}
+		switch key {
+		case "oid":
+			ptr.OID = value
+		case "size":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n < 0 {
+				return nil
+			}
+			ptr.Size = n
+		}
+	}
+	if ptr.OID == "" || ptr.Size < 0 {
+		return nil
+	}
+	return ptr
+}
+
+// parseLFSChange returns the pointer change a parsed diff shows, or nil
+// when either side is something other than a pointer or nothing.
+func parseLFSChange(parsed *ParsedDiff) *LFSChange {
+	if len(parsed.Hunks) != 1 || len(parsed.Hunks[0].Lines) > lfsPointerMaxDiffLines {
+		return nil
+	}
+	var oldText, newText strings.Builder
+	for _, line := range parsed.Hunks[0].Lines {
+		if !line.Type.IsAdd() {
+			oldText.WriteString(line.Content + "\n")
+		}
+		if !line.Type.IsRemove() {
+			newText.WriteString(line.Content + "\n")
+		}
+	}
+	change := &LFSChange{Old: ParseLFSPointer(oldText.String()), New: ParseLFSPointer(newText.String())}
+	oldEmpty := strings.TrimSpace(oldText.String()) == ""
+	newEmpty := strings.TrimSpace(newText.String()) == ""
+	if (change.Old == nil && !oldEmpty) || (change.New == nil && !newEmpty) ||
+		(change.Old == nil && change.New == nil) {
+		return nil
+	}
+	return change
+}
+
+// LFSPatterns returns the patterns the repository's root .gitattributes
+// stores in Git LFS.
+func LFSPatterns(workDir string) []string {
+	data, err := os.ReadFile(filepath.Join(workDir, ".gitattributes"))
+	if err != nil {
+		return nil
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			if attr == "filter=lfs" {
+				patterns = append(patterns, fields[0])
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// LFSTrackedPaths reports which of paths Git LFS stores, by their filter
+// attribute. Nested .gitattributes files are honoured, and the paths need
+// not exist yet.
+func LFSTrackedPaths(workDir string, paths []string) (map[string]bool, error) {
+	tracked := make(map[string]bool)
+	if len(paths) == 0 {
+		return tracked, nil
+	}
+	cmd := exec.Command("git", "check-attr", "-z", "--stdin", "filter")
+	cmd.Dir = workDir
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	// Output is path NUL attribute NUL value NUL for each path
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+2] == "lfs" {
+			tracked[fields[i]] = true
+		}
+	}
+	return tracked, nil
+}
+
+// LFSStatus describes how a repository uses Git LFS.
+type LFSStatus struct {
+	Installed bool     // git-lfs is available
+	Version   string   // First line of git lfs version, e.g. "git-lfs/3.4.0 (...)"
+	Patterns  []string // From the root .gitattributes
+	Files     int      // Files stored in LFS at HEAD
+	Missing   int      // Of those, files checked out as pointers
+}
+
+// InUse reports whether the repository stores anything in Git LFS.
+func (s *LFSStatus) InUse() bool {
+	return len(s.Patterns) > 0 || s.Files > 0
+}
+
+// GetLFSStatus reports whether git-lfs is installed and what the repository
+// stores in it. Without git-lfs only the patterns are known.
+func GetLFSStatus(workDir string) *LFSStatus {
+	status := &LFSStatus{Patterns: LFSPatterns(workDir)}
+	cmd := exec.Command("git", "lfs", "version")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return status
+	}
+	status.Installed = true
+	status.Version, _, _ = strings.Cut(strings.TrimSpace(string(output)), "\n")
+
+	cmd = exec.Command("git", "lfs", "ls-files")
+	cmd.Dir = workDir
+	if output, err = cmd.Output(); err == nil {
+		status.Files, status.Missing = parseLFSLsFiles(output)
+	}
+	return status
+}
+
+// parseLFSLsFiles counts the files in git lfs ls-files output, and those
+// whose content is not checked out: "<oid> * <path>" for a downloaded
+// file, "<oid> - <path>" for a pointer.
+func parseLFSLsFiles(output []byte) (files, missing int) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		files++
+		if fields[1] == "-" {
+			missing++
+		}
+	}
+	return files, missing
+}
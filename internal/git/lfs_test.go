@@ -0,0 +1,110 @@
+package git
+
+import (
+	"testing"
+)
+
+const (
+	testLFSOldOID = "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	testLFSNewOID = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	ptr := ParseLFSPointer(lfsPointerVersion + "\noid " + testLFSOldOID + "\nsize 12345\n")
+	if ptr == nil || ptr.OID != testLFSOldOID || ptr.Size != 12345 || ptr.ShortOID() != "4d7a214614ab" {
+		t.Fatalf("pointer = %+v", ptr)
+	}
+	for _, text := range []string{
+		"",
+		"hello\nworld\n",
+		lfsPointerVersion + "\noid " + testLFSOldOID + "\n",
+		lfsPointerVersion + "\noid " + testLFSOldOID + "\nsize big\n",
+	} {
+		if ptr := ParseLFSPointer(text); ptr != nil {
+			t.Errorf("%q parsed as %+v", text, ptr)
+		}
+	}
+}
+
+func TestParseUnifiedDiff_LFSPointer(t *testing.T) {
+	modified := `diff --git a/model.bin b/model.bin
+index 1111111..2222222 100644
+--- a/model.bin
++++ b/model.bin
+@@ -1,3 +1,3 @@
+ version https://git-lfs.github.com/spec/v1
+-oid ` + testLFSOldOID + `
+-size 1024
++oid ` + testLFSNewOID + `
++size 4096
+`
+	parsed, err := ParseUnifiedDiff(modified)
+	if err != nil || parsed.LFS == nil {
+		t.Fatalf("LFS = %v, err = %v", parsed.LFS, err)
+	}
+	if parsed.LFS.Old.OID != testLFSOldOID || parsed.LFS.Old.Size != 1024 ||
+		parsed.LFS.New.OID != testLFSNewOID || parsed.LFS.New.Size != 4096 {
+		t.Errorf("change = %+v -> %+v", parsed.LFS.Old, parsed.LFS.New)
+	}
+
+	added := `diff --git a/model.bin b/model.bin
+new file mode 100644
+--- /dev/null
++++ b/model.bin
+@@ -0,0 +1,3 @@
++version https://git-lfs.github.com/spec/v1
++oid ` + testLFSNewOID + `
++size 4096
+`
+	parsed, _ = ParseUnifiedDiff(added)
+	if parsed.LFS == nil || parsed.LFS.Old != nil || parsed.LFS.New.Size != 4096 {
+		t.Errorf("new file change = %+v", parsed.LFS)
+	}
+
+	text := `--- a/readme.md
++++ b/readme.md
+@@ -1,2 +1,2 @@
+ version https://git-lfs.github.com/spec/v1
+-old
++new
+`
+	if parsed, _ = ParseUnifiedDiff(text); parsed.LFS != nil {
+		t.Errorf("a text diff should not be read as pointers: %+v", parsed.LFS)
+	}
+}
+
+func TestLFSPatternsAndTrackedPaths(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{
+		".gitattributes":     "# assets\n*.psd filter=lfs diff=lfs merge=lfs -text\n*.md text\n",
+		"sub/.gitattributes": "*.bin filter=lfs diff=lfs merge=lfs -text\n",
+		"readme.md":          "hi\n",
+		"sub/data.bin":       "x\n",
+		"art/cover.psd":      "x\n",
+		"sub/notes.txt":      "x\n",
+	})
+	if got := LFSPatterns(dir); len(got) != 1 || got[0] != "*.psd" {
+		t.Errorf("patterns = %v", got)
+	}
+	tracked, err := LFSTrackedPaths(dir, []string{"readme.md", "sub/data.bin", "art/cover.psd", "sub/notes.txt", "new/logo.psd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracked) != 3 || !tracked["sub/data.bin"] || !tracked["art/cover.psd"] || !tracked["new/logo.psd"] {
+		t.Errorf("tracked = %v", tracked)
+	}
+}
+
+func TestGetLFSStatus_WithoutLFS(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if status := GetLFSStatus(dir); status.InUse() || len(status.Patterns) != 0 {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestParseLFSLsFiles(t *testing.T) {
+	output := "4d7a214614 * assets/logo.psd\n9f86d08188 - models/big model.bin\n\n"
+	files, missing := parseLFSLsFiles([]byte(output))
+	if files != 2 || missing != 1 {
+		t.Errorf("files = %d, missing = %d", files, missing)
+	}
+}
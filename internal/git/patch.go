@@ -0,0 +1,266 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PatchError represents a failed git apply.
+type PatchError struct {
+	Output string
+	Err    error
+}
+
+func (e *PatchError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	// ErrEmptySelection is returned when a selection contains no changed lines.
+	ErrEmptySelection = errors.New("selection contains no changes")
+	// ErrInvalidHunk is returned when a hunk index or line range is out of bounds.
+	ErrInvalidHunk = errors.New("invalid hunk selection")
+	// ErrNewlineSelection is returned when a line selection would separate a
+	// line from its end-of-file newline change.
+	ErrNewlineSelection = errors.New("selection must include the end-of-file newline change")
+	// ErrPartialFileCreation is returned for line selections in added or deleted files.
+	ErrPartialFileCreation = errors.New("line selection is not supported for added or deleted files")
+)
+
+// BuildHunkPatch returns a patch containing only the given hunk of diff.
+// Set reverse when the patch will be applied with -R (unstage or discard).
+func BuildHunkPatch(diff *ParsedDiff, hunkIdx int, reverse bool) (string, error) {
+	if diff == nil || hunkIdx < 0 || hunkIdx >= len(diff.Hunks) {
+		return "", ErrInvalidHunk
+	}
+	lines := hunkLines(&diff.Hunks[hunkIdx])
+	return buildPatch(diff, hunkIdx, 0, len(lines)-1, reverse)
+}
+
+// BuildLinePatch returns a patch for the changed lines in [startLine, endLine]
+// (inclusive indices into the hunk's Lines) of the given hunk. Unselected
+// changes are neutralized: for a forward patch, unselected additions are
+// dropped and unselected removals become context; for a reverse patch
+// (applied with -R), the opposite.
+func BuildLinePatch(diff *ParsedDiff, hunkIdx, startLine, endLine int, reverse bool) (string, error) {
+	if diff == nil || hunkIdx < 0 || hunkIdx >= len(diff.Hunks) {
+		return "", ErrInvalidHunk
+	}
+	if startLine > endLine {
+		startLine, endLine = endLine, startLine
+	}
+	lines := hunkLines(&diff.Hunks[hunkIdx])
+	if startLine < 0 || endLine >= len(lines) {
+		return "", ErrInvalidHunk
+	}
+	if startLine > 0 || endLine < len(lines)-1 {
+		if diff.OldFile == "/dev/null" || diff.NewFile == "/dev/null" {
+			return "", ErrPartialFileCreation
+		}
+	}
+	return buildPatch(diff, hunkIdx, startLine, endLine, reverse)
+}
+
+// hunkLines returns the hunk's lines bounded by its header counts. This
+// drops any trailing empty line picked up from a diff ending in a newline.
+func hunkLines(h *Hunk) []DiffLine {
+	oldSeen, newSeen := 0, 0
+	for i, l := range h.Lines {
+		if oldSeen >= h.OldCount && newSeen >= h.NewCount {
+			return h.Lines[:i]
+		}
+		switch l.Type {
+		case LineContext:
+			oldSeen++
+			newSeen++
+		case LineRemove, LineMovedRemove:
+			oldSeen++
+		case LineAdd, LineMovedAdd:
+			newSeen++
+		}
+	}
+	return h.Lines
+}
+
+// patchLine is a line in a generated patch.
+type patchLine struct {
+	prefix    byte
+	content   string
+	noNewline bool
+}
+
+// buildPatch renders a single-hunk patch keeping the changes in [start, end].
+func buildPatch(diff *ParsedDiff, hunkIdx, start, end int, reverse bool) (string, error) {
+	h := &diff.Hunks[hunkIdx]
+	lines := hunkLines(h)
+
+	var out []patchLine
+	changes := 0
+	oldCount, newCount := 0, 0
+	for i, l := range lines {
+		selected := i >= start && i <= end
+		switch l.Type {
+		case LineContext:
+			out = append(out, patchLine{' ', l.Content, l.NoNewline})
+			oldCount++
+			newCount++
+		case LineAdd, LineMovedAdd:
+			switch {
+			case selected:
+				out = append(out, patchLine{'+', l.Content, l.NoNewline})
+				newCount++
+				changes++
+			case reverse:
+				// Already present on the side being patched; keep as context
+				out = append(out, patchLine{' ', l.Content, l.NoNewline})
+				oldCount++
+				newCount++
+			}
+		case LineRemove, LineMovedRemove:
+			switch {
+			case selected:
+				out = append(out, patchLine{'-', l.Content, l.NoNewline})
+				oldCount++
+				changes++
+			case !reverse:
+				out = append(out, patchLine{' ', l.Content, l.NoNewline})
+				oldCount++
+				newCount++
+			}
+		}
+	}
+	if changes == 0 {
+		return "", ErrEmptySelection
+	}
+	if err := validateNoNewline(out); err != nil {
+		return "", err
+	}
+
+	oldStart, newStart := h.OldStart, h.NewStart
+	if oldCount == 0 && oldStart > 0 {
+		// Pure insertion: git expects the line before the insertion point
+		oldStart--
+	}
+	if newCount == 0 && newStart > 0 {
+		newStart--
+	}
+
+	var sb strings.Builder
+	writePatchHeader(&sb, diff)
+	fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@%s\n", oldStart, oldCount, newStart, newCount, h.Header)
+	for _, l := range out {
+		sb.WriteByte(l.prefix)
+		sb.WriteString(l.content)
+		sb.WriteByte('\n')
+		if l.noNewline {
+			sb.WriteString("\\ No newline at end of file\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// validateNoNewline ensures a missing-newline line is the last line on its
+// side of the patch; otherwise the selection can't be expressed.
+func validateNoNewline(lines []patchLine) error {
+	for i, l := range lines {
+		if !l.noNewline {
+			continue
+		}
+		for _, later := range lines[i+1:] {
+			onOld := later.prefix == ' ' || later.prefix == '-'
+			onNew := later.prefix == ' ' || later.prefix == '+'
+			if (l.prefix != '+' && onOld) || (l.prefix != '-' && onNew) {
+				return ErrNewlineSelection
+			}
+		}
+	}
+	return nil
+}
+
+// writePatchHeader writes the diff --git and ---/+++ headers for diff.
+func writePatchHeader(sb *strings.Builder, diff *ParsedDiff) {
+	oldName, newName := diff.OldFile, diff.NewFile
+	if oldName == "/dev/null" {
+		oldName = newName
+	}
+	if newName == "/dev/null" {
+		newName = oldName
+	}
+	fmt.Fprintf(sb, "diff --git a/%s b/%s\n", oldName, newName)
+	if diff.OldFile == "/dev/null" {
+		sb.WriteString("--- /dev/null\n")
+	} else {
+		fmt.Fprintf(sb, "--- a/%s\n", diff.OldFile)
+	}
+	if diff.NewFile == "/dev/null" {
+		sb.WriteString("+++ /dev/null\n")
+	} else {
+		fmt.Fprintf(sb, "+++ b/%s\n", diff.NewFile)
+	}
+}
+
+// ApplyPatch runs git apply with the patch on stdin. cached applies to the
+// index instead of the working tree; reverse applies the patch with -R.
+func ApplyPatch(workDir, patch string, cached, reverse bool) error {
+	args := []string{"apply", "--whitespace=nowarn"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	if reverse {
+		args = append(args, "-R")
+	}
+	args = append(args, "-")
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	cmd.Stdin = strings.NewReader(patch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &PatchError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// StageHunk stages one hunk (or line range within it) of an unstaged diff.
+// Pass startLine < 0 to stage the whole hunk.
+func StageHunk(workDir string, diff *ParsedDiff, hunkIdx, startLine, endLine int) error {
+	patch, err := selectionPatch(diff, hunkIdx, startLine, endLine, false)
+	if err != nil {
+		return err
+	}
+	return ApplyPatch(workDir, patch, true, false)
+}
+
+// UnstageHunk removes one hunk (or line range) of a staged diff from the index.
+// Pass startLine < 0 to unstage the whole hunk.
+func UnstageHunk(workDir string, diff *ParsedDiff, hunkIdx, startLine, endLine int) error {
+	patch, err := selectionPatch(diff, hunkIdx, startLine, endLine, true)
+	if err != nil {
+		return err
+	}
+	return ApplyPatch(workDir, patch, true, true)
+}
+
+// DiscardHunk reverts one hunk (or line range) of an unstaged diff in the
+// working tree. Pass startLine < 0 to discard the whole hunk.
+func DiscardHunk(workDir string, diff *ParsedDiff, hunkIdx, startLine, endLine int) error {
+	patch, err := selectionPatch(diff, hunkIdx, startLine, endLine, true)
+	if err != nil {
+		return err
+	}
+	return ApplyPatch(workDir, patch, false, true)
+}
+
+// selectionPatch builds a hunk patch, or a line patch when startLine >= 0.
+func selectionPatch(diff *ParsedDiff, hunkIdx, startLine, endLine int, reverse bool) (string, error) {
+	if startLine < 0 {
+		return BuildHunkPatch(diff, hunkIdx, reverse)
+	}
+	return BuildLinePatch(diff, hunkIdx, startLine, endLine, reverse)
+}
@@ -0,0 +1,352 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRepo creates a temp git repo with the given files committed.
+func newTestRepo(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "core.autocrlf", "false")
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@test")
+	for name, content := range files {
+		writeFile(t, dir, name, content)
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+	return dir
+}
+
+// runGit runs a git command in dir and returns its output, failing on error.
+func runGit(t testing.TB, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test",
+		"GIT_AUTHOR_EMAIL=test@test",
+		"GIT_COMMITTER_NAME=test",
+		"GIT_COMMITTER_EMAIL=test@test",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func writeFile(t testing.TB, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// parseGitDiff parses `git diff [args]` output for a single file.
+func parseGitDiff(t *testing.T, dir string, args ...string) *ParsedDiff {
+	t.Helper()
+	out := runGit(t, dir, append([]string{"diff", "--no-color"}, args...)...)
+	parsed, err := ParseUnifiedDiff(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+// indexContent returns the staged content of a file.
+func indexContent(t *testing.T, dir, name string) string {
+	t.Helper()
+	return runGit(t, dir, "show", ":"+name)
+}
+
+func numbered(n int) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		sb.WriteString("line ")
+		sb.WriteString(itoa(i))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func TestParseUnifiedDiff_NoNewline(t *testing.T) {
+	diff := `--- a/f.txt
++++ b/f.txt
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`
+	parsed, err := ParseUnifiedDiff(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := parsed.Hunks[0].Lines
+	if !lines[1].NoNewline {
+		t.Error("expected removed line to be marked NoNewline")
+	}
+	if lines[2].NoNewline {
+		t.Error("added line should not be marked NoNewline")
+	}
+}
+
+func TestBuildLinePatch_Forward(t *testing.T) {
+	diff := &ParsedDiff{
+		OldFile: "f.txt",
+		NewFile: "f.txt",
+		Hunks: []Hunk{{
+			OldStart: 1, OldCount: 3, NewStart: 1, NewCount: 3,
+			Lines: []DiffLine{
+				{Type: LineContext, Content: "a"},
+				{Type: LineRemove, Content: "b"},
+				{Type: LineRemove, Content: "c"},
+				{Type: LineAdd, Content: "B"},
+				{Type: LineAdd, Content: "C"},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		start, end int
+		reverse    bool
+		want       string
+	}{
+		{
+			name:  "first removal and first addition",
+			start: 1, end: 3,
+			want: "@@ -1,3 +1,2 @@\n a\n-b\n-c\n+B\n",
+		},
+		{
+			name:  "additions only",
+			start: 3, end: 4,
+			want: "@@ -1,3 +1,5 @@\n a\n b\n c\n+B\n+C\n",
+		},
+		{
+			name:  "reverse keeps unselected additions as context",
+			start: 1, end: 1, reverse: true,
+			want: "@@ -1,4 +1,3 @@\n a\n-b\n B\n C\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := BuildLinePatch(diff, 0, tt.start, tt.end, tt.reverse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, body, _ := strings.Cut(patch, "+++ b/f.txt\n")
+			if body != tt.want {
+				t.Errorf("patch body =\n%s\nwant\n%s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildLinePatch_Errors(t *testing.T) {
+	diff := &ParsedDiff{
+		OldFile: "/dev/null",
+		NewFile: "new.txt",
+		Hunks: []Hunk{{
+			OldStart: 0, OldCount: 0, NewStart: 1, NewCount: 2,
+			Lines: []DiffLine{{Type: LineAdd, Content: "a"}, {Type: LineAdd, Content: "b"}},
+		}},
+	}
+	if _, err := BuildLinePatch(diff, 0, 0, 0, false); !errors.Is(err, ErrPartialFileCreation) {
+		t.Errorf("expected ErrPartialFileCreation, got %v", err)
+	}
+	if _, err := BuildLinePatch(diff, 1, 0, 0, false); !errors.Is(err, ErrInvalidHunk) {
+		t.Errorf("expected ErrInvalidHunk, got %v", err)
+	}
+	if _, err := BuildHunkPatch(diff, 0, false); err != nil {
+		t.Errorf("whole-hunk patch for new file should succeed: %v", err)
+	}
+
+	ctxOnly := &ParsedDiff{OldFile: "f", NewFile: "f", Hunks: []Hunk{{
+		OldStart: 1, OldCount: 2, NewStart: 1, NewCount: 2,
+		Lines: []DiffLine{{Type: LineContext, Content: "a"}, {Type: LineContext, Content: "b"}},
+	}}}
+	if _, err := BuildLinePatch(ctxOnly, 0, 0, 1, false); !errors.Is(err, ErrEmptySelection) {
+		t.Errorf("expected ErrEmptySelection, got %v", err)
+	}
+}
+
+func TestStageHunk_AdjacentHunks(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": numbered(20)})
+
+	// Changes 8 lines apart produce two hunks whose context nearly touches
+	modified := strings.Replace(numbered(20), "line 3\n", "line three\n", 1)
+	modified = strings.Replace(modified, "line 11\n", "line eleven\n", 1)
+	writeFile(t, dir, "f.txt", modified)
+
+	diff := parseGitDiff(t, dir, "--", "f.txt")
+	if len(diff.Hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(diff.Hunks))
+	}
+
+	if err := StageHunk(dir, diff, 1, -1, -1); err != nil {
+		t.Fatalf("StageHunk: %v", err)
+	}
+	staged := indexContent(t, dir, "f.txt")
+	if !strings.Contains(staged, "line eleven\n") || strings.Contains(staged, "line three") {
+		t.Errorf("expected only second hunk staged, index:\n%s", staged)
+	}
+
+	// The remaining unstaged hunk still applies cleanly
+	diff = parseGitDiff(t, dir, "--", "f.txt")
+	if len(diff.Hunks) != 1 {
+		t.Fatalf("expected 1 unstaged hunk, got %d", len(diff.Hunks))
+	}
+	if err := StageHunk(dir, diff, 0, -1, -1); err != nil {
+		t.Fatalf("StageHunk: %v", err)
+	}
+	if got := indexContent(t, dir, "f.txt"); got != modified {
+		t.Errorf("index content mismatch:\n%s", got)
+	}
+}
+
+func TestStageHunk_PartialLines(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\nb\nc\nd\n"})
+	writeFile(t, dir, "f.txt", "a\nB\nC\nd\ne\n")
+
+	diff := parseGitDiff(t, dir, "--", "f.txt")
+	// Lines: a, -b, -c, +B, +C, d, +e
+	if err := StageHunk(dir, diff, 0, 2, 3); err != nil {
+		t.Fatalf("StageHunk lines: %v", err)
+	}
+	if got := indexContent(t, dir, "f.txt"); got != "a\nb\nB\nd\n" {
+		t.Errorf("index = %q, want %q", got, "a\nb\nB\nd\n")
+	}
+	if got := readFile(t, dir, "f.txt"); got != "a\nB\nC\nd\ne\n" {
+		t.Errorf("working tree modified: %q", got)
+	}
+}
+
+func TestUnstageHunk_PartialLines(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\nb\nc\n"})
+	writeFile(t, dir, "f.txt", "a\nx\nb\ny\nc\n")
+	runGit(t, dir, "add", "f.txt")
+
+	diff := parseGitDiff(t, dir, "--cached", "--", "f.txt")
+	// Lines: a, +x, b, +y, c - unstage only +y
+	if err := UnstageHunk(dir, diff, 0, 3, 3); err != nil {
+		t.Fatalf("UnstageHunk: %v", err)
+	}
+	if got := indexContent(t, dir, "f.txt"); got != "a\nx\nb\nc\n" {
+		t.Errorf("index = %q", got)
+	}
+}
+
+func TestDiscardHunk(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": numbered(20)})
+	modified := strings.Replace(numbered(20), "line 2\n", "line two\n", 1)
+	modified = strings.Replace(modified, "line 18\n", "line eighteen\n", 1)
+	writeFile(t, dir, "f.txt", modified)
+
+	diff := parseGitDiff(t, dir, "--", "f.txt")
+	if len(diff.Hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(diff.Hunks))
+	}
+	if err := DiscardHunk(dir, diff, 0, -1, -1); err != nil {
+		t.Fatalf("DiscardHunk: %v", err)
+	}
+	want := strings.Replace(numbered(20), "line 18\n", "line eighteen\n", 1)
+	if got := readFile(t, dir, "f.txt"); got != want {
+		t.Errorf("working tree =\n%s", got)
+	}
+}
+
+func TestStageHunk_NoNewlineAtEOF(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		modified string
+	}{
+		{"both sides missing newline", "a\nb", "a\nc"},
+		{"newline added at EOF", "a\nb", "a\nb\n"},
+		{"newline removed at EOF", "a\nb\n", "a\nb"},
+		{"append after missing newline", "a\nb", "a\nb\nc\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newTestRepo(t, map[string]string{"f.txt": tt.original})
+			writeFile(t, dir, "f.txt", tt.modified)
+
+			diff := parseGitDiff(t, dir, "--", "f.txt")
+			if err := StageHunk(dir, diff, 0, -1, -1); err != nil {
+				t.Fatalf("StageHunk: %v", err)
+			}
+			if got := indexContent(t, dir, "f.txt"); got != tt.modified {
+				t.Errorf("index = %q, want %q", got, tt.modified)
+			}
+
+			staged := parseGitDiff(t, dir, "--cached", "--", "f.txt")
+			if err := UnstageHunk(dir, staged, 0, -1, -1); err != nil {
+				t.Fatalf("UnstageHunk: %v", err)
+			}
+			if got := indexContent(t, dir, "f.txt"); got != tt.original {
+				t.Errorf("index after unstage = %q, want %q", got, tt.original)
+			}
+		})
+	}
+}
+
+func TestStageLines_NoNewlineAtEOF(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\nb"})
+	writeFile(t, dir, "f.txt", "x\na\nc")
+
+	diff := parseGitDiff(t, dir, "--", "f.txt")
+	// Lines: +x, a, -b (no newline), +c (no newline)
+	if err := StageHunk(dir, diff, 0, 0, 0); err != nil {
+		t.Fatalf("StageHunk first line: %v", err)
+	}
+	if got := indexContent(t, dir, "f.txt"); got != "x\na\nb" {
+		t.Errorf("index = %q, want %q", got, "x\na\nb")
+	}
+
+	// Adding a line after the unterminated last line without removing it
+	// can't be expressed as a patch
+	diff = parseGitDiff(t, dir, "--", "f.txt")
+	addIdx := -1
+	for i, l := range diff.Hunks[0].Lines {
+		if l.Type == LineAdd {
+			addIdx = i
+		}
+	}
+	if _, err := BuildLinePatch(diff, 0, addIdx, addIdx, false); !errors.Is(err, ErrNewlineSelection) {
+		t.Errorf("expected ErrNewlineSelection, got %v", err)
+	}
+}
+
+func TestApplyPatch_Error(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	err := ApplyPatch(dir, "--- a/f.txt\n+++ b/f.txt\n@@ -1 +1 @@\n-zzz\n+y\n", true, false)
+	var patchErr *PatchError
+	if !errors.As(err, &patchErr) {
+		t.Fatalf("expected *PatchError, got %v", err)
+	}
+	if patchErr.Error() == "" {
+		t.Error("expected git apply output in error")
+	}
+}
@@ -0,0 +1,437 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrEmptyPatch is returned when there is nothing to export, or a patch to
+// apply changes no files.
+var ErrEmptyPatch = errors.New("patch contains no changes")
+
+var (
+	// mboxFromRegex matches the separator line format-patch starts each message with.
+	mboxFromRegex = regexp.MustCompile(`^From [0-9a-f]{40} `)
+	// subjectTagRegex matches the "[PATCH 1/2]" prefix of a patch subject.
+	subjectTagRegex = regexp.MustCompile(`^\[[^\]]*\]\s*`)
+)
+
+// FormatPatches returns the commits as a mailbox in git format-patch
+// format, ready for git am. hashes are given oldest first.
+func FormatPatches(workDir string, hashes []string) (string, error) {
+	var sb strings.Builder
+	for _, hash := range hashes {
+		cmd := exec.Command("git", "format-patch", "--stdout", "-1", hash)
+		cmd.Dir = workDir
+		output, err := cmd.Output()
+		if err != nil {
+			return "", &PatchError{Output: stderrOf(err), Err: err}
+		}
+		sb.Write(output)
+	}
+	if sb.Len() == 0 {
+		return "", ErrEmptyPatch
+	}
+	return sb.String(), nil
+}
+
+// StagedPatch returns the staged changes as a patch for git apply.
+func StagedPatch(workDir string) (string, error) {
+	return diffPatch(workDir, nil)
+}
+
+// WorktreePatch returns every uncommitted change in the worktree at dir,
+// untracked files included, as a patch against HEAD. The changes are
+// collected in a scratch index so the real one is left alone.
+func WorktreePatch(dir string) (string, error) {
+	index, cleanup, err := scratchIndex(dir)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	env := append(os.Environ(), "GIT_INDEX_FILE="+index)
+
+	cmd := exec.Command("git", "add", "-A")
+	cmd.Dir = dir
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", &PatchError{Output: string(output), Err: err}
+	}
+	return diffPatch(dir, env)
+}
+
+// diffPatch returns the difference between HEAD and the index as a patch
+// with binary changes included.
+func diffPatch(workDir string, env []string) (string, error) {
+	cmd := exec.Command("git", "diff", "--cached", "--binary", "--no-color", "--no-ext-diff")
+	cmd.Dir = workDir
+	cmd.Env = env
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &PatchError{Output: stderrOf(err), Err: err}
+	}
+	if len(output) == 0 {
+		return "", ErrEmptyPatch
+	}
+	return string(output), nil
+}
+
+// scratchIndex copies the index of the worktree at workDir to a temporary
+// file. cleanup removes it.
+func scratchIndex(workDir string) (path string, cleanup func(), err error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "index")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", nil, &PatchError{Output: stderrOf(err), Err: err}
+	}
+	tmp, err := os.MkdirTemp("", "sidecar-index-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { _ = os.RemoveAll(tmp) }
+	path = filepath.Join(tmp, "index")
+
+	// A repository without commits may have no index yet; git treats a
+	// missing file as an empty index
+	data, err := os.ReadFile(gitPathAbs(workDir, string(output)))
+	if err == nil {
+		err = os.WriteFile(path, data, 0600)
+	} else if os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// PatchApplyState describes how a file in a patch would apply.
+type PatchApplyState int
+
+const (
+	PatchApplies   PatchApplyState = iota // Applies cleanly
+	PatchMerges                           // Applies with a clean 3-way merge
+	PatchConflicts                        // A 3-way merge leaves conflicts
+	PatchFails                            // Does not apply
+)
+
+// PatchPreview describes what applying a patch would change.
+type PatchPreview struct {
+	Mailbox bool           // A format-patch mailbox, applied with git am
+	Commits []*PatchCommit // A single entry without a subject for a plain diff
+}
+
+// PatchCommit is one message of a mailbox, or the whole of a plain diff.
+type PatchCommit struct {
+	Subject string
+	Author  string
+	Files   []*PatchFile
+}
+
+// PatchFile is one file changed by a patch.
+type PatchFile struct {
+	Path      string
+	OldPath   string // Set for renames
+	New       bool
+	Deleted   bool
+	Binary    bool
+	Additions int
+	Deletions int
+	Hunks     []PatchHunk
+	State     PatchApplyState
+	Reason    string // Why the file does not apply cleanly
+
+	patch string      // This file's section of the patch
+	diff  *ParsedDiff // Nil when the section could not be parsed
+}
+
+// PatchHunk is one hunk of a file in a patch.
+type PatchHunk struct {
+	Header    string // "@@ -12,4 +12,6 @@ func name"
+	Additions int
+	Deletions int
+	Err       string // Why the hunk does not apply on its own; empty when it does
+}
+
+// Files returns the number of file changes across all commits.
+func (p *PatchPreview) Files() int {
+	n := 0
+	for _, c := range p.Commits {
+		n += len(c.Files)
+	}
+	return n
+}
+
+// Failures returns the number of file changes that would not apply
+// without conflicts.
+func (p *PatchPreview) Failures() int {
+	n := 0
+	for _, c := range p.Commits {
+		for _, f := range c.Files {
+			if f.State == PatchConflicts || f.State == PatchFails {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// PreviewPatch parses a mailbox or plain diff and checks each file and hunk
+// against the index of workDir. Mailbox commits are checked in order on a
+// scratch index, so later commits see the changes of earlier ones.
+func PreviewPatch(workDir, patch string) (*PatchPreview, error) {
+	preview := parsePatch(patch)
+	if preview.Files() == 0 {
+		return nil, ErrEmptyPatch
+	}
+
+	index, cleanup, err := scratchIndex(workDir)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	env := append(os.Environ(), "GIT_INDEX_FILE="+index)
+
+	for _, c := range preview.Commits {
+		for _, f := range c.Files {
+			checkPatchFile(workDir, env, f)
+			switch f.State {
+			case PatchApplies:
+				_, _ = runApply(workDir, env, f.patch, "--cached")
+			case PatchMerges:
+				_, _ = runApply(workDir, env, f.patch, "--cached", "--3way")
+			}
+		}
+	}
+	return preview, nil
+}
+
+// checkPatchFile sets how f applies to the scratch index. When it does not
+// apply cleanly, each hunk of a modified file is also tried on its own to
+// find the ones at fault.
+func checkPatchFile(workDir string, env []string, f *PatchFile) {
+	output, err := runApply(workDir, env, f.patch, "--cached", "--check")
+	if err == nil {
+		f.State = PatchApplies
+		return
+	}
+	f.Reason = applyFailure(output, f.Path)
+
+	output, err = runApply(workDir, env, f.patch, "--cached", "--check", "--3way")
+	switch {
+	case err != nil:
+		f.State = PatchFails
+	case strings.Contains(output, "with conflicts"):
+		f.State = PatchConflicts
+	default:
+		f.State = PatchMerges
+	}
+
+	// Single-hunk patches lack the headers git needs for new, deleted and
+	// renamed files, so those are only checked as a whole
+	if f.diff == nil || f.New || f.Deleted || f.OldPath != "" {
+		return
+	}
+	for i := range f.Hunks {
+		hunkPatch, err := BuildHunkPatch(f.diff, i, false)
+		if err != nil {
+			continue
+		}
+		if _, err := runApply(workDir, env, hunkPatch, "--cached", "--check"); err != nil {
+			h := &f.diff.Hunks[i]
+			f.Hunks[i].Err = fmt.Sprintf("lines %d-%d no longer match", h.OldStart, h.OldStart+max(h.OldCount, 1)-1)
+		}
+	}
+}
+
+// runApply runs git apply with the patch on stdin and returns its output.
+func runApply(workDir string, env []string, patch string, args ...string) (string, error) {
+	args = append(append([]string{"apply", "--whitespace=nowarn"}, args...), "-")
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	cmd.Env = env
+	cmd.Stdin = strings.NewReader(patch)
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+// applyFailure returns the reason in git apply's last error line, without
+// the "error: <path>: " prefix.
+func applyFailure(output, path string) string {
+	reason := "does not apply"
+	for _, line := range strings.Split(output, "\n") {
+		if msg, ok := strings.CutPrefix(line, "error: "); ok {
+			reason = strings.TrimPrefix(msg, path+": ")
+		}
+	}
+	return reason
+}
+
+// ImportPatch applies a patch to workDir. A mailbox is applied with
+// git am --3way, creating its commits; when any of them fails the whole
+// series is aborted. A plain diff is applied with git apply --3way, which
+// may leave conflicts to resolve.
+func ImportPatch(workDir, patch string) error {
+	if isMailbox(patch) {
+		cmd := exec.Command("git", "am", "--3way")
+		cmd.Dir = workDir
+		cmd.Stdin = strings.NewReader(patch)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			abort := exec.Command("git", "am", "--abort")
+			abort.Dir = workDir
+			_ = abort.Run()
+			return &PatchError{Output: string(output), Err: err}
+		}
+		return nil
+	}
+	output, err := runApply(workDir, nil, patch, "--3way")
+	if err != nil {
+		return &PatchError{Output: output, Err: err}
+	}
+	return nil
+}
+
+// isMailbox reports whether patch starts with a format-patch message.
+func isMailbox(patch string) bool {
+	first, _, _ := strings.Cut(strings.TrimLeft(patch, "\n"), "\n")
+	return mboxFromRegex.MatchString(first)
+}
+
+// parsePatch splits a mailbox into its messages, or treats patch as a
+// single plain diff, and parses the files each one changes.
+func parsePatch(patch string) *PatchPreview {
+	preview := &PatchPreview{Mailbox: isMailbox(patch)}
+	if !preview.Mailbox {
+		preview.Commits = []*PatchCommit{{Files: parsePatchFiles(patch)}}
+		return preview
+	}
+
+	var messages []string
+	var current strings.Builder
+	for _, line := range strings.SplitAfter(patch, "\n") {
+		if mboxFromRegex.MatchString(line) && current.Len() > 0 {
+			messages = append(messages, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		messages = append(messages, current.String())
+	}
+	for _, m := range messages {
+		preview.Commits = append(preview.Commits, parsePatchMessage(m))
+	}
+	return preview
+}
+
+// parsePatchMessage parses one format-patch message.
+func parsePatchMessage(message string) *PatchCommit {
+	c := &PatchCommit{}
+	headers, body, _ := strings.Cut(message, "\n\n")
+	var last *string
+	for _, line := range strings.Split(headers, "\n") {
+		switch {
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			// Folded continuation of the previous header
+			if last != nil {
+				*last += " " + strings.TrimSpace(line)
+			}
+			continue
+		case strings.HasPrefix(line, "Subject: "):
+			c.Subject = strings.TrimPrefix(line, "Subject: ")
+			last = &c.Subject
+		case strings.HasPrefix(line, "From: "):
+			c.Author = strings.TrimPrefix(line, "From: ")
+			last = &c.Author
+		default:
+			last = nil
+		}
+	}
+	c.Subject = subjectTagRegex.ReplaceAllString(c.Subject, "")
+
+	// The diff ends at the "-- " line format-patch adds before its version
+	if i := strings.Index(body, "\n-- \n"); i >= 0 {
+		body = body[:i+1]
+	}
+	c.Files = parsePatchFiles(body)
+	return c
+}
+
+// parsePatchFiles parses each file section of a git diff, ignoring any
+// text before the first one.
+func parsePatchFiles(diff string) []*PatchFile {
+	if !strings.HasPrefix(diff, "diff --git ") {
+		i := strings.Index(diff, "\ndiff --git ")
+		if i < 0 {
+			return nil
+		}
+		diff = diff[i+1:]
+	}
+
+	var files []*PatchFile
+	for _, section := range splitIntoFileDiffs(diff) {
+		// splitIntoFileDiffs leaves an extra newline on the last section
+		section = strings.TrimRight(section, "\n") + "\n"
+		files = append(files, parsePatchFile(section))
+	}
+	return files
+}
+
+// parsePatchFile reads the paths, kind of change and hunks of one file
+// section of a git diff.
+func parsePatchFile(section string) *PatchFile {
+	f := &PatchFile{patch: section}
+	header, _, _ := strings.Cut(section, "\n")
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		f.Path = header[i+3:]
+	}
+	for _, line := range strings.Split(section, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "new file mode"):
+			f.New = true
+		case strings.HasPrefix(line, "deleted file mode"):
+			f.Deleted = true
+		case strings.HasPrefix(line, "rename from "):
+			f.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			f.Path = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "+++ b/"):
+			f.Path = strings.TrimPrefix(line, "+++ b/")
+		case line == "GIT binary patch" || strings.HasPrefix(line, "Binary files "):
+			f.Binary = true
+		}
+	}
+
+	diff, err := ParseUnifiedDiff(section)
+	if err != nil || f.Binary {
+		return f
+	}
+	f.diff = diff
+	for i := range diff.Hunks {
+		h := &diff.Hunks[i]
+		ph := PatchHunk{Header: fmt.Sprintf("@@ -%d,%d +%d,%d @@%s", h.OldStart, h.OldCount, h.NewStart, h.NewCount, h.Header)}
+		for _, l := range hunkLines(h) {
+			switch {
+			case l.Type.IsAdd():
+				ph.Additions++
+			case l.Type.IsRemove():
+				ph.Deletions++
+			}
+		}
+		f.Additions += ph.Additions
+		f.Deletions += ph.Deletions
+		f.Hunks = append(f.Hunks, ph)
+	}
+	return f
+}
@@ -0,0 +1,152 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns lines "1".."n", with line i replaced by repl[i].
+func numberedLines(n int, repl map[int]string) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		if r, ok := repl[i]; ok {
+			sb.WriteString(r + "\n")
+		} else {
+			sb.WriteString(itoa(i) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func TestFormatPatchesRoundTrip(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	other := t.TempDir()
+	runGit(t, other, "clone", "-q", dir, ".")
+	runGit(t, other, "config", "user.name", "test")
+	runGit(t, other, "config", "user.email", "test@test")
+
+	commitFiles(t, dir, "add b\n\nWith a body.", map[string]string{"b.txt": "b\n"})
+	commitFiles(t, dir, "change a", map[string]string{"a.txt": "a\nmore\n"})
+	hashes := strings.Fields(runGit(t, dir, "rev-list", "--reverse", "HEAD~2..HEAD"))
+
+	patch, err := FormatPatches(dir, hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := PreviewPatch(other, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.Mailbox || len(preview.Commits) != 2 {
+		t.Fatalf("preview = %+v", preview)
+	}
+	first, second := preview.Commits[0], preview.Commits[1]
+	if first.Subject != "add b" || !strings.HasPrefix(first.Author, "test") {
+		t.Errorf("first commit = %q by %q", first.Subject, first.Author)
+	}
+	if len(first.Files) != 1 || first.Files[0].Path != "b.txt" || !first.Files[0].New || first.Files[0].State != PatchApplies {
+		t.Errorf("first files = %+v", first.Files[0])
+	}
+	if f := second.Files[0]; f.Path != "a.txt" || f.Additions != 1 || f.Deletions != 0 || len(f.Hunks) != 1 {
+		t.Errorf("second file = %+v", f)
+	}
+	if preview.Failures() != 0 {
+		t.Errorf("failures = %d", preview.Failures())
+	}
+
+	if err := ImportPatch(other, patch); err != nil {
+		t.Fatal(err)
+	}
+	if got := logSubjects(t, other); strings.Join(got[:2], ",") != "change a,add b" {
+		t.Errorf("subjects = %q", got)
+	}
+}
+
+func TestStagedAndWorktreePatch(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "b.txt": "b\n"})
+	if _, err := StagedPatch(dir); !errors.Is(err, ErrEmptyPatch) {
+		t.Errorf("clean repo: err = %v, want ErrEmptyPatch", err)
+	}
+
+	writeFile(t, dir, "a.txt", "a2\n")
+	runGit(t, dir, "add", "a.txt")
+	writeFile(t, dir, "b.txt", "b2\n")
+	writeFile(t, dir, "new.txt", "new\n")
+
+	staged, err := StagedPatch(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(staged, "+a2") || strings.Contains(staged, "b.txt") {
+		t.Errorf("staged patch:\n%s", staged)
+	}
+
+	worktree, err := WorktreePatch(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"+a2", "+b2", "new file mode", "+new"} {
+		if !strings.Contains(worktree, want) {
+			t.Errorf("worktree patch lacks %q:\n%s", want, worktree)
+		}
+	}
+	if status := runGit(t, dir, "status", "--porcelain"); !strings.Contains(status, "?? new.txt") || !strings.Contains(status, " M b.txt") {
+		t.Errorf("the real index should be untouched:\n%s", status)
+	}
+
+	// The worktree patch recreates the changes on a clean checkout
+	runGit(t, dir, "stash", "-q", "-u")
+	if err := ImportPatch(dir, worktree); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := WorktreePatch(dir); again != worktree {
+		t.Errorf("applied patch differs:\n%s", again)
+	}
+}
+
+func TestPreviewPatchReportsFailingHunk(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": numberedLines(20, nil)})
+	writeFile(t, dir, "a.txt", numberedLines(20, map[int]string{2: "two", 18: "eighteen"}))
+	patch, err := WorktreePatch(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "checkout", "--", "a.txt")
+	commitFiles(t, dir, "conflict", map[string]string{"a.txt": numberedLines(20, map[int]string{2: "TWO"})})
+
+	preview, err := PreviewPatch(dir, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Mailbox || len(preview.Commits) != 1 || preview.Commits[0].Subject != "" {
+		t.Fatalf("preview = %+v", preview)
+	}
+	f := preview.Commits[0].Files[0]
+	if f.State != PatchConflicts || f.Reason != "patch does not apply" || preview.Failures() != 1 {
+		t.Errorf("file = %+v", f)
+	}
+	if len(f.Hunks) != 2 || f.Hunks[0].Err != "lines 1-5 no longer match" || f.Hunks[1].Err != "" {
+		t.Errorf("hunks = %+v", f.Hunks)
+	}
+
+	// Without the original blobs a 3-way merge is not possible
+	unrelated := newTestRepo(t, map[string]string{"a.txt": numberedLines(20, map[int]string{2: "TWO"})})
+	preview, err = PreviewPatch(unrelated, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := preview.Commits[0].Files[0]; f.State != PatchFails {
+		t.Errorf("unrelated repo: state = %v", f.State)
+	}
+
+	err = ImportPatch(dir, patch)
+	var pe *PatchError
+	if !errors.As(err, &pe) || !strings.Contains(pe.Output, "conflicts") {
+		t.Errorf("err = %v, want a conflicted 3-way apply", err)
+	}
+	if _, err := PreviewPatch(dir, "not a patch\n"); !errors.Is(err, ErrEmptyPatch) {
+		t.Errorf("err = %v, want ErrEmptyPatch", err)
+	}
+}
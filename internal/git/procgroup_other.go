@@ -0,0 +1,9 @@
+//go:build !unix
+
+package git
+
+import "os/exec"
+
+// killGroupOnCancel leaves cmd's default cancellation, which kills git
+// only: without process groups a running hook is left to finish.
+func killGroupOnCancel(cmd *exec.Cmd) {}
@@ -25,66 +25,101 @@ type PushStatus struct {
 // Always returns a valid PushStatus (may be minimal if operations fail).
 func GetPushStatus(workDir string) *PushStatus {
 	status := &PushStatus{}
-
-	// Check if HEAD is detached
-	branchCmd := exec.Command("git", "branch", "--show-current")
-	branchCmd.Dir = workDir
-	branchOutput, err := branchCmd.Output()
-	if err != nil {
-		return status // Return empty status on error
-	}
-	status.CurrentBranch = strings.TrimSpace(string(branchOutput))
-	status.DetachedHead = status.CurrentBranch == ""
-
-	if status.DetachedHead {
-		return status // No push status for detached HEAD
+	if !readCurrentBranch(workDir, status) {
+		return status
 	}
 
-	// Get upstream branch name
-	upstreamCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "@{upstream}")
-	upstreamCmd.Dir = workDir
-	upstreamOutput, err := upstreamCmd.Output()
+	// Get the upstream and ahead/behind counts in one call
+	// Track format: "ahead X, behind Y", either part alone, "" when in sync,
+	// or "gone" when the upstream branch was deleted
+	trackCmd := exec.Command("git", "for-each-ref", "--count=1",
+		"--format=%(upstream:short)%00%(upstream:track,nobracket)", "refs/heads/"+status.CurrentBranch)
+	trackCmd.Dir = workDir
+	trackOutput, err := trackCmd.Output()
 	if err != nil {
+		return status
+	}
+	upstream, track, _ := strings.Cut(strings.TrimSpace(string(trackOutput)), "\x00")
+	if upstream == "" || track == "gone" {
 		// No upstream configured - this is not an error, just means
 		// the branch has never been pushed or has no tracking branch
-		status.HasUpstream = false
 		return status
 	}
 	status.HasUpstream = true
-	status.UpstreamBranch = strings.TrimSpace(string(upstreamOutput))
-
-	// Get ahead/behind counts
-	// Format: "X\tY" where X is behind, Y is ahead
-	countCmd := exec.Command("git", "rev-list", "--count", "--left-right", "@{upstream}...HEAD")
-	countCmd.Dir = workDir
-	countOutput, err := countCmd.Output()
-	if err == nil {
-		parts := strings.Split(strings.TrimSpace(string(countOutput)), "\t")
-		if len(parts) == 2 {
-			status.Behind, _ = strconv.Atoi(parts[0])
-			status.Ahead, _ = strconv.Atoi(parts[1])
+	status.UpstreamBranch = upstream
+	for _, part := range strings.Split(track, ", ") {
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			status.Ahead, _ = strconv.Atoi(n)
+		} else if n, ok := strings.CutPrefix(part, "behind "); ok {
+			status.Behind, _ = strconv.Atoi(n)
 		}
 	}
 
 	// Get unpushed commit hashes if we're ahead
 	if status.Ahead > 0 {
-		// Use upstream..HEAD to get commits that are in HEAD but not in upstream
-		logCmd := exec.Command("git", "log", "@{upstream}..HEAD", "--format=%H")
-		logCmd.Dir = workDir
-		logOutput, err := logCmd.Output()
-		if err == nil {
-			hashes := strings.Split(strings.TrimSpace(string(logOutput)), "\n")
-			for _, hash := range hashes {
-				if hash != "" {
-					status.UnpushedHashes = append(status.UnpushedHashes, hash)
-				}
-			}
-		}
+		status.UnpushedHashes = unpushedHashes(workDir, "@{upstream}")
 	}
 
 	return status
 }
 
+// GetPushStatusFor is GetPushStatus measured against ref, a remote-tracking
+// branch such as "upstream/main", instead of the configured upstream.
+// HasUpstream reports whether ref exists.
+func GetPushStatusFor(workDir, ref string) *PushStatus {
+	status := &PushStatus{}
+	if !readCurrentBranch(workDir, status) {
+		return status
+	}
+	ahead, behind, err := AheadBehind(workDir, "refs/remotes/"+ref)
+	if err != nil {
+		return status
+	}
+	status.HasUpstream = true
+	status.UpstreamBranch = ref
+	status.Ahead = ahead
+	status.Behind = behind
+	if ahead > 0 {
+		status.UnpushedHashes = unpushedHashes(workDir, "refs/remotes/"+ref)
+	}
+	return status
+}
+
+// readCurrentBranch fills in the branch fields of status. It returns false
+// when HEAD is detached or the branch can't be read.
+func readCurrentBranch(workDir string, status *PushStatus) bool {
+	// Check if HEAD is detached; symbolic-ref -q exits 1 when it is
+	branchCmd := exec.Command("git", "symbolic-ref", "-q", "--short", "HEAD")
+	branchCmd.Dir = workDir
+	branchOutput, err := branchCmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			status.DetachedHead = true
+		}
+		return false
+	}
+	status.CurrentBranch = strings.TrimSpace(string(branchOutput))
+	return true
+}
+
+// unpushedHashes lists the commits in HEAD but not in base, newest first.
+func unpushedHashes(workDir, base string) []string {
+	logCmd := exec.Command("git", "log", base+"..HEAD", "--format=%H")
+	logCmd.Dir = workDir
+	logOutput, err := logCmd.Output()
+	if err != nil {
+		return nil
+	}
+	var hashes []string
+	for _, hash := range strings.Split(strings.TrimSpace(string(logOutput)), "\n") {
+		if hash != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
 // IsCommitPushed checks if a commit hash is pushed to the upstream.
 // Returns true if the commit is in the upstream branch.
 // Hash can be either full (40 chars) or short (7+ chars).
@@ -104,25 +139,91 @@ func (ps *PushStatus) IsCommitPushed(hash string) bool {
 // ExecutePush performs a git push operation.
 // Returns the output from git and any error encountered.
 func ExecutePush(workDir string, force bool) (string, error) {
-	args := []string{"push"}
-	if force {
-		args = append(args, "--force-with-lease")
+	// For new branches, set upstream automatically
+	return StreamPush(nil, workDir, PushOptions{Force: force, SetUpstream: true})
+}
+
+// ExecutePushTo pushes HEAD to branch on remote. With setUpstream, the
+// current branch starts tracking remote/branch.
+func ExecutePushTo(workDir, remote, branch string, force, setUpstream bool) (string, error) {
+	if remote == "" {
+		return "", &PushError{Output: "No remote configured", Err: errors.New("no remote configured")}
 	}
+	if branch == "" {
+		return "", &PushError{Output: "Branch name is required", Err: errors.New("no branch")}
+	}
+	return StreamPush(nil, workDir, PushOptions{Remote: remote, Branch: branch, Force: force, SetUpstream: setUpstream})
+}
 
-	// For new branches, set upstream automatically
-	remote := GetRemoteName(workDir)
+// PushOptions controls what StreamPush pushes.
+type PushOptions struct {
+	Remote      string // Defaults to the primary remote
+	Branch      string // Remote branch to push HEAD to; empty pushes HEAD to its own name
+	Force       bool   // Force with lease
+	SetUpstream bool   // Track the pushed branch
+}
+
+// StreamPush pushes HEAD as opts describe, streaming the output of git and
+// the pre-push hook to s when s is not nil. A cancelled push returns the
+// context's error.
+func StreamPush(s *Stream, workDir string, opts PushOptions) (string, error) {
+	remote := opts.Remote
+	if remote == "" {
+		remote = GetRemoteName(workDir)
+	}
 	if remote == "" {
 		return "", &PushError{Output: "No remote configured", Err: errors.New("no remote configured")}
 	}
-	args = append(args, "-u", remote, "HEAD")
+	args := []string{"push"}
+	if opts.Force {
+		args = append(args, "--force-with-lease")
+	}
+	if opts.SetUpstream {
+		args = append(args, "-u")
+	}
+	target := "HEAD"
+	if opts.Branch != "" {
+		target = "HEAD:refs/heads/" + opts.Branch
+	}
+	args = append(args, "--", remote, target)
+
+	output, err := runStreamed(s, workDir, args...)
+	if err != nil {
+		if s != nil && s.Ctx != nil && err == s.Ctx.Err() {
+			return output, err
+		}
+		return output, &PushError{Output: output, Err: err}
+	}
+	return output, nil
+}
+
+// GetPushTarget returns where the current branch pushes by default: the
+// first of branch.<name>.pushRemote, remote.pushDefault, the upstream's
+// remote and the primary remote, and the branch of the same name.
+func GetPushTarget(workDir string) (remote, branch string) {
+	status := &PushStatus{}
+	if !readCurrentBranch(workDir, status) {
+		return GetRemoteName(workDir), ""
+	}
+	branch = status.CurrentBranch
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault", "branch." + branch + ".remote"} {
+		// "." is the local repository, used by branches tracking a local branch
+		if remote = gitConfig(workDir, key); remote != "" && remote != "." {
+			return remote, branch
+		}
+	}
+	return GetRemoteName(workDir), branch
+}
 
-	cmd := exec.Command("git", args...)
+// gitConfig returns the value of a git config key, or "" if unset.
+func gitConfig(workDir, key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
 	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
+	output, err := cmd.Output()
 	if err != nil {
-		return string(output), &PushError{Output: string(output), Err: err}
+		return ""
 	}
-	return string(output), nil
+	return strings.TrimSpace(string(output))
 }
 
 // PushError wraps a git push error with its output.
@@ -135,6 +236,10 @@ func (e *PushError) Error() string {
 	return strings.TrimSpace(e.Output)
 }
 
+func (e *PushError) Unwrap() error {
+	return e.Err
+}
+
 // IsPushRejectedError returns true if the push failed because the remote
 // contains commits not present locally (non-fast-forward rejection).
 func IsPushRejectedError(err error) bool {
@@ -151,14 +256,8 @@ func IsPushRejectedError(err error) bool {
 // GetRemoteName returns the primary remote name (usually "origin").
 // Returns empty string if no remotes are configured.
 func GetRemoteName(workDir string) string {
-	cmd := exec.Command("git", "remote")
-	cmd.Dir = workDir
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-	remotes := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(remotes) == 0 || remotes[0] == "" {
+	remotes := GetRemoteNames(workDir)
+	if len(remotes) == 0 {
 		return ""
 	}
 	// Prefer "origin" if it exists
@@ -170,6 +269,24 @@ func GetRemoteName(workDir string) string {
 	return remotes[0]
 }
 
+// GetRemoteNames returns the names of the configured remotes in git's
+// order.
+func GetRemoteNames(workDir string) []string {
+	cmd := exec.Command("git", "remote")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var remotes []string
+	for _, r := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if r != "" {
+			remotes = append(remotes, r)
+		}
+	}
+	return remotes
+}
+
 // HasRemote checks if any remote is configured for the repository.
 func HasRemote(workDir string) bool {
 	return GetRemoteName(workDir) != ""
@@ -212,28 +329,12 @@ func (ps *PushStatus) CanPush() bool {
 // ExecutePushForce performs a force push with lease.
 // Returns the output from git and any error encountered.
 func ExecutePushForce(workDir string) (string, error) {
-	remote := GetRemoteName(workDir)
-	if remote == "" {
-		return "", &PushError{Output: "No remote configured", Err: errors.New("no remote configured")}
-	}
-
-	cmd := exec.Command("git", "push", "--force-with-lease", remote, "HEAD")
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return string(output), &PushError{Output: string(output), Err: err}
-	}
-	return string(output), nil
+	return StreamPush(nil, workDir, PushOptions{Force: true})
 }
 
 // ExecutePushSetUpstream performs a push with upstream tracking.
 // Returns the output from git and any error encountered.
 func ExecutePushSetUpstream(workDir string) (string, error) {
-	remote := GetRemoteName(workDir)
-	if remote == "" {
-		return "", &PushError{Output: "No remote configured", Err: errors.New("no remote configured")}
-	}
-
 	// Get current branch
 	branchCmd := exec.Command("git", "branch", "--show-current")
 	branchCmd.Dir = workDir
@@ -245,14 +346,7 @@ func ExecutePushSetUpstream(workDir string) (string, error) {
 	if branch == "" {
 		return "", &PushError{Output: "Detached HEAD - cannot push", Err: errors.New("detached head")}
 	}
-
-	cmd := exec.Command("git", "push", "-u", remote, branch)
-	cmd.Dir = workDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return string(output), &PushError{Output: string(output), Err: err}
-	}
-	return string(output), nil
+	return StreamPush(nil, workDir, PushOptions{Branch: branch, SetUpstream: true})
 }
 
 // ParsePushOutput extracts useful information from git push output.
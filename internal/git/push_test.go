@@ -1,6 +1,7 @@
 package git
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -345,3 +346,42 @@ func TestParsePushOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPushStatus(t *testing.T) {
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	branch := strings.TrimSpace(runGit(t, dir, "branch", "--show-current"))
+
+	ps := GetPushStatus(dir)
+	if ps.CurrentBranch != branch || ps.HasUpstream || ps.DetachedHead {
+		t.Errorf("no upstream: %+v", ps)
+	}
+
+	runGit(t, dir, "remote", "add", "origin", remote)
+	runGit(t, dir, "push", "-q", "-u", "origin", "HEAD")
+	ps = GetPushStatus(dir)
+	if !ps.HasUpstream || ps.UpstreamBranch != "origin/"+branch || ps.Ahead != 0 || ps.Behind != 0 {
+		t.Errorf("in sync: %+v", ps)
+	}
+
+	commitFiles(t, dir, "local", map[string]string{"b.txt": "b\n"})
+	local := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "reset", "-q", "--hard", "HEAD~1")
+	commitFiles(t, dir, "other", map[string]string{"c.txt": "c\n"})
+	runGit(t, dir, "update-ref", "refs/remotes/origin/"+branch, local)
+	ps = GetPushStatus(dir)
+	if ps.Ahead != 1 || ps.Behind != 1 || len(ps.UnpushedHashes) != 1 {
+		t.Errorf("diverged: %+v", ps)
+	}
+
+	runGit(t, dir, "update-ref", "-d", "refs/remotes/origin/"+branch)
+	if ps = GetPushStatus(dir); ps.HasUpstream {
+		t.Errorf("deleted upstream should count as none: %+v", ps)
+	}
+
+	runGit(t, dir, "checkout", "-q", "--detach")
+	if ps = GetPushStatus(dir); !ps.DetachedHead || ps.CurrentBranch != "" {
+		t.Errorf("detached: %+v", ps)
+	}
+}
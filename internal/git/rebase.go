@@ -0,0 +1,279 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RebaseAction is a git rebase todo command.
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+)
+
+// RebaseActions lists the actions in the order they cycle in the editor.
+var RebaseActions = []RebaseAction{RebasePick, RebaseReword, RebaseEdit, RebaseSquash, RebaseFixup, RebaseDrop}
+
+// rebaseScratchDir is the directory (under the git dir) holding the todo
+// and reword messages for a sidecar-driven rebase.
+const rebaseScratchDir = "sidecar-rebase"
+
+var (
+	// ErrRebaseMerges is returned when the rebase range contains merge commits.
+	ErrRebaseMerges = errors.New("range contains merge commits; interactive rebase would flatten them")
+	// ErrRebaseEmpty is returned when there are no commits to rebase.
+	ErrRebaseEmpty = errors.New("no commits to rebase")
+	// ErrRebaseSquashFirst is returned when the first commit is squashed or
+	// fixed up, leaving nothing to meld into.
+	ErrRebaseSquashFirst = errors.New("cannot squash or fixup the first commit")
+	// ErrRebaseNoCommits is returned when every commit is dropped.
+	ErrRebaseNoCommits = errors.New("every commit is dropped")
+)
+
+// RebaseTodoItem is one line of an interactive rebase todo.
+type RebaseTodoItem struct {
+	Action    RebaseAction
+	Hash      string
+	ShortHash string
+	Subject   string
+	Message   string // New message for reword; empty keeps the original
+}
+
+// RebaseResult describes the state after a rebase command returns.
+type RebaseResult struct {
+	Stopped   bool     // Rebase paused for edit, conflicts or a failed exec
+	Conflicts []string // Conflicted files when stopped on conflicts
+	Output    string
+}
+
+// RebaseProgress describes an in-progress interactive rebase.
+type RebaseProgress struct {
+	Step        int    // Todo lines done (1-based)
+	Total       int    // Total todo lines
+	HeadName    string // Branch being rebased (refs/heads/...)
+	StoppedHash string // Commit the rebase stopped at, if any
+	Conflicts   []string
+}
+
+// RebaseError wraps a git rebase error with its output.
+type RebaseError struct {
+	Output string
+	Err    error
+}
+
+func (e *RebaseError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *RebaseError) Unwrap() error {
+	return e.Err
+}
+
+// GetRebaseCommits returns the commits in base..HEAD, oldest first, as pick
+// items. An empty base lists every commit reachable from HEAD (--root).
+func GetRebaseCommits(workDir, base string) ([]RebaseTodoItem, error) {
+	rangeArg := "HEAD"
+	if base != "" {
+		rangeArg = base + "..HEAD"
+	}
+	cmd := exec.Command("git", "log", "--reverse", "--format=%H%x00%h%x00%P%x00%s", rangeArg)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &RebaseError{Output: string(output), Err: err}
+	}
+
+	var items []RebaseTodoItem
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		if len(strings.Fields(parts[2])) > 1 {
+			return nil, ErrRebaseMerges
+		}
+		items = append(items, RebaseTodoItem{
+			Action:    RebasePick,
+			Hash:      parts[0],
+			ShortHash: parts[1],
+			Subject:   parts[3],
+		})
+	}
+	if len(items) == 0 {
+		return nil, ErrRebaseEmpty
+	}
+	return items, nil
+}
+
+// ValidateRebaseTodo checks that items form a todo git will accept.
+func ValidateRebaseTodo(items []RebaseTodoItem) error {
+	kept := 0
+	for _, item := range items {
+		switch item.Action {
+		case RebaseDrop:
+			continue
+		case RebaseSquash, RebaseFixup:
+			if kept == 0 {
+				return ErrRebaseSquashFirst
+			}
+		case RebasePick, RebaseReword, RebaseEdit:
+		default:
+			return fmt.Errorf("unknown rebase action %q", item.Action)
+		}
+		kept++
+	}
+	if kept == 0 {
+		return ErrRebaseNoCommits
+	}
+	return nil
+}
+
+// FormatRebaseTodo renders items as a git-rebase-todo file. Rewords with a
+// new message become a pick followed by an exec that amends the message from
+// a file in msgDir; each message file is returned keyed by name.
+func FormatRebaseTodo(items []RebaseTodoItem, msgDir string) (string, map[string]string) {
+	var sb strings.Builder
+	messages := make(map[string]string)
+	for i, item := range items {
+		if item.Action == RebaseReword && item.Message != "" {
+			name := "msg-" + strconv.Itoa(i)
+			messages[name] = item.Message
+			fmt.Fprintf(&sb, "pick %s %s\n", item.Hash, item.Subject)
+			fmt.Fprintf(&sb, "exec git commit --amend --allow-empty --quiet -F %s\n", shellQuote(filepath.Join(msgDir, name)))
+			continue
+		}
+		fmt.Fprintf(&sb, "%s %s %s\n", item.Action, item.Hash, item.Subject)
+	}
+	return sb.String(), messages
+}
+
+// StartInteractiveRebase runs git rebase -i onto base with the given todo.
+// An empty base rebases from the root commit.
+func StartInteractiveRebase(workDir, base string, items []RebaseTodoItem) (*RebaseResult, error) {
+	if err := ValidateRebaseTodo(items); err != nil {
+		return nil, err
+	}
+
+	dir, err := rebaseScratchPath(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	todo, messages := FormatRebaseTodo(items, dir)
+	for name, msg := range messages {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(msg), 0644); err != nil {
+			return nil, err
+		}
+	}
+	todoPath := filepath.Join(dir, "todo")
+	if err := os.WriteFile(todoPath, []byte(todo), 0644); err != nil {
+		return nil, err
+	}
+
+	args := []string{"rebase", "-i"}
+	if base == "" {
+		args = append(args, "--root")
+	} else {
+		args = append(args, base)
+	}
+	return runRebase(workDir, args, "GIT_SEQUENCE_EDITOR=cp "+shellQuote(todoPath))
+}
+
+// ContinueRebase runs git rebase --continue, keeping default commit messages.
+func ContinueRebase(workDir string) (*RebaseResult, error) {
+	return runRebase(workDir, []string{"rebase", "--continue"})
+}
+
+// SkipRebase runs git rebase --skip.
+func SkipRebase(workDir string) (*RebaseResult, error) {
+	return runRebase(workDir, []string{"rebase", "--skip"})
+}
+
+// runRebase runs a rebase command non-interactively. A command that leaves
+// the rebase in progress is reported as stopped rather than as an error.
+func runRebase(workDir string, args []string, env ...string) (*RebaseResult, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	cmd.Env = append(cmd.Env, env...)
+	output, err := cmd.CombinedOutput()
+
+	if IsRebaseInProgress(workDir) {
+		return &RebaseResult{
+			Stopped:   true,
+			Conflicts: GetConflictedFiles(workDir),
+			Output:    string(output),
+		}, nil
+	}
+	removeRebaseScratch(workDir)
+	if err != nil {
+		return nil, &RebaseError{Output: string(output), Err: err}
+	}
+	return &RebaseResult{Output: string(output)}, nil
+}
+
+// GetRebaseProgress returns the state of an in-progress interactive rebase,
+// or nil if none is running.
+func GetRebaseProgress(workDir string) *RebaseProgress {
+	if !IsRebaseInProgress(workDir) {
+		return nil
+	}
+	progress := &RebaseProgress{Conflicts: GetConflictedFiles(workDir)}
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", "rebase-merge")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return progress
+	}
+	dir := gitPathAbs(workDir, string(output))
+	read := func(name string) string {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		return strings.TrimSpace(string(data))
+	}
+	progress.Step, _ = strconv.Atoi(read("msgnum"))
+	progress.Total, _ = strconv.Atoi(read("end"))
+	progress.HeadName = read("head-name")
+	progress.StoppedHash = read("stopped-sha")
+	return progress
+}
+
+// rebaseScratchPath returns the absolute path of the sidecar rebase dir.
+func rebaseScratchPath(workDir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", rebaseScratchDir)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &RebaseError{Output: string(output), Err: err}
+	}
+	return gitPathAbs(workDir, string(output)), nil
+}
+
+// removeRebaseScratch deletes the sidecar rebase dir once a rebase ends.
+func removeRebaseScratch(workDir string) {
+	if dir, err := rebaseScratchPath(workDir); err == nil {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// shellQuote quotes s for POSIX sh, which git uses to run editors and exec lines.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
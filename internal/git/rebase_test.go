@@ -0,0 +1,225 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// commitFiles writes each file and commits it with the given message.
+func commitFiles(t testing.TB, dir, message string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		writeFile(t, dir, name, content)
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", message)
+}
+
+// logSubjects returns commit subjects, newest first.
+func logSubjects(t *testing.T, dir string) []string {
+	t.Helper()
+	out := runGit(t, dir, "log", "--format=%s")
+	return strings.Split(strings.TrimSpace(out), "\n")
+}
+
+// newRebaseRepo creates a repo with commits base, one, two, three.
+func newRebaseRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir := newTestRepo(t, map[string]string{"base.txt": "base\n"})
+	base := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	commitFiles(t, dir, "one", map[string]string{"one.txt": "1\n"})
+	commitFiles(t, dir, "two", map[string]string{"two.txt": "2\n"})
+	commitFiles(t, dir, "three", map[string]string{"three.txt": "3\n"})
+	return dir, base
+}
+
+func TestValidateRebaseTodo(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []RebaseAction
+		wantErr error
+	}{
+		{"all picks", []RebaseAction{RebasePick, RebasePick}, nil},
+		{"squash into previous", []RebaseAction{RebasePick, RebaseSquash}, nil},
+		{"squash first", []RebaseAction{RebaseSquash, RebasePick}, ErrRebaseSquashFirst},
+		{"fixup after dropped first", []RebaseAction{RebaseDrop, RebaseFixup}, ErrRebaseSquashFirst},
+		{"all dropped", []RebaseAction{RebaseDrop, RebaseDrop}, ErrRebaseNoCommits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []RebaseTodoItem
+			for _, a := range tt.actions {
+				items = append(items, RebaseTodoItem{Action: a, Hash: "abc"})
+			}
+			if err := ValidateRebaseTodo(items); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateRebaseTodo() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatRebaseTodo(t *testing.T) {
+	items := []RebaseTodoItem{
+		{Action: RebasePick, Hash: "aaa", Subject: "one"},
+		{Action: RebaseReword, Hash: "bbb", Subject: "two", Message: "Two, reworded"},
+		{Action: RebaseFixup, Hash: "ccc", Subject: "three"},
+	}
+	todo, messages := FormatRebaseTodo(items, "/tmp/it's")
+	want := "pick aaa one\n" +
+		"pick bbb two\n" +
+		"exec git commit --amend --allow-empty --quiet -F '/tmp/it'\\''s/msg-1'\n" +
+		"fixup ccc three\n"
+	if todo != want {
+		t.Errorf("todo =\n%s\nwant\n%s", todo, want)
+	}
+	if messages["msg-1"] != "Two, reworded" {
+		t.Errorf("unexpected messages %v", messages)
+	}
+}
+
+func TestGetRebaseCommits(t *testing.T) {
+	dir, base := newRebaseRepo(t)
+	items, err := GetRebaseCommits(dir, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var subjects []string
+	for _, item := range items {
+		subjects = append(subjects, item.Subject)
+		if item.Action != RebasePick {
+			t.Errorf("expected pick, got %s", item.Action)
+		}
+	}
+	if strings.Join(subjects, ",") != "one,two,three" {
+		t.Errorf("subjects = %v, want oldest first", subjects)
+	}
+
+	all, err := GetRebaseCommits(dir, "")
+	if err != nil || len(all) != 4 {
+		t.Errorf("root range: %d items, err %v", len(all), err)
+	}
+}
+
+func TestStartInteractiveRebase_ReorderSquashRewordDrop(t *testing.T) {
+	dir, base := newRebaseRepo(t)
+	items, err := GetRebaseCommits(dir, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	one, two, three := items[0], items[1], items[2]
+
+	one.Action = RebaseReword
+	one.Message = "first (reworded)\n\nwith body"
+	three.Action = RebaseFixup
+	two.Action = RebaseDrop
+	// three is fixed up into one, two is dropped
+	result, err := StartInteractiveRebase(dir, base, []RebaseTodoItem{one, three, two})
+	if err != nil {
+		t.Fatalf("StartInteractiveRebase: %v", err)
+	}
+	if result.Stopped {
+		t.Fatalf("unexpected stop: %s", result.Output)
+	}
+
+	if got := logSubjects(t, dir); strings.Join(got, ",") != "first (reworded),init" {
+		t.Errorf("log = %v", got)
+	}
+	files := runGit(t, dir, "show", "--name-only", "--format=", "HEAD")
+	if !strings.Contains(files, "three.txt") || strings.Contains(files, "two.txt") {
+		t.Errorf("unexpected files in squashed commit: %s", files)
+	}
+	if body := runGit(t, dir, "log", "-1", "--format=%b"); !strings.Contains(body, "with body") {
+		t.Errorf("reword body lost: %q", body)
+	}
+	if dir, _ := rebaseScratchPath(dir); dirExists(dir) {
+		t.Error("scratch dir should be removed after rebase")
+	}
+}
+
+func TestStartInteractiveRebase_EditStopContinue(t *testing.T) {
+	dir, base := newRebaseRepo(t)
+	items, _ := GetRebaseCommits(dir, base)
+	items[1].Action = RebaseEdit
+
+	result, err := StartInteractiveRebase(dir, base, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Stopped || len(result.Conflicts) != 0 {
+		t.Fatalf("expected clean stop for edit, got %+v", result)
+	}
+	progress := GetRebaseProgress(dir)
+	if progress == nil || progress.Step != 2 || progress.Total != 3 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+
+	result, err = ContinueRebase(dir)
+	if err != nil || result.Stopped {
+		t.Fatalf("continue: %+v %v", result, err)
+	}
+	if GetRebaseProgress(dir) != nil {
+		t.Error("expected rebase to be finished")
+	}
+}
+
+func TestStartInteractiveRebase_ConflictSkipAbort(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"f.txt": "a\n"})
+	base := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	commitFiles(t, dir, "one", map[string]string{"f.txt": "b\n"})
+	commitFiles(t, dir, "two", map[string]string{"f.txt": "c\n"})
+
+	items, _ := GetRebaseCommits(dir, base)
+	// Swapping edits to the same line conflicts
+	result, err := StartInteractiveRebase(dir, base, []RebaseTodoItem{items[1], items[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Stopped || len(result.Conflicts) != 1 || result.Conflicts[0] != "f.txt" {
+		t.Fatalf("expected conflict stop on f.txt, got %+v", result)
+	}
+
+	result, err = SkipRebase(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stopped {
+		// Applying the remaining commit conflicts too; abort restores the original
+		if err := AbortRebase(dir); err != nil {
+			t.Fatal(err)
+		}
+		if got := logSubjects(t, dir); strings.Join(got, ",") != "two,one,init" {
+			t.Errorf("abort should restore history, got %v", got)
+		}
+	}
+	if GetRebaseProgress(dir) != nil {
+		t.Error("expected no rebase in progress")
+	}
+}
+
+func TestStartInteractiveRebase_Invalid(t *testing.T) {
+	dir, base := newRebaseRepo(t)
+	items, _ := GetRebaseCommits(dir, base)
+	items[0].Action = RebaseSquash
+	if _, err := StartInteractiveRebase(dir, base, items); !errors.Is(err, ErrRebaseSquashFirst) {
+		t.Errorf("expected ErrRebaseSquashFirst, got %v", err)
+	}
+}
+
+func TestGetRebaseCommits_Merges(t *testing.T) {
+	dir, base := newRebaseRepo(t)
+	runGit(t, dir, "checkout", "-q", "-b", "side", base)
+	commitFiles(t, dir, "side", map[string]string{"side.txt": "s\n"})
+	runGit(t, dir, "checkout", "-q", "-")
+	runGit(t, dir, "merge", "-q", "--no-edit", "side")
+
+	if _, err := GetRebaseCommits(dir, base); !errors.Is(err, ErrRebaseMerges) {
+		t.Errorf("expected ErrRebaseMerges, got %v", err)
+	}
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,130 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReflogEntry is one movement of a ref, newest first.
+type ReflogEntry struct {
+	Hash     string    // Commit the ref pointed to after the movement
+	Selector string    // e.g. "HEAD@{2}" or "main@{0}"
+	Action   string    // Operation that moved the ref, e.g. "commit (amend)" or "reset"
+	Message  string    // Rest of the reflog message, e.g. "moving to HEAD~1"
+	Date     time.Time // When the ref moved
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (e *ReflogEntry) ShortHash() string {
+	if len(e.Hash) > 7 {
+		return e.Hash[:7]
+	}
+	return e.Hash
+}
+
+// ReflogError wraps a git reflog error with its output.
+type ReflogError struct {
+	Output string
+	Err    error
+}
+
+func (e *ReflogError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *ReflogError) Unwrap() error {
+	return e.Err
+}
+
+// GetReflogRefs returns the refs whose reflog can be browsed: HEAD, then
+// local branches by name.
+func GetReflogRefs(workDir string) []string {
+	refs := []string{"HEAD"}
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return refs
+	}
+	return append(refs, splitNonEmptyLines(string(output))...)
+}
+
+// GetReflog returns up to limit reflog entries for ref, newest first.
+func GetReflog(workDir, ref string, limit int) ([]*ReflogEntry, error) {
+	// With --date=unix, %gd renders as ref@{<timestamp>}
+	cmd := exec.Command("git", "reflog", "show", "--date=unix", "--format=%H%x00%gd%x00%gs",
+		"-n", strconv.Itoa(limit), ref, "--")
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &ReflogError{Output: string(output), Err: err}
+	}
+	var entries []*ReflogEntry
+	for _, line := range splitNonEmptyLines(string(output)) {
+		f := strings.SplitN(line, "\x00", 3)
+		if len(f) < 3 {
+			continue
+		}
+		entry := &ReflogEntry{
+			Hash:     f[0],
+			Selector: fmt.Sprintf("%s@{%d}", ref, len(entries)),
+		}
+		if open := strings.LastIndex(f[1], "@{"); open >= 0 {
+			entry.Date = parseUnix(strings.TrimSuffix(f[1][open+2:], "}"))
+		}
+		entry.Action, entry.Message = parseReflogSubject(f[2])
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseReflogSubject splits "reset: moving to HEAD~1" into its action and
+// message. Subjects without an action prefix are returned as the message.
+func parseReflogSubject(subject string) (action, message string) {
+	action, message, ok := strings.Cut(subject, ": ")
+	if !ok {
+		return "", subject
+	}
+	return action, message
+}
+
+// ReflogPreview describes the tree at a reflog entry relative to HEAD.
+type ReflogPreview struct {
+	Subject string // Subject of the entry's commit
+	Author  string // Author of the entry's commit
+	Date    time.Time
+	Changes []string // "<status>\t<path>" for files that differ from HEAD
+}
+
+// GetReflogPreview describes the commit at hash and the files that differ
+// between HEAD and its tree.
+func GetReflogPreview(workDir, hash string) (*ReflogPreview, error) {
+	commit, err := ObjectReaderFor(workDir).ReadCommit(hash)
+	if err != nil {
+		return nil, &ReflogError{Output: err.Error(), Err: err}
+	}
+	preview := &ReflogPreview{Subject: commit.Subject(), Author: commit.Author.Name, Date: commit.Author.When}
+
+	cmd := exec.Command("git", "diff", "--name-status", "HEAD", hash)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &ReflogError{Output: string(output), Err: err}
+	}
+	preview.Changes = splitNonEmptyLines(string(output))
+	return preview, nil
+}
+
+// CreateBranchAt creates a branch pointing at start without checking it out.
+func CreateBranchAt(workDir, branchName, start string) error {
+	cmd := exec.Command("git", "branch", "--", branchName, start)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &BranchError{Output: string(output), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetReflog(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	runGit(t, dir, "reset", "-q", "--hard", "HEAD~1")
+
+	entries, err := GetReflog(dir, "HEAD", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("got %d entries, want 5", len(entries))
+	}
+	top := entries[0]
+	if top.Selector != "HEAD@{0}" || top.Action != "reset" || top.Message != "moving to HEAD~1" {
+		t.Errorf("top entry = %+v", top)
+	}
+	if top.Date.IsZero() {
+		t.Error("entry date should be parsed")
+	}
+	if entries[1].Action != "commit" || entries[1].Message != "three" || entries[1].Selector != "HEAD@{1}" {
+		t.Errorf("second entry = %+v", entries[1])
+	}
+
+	limited, err := GetReflog(dir, "HEAD", 2)
+	if err != nil || len(limited) != 2 {
+		t.Errorf("limit 2 returned %d entries, err=%v", len(limited), err)
+	}
+	if _, err := GetReflog(dir, "missing", 10); err == nil {
+		t.Error("unknown ref should fail")
+	}
+}
+
+func TestGetReflogRefs(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	runGit(t, dir, "branch", "feature")
+	refs := GetReflogRefs(dir)
+	if len(refs) != 3 || refs[0] != "HEAD" || refs[1] != "feature" {
+		t.Errorf("refs = %v", refs)
+	}
+}
+
+func TestGetReflogPreviewAndBranch(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	lost := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "reset", "-q", "--hard", "HEAD~1")
+
+	preview, err := GetReflogPreview(dir, lost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Subject != "three" || preview.Author != "test" {
+		t.Errorf("preview = %+v", preview)
+	}
+	if len(preview.Changes) != 1 || preview.Changes[0] != "A\tthree.txt" {
+		t.Errorf("changes = %v", preview.Changes)
+	}
+
+	if err := CreateBranchAt(dir, "rescue", lost); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "rev-parse", "rescue")); got != lost {
+		t.Errorf("rescue = %s, want %s", got, lost)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "branch", "--show-current")); got == "rescue" {
+		t.Error("CreateBranchAt should not check out the branch")
+	}
+}
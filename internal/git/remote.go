@@ -3,6 +3,7 @@ package git
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -17,6 +18,27 @@ func ExecuteFetch(workDir string) (string, error) {
 	return string(output), nil
 }
 
+// FetchRemote fetches remote, or every remote when remote is empty. With
+// prune, remote-tracking branches deleted on the remote are removed.
+func FetchRemote(workDir, remote string, prune bool) (string, error) {
+	args := []string{"fetch"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	if remote == "" {
+		args = append(args, "--all")
+	} else {
+		args = append(args, "--", remote)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &RemoteError{Output: string(output), Err: err}
+	}
+	return string(output), nil
+}
+
 // ExecutePull runs git pull.
 func ExecutePull(workDir string) (string, error) {
 	cmd := exec.Command("git", "pull")
@@ -79,16 +101,26 @@ func GetConflictedFiles(workDir string) []string {
 	return files
 }
 
-// IsConflictError checks if a RemoteError indicates merge/rebase conflicts.
+// IsConflictError checks if a RemoteError, CherryPickError or RevertError
+// indicates merge/rebase conflicts.
 func IsConflictError(err error) bool {
-	if re, ok := err.(*RemoteError); ok {
-		out := strings.ToLower(re.Output)
-		return strings.Contains(out, "conflict") ||
-			strings.Contains(out, "merge conflict") ||
-			strings.Contains(out, "automatic merge failed") ||
-			strings.Contains(out, "could not apply")
-	}
-	return false
+	var output string
+	switch e := err.(type) {
+	case *RemoteError:
+		output = e.Output
+	case *CherryPickError:
+		output = e.Output
+	case *RevertError:
+		output = e.Output
+	default:
+		return false
+	}
+	out := strings.ToLower(output)
+	return strings.Contains(out, "conflict") ||
+		strings.Contains(out, "merge conflict") ||
+		strings.Contains(out, "automatic merge failed") ||
+		strings.Contains(out, "could not apply") ||
+		strings.Contains(out, "could not revert")
 }
 
 // AbortMerge runs git merge --abort.
@@ -104,6 +136,9 @@ func AbortRebase(workDir string) error {
 	cmd := exec.Command("git", "rebase", "--abort")
 	cmd.Dir = workDir
 	_, err := cmd.CombinedOutput()
+	if err == nil {
+		removeRebaseScratch(workDir)
+	}
 	return err
 }
 
@@ -115,7 +150,7 @@ func IsRebaseInProgress(workDir string) bool {
 	if err != nil {
 		return false
 	}
-	path := strings.TrimSpace(string(output))
+	path := gitPathAbs(workDir, string(output))
 	if _, err := os.Stat(path); err == nil {
 		return true
 	}
@@ -125,11 +160,21 @@ func IsRebaseInProgress(workDir string) bool {
 	if err != nil {
 		return false
 	}
-	path = strings.TrimSpace(string(output))
+	path = gitPathAbs(workDir, string(output))
 	_, err = os.Stat(path)
 	return err == nil
 }
 
+// gitPathAbs resolves `git rev-parse --git-path` output, which is relative
+// to workDir unless the git dir lives elsewhere.
+func gitPathAbs(workDir, output string) string {
+	path := strings.TrimSpace(output)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	return path
+}
+
 // RemoteError wraps a git remote operation error with its output.
 type RemoteError struct {
 	Output string
@@ -139,3 +184,7 @@ type RemoteError struct {
 func (e *RemoteError) Error() string {
 	return strings.TrimSpace(e.Output)
 }
+
+func (e *RemoteError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,202 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Remote describes a configured remote and how the current branch compares
+// to it.
+type Remote struct {
+	Name     string
+	FetchURL string
+	PushURL  string // Same as FetchURL unless a push URL is configured
+	Tracking int    // Local branches whose upstream is on this remote
+
+	// Branch is the remote-tracking branch HEAD is compared against: the
+	// upstream when it lives on this remote, otherwise the branch of the
+	// same name. Empty when neither exists.
+	Branch string
+	Ahead  int
+	Behind int
+}
+
+// GetRemotes lists the configured remotes in git's order with their URLs
+// and tracking state for the current branch.
+func GetRemotes(workDir string) ([]*Remote, error) {
+	cmd := exec.Command("git", "remote", "-v")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &RemoteError{Output: stderrOf(err), Err: err}
+	}
+
+	var remotes []*Remote
+	byName := make(map[string]*Remote)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		// <name>\t<url> (fetch|push)
+		name, rest, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		url, kind, _ := strings.Cut(rest, " ")
+		r := byName[name]
+		if r == nil {
+			r = &Remote{Name: name}
+			byName[name] = r
+			remotes = append(remotes, r)
+		}
+		if kind == "(push)" {
+			r.PushURL = url
+		} else {
+			r.FetchURL = url
+		}
+	}
+	if len(remotes) == 0 {
+		return nil, nil
+	}
+
+	// Count tracking branches and note the current branch's upstream
+	cmd = exec.Command("git", "for-each-ref",
+		"--format=%(HEAD)%00%(refname:short)%00%(upstream:remotename)%00%(upstream:short)", "refs/heads")
+	cmd.Dir = workDir
+	output, _ = cmd.Output()
+	var current, upstreamRemote, upstream string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) != 4 {
+			continue
+		}
+		if r := byName[f[2]]; r != nil {
+			r.Tracking++
+		}
+		if f[0] == "*" {
+			current, upstreamRemote, upstream = f[1], f[2], f[3]
+		}
+	}
+	if current == "" {
+		return remotes, nil
+	}
+
+	existing := remoteBranches(workDir)
+	for _, r := range remotes {
+		switch {
+		case r.Name == upstreamRemote && existing[upstream]:
+			r.Branch = upstream
+		case existing[r.Name+"/"+current]:
+			r.Branch = r.Name + "/" + current
+		default:
+			continue
+		}
+		r.Ahead, r.Behind, _ = AheadBehind(workDir, r.Branch)
+	}
+	return remotes, nil
+}
+
+// remoteBranches returns the set of remote-tracking branches, such as
+// "origin/main".
+func remoteBranches(workDir string) map[string]bool {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/remotes")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, ref := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if ref != "" {
+			set[ref] = true
+		}
+	}
+	return set
+}
+
+// GetRemoteBranches lists the branches remote has been fetched with, without
+// the remote prefix and sorted.
+func GetRemoteBranches(workDir, remote string) []string {
+	var branches []string
+	for ref := range remoteBranches(workDir) {
+		if b, ok := strings.CutPrefix(ref, remote+"/"); ok && b != "HEAD" {
+			branches = append(branches, b)
+		}
+	}
+	sort.Strings(branches)
+	return branches
+}
+
+// AheadBehind counts the commits HEAD has that ref lacks, and the reverse.
+func AheadBehind(workDir, ref string) (ahead, behind int, err error) {
+	cmd := exec.Command("git", "rev-list", "--left-right", "--count", "HEAD..."+ref)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, err
+	}
+	f := strings.Fields(string(output))
+	if len(f) != 2 {
+		return 0, 0, errors.New("unexpected rev-list output: " + string(output))
+	}
+	ahead, _ = strconv.Atoi(f[0])
+	behind, _ = strconv.Atoi(f[1])
+	return ahead, behind, nil
+}
+
+// ValidateRemoteName checks name is usable as a remote name.
+func ValidateRemoteName(workDir, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &RemoteError{Output: "Remote name is required"}
+	}
+	cmd := exec.Command("git", "check-ref-format", "refs/remotes/"+name+"/HEAD")
+	cmd.Dir = workDir
+	if err := cmd.Run(); err != nil {
+		return &RemoteError{Output: "Invalid remote name: " + name, Err: err}
+	}
+	return nil
+}
+
+// AddRemote adds a remote named name that fetches from url.
+func AddRemote(workDir, name, url string) error {
+	if err := ValidateRemoteName(workDir, name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(url) == "" {
+		return &RemoteError{Output: "Remote URL is required"}
+	}
+	return runRemoteGit(workDir, "remote", "add", "--", name, url)
+}
+
+// RenameRemote renames a remote along with its remote-tracking branches and
+// the upstream settings that refer to it.
+func RenameRemote(workDir, oldName, newName string) error {
+	if err := ValidateRemoteName(workDir, newName); err != nil {
+		return err
+	}
+	return runRemoteGit(workDir, "remote", "rename", "--", oldName, newName)
+}
+
+// SetRemoteURL changes the URL a remote fetches from.
+func SetRemoteURL(workDir, name, url string) error {
+	if strings.TrimSpace(url) == "" {
+		return &RemoteError{Output: "Remote URL is required"}
+	}
+	return runRemoteGit(workDir, "remote", "set-url", "--", name, url)
+}
+
+// RemoveRemote removes a remote and its remote-tracking branches.
+func RemoveRemote(workDir, name string) error {
+	return runRemoteGit(workDir, "remote", "remove", "--", name)
+}
+
+// runRemoteGit runs a git command, wrapping failures in RemoteError.
+func runRemoteGit(workDir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &RemoteError{Output: string(output), Err: err}
+	}
+	return nil
+}
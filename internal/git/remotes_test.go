@@ -0,0 +1,198 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// forkRepo returns a clone of a bare origin with a second bare remote,
+// upstream, that has the same history.
+func forkRepo(t *testing.T) (dir, branch string) {
+	t.Helper()
+	dir = newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	branch = strings.TrimSpace(runGit(t, dir, "branch", "--show-current"))
+	for _, name := range []string{"origin", "upstream"} {
+		bare := t.TempDir()
+		runGit(t, bare, "init", "-q", "--bare")
+		runGit(t, dir, "remote", "add", name, bare)
+		runGit(t, dir, "push", "-q", name, "HEAD")
+	}
+	runGit(t, dir, "fetch", "-q", "--all")
+	runGit(t, dir, "branch", "-q", "--set-upstream-to", "origin/"+branch)
+	return dir, branch
+}
+
+func TestGetRemotes(t *testing.T) {
+	dir, branch := forkRepo(t)
+	commitFiles(t, dir, "local", map[string]string{"b.txt": "b\n"})
+	runGit(t, dir, "branch", "-q", "side", "--track", "upstream/"+branch)
+
+	remotes, err := GetRemotes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotes) != 2 || remotes[0].Name != "origin" || remotes[1].Name != "upstream" {
+		t.Fatalf("remotes = %+v", remotes)
+	}
+	origin, upstream := remotes[0], remotes[1]
+	if origin.FetchURL == "" || origin.PushURL != origin.FetchURL {
+		t.Errorf("origin URLs = %q, %q", origin.FetchURL, origin.PushURL)
+	}
+	if origin.Tracking != 1 || upstream.Tracking != 1 {
+		t.Errorf("tracking = %d, %d; want 1, 1", origin.Tracking, upstream.Tracking)
+	}
+	if origin.Branch != "origin/"+branch || origin.Ahead != 1 || origin.Behind != 0 {
+		t.Errorf("origin = %+v", origin)
+	}
+	if upstream.Branch != "upstream/"+branch || upstream.Ahead != 1 {
+		t.Errorf("upstream = %+v", upstream)
+	}
+
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	remotes, _ = GetRemotes(dir)
+	if remotes[0].Branch != "" || remotes[1].Branch != "" {
+		t.Errorf("unpushed branch should have no remote branch: %+v %+v", remotes[0], remotes[1])
+	}
+}
+
+func TestAddRenameRemoveRemote(t *testing.T) {
+	dir, branch := forkRepo(t)
+
+	if err := AddRemote(dir, "bad name", "/tmp/x"); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+	if err := AddRemote(dir, "mirror", ""); err == nil {
+		t.Error("expected an error for an empty URL")
+	}
+	if err := AddRemote(dir, "mirror", "/tmp/mirror.git"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddRemote(dir, "mirror", "/tmp/mirror.git"); err == nil {
+		t.Error("expected an error for a duplicate remote")
+	}
+	if err := SetRemoteURL(dir, "mirror", "/tmp/other.git"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RenameRemote(dir, "origin", "fork"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "rev-parse", "--abbrev-ref", "@{upstream}")); got != "fork/"+branch {
+		t.Errorf("upstream after rename = %q", got)
+	}
+
+	if err := RemoveRemote(dir, "mirror"); err != nil {
+		t.Fatal(err)
+	}
+	err := RemoveRemote(dir, "mirror")
+	var re *RemoteError
+	if !errors.As(err, &re) {
+		t.Errorf("err = %v, want *RemoteError", err)
+	}
+
+	remotes, _ := GetRemotes(dir)
+	var names []string
+	for _, r := range remotes {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "fork,upstream" {
+		t.Errorf("remotes = %q", names)
+	}
+}
+
+func TestFetchRemotePrune(t *testing.T) {
+	dir, _ := forkRepo(t)
+	runGit(t, dir, "push", "-q", "origin", "HEAD:refs/heads/gone")
+	runGit(t, dir, "push", "-q", "upstream", "HEAD:refs/heads/gone")
+	runGit(t, dir, "push", "-q", "origin", "--delete", "gone")
+	runGit(t, dir, "push", "-q", "upstream", "--delete", "gone")
+	runGit(t, dir, "update-ref", "refs/remotes/origin/gone", "HEAD")
+	runGit(t, dir, "update-ref", "refs/remotes/upstream/gone", "HEAD")
+
+	if _, err := FetchRemote(dir, "origin", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetRemoteBranches(dir, "origin"); strings.Contains(strings.Join(got, ","), "gone") {
+		t.Errorf("origin branches after prune = %q", got)
+	}
+	if got := GetRemoteBranches(dir, "upstream"); !strings.Contains(strings.Join(got, ","), "gone") {
+		t.Errorf("upstream should be untouched: %q", got)
+	}
+
+	if _, err := FetchRemote(dir, "", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetRemoteBranches(dir, "upstream"); strings.Contains(strings.Join(got, ","), "gone") {
+		t.Errorf("upstream branches after fetch --all = %q", got)
+	}
+
+	if _, err := FetchRemote(dir, "missing", false); err == nil {
+		t.Error("expected an error for an unknown remote")
+	}
+}
+
+func TestExecutePushToAndStatusFor(t *testing.T) {
+	dir, branch := forkRepo(t)
+	commitFiles(t, dir, "local", map[string]string{"b.txt": "b\n"})
+
+	ps := GetPushStatusFor(dir, "upstream/"+branch)
+	if !ps.HasUpstream || ps.UpstreamBranch != "upstream/"+branch || ps.Ahead != 1 || len(ps.UnpushedHashes) != 1 {
+		t.Errorf("before push: %+v", ps)
+	}
+	if ps = GetPushStatusFor(dir, "upstream/topic"); ps.HasUpstream {
+		t.Errorf("missing branch should have no upstream: %+v", ps)
+	}
+
+	if _, err := ExecutePushTo(dir, "upstream", "topic", false, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "rev-parse", "--abbrev-ref", "@{upstream}")); got != "upstream/topic" {
+		t.Errorf("upstream = %q, want upstream/topic", got)
+	}
+	if ps = GetPushStatusFor(dir, "upstream/topic"); ps.Ahead != 0 || ps.Behind != 0 {
+		t.Errorf("after push: %+v", ps)
+	}
+	if ps = GetPushStatusFor(dir, "origin/"+branch); ps.Ahead != 1 {
+		t.Errorf("origin should be unaffected: %+v", ps)
+	}
+
+	runGit(t, dir, "commit", "-q", "--amend", "-m", "amended")
+	if _, err := ExecutePushTo(dir, "upstream", "topic", false, false); !IsPushRejectedError(err) {
+		t.Errorf("err = %v, want a rejected push", err)
+	}
+	if _, err := ExecutePushTo(dir, "upstream", "topic", true, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExecutePushTo(dir, "upstream", "", false, false); err == nil {
+		t.Error("expected an error for an empty branch")
+	}
+}
+
+func TestGetPushTarget(t *testing.T) {
+	dir, branch := forkRepo(t)
+
+	if remote, b := GetPushTarget(dir); remote != "origin" || b != branch {
+		t.Errorf("default = %s/%s", remote, b)
+	}
+
+	runGit(t, dir, "checkout", "-q", "-b", "topic", "--track", "upstream/"+branch)
+	if remote, b := GetPushTarget(dir); remote != "upstream" || b != "topic" {
+		t.Errorf("upstream's remote = %s/%s, want upstream/topic", remote, b)
+	}
+
+	runGit(t, dir, "config", "remote.pushDefault", "origin")
+	if remote, _ := GetPushTarget(dir); remote != "origin" {
+		t.Errorf("pushDefault = %s, want origin", remote)
+	}
+
+	runGit(t, dir, "config", "branch.topic.pushRemote", "upstream")
+	if remote, _ := GetPushTarget(dir); remote != "upstream" {
+		t.Errorf("pushRemote = %s, want upstream", remote)
+	}
+
+	runGit(t, dir, "checkout", "-q", "--detach")
+	if remote, b := GetPushTarget(dir); remote != "origin" || b != "" {
+		t.Errorf("detached = %s/%s, want origin and no branch", remote, b)
+	}
+}
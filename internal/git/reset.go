@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ResetMode selects how git reset treats the index and working tree.
+type ResetMode string
+
+const (
+	ResetSoft  ResetMode = "soft"  // Keep index and working tree; commits become staged
+	ResetMixed ResetMode = "mixed" // Keep working tree; commits and index become unstaged
+	ResetHard  ResetMode = "hard"  // Discard commits, index and working tree changes
+)
+
+// ResetError wraps a git reset error with its output.
+type ResetError struct {
+	Output string
+	Err    error
+}
+
+func (e *ResetError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *ResetError) Unwrap() error {
+	return e.Err
+}
+
+// ResetPreview describes what a reset to a target commit would change.
+type ResetPreview struct {
+	Commits      []string // "<short hash> <subject>" for each commit leaving the branch, newest first
+	ChangedFiles []string // Files with uncommitted changes (lost by a hard reset)
+}
+
+// GetResetPreview returns the commits in target..HEAD and the files with
+// uncommitted changes to tracked files.
+func GetResetPreview(workDir, target string) (*ResetPreview, error) {
+	cmd := exec.Command("git", "log", "--format=%h %s", target+"..HEAD")
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &ResetError{Output: string(output), Err: err}
+	}
+	preview := &ResetPreview{Commits: splitNonEmptyLines(string(output))}
+
+	cmd = exec.Command("git", "diff", "HEAD", "--name-only")
+	cmd.Dir = workDir
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		return nil, &ResetError{Output: string(output), Err: err}
+	}
+	preview.ChangedFiles = splitNonEmptyLines(string(output))
+	return preview, nil
+}
+
+// Reset moves the current branch to target using the given mode.
+func Reset(workDir string, mode ResetMode, target string) error {
+	switch mode {
+	case ResetSoft, ResetMixed, ResetHard:
+	default:
+		return fmt.Errorf("unknown reset mode %q", mode)
+	}
+	cmd := exec.Command("git", "reset", "--"+string(mode), target)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &ResetError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// splitNonEmptyLines splits output into lines, dropping blank ones.
+func splitNonEmptyLines(output string) []string {
+	var lines []string
+	for _, l := range strings.Split(output, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,265 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SignatureStatus is git's verdict on a commit signature, as printed by %G?.
+type SignatureStatus string
+
+const (
+	SignatureGood       SignatureStatus = "G" // Good signature from a trusted key
+	SignatureUntrusted  SignatureStatus = "U" // Good signature, key of unknown validity
+	SignatureExpired    SignatureStatus = "X" // Good signature that has expired
+	SignatureExpiredKey SignatureStatus = "Y" // Good signature by an expired key
+	SignatureRevokedKey SignatureStatus = "R" // Good signature by a revoked key
+	SignatureBad        SignatureStatus = "B"
+	SignatureUnknownKey SignatureStatus = "E" // Cannot be checked, usually a missing key
+	SignatureNone       SignatureStatus = "N"
+)
+
+// CommitSignature describes the signature of a commit.
+type CommitSignature struct {
+	Status SignatureStatus
+	Signer string // %GS
+	Key    string // %GK
+}
+
+// ParseCommitSignature builds a CommitSignature from the %G?, %GS and %GK
+// placeholders.
+func ParseCommitSignature(status, signer, key string) CommitSignature {
+	s := CommitSignature{Status: SignatureStatus(status), Signer: signer, Key: key}
+	if s.Status == "" {
+		s.Status = SignatureNone
+	}
+	return s
+}
+
+// Signed reports whether the commit carries a signature of any kind.
+func (s CommitSignature) Signed() bool {
+	return s.Status != "" && s.Status != SignatureNone
+}
+
+// Good reports whether the signature verified, trusted or not.
+func (s CommitSignature) Good() bool {
+	return s.Status == SignatureGood || s.Status == SignatureUntrusted
+}
+
+// Bad reports whether the signature is invalid or no longer valid.
+func (s CommitSignature) Bad() bool {
+	switch s.Status {
+	case SignatureBad, SignatureExpired, SignatureExpiredKey, SignatureRevokedKey:
+		return true
+	}
+	return false
+}
+
+// UnknownKey reports whether the signature could not be checked.
+func (s CommitSignature) UnknownKey() bool {
+	return s.Status == SignatureUnknownKey
+}
+
+// Label describes the signature status.
+func (s CommitSignature) Label() string {
+	switch s.Status {
+	case SignatureGood:
+		return "good"
+	case SignatureUntrusted:
+		return "good (untrusted key)"
+	case SignatureExpired:
+		return "expired signature"
+	case SignatureExpiredKey:
+		return "expired key"
+	case SignatureRevokedKey:
+		return "revoked key"
+	case SignatureBad:
+		return "bad"
+	case SignatureUnknownKey:
+		return "unknown key"
+	}
+	return "unsigned"
+}
+
+// Checked reports whether the signature has been verified. Commits listed
+// without verifying have no status yet.
+func (s CommitSignature) Checked() bool {
+	return s.Status != ""
+}
+
+// signatureFormat prints a commit's hash and signature as NUL-separated
+// fields.
+const signatureFormat = "%H%x00%G?%x00%GS%x00%GK"
+
+// GetCommitSignature verifies the signature of the given commit.
+func GetCommitSignature(workDir, hash string) (CommitSignature, error) {
+	sigs, err := GetCommitSignatures(workDir, hash)
+	for _, sig := range sigs {
+		return sig, nil
+	}
+	return CommitSignature{}, err
+}
+
+// GetCommitSignatures verifies the signatures of the given commits with one
+// git call, keyed by full hash. Verification runs the signing program for
+// each signed commit, so callers should ask only for commits they show.
+func GetCommitSignatures(workDir string, revs ...string) (map[string]CommitSignature, error) {
+	sigs := make(map[string]CommitSignature, len(revs))
+	if len(revs) == 0 {
+		return sigs, nil
+	}
+	args := append([]string{"show", "-s", "--format=" + signatureFormat}, revs...)
+	cmd := exec.Command("git", append(args, "--")...)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) < 2 || parts[0] == "" {
+			continue
+		}
+		for len(parts) < 4 {
+			parts = append(parts, "")
+		}
+		sigs[parts[0]] = ParseCommitSignature(parts[1], parts[2], parts[3])
+	}
+	return sigs, nil
+}
+
+// SigningConfig is how git is configured to sign commits.
+type SigningConfig struct {
+	Enabled bool   // commit.gpgSign
+	Format  string // gpg.format: openpgp, ssh or x509
+	Key     string // user.signingKey
+}
+
+// GetSigningConfig reads the commit signing configuration of the repository
+// at workDir.
+func GetSigningConfig(workDir string) SigningConfig {
+	cfg := SigningConfig{Format: "openpgp"}
+	cmd := exec.Command("git", "config", "-z", "--get-regexp", `^(commit\.gpgsign|gpg\.format|user\.signingkey)$`)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		// Nothing configured
+		return cfg
+	}
+	for _, entry := range strings.Split(string(output), "\x00") {
+		key, value, _ := strings.Cut(entry, "\n")
+		switch strings.ToLower(key) {
+		case "commit.gpgsign":
+			cfg.Enabled = parseConfigBool(value)
+		case "gpg.format":
+			cfg.Format = value
+		case "user.signingkey":
+			cfg.Key = value
+		}
+	}
+	return cfg
+}
+
+// parseConfigBool interprets a git config boolean.
+func parseConfigBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
+
+// SigningError reports a commit that failed because git could not sign it.
+type SigningError struct {
+	Format  string // gpg.format in effect
+	NoAgent bool   // The signing agent could not be reached
+	Output  string
+	Err     error
+}
+
+func (e *SigningError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *SigningError) Unwrap() error {
+	return e.Err
+}
+
+// Hint suggests how to make signing work.
+func (e *SigningError) Hint() string {
+	switch {
+	case e.NoAgent && e.Format == "ssh":
+		return "No SSH agent is reachable. Start ssh-agent, add your signing key with ssh-add, and launch sidecar from a shell where SSH_AUTH_SOCK is set."
+	case e.NoAgent:
+		return "No GPG agent is reachable. Start it with `gpgconf --launch gpg-agent` and use a pinentry that can prompt outside a terminal."
+	}
+	return "Check user.signingKey and gpg.format, or sign from a terminal to see the full error."
+}
+
+// signingFailures are output fragments of a signing program that failed.
+var signingFailures = []string{
+	"failed to sign",
+	"signing failed",
+	"couldn't load public key",
+}
+
+// noAgentFailures are output fragments of a signing program that could not
+// reach its agent.
+var noAgentFailures = []string{
+	"no agent running",
+	"couldn't get agent socket",
+	"could not open a connection to your authentication agent",
+	"agent refused operation",
+	"inappropriate ioctl for device",
+}
+
+// commitError classifies a failed git commit, returning a *SigningError
+// when signing was the cause.
+func commitError(workDir, output string, err error) error {
+	lower := strings.ToLower(output)
+	noAgent := containsAny(lower, noAgentFailures)
+	if !noAgent && !containsAny(lower, signingFailures) {
+		return &CommitError{Output: output, Err: err}
+	}
+	format := GetSigningConfig(workDir).Format
+	// git keeps gpg's own messages to itself, so look for the socket
+	if !noAgent {
+		noAgent = agentSocketMissing(format)
+	}
+	return &SigningError{Format: format, NoAgent: noAgent, Output: output, Err: err}
+}
+
+// agentSocketMissing reports whether the agent that format signs through
+// has no socket in this environment.
+func agentSocketMissing(format string) bool {
+	var sock string
+	switch format {
+	case "ssh":
+		sock = os.Getenv("SSH_AUTH_SOCK")
+	case "openpgp":
+		out, err := exec.Command("gpgconf", "--list-dirs", "agent-socket").Output()
+		if err != nil {
+			// No way to tell
+			return false
+		}
+		sock = strings.TrimSpace(string(out))
+	default:
+		return false
+	}
+	if sock == "" {
+		return true
+	}
+	_, err := os.Stat(sock)
+	return err != nil
+}
+
+// containsAny reports whether s contains any of substrs.
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// configureSSHSigning sets up dir to sign with a fresh SSH key that git
+// trusts for verification.
+func configureSSHSigning(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	key := filepath.Join(t.TempDir(), "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v (%s)", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Dir(key), "allowed_signers", "test@test "+string(pub))
+	runGit(t, dir, "config", "gpg.format", "ssh")
+	runGit(t, dir, "config", "user.signingKey", key)
+	runGit(t, dir, "config", "gpg.ssh.allowedSignersFile", filepath.Join(filepath.Dir(key), "allowed_signers"))
+}
+
+func TestExecuteCommit_Signed(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	configureSSHSigning(t, dir)
+
+	writeFile(t, dir, "a.txt", "b\n")
+	runGit(t, dir, "add", "a.txt")
+	hash, err := ExecuteCommit(dir, "signed", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := GetCommitSignature(dir, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Good() || sig.Signer != "test@test" || sig.Key == "" {
+		t.Errorf("signature = %+v", sig)
+	}
+
+	if sig, _ = GetCommitSignature(dir, "HEAD~1"); sig.Signed() || sig.Label() != "unsigned" {
+		t.Errorf("initial commit signature = %+v", sig)
+	}
+
+	head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	parent := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD~1"))
+	sigs, err := GetCommitSignatures(dir, head, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 2 || !sigs[head].Good() || !sigs[parent].Checked() || sigs[parent].Signed() {
+		t.Errorf("batched signatures = %+v", sigs)
+	}
+}
+
+func TestExecuteCommit_SigningFailure(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if _, err := exec.LookPath("gpgconf"); err != nil {
+		t.Skip("gpgconf not available")
+	}
+	// A signing program whose agent has no socket
+	t.Setenv("GNUPGHOME", t.TempDir())
+	script := filepath.Join(t.TempDir(), "gpg")
+	writeFile(t, filepath.Dir(script), "gpg", "#!/bin/sh\necho 'gpg: signing failed: No agent running' >&2\nexit 2\n")
+	if err := os.Chmod(script, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "config", "gpg.program", script)
+
+	writeFile(t, dir, "a.txt", "b\n")
+	runGit(t, dir, "add", "a.txt")
+	_, err := ExecuteCommit(dir, "signed", true)
+	var sigErr *SigningError
+	if !errors.As(err, &sigErr) {
+		t.Fatalf("expected a SigningError, got %T: %v", err, err)
+	}
+	if !sigErr.NoAgent || sigErr.Format != "openpgp" || !strings.Contains(sigErr.Hint(), "gpg-agent") {
+		t.Errorf("signing error = %+v", sigErr)
+	}
+
+	// Without -S the commit does not need the agent
+	if _, err := ExecuteCommit(dir, "unsigned", false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetSigningConfig(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if cfg := GetSigningConfig(dir); cfg.Enabled || cfg.Format != "openpgp" {
+		t.Errorf("default config = %+v", cfg)
+	}
+	runGit(t, dir, "config", "commit.gpgSign", "yes")
+	runGit(t, dir, "config", "gpg.format", "ssh")
+	runGit(t, dir, "config", "user.signingKey", "~/.ssh/id.pub")
+	if cfg := GetSigningConfig(dir); !cfg.Enabled || cfg.Format != "ssh" || cfg.Key != "~/.ssh/id.pub" {
+		t.Errorf("config = %+v", cfg)
+	}
+}
@@ -3,9 +3,12 @@ package git
 import (
 	"bufio"
 	"bytes"
+	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Stash represents a single stash entry.
@@ -14,6 +17,7 @@ type Stash struct {
 	Ref     string // stash@{0}, stash@{1}, etc.
 	Branch  string // Branch the stash was created on
 	Message string // Stash message
+	Date    time.Time
 }
 
 // StashList represents the list of stashes.
@@ -23,7 +27,7 @@ type StashList struct {
 
 // GetStashList retrieves the list of stashes.
 func GetStashList(workDir string) (*StashList, error) {
-	cmd := exec.Command("git", "stash", "list", "--format=%gd|%gs")
+	cmd := exec.Command("git", "stash", "list", "--format=%gd|%ct|%gs")
 	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil {
@@ -34,15 +38,15 @@ func GetStashList(workDir string) (*StashList, error) {
 	list := &StashList{}
 	scanner := bufio.NewScanner(bytes.NewReader(output))
 
-	// Pattern: stash@{n}|message
+	// Pattern: stash@{n}|timestamp|message
 	// Message format: "WIP on branch: hash message" or "On branch: message"
-	re := regexp.MustCompile(`^stash@\{(\d+)\}\|(.+)$`)
+	re := regexp.MustCompile(`^stash@\{(\d+)\}\|(\d*)\|(.+)$`)
 	branchRe := regexp.MustCompile(`^(?:WIP )?[Oo]n ([^:]+): (.+)$`)
 
 	for scanner.Scan() {
 		line := scanner.Text()
 		matches := re.FindStringSubmatch(line)
-		if len(matches) != 3 {
+		if len(matches) != 4 {
 			continue
 		}
 
@@ -52,9 +56,12 @@ func GetStashList(workDir string) (*StashList, error) {
 			Index: idx,
 			Ref:   "stash@{" + matches[1] + "}",
 		}
+		if ts, err := strconv.ParseInt(matches[2], 10, 64); err == nil {
+			stash.Date = time.Unix(ts, 0)
+		}
 
 		// Parse the message for branch name
-		msgPart := matches[2]
+		msgPart := matches[3]
 		branchMatches := branchRe.FindStringSubmatch(msgPart)
 		if len(branchMatches) == 3 {
 			stash.Branch = branchMatches[1]
@@ -134,6 +141,219 @@ func StashDrop(workDir, ref string) error {
 	return nil
 }
 
+// GetStashDiff returns the diff of the stash at ref against the commit it
+// was made on, followed by the untracked files it holds as new files.
+func GetStashDiff(workDir, ref string) (string, error) {
+	diff, err := stashOutput(workDir, "diff", "--no-color", "--no-ext-diff", ref+"^1", ref)
+	if err != nil || !stashHasUntracked(workDir, ref) {
+		return diff, err
+	}
+	untracked, err := stashOutput(workDir, "diff-tree", "-p", "--root", "--no-commit-id", "--no-color", "--no-ext-diff", ref+"^3")
+	if err != nil {
+		return "", err
+	}
+	if diff == "" {
+		return untracked, nil
+	}
+	return diff + "\n" + untracked, nil
+}
+
+// stashHasUntracked reports whether the stash at ref was made with
+// --include-untracked and holds untracked files in its third parent.
+func stashHasUntracked(workDir, ref string) bool {
+	_, err := stashOutput(workDir, "rev-parse", "--verify", "-q", ref+"^3")
+	return err == nil
+}
+
+// StashCheckoutFile writes path as the stash at ref has it into the working
+// tree, leaving the index alone. A file the stash deleted is removed.
+func StashCheckoutFile(workDir, ref, path string) error {
+	source := ref
+	if stashHasUntracked(workDir, ref) {
+		if _, err := stashOutput(workDir, "cat-file", "-e", ref+"^3:"+path); err == nil {
+			source = ref + "^3"
+		}
+	}
+	_, err := stashOutput(workDir, "restore", "--source="+source, "--worktree", "--", path)
+	return err
+}
+
+// StashBranch creates branch name at the commit the stash at ref was made
+// on, checks it out and pops the stash onto it.
+func StashBranch(workDir, name, ref string) error {
+	cmd := exec.Command("git", "stash", "branch", name, ref)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &StashError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// StashRename replaces the message of the stash at ref, a stash@{n}
+// reference. Git cannot edit a stash entry in place, so a copy of the stash
+// commit carrying the new message is stored, which moves it to stash@{0},
+// and the old entry is dropped. If storing fails the stash is left as it
+// was.
+func StashRename(workDir, ref, message string) error {
+	m := stashRefRegex.FindStringSubmatch(ref)
+	if m == nil {
+		return &StashError{Output: "not a stash entry: " + ref}
+	}
+	index, _ := strconv.Atoi(m[1])
+	hash, err := stashOutput(workDir, "rev-parse", ref)
+	if err != nil {
+		return err
+	}
+	info, err := stashOutput(workDir, "show", "-s", "--date=raw",
+		"--format=%T%n%P%n%an%n%ae%n%ad%n%cn%n%ce%n%cd%n%s", hash)
+	if err != nil {
+		return err
+	}
+	fields := strings.SplitN(info, "\n", 9)
+	if len(fields) < 9 {
+		return &StashError{Output: "cannot read stash " + ref}
+	}
+	// Keep the "On branch:" prefix the stash list parses
+	if m := stashSubjectRegex.FindStringSubmatch(fields[8]); m != nil {
+		message = "On " + m[1] + ": " + message
+	}
+
+	// Storing the commit already at the tip would add no entry, so store a
+	// copy with the same tree, parents, authorship and dates
+	args := []string{"commit-tree", fields[0], "-m", message}
+	for _, parent := range strings.Fields(fields[1]) {
+		args = append(args, "-p", parent)
+	}
+	env := append(os.Environ(),
+		"GIT_AUTHOR_NAME="+fields[2], "GIT_AUTHOR_EMAIL="+fields[3], "GIT_AUTHOR_DATE="+fields[4],
+		"GIT_COMMITTER_NAME="+fields[5], "GIT_COMMITTER_EMAIL="+fields[6], "GIT_COMMITTER_DATE="+fields[7])
+	renamed, err := stashEnvOutput(workDir, env, args...)
+	if err != nil {
+		return &StashError{Output: renamed, Err: err}
+	}
+	if _, err := stashOutput(workDir, "stash", "store", "-m", message, renamed); err != nil {
+		return err
+	}
+	// Storing pushed the old entry down by one
+	return StashDrop(workDir, "stash@{"+strconv.Itoa(index+1)+"}")
+}
+
+var (
+	// stashSubjectRegex matches the subject git gives stash commits.
+	stashSubjectRegex = regexp.MustCompile(`^(?:WIP )?[Oo]n ([^:]+): `)
+	// stashRefRegex matches a stash entry reference and captures its index.
+	stashRefRegex = regexp.MustCompile(`^stash@\{(\d+)\}$`)
+)
+
+// StashPushPaths stashes the changes to paths only. includeUntracked must
+// be set when any of them is untracked.
+func StashPushPaths(workDir, message string, includeUntracked bool, paths []string) error {
+	args := []string{"stash", "push"}
+	if includeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	args = append(append(args, "--"), paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &StashError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// StashPushPatch stashes the changes in patch, a diff of the working tree
+// against the index such as BuildHunkPatch returns, then removes them from
+// the working tree. The stash is built on HEAD's tree in a scratch index, so
+// it holds the patch alone and none of the staged changes.
+func StashPushPatch(workDir, message, patch string) error {
+	head, err := stashOutput(workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	index, cleanup, err := scratchIndex(workDir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	env := append(os.Environ(), "GIT_INDEX_FILE="+index)
+
+	if output, err := stashEnvOutput(workDir, env, "read-tree", head); err != nil {
+		return &StashError{Output: output, Err: err}
+	}
+	if output, err := runApply(workDir, env, patch, "--cached"); err != nil {
+		return &StashError{Output: "The selection overlaps staged changes; unstage them first\n" + output, Err: err}
+	}
+	tree, err := stashEnvOutput(workDir, env, "write-tree")
+	if err != nil {
+		return &StashError{Output: tree, Err: err}
+	}
+
+	branch, _ := stashOutput(workDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if branch == "" || branch == "HEAD" {
+		branch = "(no branch)"
+	}
+	subject, _ := stashOutput(workDir, "log", "-1", "--format=%h %s", head)
+	if message == "" {
+		message = "WIP on " + branch + ": " + subject
+	} else {
+		message = "On " + branch + ": " + message
+	}
+	indexCommit, err := stashOutput(workDir, "commit-tree", head+"^{tree}", "-p", head, "-m", "index on "+branch+": "+subject)
+	if err != nil {
+		return err
+	}
+	stash, err := stashOutput(workDir, "commit-tree", tree, "-p", head, "-p", indexCommit, "-m", message)
+	if err != nil {
+		return err
+	}
+	if _, err := stashOutput(workDir, "stash", "store", "-m", message, stash); err != nil {
+		return err
+	}
+	if err := ApplyPatch(workDir, patch, false, true); err != nil {
+		// Leave the working tree and the stash list as they were
+		_ = StashDrop(workDir, "stash@{0}")
+		return err
+	}
+	return nil
+}
+
+// StashHunk stashes one hunk (or line range within it) of an unstaged diff
+// and removes it from the working tree. Pass startLine < 0 to stash the
+// whole hunk.
+func StashHunk(workDir string, diff *ParsedDiff, hunkIdx, startLine, endLine int) error {
+	patch, err := selectionPatch(diff, hunkIdx, startLine, endLine, false)
+	if err != nil {
+		return err
+	}
+	return StashPushPatch(workDir, "", patch)
+}
+
+// stashOutput runs git and returns its trimmed stdout, wrapping failures
+// in StashError.
+func stashOutput(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &StashError{Output: stderrOf(err), Err: err}
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// stashEnvOutput runs git with env and returns its trimmed combined output.
+func stashEnvOutput(workDir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	cmd.Env = env
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
 // StashError wraps a git stash error with its output.
 type StashError struct {
 	Output string
@@ -144,6 +364,10 @@ func (e *StashError) Error() string {
 	return strings.TrimSpace(e.Output)
 }
 
+func (e *StashError) Unwrap() error {
+	return e.Err
+}
+
 // Count returns the number of stashes.
 func (l *StashList) Count() int {
 	if l == nil {
@@ -0,0 +1,185 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetStashDiff_IncludesUntracked(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "gone.txt": "x\n"})
+	writeFile(t, dir, "a.txt", "changed\n")
+	writeFile(t, dir, "new.txt", "fresh\n")
+	runGit(t, dir, "rm", "-q", "gone.txt")
+	runGit(t, dir, "stash", "push", "-q", "--include-untracked", "-m", "work")
+
+	list, err := GetStashList(dir)
+	if err != nil || list.Count() != 1 {
+		t.Fatalf("list = %+v, %v", list, err)
+	}
+	if s := list.Stashes[0]; s.Message != "work" || s.Date.IsZero() {
+		t.Errorf("stash = %+v", s)
+	}
+
+	diff, err := GetStashDiff(dir, "stash@{0}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfd := ParseMultiFileDiff(diff)
+	var names []string
+	for _, f := range mfd.Files {
+		names = append(names, f.FileName())
+	}
+	if got := strings.Join(names, ","); got != "a.txt,gone.txt,new.txt" {
+		t.Errorf("files = %s\n%s", got, diff)
+	}
+}
+
+func TestStashCheckoutFile(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "b.txt": "b\n", "gone.txt": "x\n"})
+	writeFile(t, dir, "a.txt", "stashed a\n")
+	writeFile(t, dir, "b.txt", "stashed b\n")
+	writeFile(t, dir, "new.txt", "fresh\n")
+	runGit(t, dir, "rm", "-q", "gone.txt")
+	runGit(t, dir, "stash", "push", "-q", "--include-untracked")
+
+	for _, path := range []string{"a.txt", "new.txt", "gone.txt"} {
+		if err := StashCheckoutFile(dir, "stash@{0}", path); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+	if got := readFile(t, dir, "a.txt"); got != "stashed a\n" {
+		t.Errorf("a.txt = %q", got)
+	}
+	if got := readFile(t, dir, "b.txt"); got != "b\n" {
+		t.Errorf("b.txt should be left alone, got %q", got)
+	}
+	if got := readFile(t, dir, "new.txt"); got != "fresh\n" {
+		t.Errorf("new.txt = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gone.txt")); !os.IsNotExist(err) {
+		t.Error("gone.txt should be removed")
+	}
+	if got := runGit(t, dir, "diff", "--cached", "--name-only"); got != "" {
+		t.Errorf("index changed: %q", got)
+	}
+}
+
+func TestStashRenameAndBranch(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	writeFile(t, dir, "a.txt", "one\n")
+	runGit(t, dir, "stash", "push", "-q", "-m", "first")
+	writeFile(t, dir, "a.txt", "two\n")
+	runGit(t, dir, "stash", "push", "-q", "-m", "second")
+
+	if err := StashRename(dir, "stash@{1}", "renamed"); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := GetStashList(dir)
+	if list.Count() != 2 || list.Stashes[0].Message != "renamed" || list.Stashes[0].Branch == "" || list.Stashes[1].Message != "second" {
+		t.Fatalf("stashes = %+v %+v", list.Stashes[0], list.Stashes[1])
+	}
+
+	// Renaming the newest entry stores a copy, since storing the tip again
+	// would add nothing
+	if err := StashRename(dir, "stash@{0}", "renamed again"); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = GetStashList(dir)
+	if list.Count() != 2 || list.Stashes[0].Message != "renamed again" || list.Stashes[1].Message != "second" {
+		t.Fatalf("after renaming stash@{0}: %+v %+v", list.Stashes[0], list.Stashes[1])
+	}
+
+	// A failed store leaves the stash listed as it was
+	installHook(t, dir, "reference-transaction", "[ \"$1\" = prepared ] && exit 1\nexit 0\n")
+	if err := StashRename(dir, "stash@{1}", "lost"); err == nil {
+		t.Fatal("expected an error when storing is refused")
+	}
+	if err := os.Remove(filepath.Join(dir, ".git", "hooks", "reference-transaction")); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = GetStashList(dir)
+	if list.Count() != 2 || list.Stashes[0].Message != "renamed again" || list.Stashes[1].Message != "second" {
+		t.Fatalf("after a failed rename: %+v %+v", list.Stashes[0], list.Stashes[1])
+	}
+	if err := StashRename(dir, "stash", "bad ref"); err == nil {
+		t.Error("expected an error for a ref that is not a stash entry")
+	}
+
+	if err := StashBranch(dir, "rescued", "stash@{0}"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "branch", "--show-current")); got != "rescued" {
+		t.Errorf("branch = %q", got)
+	}
+	if got := readFile(t, dir, "a.txt"); got != "one\n" {
+		t.Errorf("a.txt = %q", got)
+	}
+	if list, _ := GetStashList(dir); list.Count() != 1 {
+		t.Errorf("stash branch should drop the stash, %d left", list.Count())
+	}
+}
+
+func TestStashPushPaths(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "b.txt": "b\n"})
+	writeFile(t, dir, "a.txt", "changed a\n")
+	writeFile(t, dir, "b.txt", "changed b\n")
+	writeFile(t, dir, "new.txt", "fresh\n")
+
+	if err := StashPushPaths(dir, "some", true, []string{"a.txt", "new.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := runGit(t, dir, "status", "--porcelain"); got != " M b.txt\n" {
+		t.Errorf("status = %q", got)
+	}
+	diff, _ := GetStashDiff(dir, "stash@{0}")
+	if !strings.Contains(diff, "changed a") || !strings.Contains(diff, "fresh") || strings.Contains(diff, "changed b") {
+		t.Errorf("stash diff:\n%s", diff)
+	}
+}
+
+func TestStashPushPatch(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": numbered(20)})
+	content := strings.Replace(numbered(20), "line 2\n", "line 2 first\n", 1)
+	content = strings.Replace(content, "line 18\n", "line 18 second\n", 1)
+	writeFile(t, dir, "a.txt", content)
+	writeFile(t, dir, "staged.txt", "staged\n")
+	runGit(t, dir, "add", "staged.txt")
+
+	diff := parseGitDiff(t, dir)
+	if len(diff.Hunks) != 2 {
+		t.Fatalf("hunks = %d", len(diff.Hunks))
+	}
+	patch, err := BuildHunkPatch(diff, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := StashPushPatch(dir, "hunk", patch); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second hunk left the working tree; the first and the index stay
+	if got := readFile(t, dir, "a.txt"); got != strings.Replace(numbered(20), "line 2\n", "line 2 first\n", 1) {
+		t.Errorf("a.txt = %q", got)
+	}
+	if got := runGit(t, dir, "diff", "--cached", "--name-only"); got != "staged.txt\n" {
+		t.Errorf("index = %q", got)
+	}
+	list, _ := GetStashList(dir)
+	if list.Count() != 1 || list.Stashes[0].Message != "hunk" {
+		t.Fatalf("stashes = %+v", list.Stashes)
+	}
+	stashDiff, _ := GetStashDiff(dir, "stash@{0}")
+	if !strings.Contains(stashDiff, "+line 18 second") || strings.Contains(stashDiff, "first") || strings.Contains(stashDiff, "staged.txt") {
+		t.Errorf("stash should hold the hunk alone:\n%s", stashDiff)
+	}
+
+	runGit(t, dir, "checkout", "--", "a.txt")
+	if err := StashPop(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dir, "a.txt"); got != strings.Replace(numbered(20), "line 18\n", "line 18 second\n", 1) {
+		t.Errorf("after pop a.txt = %q", got)
+	}
+}
@@ -0,0 +1,269 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Submodule describes a submodule of the superproject.
+type Submodule struct {
+	Path        string
+	Committed   string // Commit recorded in HEAD; empty for a new submodule
+	Recorded    string // Commit recorded in the index
+	Checkout    string // Commit checked out in the submodule; empty until initialized
+	Initialized bool
+	Conflicted  bool // Recorded commit differs between the sides of a merge
+	Modified    bool // Tracked changes inside the submodule
+	Untracked   bool // Untracked files inside the submodule
+}
+
+// PointerChanged reports whether the checked-out commit differs from the
+// recorded one.
+func (s *Submodule) PointerChanged() bool {
+	return s.Initialized && s.Checkout != s.Recorded
+}
+
+// PointerStaged reports whether the index records a different commit than
+// HEAD.
+func (s *Submodule) PointerStaged() bool {
+	return s.Recorded != s.Committed
+}
+
+// Dirty reports whether the submodule has uncommitted changes.
+func (s *Submodule) Dirty() bool {
+	return s.Modified || s.Untracked
+}
+
+// SubmoduleCommit is a commit between a submodule's recorded and checked-out
+// commits.
+type SubmoduleCommit struct {
+	Hash    string
+	Subject string
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c SubmoduleCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// SubmoduleLog lists how a submodule's checkout moved from its recorded
+// commit.
+type SubmoduleLog struct {
+	Ahead  []SubmoduleCommit // Only in the checkout, newest first
+	Behind []SubmoduleCommit // Only in the recorded commit, newest first
+}
+
+// SubmoduleError wraps a git submodule error with its output.
+type SubmoduleError struct {
+	Output string
+	Err    error
+}
+
+func (e *SubmoduleError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *SubmoduleError) Unwrap() error {
+	return e.Err
+}
+
+// submoduleMode is the index mode of a gitlink entry.
+const submoduleMode = "160000"
+
+// GetSubmodules returns the submodules of the repository at workDir,
+// optionally limited to paths, with their checkout and dirty state.
+func GetSubmodules(workDir string, paths ...string) ([]*Submodule, error) {
+	args := append([]string{"ls-files", "--stage", "-z", "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &SubmoduleError{Output: stderrOf(err), Err: err}
+	}
+
+	var subs []*Submodule
+	byPath := make(map[string]*Submodule)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> <object> <stage>\t<path>
+		info, path, ok := strings.Cut(entry, "\t")
+		f := strings.Fields(info)
+		if !ok || len(f) != 3 || f[0] != submoduleMode {
+			continue
+		}
+		s := byPath[path]
+		if s == nil {
+			s = &Submodule{Path: path}
+			byPath[path] = s
+			subs = append(subs, s)
+		}
+		switch f[2] {
+		case "0":
+			s.Recorded = f[1]
+		case "2":
+			// Our side of a conflicted pointer
+			s.Recorded = f[1]
+			s.Conflicted = true
+		default:
+			s.Conflicted = true
+		}
+	}
+	if len(subs) == 0 {
+		return nil, nil
+	}
+
+	committed := headGitlinks(workDir, subs)
+	for _, s := range subs {
+		s.Committed = committed[s.Path]
+		loadSubmoduleCheckout(workDir, s)
+	}
+	return subs, nil
+}
+
+// GetSubmodule returns the submodule at path, or an error if path is not a
+// submodule.
+func GetSubmodule(workDir, path string) (*Submodule, error) {
+	subs, err := GetSubmodules(workDir, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subs {
+		if s.Path == path {
+			return s, nil
+		}
+	}
+	return nil, &SubmoduleError{Output: path + " is not a submodule", Err: os.ErrNotExist}
+}
+
+// headGitlinks returns the commits HEAD records for subs by path.
+func headGitlinks(workDir string, subs []*Submodule) map[string]string {
+	args := []string{"ls-tree", "-z", "HEAD", "--"}
+	for _, s := range subs {
+		args = append(args, s.Path)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	committed := make(map[string]string)
+	if err != nil {
+		// No commits yet
+		return committed
+	}
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> <type> <object>\t<path>
+		info, path, ok := strings.Cut(entry, "\t")
+		f := strings.Fields(info)
+		if ok && len(f) == 3 && f[1] == "commit" {
+			committed[path] = f[2]
+		}
+	}
+	return committed
+}
+
+// loadSubmoduleCheckout fills in the checked-out commit and dirty state of
+// an initialized submodule.
+func loadSubmoduleCheckout(workDir string, s *Submodule) {
+	dir := filepath.Join(workDir, s.Path)
+	// An uninitialized submodule is an empty directory; git commands in it
+	// would run against the superproject
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return
+	}
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return
+	}
+	s.Initialized = true
+	s.Checkout = strings.TrimSpace(string(out))
+
+	cmd = exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
+	if out, err = cmd.Output(); err != nil {
+		return
+	}
+	for _, line := range splitNonEmptyLines(string(out)) {
+		if strings.HasPrefix(line, "??") {
+			s.Untracked = true
+		} else {
+			s.Modified = true
+		}
+	}
+}
+
+// GetSubmoduleLog returns the commits between the recorded and checked-out
+// commits of s, at most limit on each side. It fails when the submodule
+// has not fetched the recorded commit.
+func GetSubmoduleLog(workDir string, s *Submodule, limit int) (*SubmoduleLog, error) {
+	log := &SubmoduleLog{}
+	if !s.PointerChanged() || s.Recorded == "" {
+		return log, nil
+	}
+	dir := filepath.Join(workDir, s.Path)
+	var err error
+	if log.Ahead, err = submoduleCommits(dir, s.Recorded+".."+s.Checkout, limit); err != nil {
+		return nil, err
+	}
+	if log.Behind, err = submoduleCommits(dir, s.Checkout+".."+s.Recorded, limit); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// submoduleCommits lists the commits of a revision range in dir.
+func submoduleCommits(dir, revRange string, limit int) ([]SubmoduleCommit, error) {
+	args := []string{"log", "--format=%H%x00%s"}
+	if limit > 0 {
+		args = append(args, "-n", itoa(limit))
+	}
+	cmd := exec.Command("git", append(args, revRange, "--")...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &SubmoduleError{Output: stderrOf(err), Err: err}
+	}
+	var commits []SubmoduleCommit
+	for _, line := range splitNonEmptyLines(string(output)) {
+		hash, subject, _ := strings.Cut(line, "\x00")
+		commits = append(commits, SubmoduleCommit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
+
+// InitSubmodule clones and checks out the recorded commit of the submodule
+// at path, and of any submodules nested in it.
+func InitSubmodule(workDir, path string) error {
+	return runSubmoduleUpdate(workDir, "--init", "--recursive", "--", path)
+}
+
+// UpdateSubmodule checks out the recorded commit of the submodule at path,
+// discarding where the checkout has moved to.
+func UpdateSubmodule(workDir, path string) error {
+	return runSubmoduleUpdate(workDir, "--recursive", "--", path)
+}
+
+// runSubmoduleUpdate runs git submodule update with args.
+func runSubmoduleUpdate(workDir string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"submodule", "update"}, args...)...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &SubmoduleError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// stderrOf returns the stderr captured in an exec error, or its message.
+func stderrOf(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return string(exitErr.Stderr)
+	}
+	return err.Error()
+}
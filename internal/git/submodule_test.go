@@ -0,0 +1,93 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSubmoduleRepo creates a superproject with the repo at lib as a
+// submodule, returning the superproject and the library.
+func newSubmoduleRepo(t *testing.T) (string, string) {
+	t.Helper()
+	// Local clones need the file transport, which git disables for
+	// submodules by default
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	lib := newTestRepo(t, map[string]string{"lib.txt": "v1\n"})
+	dir := newTestRepo(t, map[string]string{"main.txt": "main\n"})
+	runGit(t, dir, "submodule", "add", "-q", lib, "lib")
+	runGit(t, dir, "commit", "-q", "-m", "add lib")
+	return dir, lib
+}
+
+func TestGetSubmodules_State(t *testing.T) {
+	dir, lib := newSubmoduleRepo(t)
+	recorded := strings.TrimSpace(runGit(t, lib, "rev-parse", "HEAD"))
+
+	sub, err := GetSubmodule(dir, "lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.Initialized || sub.Recorded != recorded || sub.Committed != recorded || sub.PointerChanged() || sub.Dirty() {
+		t.Fatalf("clean submodule = %+v", sub)
+	}
+	if _, err := GetSubmodule(dir, "main.txt"); err == nil {
+		t.Error("a regular file is not a submodule")
+	}
+
+	// Move the checkout forward and dirty it
+	commitFiles(t, dir+"/lib", "lib: two", map[string]string{"lib.txt": "v2\n"})
+	writeFile(t, dir+"/lib", "lib.txt", "v3\n")
+	writeFile(t, dir+"/lib", "new.txt", "new\n")
+	sub, err = GetSubmodule(dir, "lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.PointerChanged() || !sub.Modified || !sub.Untracked || sub.PointerStaged() {
+		t.Fatalf("moved submodule = %+v", sub)
+	}
+	log, err := GetSubmoduleLog(dir, sub, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Ahead) != 1 || log.Ahead[0].Subject != "lib: two" || len(log.Behind) != 0 {
+		t.Errorf("log = %+v", log)
+	}
+
+	runGit(t, dir, "add", "lib")
+	if sub, _ = GetSubmodule(dir, "lib"); !sub.PointerStaged() || sub.PointerChanged() {
+		t.Errorf("staged pointer = %+v", sub)
+	}
+}
+
+func TestInitAndUpdateSubmodule(t *testing.T) {
+	dir, _ := newSubmoduleRepo(t)
+	clone := t.TempDir()
+	runGit(t, clone, "clone", "-q", dir, ".")
+
+	subs, err := GetSubmodules(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].Initialized || subs[0].Recorded == "" {
+		t.Fatalf("fresh clone submodules = %+v", subs)
+	}
+
+	if err := InitSubmodule(clone, "lib"); err != nil {
+		t.Fatal(err)
+	}
+	sub, _ := GetSubmodule(clone, "lib")
+	if !sub.Initialized || sub.PointerChanged() {
+		t.Fatalf("initialized submodule = %+v", sub)
+	}
+
+	commitFiles(t, clone+"/lib", "lib: local", map[string]string{"lib.txt": "local\n"})
+	if err := UpdateSubmodule(clone, "lib"); err != nil {
+		t.Fatal(err)
+	}
+	if sub, _ = GetSubmodule(clone, "lib"); sub.PointerChanged() {
+		t.Errorf("update should check out the recorded commit, got %+v", sub)
+	}
+}
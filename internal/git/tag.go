@@ -0,0 +1,342 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag represents a git tag, local, on the remote, or both.
+type Tag struct {
+	Name      string
+	Hash      string    // Target commit hash (peeled for annotated tags)
+	Annotated bool      // Annotated tag object rather than a lightweight ref
+	Subject   string    // First line of the tag message (annotated) or target commit
+	Tagger    string    // Tagger name (annotated) or commit author
+	Date      time.Time // Tag date (annotated) or commit date
+	Local     bool      // Exists in the local repository
+	Remote    bool      // Exists on the remote
+}
+
+// ShortHash returns the abbreviated target hash.
+func (t *Tag) ShortHash() string {
+	if len(t.Hash) > 7 {
+		return t.Hash[:7]
+	}
+	return t.Hash
+}
+
+// TagError wraps a git tag error with its output.
+type TagError struct {
+	Output string
+	Err    error
+}
+
+func (e *TagError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// tagFormat lists a tag's fields separated by NUL. *objectname is only set
+// for annotated tags; tagger fields describe the tag, author fields the
+// commit of a lightweight tag.
+const tagFormat = "%(refname:short)%00%(objecttype)%00%(objectname)%00%(*objectname)%00" +
+	"%(contents:subject)%00%(taggername)%00%(taggerdate:unix)%00%(authorname)%00%(authordate:unix)"
+
+// GetTags returns the local tags, newest first.
+func GetTags(workDir string) ([]*Tag, error) {
+	cmd := exec.Command("git", "for-each-ref", "refs/tags", "--sort=-creatordate", "--format="+tagFormat)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &TagError{Output: string(output), Err: err}
+	}
+	var tags []*Tag
+	for _, line := range splitNonEmptyLines(string(output)) {
+		if tag := parseTagLine(line); tag != nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// parseTagLine parses one line of tagFormat output.
+func parseTagLine(line string) *Tag {
+	f := strings.Split(line, "\x00")
+	if len(f) < 9 {
+		return nil
+	}
+	tag := &Tag{Name: f[0], Local: true}
+	if f[1] == "tag" {
+		tag.Annotated = true
+		tag.Hash = f[3]
+		tag.Subject = f[4]
+		tag.Tagger = f[5]
+		tag.Date = parseUnix(f[6])
+		if tag.Hash == "" {
+			// Tag of a non-commit object; point at the tag itself
+			tag.Hash = f[2]
+		}
+		return tag
+	}
+	tag.Hash = f[2]
+	tag.Subject = f[4]
+	tag.Tagger = f[7]
+	tag.Date = parseUnix(f[8])
+	return tag
+}
+
+// parseUnix parses a unix timestamp, returning the zero time on failure.
+func parseUnix(s string) time.Time {
+	sec, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// GetRemoteTags lists the tags on remote as name -> peeled commit hash.
+// This contacts the remote.
+func GetRemoteTags(workDir, remote string) (map[string]string, error) {
+	cmd := exec.Command("git", "ls-remote", "--tags", remote)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		msg := err.Error()
+		if errors.As(err, &exitErr) {
+			msg = string(exitErr.Stderr)
+		}
+		return nil, &TagError{Output: msg, Err: err}
+	}
+	tags := make(map[string]string)
+	for _, line := range splitNonEmptyLines(string(output)) {
+		hash, ref, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		name := strings.TrimPrefix(ref, "refs/tags/")
+		if peeled, isPeeled := strings.CutSuffix(name, "^{}"); isPeeled {
+			// The peeled commit wins over the tag object hash
+			tags[peeled] = hash
+			continue
+		}
+		if _, exists := tags[name]; !exists {
+			tags[name] = hash
+		}
+	}
+	return tags, nil
+}
+
+// MergeRemoteTags marks local tags present on the remote and appends
+// remote-only tags, sorted by name, after the local ones.
+func MergeRemoteTags(local []*Tag, remote map[string]string) []*Tag {
+	merged := make([]*Tag, 0, len(local)+len(remote))
+	seen := make(map[string]bool, len(local))
+	for _, t := range local {
+		_, t.Remote = remote[t.Name]
+		seen[t.Name] = true
+		merged = append(merged, t)
+	}
+	var remoteOnly []*Tag
+	for name, hash := range remote {
+		if !seen[name] {
+			remoteOnly = append(remoteOnly, &Tag{Name: name, Hash: hash, Remote: true})
+		}
+	}
+	sort.Slice(remoteOnly, func(i, j int) bool { return remoteOnly[i].Name < remoteOnly[j].Name })
+	return append(merged, remoteOnly...)
+}
+
+// TagsByCommit indexes tag names by target commit hash for decorations.
+func TagsByCommit(tags []*Tag) map[string][]string {
+	byHash := make(map[string][]string)
+	for _, t := range tags {
+		if t.Local {
+			byHash[t.Hash] = append(byHash[t.Hash], t.Name)
+		}
+	}
+	return byHash
+}
+
+// ValidateTagName checks name with git check-ref-format.
+func ValidateTagName(workDir, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &TagError{Output: "Tag name is required"}
+	}
+	cmd := exec.Command("git", "check-ref-format", "refs/tags/"+name)
+	cmd.Dir = workDir
+	if err := cmd.Run(); err != nil {
+		return &TagError{Output: "Invalid tag name: " + name, Err: err}
+	}
+	return nil
+}
+
+// CreateTag tags target. A message or sign creates an annotated tag;
+// otherwise the tag is lightweight. Signing uses the configured GPG key.
+func CreateTag(workDir, name, target, message string, sign bool) error {
+	if err := ValidateTagName(workDir, name); err != nil {
+		return err
+	}
+	if sign && message == "" {
+		// Signed tags are annotated and need a message to avoid the editor
+		message = name
+	}
+	args := []string{"tag"}
+	switch {
+	case sign:
+		args = append(args, "-s", "-m", message)
+	case message != "":
+		args = append(args, "-a", "-m", message)
+	}
+	args = append(args, "--", name)
+	if target != "" {
+		args = append(args, target)
+	}
+	return runTagGit(workDir, args...)
+}
+
+// DeleteTag deletes a local tag.
+func DeleteTag(workDir, name string) error {
+	return runTagGit(workDir, "tag", "-d", "--", name)
+}
+
+// DeleteRemoteTag deletes a tag from remote.
+func DeleteRemoteTag(workDir, remote, name string) error {
+	return runTagGit(workDir, "push", remote, "--delete", "refs/tags/"+name)
+}
+
+// PushTag pushes a single tag to remote.
+func PushTag(workDir, remote, name string) error {
+	return runTagGit(workDir, "push", remote, "refs/tags/"+name)
+}
+
+// runTagGit runs a git command, wrapping failures in TagError.
+func runTagGit(workDir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return &TagError{Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// ReleaseSummary describes the commits since a tag, for release notes.
+type ReleaseSummary struct {
+	Tag          string   // Tag the range starts from; empty means all history
+	Commits      []string // "<short hash> <subject>", newest first
+	Authors      []string // Distinct author names, by first appearance
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// GetLatestTagDistance returns the most recent tag reachable from HEAD and
+// the number of commits since it, from a single git call. The tag is "" if
+// there is none.
+func GetLatestTagDistance(workDir string) (tag string, since int) {
+	cmd := exec.Command("git", "describe", "--tags", "--long", "--abbrev=7")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", 0
+	}
+	// <tag>-<count>-g<hash>; the tag itself may contain dashes
+	desc := strings.TrimSpace(string(output))
+	rest, _, ok := cutLast(desc, "-")
+	if !ok {
+		return "", 0
+	}
+	tag, count, ok := cutLast(rest, "-")
+	if !ok {
+		return "", 0
+	}
+	since, _ = strconv.Atoi(count)
+	return tag, since
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// GetReleaseSummary summarizes tag..HEAD. An empty tag summarizes all of HEAD.
+func GetReleaseSummary(workDir, tag string) (*ReleaseSummary, error) {
+	rev := "HEAD"
+	if tag != "" {
+		rev = "refs/tags/" + tag + "..HEAD"
+	}
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%h %s%x00%an", rev)
+	cmd.Dir = workDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &TagError{Output: string(output), Err: err}
+	}
+	summary := &ReleaseSummary{Tag: tag}
+	seen := make(map[string]bool)
+	for _, line := range splitNonEmptyLines(string(output)) {
+		commit, author, _ := strings.Cut(line, "\x00")
+		summary.Commits = append(summary.Commits, commit)
+		if author != "" && !seen[author] {
+			seen[author] = true
+			summary.Authors = append(summary.Authors, author)
+		}
+	}
+
+	if tag != "" {
+		cmd = exec.Command("git", "diff", "--shortstat", "refs/tags/"+tag, "HEAD")
+		cmd.Dir = workDir
+		if output, err := cmd.Output(); err == nil {
+			summary.FilesChanged, summary.Insertions, summary.Deletions = parseShortStat(string(output))
+		}
+	}
+	return summary, nil
+}
+
+// parseShortStat parses "N files changed, N insertions(+), N deletions(-)".
+func parseShortStat(s string) (files, insertions, deletions int) {
+	for _, part := range strings.Split(strings.TrimSpace(s), ",") {
+		fields := strings.Fields(part)
+		if len(fields) < 2 {
+			continue
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(fields[1], "file"):
+			files = n
+		case strings.HasPrefix(fields[1], "insertion"):
+			insertions = n
+		case strings.HasPrefix(fields[1], "deletion"):
+			deletions = n
+		}
+	}
+	return files, insertions, deletions
+}
+
+// Markdown renders the summary as a release notes draft.
+func (s *ReleaseSummary) Markdown() string {
+	var sb strings.Builder
+	if s.Tag != "" {
+		sb.WriteString("## Changes since " + s.Tag + "\n\n")
+	} else {
+		sb.WriteString("## Changes\n\n")
+	}
+	for _, c := range s.Commits {
+		hash, subject, _ := strings.Cut(c, " ")
+		sb.WriteString("- " + subject + " (" + hash + ")\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateAndListTags(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	first := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	commitFiles(t, dir, "feat: second", map[string]string{"a.txt": "b\n"})
+
+	if err := CreateTag(dir, "v1.0.0", first, "First release", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateTag(dir, "light", "", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateTag(dir, "bad..name", "", "", false); err == nil {
+		t.Error("invalid tag names should be refused")
+	}
+
+	tags, err := GetTags(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]*Tag)
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+	if tag := byName["v1.0.0"]; tag == nil || !tag.Annotated || tag.Hash != first || tag.Subject != "First release" {
+		t.Errorf("annotated tag = %+v", tag)
+	}
+	if tag := byName["light"]; tag == nil || tag.Annotated || tag.Subject != "feat: second" {
+		t.Errorf("lightweight tag = %+v", tag)
+	}
+	if decorations := TagsByCommit(tags); len(decorations[first]) != 1 {
+		t.Errorf("decorations = %v", decorations)
+	}
+
+	if err := DeleteTag(dir, "light"); err != nil {
+		t.Fatal(err)
+	}
+	if tags, _ := GetTags(dir); len(tags) != 1 {
+		t.Errorf("expected one tag after delete, got %d", len(tags))
+	}
+}
+
+func TestPushAndDeleteRemoteTag(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	runGit(t, dir, "remote", "add", "origin", remote)
+
+	if err := CreateTag(dir, "v1", "", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := PushTag(dir, "origin", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	remoteTags, err := GetRemoteTags(dir, "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	if remoteTags["v1"] != head {
+		t.Errorf("remote tags = %v", remoteTags)
+	}
+
+	local, _ := GetTags(dir)
+	merged := MergeRemoteTags(local, map[string]string{"v1": head, "v0": "abc"})
+	if len(merged) != 2 || !merged[0].Remote || merged[1].Name != "v0" || merged[1].Local {
+		t.Errorf("merged = %+v, %+v", merged[0], merged[len(merged)-1])
+	}
+
+	if err := DeleteRemoteTag(dir, "origin", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if remoteTags, _ := GetRemoteTags(dir, "origin"); len(remoteTags) != 0 {
+		t.Errorf("remote tag should be deleted, got %v", remoteTags)
+	}
+}
+
+func TestGetReleaseSummary(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	if err := CreateTag(dir, "v1", "", "v1", false); err != nil {
+		t.Fatal(err)
+	}
+	commitFiles(t, dir, "feat: add b", map[string]string{"b.txt": "b\n"})
+	commitFiles(t, dir, "fix: tweak a", map[string]string{"a.txt": "a2\n"})
+
+	if tag, since := GetLatestTagDistance(dir); tag != "v1" || since != 2 {
+		t.Errorf("GetLatestTagDistance = %q, %d", tag, since)
+	}
+	summary, err := GetReleaseSummary(dir, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Commits) != 2 || !strings.HasSuffix(summary.Commits[0], "fix: tweak a") {
+		t.Errorf("commits = %v", summary.Commits)
+	}
+	if summary.FilesChanged != 2 || summary.Insertions != 2 || summary.Deletions != 1 {
+		t.Errorf("stats = %d files +%d -%d", summary.FilesChanged, summary.Insertions, summary.Deletions)
+	}
+	if md := summary.Markdown(); !strings.Contains(md, "## Changes since v1") || !strings.Contains(md, "- feat: add b (") {
+		t.Errorf("markdown = %q", md)
+	}
+}
@@ -0,0 +1,359 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// UndoOp identifies a risky operation that can be reversed.
+type UndoOp string
+
+const (
+	UndoDiscard   UndoOp = "discard"    // Discarded working tree or hunk changes
+	UndoAmend     UndoOp = "amend"      // Amended the last commit
+	UndoForcePush UndoOp = "force-push" // Force pushed the current branch
+	UndoReset     UndoOp = "reset"      // Reset the current branch
+	UndoStashDrop UndoOp = "stash-drop" // Dropped a stash
+)
+
+// maxUndoPoints bounds the undo log kept in the git directory.
+const maxUndoPoints = 20
+
+// undoLogFile is the undo log's name inside the git directory.
+const undoLogFile = "sidecar-undo.json"
+
+// UndoPoint records the state needed to reverse one operation.
+type UndoPoint struct {
+	Op      UndoOp            `json:"op"`
+	Summary string            `json:"summary"`
+	Time    time.Time         `json:"time"`
+	Head    string            `json:"head,omitempty"`    // HEAD before the operation
+	Stash   string            `json:"stash,omitempty"`   // Stash commit of uncommitted changes (git stash create)
+	Paths   []string          `json:"paths,omitempty"`   // Tracked paths restored from Stash
+	Files   map[string]string `json:"files,omitempty"`   // Untracked path -> blob hash
+	Mode    ResetMode         `json:"mode,omitempty"`    // Reset mode
+	Remote  string            `json:"remote,omitempty"`  // Force push remote
+	Branch  string            `json:"branch,omitempty"`  // Force pushed branch
+	Old     string            `json:"old,omitempty"`     // Remote branch before the force push
+	New     string            `json:"new,omitempty"`     // Commit that was force pushed
+	Message string            `json:"message,omitempty"` // Dropped stash message
+}
+
+// UndoError wraps a failure to record or reverse an operation.
+type UndoError struct {
+	Output string
+	Err    error
+}
+
+func (e *UndoError) Error() string {
+	return strings.TrimSpace(e.Output)
+}
+
+func (e *UndoError) Unwrap() error {
+	return e.Err
+}
+
+// CaptureDiscardUndo saves paths, tracked or untracked, before they are
+// discarded. It returns nil when there is nothing to restore.
+func CaptureDiscardUndo(workDir string, paths ...string) (*UndoPoint, error) {
+	point := &UndoPoint{Op: UndoDiscard, Summary: "discard " + strings.Join(paths, ", ")}
+	stash, err := undoGitOutput(workDir, "stash", "create")
+	if err != nil {
+		return nil, err
+	}
+	point.Stash = stash
+	for _, path := range paths {
+		tracked, _ := undoGitOutput(workDir, "ls-files", "--", path)
+		if tracked != "" {
+			if stash != "" {
+				point.Paths = append(point.Paths, path)
+			}
+			continue
+		}
+		blob, err := undoGitOutput(workDir, "hash-object", "-w", "--", path)
+		if err != nil {
+			return nil, err
+		}
+		if point.Files == nil {
+			point.Files = make(map[string]string)
+		}
+		point.Files[path] = blob
+	}
+	if len(point.Paths) == 0 && len(point.Files) == 0 {
+		return nil, nil
+	}
+	return point, nil
+}
+
+// CaptureAmendUndo saves HEAD before the last commit is amended.
+func CaptureAmendUndo(workDir string) (*UndoPoint, error) {
+	head, err := undoGitOutput(workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return &UndoPoint{Op: UndoAmend, Summary: "amend " + shortUndoHash(head), Head: head}, nil
+}
+
+// CaptureResetUndo saves HEAD, and for mixed and hard resets the index and
+// working tree, before a reset.
+func CaptureResetUndo(workDir string, mode ResetMode) (*UndoPoint, error) {
+	head, err := undoGitOutput(workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	point := &UndoPoint{Op: UndoReset, Summary: fmt.Sprintf("reset --%s from %s", mode, shortUndoHash(head)), Head: head, Mode: mode}
+	if mode != ResetSoft {
+		if point.Stash, err = undoGitOutput(workDir, "stash", "create"); err != nil {
+			return nil, err
+		}
+	}
+	return point, nil
+}
+
+// CaptureForcePushUndo saves the remote branch before HEAD is force pushed
+// to it. It returns nil for a branch the remote does not have yet.
+func CaptureForcePushUndo(workDir, remote string) (*UndoPoint, error) {
+	branch, err := undoGitOutput(workDir, "branch", "--show-current")
+	if err != nil || branch == "" {
+		return nil, err
+	}
+	return CaptureForcePushToUndo(workDir, remote, branch)
+}
+
+// CaptureForcePushToUndo is CaptureForcePushUndo for a push of HEAD to a
+// remote branch other than the current branch's namesake.
+func CaptureForcePushToUndo(workDir, remote, branch string) (*UndoPoint, error) {
+	old, _ := undoGitOutput(workDir, "rev-parse", "--verify", "-q", "refs/remotes/"+remote+"/"+branch)
+	if old == "" {
+		return nil, nil
+	}
+	head, err := undoGitOutput(workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return &UndoPoint{
+		Op:      UndoForcePush,
+		Summary: fmt.Sprintf("force push %s to %s", branch, remote),
+		Remote:  remote,
+		Branch:  branch,
+		Old:     old,
+		New:     head,
+	}, nil
+}
+
+// CaptureStashDropUndo saves the stash at ref before it is dropped.
+func CaptureStashDropUndo(workDir, ref string) (*UndoPoint, error) {
+	hash, err := undoGitOutput(workDir, "rev-parse", ref)
+	if err != nil {
+		return nil, err
+	}
+	message, _ := undoGitOutput(workDir, "show", "-s", "--format=%s", hash)
+	return &UndoPoint{Op: UndoStashDrop, Summary: "drop " + ref, Stash: hash, Message: message}, nil
+}
+
+// RecordUndo adds a point captured before an operation to the undo log.
+// Call it only once the operation succeeded; a nil point is ignored.
+func RecordUndo(workDir string, point *UndoPoint) error {
+	if point == nil {
+		return nil
+	}
+	return appendUndoPoint(workDir, point)
+}
+
+// LastUndoPoint returns the most recent undo point, or nil if there is none.
+func LastUndoPoint(workDir string) *UndoPoint {
+	points, err := readUndoLog(workDir)
+	if err != nil || len(points) == 0 {
+		return nil
+	}
+	return points[len(points)-1]
+}
+
+// UndoLast reverses the most recent recorded operation and removes it from
+// the log. On failure the point is kept so the undo can be retried, or
+// dropped with DropLastUndo.
+func UndoLast(workDir string) (*UndoPoint, error) {
+	points, err := readUndoLog(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, &UndoError{Output: "Nothing to undo"}
+	}
+	point := points[len(points)-1]
+	if err := reverseUndoPoint(workDir, point); err != nil {
+		return point, err
+	}
+	return point, writeUndoLog(workDir, points[:len(points)-1])
+}
+
+// DropLastUndo removes the most recent undo point without reversing it,
+// for a point that can no longer be replayed.
+func DropLastUndo(workDir string) (*UndoPoint, error) {
+	points, err := readUndoLog(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, &UndoError{Output: "Nothing to undo"}
+	}
+	point := points[len(points)-1]
+	return point, writeUndoLog(workDir, points[:len(points)-1])
+}
+
+// reverseUndoPoint restores the state recorded in point.
+func reverseUndoPoint(workDir string, point *UndoPoint) error {
+	switch point.Op {
+	case UndoDiscard:
+		if len(point.Paths) > 0 {
+			args := append([]string{"restore", "--source=" + point.Stash + "^2", "--staged", "--"}, point.Paths...)
+			if _, err := undoGitOutput(workDir, args...); err != nil {
+				return err
+			}
+			args = append([]string{"restore", "--source=" + point.Stash, "--worktree", "--"}, point.Paths...)
+			if _, err := undoGitOutput(workDir, args...); err != nil {
+				return err
+			}
+		}
+		for path, blob := range point.Files {
+			obj, err := ObjectReaderFor(workDir).Read(blob)
+			if err != nil {
+				return &UndoError{Output: "Could not restore " + path, Err: err}
+			}
+			full := filepath.Join(workDir, path)
+			if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+				return &UndoError{Output: err.Error(), Err: err}
+			}
+			if err := os.WriteFile(full, obj.Data, 0o644); err != nil {
+				return &UndoError{Output: err.Error(), Err: err}
+			}
+		}
+		return nil
+	case UndoAmend:
+		_, err := undoGitOutput(workDir, "reset", "--soft", point.Head)
+		return err
+	case UndoReset:
+		switch point.Mode {
+		case ResetSoft:
+			_, err := undoGitOutput(workDir, "reset", "--soft", point.Head)
+			return err
+		case ResetMixed:
+			if _, err := undoGitOutput(workDir, "reset", "--mixed", "-q", point.Head); err != nil {
+				return err
+			}
+			if point.Stash != "" {
+				_, err := undoGitOutput(workDir, "read-tree", point.Stash+"^2")
+				return err
+			}
+			return nil
+		default:
+			// --keep refuses to overwrite changes made since the reset
+			if _, err := undoGitOutput(workDir, "reset", "--keep", point.Head); err != nil {
+				return err
+			}
+			if point.Stash != "" {
+				_, err := undoGitOutput(workDir, "stash", "apply", "--index", point.Stash)
+				return err
+			}
+			return nil
+		}
+	case UndoForcePush:
+		// The lease fails if someone pushed on top of our force push
+		_, err := undoGitOutput(workDir, "push",
+			"--force-with-lease=refs/heads/"+point.Branch+":"+point.New,
+			point.Remote, point.Old+":refs/heads/"+point.Branch)
+		return err
+	case UndoStashDrop:
+		_, err := undoGitOutput(workDir, "stash", "store", "-m", point.Message, point.Stash)
+		return err
+	}
+	return &UndoError{Output: fmt.Sprintf("Cannot undo %q", point.Op)}
+}
+
+// undoLogPath returns the undo log path in the repository's git directory.
+func undoLogPath(workDir string) (string, error) {
+	dir, err := undoGitOutput(workDir, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, undoLogFile), nil
+}
+
+// readUndoLog returns the recorded undo points, oldest first.
+func readUndoLog(workDir string) ([]*UndoPoint, error) {
+	path, err := undoLogPath(workDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &UndoError{Output: err.Error(), Err: err}
+	}
+	var points []*UndoPoint
+	if err := json.Unmarshal(data, &points); err != nil {
+		return nil, &UndoError{Output: "Corrupt undo log: " + err.Error(), Err: err}
+	}
+	return points, nil
+}
+
+// writeUndoLog replaces the undo log with points.
+func writeUndoLog(workDir string, points []*UndoPoint) error {
+	path, err := undoLogPath(workDir)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(points)
+	if err != nil {
+		return &UndoError{Output: err.Error(), Err: err}
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return &UndoError{Output: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// appendUndoPoint adds point to the log, dropping the oldest beyond
+// maxUndoPoints.
+func appendUndoPoint(workDir string, point *UndoPoint) error {
+	points, err := readUndoLog(workDir)
+	if err != nil {
+		return err
+	}
+	point.Time = time.Now()
+	points = append(points, point)
+	if len(points) > maxUndoPoints {
+		points = points[len(points)-maxUndoPoints:]
+	}
+	return writeUndoLog(workDir, points)
+}
+
+// undoGitOutput runs git and returns its trimmed stdout, wrapping failures
+// in UndoError.
+func undoGitOutput(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &UndoError{Output: stderr.String(), Err: err}
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// shortUndoHash abbreviates hash for summaries.
+func shortUndoHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
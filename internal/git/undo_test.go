@@ -0,0 +1,223 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUndoLast_Empty(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	var undoErr *UndoError
+	if _, err := UndoLast(dir); !errors.As(err, &undoErr) || undoErr.Error() != "Nothing to undo" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestUndo_Discard(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n", "b.txt": "b\n"})
+	writeFile(t, dir, "a.txt", "staged\n")
+	runGit(t, dir, "add", "a.txt")
+	writeFile(t, dir, "a.txt", "worktree\n")
+	writeFile(t, dir, "new/c.txt", "untracked\n")
+
+	point, err := CaptureDiscardUndo(dir, "a.txt", "new/c.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscardStaged(dir, "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiscardUntracked(dir, "new/c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+
+	point, err = UndoLast(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if point.Op != UndoDiscard {
+		t.Errorf("op = %s", point.Op)
+	}
+	if got := readFile(t, dir, "a.txt"); got != "worktree\n" {
+		t.Errorf("a.txt = %q", got)
+	}
+	if got := indexContent(t, dir, "a.txt"); got != "staged\n" {
+		t.Errorf("staged a.txt = %q", got)
+	}
+	if got := readFile(t, dir, "new/c.txt"); got != "untracked\n" {
+		t.Errorf("new/c.txt = %q", got)
+	}
+	if LastUndoPoint(dir) != nil {
+		t.Error("undo should consume the point")
+	}
+}
+
+func TestUndo_Amend(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	point, err := CaptureAmendUndo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "commit", "-q", "--amend", "-m", "three amended")
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UndoLast(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := logSubjects(t, dir)[0]; got != "three" {
+		t.Errorf("HEAD subject = %q", got)
+	}
+}
+
+func TestUndo_ResetHard(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	writeFile(t, dir, "three.txt", "dirty\n")
+	point, err := CaptureResetUndo(dir, ResetHard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Reset(dir, ResetHard, "HEAD~2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	point, err = UndoLast(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(point.Summary, "reset --hard") {
+		t.Errorf("summary = %q", point.Summary)
+	}
+	if got := logSubjects(t, dir)[0]; got != "three" {
+		t.Errorf("HEAD subject = %q", got)
+	}
+	if got := readFile(t, dir, "three.txt"); got != "dirty\n" {
+		t.Errorf("three.txt = %q", got)
+	}
+}
+
+func TestUndo_ResetMixedRestoresIndex(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	writeFile(t, dir, "one.txt", "staged\n")
+	runGit(t, dir, "add", "one.txt")
+	point, err := CaptureResetUndo(dir, ResetMixed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Reset(dir, ResetMixed, "HEAD~1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UndoLast(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := logSubjects(t, dir)[0]; got != "three" {
+		t.Errorf("HEAD subject = %q", got)
+	}
+	if got := indexContent(t, dir, "one.txt"); got != "staged\n" {
+		t.Errorf("staged one.txt = %q", got)
+	}
+}
+
+func TestUndo_StashDrop(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	writeFile(t, dir, "a.txt", "changed\n")
+	runGit(t, dir, "stash", "push", "-q", "-m", "keep me")
+	point, err := CaptureStashDropUndo(dir, "stash@{0}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := StashDrop(dir, "stash@{0}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UndoLast(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := runGit(t, dir, "stash", "list"); !strings.Contains(got, "keep me") {
+		t.Errorf("stash list = %q", got)
+	}
+}
+
+func TestUndo_ForcePush(t *testing.T) {
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	dir, _ := newRebaseRepo(t)
+	runGit(t, dir, "remote", "add", "origin", remote)
+	runGit(t, dir, "push", "-q", "-u", "origin", "HEAD")
+	pushed := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+	runGit(t, dir, "reset", "-q", "--hard", "HEAD~1")
+	point, err := CaptureForcePushUndo(dir, "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "push", "-q", "--force-with-lease", "origin", "HEAD")
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UndoLast(dir); err != nil {
+		t.Fatal(err)
+	}
+	branch := strings.TrimSpace(runGit(t, dir, "branch", "--show-current"))
+	if got := strings.TrimSpace(runGit(t, remote, "rev-parse", branch)); got != pushed {
+		t.Errorf("remote %s = %s, want %s", branch, got, pushed)
+	}
+}
+
+func TestUndoLog_Capped(t *testing.T) {
+	dir := newTestRepo(t, map[string]string{"a.txt": "a\n"})
+	for i := 0; i < maxUndoPoints+5; i++ {
+		point, err := CaptureAmendUndo(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := RecordUndo(dir, point); err != nil {
+			t.Fatal(err)
+		}
+	}
+	points, err := readUndoLog(dir)
+	if err != nil || len(points) != maxUndoPoints {
+		t.Errorf("got %d points, err=%v", len(points), err)
+	}
+	if path, _ := undoLogPath(dir); filepath.Base(path) != undoLogFile {
+		t.Errorf("log path = %s", path)
+	}
+}
+
+func TestDropLastUndo(t *testing.T) {
+	dir, _ := newRebaseRepo(t)
+	point, err := CaptureAmendUndo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordUndo(dir, point); err != nil {
+		t.Fatal(err)
+	}
+	head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+	dropped, err := DropLastUndo(dir)
+	if err != nil || dropped.Op != UndoAmend {
+		t.Fatalf("DropLastUndo = %+v, %v", dropped, err)
+	}
+	if LastUndoPoint(dir) != nil {
+		t.Error("the point should be gone")
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")); got != head {
+		t.Errorf("dropping should not reverse anything: HEAD = %s, want %s", got, head)
+	}
+	if err := RecordUndo(dir, nil); err != nil || LastUndoPoint(dir) != nil {
+		t.Errorf("a nil point should not be recorded: %v", err)
+	}
+}
@@ -88,6 +88,15 @@ func New() *Renderer {
 	}
 }
 
+// NewWithProtocol creates a renderer for a known protocol, skipping
+// terminal detection
+func NewWithProtocol(protocol Protocol) *Renderer {
+	return &Renderer{
+		protocol: protocol,
+		cache:    make(map[cacheKey]*RenderResult),
+	}
+}
+
 // Protocol returns detected protocol
 func (r *Renderer) Protocol() Protocol {
 	return r.protocol
@@ -97,6 +97,13 @@ func TestRendererNew(t *testing.T) {
 	_ = r.Protocol()
 }
 
+func TestRendererNewWithProtocol(t *testing.T) {
+	r := NewWithProtocol(ProtocolKitty)
+	if r.Protocol() != ProtocolKitty || r.cache == nil {
+		t.Errorf("renderer = %+v", r)
+	}
+}
+
 func TestRendererCacheOperations(t *testing.T) {
 	r := New()
 
@@ -331,3 +338,32 @@ func createTestPNG(path string) error {
 
 	return png.Encode(f, img)
 }
+
+func TestReadInfo(t *testing.T) {
+	dir := t.TempDir()
+	pngPath := filepath.Join(dir, "test.png")
+	if err := createTestPNG(pngPath); err != nil {
+		t.Fatal(err)
+	}
+	info, err := ReadInfo(pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat, _ := os.Stat(pngPath)
+	if info.Width != 2 || info.Height != 2 || info.Format != "png" || info.Size != stat.Size() || !info.HasDimensions() {
+		t.Errorf("info = %+v", info)
+	}
+
+	icoPath := filepath.Join(dir, "favicon.ico")
+	if err := os.WriteFile(icoPath, []byte("not decodable"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err = ReadInfo(icoPath)
+	if err != nil || info.HasDimensions() || info.Format != "" || info.Size != 13 {
+		t.Errorf("undecodable image: info = %+v, err = %v", info, err)
+	}
+
+	if _, err := ReadInfo(filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,46 @@
+package image
+
+import (
+	stdimage "image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+// Info describes an image file without decoding its pixels.
+type Info struct {
+	Width  int
+	Height int
+	Format string // Decoder name, e.g. "png" or "jpeg"; empty if unknown
+	Size   int64  // File size in bytes
+}
+
+// ReadInfo returns the size of the file at path and, when its format can
+// be decoded, its dimensions. An undecodable image is not an error: only
+// Size is set.
+func ReadInfo(path string) (*Info, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{Size: stat.Size()}
+	if cfg, format, err := stdimage.DecodeConfig(f); err == nil {
+		info.Width, info.Height, info.Format = cfg.Width, cfg.Height, format
+	}
+	return info, nil
+}
+
+// HasDimensions reports whether the image's dimensions are known.
+func (i *Info) HasDimensions() bool {
+	return i.Width > 0 && i.Height > 0
+}
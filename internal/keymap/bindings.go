@@ -19,6 +19,8 @@ func DefaultBindings() []Binding {
 		{Key: "7", Command: "focus-plugin-7", Context: ContextGlobal},
 		{Key: "8", Command: "focus-plugin-8", Context: ContextGlobal},
 		{Key: "9", Command: "focus-plugin-9", Context: ContextGlobal},
+		{Key: "ctrl+y", Command: "undo-git-op", Context: ContextGlobal},
+		{Key: "alt+y", Command: "drop-undo-point", Context: ContextGlobal},
 
 		// Navigation (Global defaults)
 		{Key: "j", Command: "cursor-down", Context: ContextGlobal},
@@ -67,12 +69,23 @@ func DefaultBindings() []Binding {
 		{Key: "z", Command: "stash", Context: ContextGitStatus},
 		{Key: "Z", Command: "stash-pop", Context: ContextGitStatus},
 		{Key: "ctrl+z", Command: "stash-apply", Context: ContextGitStatus},
+		{Key: "w", Command: "show-stashes", Context: ContextGitStatus},
 		{Key: "O", Command: "open-in-file-browser", Context: ContextGitStatus},
 		{Key: "o", Command: "open-in-github", Context: ContextGitStatus},
 		{Key: "y", Command: "yank-file", Context: ContextGitStatus},
 		{Key: "Y", Command: "yank-path", Context: ContextGitStatus},
 		{Key: "D", Command: "discard-changes", Context: ContextGitStatus},
 		{Key: "\\", Command: "toggle-sidebar", Context: ContextGitStatus},
+		{Key: "R", Command: "rebase", Context: ContextGitStatus},
+		{Key: "T", Command: "show-tags", Context: ContextGitStatus},
+		{Key: "H", Command: "show-reflog", Context: ContextGitStatus},
+		{Key: "=", Command: "compare", Context: ContextGitStatus},
+		{Key: "M", Command: "show-submodules", Context: ContextGitStatus},
+		{Key: "E", Command: "show-remotes", Context: ContextGitStatus},
+		{Key: "e", Command: "export-patch", Context: ContextGitStatus},
+		{Key: "I", Command: "apply-patch", Context: ContextGitStatus},
+		{Key: "B", Command: "bisect", Context: ContextGitStatus},
+		{Key: "backspace", Command: "leave-submodule", Context: ContextGitStatus},
 
 		// Git status commits context (sidebar)
 		{Key: "j", Command: "cursor-down", Context: ContextGitStatusCommits},
@@ -90,6 +103,15 @@ func DefaultBindings() []Binding {
 		{Key: "N", Command: "prev-match", Context: ContextGitStatusCommits},
 		{Key: "o", Command: "open-in-github", Context: ContextGitStatusCommits},
 		{Key: "v", Command: "toggle-graph", Context: ContextGitStatusCommits},
+		{Key: "R", Command: "rebase", Context: ContextGitStatusCommits},
+		{Key: "space", Command: "mark-commit", Context: ContextGitStatusCommits},
+		{Key: "C", Command: "cherry-pick", Context: ContextGitStatusCommits},
+		{Key: "t", Command: "revert-commit", Context: ContextGitStatusCommits},
+		{Key: "X", Command: "reset-to-commit", Context: ContextGitStatusCommits},
+		{Key: "a", Command: "tag-commit", Context: ContextGitStatusCommits},
+		{Key: "T", Command: "show-tags", Context: ContextGitStatusCommits},
+		{Key: "e", Command: "export-patch", Context: ContextGitStatusCommits},
+		{Key: "B", Command: "bisect", Context: ContextGitStatusCommits},
 		{Key: "P", Command: "push", Context: ContextGitStatusCommits},
 		{Key: "L", Command: "pull", Context: ContextGitStatusCommits},
 		{Key: "\\", Command: "toggle-sidebar", Context: ContextGitStatusCommits},
@@ -103,6 +125,7 @@ func DefaultBindings() []Binding {
 		{Key: "up", Command: "navigate", Context: ContextGitHistorySearch},
 		{Key: "alt+r", Command: "toggle-regex", Context: ContextGitHistorySearch},
 		{Key: "alt+c", Command: "toggle-case", Context: ContextGitHistorySearch},
+		{Key: "tab", Command: "cycle-mode", Context: ContextGitHistorySearch},
 
 		// Git path filter modal context
 		{Key: "enter", Command: "apply-filter", Context: ContextGitPathFilter},
@@ -119,6 +142,7 @@ func DefaultBindings() []Binding {
 		{Key: "v", Command: "toggle-diff-view", Context: ContextGitStatusDiff},
 		{Key: "\\", Command: "toggle-sidebar", Context: ContextGitStatusDiff},
 		{Key: "w", Command: "toggle-wrap", Context: ContextGitStatusDiff},
+		{Key: "W", Command: "diff-options", Context: ContextGitStatusDiff},
 
 		// Git commit preview context
 		{Key: "j", Command: "scroll-down", Context: ContextGitCommitPreview},
@@ -130,6 +154,7 @@ func DefaultBindings() []Binding {
 		{Key: "o", Command: "open-in-github", Context: ContextGitCommitPreview},
 		{Key: "b", Command: "open-in-file-browser", Context: ContextGitCommitPreview},
 		{Key: "\\", Command: "toggle-sidebar", Context: ContextGitCommitPreview},
+		{Key: "W", Command: "diff-options", Context: ContextGitCommitPreview},
 
 		// Git diff context (full screen)
 		{Key: "esc", Command: "close-diff", Context: ContextGitDiff},
@@ -140,8 +165,13 @@ func DefaultBindings() []Binding {
 		{Key: "up", Command: "scroll-up", Context: ContextGitDiff},
 		{Key: "ctrl+d", Command: "page-down", Context: ContextGitDiff},
 		{Key: "ctrl+u", Command: "page-up", Context: ContextGitDiff},
-		{Key: "s", Command: "stage-file", Context: ContextGitDiff},
-		{Key: "u", Command: "unstage-file", Context: ContextGitDiff},
+		{Key: "s", Command: "stage-hunk", Context: ContextGitDiff},
+		{Key: "u", Command: "unstage-hunk", Context: ContextGitDiff},
+		{Key: "D", Command: "discard-hunk", Context: ContextGitDiff},
+		{Key: "z", Command: "stash-hunk", Context: ContextGitDiff},
+		{Key: "n", Command: "next-hunk", Context: ContextGitDiff},
+		{Key: "N", Command: "prev-hunk", Context: ContextGitDiff},
+		{Key: "V", Command: "select-lines", Context: ContextGitDiff},
 		{Key: "[", Command: "prev-file", Context: ContextGitDiff},
 		{Key: "]", Command: "next-file", Context: ContextGitDiff},
 		{Key: "y", Command: "yank-diff", Context: ContextGitDiff},
@@ -149,12 +179,18 @@ func DefaultBindings() []Binding {
 		{Key: "v", Command: "toggle-diff-view", Context: ContextGitDiff},
 		{Key: "\\", Command: "toggle-sidebar", Context: ContextGitDiff},
 		{Key: "w", Command: "toggle-wrap", Context: ContextGitDiff},
+		{Key: "W", Command: "diff-options", Context: ContextGitDiff},
 
 		// Git push menu context
 		{Key: "p", Command: "push", Context: ContextGitPushMenu},
 		{Key: "f", Command: "force-push", Context: ContextGitPushMenu},
 		{Key: "u", Command: "push-upstream", Context: ContextGitPushMenu},
+		{Key: "right", Command: "next-remote", Context: ContextGitPushMenu},
+		{Key: "left", Command: "prev-remote", Context: ContextGitPushMenu},
+		{Key: "b", Command: "edit-push-branch", Context: ContextGitPushMenu},
 		{Key: "esc", Command: "cancel", Context: ContextGitPushMenu},
+		{Key: "enter", Command: "push", Context: ContextGitPushBranch},
+		{Key: "esc", Command: "cancel", Context: ContextGitPushBranch},
 
 		// Git pull menu context
 		{Key: "p", Command: "pull-merge", Context: ContextGitPullMenu},
@@ -180,15 +216,184 @@ func DefaultBindings() []Binding {
 
 		// Git pull conflict context
 		{Key: "a", Command: "abort-pull", Context: ContextGitPullConflict},
+		{Key: "c", Command: "continue-conflict", Context: ContextGitPullConflict},
+		{Key: "r", Command: "resolve-conflicts", Context: ContextGitPullConflict},
 		{Key: "esc", Command: "dismiss", Context: ContextGitPullConflict},
 
+		// Git large files context (staging outside Git LFS)
+		{Key: "y", Command: "confirm-stage", Context: ContextGitLargeFiles},
+		{Key: "esc", Command: "dismiss", Context: ContextGitLargeFiles},
+
 		// Git stash pop context
 		{Key: "y", Command: "confirm-pop", Context: ContextGitStashPop},
 		{Key: "esc", Command: "dismiss", Context: ContextGitStashPop},
 
+		// Git rebase editor context
+		{Key: "enter", Command: "start-rebase", Context: ContextGitRebase},
+		{Key: "esc", Command: "cancel", Context: ContextGitRebase},
+		{Key: "p", Command: "rebase-pick", Context: ContextGitRebase},
+		{Key: "r", Command: "rebase-reword", Context: ContextGitRebase},
+		{Key: "e", Command: "rebase-edit", Context: ContextGitRebase},
+		{Key: "s", Command: "rebase-squash", Context: ContextGitRebase},
+		{Key: "f", Command: "rebase-fixup", Context: ContextGitRebase},
+		{Key: "d", Command: "rebase-drop", Context: ContextGitRebase},
+		{Key: "J", Command: "move-down", Context: ContextGitRebase},
+		{Key: "K", Command: "move-up", Context: ContextGitRebase},
+
+		// Git rebase reword context
+		{Key: "ctrl+s", Command: "save-reword", Context: ContextGitRebaseReword},
+		{Key: "esc", Command: "cancel", Context: ContextGitRebaseReword},
+
+		// Git rebase stopped context
+		{Key: "c", Command: "continue-rebase", Context: ContextGitRebaseStopped},
+		{Key: "s", Command: "skip-rebase", Context: ContextGitRebaseStopped},
+		{Key: "r", Command: "resolve-conflicts", Context: ContextGitRebaseStopped},
+		{Key: "a", Command: "abort-rebase", Context: ContextGitRebaseStopped},
+		{Key: "esc", Command: "dismiss", Context: ContextGitRebaseStopped},
+
+		// Git cherry-pick target picker context
+		{Key: "enter", Command: "select", Context: ContextGitCherryPick},
+		{Key: "esc", Command: "cancel", Context: ContextGitCherryPick},
+
+		// Git reset confirm context
+		{Key: "enter", Command: "confirm-reset", Context: ContextGitReset},
+		{Key: "s", Command: "reset-mode", Context: ContextGitReset},
+		{Key: "m", Command: "reset-mode", Context: ContextGitReset},
+		{Key: "h", Command: "reset-mode", Context: ContextGitReset},
+		{Key: "esc", Command: "cancel", Context: ContextGitReset},
+
+		// Git conflict resolver context
+		{Key: "o", Command: "take-ours", Context: ContextGitConflicts},
+		{Key: "t", Command: "take-theirs", Context: ContextGitConflicts},
+		{Key: "b", Command: "take-both", Context: ContextGitConflicts},
+		{Key: "s", Command: "stage-resolved", Context: ContextGitConflicts},
+		{Key: "c", Command: "continue-conflict", Context: ContextGitConflicts},
+		{Key: "e", Command: "edit-conflict", Context: ContextGitConflicts},
+		{Key: "A", Command: "abort-conflict", Context: ContextGitConflicts},
+		{Key: "esc", Command: "cancel", Context: ContextGitConflicts},
+
+		// Git tags context
+		{Key: "n", Command: "new-tag", Context: ContextGitTags},
+		{Key: "p", Command: "push-tag", Context: ContextGitTags},
+		{Key: "d", Command: "delete-tag", Context: ContextGitTags},
+		{Key: "D", Command: "delete-remote-tag", Context: ContextGitTags},
+		{Key: "enter", Command: "release-notes", Context: ContextGitTags},
+		{Key: "esc", Command: "cancel", Context: ContextGitTags},
+
+		// Git create tag context
+		{Key: "enter", Command: "create-tag", Context: ContextGitCreateTag},
+		{Key: "esc", Command: "cancel", Context: ContextGitCreateTag},
+
+		// Git release notes context
+		{Key: "y", Command: "yank-release-notes", Context: ContextGitRelease},
+		{Key: "esc", Command: "cancel", Context: ContextGitRelease},
+
+		// Git reflog context
+		{Key: "enter", Command: "reset-to-entry", Context: ContextGitReflog},
+		{Key: "r", Command: "reset-to-entry", Context: ContextGitReflog},
+		{Key: "b", Command: "branch-at-entry", Context: ContextGitReflog},
+		{Key: "tab", Command: "next-ref", Context: ContextGitReflog},
+		{Key: "esc", Command: "cancel", Context: ContextGitReflog},
+
+		// Git reflog branch name context
+		{Key: "enter", Command: "create-branch", Context: ContextGitReflogBranch},
+		{Key: "esc", Command: "cancel", Context: ContextGitReflogBranch},
+
+		// Git compare picker context
+		{Key: "enter", Command: "run-compare", Context: ContextGitComparePick},
+		{Key: "tab", Command: "switch-side", Context: ContextGitComparePick},
+		{Key: "esc", Command: "cancel", Context: ContextGitComparePick},
+
+		// Git compare context
+		{Key: "tab", Command: "next-tab", Context: ContextGitCompare},
+		{Key: "v", Command: "toggle-diff-view", Context: ContextGitCompare},
+		{Key: "s", Command: "swap-sides", Context: ContextGitCompare},
+		{Key: "c", Command: "change-refs", Context: ContextGitCompare},
+		{Key: "esc", Command: "cancel", Context: ContextGitCompare},
+
+		// Git submodules context
+		{Key: "enter", Command: "enter-submodule", Context: ContextGitSubmodules},
+		{Key: "i", Command: "init-submodule", Context: ContextGitSubmodules},
+		{Key: "u", Command: "update-submodule", Context: ContextGitSubmodules},
+		{Key: "s", Command: "stage-submodule", Context: ContextGitSubmodules},
+		{Key: "esc", Command: "cancel", Context: ContextGitSubmodules},
+
+		// Git remotes context (remotes panel)
+		{Key: "a", Command: "add-remote", Context: ContextGitRemotes},
+		{Key: "e", Command: "edit-remote", Context: ContextGitRemotes},
+		{Key: "d", Command: "remove-remote", Context: ContextGitRemotes},
+		{Key: "f", Command: "fetch-remote", Context: ContextGitRemotes},
+		{Key: "F", Command: "fetch-all", Context: ContextGitRemotes},
+		{Key: "p", Command: "push-to-remote", Context: ContextGitRemotes},
+		{Key: "esc", Command: "cancel", Context: ContextGitRemotes},
+
+		// Git remote edit context (add/edit remote modal)
+		{Key: "enter", Command: "save-remote", Context: ContextGitRemoteEdit},
+		{Key: "esc", Command: "cancel", Context: ContextGitRemoteEdit},
+
+		// Git patch export context (export patch modal)
+		{Key: "enter", Command: "save-patch", Context: ContextGitPatchExport},
+		{Key: "esc", Command: "cancel", Context: ContextGitPatchExport},
+
+		// Git patch apply context (apply patch modal)
+		{Key: "enter", Command: "load-patch", Context: ContextGitPatchApply},
+		{Key: "esc", Command: "cancel", Context: ContextGitPatchApply},
+
+		// Git bisect start context (choosing the good and bad commits)
+		{Key: "enter", Command: "start-bisect", Context: ContextGitBisectStart},
+		{Key: "esc", Command: "cancel", Context: ContextGitBisectStart},
+
+		// Git bisect context (bisect panel)
+		{Key: "g", Command: "bisect-good", Context: ContextGitBisect},
+		{Key: "b", Command: "bisect-bad", Context: ContextGitBisect},
+		{Key: "s", Command: "bisect-skip", Context: ContextGitBisect},
+		{Key: "r", Command: "bisect-run", Context: ContextGitBisect},
+		{Key: "x", Command: "cancel-run", Context: ContextGitBisect},
+		{Key: "R", Command: "reset-bisect", Context: ContextGitBisect},
+		{Key: "enter", Command: "show-culprit", Context: ContextGitBisect},
+		{Key: "esc", Command: "cancel", Context: ContextGitBisect},
+
+		// Git bisect run context (test command input)
+		{Key: "enter", Command: "run-bisect", Context: ContextGitBisectRun},
+		{Key: "esc", Command: "cancel", Context: ContextGitBisectRun},
+
+		// Git stashes context (stash browser)
+		{Key: "a", Command: "apply-stash", Context: ContextGitStashes},
+		{Key: "p", Command: "pop-stash", Context: ContextGitStashes},
+		{Key: "d", Command: "drop-stash", Context: ContextGitStashes},
+		{Key: "o", Command: "checkout-stash-file", Context: ContextGitStashes},
+		{Key: "b", Command: "stash-branch", Context: ContextGitStashes},
+		{Key: "r", Command: "rename-stash", Context: ContextGitStashes},
+		{Key: "n", Command: "new-stash", Context: ContextGitStashes},
+		{Key: "v", Command: "toggle-diff-view", Context: ContextGitStashes},
+		{Key: "esc", Command: "cancel", Context: ContextGitStashes},
+
+		// Git stash input context (branch name or stash message)
+		{Key: "enter", Command: "confirm", Context: ContextGitStashInput},
+		{Key: "esc", Command: "cancel", Context: ContextGitStashInput},
+
+		// Git stash push context (stash chosen files)
+		{Key: "enter", Command: "stash-files", Context: ContextGitStashPush},
+		{Key: "esc", Command: "cancel", Context: ContextGitStashPush},
+
+		// Git hook output context (commit or push hooks)
+		{Key: "x", Command: "cancel-hook-run", Context: ContextGitHookOutput},
+		{Key: "enter", Command: "open-hook-location", Context: ContextGitHookOutput},
+		{Key: "r", Command: "retry-hook-run", Context: ContextGitHookOutput},
+		{Key: "n", Command: "commit-no-verify", Context: ContextGitHookOutput},
+		{Key: "esc", Command: "cancel", Context: ContextGitHookOutput},
+
+		// Git diff options context
+		{Key: "l", Command: "next-value", Context: ContextGitDiffOptions},
+		{Key: "h", Command: "prev-value", Context: ContextGitDiffOptions},
+		{Key: "r", Command: "reset-options", Context: ContextGitDiffOptions},
+		{Key: "esc", Command: "close", Context: ContextGitDiffOptions},
+
 		// Git commit context
 		{Key: "ctrl+s", Command: "execute-commit", Context: ContextGitCommit},
 		{Key: "ctrl+enter", Command: "execute-commit", Context: ContextGitCommit},
+		{Key: "ctrl+g", Command: "toggle-sign", Context: ContextGitCommit},
+		{Key: "ctrl+t", Command: "toggle-composer", Context: ContextGitCommit},
 		{Key: "esc", Command: "cancel", Context: ContextGitCommit},
 
 		// Git history context
@@ -220,6 +425,8 @@ func DefaultBindings() []Binding {
 		{Key: "y", Command: "yank-details", Context: ContextConversationsSidebar},
 		{Key: "Y", Command: "yank-resume", Context: ContextConversationsSidebar},
 		{Key: "R", Command: "resume-in-workspace", Context: ContextConversationsSidebar},
+		{Key: "W", Command: "live-feed", Context: ContextConversationsSidebar},
+		{Key: "L", Command: "frequent-prompts", Context: ContextConversationsSidebar},
 
 		// Conversations main context (two-pane mode, right pane focused)
 		{Key: "tab", Command: "switch-pane", Context: ContextConversationsMain},
@@ -238,6 +445,26 @@ func DefaultBindings() []Binding {
 		{Key: "y", Command: "yank-details", Context: ContextConversationsMain},
 		{Key: "Y", Command: "yank-resume", Context: ContextConversationsMain},
 		{Key: "R", Command: "resume-in-workspace", Context: ContextConversationsMain},
+		{Key: "P", Command: "save-prompt", Context: ContextConversationsMain},
+
+		// Conversations live feed context
+		{Key: "esc", Command: "back", Context: ContextConversationsLiveFeed},
+		{Key: "j", Command: "cursor-down", Context: ContextConversationsLiveFeed},
+		{Key: "k", Command: "cursor-up", Context: ContextConversationsLiveFeed},
+		{Key: "enter", Command: "open-session", Context: ContextConversationsLiveFeed},
+		{Key: "a", Command: "filter-adapter", Context: ContextConversationsLiveFeed},
+		{Key: "c", Command: "clear-feed", Context: ContextConversationsLiveFeed},
+
+		// Conversations frequent prompts context
+		{Key: "esc", Command: "back", Context: ContextConversationsFrequentPrompts},
+		{Key: "j", Command: "cursor-down", Context: ContextConversationsFrequentPrompts},
+		{Key: "k", Command: "cursor-up", Context: ContextConversationsFrequentPrompts},
+		{Key: "enter", Command: "save-prompt", Context: ContextConversationsFrequentPrompts},
+		{Key: "r", Command: "refresh", Context: ContextConversationsFrequentPrompts},
+
+		// Conversations save-as-prompt modal context
+		{Key: "enter", Command: "confirm", Context: ContextConversationsPromptModal},
+		{Key: "esc", Command: "cancel", Context: ContextConversationsPromptModal},
 
 		// File browser tree context
 		{Key: "tab", Command: "switch-pane", Context: ContextFileBrowserTree},
@@ -283,6 +510,7 @@ func DefaultBindings() []Binding {
 		{Key: "e", Command: "edit", Context: ContextFileBrowserPreview},
 		{Key: "E", Command: "edit-external", Context: ContextFileBrowserPreview},
 		{Key: "B", Command: "blame", Context: ContextFileBrowserPreview},
+		{Key: "H", Command: "line-history", Context: ContextFileBrowserPreview},
 		{Key: "m", Command: "toggle-markdown", Context: ContextFileBrowserPreview},
 		{Key: "esc", Command: "back", Context: ContextFileBrowserPreview},
 		{Key: "h", Command: "back", Context: ContextFileBrowserPreview},
@@ -355,6 +583,7 @@ func DefaultBindings() []Binding {
 		{Key: "N", Command: "reject", Context: ContextWorkspaceList},
 		{Key: "K", Command: "kill-shell", Context: ContextWorkspaceList},
 		{Key: "O", Command: "open-in-git", Context: ContextWorkspaceList},
+		{Key: "=", Command: "compare-branch", Context: ContextWorkspaceList},
 		{Key: "l", Command: "focus-right", Context: ContextWorkspaceList},
 		{Key: "right", Command: "focus-right", Context: ContextWorkspaceList},
 		{Key: "tab", Command: "switch-pane", Context: ContextWorkspaceList},
@@ -368,6 +597,16 @@ func DefaultBindings() []Binding {
 		{Key: "esc", Command: "cancel", Context: ContextWorkspaceFetchPR},
 		{Key: "enter", Command: "fetch", Context: ContextWorkspaceFetchPR},
 
+		// Workspace conflict resolver context
+		{Key: "o", Command: "take-ours", Context: ContextWorkspaceConflicts},
+		{Key: "t", Command: "take-theirs", Context: ContextWorkspaceConflicts},
+		{Key: "b", Command: "take-both", Context: ContextWorkspaceConflicts},
+		{Key: "s", Command: "stage-resolved", Context: ContextWorkspaceConflicts},
+		{Key: "c", Command: "continue-conflict", Context: ContextWorkspaceConflicts},
+		{Key: "e", Command: "edit-conflict", Context: ContextWorkspaceConflicts},
+		{Key: "A", Command: "abort-conflict", Context: ContextWorkspaceConflicts},
+		{Key: "esc", Command: "cancel", Context: ContextWorkspaceConflicts},
+
 		// Workspace preview context
 		{Key: "h", Command: "focus-left", Context: ContextWorkspacePreview},
 		{Key: "left", Command: "focus-left", Context: ContextWorkspacePreview},
@@ -388,6 +627,13 @@ func DefaultBindings() []Binding {
 		{Key: "k", Command: "scroll-up", Context: ContextWorkspacePreview},
 		{Key: "ctrl+d", Command: "page-down", Context: ContextWorkspacePreview},
 		{Key: "ctrl+u", Command: "page-up", Context: ContextWorkspacePreview},
+		{Key: "W", Command: "diff-options", Context: ContextWorkspacePreview},
+
+		// Workspace diff options context
+		{Key: "l", Command: "next-value", Context: ContextWorkspaceDiffOptions},
+		{Key: "h", Command: "prev-value", Context: ContextWorkspaceDiffOptions},
+		{Key: "r", Command: "reset-options", Context: ContextWorkspaceDiffOptions},
+		{Key: "esc", Command: "close", Context: ContextWorkspaceDiffOptions},
 
 		// Workspace merge error context
 		{Key: "esc", Command: "dismiss-merge-error", Context: ContextWorkspaceMergeError},
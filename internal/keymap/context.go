@@ -39,19 +39,50 @@ const (
 	ContextGitCommit        FocusContext = "git-commit"
 	ContextGitHistory       FocusContext = "git-history"
 	ContextGitCommitDetail  FocusContext = "git-commit-detail"
+	ContextGitRebase        FocusContext = "git-rebase"
+	ContextGitRebaseReword  FocusContext = "git-rebase-reword"
+	ContextGitRebaseStopped FocusContext = "git-rebase-stopped"
+	ContextGitCherryPick    FocusContext = "git-cherry-pick"
+	ContextGitReset         FocusContext = "git-reset"
+	ContextGitConflicts     FocusContext = "git-conflicts"
+	ContextGitTags          FocusContext = "git-tags"
+	ContextGitCreateTag     FocusContext = "git-create-tag"
+	ContextGitRelease       FocusContext = "git-release"
+	ContextGitReflog        FocusContext = "git-reflog"
+	ContextGitReflogBranch  FocusContext = "git-reflog-branch"
+	ContextGitComparePick   FocusContext = "git-compare-pick"
+	ContextGitCompare       FocusContext = "git-compare"
+	ContextGitDiffOptions   FocusContext = "git-diff-options"
+	ContextGitSubmodules    FocusContext = "git-submodules"
+	ContextGitRemotes       FocusContext = "git-remotes"
+	ContextGitRemoteEdit    FocusContext = "git-remote-edit"
+	ContextGitPushBranch    FocusContext = "git-push-branch"
+	ContextGitPatchExport   FocusContext = "git-patch-export"
+	ContextGitPatchApply    FocusContext = "git-patch-apply"
+	ContextGitBisectStart   FocusContext = "git-bisect-start"
+	ContextGitBisect        FocusContext = "git-bisect"
+	ContextGitBisectRun     FocusContext = "git-bisect-run"
+	ContextGitStashes       FocusContext = "git-stashes"
+	ContextGitStashInput    FocusContext = "git-stash-input"
+	ContextGitStashPush     FocusContext = "git-stash-push"
+	ContextGitHookOutput    FocusContext = "git-hook-output"
+	ContextGitLargeFiles    FocusContext = "git-large-files"
 
 	// Issue contexts
 	ContextIssueInput   FocusContext = "issue-input"
 	ContextIssuePreview FocusContext = "issue-preview"
 
 	// Conversations contexts
-	ContextConversationsSidebar       FocusContext = "conversations-sidebar"
-	ContextConversationsMain          FocusContext = "conversations-main"
-	ContextConversationsSearch        FocusContext = "conversations-search"
-	ContextConversationsFilter        FocusContext = "conversations-filter"
-	ContextConversationsContentSearch FocusContext = "conversations-content-search"
-	ContextConversationsResumeModal   FocusContext = "conversations-resume-modal"
-	ContextTurnDetail                 FocusContext = "turn-detail"
+	ContextConversationsSidebar         FocusContext = "conversations-sidebar"
+	ContextConversationsMain            FocusContext = "conversations-main"
+	ContextConversationsSearch          FocusContext = "conversations-search"
+	ContextConversationsFilter          FocusContext = "conversations-filter"
+	ContextConversationsContentSearch   FocusContext = "conversations-content-search"
+	ContextConversationsResumeModal     FocusContext = "conversations-resume-modal"
+	ContextConversationsLiveFeed        FocusContext = "conversations-live-feed"
+	ContextConversationsPromptModal     FocusContext = "conversations-prompt-modal"
+	ContextConversationsFrequentPrompts FocusContext = "conversations-frequent-prompts"
+	ContextTurnDetail                   FocusContext = "turn-detail"
 
 	// File browser contexts
 	ContextFileBrowserTree          FocusContext = "file-browser-tree"
@@ -83,6 +114,8 @@ const (
 	ContextWorkspaceTypeSelector       FocusContext = "workspace-type-selector"
 	ContextWorkspaceFetchPR            FocusContext = "workspace-fetch-pr"
 	ContextWorkspaceFilePicker         FocusContext = "workspace-file-picker"
+	ContextWorkspaceConflicts          FocusContext = "workspace-conflicts"
+	ContextWorkspaceDiffOptions        FocusContext = "workspace-diff-options"
 
 	// Notes contexts
 	ContextNotesList        FocusContext = "notes-list"
@@ -129,6 +162,34 @@ func AllContexts() []FocusContext {
 		ContextGitCommit,
 		ContextGitHistory,
 		ContextGitCommitDetail,
+		ContextGitRebase,
+		ContextGitRebaseReword,
+		ContextGitRebaseStopped,
+		ContextGitCherryPick,
+		ContextGitReset,
+		ContextGitConflicts,
+		ContextGitTags,
+		ContextGitCreateTag,
+		ContextGitRelease,
+		ContextGitReflog,
+		ContextGitReflogBranch,
+		ContextGitComparePick,
+		ContextGitCompare,
+		ContextGitDiffOptions,
+		ContextGitSubmodules,
+		ContextGitRemotes,
+		ContextGitRemoteEdit,
+		ContextGitPushBranch,
+		ContextGitPatchExport,
+		ContextGitPatchApply,
+		ContextGitBisectStart,
+		ContextGitBisect,
+		ContextGitBisectRun,
+		ContextGitStashes,
+		ContextGitStashInput,
+		ContextGitStashPush,
+		ContextGitHookOutput,
+		ContextGitLargeFiles,
 		ContextIssueInput,
 		ContextIssuePreview,
 		ContextConversationsSidebar,
@@ -137,6 +198,9 @@ func AllContexts() []FocusContext {
 		ContextConversationsFilter,
 		ContextConversationsContentSearch,
 		ContextConversationsResumeModal,
+		ContextConversationsLiveFeed,
+		ContextConversationsPromptModal,
+		ContextConversationsFrequentPrompts,
 		ContextTurnDetail,
 		ContextFileBrowserTree,
 		ContextFileBrowserPreview,
@@ -165,6 +229,8 @@ func AllContexts() []FocusContext {
 		ContextWorkspaceTypeSelector,
 		ContextWorkspaceFetchPR,
 		ContextWorkspaceFilePicker,
+		ContextWorkspaceConflicts,
+		ContextWorkspaceDiffOptions,
 		ContextNotesList,
 		ContextNotesInfo,
 		ContextNotesSearch,
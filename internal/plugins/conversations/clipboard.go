@@ -67,7 +67,7 @@ func (p *Plugin) yankResumeCommand() tea.Cmd {
 		return nil
 	}
 
-	cmd := resumeCommand(session)
+	cmd := p.resumeCommand(session)
 	if cmd == "" {
 		return nil
 	}
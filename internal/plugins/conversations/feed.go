@@ -0,0 +1,416 @@
+package conversations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/adapter"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	feedMaxEntries   = 500 // Rolling buffer size; oldest entries are dropped first
+	feedPrimeEntries = 3   // Trailing entries shown per session when it first joins the feed
+	feedSnippetChars = 240 // Max characters kept per entry
+)
+
+// FeedEntryKind classifies a live feed entry.
+type FeedEntryKind int
+
+const (
+	FeedEntryText  FeedEntryKind = iota // Assistant text
+	FeedEntryTool                       // Tool call being made
+	FeedEntryError                      // Tool result flagged as error
+)
+
+// FeedEntry is a single line in the live activity feed.
+type FeedEntry struct {
+	Time         time.Time
+	SessionID    string
+	SessionName  string
+	AdapterID    string
+	AdapterIcon  string
+	WorktreeName string
+	Kind         FeedEntryKind
+	Text         string
+}
+
+// LiveFeed tails active sessions across adapters and keeps a rolling stream
+// of what each agent is doing.
+type LiveFeed struct {
+	Entries       []FeedEntry    // Sorted oldest → newest
+	AdapterFilter string         // Adapter ID to show ("" = all)
+	Cursor        int            // Selected row in Visible() (0 = newest)
+	ScrollOff     int            // First visible row
+	seenCounts    map[string]int // session ID -> messages already ingested
+}
+
+// NewLiveFeed creates an empty live feed.
+func NewLiveFeed() *LiveFeed {
+	return &LiveFeed{seenCounts: make(map[string]int)}
+}
+
+// Ingest appends entries for messages the feed has not seen yet and returns
+// the number of entries added. The first time a session is ingested only its
+// trailing feedPrimeEntries entries are kept so the feed isn't flooded with
+// history.
+func (f *LiveFeed) Ingest(session adapter.Session, messages []adapter.Message) int {
+	prev, known := f.seenCounts[session.ID]
+	if prev > len(messages) {
+		// Session was rewritten or truncated; restart from scratch
+		prev, known = 0, false
+	}
+	f.seenCounts[session.ID] = len(messages)
+
+	entries := feedEntriesFromMessages(session, messages[prev:])
+	if !known && len(entries) > feedPrimeEntries {
+		entries = entries[len(entries)-feedPrimeEntries:]
+	}
+	if len(entries) == 0 {
+		return 0
+	}
+
+	f.Entries = append(f.Entries, entries...)
+	sort.SliceStable(f.Entries, func(i, j int) bool {
+		return f.Entries[i].Time.Before(f.Entries[j].Time)
+	})
+	if over := len(f.Entries) - feedMaxEntries; over > 0 {
+		f.Entries = f.Entries[over:]
+	}
+	return len(entries)
+}
+
+// Visible returns entries matching the adapter filter, newest first.
+func (f *LiveFeed) Visible() []FeedEntry {
+	result := make([]FeedEntry, 0, len(f.Entries))
+	for i := len(f.Entries) - 1; i >= 0; i-- {
+		e := f.Entries[i]
+		if f.AdapterFilter != "" && e.AdapterID != f.AdapterFilter {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// reselect moves the cursor to entry, searching Visible() from row from,
+// and shifts the scroll offset by as much so the selection stays in place
+// as newer entries are added above it. The oldest row is selected if entry
+// was dropped from the buffer.
+func (f *LiveFeed) reselect(entry FeedEntry, from int) {
+	visible := f.Visible()
+	if len(visible) == 0 {
+		return
+	}
+	idx := len(visible) - 1
+	for i := max(from, 0); i < len(visible); i++ {
+		if sameFeedEntry(visible[i], entry) {
+			idx = i
+			break
+		}
+	}
+	f.ScrollOff = max(f.ScrollOff+idx-f.Cursor, 0)
+	f.Cursor = idx
+}
+
+// sameFeedEntry reports whether a and b are the same feed line.
+func sameFeedEntry(a, b FeedEntry) bool {
+	return a.Time.Equal(b.Time) && a.SessionID == b.SessionID && a.Kind == b.Kind && a.Text == b.Text
+}
+
+// CycleAdapterFilter advances the filter through "all" and each adapter ID.
+func (f *LiveFeed) CycleAdapterFilter(adapterIDs []string) {
+	if len(adapterIDs) == 0 {
+		f.AdapterFilter = ""
+		return
+	}
+	ids := append([]string(nil), adapterIDs...)
+	sort.Strings(ids)
+	next := ""
+	if f.AdapterFilter == "" {
+		next = ids[0]
+	} else {
+		for i, id := range ids {
+			if id == f.AdapterFilter && i+1 < len(ids) {
+				next = ids[i+1]
+				break
+			}
+		}
+	}
+	f.AdapterFilter = next
+	f.Cursor = 0
+	f.ScrollOff = 0
+}
+
+// Clear drops all entries but keeps per-session progress so old messages
+// are not replayed.
+func (f *LiveFeed) Clear() {
+	f.Entries = nil
+	f.Cursor = 0
+	f.ScrollOff = 0
+}
+
+// feedEntriesFromMessages extracts assistant text, tool calls and errors.
+func feedEntriesFromMessages(session adapter.Session, messages []adapter.Message) []FeedEntry {
+	var entries []FeedEntry
+	add := func(ts time.Time, kind FeedEntryKind, text string) {
+		text = feedSnippet(text)
+		if text == "" {
+			return
+		}
+		entries = append(entries, FeedEntry{
+			Time:         ts,
+			SessionID:    session.ID,
+			SessionName:  feedSessionName(session),
+			AdapterID:    session.AdapterID,
+			AdapterIcon:  session.AdapterIcon,
+			WorktreeName: session.WorktreeName,
+			Kind:         kind,
+			Text:         text,
+		})
+	}
+
+	for _, msg := range messages {
+		if len(msg.ContentBlocks) > 0 {
+			for _, block := range msg.ContentBlocks {
+				switch block.Type {
+				case "text":
+					if msg.Role == "assistant" {
+						add(msg.Timestamp, FeedEntryText, block.Text)
+					}
+				case "tool_use":
+					add(msg.Timestamp, FeedEntryTool, formatFeedTool(block.ToolName, block.ToolInput))
+				case "tool_result":
+					if block.IsError {
+						add(msg.Timestamp, FeedEntryError, block.ToolOutput)
+					}
+				}
+			}
+			continue
+		}
+		if msg.Role != "assistant" {
+			continue
+		}
+		add(msg.Timestamp, FeedEntryText, msg.Content)
+		for _, tu := range msg.ToolUses {
+			add(msg.Timestamp, FeedEntryTool, formatFeedTool(tu.Name, tu.Input))
+		}
+	}
+	return entries
+}
+
+// formatFeedTool renders a tool call as "Name target".
+func formatFeedTool(name, input string) string {
+	if name == "" {
+		return ""
+	}
+	if fp := extractFilePath(input); fp != "" {
+		return name + " " + fp
+	}
+	return name
+}
+
+// feedSnippet collapses whitespace and truncates text for a single feed row.
+func feedSnippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > feedSnippetChars {
+		text = string(runes[:feedSnippetChars-3]) + "..."
+	}
+	return text
+}
+
+// feedSessionName returns the best short label for a session.
+func feedSessionName(session adapter.Session) string {
+	if session.Name != "" {
+		return session.Name
+	}
+	if session.Slug != "" {
+		return session.Slug
+	}
+	return shortID(session.ID)
+}
+
+// FeedLoadedMsg carries the latest messages for a session tailed by the live feed.
+type FeedLoadedMsg struct {
+	Epoch    uint64 // Epoch when request was issued (for stale detection)
+	Session  adapter.Session
+	Messages []adapter.Message
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m FeedLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openLiveFeed switches to the live feed view and primes it from active sessions.
+func (p *Plugin) openLiveFeed() tea.Cmd {
+	if p.feed == nil {
+		p.feed = NewLiveFeed()
+	}
+	p.view = ViewLiveFeed
+	var cmds []tea.Cmd
+	for _, s := range p.sessions {
+		if s.IsActive {
+			cmds = append(cmds, p.loadFeedUpdate(s.ID))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadFeedUpdate reads a session's messages for the live feed.
+func (p *Plugin) loadFeedUpdate(sessionID string) tea.Cmd {
+	var session *adapter.Session
+	for i := range p.sessions {
+		if p.sessions[i].ID == sessionID {
+			session = &p.sessions[i]
+			break
+		}
+	}
+	if session == nil {
+		return nil
+	}
+	a := p.adapters[session.AdapterID]
+	if a == nil {
+		return nil
+	}
+	var epoch uint64
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+	}
+	s := *session
+	return func() tea.Msg {
+		messages, err := a.Messages(s.ID)
+		if err != nil {
+			return nil
+		}
+		return FeedLoadedMsg{Epoch: epoch, Session: s, Messages: messages}
+	}
+}
+
+// liveFeedAdapterIDs returns the IDs of all detected adapters.
+func (p *Plugin) liveFeedAdapterIDs() []string {
+	ids := make([]string, 0, len(p.adapters))
+	for id := range p.adapters {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// liveFeedContentHeight returns the number of rows available for feed entries.
+func (p *Plugin) liveFeedContentHeight() int {
+	h := p.height - 4 // title, rule, status line, blank
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// ensureFeedCursorVisible keeps the feed cursor within the scroll window.
+func (p *Plugin) ensureFeedCursorVisible() {
+	if p.feed == nil {
+		return
+	}
+	h := p.liveFeedContentHeight()
+	if p.feed.Cursor < p.feed.ScrollOff {
+		p.feed.ScrollOff = p.feed.Cursor
+	}
+	if p.feed.Cursor >= p.feed.ScrollOff+h {
+		p.feed.ScrollOff = p.feed.Cursor - h + 1
+	}
+}
+
+// renderLiveFeed renders the live activity feed.
+func (p *Plugin) renderLiveFeed() string {
+	var lines []string
+	lines = append(lines, styles.Title.Render(" Live Feed"))
+	lines = append(lines, styles.Muted.Render(strings.Repeat("━", max(p.width-2, 1))))
+
+	active := 0
+	for _, s := range p.sessions {
+		if s.IsActive {
+			active++
+		}
+	}
+	filter := "all"
+	if p.feed != nil && p.feed.AdapterFilter != "" {
+		filter = p.feed.AdapterFilter
+		if a := p.adapters[filter]; a != nil {
+			filter = a.Name()
+		}
+	}
+	status := fmt.Sprintf(" %d active sessions  │  adapter: %s", active, filter)
+	lines = append(lines, styles.Subtitle.Render(status), "")
+
+	var visible []FeedEntry
+	if p.feed != nil {
+		visible = p.feed.Visible()
+	}
+	if len(visible) == 0 {
+		lines = append(lines, styles.Muted.Render(" Waiting for agent activity..."))
+		return strings.Join(lines, "\n")
+	}
+
+	h := p.liveFeedContentHeight()
+	start := p.feed.ScrollOff
+	if start >= len(visible) {
+		start = 0
+	}
+	end := start + h
+	if end > len(visible) {
+		end = len(visible)
+	}
+	for i := start; i < end; i++ {
+		lines = append(lines, p.renderFeedEntry(visible[i], i == p.feed.Cursor))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderFeedEntry renders one feed row.
+// Format: 15:04:05 [icon] [worktree] session  ▸ text
+func (p *Plugin) renderFeedEntry(e FeedEntry, selected bool) string {
+	icon := renderAdapterIcon(adapter.Session{AdapterID: e.AdapterID, AdapterIcon: e.AdapterIcon})
+	prefix := " " + e.Time.Local().Format("15:04:05") + " "
+
+	label := e.SessionName
+	if runes := []rune(label); len(runes) > 20 {
+		label = string(runes[:17]) + "..."
+	}
+	if e.WorktreeName != "" {
+		wt := e.WorktreeName
+		if runes := []rune(wt); len(runes) > 12 {
+			wt = string(runes[:9]) + "..."
+		}
+		label = "[" + wt + "] " + label
+	}
+
+	var marker string
+	var textStyle lipgloss.Style
+	switch e.Kind {
+	case FeedEntryTool:
+		marker = "⚙"
+		textStyle = styles.Code
+	case FeedEntryError:
+		marker = "✗"
+		textStyle = styles.StatusDeleted
+	default:
+		marker = "▸"
+		textStyle = styles.Body
+	}
+
+	used := lipgloss.Width(prefix) + 2 + len([]rune(label)) + 4
+	textWidth := p.width - used - 1
+	if textWidth < 10 {
+		textWidth = 10
+	}
+	text := ui.TruncateString(e.Text, textWidth)
+
+	if selected {
+		plain := prefix + (e.AdapterIcon) + " " + label + "  " + marker + " " + text
+		return styles.ListItemSelected.Render(ui.TruncateString(plain, max(p.width-1, 1)))
+	}
+	return styles.Muted.Render(prefix) + icon + " " + styles.Subtitle.Render(label) + "  " +
+		textStyle.Render(marker+" "+text)
+}
@@ -0,0 +1,268 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+)
+
+func feedTestMessages(base time.Time, n int) []adapter.Message {
+	msgs := make([]adapter.Message, n)
+	for i := range msgs {
+		msgs[i] = adapter.Message{
+			Role:      "assistant",
+			Content:   "step " + string(rune('a'+i)),
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return msgs
+}
+
+func TestLiveFeedIngestPrimesTrailingEntries(t *testing.T) {
+	f := NewLiveFeed()
+	session := adapter.Session{ID: "s1", Name: "fix bug", AdapterID: "claude-code"}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	added := f.Ingest(session, feedTestMessages(base, 10))
+	if added != feedPrimeEntries {
+		t.Fatalf("expected %d primed entries, got %d", feedPrimeEntries, added)
+	}
+	if got := f.Entries[len(f.Entries)-1].Text; got != "step j" {
+		t.Errorf("expected newest entry 'step j', got %q", got)
+	}
+
+	// Subsequent ingest only adds new messages
+	added = f.Ingest(session, feedTestMessages(base, 12))
+	if added != 2 {
+		t.Fatalf("expected 2 new entries, got %d", added)
+	}
+	if len(f.Entries) != feedPrimeEntries+2 {
+		t.Errorf("expected %d entries, got %d", feedPrimeEntries+2, len(f.Entries))
+	}
+
+	// No new messages -> nothing added
+	if added = f.Ingest(session, feedTestMessages(base, 12)); added != 0 {
+		t.Errorf("expected 0 entries on repeat ingest, got %d", added)
+	}
+}
+
+func TestLiveFeedIngestTruncatedSession(t *testing.T) {
+	f := NewLiveFeed()
+	session := adapter.Session{ID: "s1"}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	f.Ingest(session, feedTestMessages(base, 5))
+	f.Clear()
+
+	// Session shrank (rewritten) - treat it as new and prime again
+	if added := f.Ingest(session, feedTestMessages(base, 2)); added != 2 {
+		t.Errorf("expected 2 entries after truncation, got %d", added)
+	}
+}
+
+func TestLiveFeedBufferCapped(t *testing.T) {
+	f := NewLiveFeed()
+	session := adapter.Session{ID: "s1"}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	f.Ingest(session, nil)
+	msgs := make([]adapter.Message, feedMaxEntries+50)
+	for i := range msgs {
+		msgs[i] = adapter.Message{Role: "assistant", Content: "x", Timestamp: base.Add(time.Duration(i) * time.Second)}
+	}
+	f.Ingest(session, msgs)
+	if len(f.Entries) != feedMaxEntries {
+		t.Errorf("expected buffer capped at %d, got %d", feedMaxEntries, len(f.Entries))
+	}
+}
+
+func TestFeedEntriesFromMessages(t *testing.T) {
+	session := adapter.Session{ID: "s1", Slug: "slug-name", AdapterID: "codex"}
+	ts := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		messages []adapter.Message
+		want     []FeedEntryKind
+		wantText []string
+	}{
+		{
+			name:     "user message skipped",
+			messages: []adapter.Message{{Role: "user", Content: "do something", Timestamp: ts}},
+		},
+		{
+			name: "content blocks",
+			messages: []adapter.Message{{
+				Role:      "assistant",
+				Timestamp: ts,
+				ContentBlocks: []adapter.ContentBlock{
+					{Type: "text", Text: "Looking at\n  the code"},
+					{Type: "tool_use", ToolName: "Read", ToolInput: `{"file_path":"main.go"}`},
+					{Type: "tool_result", ToolOutput: "ok"},
+					{Type: "tool_result", ToolOutput: "permission denied", IsError: true},
+				},
+			}},
+			want:     []FeedEntryKind{FeedEntryText, FeedEntryTool, FeedEntryError},
+			wantText: []string{"Looking at the code", "Read main.go", "permission denied"},
+		},
+		{
+			name: "legacy tool uses",
+			messages: []adapter.Message{{
+				Role:      "assistant",
+				Content:   "Running tests",
+				Timestamp: ts,
+				ToolUses:  []adapter.ToolUse{{Name: "Bash", Input: `{"command":"go test"}`}},
+			}},
+			want:     []FeedEntryKind{FeedEntryText, FeedEntryTool},
+			wantText: []string{"Running tests", "Bash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := feedEntriesFromMessages(session, tt.messages)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d entries, got %d: %+v", len(tt.want), len(got), got)
+			}
+			for i, e := range got {
+				if e.Kind != tt.want[i] {
+					t.Errorf("entry %d: kind = %v, want %v", i, e.Kind, tt.want[i])
+				}
+				if e.Text != tt.wantText[i] {
+					t.Errorf("entry %d: text = %q, want %q", i, e.Text, tt.wantText[i])
+				}
+				if e.SessionName != "slug-name" || e.AdapterID != "codex" {
+					t.Errorf("entry %d: unexpected session fields %+v", i, e)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedSnippetTruncates(t *testing.T) {
+	got := feedSnippet(strings.Repeat("a", feedSnippetChars+10))
+	if len([]rune(got)) != feedSnippetChars {
+		t.Errorf("expected %d chars, got %d", feedSnippetChars, len([]rune(got)))
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("expected ellipsis suffix, got %q", got)
+	}
+}
+
+func TestLiveFeedAdapterFilter(t *testing.T) {
+	f := NewLiveFeed()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	f.Ingest(adapter.Session{ID: "a", AdapterID: "codex"}, feedTestMessages(base, 1))
+	f.Ingest(adapter.Session{ID: "b", AdapterID: "claude-code"}, feedTestMessages(base.Add(time.Minute), 1))
+
+	if len(f.Visible()) != 2 {
+		t.Fatalf("expected 2 visible entries, got %d", len(f.Visible()))
+	}
+	if f.Visible()[0].SessionID != "b" {
+		t.Errorf("expected newest entry first, got %q", f.Visible()[0].SessionID)
+	}
+
+	ids := []string{"codex", "claude-code"}
+	want := []string{"claude-code", "codex", ""}
+	for _, w := range want {
+		f.CycleAdapterFilter(ids)
+		if f.AdapterFilter != w {
+			t.Fatalf("expected filter %q, got %q", w, f.AdapterFilter)
+		}
+		if w != "" && (len(f.Visible()) != 1 || f.Visible()[0].AdapterID != w) {
+			t.Errorf("filter %q: unexpected visible entries %+v", w, f.Visible())
+		}
+	}
+}
+
+func TestUpdateLiveFeedKeys(t *testing.T) {
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.sessions = []adapter.Session{
+		{ID: "s1", AdapterID: "mock", IsActive: true},
+		{ID: "s2", AdapterID: "mock"},
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if p.view != ViewLiveFeed {
+		t.Fatalf("expected live feed view, got %v", p.view)
+	}
+	if cmd == nil {
+		t.Error("expected load command for active session")
+	}
+	if got := p.FocusContext(); got != "conversations-live-feed" {
+		t.Errorf("expected live feed context, got %q", got)
+	}
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.Update(FeedLoadedMsg{Session: p.sessions[0], Messages: feedTestMessages(base, 2)})
+	if len(p.feed.Visible()) != 2 {
+		t.Fatalf("expected 2 feed entries, got %d", len(p.feed.Visible()))
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if p.feed.Cursor != 1 {
+		t.Errorf("expected cursor 1, got %d", p.feed.Cursor)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.view != ViewSessions {
+		t.Errorf("expected sessions view after enter, got %v", p.view)
+	}
+	if p.selectedSession != "s1" {
+		t.Errorf("expected selected session s1, got %q", p.selectedSession)
+	}
+	if p.activePane != PaneMessages {
+		t.Errorf("expected messages pane, got %v", p.activePane)
+	}
+
+	p.activePane = PaneSidebar
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if len(p.feed.Visible()) != 0 {
+		t.Errorf("expected feed cleared, got %d entries", len(p.feed.Visible()))
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.view != ViewSessions {
+		t.Errorf("expected sessions view after esc, got %v", p.view)
+	}
+}
+
+func TestFeedLoadedKeepsSelectedEntry(t *testing.T) {
+	p := New()
+	p.width, p.height = 100, 40
+	p.feed = NewLiveFeed()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s1 := adapter.Session{ID: "s1", AdapterID: "mock"}
+
+	p.Update(FeedLoadedMsg{Session: s1, Messages: feedTestMessages(base, 3)})
+	p.feed.AdapterFilter = "mock"
+	p.feed.Cursor = 1
+
+	assertSelected := func(step string) {
+		t.Helper()
+		visible := p.feed.Visible()
+		if p.feed.Cursor >= len(visible) {
+			t.Fatalf("%s: cursor %d out of %d entries", step, p.feed.Cursor, len(visible))
+		}
+		if got := visible[p.feed.Cursor]; got.SessionID != "s1" || got.Text != "step b" {
+			t.Errorf("%s: selected %s %q, want s1 %q", step, got.SessionID, got.Text, "step b")
+		}
+	}
+	assertSelected("initial")
+
+	p.Update(FeedLoadedMsg{Session: adapter.Session{ID: "s2", AdapterID: "other"}, Messages: feedTestMessages(base.Add(time.Minute), 2)})
+	assertSelected("entries hidden by the filter")
+
+	p.Update(FeedLoadedMsg{Session: adapter.Session{ID: "s3", AdapterID: "mock"}, Messages: feedTestMessages(base.Add(-time.Hour), 2)})
+	assertSelected("older entries sorted below")
+
+	p.Update(FeedLoadedMsg{Session: s1, Messages: feedTestMessages(base, 5)})
+	assertSelected("newer entries above")
+	if p.feed.Cursor != 3 {
+		t.Errorf("cursor = %d, want 3 after two newer entries", p.feed.Cursor)
+	}
+}
@@ -0,0 +1,308 @@
+package conversations
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	frequentPromptsMaxSessions = 200  // Most recent sessions scanned
+	frequentPromptsMaxSamples  = 3000 // Cap on user messages clustered
+	frequentPromptsMinTokens   = 3    // Shorter messages ("yes", "continue") are skipped
+	frequentPromptsMaxClusters = 100
+	promptSimilarityThreshold  = 0.6 // Jaccard similarity to join a cluster
+)
+
+var (
+	promptTaskIDRegex = regexp.MustCompile(`\btd-[0-9a-f]{4,}\b|\b[a-z][a-z0-9]+-[0-9]+\b|#[0-9]+`)
+	promptPathRegex   = regexp.MustCompile(`(?:\.{0,2}/)?(?:[\w.-]+/)+[\w.-]+|\b[\w-]+\.[a-z]{1,4}\b`)
+	promptNumberRegex = regexp.MustCompile(`\b[0-9]+\b`)
+	promptPunctRegex  = regexp.MustCompile(`[^\w<>\s]+`)
+)
+
+// promptSample is one user message considered for clustering.
+type promptSample struct {
+	Text      string
+	SessionID string
+	Time      time.Time
+}
+
+// PromptCluster groups near-duplicate user messages across sessions.
+type PromptCluster struct {
+	Text       string    // Most recent original text in the cluster
+	Count      int       // Number of messages in the cluster
+	SessionIDs []string  // Distinct sessions the prompt appeared in
+	LastUsed   time.Time // Timestamp of most recent message
+
+	tokens map[string]struct{} // Tokens of the first member, used for matching
+}
+
+// normalizePromptText lowercases text and masks IDs, paths and numbers so
+// prompts that differ only in those details compare equal.
+func normalizePromptText(text string) string {
+	text = strings.ToLower(text)
+	text = promptPathRegex.ReplaceAllString(text, " <path> ")
+	text = promptTaskIDRegex.ReplaceAllString(text, " <ticket> ")
+	text = promptNumberRegex.ReplaceAllString(text, " <n> ")
+	text = promptPunctRegex.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// promptTokenSet returns the distinct words of normalized text.
+func promptTokenSet(normalized string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.Fields(normalized) {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// jaccardSimilarity returns |a∩b| / |a∪b|.
+func jaccardSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	inter := 0
+	for w := range small {
+		if _, ok := large[w]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// clusterPrompts greedily groups samples whose normalized token sets are
+// at least threshold-similar. Only clusters seen more than once are
+// returned, most frequent first.
+func clusterPrompts(samples []promptSample, threshold float64) []PromptCluster {
+	// Newest first so each cluster's representative text is its latest use
+	sorted := append([]promptSample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.After(sorted[j].Time)
+	})
+
+	var clusters []*PromptCluster
+	sessionSeen := make(map[*PromptCluster]map[string]bool)
+	for _, s := range sorted {
+		tokens := promptTokenSet(normalizePromptText(s.Text))
+		if len(tokens) < frequentPromptsMinTokens {
+			continue
+		}
+
+		var match *PromptCluster
+		for _, c := range clusters {
+			// Size ratio bounds Jaccard; skip clusters that can't match
+			lo, hi := len(tokens), len(c.tokens)
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if float64(lo)/float64(hi) < threshold {
+				continue
+			}
+			if jaccardSimilarity(tokens, c.tokens) >= threshold {
+				match = c
+				break
+			}
+		}
+		if match == nil {
+			match = &PromptCluster{Text: s.Text, LastUsed: s.Time, tokens: tokens}
+			clusters = append(clusters, match)
+			sessionSeen[match] = make(map[string]bool)
+		}
+		match.Count++
+		if !sessionSeen[match][s.SessionID] {
+			sessionSeen[match][s.SessionID] = true
+			match.SessionIDs = append(match.SessionIDs, s.SessionID)
+		}
+	}
+
+	var result []PromptCluster
+	for _, c := range clusters {
+		if c.Count > 1 {
+			result = append(result, *c)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].LastUsed.After(result[j].LastUsed)
+	})
+	if len(result) > frequentPromptsMaxClusters {
+		result = result[:frequentPromptsMaxClusters]
+	}
+	return result
+}
+
+// FrequentPromptsLoadedMsg carries clustered user prompts across sessions.
+type FrequentPromptsLoadedMsg struct {
+	Epoch    uint64 // Epoch when request was issued (for stale detection)
+	Clusters []PromptCluster
+	Scanned  int // Number of user messages considered
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m FrequentPromptsLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openFrequentPrompts switches to the frequent prompts view and starts
+// scanning recent sessions in the background.
+func (p *Plugin) openFrequentPrompts() tea.Cmd {
+	p.view = ViewFrequentPrompts
+	p.frequentLoading = true
+	p.frequentCursor = 0
+	p.frequentScrollOff = 0
+
+	sessions := p.sessions
+	if len(sessions) > frequentPromptsMaxSessions {
+		sessions = sessions[:frequentPromptsMaxSessions]
+	}
+	sessions = append([]adapter.Session(nil), sessions...)
+	adapters := make(map[string]adapter.Adapter, len(p.adapters))
+	for id, a := range p.adapters {
+		adapters[id] = a
+	}
+	var epoch uint64
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+	}
+
+	return func() tea.Msg {
+		var samples []promptSample
+		for _, s := range sessions {
+			a := adapters[s.AdapterID]
+			if a == nil {
+				continue
+			}
+			messages, err := a.Messages(s.ID)
+			if err != nil {
+				continue
+			}
+			for _, m := range messages {
+				if text := userPromptText(m); text != "" {
+					samples = append(samples, promptSample{Text: text, SessionID: s.ID, Time: m.Timestamp})
+				}
+			}
+			if len(samples) >= frequentPromptsMaxSamples {
+				break
+			}
+		}
+		return FrequentPromptsLoadedMsg{
+			Epoch:    epoch,
+			Clusters: clusterPrompts(samples, promptSimilarityThreshold),
+			Scanned:  len(samples),
+		}
+	}
+}
+
+// updateFrequentPrompts handles key events in the frequent prompts view.
+func (p *Plugin) updateFrequentPrompts(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "L":
+		p.view = ViewSessions
+
+	case "j", "down":
+		if p.frequentCursor < len(p.frequentPrompts)-1 {
+			p.frequentCursor++
+			p.ensureFrequentCursorVisible()
+		}
+
+	case "k", "up":
+		if p.frequentCursor > 0 {
+			p.frequentCursor--
+			p.ensureFrequentCursorVisible()
+		}
+
+	case "g":
+		p.frequentCursor = 0
+		p.frequentScrollOff = 0
+
+	case "G":
+		if len(p.frequentPrompts) > 0 {
+			p.frequentCursor = len(p.frequentPrompts) - 1
+			p.ensureFrequentCursorVisible()
+		}
+
+	case "enter", "P":
+		// Promote the selected cluster's representative prompt
+		if p.frequentCursor < len(p.frequentPrompts) {
+			return p, p.openPromptModal(p.frequentPrompts[p.frequentCursor].Text)
+		}
+
+	case "r":
+		return p, p.openFrequentPrompts()
+	}
+	return p, nil
+}
+
+// frequentPromptsContentHeight returns the number of rows available for clusters.
+func (p *Plugin) frequentPromptsContentHeight() int {
+	h := p.height - 4 // title, rule, status line, blank
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// ensureFrequentCursorVisible keeps the cursor within the scroll window.
+func (p *Plugin) ensureFrequentCursorVisible() {
+	h := p.frequentPromptsContentHeight()
+	if p.frequentCursor < p.frequentScrollOff {
+		p.frequentScrollOff = p.frequentCursor
+	}
+	if p.frequentCursor >= p.frequentScrollOff+h {
+		p.frequentScrollOff = p.frequentCursor - h + 1
+	}
+}
+
+// renderFrequentPrompts renders clustered user prompts.
+// Format: ×count  sessions  last-used  prompt text
+func (p *Plugin) renderFrequentPrompts() string {
+	var lines []string
+	lines = append(lines, styles.Title.Render(" Frequent Prompts"))
+	lines = append(lines, styles.Muted.Render(strings.Repeat("━", max(p.width-2, 1))))
+
+	if p.frequentLoading {
+		lines = append(lines, styles.Muted.Render(" Scanning sessions..."))
+		return strings.Join(lines, "\n")
+	}
+
+	status := fmt.Sprintf(" %d clusters from %d user messages  │  enter: save as prompt", len(p.frequentPrompts), p.frequentScanned)
+	lines = append(lines, styles.Subtitle.Render(status), "")
+
+	if len(p.frequentPrompts) == 0 {
+		lines = append(lines, styles.Muted.Render(" No repeated prompts found"))
+		return strings.Join(lines, "\n")
+	}
+
+	h := p.frequentPromptsContentHeight()
+	start := p.frequentScrollOff
+	if start >= len(p.frequentPrompts) {
+		start = 0
+	}
+	end := min(start+h, len(p.frequentPrompts))
+	for i := start; i < end; i++ {
+		c := p.frequentPrompts[i]
+		meta := fmt.Sprintf(" ×%-3d %3d sess  %s  ", c.Count, len(c.SessionIDs), fmt.Sprintf("%-9s", formatTimeAgo(c.LastUsed)))
+		textWidth := max(p.width-len([]rune(meta))-1, 10)
+		text := ui.TruncateString(strings.Join(strings.Fields(c.Text), " "), textWidth)
+		if i == p.frequentCursor {
+			lines = append(lines, styles.ListItemSelected.Render(meta+text))
+		} else {
+			lines = append(lines, styles.Muted.Render(meta)+styles.Body.Render(text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
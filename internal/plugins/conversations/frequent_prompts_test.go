@@ -0,0 +1,194 @@
+package conversations
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+)
+
+func TestNormalizePromptText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Start work on td-aa4136!", "start work on <ticket>"},
+		{"Fix the bug in internal/app/model.go, line 42", "fix the bug in <path> line <n>"},
+		{"  Review   PROJ-12  please ", "review <ticket> please"},
+	}
+	for _, tt := range tests {
+		if got := normalizePromptText(tt.in); got != tt.want {
+			t.Errorf("normalizePromptText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	a := promptTokenSet("a b c d")
+	b := promptTokenSet("a b c e")
+	if got := jaccardSimilarity(a, b); got != 0.6 {
+		t.Errorf("jaccardSimilarity = %v, want 0.6", got)
+	}
+	if got := jaccardSimilarity(a, a); got != 1 {
+		t.Errorf("identical sets similarity = %v, want 1", got)
+	}
+}
+
+func TestClusterPrompts(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := []promptSample{
+		{Text: "Start work on td-aa4136 and use td to track progress", SessionID: "s1", Time: base},
+		{Text: "Start work on td-bb5555 and use td to track progress", SessionID: "s2", Time: base.Add(time.Hour)},
+		{Text: "start work on td-cc6666, use td to track progress", SessionID: "s2", Time: base.Add(2 * time.Hour)},
+		{Text: "Write release notes for the next version", SessionID: "s3", Time: base},
+		{Text: "Write release notes for the next version", SessionID: "s1", Time: base.Add(time.Minute)},
+		{Text: "Explain how the watcher coalesces events", SessionID: "s1", Time: base},
+		{Text: "yes", SessionID: "s1", Time: base},
+		{Text: "yes", SessionID: "s2", Time: base},
+	}
+
+	clusters := clusterPrompts(samples, promptSimilarityThreshold)
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %+v", len(clusters), clusters)
+	}
+
+	first := clusters[0]
+	if first.Count != 3 {
+		t.Errorf("expected top cluster count 3, got %d", first.Count)
+	}
+	if len(first.SessionIDs) != 2 {
+		t.Errorf("expected 2 distinct sessions, got %v", first.SessionIDs)
+	}
+	if first.Text != "start work on td-cc6666, use td to track progress" {
+		t.Errorf("expected most recent text as representative, got %q", first.Text)
+	}
+	if !first.LastUsed.Equal(base.Add(2 * time.Hour)) {
+		t.Errorf("unexpected LastUsed %v", first.LastUsed)
+	}
+
+	if clusters[1].Count != 2 || clusters[1].Text != "Write release notes for the next version" {
+		t.Errorf("unexpected second cluster %+v", clusters[1])
+	}
+}
+
+func TestUserPromptText(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  adapter.Message
+		want string
+	}{
+		{"assistant ignored", adapter.Message{Role: "assistant", Content: "hello"}, ""},
+		{"plain content", adapter.Message{Role: "user", Content: "  fix it  "}, "fix it"},
+		{"tool result placeholder", adapter.Message{Role: "user", Content: "[1 tool result(s)]"}, ""},
+		{"user query tag", adapter.Message{Role: "user", Content: "<ctx>x</ctx><user_query>do it</user_query>"}, "do it"},
+		{
+			"content blocks",
+			adapter.Message{Role: "user", ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_result", ToolOutput: "ok"},
+				{Type: "text", Text: "next step"},
+			}},
+			"next step",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userPromptText(tt.msg); got != tt.want {
+				t.Errorf("userPromptText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPromptName(t *testing.T) {
+	if got := defaultPromptName("Start   work on the ticket and then review everything"); got != "Start work on the ticket and" {
+		t.Errorf("defaultPromptName() = %q", got)
+	}
+}
+
+func TestUpdateFrequentPromptsView(t *testing.T) {
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.sessions = []adapter.Session{{ID: "s1", AdapterID: "mock"}}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if p.view != ViewFrequentPrompts {
+		t.Fatalf("expected frequent prompts view, got %v", p.view)
+	}
+	if cmd == nil {
+		t.Fatal("expected scan command")
+	}
+	if !p.frequentLoading {
+		t.Error("expected loading state while scanning")
+	}
+	if got := p.FocusContext(); got != "conversations-frequent-prompts" {
+		t.Errorf("expected frequent prompts context, got %q", got)
+	}
+
+	p.Update(FrequentPromptsLoadedMsg{
+		Clusters: []PromptCluster{
+			{Text: "Start work on td-aa4136", Count: 3},
+			{Text: "Write release notes", Count: 2},
+		},
+		Scanned: 10,
+	})
+	if p.frequentLoading || len(p.frequentPrompts) != 2 {
+		t.Fatalf("expected 2 loaded clusters, loading=%v", p.frequentLoading)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !p.showPromptModal {
+		t.Fatal("expected save-as-prompt modal to open")
+	}
+	if p.promptBody != "Start work on td-aa4136" {
+		t.Errorf("unexpected prompt body %q", p.promptBody)
+	}
+	if len(p.promptSuggestions) != 1 || p.promptSuggestions[0].Placeholder != "{{ticket}}" {
+		t.Errorf("expected ticket placeholder suggestion, got %+v", p.promptSuggestions)
+	}
+	if got := p.FocusContext(); got != "conversations-prompt-modal" {
+		t.Errorf("expected prompt modal context, got %q", got)
+	}
+	if !p.ConsumesTextInput() {
+		t.Error("expected prompt modal to consume text input")
+	}
+
+	// Placeholder toggles are reflected in the saved body
+	p.promptApply[0] = true
+	if got := p.promptPreviewBody(); got != "Start work on {{ticket}}" {
+		t.Errorf("promptPreviewBody() = %q", got)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.showPromptModal {
+		t.Error("expected esc to close modal")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.view != ViewSessions {
+		t.Errorf("expected sessions view after esc, got %v", p.view)
+	}
+}
+
+func TestSavePromptRequiresUserMessage(t *testing.T) {
+	p := New()
+	p.activePane = PaneMessages
+	p.messages = []adapter.Message{{ID: "m1", Role: "assistant", Content: "done"}}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if p.showPromptModal {
+		t.Error("modal should not open for assistant message")
+	}
+	if cmd == nil {
+		t.Error("expected error toast command")
+	}
+
+	p.messages = []adapter.Message{{ID: "m1", Role: "user", Content: "Refactor internal/app/model.go"}}
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if !p.showPromptModal {
+		t.Fatal("expected modal to open for user message")
+	}
+	if got := p.promptNameInput.Value(); got != "Refactor internal/app/model.go" {
+		t.Errorf("unexpected default name %q", got)
+	}
+}
@@ -13,9 +13,47 @@ func (p *Plugin) handleMouse(msg tea.MouseMsg) (*Plugin, tea.Cmd) {
 		cmd := p.handleResumeModalMouse(msg)
 		return p, cmd
 	}
+	if p.showPromptModal {
+		cmd := p.handlePromptModalMouse(msg)
+		return p, cmd
+	}
 
 	action := p.mouseHandler.HandleMouse(msg)
 
+	// Frequent prompts only supports scrolling
+	if p.view == ViewFrequentPrompts {
+		switch action.Type {
+		case mouse.ActionScrollUp:
+			if p.frequentCursor > 0 {
+				p.frequentCursor--
+			}
+		case mouse.ActionScrollDown:
+			if p.frequentCursor < len(p.frequentPrompts)-1 {
+				p.frequentCursor++
+			}
+		}
+		p.ensureFrequentCursorVisible()
+		return p, nil
+	}
+
+	// Live feed only supports scrolling
+	if p.view == ViewLiveFeed {
+		if p.feed != nil {
+			switch action.Type {
+			case mouse.ActionScrollUp:
+				if p.feed.Cursor > 0 {
+					p.feed.Cursor--
+				}
+			case mouse.ActionScrollDown:
+				if p.feed.Cursor < len(p.feed.Visible())-1 {
+					p.feed.Cursor++
+				}
+			}
+			p.ensureFeedCursorVisible()
+		}
+		return p, nil
+	}
+
 	switch action.Type {
 	case mouse.ActionClick:
 		return p.handleMouseClick(action)
@@ -19,6 +19,7 @@ import (
 	"github.com/guyghost/sidecar/internal/modal"
 	"github.com/guyghost/sidecar/internal/mouse"
 	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/plugins/workspace"
 	"github.com/guyghost/sidecar/internal/state"
 	"github.com/guyghost/sidecar/internal/ui"
 )
@@ -71,6 +72,8 @@ const (
 	ViewMessages
 	ViewAnalytics
 	ViewMessageDetail
+	ViewLiveFeed
+	ViewFrequentPrompts
 )
 
 // FocusPane represents which pane is active in two-pane mode.
@@ -92,6 +95,7 @@ type renderCacheKey struct {
 type Plugin struct {
 	ctx          *plugin.Context
 	adapters     map[string]adapter.Adapter
+	agents       *workspace.AgentRegistry // Agents that sessions resume in
 	focused      bool
 	mouseHandler *mouse.Handler
 
@@ -138,6 +142,16 @@ type Plugin struct {
 	analyticsScrollOff int
 	analyticsLines     []string // pre-rendered lines for scrolling
 
+	// Live feed state (tails active sessions across adapters)
+	feed *LiveFeed
+
+	// Frequent prompts view state
+	frequentPrompts   []PromptCluster
+	frequentCursor    int
+	frequentScrollOff int
+	frequentScanned   int
+	frequentLoading   bool
+
 	// Layout state
 	activePane         FocusPane // Which pane is focused
 	sidebarRestore     FocusPane // Tracks pane focused before collapse; restored on expand via toggleSidebar()
@@ -238,6 +252,16 @@ type Plugin struct {
 	resumeFocus           int
 	resumeSession         *adapter.Session
 
+	// Save-as-prompt modal state
+	showPromptModal   bool
+	promptModal       *modal.Modal
+	promptModalWidth  int
+	promptNameInput   textinput.Model
+	promptBody        string // original user message text
+	promptScope       int    // 0=project, 1=global
+	promptSuggestions []workspace.PlaceholderSuggestion
+	promptApply       []bool // parallel to promptSuggestions
+
 	// Content search state (td-6ac70a: cross-conversation search)
 	contentSearchMode  bool                // True when content search modal is open
 	contentSearchState *ContentSearchState // Content search state
@@ -357,6 +381,23 @@ func (p *Plugin) resetState() {
 	p.analyticsScrollOff = 0
 	p.analyticsLines = nil
 
+	// Live feed state
+	p.feed = nil
+	if p.view == ViewLiveFeed {
+		p.view = ViewSessions
+	}
+
+	// Frequent prompts state
+	p.frequentPrompts = nil
+	p.frequentCursor = 0
+	p.frequentScrollOff = 0
+	p.frequentScanned = 0
+	p.frequentLoading = false
+	if p.view == ViewFrequentPrompts {
+		p.view = ViewSessions
+	}
+	p.resetPromptModal()
+
 	// Layout state - reset to defaults but preserve sidebarWidth (persisted)
 	p.activePane = PaneSidebar
 	p.sidebarRestore = PaneSidebar
@@ -429,6 +470,7 @@ func (p *Plugin) resetState() {
 // Init initializes the plugin with context.
 func (p *Plugin) Init(ctx *plugin.Context) error {
 	p.ctx = ctx
+	p.agents = workspace.AgentRegistryFromConfig(ctx.Config)
 
 	// Reset all state for clean reinitialization (td-84a1cb)
 	p.resetState()
@@ -546,9 +588,18 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p, cmd
 		}
 
+		if p.showPromptModal {
+			cmd := p.handlePromptModalKeys(msg)
+			return p, cmd
+		}
+
 		switch p.view {
 		case ViewAnalytics:
 			return p.updateAnalytics(msg)
+		case ViewLiveFeed:
+			return p.updateLiveFeed(msg)
+		case ViewFrequentPrompts:
+			return p.updateFrequentPrompts(msg)
 		default:
 			// Route based on active pane
 			if p.activePane == PaneMessages {
@@ -921,6 +972,11 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			cmds = append(cmds, p.scheduleMessageReload(p.selectedSession))
 		}
 
+		// Tail the changed session into the live feed while it is open
+		if p.view == ViewLiveFeed && msg.SessionID != "" {
+			cmds = append(cmds, p.loadFeedUpdate(msg.SessionID))
+		}
+
 		return p, tea.Batch(cmds...)
 
 	case CoalescedRefreshMsg:
@@ -949,6 +1005,36 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 
 		return p, tea.Batch(cmds...)
 
+	case FrequentPromptsLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil // Ignore stale message from previous project
+		}
+		p.frequentPrompts = msg.Clusters
+		p.frequentScanned = msg.Scanned
+		p.frequentLoading = false
+		if p.frequentCursor >= len(p.frequentPrompts) {
+			p.frequentCursor = max(len(p.frequentPrompts)-1, 0)
+		}
+		p.ensureFrequentCursorVisible()
+		return p, nil
+
+	case FeedLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil // Ignore stale message from previous project
+		}
+		if p.feed == nil {
+			return p, nil
+		}
+		// Keep the selected entry in place as newer entries arrive above it.
+		// Entries hidden by the filter or older than the selection don't move it.
+		visible := p.feed.Visible()
+		cursor := p.feed.Cursor
+		if added := p.feed.Ingest(msg.Session, msg.Messages); added > 0 && cursor > 0 && cursor < len(visible) {
+			p.feed.reselect(visible[cursor], cursor)
+			p.ensureFeedCursorVisible()
+		}
+		return p, nil
+
 	case tea.WindowSizeMsg:
 		p.width = msg.Width
 		p.height = msg.Height
@@ -1036,6 +1122,11 @@ func (p *Plugin) View(width, height int) string {
 		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
 	}
 
+	if p.showPromptModal {
+		content := p.renderPromptModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+	}
+
 	var content string
 	if len(p.adapters) == 0 {
 		content = renderNoAdapter()
@@ -1043,6 +1134,10 @@ func (p *Plugin) View(width, height int) string {
 		switch p.view {
 		case ViewAnalytics:
 			content = p.renderAnalytics()
+		case ViewLiveFeed:
+			content = p.renderLiveFeed()
+		case ViewFrequentPrompts:
+			content = p.renderFrequentPrompts()
 		default:
 			content = p.renderTwoPane()
 		}
@@ -1101,9 +1196,31 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "back", Name: "Back", Description: "Return to sidebar", Category: plugin.CategoryNavigation, Context: "conversations-main", Priority: 4},
 			{ID: "open", Name: "Open", Description: "Open in CLI", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 5},
 			{ID: "yank", Name: "Yank", Description: "Yank turn content", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 6},
+			{ID: "save-prompt", Name: "Prompt", Description: "Save user message as prompt", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 6},
 			{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "conversations-main", Priority: 7},
 		}
 	}
+	if p.showPromptModal {
+		return []plugin.Command{
+			{ID: "confirm", Name: "Save", Description: "Save prompt to library", Category: plugin.CategoryActions, Context: "conversations-prompt-modal", Priority: 1},
+			{ID: "cancel", Name: "Cancel", Description: "Cancel", Category: plugin.CategoryActions, Context: "conversations-prompt-modal", Priority: 1},
+		}
+	}
+	if p.view == ViewFrequentPrompts {
+		return []plugin.Command{
+			{ID: "back", Name: "Back", Description: "Return to conversations", Category: plugin.CategoryNavigation, Context: "conversations-frequent-prompts", Priority: 1},
+			{ID: "save-prompt", Name: "Save", Description: "Save prompt to library", Category: plugin.CategoryActions, Context: "conversations-frequent-prompts", Priority: 2},
+			{ID: "refresh", Name: "Refresh", Description: "Rescan sessions", Category: plugin.CategoryActions, Context: "conversations-frequent-prompts", Priority: 3},
+		}
+	}
+	if p.view == ViewLiveFeed {
+		return []plugin.Command{
+			{ID: "back", Name: "Back", Description: "Return to conversations", Category: plugin.CategoryNavigation, Context: "conversations-live-feed", Priority: 1},
+			{ID: "open-session", Name: "Open", Description: "Open session of selected entry", Category: plugin.CategoryActions, Context: "conversations-live-feed", Priority: 2},
+			{ID: "filter-adapter", Name: "Adapter", Description: "Cycle adapter filter", Category: plugin.CategorySearch, Context: "conversations-live-feed", Priority: 2},
+			{ID: "clear-feed", Name: "Clear", Description: "Clear feed entries", Category: plugin.CategoryActions, Context: "conversations-live-feed", Priority: 3},
+		}
+	}
 	if p.view == ViewAnalytics {
 		return []plugin.Command{
 			{ID: "back", Name: "Back", Description: "Return to conversations", Category: plugin.CategoryNavigation, Context: "analytics", Priority: 1},
@@ -1115,6 +1232,8 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "filter", Name: "Filter", Description: "Filter by project", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 2},
 		{ID: "content-search", Name: "Find", Description: "Search content (F)", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 2},
 		{ID: "resume-in-workspace", Name: "Resume", Description: "Resume in workspace", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 3},
+		{ID: "live-feed", Name: "Live", Description: "Live feed of active sessions", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 3},
+		{ID: "frequent-prompts", Name: "Prompts", Description: "Frequently used prompts", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 4},
 		{ID: "yank-details", Name: "Copy Details", Description: "Copy session details", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 3},
 		{ID: "yank-resume", Name: "Copy Resume", Description: "Copy resume command", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 5},
@@ -1131,6 +1250,9 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 	if p.showResumeModal {
 		return keymap.ContextConversationsResumeModal
 	}
+	if p.showPromptModal {
+		return keymap.ContextConversationsPromptModal
+	}
 	if p.searchMode {
 		return keymap.ContextConversationsSearch
 	}
@@ -1144,6 +1266,10 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 	switch p.view {
 	case ViewAnalytics:
 		return keymap.ContextTDMonitor
+	case ViewLiveFeed:
+		return keymap.ContextConversationsLiveFeed
+	case ViewFrequentPrompts:
+		return keymap.ContextConversationsFrequentPrompts
 	default:
 		// Return context based on active pane
 		if p.activePane == PaneSidebar {
@@ -1156,7 +1282,7 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 // ConsumesTextInput reports whether conversation UI currently has a focused
 // text-entry flow where app shortcuts should not intercept characters.
 func (p *Plugin) ConsumesTextInput() bool {
-	return p.searchMode || p.filterMode || p.contentSearchMode
+	return p.searchMode || p.filterMode || p.contentSearchMode || p.showPromptModal
 }
 
 // Diagnostics returns plugin health info.
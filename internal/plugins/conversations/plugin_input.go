@@ -163,6 +163,14 @@ func (p *Plugin) updateSessions(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		p.view = ViewAnalytics
 		return p, nil
 
+	case "W":
+		// Open live feed of active sessions
+		return p, p.openLiveFeed()
+
+	case "L":
+		// Open frequently used prompts across sessions
+		return p, p.openFrequentPrompts()
+
 	case "y":
 		// Yank session details to clipboard
 		return p, p.yankSessionDetails()
@@ -318,6 +326,69 @@ func (p *Plugin) updateSearch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	return p, nil
 }
 
+// updateLiveFeed handles key events in the live feed view.
+func (p *Plugin) updateLiveFeed(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.feed == nil {
+		p.view = ViewSessions
+		return p, nil
+	}
+	visible := p.feed.Visible()
+
+	switch msg.String() {
+	case "esc", "q", "W":
+		p.view = ViewSessions
+
+	case "j", "down":
+		if p.feed.Cursor < len(visible)-1 {
+			p.feed.Cursor++
+			p.ensureFeedCursorVisible()
+		}
+
+	case "k", "up":
+		if p.feed.Cursor > 0 {
+			p.feed.Cursor--
+			p.ensureFeedCursorVisible()
+		}
+
+	case "g":
+		p.feed.Cursor = 0
+		p.feed.ScrollOff = 0
+
+	case "G":
+		if len(visible) > 0 {
+			p.feed.Cursor = len(visible) - 1
+			p.ensureFeedCursorVisible()
+		}
+
+	case "a":
+		p.feed.CycleAdapterFilter(p.liveFeedAdapterIDs())
+
+	case "c":
+		p.feed.Clear()
+
+	case "enter":
+		// Jump to the session that produced the selected entry
+		if p.feed.Cursor < len(visible) {
+			sessionID := visible[p.feed.Cursor].SessionID
+			for i, s := range p.visibleSessions() {
+				if s.ID == sessionID {
+					p.cursor = i
+					p.ensureCursorVisible()
+					break
+				}
+			}
+			p.view = ViewSessions
+			p.setSelectedSession(sessionID)
+			p.activePane = PaneMessages
+			return p, tea.Batch(
+				p.loadMessages(sessionID),
+				p.loadUsage(sessionID),
+			)
+		}
+	}
+	return p, nil
+}
+
 // updateAnalytics handles key events in analytics view.
 func (p *Plugin) updateAnalytics(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	// Calculate max scroll based on content
@@ -676,6 +747,10 @@ func (p *Plugin) updateMessages(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		// Open resume modal for workspace
 		return p, p.openResumeModal()
 
+	case "P":
+		// Save selected user message to the workspace prompt library
+		return p, p.openPromptModal(p.selectedUserPromptText())
+
 	case "F":
 		// Open content search modal (td-6ac70a)
 		return p.openContentSearch()
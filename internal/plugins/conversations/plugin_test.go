@@ -3090,7 +3090,7 @@ func TestResumeCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := resumeCommand(tt.session)
+			result := (&Plugin{}).resumeCommand(tt.session)
 			if result != tt.expected {
 				t.Errorf("resumeCommand() = %q, want %q", result, tt.expected)
 			}
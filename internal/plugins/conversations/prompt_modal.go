@@ -0,0 +1,338 @@
+package conversations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/modal"
+	"github.com/guyghost/sidecar/internal/plugins/workspace"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// Prompt scope constants (index into promptScopeLabels)
+const (
+	promptScopeProject = 0
+	promptScopeGlobal  = 1
+)
+
+// Save-as-prompt modal field IDs
+const (
+	promptNameFieldID         = "prompt-name"
+	promptScopeListID         = "prompt-scope-list"
+	promptSaveID              = "prompt-save"
+	promptCancelID            = "prompt-cancel"
+	promptScopeItemPrefix     = "prompt-scope-"
+	promptPlaceholderIDPrefix = "prompt-placeholder-"
+)
+
+// promptScopeLabels are the options for where the prompt is saved.
+var promptScopeLabels = []string{"Project (.sidecar/config.json)", "Global (~/.config/sidecar/config.json)"}
+
+// promptPreviewLines caps the body preview shown in the modal.
+const promptPreviewLines = 6
+
+// userPromptText returns the human-typed text of a user message, or "" for
+// tool results, command output and non-user messages.
+func userPromptText(msg adapter.Message) string {
+	if msg.Role != "user" {
+		return ""
+	}
+	var text string
+	if len(msg.ContentBlocks) > 0 {
+		var parts []string
+		for _, block := range msg.ContentBlocks {
+			if block.Type == "text" && block.Text != "" {
+				parts = append(parts, block.Text)
+			}
+		}
+		text = strings.Join(parts, "\n")
+	} else {
+		text = msg.Content
+	}
+	text = stripXMLTags(text)
+	if strings.HasSuffix(text, "tool result(s)]") {
+		return ""
+	}
+	return text
+}
+
+// selectedUserPromptText returns the user text under the cursor in the
+// messages pane (flow or turn view).
+func (p *Plugin) selectedUserPromptText() string {
+	if p.turnViewMode {
+		if p.turnCursor < 0 || p.turnCursor >= len(p.turns) {
+			return ""
+		}
+		var parts []string
+		for _, m := range p.turns[p.turnCursor].Messages {
+			if t := userPromptText(m); t != "" {
+				parts = append(parts, t)
+			}
+		}
+		return strings.Join(parts, "\n\n")
+	}
+	if msg := p.getSelectedMessage(); msg != nil {
+		return userPromptText(*msg)
+	}
+	return ""
+}
+
+// defaultPromptName derives a short prompt name from its first words.
+func defaultPromptName(text string) string {
+	words := strings.Fields(text)
+	if len(words) > 6 {
+		words = words[:6]
+	}
+	name := strings.Join(words, " ")
+	if runes := []rune(name); len(runes) > 40 {
+		name = string(runes[:37]) + "..."
+	}
+	return name
+}
+
+// openPromptModal opens the save-as-prompt modal for the given text.
+func (p *Plugin) openPromptModal(text string) tea.Cmd {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "Select a user message to save as prompt", IsError: true}
+		}
+	}
+
+	p.promptBody = text
+	p.promptNameInput = textinput.New()
+	p.promptNameInput.Placeholder = "Prompt name"
+	p.promptNameInput.SetValue(defaultPromptName(text))
+	p.promptNameInput.CharLimit = 60
+	p.promptScope = promptScopeProject
+	p.promptSuggestions = workspace.SuggestPlaceholders(text)
+	p.promptApply = make([]bool, len(p.promptSuggestions))
+
+	// Clear cached modal to rebuild with new content
+	p.promptModal = nil
+	p.promptModalWidth = 0
+	p.showPromptModal = true
+	return nil
+}
+
+// ensurePromptModal builds or caches the save-as-prompt modal.
+func (p *Plugin) ensurePromptModal() {
+	if !p.showPromptModal {
+		return
+	}
+
+	modalW := 70
+	maxW := p.width - 4
+	if maxW < 20 {
+		maxW = 20
+	}
+	if modalW > maxW {
+		modalW = maxW
+	}
+
+	if p.promptModal != nil && p.promptModalWidth == modalW {
+		return
+	}
+	p.promptModalWidth = modalW
+
+	scopeItems := make([]modal.ListItem, len(promptScopeLabels))
+	for i, label := range promptScopeLabels {
+		scopeItems[i] = modal.ListItem{
+			ID:    fmt.Sprintf("%s%d", promptScopeItemPrefix, i),
+			Label: label,
+		}
+	}
+
+	m := modal.New("Save as Prompt",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(promptSaveID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text("Name:")).
+		AddSection(modal.Input(promptNameFieldID, &p.promptNameInput, modal.WithSubmitOnEnter(false))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Text("Save to:")).
+		AddSection(modal.List(promptScopeListID, scopeItems, &p.promptScope, modal.WithMaxVisible(len(scopeItems))))
+
+	if len(p.promptSuggestions) > 0 {
+		m.AddSection(modal.Spacer()).
+			AddSection(modal.Text("Placeholders:"))
+		for i, s := range p.promptSuggestions {
+			label := s.Literal + " → " + s.Placeholder
+			m.AddSection(modal.Checkbox(fmt.Sprintf("%s%d", promptPlaceholderIDPrefix, i), label, &p.promptApply[i]))
+		}
+	}
+
+	p.promptModal = m.
+		AddSection(modal.Spacer()).
+		AddSection(p.promptPreviewSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Save ", promptSaveID),
+			modal.Btn(" Cancel ", promptCancelID),
+		))
+}
+
+// promptPreviewSection renders the prompt body with selected placeholders applied.
+func (p *Plugin) promptPreviewSection() modal.Section {
+	return modal.Custom(
+		func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+			lines := strings.Split(p.promptPreviewBody(), "\n")
+			truncated := len(lines) > promptPreviewLines
+			if truncated {
+				lines = lines[:promptPreviewLines]
+			}
+			for i, line := range lines {
+				lines[i] = ui.TruncateString(line, max(contentWidth, 1))
+			}
+			if truncated {
+				lines = append(lines, "...")
+			}
+			content := styles.Muted.Render("Preview:") + "\n" + styles.Body.Render(strings.Join(lines, "\n"))
+			return modal.RenderedSection{Content: content}
+		},
+		func(msg tea.Msg, focusID string) (string, tea.Cmd) {
+			return "", nil
+		},
+	)
+}
+
+// promptPreviewBody returns the body with the checked placeholders applied.
+func (p *Plugin) promptPreviewBody() string {
+	var selected []workspace.PlaceholderSuggestion
+	for i, s := range p.promptSuggestions {
+		if i < len(p.promptApply) && p.promptApply[i] {
+			selected = append(selected, s)
+		}
+	}
+	return workspace.ApplyPlaceholders(p.promptBody, selected)
+}
+
+// handlePromptModalKeys handles keyboard input for the save-as-prompt modal.
+func (p *Plugin) handlePromptModalKeys(msg tea.KeyMsg) tea.Cmd {
+	p.ensurePromptModal()
+	if p.promptModal == nil {
+		return nil
+	}
+
+	action, cmd := p.promptModal.HandleKey(msg)
+	if c := p.handlePromptModalAction(action); c != nil {
+		return c
+	}
+	return cmd
+}
+
+// handlePromptModalMouse handles mouse input for the save-as-prompt modal.
+func (p *Plugin) handlePromptModalMouse(msg tea.MouseMsg) tea.Cmd {
+	p.ensurePromptModal()
+	if p.promptModal == nil {
+		return nil
+	}
+
+	action := p.promptModal.HandleMouse(msg, p.mouseHandler)
+
+	// Checkbox clicks only return the ID; toggle here
+	if strings.HasPrefix(action, promptPlaceholderIDPrefix) {
+		var idx int
+		_, _ = fmt.Sscanf(action, promptPlaceholderIDPrefix+"%d", &idx)
+		if idx >= 0 && idx < len(p.promptApply) {
+			p.promptApply[idx] = !p.promptApply[idx]
+		}
+		return nil
+	}
+	return p.handlePromptModalAction(action)
+}
+
+// handlePromptModalAction applies a modal action shared by keys and mouse.
+func (p *Plugin) handlePromptModalAction(action string) tea.Cmd {
+	switch action {
+	case promptSaveID:
+		return p.executeSavePrompt()
+	case promptCancelID, "cancel":
+		p.resetPromptModal()
+		return nil
+	}
+
+	if strings.HasPrefix(action, promptScopeItemPrefix) {
+		var idx int
+		_, _ = fmt.Sscanf(action, promptScopeItemPrefix+"%d", &idx)
+		if idx >= 0 && idx < len(promptScopeLabels) {
+			p.promptScope = idx
+		}
+	}
+	return nil
+}
+
+// renderPromptModal renders the save-as-prompt modal over the current view.
+func (p *Plugin) renderPromptModal(width, height int) string {
+	p.ensurePromptModal()
+	if p.promptModal == nil {
+		return ""
+	}
+
+	var background string
+	if p.view == ViewFrequentPrompts {
+		background = p.renderFrequentPrompts()
+	} else {
+		background = p.renderTwoPane()
+	}
+
+	rendered := p.promptModal.Render(width, height, p.mouseHandler)
+	return ui.OverlayModal(background, rendered, width, height)
+}
+
+// resetPromptModal closes and resets the save-as-prompt modal state.
+func (p *Plugin) resetPromptModal() {
+	p.showPromptModal = false
+	p.promptModal = nil
+	p.promptModalWidth = 0
+	p.promptBody = ""
+	p.promptScope = promptScopeProject
+	p.promptSuggestions = nil
+	p.promptApply = nil
+}
+
+// executeSavePrompt writes the prompt to the selected config file.
+func (p *Plugin) executeSavePrompt() tea.Cmd {
+	name := strings.TrimSpace(p.promptNameInput.Value())
+	if name == "" {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "Prompt name is required", IsError: true}
+		}
+	}
+
+	body := p.promptPreviewBody()
+	ticketMode := workspace.TicketNone
+	if workspace.HasTicketPlaceholder(body) {
+		ticketMode = workspace.TicketRequired
+	}
+	prompt := workspace.Prompt{Name: name, TicketMode: ticketMode, Body: body}
+
+	source := "project"
+	if p.promptScope == promptScopeGlobal {
+		source = "global"
+	}
+	home, _ := os.UserHomeDir()
+	globalDir := filepath.Join(home, ".config", "sidecar")
+	var workDir string
+	if p.ctx != nil {
+		workDir = p.ctx.WorkDir
+	}
+	dir := workspace.PromptConfigDir(globalDir, workDir, source)
+
+	p.resetPromptModal()
+	return func() tea.Msg {
+		if err := workspace.SavePrompt(dir, prompt); err != nil {
+			return app.ToastMsg{Message: "Save prompt failed: " + err.Error(), Duration: 3 * time.Second, IsError: true}
+		}
+		return app.ToastMsg{Message: fmt.Sprintf("Saved prompt %q (%s)", name, source), Duration: 2 * time.Second}
+	}
+}
@@ -66,11 +66,13 @@ func (p *Plugin) ensureResumeModal() {
 	}
 
 	// Build agent selection list
-	agentItems := make([]modal.ListItem, len(workspace.AgentTypeOrder))
-	for i, at := range workspace.AgentTypeOrder {
+	registry := p.agentRegistry()
+	agentOrder := registry.Order()
+	agentItems := make([]modal.ListItem, len(agentOrder))
+	for i, at := range agentOrder {
 		agentItems[i] = modal.ListItem{
 			ID:    fmt.Sprintf("%s%d", resumeAgentItemPrefix, i),
-			Label: workspace.AgentDisplayNames[at],
+			Label: registry.DisplayName(at),
 		}
 	}
 
@@ -139,16 +141,16 @@ func (p *Plugin) shouldShowResumeSkipPerms() bool {
 	if !p.isResumeWorktreeMode() {
 		return false
 	}
-	if p.resumeAgentIdx < 0 || p.resumeAgentIdx >= len(workspace.AgentTypeOrder) {
+	agentOrder := p.agentRegistry().Order()
+	if p.resumeAgentIdx < 0 || p.resumeAgentIdx >= len(agentOrder) {
 		return false
 	}
-	agentType := workspace.AgentTypeOrder[p.resumeAgentIdx]
+	agentType := agentOrder[p.resumeAgentIdx]
 	if agentType == workspace.AgentNone {
 		return false
 	}
 	// Only show if agent has a skip permissions flag
-	_, hasFlag := workspace.SkipPermissionsFlags[agentType]
-	return hasFlag && workspace.SkipPermissionsFlags[agentType] != ""
+	return p.agentRegistry().SkipPermsFlag(agentType) != ""
 }
 
 // handleResumeModalKeys handles keyboard input for the resume modal.
@@ -180,7 +182,7 @@ func (p *Plugin) handleResumeModalKeys(msg tea.KeyMsg) tea.Cmd {
 	if strings.HasPrefix(action, resumeAgentItemPrefix) {
 		var idx int
 		_, _ = fmt.Sscanf(action, resumeAgentItemPrefix+"%d", &idx)
-		if idx >= 0 && idx < len(workspace.AgentTypeOrder) {
+		if idx >= 0 && idx < len(p.agentRegistry().Order()) {
 			p.resumeAgentIdx = idx
 		}
 	}
@@ -217,7 +219,7 @@ func (p *Plugin) handleResumeModalMouse(msg tea.MouseMsg) tea.Cmd {
 	if strings.HasPrefix(action, resumeAgentItemPrefix) {
 		var idx int
 		_, _ = fmt.Sscanf(action, resumeAgentItemPrefix+"%d", &idx)
-		if idx >= 0 && idx < len(workspace.AgentTypeOrder) {
+		if idx >= 0 && idx < len(p.agentRegistry().Order()) {
 			p.resumeAgentIdx = idx
 		}
 	}
@@ -251,7 +253,7 @@ func (p *Plugin) openResumeModal() tea.Cmd {
 	}
 
 	// Check if adapter supports resume
-	cmd := resumeCommand(session)
+	cmd := p.resumeCommand(session)
 	if cmd == "" {
 		return func() tea.Msg {
 			return app.ToastMsg{Message: "Resume not supported for " + session.AdapterName, IsError: true}
@@ -276,7 +278,7 @@ func (p *Plugin) openResumeModal() tea.Cmd {
 	p.resumeBaseBranchInput.CharLimit = 100
 
 	// Set default agent based on adapter
-	p.resumeAgentIdx = defaultAgentIdxForAdapter(session.AdapterID)
+	p.resumeAgentIdx = p.defaultAgentIdxForAdapter(session.AdapterID)
 	p.resumeSkipPermissions = false
 
 	// Clear cached modal to rebuild with new session
@@ -306,7 +308,7 @@ func (p *Plugin) executeResume() tea.Cmd {
 	}
 
 	// Generate resume command
-	resumeCmd := resumeCommand(session)
+	resumeCmd := p.resumeCommand(session)
 	if resumeCmd == "" {
 		return func() tea.Msg {
 			return app.ToastMsg{Message: "Resume not supported for " + session.AdapterName, IsError: true}
@@ -329,8 +331,8 @@ func (p *Plugin) executeResume() tea.Cmd {
 		if msg.BaseBranch == "" {
 			msg.BaseBranch = "HEAD"
 		}
-		if p.resumeAgentIdx >= 0 && p.resumeAgentIdx < len(workspace.AgentTypeOrder) {
-			msg.AgentType = workspace.AgentTypeOrder[p.resumeAgentIdx]
+		if agentOrder := p.agentRegistry().Order(); p.resumeAgentIdx >= 0 && p.resumeAgentIdx < len(agentOrder) {
+			msg.AgentType = agentOrder[p.resumeAgentIdx]
 		}
 		msg.SkipPerms = p.resumeSkipPermissions
 	}
@@ -361,33 +363,29 @@ func (p *Plugin) getSessionForResume() *adapter.Session {
 	return nil
 }
 
-// defaultAgentIdxForAdapter returns the index in AgentTypeOrder for the given adapter.
-func defaultAgentIdxForAdapter(adapterID string) int {
-	var agentType workspace.AgentType
-	switch adapterID {
-	case "claude-code":
-		agentType = workspace.AgentClaude
-	case "codex":
-		agentType = workspace.AgentCodex
-	case "gemini-cli":
-		agentType = workspace.AgentGemini
-	case "cursor-cli":
-		agentType = workspace.AgentCursor
-	case "opencode":
-		agentType = workspace.AgentOpenCode
-	default:
-		return 0 // Default to first (Claude)
-	}
-
-	// Find index in AgentTypeOrder
-	for i, at := range workspace.AgentTypeOrder {
-		if at == agentType {
+// defaultAgentIdxForAdapter returns the index in the agent order of the
+// agent whose sessions the given adapter reads.
+func (p *Plugin) defaultAgentIdxForAdapter(adapterID string) int {
+	spec, ok := p.agentRegistry().ForAdapter(adapterID)
+	if !ok {
+		return 0 // Default to first
+	}
+	for i, at := range p.agentRegistry().Order() {
+		if at == spec.ID {
 			return i
 		}
 	}
 	return 0
 }
 
+// agentRegistry returns the configured agents, or the built-ins before Init.
+func (p *Plugin) agentRegistry() *workspace.AgentRegistry {
+	if p.agents == nil {
+		return workspace.DefaultAgentRegistry()
+	}
+	return p.agents
+}
+
 // sanitizeBranchName converts a string to a valid git branch name.
 var branchNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9-]`)
 
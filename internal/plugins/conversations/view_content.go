@@ -298,26 +298,13 @@ func adapterFilterOptions(adapters map[string]adapter.Adapter) []adapterFilterOp
 	return options
 }
 
-func resumeCommand(session *adapter.Session) string {
+// resumeCommand returns the command that resumes session in its agent, or
+// "" when no configured agent can.
+func (p *Plugin) resumeCommand(session *adapter.Session) string {
 	if session == nil || session.ID == "" {
 		return ""
 	}
-	switch session.AdapterID {
-	case "claude-code":
-		return fmt.Sprintf("claude --resume %s", session.ID)
-	case "codex":
-		return fmt.Sprintf("codex resume %s", session.ID)
-	case "opencode":
-		return fmt.Sprintf("opencode --continue -s %s", session.ID)
-	case "gemini-cli":
-		return fmt.Sprintf("gemini --resume %s", session.ID)
-	case "cursor-cli":
-		return fmt.Sprintf("cursor-agent --resume %s", session.ID)
-	case "amp":
-		return fmt.Sprintf("amp --resume %s", session.ID)
-	default:
-		return ""
-	}
+	return p.agentRegistry().ResumeCommand(session.AdapterID, session.ID)
 }
 
 // modelShortName maps model IDs to short display names.
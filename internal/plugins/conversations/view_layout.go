@@ -738,7 +738,7 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 
 	// Header Line 3: Resume command with copy hint
 	if session != nil {
-		resumeCmd := resumeCommand(session)
+		resumeCmd := p.resumeCommand(session)
 		if resumeCmd != "" {
 			maxCmdLen := contentWidth - 12 // Leave room for copy hint
 			if len(resumeCmd) > maxCmdLen {
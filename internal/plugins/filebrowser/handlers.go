@@ -571,6 +571,10 @@ func (p *Plugin) handlePreviewKey(key string) (plugin.Plugin, tea.Cmd) {
 			return p.openBlameView(p.previewFile)
 		}
 
+	case "H":
+		// Show history of the selected lines or current function
+		return p, p.openLineHistory()
+
 	case "[":
 		return p, p.cycleTab(-1)
 
@@ -0,0 +1,50 @@
+package filebrowser
+
+import (
+	"regexp"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/app"
+)
+
+// funcDefRegex matches a function definition line in common languages,
+// capturing the function name.
+var funcDefRegex = regexp.MustCompile(`^\s*(?:func\s+(?:\([^)]*\)\s*)?|(?:async\s+)?def\s+|(?:export\s+)?(?:async\s+)?function\s+|(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?fn\s+)([A-Za-z_]\w*)`)
+
+// lineHistoryTarget returns what the git plugin should trace for the
+// preview: the selected lines, otherwise the function around the current
+// line, otherwise the current line alone. Lines are 1-indexed.
+func (p *Plugin) lineHistoryTarget() (start, end int, funcName string) {
+	if p.selection.HasSelection() {
+		start, end = p.selection.Start.Line, p.selection.End.Line
+		if start > end {
+			start, end = end, start
+		}
+		return start + 1, end + 1, ""
+	}
+	line := p.getCurrentPreviewLine()
+	for i := line; i >= 0 && i < len(p.previewLines); i-- {
+		if m := funcDefRegex.FindStringSubmatch(p.previewLines[i]); m != nil {
+			return 0, 0, m[1]
+		}
+	}
+	return line + 1, line + 1, ""
+}
+
+// openLineHistory shows the history of the lines picked in the preview in
+// the git plugin.
+func (p *Plugin) openLineHistory() tea.Cmd {
+	if p.previewFile == "" || p.isBinary || len(p.previewLines) == 0 {
+		return nil
+	}
+	if p.markdownRenderMode && p.isMarkdownFile() {
+		// Rendered lines don't map to lines of the file
+		return nil
+	}
+	start, end, funcName := p.lineHistoryTarget()
+	msg := LineHistoryMsg{Path: p.previewFile, StartLine: start, EndLine: end, FuncName: funcName}
+	return tea.Sequence(
+		app.FocusPlugin("git-status"),
+		func() tea.Msg { return msg },
+	)
+}
@@ -0,0 +1,45 @@
+package filebrowser
+
+import (
+	"testing"
+
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+func TestLineHistoryTarget(t *testing.T) {
+	p := &Plugin{
+		previewFile: "main.go",
+		previewLines: []string{
+			"package main",
+			"",
+			"func (s *Server) Run[T any](ctx context.Context) error {",
+			"\treturn nil",
+			"}",
+		},
+	}
+	p.selection.Clear()
+
+	p.previewScroll = 3
+	if start, end, fn := p.lineHistoryTarget(); fn != "Run" || start != 0 || end != 0 {
+		t.Errorf("inside a function: got %d,%d %q, want Run", start, end, fn)
+	}
+
+	p.previewScroll = 1
+	if start, end, fn := p.lineHistoryTarget(); fn != "" || start != 2 || end != 2 {
+		t.Errorf("outside a function: got %d,%d %q, want line 2", start, end, fn)
+	}
+
+	p.selection.Start = ui.SelectionPoint{Line: 4, Col: 0}
+	p.selection.End = ui.SelectionPoint{Line: 2, Col: 3}
+	if start, end, fn := p.lineHistoryTarget(); fn != "" || start != 3 || end != 5 {
+		t.Errorf("selection: got %d,%d %q, want 3,5", start, end, fn)
+	}
+
+	if p.openLineHistory() == nil {
+		t.Error("expected a command for a text preview")
+	}
+	p.isBinary = true
+	if p.openLineHistory() != nil {
+		t.Error("binary previews have no line history")
+	}
+}
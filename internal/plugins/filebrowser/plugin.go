@@ -60,7 +60,17 @@ type (
 	WatchEventMsg   struct{}
 	// NavigateToFileMsg requests navigation to a specific file (from other plugins).
 	NavigateToFileMsg struct {
-		Path string // Relative path from workdir
+		Path   string // Relative path from workdir
+		Edit   bool   // Open the file in the inline editor after navigating
+		LineNo int    // 0-indexed line for the editor when Edit is set
+	}
+	// LineHistoryMsg asks the git plugin to show the history of a line
+	// range, or of a function when FuncName is set. Sent after focusing it.
+	LineHistoryMsg struct {
+		Path      string // Relative path from workdir
+		StartLine int    // 1-indexed first line
+		EndLine   int    // 1-indexed last line (inclusive)
+		FuncName  string // Function name; overrides the line range
 	}
 	// RevealErrorMsg is sent when reveal in file manager fails.
 	RevealErrorMsg struct {
@@ -660,6 +670,10 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		return p, tea.Batch(cmds...)
 
 	case NavigateToFileMsg:
+		if msg.Edit {
+			_, navCmd := p.navigateToFile(msg.Path)
+			return p, tea.Batch(navCmd, p.enterInlineEditMode(msg.Path, msg.LineNo))
+		}
 		return p.navigateToFile(msg.Path)
 
 	case RevealErrorMsg:
@@ -823,6 +837,7 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "prev-tab", Name: "Tab←", Description: "Previous tab", Category: plugin.CategoryNavigation, Context: "file-browser-preview", Priority: 3},
 		{ID: "next-tab", Name: "Tab→", Description: "Next tab", Category: plugin.CategoryNavigation, Context: "file-browser-preview", Priority: 3},
 		{ID: "blame", Name: "Blame", Description: "Show git blame", Category: plugin.CategoryView, Context: "file-browser-preview", Priority: 3},
+		{ID: "line-history", Name: "History", Description: "Show history of selected lines or current function", Category: plugin.CategorySearch, Context: "file-browser-preview", Priority: 4},
 		{ID: "search-content", Name: "Search", Description: "Search file content", Category: plugin.CategorySearch, Context: "file-browser-preview", Priority: 3},
 		{ID: "toggle-wrap", Name: "Wrap", Description: "Toggle line wrapping", Category: plugin.CategoryView, Context: "file-browser-preview", Priority: 3},
 		{ID: "toggle-markdown", Name: "Render", Description: "Toggle markdown rendering", Category: plugin.CategoryActions, Context: "file-browser-preview", Priority: 4},
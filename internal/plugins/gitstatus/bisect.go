@@ -0,0 +1,770 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	bisectBadID    = "bisect-bad"
+	bisectGoodID   = "bisect-good"
+	bisectCmdID    = "bisect-command"
+	bisectActionID = "bisect-start"
+)
+
+// Bisect operations reported by BisectOpDoneMsg, besides the marks.
+const (
+	bisectOpStart = "start"
+	bisectOpReset = "reset"
+)
+
+// bisectOutputLines caps the run output kept for the bisect panel.
+const bisectOutputLines = 200
+
+// sessionGrace is how long after a session's last activity a commit is
+// still attributed to it.
+const sessionGrace = 10 * time.Minute
+
+// BisectOpDoneMsg is sent when a bisect start, mark or reset returns.
+type BisectOpDoneMsg struct {
+	Epoch  uint64 // Epoch when request was issued (for stale detection)
+	Op     string // bisectOpStart, bisectOpReset or a BisectMark
+	Output string
+	State  *BisectState
+	Err    error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m BisectOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// BisectRunOutputMsg carries lines printed by a bisect run.
+type BisectRunOutputMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Lines []string
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m BisectRunOutputMsg) GetEpoch() uint64 { return m.Epoch }
+
+// BisectRunDoneMsg is sent when a bisect run exits or is cancelled.
+type BisectRunDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	State *BisectState
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m BisectRunDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// BisectRunTickMsg redraws the elapsed time of a bisect run.
+type BisectRunTickMsg struct{}
+
+// BisectCulpritLoadedMsg is sent when the first bad commit and the agent
+// session it came from are read.
+type BisectCulpritLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Commit  *Commit
+	Session *adapter.Session // Nil when no session was active at the commit
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m BisectCulpritLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openBisect opens the bisect panel when a bisect is running, or the start
+// modal otherwise.
+func (p *Plugin) openBisect() tea.Cmd {
+	if p.bisect != nil && p.bisect.Active {
+		p.openBisectPanel()
+		if p.bisect.Done() && p.bisectCulprit == nil {
+			return p.loadBisectCulprit(p.bisect.Culprit)
+		}
+		return nil
+	}
+	return p.openBisectStart()
+}
+
+// openBisectStart opens the start modal. Two marked commits give the bad
+// and good ends; otherwise the cursor commit is bad and the latest tag good.
+func (p *Plugin) openBisectStart() tea.Cmd {
+	bad, good := "HEAD", p.latestTag
+	if commits := p.selectedCommits(); len(commits) >= 2 {
+		bad, good = shortHash(commits[0].Hash), shortHash(commits[len(commits)-1].Hash)
+	} else if commit := p.cursorCommit(); commit != nil {
+		bad = shortHash(commit.Hash)
+	}
+
+	p.bisectBad = textinput.New()
+	p.bisectBad.Placeholder = "HEAD"
+	p.bisectBad.CharLimit = 200
+	p.bisectBad.SetValue(bad)
+	p.bisectBad.Focus()
+	p.bisectGood = textinput.New()
+	p.bisectGood.Placeholder = "v1.0.0"
+	p.bisectGood.CharLimit = 200
+	p.bisectGood.SetValue(good)
+	p.bisectCmd = p.newBisectCmdInput()
+	p.bisectStartErr = ""
+	p.bisectStartModal = nil
+	p.viewMode = ViewModeBisectStart
+	return textinput.Blink
+}
+
+// newBisectCmdInput returns the test command input, filled with the last
+// command run or the configured default.
+func (p *Plugin) newBisectCmdInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "go test ./..."
+	input.CharLimit = 500
+	command := p.bisectLastCmd
+	if command == "" && p.ctx != nil && p.ctx.Config != nil {
+		command = p.ctx.Config.Plugins.GitStatus.BisectCommand
+	}
+	input.SetValue(command)
+	return input
+}
+
+// closeBisectStart closes the start modal.
+func (p *Plugin) closeBisectStart() {
+	p.viewMode = ViewModeStatus
+	p.bisectStartErr = ""
+	p.bisectStartModal = nil
+	p.bisectStartWidth = 0
+}
+
+// ensureBisectStartModal builds/rebuilds the start modal.
+func (p *Plugin) ensureBisectStartModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.bisectStartModal != nil && p.bisectStartWidth == modalW {
+		return
+	}
+	p.bisectStartWidth = modalW
+
+	p.bisectStartModal = modal.New("Start Bisect",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(bisectActionID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.InputWithLabel(bisectBadID, "Bad commit", &p.bisectBad)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(bisectGoodID, "Good commit", &p.bisectGood)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(bisectCmdID, "Test command (optional, runs git bisect run)", &p.bisectCmd)).
+		AddSection(modal.When(func() bool { return p.bisectStartErr != "" }, modal.Custom(
+			func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+				return modal.RenderedSection{Content: styles.StatusDeleted.Render(strings.TrimSpace(p.bisectStartErr))}
+			}, nil))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Start ", bisectActionID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// updateBisectStart handles key events in the start modal.
+func (p *Plugin) updateBisectStart(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureBisectStartModal()
+	action, cmd := p.bisectStartModal.HandleKey(msg)
+	switch action {
+	case bisectActionID:
+		return p, p.doStartBisect()
+	case "cancel":
+		p.closeBisectStart()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handleBisectStartMouse handles mouse events in the start modal.
+func (p *Plugin) handleBisectStartMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.bisectStartModal == nil {
+		return p, nil
+	}
+	switch p.bisectStartModal.HandleMouse(msg, p.mouseHandler) {
+	case bisectActionID:
+		return p, p.doStartBisect()
+	case "cancel":
+		p.closeBisectStart()
+	}
+	return p, nil
+}
+
+// doStartBisect starts the bisect between the chosen commits.
+func (p *Plugin) doStartBisect() tea.Cmd {
+	bad := strings.TrimSpace(p.bisectBad.Value())
+	good := strings.TrimSpace(p.bisectGood.Value())
+	if bad == "" || good == "" {
+		p.bisectStartErr = "Both a bad and a good commit are required"
+		return nil
+	}
+	if p.bisectBusy != "" {
+		return nil
+	}
+	p.bisectStartErr = ""
+	p.bisectBusy = "Starting bisect..."
+	p.bisectLastCmd = strings.TrimSpace(p.bisectCmd.Value())
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		output, err := StartBisect(workDir, bad, good)
+		if err != nil {
+			return BisectOpDoneMsg{Epoch: epoch, Op: bisectOpStart, Err: err}
+		}
+		state, err := GetBisectState(workDir)
+		return BisectOpDoneMsg{Epoch: epoch, Op: bisectOpStart, Output: output, State: state, Err: err}
+	}
+}
+
+// openBisectPanel shows the bisect panel.
+func (p *Plugin) openBisectPanel() {
+	p.viewMode = ViewModeBisect
+	p.bisectEditingCmd = false
+	p.bisectErr = ""
+	p.bisectModal = nil
+}
+
+// closeBisectPanel hides the bisect panel; a run keeps going in the
+// background.
+func (p *Plugin) closeBisectPanel() {
+	p.viewMode = ViewModeStatus
+	p.bisectEditingCmd = false
+	p.bisectModal = nil
+	p.bisectModalWidth = 0
+}
+
+// updateBisect handles key events in the bisect panel.
+func (p *Plugin) updateBisect(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.bisectEditingCmd {
+		return p.updateBisectCmd(msg)
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.closeBisectPanel()
+	case "g":
+		return p, p.doMarkBisect(BisectGood)
+	case "b":
+		return p, p.doMarkBisect(BisectBad)
+	case "s":
+		return p, p.doMarkBisect(BisectSkip)
+	case "r":
+		if p.bisectRunCancel == nil && !p.bisect.Done() {
+			p.bisectCmd = p.newBisectCmdInput()
+			p.bisectCmd.Focus()
+			p.bisectEditingCmd = true
+			p.bisectErr = ""
+			return p, textinput.Blink
+		}
+	case "x":
+		if p.bisectRunCancel != nil {
+			p.bisectRunCancel()
+		}
+	case "R":
+		return p, p.doResetBisect()
+	case "enter":
+		return p, p.showBisectCulprit()
+	}
+	return p, nil
+}
+
+// updateBisectCmd handles key events while editing the test command.
+func (p *Plugin) updateBisectCmd(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		p.bisectEditingCmd = false
+		p.bisectErr = ""
+		return p, nil
+	case "enter":
+		command := strings.TrimSpace(p.bisectCmd.Value())
+		if command == "" {
+			p.bisectErr = "Test command is required"
+			return p, nil
+		}
+		p.bisectEditingCmd = false
+		return p, p.startBisectRun(command)
+	}
+	var cmd tea.Cmd
+	p.bisectCmd, cmd = p.bisectCmd.Update(msg)
+	return p, cmd
+}
+
+// handleBisectMouse handles mouse events in the bisect panel.
+func (p *Plugin) handleBisectMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.bisectModal == nil {
+		return p, nil
+	}
+	if p.bisectModal.HandleMouse(msg, p.mouseHandler) == "cancel" {
+		p.closeBisectPanel()
+	}
+	return p, nil
+}
+
+// doMarkBisect marks the commit under test.
+func (p *Plugin) doMarkBisect(mark BisectMark) tea.Cmd {
+	if p.bisectBusy != "" || p.bisectRunCancel != nil || p.bisect.Done() {
+		return nil
+	}
+	p.bisectBusy = "Marking " + string(mark) + "..."
+	p.bisectErr = ""
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		output, err := MarkBisect(workDir, mark)
+		if err != nil {
+			return BisectOpDoneMsg{Epoch: epoch, Op: string(mark), Err: err}
+		}
+		state, err := GetBisectState(workDir)
+		return BisectOpDoneMsg{Epoch: epoch, Op: string(mark), Output: output, State: state, Err: err}
+	}
+}
+
+// doResetBisect ends the bisect, cancelling a run first.
+func (p *Plugin) doResetBisect() tea.Cmd {
+	if p.bisectBusy != "" {
+		return nil
+	}
+	if p.bisectRunCancel != nil {
+		p.bisectRunCancel()
+	}
+	p.bisectBusy = "Ending bisect..."
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		err := ResetBisect(workDir)
+		return BisectOpDoneMsg{Epoch: epoch, Op: bisectOpReset, Err: err}
+	}
+}
+
+// handleBisectOpDone updates the panel after a start, mark or reset and
+// reloads the history, whose checked-out commit has moved.
+func (p *Plugin) handleBisectOpDone(msg BisectOpDoneMsg) tea.Cmd {
+	p.bisectBusy = ""
+	if msg.Err != nil {
+		switch {
+		case msg.Op == bisectOpStart && p.viewMode == ViewModeBisectStart:
+			// Keep the modal open so the commits can be fixed
+			p.bisectStartErr = msg.Err.Error()
+			return nil
+		case p.viewMode == ViewModeBisect:
+			p.bisectErr = msg.Err.Error()
+		default:
+			p.showErrorModal("Bisect Failed", msg.Err)
+		}
+		return tea.Batch(p.refresh(), p.loadRecentCommits())
+	}
+
+	reload := tea.Batch(p.refresh(), p.loadRecentCommits())
+	if msg.Op == bisectOpReset {
+		p.bisect = nil
+		p.bisectCulprit, p.bisectSession = nil, nil
+		p.bisectOutput = nil
+		if p.viewMode == ViewModeBisect {
+			p.closeBisectPanel()
+		}
+		return tea.Batch(appmsg.ShowToast("Bisect ended", 2*time.Second), reload)
+	}
+
+	p.bisect = msg.State
+	p.bisectOutput = splitOutputLines(msg.Output)
+	if msg.Op == bisectOpStart {
+		// Marks chose the range; the list now shows the bisect's verdicts
+		p.markedCommits = nil
+		p.closeBisectStart()
+		p.bisectCulprit, p.bisectSession = nil, nil
+		p.openBisectPanel()
+		if p.bisectLastCmd != "" {
+			return tea.Batch(reload, p.startBisectRun(p.bisectLastCmd))
+		}
+	}
+	if p.bisect.Done() {
+		return tea.Batch(reload, p.loadBisectCulprit(p.bisect.Culprit))
+	}
+	return reload
+}
+
+// startBisectRun runs command at each step with git bisect run, streaming
+// its output into the panel.
+func (p *Plugin) startBisectRun(command string) tea.Cmd {
+	if p.bisectRunCancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, 64)
+	p.bisectRunCancel = cancel
+	p.bisectRunEvents = events
+	p.bisectRunStart = time.Now()
+	p.bisectRunElapsed = 0
+	p.bisectLastCmd = command
+	p.bisectOutput = nil
+	p.bisectErr = ""
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	go func() {
+		defer close(events)
+		w := &runOutputWriter{ctx: ctx, events: events, wrap: func(lines []string) tea.Msg {
+			return BisectRunOutputMsg{Epoch: epoch, Lines: lines}
+		}}
+		err := RunBisect(ctx, workDir, command, w)
+		w.flush()
+		state, stateErr := GetBisectState(workDir)
+		if err == nil {
+			err = stateErr
+		}
+		events <- BisectRunDoneMsg{Epoch: epoch, State: state, Err: err}
+	}()
+	return tea.Batch(waitRunEvents(events), bisectRunTick())
+}
+
+// bisectRunTick schedules the next elapsed time redraw.
+func bisectRunTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return BisectRunTickMsg{}
+	})
+}
+
+// handleBisectRunOutput appends streamed lines and waits for more.
+func (p *Plugin) handleBisectRunOutput(msg BisectRunOutputMsg) tea.Cmd {
+	p.bisectOutput = append(p.bisectOutput, msg.Lines...)
+	if n := len(p.bisectOutput); n > bisectOutputLines {
+		p.bisectOutput = p.bisectOutput[n-bisectOutputLines:]
+	}
+	return waitRunEvents(p.bisectRunEvents)
+}
+
+// handleBisectRunTick keeps the elapsed time ticking while a run lasts.
+func (p *Plugin) handleBisectRunTick() tea.Cmd {
+	if p.bisectRunCancel == nil {
+		return nil
+	}
+	return bisectRunTick()
+}
+
+// handleBisectRunDone reports how a bisect run ended.
+func (p *Plugin) handleBisectRunDone(msg BisectRunDoneMsg) tea.Cmd {
+	if p.bisectRunCancel != nil {
+		p.bisectRunCancel()
+	}
+	p.bisectRunCancel = nil
+	p.bisectRunEvents = nil
+	p.bisectRunElapsed = time.Since(p.bisectRunStart)
+	if msg.State != nil {
+		p.bisect = msg.State
+	}
+
+	reload := tea.Batch(p.refresh(), p.loadRecentCommits())
+	switch {
+	case errors.Is(msg.Err, context.Canceled):
+		return tea.Batch(appmsg.ShowToast("Bisect run cancelled", 2*time.Second), reload)
+	case msg.Err != nil:
+		p.bisectErr = msg.Err.Error()
+		return reload
+	case p.bisect.Done():
+		return tea.Batch(appmsg.ShowToast("Found first bad commit "+shortHash(p.bisect.Culprit), 2*time.Second),
+			reload, p.loadBisectCulprit(p.bisect.Culprit))
+	}
+	return reload
+}
+
+// loadBisectCulprit reads the first bad commit and looks for the agent
+// session that was active when it was made.
+func (p *Plugin) loadBisectCulprit(hash string) tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	roots := []string{p.repoRoot}
+	if p.ctx.ProjectRoot != "" && p.ctx.ProjectRoot != p.repoRoot {
+		roots = append(roots, p.ctx.ProjectRoot)
+	}
+	adapters := p.ctx.Adapters
+	return func() tea.Msg {
+		commit, err := GetCommitDetail(workDir, hash)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		var sessions []adapter.Session
+		ids := make([]string, 0, len(adapters))
+		for id := range adapters {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, root := range roots {
+			for _, id := range ids {
+				// An adapter that cannot list sessions just contributes none
+				found, _ := adapters[id].Sessions(root)
+				sessions = append(sessions, found...)
+			}
+		}
+		return BisectCulpritLoadedMsg{Epoch: epoch, Commit: commit, Session: matchCommitSession(sessions, commit.Date)}
+	}
+}
+
+// matchCommitSession returns the session that was active when a commit was
+// made at when, preferring the one started most recently.
+func matchCommitSession(sessions []adapter.Session, when time.Time) *adapter.Session {
+	var best *adapter.Session
+	for i := range sessions {
+		s := &sessions[i]
+		if s.IsSubAgent || s.CreatedAt.IsZero() || when.Before(s.CreatedAt) || when.After(s.UpdatedAt.Add(sessionGrace)) {
+			continue
+		}
+		if best == nil || s.CreatedAt.After(best.CreatedAt) {
+			best = s
+		}
+	}
+	return best
+}
+
+// handleBisectCulpritLoaded opens the first bad commit in the preview.
+func (p *Plugin) handleBisectCulpritLoaded(msg BisectCulpritLoadedMsg) {
+	p.bisectCulprit = msg.Commit
+	p.bisectSession = msg.Session
+	if idx := p.findCommitIndex(msg.Commit.Hash); idx >= 0 {
+		p.cursor = len(p.tree.AllEntries()) + idx
+		p.ensureCommitVisible(idx)
+	}
+	p.selectedDiffFile = ""
+	p.diffPaneParsedDiff = nil
+	p.previewCommit = msg.Commit
+	p.previewCommitCursor = 0
+	p.previewCommitScroll = 0
+}
+
+// showBisectCulprit closes the panel and focuses the culprit's preview.
+func (p *Plugin) showBisectCulprit() tea.Cmd {
+	if !p.bisect.Done() {
+		return nil
+	}
+	p.closeBisectPanel()
+	if p.bisectCulprit == nil || p.bisectCulprit.Hash != p.bisect.Culprit {
+		return p.loadBisectCulprit(p.bisect.Culprit)
+	}
+	p.handleBisectCulpritLoaded(BisectCulpritLoadedMsg{Commit: p.bisectCulprit, Session: p.bisectSession})
+	p.activePane = PaneDiff
+	return nil
+}
+
+// bisectCommitLabel returns a commit's short hash and, when it is loaded,
+// its subject.
+func (p *Plugin) bisectCommitLabel(hash string) string {
+	label := shortHash(hash)
+	for _, c := range p.recentCommits {
+		if c.Hash == hash {
+			return label + " " + c.Subject
+		}
+	}
+	return label
+}
+
+// ensureBisectModal builds/rebuilds the bisect panel.
+func (p *Plugin) ensureBisectModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.bisectModal != nil && p.bisectModalWidth == modalW {
+		return
+	}
+	p.bisectModalWidth = modalW
+
+	p.bisectModal = modal.New("Bisect",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.bisectProgressSection()).
+		AddSection(modal.When(func() bool { return p.bisect.Done() && p.bisectCulprit != nil }, p.bisectCulpritSection())).
+		AddSection(modal.When(func() bool { return len(p.bisectOutput) > 0 || p.bisectRunCancel != nil }, p.bisectOutputSection())).
+		AddSection(modal.Spacer()).
+		AddSection(p.bisectStatusSection())
+}
+
+// bisectProgressSection shows the marked ends and how far the bisect has
+// narrowed the range.
+func (p *Plugin) bisectProgressSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		state := p.bisect
+		if state == nil || !state.Active {
+			return modal.RenderedSection{Content: styles.Muted.Render("No bisect in progress.")}
+		}
+		var lines []string
+		if state.Bad != "" {
+			lines = append(lines, styles.StatusDeleted.Render("✗ bad  ")+ui.TruncateString(p.bisectCommitLabel(state.Bad), contentWidth-7))
+		}
+		for _, hash := range state.Good {
+			lines = append(lines, styles.StatusStaged.Render("✓ good ")+ui.TruncateString(p.bisectCommitLabel(hash), contentWidth-7))
+		}
+		if n := len(state.Skipped); n > 0 {
+			lines = append(lines, styles.Muted.Render(fmt.Sprintf("~ %d skipped", n)))
+		}
+		lines = append(lines, "")
+		switch {
+		case state.Done():
+			lines = append(lines, styles.StatusDeleted.Render("First bad commit: ")+ui.TruncateString(p.bisectCommitLabel(state.Culprit), contentWidth-18))
+		case state.Bad == "" || len(state.Good) == 0:
+			lines = append(lines, styles.Muted.Render("Mark a good and a bad commit to narrow the range"))
+		default:
+			lines = append(lines, styles.StatusModified.Render(fmt.Sprintf("%d commit(s) left, about %d step(s)", state.Remaining, state.Steps)))
+		}
+		if !state.Done() && state.Current != "" {
+			lines = append(lines, styles.StatusInProgress.Render("▶ testing ")+ui.TruncateString(p.bisectCommitLabel(state.Current), contentWidth-10))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// bisectCulpritSection describes the first bad commit and the agent session
+// it was made in.
+func (p *Plugin) bisectCulpritSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		c := p.bisectCulprit
+		if c == nil {
+			return modal.RenderedSection{}
+		}
+		lines := []string{
+			"",
+			styles.Title.Render(ui.TruncateString(c.Subject, contentWidth)),
+			styles.Muted.Render(ui.TruncateString(c.Author+" · "+RelativeTime(c.Date), contentWidth)),
+		}
+		if s := p.bisectSession; s != nil {
+			name := s.Name
+			if name == "" {
+				name = s.Slug
+			}
+			lines = append(lines, styles.Body.Render(ui.TruncateString("Agent session: "+s.AdapterName+" · "+name, contentWidth)))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// bisectOutputSection shows the tail of the run output or the last step's
+// report.
+func (p *Plugin) bisectOutputSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		lines := []string{""}
+		if p.bisectLastCmd != "" && (p.bisectRunCancel != nil || p.bisectRunElapsed > 0) {
+			elapsed := p.bisectRunElapsed
+			heading := "Ran "
+			if p.bisectRunCancel != nil {
+				elapsed = time.Since(p.bisectRunStart)
+				heading = "Running "
+			}
+			heading += p.bisectLastCmd + " · " + elapsed.Truncate(time.Second).String()
+			lines = append(lines, styles.StatusInProgress.Render(ui.TruncateString(heading, contentWidth)))
+		}
+		maxLines := max(p.height/3, 4)
+		start := max(len(p.bisectOutput)-maxLines, 0)
+		for _, line := range p.bisectOutput[start:] {
+			lines = append(lines, styles.Muted.Render(ui.TruncateString(strings.ReplaceAll(line, "\t", "    "), contentWidth)))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// bisectStatusSection shows the command input, progress, errors and key
+// hints.
+func (p *Plugin) bisectStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var lines []string
+		if p.bisectEditingCmd {
+			lines = append(lines, "Test command: "+p.bisectCmd.View())
+			if p.bisectErr != "" {
+				lines = append(lines, styles.StatusDeleted.Render(strings.TrimSpace(p.bisectErr)))
+			}
+			lines = append(lines, styles.Muted.Render("enter run · esc cancel"))
+			return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+		}
+		switch {
+		case p.bisectBusy != "":
+			lines = append(lines, styles.StatusInProgress.Render(p.bisectBusy))
+		case p.bisectErr != "":
+			lines = append(lines, styles.StatusDeleted.Render(ui.TruncateString(strings.TrimSpace(p.bisectErr), contentWidth)))
+		}
+		switch {
+		case p.bisectRunCancel != nil:
+			lines = append(lines, styles.Muted.Render("x cancel run · R end bisect · esc hide"))
+		case p.bisect.Done():
+			lines = append(lines, styles.Muted.Render("enter show culprit · R end bisect · esc hide"))
+		default:
+			lines = append(lines, styles.Muted.Render("g good · b bad · s skip · r run command · R end bisect · esc hide"))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderBisectStart renders the start modal over the status view.
+func (p *Plugin) renderBisectStart() string {
+	background := p.renderThreePaneView()
+	p.ensureBisectStartModal()
+	modalContent := p.bisectStartModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// renderBisect renders the bisect panel over the status view.
+func (p *Plugin) renderBisect() string {
+	background := p.renderThreePaneView()
+	p.ensureBisectModal()
+	modalContent := p.bisectModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// bisectIndicator returns the commit list marker for a commit's part in the
+// bisect: styled and plain, or empty when it has none.
+func (p *Plugin) bisectIndicator(hash string) (string, string) {
+	state := p.bisect
+	if state == nil || !state.Active {
+		return "", ""
+	}
+	switch {
+	case hash == state.Culprit || hash == state.Bad:
+		return styles.StatusDeleted.Render("✗"), "✗"
+	case hash == state.Current && state.Candidates[hash]:
+		return styles.StatusInProgress.Render("▶"), "▶"
+	}
+	for _, h := range state.Good {
+		if h == hash {
+			return styles.StatusStaged.Render("✓"), "✓"
+		}
+	}
+	for _, h := range state.Skipped {
+		if h == hash {
+			return styles.Muted.Render("~"), "~"
+		}
+	}
+	if state.Candidates[hash] && !state.Done() {
+		return styles.StatusModified.Render("?"), "?"
+	}
+	return "", ""
+}
+
+// outsideBisectRange reports whether a commit has been ruled out by the
+// bisect, so the list can dim it.
+func (p *Plugin) outsideBisectRange(hash string) bool {
+	state := p.bisect
+	if state == nil || state.Candidates == nil {
+		return false
+	}
+	if state.Done() {
+		return hash != state.Culprit
+	}
+	return !state.Candidates[hash]
+}
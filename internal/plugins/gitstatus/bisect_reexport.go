@@ -0,0 +1,27 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export bisect types from internal/git.
+type (
+	BisectState = git.BisectState
+	BisectMark  = git.BisectMark
+	BisectError = git.BisectError
+)
+
+// Re-export bisect marks.
+const (
+	BisectGood = git.BisectGood
+	BisectBad  = git.BisectBad
+	BisectSkip = git.BisectSkip
+)
+
+// Re-export bisect functions.
+var (
+	IsBisectInProgress = git.IsBisectInProgress
+	GetBisectState     = git.GetBisectState
+	StartBisect        = git.StartBisect
+	MarkBisect         = git.MarkBisect
+	ResetBisect        = git.ResetBisect
+	RunBisect          = git.RunBisect
+)
@@ -0,0 +1,192 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/adapter"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+// newBisectPlugin returns a plugin on a repo with commits c1..c8, where c5
+// writes "bug" into state.txt, and the commits newest first.
+func newBisectPlugin(t *testing.T) (*Plugin, []*Commit) {
+	t.Helper()
+	dir, git := initTestRepo(t)
+	for i := 1; i <= 8; i++ {
+		state := "ok\n"
+		if i >= 5 {
+			state = "bug\n"
+		}
+		// Distinct dates keep the history of several tips in order
+		date := fmt.Sprintf("2026-01-01T10:%02d:00", i)
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		writeTestFile(t, dir, "state.txt", state)
+		git("add", "-A")
+		git("commit", "-q", "--allow-empty", "-m", fmt.Sprintf("c%d", i))
+	}
+
+	p := newTestPlugin(t, dir)
+	p.Update(p.refresh()())
+	p.Update(p.loadRecentCommits()())
+	if len(p.recentCommits) != 8 {
+		t.Fatalf("commits = %d", len(p.recentCommits))
+	}
+	return p, p.recentCommits
+}
+
+// runBatch runs cmd and feeds its messages, one level of batch deep, to p.
+func runBatch(p *Plugin, cmd tea.Cmd) {
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c != nil {
+				p.Update(c())
+			}
+		}
+		return
+	}
+	p.Update(msg)
+}
+
+func TestBisect_MarkUntilCulprit(t *testing.T) {
+	p, commits := newBisectPlugin(t)
+	newest, oldest := commits[0], commits[7]
+	p.markedCommits = map[string]bool{newest.Hash: true, oldest.Hash: true}
+	p.cursor = len(p.tree.AllEntries())
+
+	p.Update(runeKey("B"))
+	if p.viewMode != ViewModeBisectStart || p.FocusContext() != keymap.ContextGitBisectStart || !p.ConsumesTextInput() {
+		t.Fatal("B should open the start modal")
+	}
+	if p.bisectBad.Value() != shortHash(newest.Hash) || p.bisectGood.Value() != shortHash(oldest.Hash) {
+		t.Fatalf("bad %q, good %q", p.bisectBad.Value(), p.bisectGood.Value())
+	}
+	p.View(120, 30)
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should start the bisect")
+	}
+	runBatch(p, func() tea.Msg { return tea.BatchMsg{cmd} })
+	if p.viewMode != ViewModeBisect || p.FocusContext() != keymap.ContextGitBisect || p.bisect == nil || p.bisect.Remaining != 7 {
+		t.Fatalf("start failed: mode %v, state %+v, err %q", p.viewMode, p.bisect, p.bisectStartErr)
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "7 commit(s) left") || !strings.Contains(view, "g good") {
+		t.Errorf("panel should show progress:\n%s", view)
+	}
+	if mark, _ := p.bisectIndicator(p.bisect.Current); len(p.markedCommits) != 0 || !strings.Contains(mark, "▶") {
+		t.Error("the list should show the commit under test in place of the marks")
+	}
+
+	for i := 0; i < 6 && !p.bisect.Done(); i++ {
+		data, err := os.ReadFile(filepath.Join(p.repoRoot, "state.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := "g"
+		if string(data) == "bug\n" {
+			key = "b"
+		}
+		_, cmd := p.Update(runeKey(key))
+		if cmd == nil {
+			t.Fatalf("%s should mark the commit", key)
+		}
+		// The mark, then the reloads and culprit lookup it returns
+		_, cmd = p.Update(cmd())
+		runBatch(p, cmd)
+	}
+	culprit := commits[3]
+	if !p.bisect.Done() || p.bisect.Culprit != culprit.Hash {
+		t.Fatalf("culprit = %q, want c5 %s", p.bisect.Culprit, culprit.Hash)
+	}
+	if p.previewCommit == nil || p.previewCommit.Hash != culprit.Hash || p.bisectCulprit == nil {
+		t.Fatal("the culprit should open in the preview")
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "First bad commit") || !strings.Contains(view, "enter show culprit") {
+		t.Errorf("panel should report the culprit:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.viewMode != ViewModeStatus || p.activePane != PaneDiff {
+		t.Error("enter should close the panel and focus the culprit's preview")
+	}
+	if mark, _ := p.bisectIndicator(culprit.Hash); !strings.Contains(mark, "✗") || !p.outsideBisectRange(commits[0].Hash) {
+		t.Error("the list should mark the culprit and dim the rest")
+	}
+
+	p.activePane = PaneSidebar
+	p.Update(runeKey("B"))
+	if p.viewMode != ViewModeBisect {
+		t.Fatal("B during a bisect should reopen the panel")
+	}
+	_, cmd = p.Update(runeKey("R"))
+	runBatch(p, func() tea.Msg { return tea.BatchMsg{cmd} })
+	if p.viewMode != ViewModeStatus || p.bisect != nil || IsBisectInProgress(p.repoRoot) {
+		t.Error("R should end the bisect")
+	}
+}
+
+func TestBisect_RunStreamsOutput(t *testing.T) {
+	p, commits := newBisectPlugin(t)
+	if _, err := StartBisect(p.repoRoot, commits[0].Hash, commits[7].Hash); err != nil {
+		t.Fatal(err)
+	}
+	p.Update(p.loadRecentCommits()())
+	p.Update(runeKey("B"))
+	if p.viewMode != ViewModeBisect {
+		t.Fatal("B should open the panel of the running bisect")
+	}
+
+	p.Update(runeKey("r"))
+	if p.FocusContext() != keymap.ContextGitBisectRun || !p.ConsumesTextInput() {
+		t.Fatal("r should focus the command input")
+	}
+	p.bisectCmd.SetValue("echo testing; grep -q ok state.txt")
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.bisectRunCancel == nil {
+		t.Fatal("enter should start the run")
+	}
+
+	deadline := time.After(30 * time.Second)
+	for p.bisectRunCancel != nil {
+		select {
+		case <-deadline:
+			t.Fatal("bisect run did not finish")
+		default:
+		}
+		p.Update(waitRunEvents(p.bisectRunEvents)())
+	}
+	if !p.bisect.Done() || p.bisect.Culprit != commits[3].Hash {
+		t.Fatalf("culprit = %q, err %q", p.bisect.Culprit, p.bisectErr)
+	}
+	view := p.View(120, 30)
+	for _, want := range []string{"Ran echo testing", "testing", "is the first bad commit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel lacks %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestMatchCommitSession(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	sessions := []adapter.Session{
+		{ID: "old", CreatedAt: base.Add(-2 * time.Hour), UpdatedAt: base.Add(time.Hour)},
+		{ID: "new", CreatedAt: base.Add(-time.Hour), UpdatedAt: base.Add(-30 * time.Minute)},
+		{ID: "sub", CreatedAt: base.Add(-10 * time.Minute), UpdatedAt: base.Add(time.Hour), IsSubAgent: true},
+	}
+	if s := matchCommitSession(sessions, base); s == nil || s.ID != "old" {
+		t.Errorf("got %+v, want the session still active at the commit", s)
+	}
+	if s := matchCommitSession(sessions, base.Add(-25*time.Minute)); s == nil || s.ID != "new" {
+		t.Errorf("got %+v, want the most recently started session", s)
+	}
+	if s := matchCommitSession(sessions, base.Add(3*time.Hour)); s != nil {
+		t.Errorf("got %+v, want none after every session ended", s)
+	}
+}
@@ -0,0 +1,18 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export object reader types from internal/git.
+type (
+	ObjectReader = git.ObjectReader
+	CommitObject = git.CommitObject
+)
+
+// Re-export object reader functions.
+var (
+	ObjectReaderFor    = git.ObjectReaderFor
+	CloseObjectReader  = git.CloseObjectReader
+	CloseObjectReaders = git.CloseObjectReaders
+	CacheBlob          = git.CacheBlob
+	PruneBlobCache     = git.PruneBlobCache
+)
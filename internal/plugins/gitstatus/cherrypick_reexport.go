@@ -0,0 +1,33 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export cherry-pick, revert and reset types from internal/git.
+type (
+	CherryPickError = git.CherryPickError
+	RevertError     = git.RevertError
+	ResetError      = git.ResetError
+	ResetMode       = git.ResetMode
+	ResetPreview    = git.ResetPreview
+	WorktreeInfo    = git.WorktreeInfo
+)
+
+// Re-export reset modes.
+const (
+	ResetSoft  = git.ResetSoft
+	ResetMixed = git.ResetMixed
+	ResetHard  = git.ResetHard
+)
+
+// Re-export cherry-pick, revert and reset functions.
+var (
+	CherryPick         = git.CherryPick
+	Revert             = git.Revert
+	ContinueCherryPick = git.ContinueCherryPick
+	ContinueRevert     = git.ContinueRevert
+	AbortCherryPick    = git.AbortCherryPick
+	AbortRevert        = git.AbortRevert
+	GetResetPreview    = git.GetResetPreview
+	Reset              = git.Reset
+	GetWorktrees       = git.GetWorktrees
+)
@@ -86,7 +86,9 @@ func formatCommitAsMarkdown(commit *Commit) string {
 		for _, f := range commit.Files {
 			status := fileStatusIcon(f.Status)
 			sb.WriteString(fmt.Sprintf("- %s `%s`", status, f.Path))
-			if f.Additions > 0 || f.Deletions > 0 {
+			if f.Binary && f.Size > 0 {
+				sb.WriteString(fmt.Sprintf(" (binary, %s)", formatBlobSize(f.Size)))
+			} else if f.Additions > 0 || f.Deletions > 0 {
 				sb.WriteString(fmt.Sprintf(" (+%d/-%d)", f.Additions, f.Deletions))
 			}
 			sb.WriteString("\n")
@@ -113,3 +115,17 @@ func fileStatusIcon(status FileStatus) string {
 		return "[?]"
 	}
 }
+
+// formatBlobSize formats a size in bytes for display.
+func formatBlobSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -79,6 +79,7 @@ func TestFormatCommitAsMarkdown(t *testing.T) {
 					{Path: "file1.go", Status: StatusModified, Additions: 10, Deletions: 5},
 					{Path: "file2.go", Status: StatusAdded, Additions: 20, Deletions: 0},
 					{Path: "file3.go", Status: StatusDeleted, Additions: 0, Deletions: 15},
+					{Path: "logo.png", Status: StatusModified, Binary: true, Size: 2560},
 				},
 			},
 			contains: []string{
@@ -87,6 +88,7 @@ func TestFormatCommitAsMarkdown(t *testing.T) {
 				"[M] `file1.go` (+10/-5)",
 				"[A] `file2.go` (+20/-0)",
 				"[D] `file3.go` (+0/-15)",
+				"[M] `logo.png` (binary, 2.5KB)",
 			},
 		},
 	}
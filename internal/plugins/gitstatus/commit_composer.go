@@ -0,0 +1,319 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/modal"
+	"github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/state"
+	"github.com/guyghost/sidecar/internal/styles"
+)
+
+const (
+	composerTypeID     = "commit-type"
+	composerScopeID    = "commit-scope"
+	composerSubjectID  = "commit-subject"
+	composerBreakingID = "commit-breaking"
+	composerBodyID     = "commit-body"
+	composerTrailersID = "commit-trailers"
+)
+
+// linkedTaskFile holds the td task a workspace worktree is linked to; the
+// workspace plugin writes it.
+const linkedTaskFile = ".sidecar-task"
+
+// commitComposer holds the fields of the conventional commit composer.
+type commitComposer struct {
+	types    []string
+	typeIdx  int
+	scope    textinput.Model
+	subject  textinput.Model
+	breaking bool
+	body     textarea.Model
+	trailers textarea.Model
+}
+
+// newCommitComposer fills a composer from m. types and scopes are offered
+// in order; task adds a Refs trailer.
+func newCommitComposer(m ConventionalMessage, types, scopes []string, task string, width int) *commitComposer {
+	c := &commitComposer{types: types, breaking: m.Breaking}
+	if m.Type != "" {
+		c.typeIdx = -1
+		for i, t := range types {
+			if t == m.Type {
+				c.typeIdx = i
+			}
+		}
+		if c.typeIdx < 0 {
+			c.types = append([]string{m.Type}, types...)
+			c.typeIdx = 0
+		}
+	}
+
+	c.scope = textinput.New()
+	c.scope.Placeholder = "optional"
+	c.scope.ShowSuggestions = true
+	c.scope.SetSuggestions(scopes)
+	// Tab belongs to the modal's focus cycling
+	c.scope.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	c.scope.SetValue(m.Scope)
+
+	c.subject = textinput.New()
+	c.subject.Placeholder = "short summary in the imperative"
+	c.subject.CharLimit = 0
+	c.subject.SetValue(m.Subject)
+
+	trailers := m.Trailers
+	if task != "" && !hasTaskTrailer(trailers, task) {
+		trailers = append(trailers, "Refs: "+task)
+	}
+	c.body = newComposerTextarea("Why the change was made...", width, 3)
+	c.body.SetValue(m.Body)
+	c.trailers = newComposerTextarea("Key: value", width, 2)
+	c.trailers.SetValue(strings.Join(trailers, "\n"))
+	return c
+}
+
+// newComposerTextarea returns an unfocused textarea sized for the modal.
+func newComposerTextarea(placeholder string, width, height int) textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = placeholder
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(styles.TextSecondary)
+	ta.CharLimit = 0
+	ta.ShowLineNumbers = false
+	ta.SetWidth(width)
+	ta.SetHeight(height)
+	return ta
+}
+
+// hasTaskTrailer reports whether trailers already mention task.
+func hasTaskTrailer(trailers []string, task string) bool {
+	for _, t := range trailers {
+		if strings.Contains(t, task) {
+			return true
+		}
+	}
+	return false
+}
+
+// parts returns the composed message as its conventional parts.
+func (c *commitComposer) parts() ConventionalMessage {
+	m := ConventionalMessage{
+		Scope:    strings.TrimSpace(c.scope.Value()),
+		Breaking: c.breaking,
+		Subject:  strings.TrimSpace(c.subject.Value()),
+		Body:     c.body.Value(),
+		Trailers: strings.Split(c.trailers.Value(), "\n"),
+	}
+	if c.typeIdx < len(c.types) {
+		m.Type = c.types[c.typeIdx]
+	}
+	return m
+}
+
+// message returns the composed commit message, or "" until it has a
+// subject.
+func (c *commitComposer) message() string {
+	m := c.parts()
+	if m.Subject == "" {
+		return ""
+	}
+	return m.String()
+}
+
+// linkedTask returns the td task the worktree at dir is linked to.
+func linkedTask(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, linkedTaskFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// commitMessageText returns the message the commit modal will commit.
+func (p *Plugin) commitMessageText() string {
+	if p.composer != nil {
+		return p.composer.message()
+	}
+	return strings.TrimSpace(p.commitMessage.Value())
+}
+
+// openComposer switches the commit modal to the composer, carrying over the
+// message typed so far.
+func (p *Plugin) openComposer() {
+	parsed, _ := ParseConventional(p.commitMessage.Value())
+	types, scopes := historyConventions(p.recentCommits)
+	p.composer = newCommitComposer(parsed, types, scopes, linkedTask(p.repoRoot), p.commitModalWidth()-8)
+	p.commitModal = nil
+	p.commitModalWidthCache = 0
+}
+
+// toggleComposer switches between the composer and the free-text message,
+// saving the choice.
+func (p *Plugin) toggleComposer() {
+	if p.composer != nil {
+		if message := p.composer.message(); message != "" {
+			p.commitMessage.SetValue(message)
+		}
+		p.composer = nil
+		p.commitModal = nil
+		p.commitModalWidthCache = 0
+	} else {
+		p.openComposer()
+	}
+	_ = state.SetCommitComposer(p.composer != nil)
+}
+
+// commitTextFocused reports whether focusID is a multi-line or toggle field
+// of the commit modal, where enter edits rather than commits.
+func commitTextFocused(focusID string) bool {
+	switch focusID {
+	case commitMessageID, composerBodyID, composerTrailersID, composerBreakingID:
+		return true
+	}
+	return false
+}
+
+// commitHeaderLimit returns the header length lint warns past.
+func (p *Plugin) commitHeaderLimit() int {
+	if p.ctx != nil && p.ctx.Config != nil && p.ctx.Config.Plugins.GitStatus.CommitSubjectLength > 0 {
+		return p.ctx.Config.Plugins.GitStatus.CommitSubjectLength
+	}
+	return defaultCommitSubjectLength
+}
+
+// composerSections returns the modal sections of the composer fields.
+func (p *Plugin) composerSections() []modal.Section {
+	c := p.composer
+	return []modal.Section{
+		p.composerTypeSection(),
+		modal.InputWithLabel(composerScopeID, "Scope", &c.scope),
+		modal.InputWithLabel(composerSubjectID, "Subject", &c.subject),
+		modal.Checkbox(composerBreakingID, "Breaking change", &c.breaking),
+		modal.TextareaWithLabel(composerBodyID, "Body", &c.body, 3),
+		modal.TextareaWithLabel(composerTrailersID, "Trailers", &c.trailers, 2),
+	}
+}
+
+// composerTypeSection renders the commit types as a row to pick from with
+// left and right.
+func (p *Plugin) composerTypeSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		c := p.composer
+		focused := focusID == composerTypeID
+		label := "Type  "
+		chips := make([]string, len(c.types))
+		for i, t := range c.types {
+			switch {
+			case i == c.typeIdx && focused:
+				chips[i] = styles.ButtonFocused.Render(t)
+			case i == c.typeIdx:
+				chips[i] = styles.ListItemSelected.Render(" " + t + " ")
+			default:
+				chips[i] = styles.Muted.Render(" " + t + " ")
+			}
+		}
+		// Slide the row so the selected type stays visible
+		avail := contentWidth - len(label)
+		start, end := 0, c.typeIdx+1
+		for start < c.typeIdx && lipgloss.Width(strings.Join(chips[start:end], "")) > avail {
+			start++
+		}
+		for end < len(chips) && lipgloss.Width(strings.Join(chips[start:end+1], "")) <= avail {
+			end++
+		}
+		line := styles.Body.Render(label) + strings.Join(chips[start:end], "")
+		return modal.RenderedSection{
+			Content:    line,
+			Focusables: []modal.FocusableInfo{{ID: composerTypeID, Width: contentWidth, Height: 1}},
+		}
+	}, func(m tea.Msg, focusID string) (string, tea.Cmd) {
+		c := p.composer
+		keyMsg, ok := m.(tea.KeyMsg)
+		if !ok || focusID != composerTypeID || len(c.types) == 0 {
+			return "", nil
+		}
+		switch keyMsg.String() {
+		case "left", "h":
+			c.typeIdx = (c.typeIdx - 1 + len(c.types)) % len(c.types)
+		case "right", "l":
+			c.typeIdx = (c.typeIdx + 1) % len(c.types)
+		}
+		return "", nil
+	})
+}
+
+// composerToggleSection shows whether the composer is on, like the other
+// commit modal toggles.
+func (p *Plugin) composerToggleSection() modal.Section {
+	on := p.composer != nil
+	return modal.CheckboxDisplay("Conventional commit composer", &on, "ctrl+t")
+}
+
+// commitLintSection shows the lint warnings for the message being written.
+func (p *Plugin) commitLintSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		conventional := p.composer != nil || usesConventionalCommits(p.recentCommits)
+		warnings := LintCommitMessage(p.commitMessageText(), p.commitHeaderLimit(), conventional)
+		lines := make([]string, len(warnings))
+		for i, w := range warnings {
+			lines[i] = styles.StatusModified.Render("⚠ " + w)
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// CommitTemplateLoadedMsg carries the starting commit message from
+// commit.template.
+type CommitTemplateLoadedMsg struct {
+	Epoch   uint64
+	Message string
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CommitTemplateLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// loadCommitTemplate builds the starting message from the commit.template
+// contents without comment lines. The prepare-commit-msg hook is left to
+// git, which runs it once when the commit is made.
+func (p *Plugin) loadCommitTemplate() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		template, err := GetCommitTemplate(workDir)
+		if err != nil {
+			return CommitTemplateLoadedMsg{Epoch: epoch, Err: err}
+		}
+		return CommitTemplateLoadedMsg{Epoch: epoch, Message: StripCommitComments(workDir, template)}
+	}
+}
+
+// handleCommitTemplateLoaded fills the commit modal with the template unless
+// something was typed already.
+func (p *Plugin) handleCommitTemplateLoaded(m CommitTemplateLoadedMsg) tea.Cmd {
+	if p.viewMode != ViewModeCommit || p.commitAmend || strings.TrimSpace(p.commitMessage.Value()) != "" {
+		return nil
+	}
+	if p.composer != nil && (p.composer.subject.Value() != "" || p.composer.scope.Value() != "") {
+		return nil
+	}
+	if m.Message != "" {
+		p.commitMessage.SetValue(m.Message)
+		if p.composer != nil {
+			p.openComposer()
+		}
+	}
+	if m.Err != nil {
+		return msg.ShowToast("Commit template: "+m.Err.Error(), 3*time.Second)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/state"
+)
+
+func newComposerPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	if err := state.InitWithDir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	p := newHistoryOpsPlugin(t)
+	p.repoRoot = t.TempDir()
+	if err := os.WriteFile(filepath.Join(p.repoRoot, linkedTaskFile), []byte("td-a1b2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p.recentCommits[0].Subject = "fix(ui): three"
+	p.recentCommits[1].Subject = "fix(git): two"
+	p.viewMode = ViewModeCommit
+	p.initCommitTextarea()
+	return p
+}
+
+func TestCommitComposer_BuildsMessage(t *testing.T) {
+	p := newComposerPlugin(t)
+	p.commitMessage.SetValue("wip")
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if p.composer == nil || !state.GetCommitComposer() {
+		t.Fatal("ctrl+t should open the composer and remember it")
+	}
+	view := p.View(100, 40)
+	for _, want := range []string{"Type", "fix", "Scope", "Refs: td-a1b2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("composer missing %q", want)
+		}
+	}
+
+	// Type -> scope -> subject; inputs take focus when rendered
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.View(100, 40)
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" and more.")})
+	if got := p.commitMessageText(); got != "fix: wip and more.\n\nRefs: td-a1b2" {
+		t.Errorf("composed message = %q", got)
+	}
+	if view := p.View(100, 40); !strings.Contains(view, "Subject should not end with a period") {
+		t.Error("lint warnings should show in the modal")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if p.composer != nil || !strings.HasPrefix(p.commitMessage.Value(), "fix: wip and more.") {
+		t.Errorf("closing the composer should keep the message, got %q", p.commitMessage.Value())
+	}
+}
+
+func TestCommitComposer_TemplateFillsEmptyMessage(t *testing.T) {
+	p := newComposerPlugin(t)
+	p.Update(CommitTemplateLoadedMsg{Message: "chore(deps): bump"})
+	if p.commitMessage.Value() != "chore(deps): bump" {
+		t.Errorf("template should fill the message, got %q", p.commitMessage.Value())
+	}
+
+	p.commitMessage.SetValue("typed")
+	p.Update(CommitTemplateLoadedMsg{Message: "chore: other"})
+	if p.commitMessage.Value() != "typed" {
+		t.Error("template should not replace a typed message")
+	}
+}
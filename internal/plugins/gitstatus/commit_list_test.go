@@ -82,3 +82,24 @@ func TestEnsureCommitListFilled(t *testing.T) {
 		t.Fatal("expected nil cmd while loading more commits")
 	}
 }
+
+func TestPrefetchCommits(t *testing.T) {
+	p := &Plugin{
+		ctx:                  &plugin.Context{WorkDir: "/tmp"},
+		tree:                 &FileTree{},
+		recentCommits:        makeCommitsWithHash(commitHistoryPageSize),
+		moreCommitsAvailable: true,
+	}
+
+	p.cursor = commitHistoryPageSize - commitPrefetchMargin - 1
+	if cmd := p.prefetchCommits(); cmd != nil {
+		t.Fatal("expected no prefetch far from the end of the list")
+	}
+	p.cursor++
+	if cmd := p.prefetchCommits(); cmd == nil || !p.loadingMoreCommits {
+		t.Fatal("expected the next page to load within the prefetch margin")
+	}
+	if cmd := p.prefetchCommits(); cmd != nil {
+		t.Fatal("expected no second load while a page is loading")
+	}
+}
@@ -3,12 +3,18 @@ package gitstatus
 import "github.com/guyghost/sidecar/internal/git"
 
 // Re-export commit types from internal/git for backward compatibility.
-type CommitError = git.CommitError
+type (
+	CommitError   = git.CommitError
+	CommitOptions = git.CommitOptions
+)
 
 // Re-export commit functions.
 var (
-	ExecuteCommit = git.ExecuteCommit
-	ExecuteAmend  = git.ExecuteAmend
+	ExecuteCommit       = git.ExecuteCommit
+	ExecuteAmend        = git.ExecuteAmend
+	StreamCommit        = git.StreamCommit
+	GetCommitTemplate   = git.GetCommitTemplate
+	StripCommitComments = git.StripCommitComments
 )
 
 // GetLastCommitMessage wraps git.GetLastCommitMessage.
@@ -27,3 +33,18 @@ var (
 	DiscardStaged    = git.DiscardStaged
 	DiscardUntracked = git.DiscardUntracked
 )
+
+// Re-export commit signature types.
+type (
+	CommitSignature = git.CommitSignature
+	SigningConfig   = git.SigningConfig
+	SigningError    = git.SigningError
+)
+
+// Re-export commit signature functions.
+var (
+	ParseCommitSignature = git.ParseCommitSignature
+	GetCommitSignature   = git.GetCommitSignature
+	GetCommitSignatures  = git.GetCommitSignatures
+	GetSigningConfig     = git.GetSigningConfig
+)
@@ -40,9 +40,19 @@ func (p *Plugin) ensureCommitModal() {
 	).
 		AddSection(p.commitHeaderSection()).
 		AddSection(p.commitStagedSection()).
-		AddSection(modal.Spacer()).
-		AddSection(modal.Textarea(commitMessageID, &p.commitMessage, 4)).
+		AddSection(modal.Spacer())
+	if p.composer != nil {
+		for _, s := range p.composerSections() {
+			p.commitModal.AddSection(s)
+		}
+	} else {
+		p.commitModal.AddSection(modal.Textarea(commitMessageID, &p.commitMessage, 4))
+	}
+	p.commitModal.
+		AddSection(p.commitLintSection()).
 		AddSection(modal.When(p.showCommitAmendToggle, modal.CheckboxDisplay("Amend last commit", &p.commitAmend, "ctrl+a"))).
+		AddSection(p.commitSignSection()).
+		AddSection(p.composerToggleSection()).
 		AddSection(p.commitStatusSection()).
 		AddSection(modal.Buttons(
 			modal.Btn(p.commitButtonLabel(), commitActionID),
@@ -57,6 +67,14 @@ func (p *Plugin) showCommitAmendToggle() bool {
 	return p.tree.HasStagedFiles()
 }
 
+// commitSignSection offers -S, or notes that git config already signs.
+func (p *Plugin) commitSignSection() modal.Section {
+	if p.signing.Enabled {
+		return modal.Text(styles.Muted.Render("Signed with " + p.signing.Format + " (commit.gpgSign)"))
+	}
+	return modal.CheckboxDisplay("Sign commit (-S)", &p.commitSign, "ctrl+g")
+}
+
 func (p *Plugin) commitButtonLabel() string {
 	if p.commitAmend {
 		return " Amend "
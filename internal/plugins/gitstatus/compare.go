@@ -0,0 +1,690 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/modal"
+	"github.com/guyghost/sidecar/internal/mouse"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/state"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// Compare view tabs.
+const (
+	compareTabCommits = iota
+	compareTabFiles
+	compareTabDiff
+	compareTabCount
+)
+
+var compareTabNames = [compareTabCount]string{"Commits", "Files", "Diff"}
+
+const (
+	compareSuggestMax = 8 // Ref suggestions shown under the picker inputs
+	compareHeaderRows = 4 // Breadcrumb, summary, tabs and separator
+)
+
+// OpenCompareMsg asks the git plugin to compare Head against Base. Other
+// plugins send it after focusing git-status. An empty Base uses the default
+// branch and an empty Head the current branch; when both are set the
+// comparison runs straight away.
+type OpenCompareMsg struct {
+	Base string
+	Head string
+}
+
+// CompareRefsLoadedMsg is sent when the refs offered by the compare picker load.
+type CompareRefsLoadedMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Refs  []CompareRef
+	Base  string // Default base ref
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CompareRefsLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// CompareLoadedMsg is sent when a comparison between two refs finishes.
+type CompareLoadedMsg struct {
+	Epoch      uint64 // Epoch when request was issued (for stale detection)
+	Comparison *Comparison
+	Err        error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CompareLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openComparePicker opens the ref picker with the given sides prefilled.
+func (p *Plugin) openComparePicker(base, head string) tea.Cmd {
+	if head == "" {
+		head = "HEAD"
+		if p.pushStatus != nil && p.pushStatus.CurrentBranch != "" {
+			head = p.pushStatus.CurrentBranch
+		}
+	}
+	for i, value := range []string{base, head} {
+		input := textinput.New()
+		input.Placeholder = "branch, tag or commit"
+		input.CharLimit = 200
+		input.SetValue(value)
+		p.compareInputs[i] = input
+	}
+	p.compareField = 1
+	if base == "" {
+		p.compareField = 0
+	}
+	p.compareInputs[p.compareField].Focus()
+	p.compareSuggest = -1
+	p.comparePickErr = ""
+	p.compareRunning = false
+	p.comparePickModal = nil
+	p.viewMode = ViewModeComparePick
+	return tea.Batch(textinput.Blink, p.loadCompareRefs())
+}
+
+// loadCompareRefs lists the refs the picker suggests and the default base.
+func (p *Plugin) loadCompareRefs() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		return CompareRefsLoadedMsg{Epoch: epoch, Refs: ListCompareRefs(workDir), Base: GetDefaultCompareBase(workDir)}
+	}
+}
+
+// handleCompareRefsLoaded stores the suggestions and fills in an empty base.
+func (p *Plugin) handleCompareRefsLoaded(msg CompareRefsLoadedMsg) {
+	p.compareRefs = msg.Refs
+	if p.viewMode == ViewModeComparePick && p.compareInputs[0].Value() == "" && !p.compareRunning {
+		p.compareInputs[0].SetValue(msg.Base)
+		p.compareInputs[0].CursorEnd()
+	}
+}
+
+// handleOpenCompare opens the compare picker for another plugin, running the
+// comparison at once when both sides are given.
+func (p *Plugin) handleOpenCompare(msg OpenCompareMsg) tea.Cmd {
+	if p.inNoRepoMode() {
+		return nil
+	}
+	cmd := p.openComparePicker(msg.Base, msg.Head)
+	if msg.Base == "" || msg.Head == "" {
+		return cmd
+	}
+	return tea.Batch(cmd, p.doCompare())
+}
+
+// compareSuggestions returns the refs matching the focused input.
+func (p *Plugin) compareSuggestions() []CompareRef {
+	query := strings.ToLower(strings.TrimSpace(p.compareInputs[p.compareField].Value()))
+	var matches []CompareRef
+	for _, ref := range p.compareRefs {
+		if query == "" || strings.Contains(strings.ToLower(ref.Name), query) {
+			matches = append(matches, ref)
+			if len(matches) == compareSuggestMax {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// acceptCompareSuggestion copies the highlighted suggestion into the focused input.
+func (p *Plugin) acceptCompareSuggestion() {
+	suggestions := p.compareSuggestions()
+	if p.compareSuggest < 0 || p.compareSuggest >= len(suggestions) {
+		return
+	}
+	p.compareInputs[p.compareField].SetValue(suggestions[p.compareSuggest].Name)
+	p.compareInputs[p.compareField].CursorEnd()
+	p.compareSuggest = -1
+}
+
+// focusCompareField moves input focus to field 0 (base) or 1 (compare).
+func (p *Plugin) focusCompareField(field int) {
+	p.compareInputs[p.compareField].Blur()
+	p.compareField = field
+	p.compareInputs[field].Focus()
+	p.compareSuggest = -1
+}
+
+// updateComparePick handles key events in the compare ref picker.
+func (p *Plugin) updateComparePick(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.compareRunning {
+		if msg.String() == "esc" {
+			p.closeCompare()
+		}
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc":
+		p.closeCompare()
+		return p, nil
+	case "tab", "shift+tab":
+		p.acceptCompareSuggestion()
+		p.focusCompareField(1 - p.compareField)
+		return p, nil
+	case "down", "ctrl+n":
+		if p.compareSuggest < len(p.compareSuggestions())-1 {
+			p.compareSuggest++
+		}
+		return p, nil
+	case "up", "ctrl+p":
+		if p.compareSuggest >= 0 {
+			p.compareSuggest--
+		}
+		return p, nil
+	case "enter":
+		p.acceptCompareSuggestion()
+		return p, p.doCompare()
+	}
+	var cmd tea.Cmd
+	p.compareInputs[p.compareField], cmd = p.compareInputs[p.compareField].Update(msg)
+	p.compareSuggest = -1
+	p.comparePickErr = ""
+	return p, cmd
+}
+
+// handleComparePickMouse handles mouse events in the compare ref picker.
+func (p *Plugin) handleComparePickMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.comparePickModal == nil {
+		return p, nil
+	}
+	switch p.comparePickModal.HandleMouse(msg, p.mouseHandler) {
+	case "compare":
+		return p, p.doCompare()
+	case "cancel":
+		p.closeCompare()
+	}
+	return p, nil
+}
+
+// doCompare runs the comparison described by the picker.
+func (p *Plugin) doCompare() tea.Cmd {
+	base := strings.TrimSpace(p.compareInputs[0].Value())
+	head := strings.TrimSpace(p.compareInputs[1].Value())
+	if base == "" || head == "" {
+		p.comparePickErr = "Pick both sides to compare"
+		return nil
+	}
+	p.comparePickErr = ""
+	p.compareRunning = true
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		c, err := CompareRefs(workDir, base, head)
+		return CompareLoadedMsg{Epoch: epoch, Comparison: c, Err: err}
+	}
+}
+
+// handleCompareLoaded shows the comparison, or the error in the picker.
+func (p *Plugin) handleCompareLoaded(msg CompareLoadedMsg) {
+	if p.viewMode != ViewModeComparePick && p.viewMode != ViewModeCompare {
+		return
+	}
+	p.compareRunning = false
+	if msg.Err != nil {
+		p.viewMode = ViewModeComparePick
+		p.comparePickErr = msg.Err.Error()
+		return
+	}
+	p.comparison = msg.Comparison
+	p.compareDiff = ParseMultiFileDiff(msg.Comparison.Diff)
+	p.compareTab = compareTabCommits
+	if len(msg.Comparison.HeadOnly)+len(msg.Comparison.BaseOnly) == 0 {
+		p.compareTab = compareTabFiles
+	}
+	p.compareCursor = 0
+	p.compareScroll = 0
+	p.viewMode = ViewModeCompare
+}
+
+// closeCompare leaves the compare picker or view.
+func (p *Plugin) closeCompare() {
+	p.viewMode = ViewModeStatus
+	p.compareRunning = false
+	p.comparePickModal = nil
+	p.comparePickWidth = 0
+	p.comparison = nil
+	p.compareDiff = nil
+}
+
+// ensureComparePickModal builds/rebuilds the compare ref picker.
+func (p *Plugin) ensureComparePickModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.comparePickModal != nil && p.comparePickWidth == modalW {
+		return
+	}
+	p.comparePickWidth = modalW
+
+	p.comparePickModal = modal.New("Compare",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.compareInputsSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.compareSuggestSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Compare ", "compare"),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// compareInputsSection renders the base and compare inputs.
+func (p *Plugin) compareInputsSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var sb strings.Builder
+		for i, label := range []string{"Base   ", "Compare"} {
+			style := styles.Muted
+			if i == p.compareField {
+				style = styles.Title
+			}
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			p.compareInputs[i].Width = contentWidth - 10
+			sb.WriteString(style.Render(label) + " " + p.compareInputs[i].View())
+		}
+		switch {
+		case p.compareRunning:
+			sb.WriteString("\n\n" + styles.StatusInProgress.Render("Comparing..."))
+		case p.comparePickErr != "":
+			sb.WriteString("\n\n" + styles.StatusDeleted.Render(ui.TruncateString(strings.TrimSpace(p.comparePickErr), contentWidth)))
+		}
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// compareSuggestSection lists the refs matching the focused input.
+func (p *Plugin) compareSuggestSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		suggestions := p.compareSuggestions()
+		if len(suggestions) == 0 {
+			return modal.RenderedSection{Content: styles.Muted.Render("No matching refs; any commit-ish works")}
+		}
+		lines := make([]string, len(suggestions))
+		for i, ref := range suggestions {
+			kind := fmt.Sprintf("%-8s", ref.Kind)
+			name := ui.TruncateString(ref.Name, contentWidth-len(kind)-2)
+			pad := strings.Repeat(" ", max(0, contentWidth-len(kind)-1-lipgloss.Width(name)))
+			if i == p.compareSuggest {
+				lines[i] = styles.ListItemSelected.Render(name + pad + kind)
+				continue
+			}
+			lines[i] = styles.Body.Render(name) + pad + styles.Muted.Render(kind)
+		}
+		lines = append(lines, "", styles.Muted.Render("↑/↓ pick · tab switch side · enter compare · esc cancel"))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderComparePick renders the compare ref picker over the status view.
+func (p *Plugin) renderComparePick() string {
+	background := p.renderThreePaneView()
+	p.ensureComparePickModal()
+	modalContent := p.comparePickModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// compareBodyHeight returns the rows available below the compare header.
+func (p *Plugin) compareBodyHeight() int {
+	h := p.height - 2 - compareHeaderRows
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// compareRowCount returns the number of selectable rows in the current tab.
+func (p *Plugin) compareRowCount() int {
+	c := p.comparison
+	if c == nil {
+		return 0
+	}
+	switch p.compareTab {
+	case compareTabCommits:
+		return len(c.HeadOnly) + len(c.BaseOnly)
+	case compareTabFiles:
+		return len(c.Files)
+	}
+	return p.compareDiff.TotalLines()
+}
+
+// moveCompare moves the list cursor, or scrolls the diff, by delta rows.
+func (p *Plugin) moveCompare(delta int) {
+	if p.compareTab == compareTabDiff {
+		p.compareScroll += delta
+		maxScroll := p.compareDiff.TotalLines() - p.compareBodyHeight()
+		if p.compareScroll > maxScroll {
+			p.compareScroll = maxScroll
+		}
+		if p.compareScroll < 0 {
+			p.compareScroll = 0
+		}
+		return
+	}
+	p.compareCursor += delta
+	if n := p.compareRowCount(); p.compareCursor >= n {
+		p.compareCursor = n - 1
+	}
+	if p.compareCursor < 0 {
+		p.compareCursor = 0
+	}
+}
+
+// setCompareTab switches tabs, starting lists and the diff at the top.
+func (p *Plugin) setCompareTab(tab int) {
+	p.compareTab = (tab + compareTabCount) % compareTabCount
+	p.compareCursor = 0
+	p.compareScroll = 0
+}
+
+// showCompareFile opens the diff tab at the file under the cursor.
+func (p *Plugin) showCompareFile() {
+	if p.comparison == nil || p.compareCursor >= len(p.comparison.Files) {
+		return
+	}
+	path := p.comparison.Files[p.compareCursor].Path
+	p.setCompareTab(compareTabDiff)
+	for i := range p.compareDiff.Files {
+		if p.compareDiff.Files[i].FileName() == path {
+			p.compareScroll = p.compareDiff.FileStartLine(i)
+			return
+		}
+	}
+}
+
+// jumpCompareFile scrolls the diff to the next (delta 1) or previous file.
+func (p *Plugin) jumpCompareFile(delta int) {
+	p.compareScroll = jumpDiffFile(p.compareDiff, p.compareScroll, delta)
+}
+
+// currentDiffFile returns the index of the file shown at scroll in mfd.
+func currentDiffFile(mfd *MultiFileDiff, scroll int) int {
+	current := 0
+	for i := 0; i < mfd.FileCount(); i++ {
+		if mfd.FileStartLine(i) <= scroll {
+			current = i
+		}
+	}
+	return current
+}
+
+// jumpDiffFile returns the scroll position of the next (delta 1) or
+// previous file in mfd, or scroll when there is none.
+func jumpDiffFile(mfd *MultiFileDiff, scroll, delta int) int {
+	n := mfd.FileCount()
+	if n == 0 {
+		return scroll
+	}
+	current := currentDiffFile(mfd, scroll)
+	target := current + delta
+	if delta < 0 && scroll > mfd.FileStartLine(current) {
+		// Back to the top of the current file first
+		target = current
+	}
+	if target < 0 || target >= n {
+		return scroll
+	}
+	return mfd.FileStartLine(target)
+}
+
+// updateCompare handles key events in the compare view.
+func (p *Plugin) updateCompare(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.comparison == nil {
+		p.closeCompare()
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.closeCompare()
+	case "tab":
+		p.setCompareTab(p.compareTab + 1)
+	case "shift+tab":
+		p.setCompareTab(p.compareTab - 1)
+	case "j", "down":
+		p.moveCompare(1)
+	case "k", "up":
+		p.moveCompare(-1)
+	case "ctrl+d":
+		p.moveCompare(p.compareBodyHeight() / 2)
+	case "ctrl+u":
+		p.moveCompare(-p.compareBodyHeight() / 2)
+	case "g":
+		p.compareCursor, p.compareScroll = 0, 0
+	case "G":
+		p.moveCompare(p.compareRowCount())
+	case "enter":
+		if p.compareTab == compareTabFiles {
+			p.showCompareFile()
+		}
+	case "]":
+		p.jumpCompareFile(1)
+	case "[":
+		p.jumpCompareFile(-1)
+	case "v":
+		if p.diffViewMode == DiffViewUnified {
+			p.diffViewMode = DiffViewSideBySide
+			_ = state.SetGitDiffMode("side-by-side")
+		} else {
+			p.diffViewMode = DiffViewUnified
+			_ = state.SetGitDiffMode("unified")
+		}
+	case "s":
+		// Swap sides and compare again
+		base, head := p.comparison.Base, p.comparison.Head
+		cmd := p.openComparePicker(head, base)
+		return p, tea.Batch(cmd, p.doCompare())
+	case "c":
+		return p, p.openComparePicker(p.comparison.Base, p.comparison.Head)
+	}
+	return p, nil
+}
+
+// handleCompareMouse handles mouse events in the compare view.
+func (p *Plugin) handleCompareMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	action := p.mouseHandler.HandleMouse(msg)
+	switch action.Type {
+	case mouse.ActionClick:
+		if action.Region == nil {
+			return p, nil
+		}
+		switch action.Region.ID {
+		case regionCompareBack:
+			p.closeCompare()
+		case regionCompareTab:
+			if tab, ok := action.Region.Data.(int); ok {
+				p.setCompareTab(tab)
+			}
+		}
+	case mouse.ActionScrollUp, mouse.ActionScrollDown:
+		p.moveCompare(action.Delta)
+	}
+	return p, nil
+}
+
+// renderCompare renders the full-screen compare view.
+func (p *Plugin) renderCompare() string {
+	paneHeight := p.height - 2
+	contentWidth := p.width - 4
+	if contentWidth < 20 {
+		contentWidth = 20
+	}
+	c := p.comparison
+	p.mouseHandler.Clear()
+	p.mouseHandler.HitMap.AddRect(regionCompare, 0, 0, p.width, p.height, nil)
+	if c == nil {
+		return p.wrapDiffContent(styles.Muted.Render("Comparing..."), paneHeight)
+	}
+
+	var sb strings.Builder
+
+	// Breadcrumb: back link, the range and the diff mode
+	back := styles.Link.Render("← Back")
+	backWidth := lipgloss.Width(back)
+	p.mouseHandler.HitMap.AddRect(regionCompareBack, 2, 1, backWidth, 1, nil)
+	viewModeStr := "unified"
+	if p.diffViewMode == DiffViewSideBySide {
+		viewModeStr = "side-by-side"
+	}
+	mode := styles.Muted.Render("[" + viewModeStr + "]")
+	rangeW := contentWidth - backWidth - lipgloss.Width(mode) - 6
+	sb.WriteString(back + styles.Muted.Render(" · ") + styles.Title.Render(ui.TruncateString(c.Base+"..."+c.Head, rangeW)) + " " + mode)
+	sb.WriteString("\n")
+
+	summary := fmt.Sprintf("%s is %d ahead, %d behind %s · base %s · %d file(s) ",
+		c.Head, len(c.HeadOnly), len(c.BaseOnly), c.Base, shortHash(c.MergeBase), len(c.Files))
+	sb.WriteString(styles.Muted.Render(ui.TruncateString(summary, contentWidth-16)))
+	sb.WriteString(styles.DiffAdd.Render(fmt.Sprintf("+%d", c.Additions())) + " " + styles.DiffRemove.Render(fmt.Sprintf("-%d", c.Deletions())))
+	sb.WriteString("\n")
+
+	// Tabs, clickable
+	x := 2
+	counts := [compareTabCount]string{
+		fmt.Sprintf(" (%d)", len(c.HeadOnly)+len(c.BaseOnly)),
+		fmt.Sprintf(" (%d)", len(c.Files)),
+		"",
+	}
+	for i, name := range compareTabNames {
+		label := " " + name + counts[i] + " "
+		style := styles.Muted
+		if i == p.compareTab {
+			style = styles.ListItemSelected
+		}
+		sb.WriteString(style.Render(label) + " ")
+		w := lipgloss.Width(label)
+		p.mouseHandler.HitMap.AddRect(regionCompareTab, x, 3, w, 1, i)
+		x += w + 1
+	}
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render(strings.Repeat("━", contentWidth)))
+	sb.WriteString("\n")
+
+	height := p.compareBodyHeight()
+	switch p.compareTab {
+	case compareTabCommits:
+		sb.WriteString(p.renderCompareCommits(contentWidth, height))
+	case compareTabFiles:
+		sb.WriteString(p.renderCompareFiles(contentWidth, height))
+	default:
+		diff := RenderMultiFileDiff(p.compareDiff, p.diffViewMode, contentWidth, p.compareScroll, height, 0, false)
+		lines := strings.Split(diff, "\n")
+		for i, line := range lines {
+			if lipgloss.Width(line) > contentWidth {
+				lines[i] = truncateStyledLine(line, contentWidth-3) + "..."
+			}
+		}
+		sb.WriteString(strings.Join(lines, "\n"))
+	}
+	return p.wrapDiffContent(sb.String(), paneHeight)
+}
+
+// renderCompareCommits lists the commits only on the compare side, then
+// those only on the base side.
+func (p *Plugin) renderCompareCommits(width, height int) string {
+	c := p.comparison
+	var rows []string
+	cursorRow := 0
+	idx := 0
+	for _, side := range []struct {
+		ref     string
+		commits []*CompareCommit
+	}{{c.Head, c.HeadOnly}, {c.Base, c.BaseOnly}} {
+		if len(rows) > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, styles.Title.Render(fmt.Sprintf("Only in %s (%d)", side.ref, len(side.commits))))
+		if len(side.commits) == 0 {
+			rows = append(rows, styles.Muted.Render("  none"))
+		}
+		for _, commit := range side.commits {
+			if idx == p.compareCursor {
+				cursorRow = len(rows)
+			}
+			rows = append(rows, renderCompareCommitLine(commit, width, idx == p.compareCursor))
+			idx++
+		}
+	}
+	return scrollRows(rows, cursorRow, height)
+}
+
+// renderCompareCommitLine renders one commit: hash, subject, author and age.
+func renderCompareCommitLine(commit *CompareCommit, width int, selected bool) string {
+	meta := commit.Author
+	if !commit.Date.IsZero() {
+		meta += " · " + RelativeTime(commit.Date)
+	}
+	subjectW := width - 11 - lipgloss.Width(meta)
+	if subjectW < 10 {
+		subjectW = 10
+	}
+	subject := ui.TruncateString(commit.Subject, subjectW)
+	pad := strings.Repeat(" ", max(0, subjectW-lipgloss.Width(subject)))
+	if selected {
+		return styles.ListItemSelected.Render("  " + commit.ShortHash() + "  " + subject + pad + meta)
+	}
+	return "  " + styles.Code.Render(commit.ShortHash()) + "  " + styles.Body.Render(subject) + pad + styles.Muted.Render(meta)
+}
+
+// renderCompareFiles lists the changed files with their line counts.
+func (p *Plugin) renderCompareFiles(width, height int) string {
+	c := p.comparison
+	if len(c.Files) == 0 {
+		return styles.Muted.Render("No file changes since the merge base")
+	}
+	rows := make([]string, len(c.Files))
+	for i, f := range c.Files {
+		stat := fmt.Sprintf("+%d -%d", f.Additions, f.Deletions)
+		if f.Binary {
+			stat = "binary"
+		}
+		path := f.Path
+		if f.OldPath != "" {
+			path = f.OldPath + " → " + f.Path
+		}
+		path = ui.TruncateString(path, width-lipgloss.Width(stat)-6)
+		pad := strings.Repeat(" ", max(0, width-4-lipgloss.Width(path)-lipgloss.Width(stat)))
+		if i == p.compareCursor {
+			rows[i] = styles.ListItemSelected.Render(f.Status + "  " + path + pad + stat)
+			continue
+		}
+		statusStyle := styles.StatusModified
+		switch f.Status {
+		case "A":
+			statusStyle = styles.StatusStaged
+		case "D":
+			statusStyle = styles.StatusDeleted
+		}
+		styledStat := styles.DiffAdd.Render(fmt.Sprintf("+%d", f.Additions)) + " " + styles.DiffRemove.Render(fmt.Sprintf("-%d", f.Deletions))
+		if f.Binary {
+			styledStat = styles.Muted.Render(stat)
+		}
+		rows[i] = statusStyle.Render(f.Status) + "  " + styles.Body.Render(path) + pad + styledStat
+	}
+	return scrollRows(rows, p.compareCursor, height)
+}
+
+// scrollRows returns the height rows around cursor, keeping it in view.
+func scrollRows(rows []string, cursor, height int) string {
+	start := 0
+	if cursor >= height {
+		start = cursor - height + 1
+	}
+	end := start + height
+	if end > len(rows) {
+		end = len(rows)
+	}
+	return strings.Join(rows[start:end], "\n")
+}
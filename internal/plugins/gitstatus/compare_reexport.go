@@ -0,0 +1,28 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export comparison types from internal/git.
+type (
+	CompareRef     = git.CompareRef
+	CompareRefKind = git.CompareRefKind
+	CompareCommit  = git.CompareCommit
+	CompareFile    = git.CompareFile
+	Comparison     = git.Comparison
+	CompareError   = git.CompareError
+)
+
+// Re-export compare ref kinds.
+const (
+	CompareRefBranch   = git.CompareRefBranch
+	CompareRefWorktree = git.CompareRefWorktree
+	CompareRefRemote   = git.CompareRefRemote
+	CompareRefTag      = git.CompareRefTag
+)
+
+// Re-export comparison functions.
+var (
+	ListCompareRefs       = git.ListCompareRefs
+	GetDefaultCompareBase = git.GetDefaultCompareBase
+	CompareRefs           = git.CompareRefs
+)
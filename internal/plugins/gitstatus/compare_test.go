@@ -0,0 +1,165 @@
+package gitstatus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+const compareTestDiff = `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,2 @@
+ package a
+-var x = 1
++var x = 2
+diff --git a/b.go b/b.go
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/b.go
+@@ -0,0 +1 @@
++package b
+`
+
+func newComparePlugin(t *testing.T) *Plugin {
+	t.Helper()
+	p := newHistoryOpsPlugin(t)
+	p.Update(runeKey("="))
+	if p.viewMode != ViewModeComparePick || p.FocusContext() != keymap.ContextGitComparePick || !p.ConsumesTextInput() {
+		t.Fatalf("= should open the compare picker, viewMode=%v", p.viewMode)
+	}
+	p.Update(CompareRefsLoadedMsg{
+		Base: "main",
+		Refs: []CompareRef{
+			{Name: "main", Kind: CompareRefBranch},
+			{Name: "feature/login", Kind: CompareRefWorktree},
+			{Name: "origin/main", Kind: CompareRefRemote},
+			{Name: "v1.0.0", Kind: CompareRefTag},
+		},
+	})
+	return p
+}
+
+func loadedComparison() CompareLoadedMsg {
+	return CompareLoadedMsg{Comparison: &Comparison{
+		Base:      "main",
+		Head:      "feature/login",
+		MergeBase: "abc0000000",
+		HeadOnly:  []*CompareCommit{{Hash: "fff0000000", Subject: "Add login form", Author: "Ada"}},
+		BaseOnly:  []*CompareCommit{{Hash: "eee0000000", Subject: "Bump deps", Author: "Grace"}},
+		Files: []CompareFile{
+			{Path: "a.go", Status: "M", Additions: 1, Deletions: 1},
+			{Path: "b.go", Status: "A", Additions: 1},
+		},
+		Diff: compareTestDiff,
+	}}
+}
+
+func TestComparePick_SuggestionsFillFields(t *testing.T) {
+	p := newComparePlugin(t)
+	if p.compareInputs[0].Value() != "main" || p.compareInputs[1].Value() != "HEAD" {
+		t.Fatalf("defaults = %q...%q", p.compareInputs[0].Value(), p.compareInputs[1].Value())
+	}
+
+	// Switch to the compare side and retype it from suggestions
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.compareInputs[1].SetValue("")
+	p.Update(runeKey("l"))
+	p.Update(runeKey("o"))
+	got := p.compareSuggestions()
+	if len(got) != 1 || got[0].Name != "feature/login" {
+		t.Fatalf("suggestions for \"lo\" = %v", got)
+	}
+	view := p.View(120, 40)
+	if !strings.Contains(view, "feature/login") || !strings.Contains(view, "worktree") {
+		t.Error("picker should list matching refs with their kind")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.compareInputs[1].Value() != "feature/login" || p.compareField != 0 {
+		t.Errorf("tab should accept the suggestion and switch sides, got %q field %d", p.compareInputs[1].Value(), p.compareField)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !p.compareRunning {
+		t.Fatal("enter should run the comparison")
+	}
+	if !strings.Contains(p.View(120, 40), "Comparing...") {
+		t.Error("picker should show progress")
+	}
+}
+
+func TestComparePick_ErrorStaysInPicker(t *testing.T) {
+	p := newComparePlugin(t)
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(CompareLoadedMsg{Err: &CompareError{Output: "Unknown ref: nope", Err: errors.New("exit 1")}})
+	if p.viewMode != ViewModeComparePick || p.compareRunning {
+		t.Fatalf("failed compare should stay in the picker, viewMode=%v", p.viewMode)
+	}
+	if !strings.Contains(p.View(120, 40), "Unknown ref: nope") {
+		t.Error("picker should show the error")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus {
+		t.Error("esc should close the picker")
+	}
+}
+
+func TestCompare_TabsAndFileJump(t *testing.T) {
+	p := newComparePlugin(t)
+	p.Update(loadedComparison())
+	if p.viewMode != ViewModeCompare || p.FocusContext() != keymap.ContextGitCompare || p.ConsumesTextInput() {
+		t.Fatalf("loaded comparison should open the compare view, viewMode=%v", p.viewMode)
+	}
+
+	view := p.View(120, 40)
+	for _, want := range []string{"main...feature/login", "1 ahead, 1 behind", "Only in feature/login", "Add login form", "Only in main", "Bump deps"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("commits tab missing %q", want)
+		}
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.compareTab != compareTabFiles {
+		t.Fatalf("tab should switch to files, got %d", p.compareTab)
+	}
+	view = p.View(120, 40)
+	if !strings.Contains(view, "a.go") || !strings.Contains(view, "b.go") {
+		t.Error("files tab should list changed files")
+	}
+
+	p.Update(runeKey("j"))
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.compareTab != compareTabDiff || p.compareScroll != p.compareDiff.FileStartLine(1) {
+		t.Errorf("enter should open the diff at b.go, tab=%d scroll=%d", p.compareTab, p.compareScroll)
+	}
+	p.Update(runeKey("["))
+	if p.compareScroll != 0 {
+		t.Errorf("[ should jump to the previous file, scroll=%d", p.compareScroll)
+	}
+	if !strings.Contains(p.View(120, 40), "package b") {
+		t.Error("diff tab should render the aggregate diff")
+	}
+
+	p.Update(runeKey("s"))
+	if p.viewMode != ViewModeComparePick || p.compareInputs[0].Value() != "feature/login" || p.compareInputs[1].Value() != "main" {
+		t.Errorf("s should swap sides and compare again, got %q...%q", p.compareInputs[0].Value(), p.compareInputs[1].Value())
+	}
+}
+
+func TestOpenCompareMsg_RunsDirectly(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	_, cmd := p.Update(OpenCompareMsg{Base: "main", Head: "feature/login"})
+	if cmd == nil || p.viewMode != ViewModeComparePick || !p.compareRunning {
+		t.Fatalf("OpenCompareMsg with both refs should start comparing, viewMode=%v", p.viewMode)
+	}
+	if p.compareInputs[0].Value() != "main" || p.compareInputs[1].Value() != "feature/login" {
+		t.Errorf("refs = %q...%q", p.compareInputs[0].Value(), p.compareInputs[1].Value())
+	}
+}
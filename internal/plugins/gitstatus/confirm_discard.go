@@ -27,9 +27,19 @@ func (p *Plugin) buildDiscardModal() {
 		statusLabel = "untracked"
 	}
 
+	// Hunk discards come from the diff view and only touch the working tree
+	if p.discardHunk {
+		statusLabel = "hunk"
+		if p.diffLineMode {
+			statusLabel = "selected line"
+		}
+	}
+
 	// Determine warning message
 	var warningMsg string
-	if entry.Status == StatusUntracked {
+	if p.discardHunk {
+		warningMsg = styles.Muted.Render("This will revert these changes in the working tree.")
+	} else if entry.Status == StatusUntracked {
 		warningMsg = styles.StatusDeleted.Render("This will permanently delete the file!")
 	} else {
 		warningMsg = styles.Muted.Render("This will revert to the last committed state.")
@@ -61,8 +71,13 @@ func (p *Plugin) buildDiscardModal() {
 
 // renderConfirmDiscard renders the confirm discard modal overlay.
 func (p *Plugin) renderConfirmDiscard() string {
-	// Render the background (status view dimmed)
-	background := p.renderThreePaneView()
+	// Render the background (status or diff view dimmed)
+	var background string
+	if p.discardReturnMode == ViewModeDiff {
+		background = p.renderDiffView()
+	} else {
+		background = p.renderThreePaneView()
+	}
 
 	if p.discardFile == nil {
 		return background
@@ -0,0 +1,198 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/modal"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// defaultLargeFileWarnMB is the staging size limit when no config is loaded.
+const defaultLargeFileWarnMB = 10
+
+// largeFileModalRows is how many large files the modal lists by name.
+const largeFileModalRows = 8
+
+// largeFile is a file over the size limit that is about to be staged
+// outside Git LFS.
+type largeFile struct {
+	Path string
+	Size int64
+}
+
+// largeFileLimit returns the size in bytes past which staging a file not
+// stored in Git LFS asks first, or 0 when the warning is off.
+func (p *Plugin) largeFileLimit() int64 {
+	mb := defaultLargeFileWarnMB
+	if p.ctx != nil && p.ctx.Config != nil {
+		mb = p.ctx.Config.Plugins.GitStatus.LargeFileWarnMB
+	}
+	return int64(mb) << 20
+}
+
+// largeFilesToStage returns the files of entries, folders included, that
+// are over the size limit and not stored in Git LFS.
+func (p *Plugin) largeFilesToStage(entries []*FileEntry) []largeFile {
+	limit := p.largeFileLimit()
+	if limit <= 0 {
+		return nil
+	}
+	var files []largeFile
+	var check func(entries []*FileEntry)
+	check = func(entries []*FileEntry) {
+		for _, entry := range entries {
+			if entry.IsFolder {
+				check(entry.Children)
+				continue
+			}
+			if entry.LFS || entry.Submodule != nil || entry.Status == StatusDeleted {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(p.repoRoot, entry.Path))
+			if err == nil && info.Mode().IsRegular() && info.Size() > limit {
+				files = append(files, largeFile{Path: entry.Path, Size: info.Size()})
+			}
+		}
+	}
+	check(entries)
+	return files
+}
+
+// stageChecked runs stage, asking first when entries include large files
+// that Git LFS does not store.
+func (p *Plugin) stageChecked(entries []*FileEntry, stage func() tea.Cmd) tea.Cmd {
+	files := p.largeFilesToStage(entries)
+	if len(files) == 0 {
+		return stage()
+	}
+	p.largeFiles = files
+	p.largeFileStage = stage
+	p.largeFileModal = nil
+	p.largeFileReturnMode = p.viewMode
+	p.viewMode = ViewModeConfirmLargeFiles
+	return nil
+}
+
+// buildLargeFileModal creates the large file confirmation modal.
+func (p *Plugin) buildLargeFileModal() {
+	modalWidth := 60
+	if modalWidth > p.width-10 {
+		modalWidth = p.width - 10
+	}
+	if modalWidth < 20 {
+		modalWidth = 20
+	}
+
+	what := "This file is"
+	if len(p.largeFiles) > 1 {
+		what = fmt.Sprintf("These %d files are", len(p.largeFiles))
+	}
+	sections := []modal.Section{
+		modal.Text(fmt.Sprintf("%s over %s and not stored in Git LFS:", what, formatBlobSize(p.largeFileLimit()))),
+		modal.Spacer(),
+	}
+	for i, f := range p.largeFiles {
+		if i == largeFileModalRows {
+			sections = append(sections, modal.Text(styles.Muted.Render(fmt.Sprintf("…and %d more", len(p.largeFiles)-i))))
+			break
+		}
+		size := formatBlobSize(f.Size)
+		path := ui.TruncateStart(f.Path, max(10, modalWidth-len(size)-8))
+		sections = append(sections, modal.Text(styles.Subtitle.Render(path)+"  "+styles.Muted.Render(size)))
+	}
+	sections = append(sections,
+		modal.Spacer(),
+		modal.Text(lipgloss.NewStyle().Foreground(styles.Warning).Bold(true).Render("Warning: ")+"Large files stay in the history for good."),
+		modal.Text(styles.Muted.Render("Track them with git lfs track to keep clones small.")),
+		modal.Spacer(),
+		modal.Buttons(
+			modal.Btn(" Stage ", "stage", modal.BtnDanger()),
+			modal.Btn(" Cancel ", "cancel"),
+		),
+	)
+
+	m := modal.New("Stage Large Files",
+		modal.WithVariant(modal.VariantDanger),
+		modal.WithWidth(modalWidth),
+	)
+	for _, s := range sections {
+		m = m.AddSection(s)
+	}
+	p.largeFileModal = m
+}
+
+// renderConfirmLargeFiles renders the large file confirmation modal overlay.
+func (p *Plugin) renderConfirmLargeFiles() string {
+	background := p.renderThreePaneView()
+	if len(p.largeFiles) == 0 {
+		return background
+	}
+	if p.largeFileModal == nil {
+		p.buildLargeFileModal()
+	}
+	modalContent := p.largeFileModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// handleLargeFilesMouse handles mouse events for the large file
+// confirmation modal.
+func (p *Plugin) handleLargeFilesMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.largeFileModal == nil {
+		return p, nil
+	}
+	switch p.largeFileModal.HandleMouse(msg, p.mouseHandler) {
+	case "stage":
+		return p.confirmLargeFiles()
+	case "cancel":
+		return p.cancelLargeFiles()
+	}
+	return p, nil
+}
+
+// updateConfirmLargeFiles handles key events in the large file
+// confirmation modal.
+func (p *Plugin) updateConfirmLargeFiles(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.largeFileModal == nil {
+		p.buildLargeFileModal()
+	}
+
+	// Quick confirm shortcut
+	switch msg.String() {
+	case "y", "Y":
+		return p.confirmLargeFiles()
+	}
+
+	action, cmd := p.largeFileModal.HandleKey(msg)
+	switch action {
+	case "stage":
+		return p.confirmLargeFiles()
+	case "cancel":
+		return p.cancelLargeFiles()
+	}
+	return p, cmd
+}
+
+// confirmLargeFiles stages the files and closes the modal.
+func (p *Plugin) confirmLargeFiles() (plugin.Plugin, tea.Cmd) {
+	stage := p.largeFileStage
+	p.cancelLargeFiles()
+	if stage == nil {
+		return p, nil
+	}
+	return p, stage()
+}
+
+// cancelLargeFiles closes the modal without staging.
+func (p *Plugin) cancelLargeFiles() (plugin.Plugin, tea.Cmd) {
+	p.viewMode = p.largeFileReturnMode
+	p.largeFiles = nil
+	p.largeFileStage = nil
+	p.largeFileModal = nil
+	return p, nil
+}
@@ -0,0 +1,21 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export conflict resolution types from internal/git.
+type (
+	ConflictOp    = git.ConflictOp
+	ConflictError = git.ConflictError
+)
+
+// Re-export conflict operations.
+const (
+	ConflictOpNone       = git.ConflictOpNone
+	ConflictOpMerge      = git.ConflictOpMerge
+	ConflictOpRebase     = git.ConflictOpRebase
+	ConflictOpCherryPick = git.ConflictOpCherryPick
+	ConflictOpRevert     = git.ConflictOpRevert
+)
+
+// Re-export conflict functions.
+var GetConflictOp = git.GetConflictOp
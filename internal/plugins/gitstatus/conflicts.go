@@ -0,0 +1,136 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/app"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
+)
+
+// Modal button IDs that open the conflict resolver.
+const (
+	pullConflictResolveID = "pull-conflict-resolve"
+	rebaseResolveID       = "rebase-resolve"
+)
+
+// openConflictResolver opens the three-way resolver for files in dir.
+// fallback names the operation when it can no longer be detected.
+func (p *Plugin) openConflictResolver(dir string, fallback ConflictOp, files []string) tea.Cmd {
+	if dir == "" {
+		dir = p.repoRoot
+	}
+	op := GetConflictOp(dir)
+	if op == ConflictOpNone {
+		op = fallback
+	}
+	p.conflictResolver = conflict.New(dir, op, files)
+	p.viewMode = ViewModeConflicts
+	p.clearPullConflictModal()
+	p.rebaseStopModal = nil
+	p.rebaseStopWidth = 0
+	return p.conflictResolver.Init()
+}
+
+// resolvePullConflict opens the resolver from the conflict modal.
+func (p *Plugin) resolvePullConflict() (plugin.Plugin, tea.Cmd) {
+	return p, p.openConflictResolver(p.conflictDir(), ConflictOp(p.pullConflictType), p.pullConflictFiles)
+}
+
+// resolveRebaseConflicts opens the resolver from the stopped rebase modal.
+func (p *Plugin) resolveRebaseConflicts() (plugin.Plugin, tea.Cmd) {
+	if p.rebaseProgress == nil || len(p.rebaseProgress.Conflicts) == 0 {
+		return p, nil
+	}
+	return p, p.openConflictResolver(p.repoRoot, ConflictOpRebase, p.rebaseProgress.Conflicts)
+}
+
+// updateConflictResolver forwards a message to the resolver and acts on
+// the result.
+func (p *Plugin) updateConflictResolver(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
+	r := p.conflictResolver
+	if r == nil {
+		return p, nil
+	}
+	action, cmd := r.Update(msg)
+	switch action {
+	case conflict.ActionClose:
+		p.closeConflictResolver()
+		return p, tea.Batch(cmd, p.refresh())
+	case conflict.ActionEdit:
+		return p, p.editConflictFile(r.WorkDir(), r.EditPath(), r.EditLine())
+	case conflict.ActionDone, conflict.ActionAborted:
+		label := pullConflictLabels[string(r.Op())]
+		if label == "" {
+			label = "Merge"
+		}
+		toast := label + " complete"
+		if action == conflict.ActionAborted {
+			toast = label + " aborted"
+		}
+		p.closeConflictResolver()
+		p.pullConflictFiles = nil
+		p.pullConflictType = ""
+		p.pullConflictDir = ""
+		p.rebaseProgress = nil
+		p.markedCommits = nil
+		done := appmsg.ShowToast(toast, 2*time.Second)
+		if action == conflict.ActionDone && r.Op() == ConflictOpRebase {
+			done = p.rebaseDoneOrStopped(r.WorkDir(), done)
+		}
+		return p, tea.Batch(done, p.refresh(), p.loadRecentCommits())
+	}
+	return p, cmd
+}
+
+// rebaseDoneOrStopped reopens the stopped rebase view when the rebase
+// stopped again without conflicts (an edit step), otherwise runs done.
+func (p *Plugin) rebaseDoneOrStopped(workDir string, done tea.Cmd) tea.Cmd {
+	epoch := p.ctx.Epoch
+	return func() tea.Msg {
+		if progress := GetRebaseProgress(workDir); progress != nil {
+			result := &RebaseResult{Stopped: true, Conflicts: progress.Conflicts}
+			return RebaseResultMsg{Epoch: epoch, Op: rebaseOpResume, Result: result, Progress: progress}
+		}
+		return done()
+	}
+}
+
+// editConflictFile opens the merged file at line in the file browser's
+// inline editor, or in the external editor for other worktrees.
+func (p *Plugin) editConflictFile(dir, path string, line int) tea.Cmd {
+	if dir != p.repoRoot {
+		return func() tea.Msg {
+			editor := os.Getenv("EDITOR")
+			if editor == "" {
+				editor = "vim"
+			}
+			return plugin.OpenFileMsg{Editor: editor, Path: filepath.Join(dir, path), LineNo: line + 1}
+		}
+	}
+	return tea.Batch(
+		app.FocusPlugin("file-browser"),
+		func() tea.Msg {
+			return filebrowser.NavigateToFileMsg{Path: path, Edit: true, LineNo: line}
+		},
+	)
+}
+
+// closeConflictResolver returns to the status view.
+func (p *Plugin) closeConflictResolver() {
+	p.conflictResolver = nil
+	p.viewMode = ViewModeStatus
+}
+
+// renderConflicts renders the full-screen conflict resolver.
+func (p *Plugin) renderConflicts() string {
+	if p.conflictResolver == nil {
+		return p.renderThreePaneView()
+	}
+	return p.conflictResolver.View(p.width, p.height)
+}
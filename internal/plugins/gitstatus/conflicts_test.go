@@ -0,0 +1,57 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+func TestPullConflict_ResolveOpensResolver(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.viewMode = ViewModePullConflict
+	p.pullConflictType = "merge"
+	p.pullConflictFiles = []string{"f.txt"}
+	if !strings.Contains(p.View(100, 30), "Resolve") {
+		t.Error("conflict modal should offer Resolve")
+	}
+
+	if _, cmd := p.Update(runeKey("r")); cmd == nil {
+		t.Fatal("r should load the first conflicted file")
+	}
+	if p.viewMode != ViewModeConflicts || p.FocusContext() != keymap.ContextGitConflicts {
+		t.Fatalf("expected conflict resolver, viewMode=%v", p.viewMode)
+	}
+	if r := p.conflictResolver; r == nil || r.WorkDir() != "/repo" || r.Op() != ConflictOpMerge {
+		t.Fatalf("resolver = %+v", p.conflictResolver)
+	}
+	if view := p.View(100, 30); !strings.Contains(view, "Resolve Conflicts") || !strings.Contains(view, "f.txt") {
+		t.Error("resolver view should list the conflicted file")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus || p.conflictResolver != nil {
+		t.Error("esc should close the resolver")
+	}
+}
+
+func TestRebaseStopped_ResolveOpensResolver(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.viewMode = ViewModeRebaseStopped
+	p.rebaseProgress = &RebaseProgress{Step: 1, Total: 2}
+
+	if p.Update(runeKey("r")); p.viewMode != ViewModeRebaseStopped {
+		t.Fatal("r without conflicts should keep the stopped modal")
+	}
+
+	p.rebaseProgress.Conflicts = []string{"a.go"}
+	p.rebaseStopModal = nil
+	p.Update(runeKey("r"))
+	if p.viewMode != ViewModeConflicts || p.conflictResolver.Op() != ConflictOpRebase {
+		t.Fatalf("expected rebase resolver, viewMode=%v", p.viewMode)
+	}
+	if view := p.View(100, 30); !strings.Contains(view, "rebase") || !strings.Contains(view, "a.go") {
+		t.Error("resolver should show the rebase and its conflicted file")
+	}
+}
@@ -0,0 +1,211 @@
+package gitstatus
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// conventionalTypes are the commit types of the Conventional Commits spec
+// and its common extensions.
+var conventionalTypes = []string{
+	"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert",
+}
+
+// conventionalHeader matches "type(scope)!: subject".
+var conventionalHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: ?(.*)$`)
+
+// trailerLine matches a git trailer such as "Refs: td-a1b2".
+var trailerLine = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9-]*|BREAKING CHANGE): \S`)
+
+// defaultCommitSubjectLength is the header length lint warns past when the
+// config doesn't set one.
+const defaultCommitSubjectLength = 72
+
+// ConventionalMessage is a commit message split into Conventional Commits
+// parts.
+type ConventionalMessage struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+	Body     string
+	Trailers []string // "Key: value" lines
+}
+
+// ParseConventional splits message into its parts. ok is false when the
+// header is not a conventional one, in which case the header is the
+// subject.
+func ParseConventional(message string) (m ConventionalMessage, ok bool) {
+	message = strings.TrimSpace(message)
+	header, rest, _ := strings.Cut(message, "\n")
+	if match := conventionalHeader.FindStringSubmatch(header); match != nil {
+		m.Type, m.Scope, m.Breaking, m.Subject = match[1], match[2], match[3] == "!", match[4]
+		ok = true
+	} else {
+		m.Subject = header
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if last := paragraphs[len(paragraphs)-1]; last != "" && isTrailerBlock(last) {
+		m.Trailers = strings.Split(last, "\n")
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	m.Body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+	return m, ok
+}
+
+// isTrailerBlock reports whether every line of paragraph is a trailer.
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !trailerLine.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// Header returns the "type(scope)!: subject" line.
+func (m ConventionalMessage) Header() string {
+	header := m.Type
+	if m.Scope != "" {
+		header += "(" + m.Scope + ")"
+	}
+	if m.Breaking {
+		header += "!"
+	}
+	return header + ": " + m.Subject
+}
+
+// String assembles the full commit message.
+func (m ConventionalMessage) String() string {
+	parts := []string{m.Header()}
+	if body := strings.TrimSpace(m.Body); body != "" {
+		parts = append(parts, body)
+	}
+	var trailers []string
+	for _, t := range m.Trailers {
+		if t = strings.TrimSpace(t); t != "" {
+			trailers = append(trailers, t)
+		}
+	}
+	if len(trailers) > 0 {
+		parts = append(parts, strings.Join(trailers, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// LintCommitMessage checks the shape of message and returns a warning for
+// each problem. maxHeader is the longest header allowed; conventional adds
+// the Conventional Commits rules.
+func LintCommitMessage(message string, maxHeader int, conventional bool) []string {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil
+	}
+	lines := strings.Split(message, "\n")
+	header := lines[0]
+
+	var warnings []string
+	if n := len([]rune(header)); maxHeader > 0 && n > maxHeader {
+		warnings = append(warnings, fmt.Sprintf("Header is %d characters (max %d)", n, maxHeader))
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		warnings = append(warnings, "Leave a blank line after the header")
+	}
+	if !conventional {
+		return warnings
+	}
+
+	match := conventionalHeader.FindStringSubmatch(header)
+	if match == nil {
+		return append(warnings, "Header should be type(scope): subject")
+	}
+	typ, scope, subject := match[1], match[2], match[4]
+	switch {
+	case typ != strings.ToLower(typ):
+		warnings = append(warnings, "Type should be lowercase")
+	case !isConventionalType(typ):
+		warnings = append(warnings, fmt.Sprintf("Unknown type %q", typ))
+	}
+	if strings.Contains(header, "()") || (scope != "" && strings.TrimSpace(scope) != scope) {
+		warnings = append(warnings, "Scope should not be empty or padded")
+	}
+	if !strings.Contains(header, ": ") {
+		warnings = append(warnings, "Put a space after the colon")
+	}
+	switch {
+	case strings.TrimSpace(subject) == "":
+		warnings = append(warnings, "Subject is empty")
+	case strings.HasSuffix(subject, "."):
+		warnings = append(warnings, "Subject should not end with a period")
+	}
+	return warnings
+}
+
+// isConventionalType reports whether typ is a known commit type.
+func isConventionalType(typ string) bool {
+	for _, t := range conventionalTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// usesConventionalCommits reports whether most non-merge commits follow the
+// Conventional Commits header format.
+func usesConventionalCommits(commits []*Commit) bool {
+	conventional, total := 0, 0
+	for _, c := range commits {
+		if c.IsMerge {
+			continue
+		}
+		total++
+		if match := conventionalHeader.FindStringSubmatch(c.Subject); match != nil && isConventionalType(strings.ToLower(match[1])) {
+			conventional++
+		}
+	}
+	return total > 0 && conventional*2 > total
+}
+
+// historyConventions returns the commit types and scopes used in commits,
+// most frequent first. Types are followed by the standard ones not yet
+// used.
+func historyConventions(commits []*Commit) (types, scopes []string) {
+	typeCounts := make(map[string]int)
+	scopeCounts := make(map[string]int)
+	for _, c := range commits {
+		match := conventionalHeader.FindStringSubmatch(c.Subject)
+		if match == nil || !strings.Contains(c.Subject, ": ") {
+			continue
+		}
+		typeCounts[strings.ToLower(match[1])]++
+		if scope := strings.TrimSpace(match[2]); scope != "" {
+			scopeCounts[scope]++
+		}
+	}
+	types = byFrequency(typeCounts)
+	for _, t := range conventionalTypes {
+		if typeCounts[t] == 0 {
+			types = append(types, t)
+		}
+	}
+	return types, byFrequency(scopeCounts)
+}
+
+// byFrequency returns the keys of counts, most frequent first, then by name.
+func byFrequency(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,67 @@
+package gitstatus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConventional_RoundTrip(t *testing.T) {
+	message := "feat(api)!: drop v1 endpoints\n\nClients moved to v2.\n\nSecond paragraph.\n\nRefs: td-a1b2\nBREAKING CHANGE: v1 is gone"
+	m, ok := ParseConventional(message)
+	if !ok || m.Type != "feat" || m.Scope != "api" || !m.Breaking || m.Subject != "drop v1 endpoints" {
+		t.Fatalf("parsed = %+v", m)
+	}
+	if m.Body != "Clients moved to v2.\n\nSecond paragraph." || len(m.Trailers) != 2 {
+		t.Errorf("body = %q, trailers = %v", m.Body, m.Trailers)
+	}
+	if got := m.String(); got != message {
+		t.Errorf("String() = %q", got)
+	}
+
+	if m, ok := ParseConventional("Update readme\n\nNotes: are a paragraph"); ok || m.Subject != "Update readme" || m.Body != "" {
+		t.Errorf("plain message = %+v, ok = %v", m, ok)
+	}
+}
+
+func TestLintCommitMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		max     int
+		want    []string
+	}{
+		{"fix(ui): align columns", 72, nil},
+		{"Update readme", 72, []string{"Header should be type(scope): subject"}},
+		{"Feat: add x", 72, []string{"Type should be lowercase"}},
+		{"feature: add x.", 72, []string{`Unknown type "feature"`, "Subject should not end with a period"}},
+		{"fix():add x\nbody", 72, []string{"Leave a blank line after the header", "Scope should not be empty or padded", "Put a space after the colon"}},
+		{"fix: " + strings.Repeat("x", 30), 20, []string{"Header is 35 characters (max 20)"}},
+	}
+	for _, tt := range tests {
+		if got := LintCommitMessage(tt.message, tt.max, true); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LintCommitMessage(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+
+	// Repos without the convention only get the shape rules
+	if got := LintCommitMessage("Update readme\nbody", 72, false); !reflect.DeepEqual(got, []string{"Leave a blank line after the header"}) {
+		t.Errorf("plain lint = %q", got)
+	}
+}
+
+func TestHistoryConventions(t *testing.T) {
+	commits := []*Commit{
+		{Subject: "fix(ui): a"}, {Subject: "docs: b"}, {Subject: "fix(git): c"},
+		{Subject: "fix(ui): d"}, {Subject: "Merge branch 'x'"},
+	}
+	types, scopes := historyConventions(commits)
+	if types[0] != "fix" || types[1] != "docs" || types[2] != "feat" || len(types) != len(conventionalTypes) {
+		t.Errorf("types = %v", types)
+	}
+	if !reflect.DeepEqual(scopes, []string{"ui", "git"}) {
+		t.Errorf("scopes = %v", scopes)
+	}
+	if !usesConventionalCommits(commits) || usesConventionalCommits(commits[3:]) {
+		t.Error("history is conventional when most commits are")
+	}
+}
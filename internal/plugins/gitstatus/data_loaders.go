@@ -2,12 +2,15 @@ package gitstatus
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/state"
 )
 
 // loadDiff loads the diff for a file.
 func (p *Plugin) loadDiff(path string, staged bool, status FileStatus) tea.Cmd {
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
+	cache := p.imageCache()
+	opts := state.GetDiffOptions(state.DiffViewGitStatus)
 	return func() tea.Msg {
 		var rawDiff string
 		var err error
@@ -16,13 +19,17 @@ func (p *Plugin) loadDiff(path string, staged bool, status FileStatus) tea.Cmd {
 		if status == StatusUntracked {
 			rawDiff, err = GetNewFileDiff(workDir, path)
 		} else {
-			rawDiff, err = GetDiff(workDir, path, staged)
+			rawDiff, err = GetDiff(workDir, path, staged, opts)
 		}
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
 
-		return DiffLoadedMsg{Epoch: epoch, Content: rawDiff, Raw: rawDiff}
+		msg := DiffLoadedMsg{Epoch: epoch, Content: rawDiff, Raw: rawDiff}
+		if parsed, _ := ParseUnifiedDiff(rawDiff); wantsImageDiff(path, status, parsed) {
+			msg.Image = loadImageDiff(workDir, cache, path, staged, status)
+		}
+		return msg
 	}
 }
 
@@ -30,6 +37,8 @@ func (p *Plugin) loadDiff(path string, staged bool, status FileStatus) tea.Cmd {
 func (p *Plugin) loadInlineDiff(path string, staged bool, status FileStatus) tea.Cmd {
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
+	cache := p.imageCache()
+	opts := state.GetDiffOptions(state.DiffViewGitStatus)
 	return func() tea.Msg {
 		var rawDiff string
 		var err error
@@ -38,13 +47,17 @@ func (p *Plugin) loadInlineDiff(path string, staged bool, status FileStatus) tea
 		if status == StatusUntracked {
 			rawDiff, err = GetNewFileDiff(workDir, path)
 		} else {
-			rawDiff, err = GetDiff(workDir, path, staged)
+			rawDiff, err = GetDiff(workDir, path, staged, opts)
 		}
 		if err != nil {
 			return InlineDiffLoadedMsg{Epoch: epoch, File: path, Raw: "", Parsed: nil}
 		}
 		parsed, _ := ParseUnifiedDiff(rawDiff)
-		return InlineDiffLoadedMsg{Epoch: epoch, File: path, Raw: rawDiff, Parsed: parsed}
+		msg := InlineDiffLoadedMsg{Epoch: epoch, File: path, Raw: rawDiff, Parsed: parsed}
+		if wantsImageDiff(path, status, parsed) {
+			msg.Image = loadImageDiff(workDir, cache, path, staged, status)
+		}
+		return msg
 	}
 }
 
@@ -57,7 +70,13 @@ func (p *Plugin) loadRecentCommits() tea.Cmd {
 		if err != nil {
 			return RecentCommitsLoadedMsg{Epoch: epoch, Commits: nil, PushStatus: nil}
 		}
-		return RecentCommitsLoadedMsg{Epoch: epoch, Commits: commits, PushStatus: pushStatus}
+		msg := RecentCommitsLoadedMsg{Epoch: epoch, Commits: commits, PushStatus: pushStatus}
+		// Tags decorate the history rows; a failure just leaves them undecorated
+		msg.Tags, _ = GetTags(workDir)
+		msg.LatestTag, msg.SinceLatestTag = GetLatestTagDistance(workDir)
+		msg.Signing = GetSigningConfig(workDir)
+		msg.Bisect, _ = GetBisectState(workDir)
+		return msg
 	}
 }
 
@@ -70,6 +89,9 @@ func (p *Plugin) loadMoreCommits() tea.Cmd {
 
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
+	if p.historyFilterActive {
+		return p.loadFilteredCommitsPage(len(p.filteredCommits))
+	}
 	skip := len(p.recentCommits)
 	return func() tea.Msg {
 		commits, pushStatus, err := GetCommitHistoryWithPushStatusOffset(workDir, commitHistoryPageSize, skip)
@@ -83,19 +105,25 @@ func (p *Plugin) loadMoreCommits() tea.Cmd {
 
 // loadFilteredCommits fetches commits with current filter options.
 func (p *Plugin) loadFilteredCommits() tea.Cmd {
+	p.moreFilteredAvailable = false
+	p.previewMatchFiles = nil
+	return p.loadFilteredCommitsPage(0)
+}
+
+// loadFilteredCommitsPage fetches the page of filtered commits starting at
+// skip. Pages after the first are appended as the list scrolls.
+func (p *Plugin) loadFilteredCommitsPage(skip int) tea.Cmd {
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
-	opts := HistoryFilterOpts{
-		Author: p.historyFilterAuthor,
-		Path:   p.historyFilterPath,
-		Limit:  50,
-	}
+	opts := p.historyFilterOpts()
+	opts.Limit = commitHistoryPageSize
+	opts.Skip = skip
 	return func() tea.Msg {
 		commits, pushStatus, err := GetCommitHistoryFilteredWithPushStatus(workDir, opts)
 		if err != nil {
-			return FilteredCommitsLoadedMsg{Epoch: epoch, Commits: nil, PushStatus: nil}
+			return FilteredCommitsLoadedMsg{Epoch: epoch, Opts: opts, Err: err}
 		}
-		return FilteredCommitsLoadedMsg{Epoch: epoch, Commits: commits, PushStatus: pushStatus}
+		return FilteredCommitsLoadedMsg{Epoch: epoch, Commits: commits, PushStatus: pushStatus, Opts: opts}
 	}
 }
 
@@ -135,8 +163,9 @@ func (p *Plugin) loadFullFolderDiff(entry *FileEntry) tea.Cmd {
 func (p *Plugin) loadCommitFileDiff(hash, path, parentHash string) tea.Cmd {
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
+	opts := state.GetDiffOptions(state.DiffViewCommit)
 	return func() tea.Msg {
-		rawDiff, err := GetCommitDiff(workDir, hash, path, parentHash)
+		rawDiff, err := GetCommitDiff(workDir, hash, path, parentHash, opts)
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -150,11 +179,21 @@ func (p *Plugin) loadCommitFileDiff(hash, path, parentHash string) tea.Cmd {
 func (p *Plugin) loadCommitDetailForPreview(hash string) tea.Cmd {
 	epoch := p.ctx.Epoch
 	workDir := p.repoRoot
+	var query *HistoryQuery
+	var matchDiff string
+	if p.historyFilterActive && p.historyQuery.matchesContent() {
+		q := *p.historyQuery
+		query = &q
+		if c := p.findQueryCommit(hash); c != nil {
+			matchDiff = c.MatchDiff
+		}
+	}
 	return func() tea.Msg {
 		commit, err := GetCommitDetail(workDir, hash)
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
-		return CommitPreviewLoadedMsg{Epoch: epoch, Commit: commit}
+		matches := queryMatchedFiles(workDir, hash, query, matchDiff)
+		return CommitPreviewLoadedMsg{Epoch: epoch, Commit: commit, MatchFiles: matches}
 	}
 }
@@ -9,14 +9,14 @@ import (
 )
 
 // GetDiff returns the diff for a file.
-func GetDiff(workDir, path string, staged bool) (string, error) {
-	args := []string{"diff"}
+func GetDiff(workDir, path string, staged bool, opts DiffOptions) (string, error) {
+	var args []string
 	if staged {
 		args = append(args, "--cached")
 	}
 	args = append(args, "--", path)
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command("git", opts.Command("diff", args...)...)
 	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil {
@@ -33,13 +33,13 @@ func GetDiff(workDir, path string, staged bool) (string, error) {
 }
 
 // GetFullDiff returns the diff for all changes.
-func GetFullDiff(workDir string, staged bool) (string, error) {
-	args := []string{"diff"}
+func GetFullDiff(workDir string, staged bool, opts DiffOptions) (string, error) {
+	var args []string
 	if staged {
 		args = append(args, "--cached")
 	}
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command("git", opts.Command("diff", args...)...)
 	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil {
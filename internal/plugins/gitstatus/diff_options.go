@@ -0,0 +1,210 @@
+package gitstatus
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/state"
+	"github.com/guyghost/sidecar/internal/styles"
+)
+
+// Choices offered for the numeric options; 0 keeps git's default.
+var (
+	diffContextChoices     = []int{0, 1, 5, 10, 25}
+	renameThresholdChoices = []int{0, 90, 75, 50, 30}
+)
+
+// Rows of the diff options menu.
+const (
+	diffOptWhitespace = iota
+	diffOptBlankLines
+	diffOptContext
+	diffOptAlgorithm
+	diffOptRenames
+	diffOptCopies
+	diffOptMoved
+	diffOptCount
+)
+
+// DiffOptionsMenu edits the diff options of one view and saves them as they
+// change. The workspace plugin uses it for its diff tab.
+type DiffOptionsMenu struct {
+	View   string // state.DiffView* key the options are saved under
+	Opts   DiffOptions
+	cursor int
+}
+
+// NewDiffOptionsMenu returns a menu for view's saved options.
+func NewDiffOptionsMenu(view string) *DiffOptionsMenu {
+	return &DiffOptionsMenu{View: view, Opts: state.GetDiffOptions(view)}
+}
+
+// HandleKey applies a key press. changed reports that the options were
+// modified and the diff should be reloaded; done that the menu was closed.
+func (m *DiffOptionsMenu) HandleKey(key string) (changed, done bool) {
+	before := m.Opts
+	switch key {
+	case "esc", "q", "W", "enter":
+		return false, true
+	case "j", "down":
+		m.cursor = (m.cursor + 1) % diffOptCount
+	case "k", "up":
+		m.cursor = (m.cursor + diffOptCount - 1) % diffOptCount
+	case "l", "right", " ":
+		m.step(1)
+	case "h", "left":
+		m.step(-1)
+	case "r":
+		m.Opts = DiffOptions{}
+	}
+	if m.Opts == before {
+		return false, false
+	}
+	_ = state.SetDiffOptions(m.View, m.Opts)
+	return true, false
+}
+
+// step moves the option under the cursor to its next or previous value.
+func (m *DiffOptionsMenu) step(dir int) {
+	o := &m.Opts
+	switch m.cursor {
+	case diffOptWhitespace:
+		modes := []WhitespaceMode{WhitespaceShow, WhitespaceAll, WhitespaceChanges, WhitespaceEOL}
+		o.Whitespace = modes[cycleIndex(indexOf(modes, o.Whitespace), dir, len(modes))]
+	case diffOptBlankLines:
+		o.IgnoreBlankLines = !o.IgnoreBlankLines
+	case diffOptContext:
+		o.Context = diffContextChoices[cycleIndex(indexOf(diffContextChoices, o.Context), dir, len(diffContextChoices))]
+	case diffOptAlgorithm:
+		algos := []DiffAlgorithm{AlgorithmDefault, AlgorithmPatience, AlgorithmHistogram}
+		o.Algorithm = algos[cycleIndex(indexOf(algos, o.Algorithm), dir, len(algos))]
+	case diffOptRenames:
+		o.RenameThreshold = renameThresholdChoices[cycleIndex(indexOf(renameThresholdChoices, o.RenameThreshold), dir, len(renameThresholdChoices))]
+	case diffOptCopies:
+		o.FindCopies = !o.FindCopies
+	case diffOptMoved:
+		o.ColorMoved = !o.ColorMoved
+	}
+}
+
+func indexOf[T comparable](values []T, v T) int {
+	for i, x := range values {
+		if x == v {
+			return i
+		}
+	}
+	return 0
+}
+
+func cycleIndex(i, dir, n int) int {
+	return ((i+dir)%n + n) % n
+}
+
+// rows returns the label and current value of each option.
+func (m *DiffOptionsMenu) rows() [diffOptCount][2]string {
+	o := m.Opts
+	whitespace := map[WhitespaceMode]string{
+		WhitespaceShow:    "show",
+		WhitespaceAll:     "ignore all (-w)",
+		WhitespaceChanges: "ignore changes (-b)",
+		WhitespaceEOL:     "ignore at end of line",
+	}[o.Whitespace]
+	context := "default (3)"
+	if o.Context > 0 {
+		context = formatInt(o.Context) + " lines"
+	}
+	algorithm := string(o.Algorithm)
+	if algorithm == "" {
+		algorithm = "default (myers)"
+	}
+	renames := "default (50%)"
+	if o.RenameThreshold > 0 {
+		renames = formatInt(o.RenameThreshold) + "% similar"
+	}
+	return [diffOptCount][2]string{
+		{"Whitespace", whitespace},
+		{"Blank lines", onOff(o.IgnoreBlankLines, "ignore", "show")},
+		{"Context", context},
+		{"Algorithm", algorithm},
+		{"Renames", renames},
+		{"Copies", onOff(o.FindCopies, "detect", "off")},
+		{"Moved lines", onOff(o.ColorMoved, "highlight", "off")},
+	}
+}
+
+func onOff(v bool, on, off string) string {
+	if v {
+		return on
+	}
+	return off
+}
+
+// Render returns the menu as a modal box.
+func (m *DiffOptionsMenu) Render(width int) string {
+	modalWidth := width - 4
+	if modalWidth > 50 {
+		modalWidth = 50
+	}
+
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("Diff Options"))
+	sb.WriteString("\n\n")
+	for i, row := range m.rows() {
+		label := row[0] + strings.Repeat(" ", 13-len(row[0]))
+		if i == m.cursor {
+			sb.WriteString(styles.ListCursor.Render("▸ "))
+			sb.WriteString(styles.ListItemSelected.Render(label + "‹ " + row[1] + " ›"))
+		} else {
+			sb.WriteString("  ")
+			sb.WriteString(styles.Muted.Render(label))
+			sb.WriteString(row[1])
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render("j/k nav · h/l change · r reset · esc close"))
+	return styles.ModalBox.Width(modalWidth).Render(sb.String())
+}
+
+// diffOptionsView returns the view whose options apply to the current diff.
+func (p *Plugin) diffOptionsView() string {
+	if p.diffCommit != "" || (p.viewMode == ViewModeStatus && p.previewCommit != nil && p.cursorOnCommit()) {
+		return state.DiffViewCommit
+	}
+	return state.DiffViewGitStatus
+}
+
+// openDiffOptions opens the options menu for the current diff.
+func (p *Plugin) openDiffOptions() {
+	p.diffOptionsMenu = NewDiffOptionsMenu(p.diffOptionsView())
+}
+
+// updateDiffOptions handles keys while the options menu is open, reloading
+// the diff on screen whenever an option changes.
+func (p *Plugin) updateDiffOptions(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	changed, done := p.diffOptionsMenu.HandleKey(msg.String())
+	if done {
+		p.diffOptionsMenu = nil
+	}
+	if !changed {
+		return p, nil
+	}
+	return p, p.reloadDiffForOptions()
+}
+
+// reloadDiffForOptions reloads whichever diff is showing.
+func (p *Plugin) reloadDiffForOptions() tea.Cmd {
+	if p.viewMode == ViewModeDiff {
+		if p.diffCommit != "" {
+			return p.loadCommitFileDiff(p.diffCommit, p.diffFile, p.diffCommitParent)
+		}
+		return p.loadDiff(p.diffFile, p.diffStaged, p.diffFileStatus)
+	}
+	if p.cursorOnCommit() {
+		// The preview lists files; their diffs load with the new options
+		return nil
+	}
+	p.forceNextDiffReload = true
+	return p.autoLoadDiff()
+}
@@ -0,0 +1,87 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+	"github.com/guyghost/sidecar/internal/state"
+)
+
+func TestDiffOptionsMenu_ChangesReloadAndPersist(t *testing.T) {
+	if err := state.InitWithDir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	p := newDiffStagingPlugin(t, twoHunkDiff, false)
+	if !p.diffHunksAvailable() {
+		t.Fatal("hunks should be stageable with default options")
+	}
+
+	p.Update(runeKey("W"))
+	if p.diffOptionsMenu == nil || p.FocusContext() != keymap.ContextGitDiffOptions {
+		t.Fatal("W should open the diff options menu")
+	}
+	if !strings.Contains(p.View(100, 30), "Diff Options") {
+		t.Error("menu should render over the diff")
+	}
+
+	// Whitespace is the first row
+	_, cmd := p.Update(runeKey("l"))
+	if cmd == nil {
+		t.Error("changing an option should reload the diff")
+	}
+	if got := state.GetDiffOptions(state.DiffViewGitStatus).Whitespace; got != WhitespaceAll {
+		t.Errorf("saved whitespace = %q, want %q", got, WhitespaceAll)
+	}
+	if !state.GetDiffOptions(state.DiffViewCommit).IsDefault() {
+		t.Error("commit diffs keep their own options")
+	}
+	if p.diffHunksAvailable() {
+		t.Error("hunks from a diff ignoring whitespace should not be stageable")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.diffOptionsMenu != nil || p.viewMode != ViewModeDiff {
+		t.Fatal("esc should close the menu and stay in the diff")
+	}
+	if !strings.Contains(p.View(100, 30), "-w") {
+		t.Error("breadcrumb should show the active options")
+	}
+
+	p.Update(runeKey("W"))
+	p.Update(runeKey("r"))
+	if !state.GetDiffOptions(state.DiffViewGitStatus).IsDefault() {
+		t.Error("r should restore the defaults")
+	}
+}
+
+func TestDiffOptionsView_CommitDiff(t *testing.T) {
+	p := newDiffStagingPlugin(t, twoHunkDiff, false)
+	p.diffCommit = "abc1234"
+	if p.diffOptionsView() != state.DiffViewCommit {
+		t.Errorf("commit diffs should use the commit options, got %q", p.diffOptionsView())
+	}
+}
+
+func TestRenderLineDiff_MovedLines(t *testing.T) {
+	parsed := &ParsedDiff{Hunks: []Hunk{{
+		OldStart: 1, OldCount: 1, NewStart: 1, NewCount: 1,
+		Lines: []DiffLine{
+			{Type: LineMovedRemove, OldLineNo: 1, Content: "moved away"},
+			{Type: LineMovedAdd, NewLineNo: 1, Content: "moved here"},
+		},
+	}}}
+	for _, out := range []string{
+		RenderLineDiff(parsed, 80, 0, 10, 0, nil, false),
+		RenderSideBySide(parsed, 80, 0, 10, 0, nil, false),
+	} {
+		if !strings.Contains(out, "moved away") || !strings.Contains(out, "moved here") {
+			t.Errorf("moved lines missing from output:\n%s", out)
+		}
+	}
+	// Both sides of a move pair up in the split view like an edit
+	if pairs := groupLinesForSideBySide(parsed.Hunks[0].Lines); len(pairs) != 1 {
+		t.Errorf("pairs = %d, want 1", len(pairs))
+	}
+}
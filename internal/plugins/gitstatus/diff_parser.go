@@ -12,6 +12,7 @@ type (
 	Hunk         = git.Hunk
 	ParsedDiff   = git.ParsedDiff
 	FileDiffInfo = git.FileDiffInfo
+	PatchError   = git.PatchError
 )
 
 // MultiFileDiff wraps git.MultiFileDiff to add UI rendering methods.
@@ -21,14 +22,19 @@ type MultiFileDiff struct {
 
 // Re-export diff constants.
 const (
-	LineContext = git.LineContext
-	LineAdd     = git.LineAdd
-	LineRemove  = git.LineRemove
+	LineContext     = git.LineContext
+	LineAdd         = git.LineAdd
+	LineRemove      = git.LineRemove
+	LineMovedAdd    = git.LineMovedAdd
+	LineMovedRemove = git.LineMovedRemove
 )
 
 // Re-export diff functions.
 var (
 	ParseUnifiedDiff = git.ParseUnifiedDiff
+	StageHunk        = git.StageHunk
+	UnstageHunk      = git.UnstageHunk
+	DiscardHunk      = git.DiscardHunk
 )
 
 // ParseMultiFileDiff wraps git.ParseMultiFileDiff and returns our local MultiFileDiff.
@@ -28,6 +28,15 @@ var (
 				Background(styles.DiffRemoveBg).
 				Bold(true)
 
+	// Lines of a block git detected as moved rather than changed
+	movedAddStyle = lipgloss.NewStyle().
+			Foreground(styles.Info).
+			Background(styles.DiffAddBg)
+
+	movedRemoveStyle = lipgloss.NewStyle().
+				Foreground(styles.Warning).
+				Background(styles.DiffRemoveBg)
+
 	hunkHeaderStyle = lipgloss.NewStyle().
 			Foreground(styles.Info).
 			Background(styles.BgSecondary).
@@ -40,13 +49,63 @@ var (
 			Foreground(styles.TextPrimary).
 			Background(styles.BgTertiary).
 			Bold(true)
+
+	selectedHunkHeaderStyle = lipgloss.NewStyle().
+				Foreground(styles.TextPrimary).
+				Background(styles.Primary).
+				Bold(true)
+
+	// Hunks matching the active history search
+	matchedHunkHeaderStyle = lipgloss.NewStyle().
+				Foreground(styles.BgPrimary).
+				Background(styles.Warning).
+				Bold(true)
+
+	selectionMarkerStyle = lipgloss.NewStyle().
+				Foreground(styles.Primary).
+				Bold(true)
 )
 
+// DiffSelection marks a hunk, or a line range within it, for staging.
+type DiffSelection struct {
+	Hunk      int // Index into ParsedDiff.Hunks
+	StartLine int // First selected line index within the hunk; -1 selects the whole hunk
+	EndLine   int // Last selected line index (inclusive)
+}
+
+// containsLine reports whether line li of hunk hi is selected.
+func (s *DiffSelection) containsLine(hi, li int) bool {
+	if s == nil || s.Hunk != hi {
+		return false
+	}
+	if s.StartLine < 0 {
+		return true
+	}
+	return li >= s.StartLine && li <= s.EndLine
+}
+
+// gutterSeparator returns the line-number separator, highlighted when selected.
+func gutterSeparator(selected bool) string {
+	if selected {
+		return selectionMarkerStyle.Render("┃")
+	}
+	return "│"
+}
+
 // RenderLineDiff renders a parsed diff in unified line-by-line format with line numbers.
 // horizontalOffset scrolls the content horizontally (0 = no scroll).
 // highlighter is optional - if nil, no syntax highlighting is applied.
 // wrapEnabled wraps long lines instead of truncating them.
 func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffset int, highlighter *SyntaxHighlighter, wrapEnabled bool) string {
+	return RenderLineDiffSelection(diff, nil, width, startLine, maxLines, horizontalOffset, highlighter, wrapEnabled)
+}
+
+// RenderLineDiffSelection renders a unified diff like RenderLineDiff, marking
+// the selected hunk or lines in the gutter. sel may be nil.
+func RenderLineDiffSelection(diff *ParsedDiff, sel *DiffSelection, width, startLine, maxLines, horizontalOffset int, highlighter *SyntaxHighlighter, wrapEnabled bool) string {
+	if diff != nil && diff.LFS != nil {
+		return renderLFSChange(diff.LFS, width)
+	}
 	if diff == nil || diff.Binary {
 		if diff != nil && diff.Binary {
 			return styles.Muted.Render(" Binary file differs")
@@ -73,7 +132,7 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 	contentWidth := width - (lineNoWidth*2 + 4) // Two line numbers + separators
 	isFirstHunk := true
 
-	for _, hunk := range diff.Hunks {
+	for hi, hunk := range diff.Hunks {
 		// Skip until we reach the start line
 		if lineNum < startLine {
 			lineNum++
@@ -86,7 +145,7 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 				// Render hunk header
 				header := truncateLine(fmt.Sprintf("@@ -%d,%d +%d,%d @@%s",
 					hunk.OldStart, hunk.OldCount, hunk.NewStart, hunk.NewCount, hunk.Header), contentWidth)
-				sb.WriteString(hunkHeaderStyle.Render(header))
+				sb.WriteString(hunkHeaderRenderStyle(sel, hi, hunk.Match).Render(header))
 				sb.WriteString("\n")
 				rendered++
 				isFirstHunk = false
@@ -100,7 +159,7 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 			// Render hunk header
 			header := truncateLine(fmt.Sprintf("@@ -%d,%d +%d,%d @@%s",
 				hunk.OldStart, hunk.OldCount, hunk.NewStart, hunk.NewCount, hunk.Header), contentWidth)
-			sb.WriteString(hunkHeaderStyle.Render(header))
+			sb.WriteString(hunkHeaderRenderStyle(sel, hi, hunk.Match).Render(header))
 			sb.WriteString("\n")
 			rendered++
 			isFirstHunk = false
@@ -110,7 +169,7 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 			break
 		}
 
-		for _, line := range hunk.Lines {
+		for li, line := range hunk.Lines {
 			lineNum++
 			if lineNum <= startLine {
 				continue
@@ -130,9 +189,10 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 				newNo = fmt.Sprintf("%d", line.NewLineNo)
 			}
 
-			lineNos := fmt.Sprintf("%s %s │ ",
+			lineNos := fmt.Sprintf("%s %s %s ",
 				lineNoStyle.Render(oldNo),
-				lineNoStyle.Render(newNo))
+				lineNoStyle.Render(newNo),
+				gutterSeparator(sel.containsLine(hi, li)))
 
 			// Render content with appropriate style
 			var content string
@@ -177,6 +237,15 @@ func RenderLineDiff(diff *ParsedDiff, width, startLine, maxLines, horizontalOffs
 // highlighter is optional - if nil, no syntax highlighting is applied.
 // wrapEnabled wraps long lines instead of truncating them.
 func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOffset int, highlighter *SyntaxHighlighter, wrapEnabled bool) string {
+	return RenderSideBySideSelection(diff, nil, width, startLine, maxLines, horizontalOffset, highlighter, wrapEnabled)
+}
+
+// RenderSideBySideSelection renders a side-by-side diff like RenderSideBySide,
+// marking rows that contain a selected line. sel may be nil.
+func RenderSideBySideSelection(diff *ParsedDiff, sel *DiffSelection, width, startLine, maxLines, horizontalOffset int, highlighter *SyntaxHighlighter, wrapEnabled bool) string {
+	if diff != nil && diff.LFS != nil {
+		return renderLFSChange(diff.LFS, width)
+	}
 	if diff == nil || diff.Binary {
 		if diff != nil && diff.Binary {
 			return styles.Muted.Render(" Binary file differs")
@@ -199,7 +268,8 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 		Align(lipgloss.Right)
 
 	isFirstHunk := true
-	for _, hunk := range diff.Hunks {
+	for hi := range diff.Hunks {
+		hunk := &diff.Hunks[hi]
 		if rendered >= maxLines {
 			break
 		}
@@ -213,7 +283,7 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 			}
 			header := fmt.Sprintf("@@ -%d,%d +%d,%d @@",
 				hunk.OldStart, hunk.OldCount, hunk.NewStart, hunk.NewCount)
-			sb.WriteString(hunkHeaderStyle.Render(padRight(header, width-1)))
+			sb.WriteString(hunkHeaderRenderStyle(sel, hi, hunk.Match).Render(padRight(header, width-1)))
 			sb.WriteString("\n")
 			rendered++
 			isFirstHunk = false
@@ -222,6 +292,7 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 
 		// Group lines into pairs (remove/add or context)
 		pairs := groupLinesForSideBySide(hunk.Lines)
+		selected := selectedLineSet(hunk, sel, hi)
 
 		for _, pair := range pairs {
 			if rendered >= maxLines {
@@ -231,6 +302,7 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 				lineNum++
 				continue
 			}
+			gutter := gutterSeparator(selected[pair.left] || selected[pair.right])
 
 			// Left side (old)
 			leftLineNo := " "
@@ -298,13 +370,13 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 					lLine = padToWidth(lLine, contentWidth)
 					rLine = padToWidth(rLine, contentWidth)
 					if vi == 0 {
-						sb.WriteString(fmt.Sprintf("%s │%s", lineNoStyle.Render(leftLineNo), lLine))
+						sb.WriteString(fmt.Sprintf("%s %s%s", lineNoStyle.Render(leftLineNo), gutter, lLine))
 						sb.WriteString(sep)
-						sb.WriteString(fmt.Sprintf("%s │%s", lineNoStyle.Render(rightLineNo), rLine))
+						sb.WriteString(fmt.Sprintf("%s %s%s", lineNoStyle.Render(rightLineNo), gutter, rLine))
 					} else {
-						sb.WriteString(fmt.Sprintf("%s │%s", lineNoPad, lLine))
+						sb.WriteString(fmt.Sprintf("%s %s%s", lineNoPad, gutter, lLine))
 						sb.WriteString(sep)
-						sb.WriteString(fmt.Sprintf("%s │%s", lineNoPad, rLine))
+						sb.WriteString(fmt.Sprintf("%s %s%s", lineNoPad, gutter, rLine))
 					}
 					sb.WriteString("\n")
 					rendered++
@@ -313,12 +385,14 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 				leftRendered = padToWidth(leftRendered, contentWidth)
 				rightRendered = padToWidth(rightRendered, contentWidth)
 
-				leftPanel := fmt.Sprintf("%s │%s",
+				leftPanel := fmt.Sprintf("%s %s%s",
 					lineNoStyle.Render(leftLineNo),
+					gutter,
 					leftRendered)
 
-				rightPanel := fmt.Sprintf("%s │%s",
+				rightPanel := fmt.Sprintf("%s %s%s",
 					lineNoStyle.Render(rightLineNo),
+					gutter,
 					rightRendered)
 
 				sb.WriteString(leftPanel)
@@ -334,6 +408,32 @@ func RenderSideBySide(diff *ParsedDiff, width, startLine, maxLines, horizontalOf
 	return sb.String()
 }
 
+// hunkHeaderRenderStyle returns the header style for hunk hi.
+func hunkHeaderRenderStyle(sel *DiffSelection, hi int, match bool) lipgloss.Style {
+	if sel != nil && sel.Hunk == hi {
+		return selectedHunkHeaderStyle
+	}
+	if match {
+		return matchedHunkHeaderStyle
+	}
+	return hunkHeaderStyle
+}
+
+// selectedLineSet returns the selected lines of hunk hi keyed by pointer,
+// matching the pointers produced by groupLinesForSideBySide.
+func selectedLineSet(hunk *Hunk, sel *DiffSelection, hi int) map[*DiffLine]bool {
+	if sel == nil || sel.Hunk != hi {
+		return nil
+	}
+	set := make(map[*DiffLine]bool)
+	for li := range hunk.Lines {
+		if sel.containsLine(hi, li) {
+			set[&hunk.Lines[li]] = true
+		}
+	}
+	return set
+}
+
 // linePair represents a pair of lines for side-by-side view.
 type linePair struct {
 	left  *DiffLine
@@ -354,16 +454,16 @@ func groupLinesForSideBySide(lines []DiffLine) []linePair {
 			pairs = append(pairs, linePair{left: line, right: line})
 			i++
 
-		case LineRemove:
+		case LineRemove, LineMovedRemove:
 			// Check if followed by add lines
 			removeStart := i
-			for i < len(lines) && lines[i].Type == LineRemove {
+			for i < len(lines) && lines[i].Type.IsRemove() {
 				i++
 			}
 			removeEnd := i
 
 			addStart := i
-			for i < len(lines) && lines[i].Type == LineAdd {
+			for i < len(lines) && lines[i].Type.IsAdd() {
 				i++
 			}
 			addEnd := i
@@ -387,7 +487,7 @@ func groupLinesForSideBySide(lines []DiffLine) []linePair {
 				pairs = append(pairs, linePair{left: left, right: right})
 			}
 
-		case LineAdd:
+		case LineAdd, LineMovedAdd:
 			// Orphan add (shouldn't happen if grouping is correct)
 			pairs = append(pairs, linePair{left: nil, right: line})
 			i++
@@ -418,6 +518,10 @@ func renderDiffContent(line DiffLine, maxWidth int, highlighter *SyntaxHighlight
 		baseStyle = styles.DiffAdd
 	case LineRemove:
 		baseStyle = styles.DiffRemove
+	case LineMovedAdd:
+		baseStyle = movedAddStyle
+	case LineMovedRemove:
+		baseStyle = movedRemoveStyle
 	default:
 		baseStyle = styles.DiffContext
 	}
@@ -490,6 +594,10 @@ func renderSideBySideContent(content string, lineType LineType, maxWidth int, hi
 		baseStyle = styles.DiffAdd
 	case LineRemove:
 		baseStyle = styles.DiffRemove
+	case LineMovedAdd:
+		baseStyle = movedAddStyle
+	case LineMovedRemove:
+		baseStyle = movedRemoveStyle
 	default:
 		baseStyle = styles.DiffContext
 	}
@@ -527,6 +635,10 @@ func renderSyntaxHighlighted(content string, lineType LineType, highlighter *Syn
 			return styles.DiffAdd.Background(styles.DiffAddBg)
 		case LineRemove:
 			return styles.DiffRemove.Background(styles.DiffRemoveBg)
+		case LineMovedAdd:
+			return movedAddStyle
+		case LineMovedRemove:
+			return movedRemoveStyle
 		default:
 			return styles.DiffContext
 		}
@@ -561,6 +673,11 @@ func blendSyntaxWithDiff(syntaxStyle lipgloss.Style, lineType LineType) lipgloss
 	case LineRemove:
 		// Keep syntax foreground, add red background for diff indication
 		return syntaxStyle.Background(styles.DiffRemoveBg)
+	case LineMovedAdd:
+		// Moved blocks keep their own color so they stand out from edits
+		return movedAddStyle
+	case LineMovedRemove:
+		return movedRemoveStyle
 	default:
 		// Context lines: use syntax color if available, otherwise muted
 		fg := syntaxStyle.GetForeground()
@@ -624,7 +741,7 @@ type SideBySideClipInfo struct {
 // GetSideBySideClipInfo calculates clipping info for a side-by-side diff.
 // contentWidth is the width available for each side's content (after line numbers).
 func GetSideBySideClipInfo(diff *ParsedDiff, contentWidth, horizontalOffset int) SideBySideClipInfo {
-	if diff == nil || diff.Binary {
+	if diff == nil || diff.Binary || diff.LFS != nil {
 		return SideBySideClipInfo{}
 	}
 
@@ -764,6 +881,18 @@ func (mfd *MultiFileDiff) TotalLines() int {
 	return total
 }
 
+// FileStartLine returns the rendered line where file i's header starts.
+func (mfd *MultiFileDiff) FileStartLine(i int) int {
+	if mfd == nil {
+		return 0
+	}
+	line := 0
+	for j := 0; j < i && j < len(mfd.Files); j++ {
+		line += 1 + mfd.Files[j].Diff.TotalLines() + 1 // Header, diff, blank line
+	}
+	return line
+}
+
 // FileAtLine returns the file index at the given line position, or -1 if none.
 func (mfd *MultiFileDiff) FileAtLine(line int) int {
 	if mfd == nil {
@@ -0,0 +1,282 @@
+package gitstatus
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/state"
+)
+
+// Hunk operations available from the diff view.
+const (
+	hunkOpStage   = "stage"
+	hunkOpUnstage = "unstage"
+	hunkOpDiscard = "discard"
+	hunkOpStash   = "stash"
+)
+
+// hunkOpVerbs maps an operation to its present and past tense labels.
+var hunkOpVerbs = map[string][2]string{
+	hunkOpStage:   {"Stage", "Staged"},
+	hunkOpUnstage: {"Unstage", "Unstaged"},
+	hunkOpDiscard: {"Discard", "Discarded"},
+	hunkOpStash:   {"Stash", "Stashed"},
+}
+
+// HunkOpDoneMsg is sent when a hunk or line-range operation completes.
+type HunkOpDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Op    string // hunkOpStage, hunkOpUnstage, hunkOpDiscard or hunkOpStash
+	Lines bool   // True for a line-range operation
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m HunkOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openFileDiff opens the full-screen diff view for a status entry.
+func (p *Plugin) openFileDiff(entry *FileEntry) tea.Cmd {
+	p.diffReturnMode = p.viewMode
+	p.viewMode = ViewModeDiff
+	p.diffFile = entry.Path
+	p.diffCommit = ""
+	p.diffCommitSubject = ""
+	p.diffCommitShortHash = ""
+	p.diffScroll = 0
+	p.diffLoaded = false
+	p.diffStaged = entry.Staged
+	p.diffFileStatus = entry.Status
+	p.diffHunksEnabled = !entry.IsFolder && entry.Status != StatusUntracked && entry.Status != StatusUnmerged
+	p.diffHunkCursor = 0
+	p.diffLineMode = false
+	if entry.IsFolder {
+		return p.loadFullFolderDiff(entry)
+	}
+	return p.loadDiff(entry.Path, entry.Staged, entry.Status)
+}
+
+// diffHunksAvailable reports whether the current diff supports hunk operations.
+func (p *Plugin) diffHunksAvailable() bool {
+	return p.diffHunksEnabled && p.diffCommit == "" && p.parsedDiff != nil &&
+		!p.parsedDiff.Binary && p.parsedDiff.LFS == nil && len(p.parsedDiff.Hunks) > 0 &&
+		state.GetDiffOptions(state.DiffViewGitStatus).Patchable()
+}
+
+// diffSelection returns the hunk or line selection to highlight, or nil.
+func (p *Plugin) diffSelection() *DiffSelection {
+	if !p.diffHunksAvailable() || p.diffHunkCursor >= len(p.parsedDiff.Hunks) {
+		return nil
+	}
+	if !p.diffLineMode {
+		return &DiffSelection{Hunk: p.diffHunkCursor, StartLine: -1, EndLine: -1}
+	}
+	start, end := p.diffLineAnchor, p.diffLineCursor
+	if start > end {
+		start, end = end, start
+	}
+	return &DiffSelection{Hunk: p.diffHunkCursor, StartLine: start, EndLine: end}
+}
+
+// clampDiffHunkCursor keeps the hunk cursor valid after the diff reloads.
+func (p *Plugin) clampDiffHunkCursor() {
+	if p.parsedDiff == nil || len(p.parsedDiff.Hunks) == 0 {
+		p.diffHunkCursor = 0
+		p.diffLineMode = false
+		return
+	}
+	if p.diffHunkCursor >= len(p.parsedDiff.Hunks) {
+		p.diffHunkCursor = len(p.parsedDiff.Hunks) - 1
+	}
+	if p.diffLineMode {
+		n := len(p.parsedDiff.Hunks[p.diffHunkCursor].Lines)
+		if p.diffLineCursor >= n || p.diffLineAnchor >= n {
+			p.diffLineMode = false
+		}
+	}
+}
+
+// diffRowOffset returns the scroll position of a line within a hunk as
+// counted by the renderers: one row per hunk header plus one per unified
+// line or side-by-side pair. lineIdx < 0 returns the hunk header's row.
+func diffRowOffset(diff *ParsedDiff, mode DiffViewMode, hunkIdx, lineIdx int) int {
+	row := 0
+	for hi := range diff.Hunks {
+		hunk := &diff.Hunks[hi]
+		if hi == hunkIdx {
+			if lineIdx < 0 {
+				return row
+			}
+			if mode != DiffViewSideBySide {
+				return row + 1 + lineIdx
+			}
+			target := &hunk.Lines[lineIdx]
+			for pi, pair := range groupLinesForSideBySide(hunk.Lines) {
+				if pair.left == target || pair.right == target {
+					return row + 1 + pi
+				}
+			}
+			return row
+		}
+		if mode == DiffViewSideBySide {
+			row += 1 + len(groupLinesForSideBySide(hunk.Lines))
+		} else {
+			row += 1 + len(hunk.Lines)
+		}
+	}
+	return row
+}
+
+// diffVisibleRows approximates the rows available for diff content.
+func (p *Plugin) diffVisibleRows() int {
+	rows := p.height - 4 // border + breadcrumb + separator
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// scrollToDiffHunk scrolls so the selected hunk's header is at the top.
+func (p *Plugin) scrollToDiffHunk() {
+	p.diffScroll = diffRowOffset(p.parsedDiff, p.diffViewMode, p.diffHunkCursor, -1)
+}
+
+// ensureDiffLineVisible scrolls to keep the line cursor on screen.
+func (p *Plugin) ensureDiffLineVisible() {
+	row := diffRowOffset(p.parsedDiff, p.diffViewMode, p.diffHunkCursor, p.diffLineCursor)
+	rows := p.diffVisibleRows()
+	if row < p.diffScroll {
+		p.diffScroll = row
+	} else if row >= p.diffScroll+rows {
+		p.diffScroll = row - rows + 1
+	}
+}
+
+// moveDiffHunk moves the hunk cursor by delta and scrolls to it.
+func (p *Plugin) moveDiffHunk(delta int) {
+	if !p.diffHunksAvailable() {
+		return
+	}
+	next := p.diffHunkCursor + delta
+	if next < 0 || next >= len(p.parsedDiff.Hunks) {
+		return
+	}
+	p.diffHunkCursor = next
+	p.diffLineMode = false
+	p.scrollToDiffHunk()
+}
+
+// toggleDiffLineMode enters or leaves line selection within the current hunk.
+// Entering places the cursor on the hunk's first changed line.
+func (p *Plugin) toggleDiffLineMode() {
+	if p.diffLineMode {
+		p.diffLineMode = false
+		return
+	}
+	if !p.diffHunksAvailable() {
+		return
+	}
+	lines := p.parsedDiff.Hunks[p.diffHunkCursor].Lines
+	first := 0
+	for i, l := range lines {
+		if l.Type != LineContext {
+			first = i
+			break
+		}
+	}
+	p.diffLineMode = true
+	p.diffLineAnchor = first
+	p.diffLineCursor = first
+	p.ensureDiffLineVisible()
+}
+
+// moveDiffLineCursor extends the line selection by delta within the hunk.
+func (p *Plugin) moveDiffLineCursor(delta int) {
+	n := len(p.parsedDiff.Hunks[p.diffHunkCursor].Lines)
+	next := p.diffLineCursor + delta
+	if next < 0 || next >= n {
+		return
+	}
+	p.diffLineCursor = next
+	p.ensureDiffLineVisible()
+}
+
+// doHunkOp applies op to the selected hunk or lines asynchronously.
+func (p *Plugin) doHunkOp(op string) tea.Cmd {
+	sel := p.diffSelection()
+	if sel == nil {
+		return nil
+	}
+	switch {
+	case op == hunkOpStage && p.diffStaged:
+		return hunkOpToast("Already staged", false)
+	case op == hunkOpUnstage && !p.diffStaged:
+		return hunkOpToast("Not staged", false)
+	case op == hunkOpDiscard && p.diffStaged:
+		return hunkOpToast("Unstage before discarding", false)
+	case op == hunkOpStash && p.diffStaged:
+		return hunkOpToast("Unstage before stashing", false)
+	}
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	diff := p.parsedDiff
+	path := p.diffFile
+	return func() tea.Msg {
+		var point *UndoPoint
+		var undoErr error
+		if op == hunkOpDiscard {
+			// Save the whole file so the global undo can bring the hunk back
+			point, undoErr = CaptureDiscardUndo(workDir, path)
+		}
+		var err error
+		switch op {
+		case hunkOpStage:
+			err = StageHunk(workDir, diff, sel.Hunk, sel.StartLine, sel.EndLine)
+		case hunkOpUnstage:
+			err = UnstageHunk(workDir, diff, sel.Hunk, sel.StartLine, sel.EndLine)
+		case hunkOpDiscard:
+			err = DiscardHunk(workDir, diff, sel.Hunk, sel.StartLine, sel.EndLine)
+		case hunkOpStash:
+			err = StashHunk(workDir, diff, sel.Hunk, sel.StartLine, sel.EndLine)
+		}
+		result := HunkOpDoneMsg{Epoch: epoch, Op: op, Lines: sel.StartLine >= 0, Err: err}
+		if err != nil {
+			return result
+		}
+		return recordUndo(workDir, point, undoErr, result)
+	}
+}
+
+// handleHunkOpDone reports the result and reloads the diff and status.
+func (p *Plugin) handleHunkOpDone(msg HunkOpDoneMsg) tea.Cmd {
+	verbs := hunkOpVerbs[msg.Op]
+	if msg.Err != nil {
+		return hunkOpToast(verbs[0]+" failed: "+msg.Err.Error(), true)
+	}
+	p.diffLineMode = false
+	what := " hunk"
+	if msg.Lines {
+		what = " lines"
+	}
+	toast := hunkOpToast(verbs[1]+what, false)
+	if p.viewMode != ViewModeDiff || p.diffFile == "" {
+		return tea.Batch(toast, p.refresh())
+	}
+	return tea.Batch(
+		toast,
+		p.loadDiff(p.diffFile, p.diffStaged, p.diffFileStatus),
+		p.refresh(),
+	)
+}
+
+// hunkOpToast returns a toast command for hunk operations.
+func hunkOpToast(message string, isError bool) tea.Cmd {
+	duration := 2 * time.Second
+	if isError {
+		duration = 3 * time.Second
+	}
+	return func() tea.Msg {
+		return app.ToastMsg{Message: message, Duration: duration, IsError: isError}
+	}
+}
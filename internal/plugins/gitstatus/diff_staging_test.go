@@ -0,0 +1,173 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/mouse"
+	"github.com/guyghost/sidecar/internal/plugin"
+)
+
+const twoHunkDiff = `diff --git a/f.go b/f.go
+--- a/f.go
++++ b/f.go
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+@@ -10,3 +10,4 @@
+ x
++y
++z
+ w`
+
+func newDiffStagingPlugin(t *testing.T, raw string, staged bool) *Plugin {
+	t.Helper()
+	parsed, err := ParseUnifiedDiff(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Plugin{
+		ctx:              &plugin.Context{},
+		hasRepo:          true,
+		viewMode:         ViewModeDiff,
+		width:            100,
+		height:           30,
+		mouseHandler:     mouse.NewHandler(),
+		diffFile:         "f.go",
+		diffContent:      raw,
+		diffRaw:          raw,
+		diffLoaded:       true,
+		parsedDiff:       parsed,
+		diffStaged:       staged,
+		diffFileStatus:   StatusModified,
+		diffHunksEnabled: true,
+	}
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDiffHunkNavigation(t *testing.T) {
+	p := newDiffStagingPlugin(t, twoHunkDiff, false)
+
+	p.Update(runeKey("n"))
+	if p.diffHunkCursor != 1 {
+		t.Fatalf("diffHunkCursor = %d, want 1", p.diffHunkCursor)
+	}
+	// Second hunk header follows first header + 4 lines
+	if p.diffScroll != 5 {
+		t.Errorf("diffScroll = %d, want 5", p.diffScroll)
+	}
+	p.Update(runeKey("n"))
+	if p.diffHunkCursor != 1 {
+		t.Errorf("cursor moved past last hunk: %d", p.diffHunkCursor)
+	}
+	p.Update(runeKey("N"))
+	if p.diffHunkCursor != 0 || p.diffScroll != 0 {
+		t.Errorf("after N: cursor=%d scroll=%d", p.diffHunkCursor, p.diffScroll)
+	}
+
+	// Side-by-side pairs -b/+B into one row
+	p.diffViewMode = DiffViewSideBySide
+	p.Update(runeKey("n"))
+	if p.diffScroll != 4 {
+		t.Errorf("side-by-side diffScroll = %d, want 4", p.diffScroll)
+	}
+}
+
+func TestDiffLineSelection(t *testing.T) {
+	p := newDiffStagingPlugin(t, twoHunkDiff, false)
+	p.Update(runeKey("n"))
+	p.Update(runeKey("V"))
+	if !p.diffLineMode {
+		t.Fatal("expected line mode")
+	}
+	sel := p.diffSelection()
+	if sel == nil || sel.Hunk != 1 || sel.StartLine != 1 || sel.EndLine != 1 {
+		t.Fatalf("unexpected selection %+v", sel)
+	}
+
+	scroll := p.diffScroll
+	p.Update(runeKey("j"))
+	sel = p.diffSelection()
+	if sel.StartLine != 1 || sel.EndLine != 2 {
+		t.Errorf("expected lines 1-2 selected, got %+v", sel)
+	}
+	if p.diffScroll != scroll {
+		t.Errorf("j in line mode should move the cursor, not scroll (%d -> %d)", scroll, p.diffScroll)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.diffLineMode || p.viewMode != ViewModeDiff {
+		t.Error("esc should leave line mode without closing the diff")
+	}
+}
+
+func TestDiffHunkOpGuards(t *testing.T) {
+	p := newDiffStagingPlugin(t, twoHunkDiff, true)
+	if cmd := p.doHunkOp(hunkOpStage); cmd == nil {
+		t.Error("expected toast when staging an already staged diff")
+	}
+
+	// Commit diffs don't offer hunk selection
+	p.diffCommit = "abc123"
+	if p.diffSelection() != nil {
+		t.Error("commit diff should have no selection")
+	}
+	if cmd := p.doHunkOp(hunkOpUnstage); cmd != nil {
+		t.Error("expected no-op for commit diff")
+	}
+}
+
+func TestDiffDiscardHunkOpensConfirm(t *testing.T) {
+	p := newDiffStagingPlugin(t, twoHunkDiff, false)
+	p.Update(runeKey("D"))
+	if p.viewMode != ViewModeConfirmDiscard || !p.discardHunk {
+		t.Fatalf("expected hunk discard confirm, viewMode=%v", p.viewMode)
+	}
+	p.cancelDiscard()
+	if p.viewMode != ViewModeDiff || p.discardHunk {
+		t.Error("cancel should return to diff view")
+	}
+}
+
+func TestRenderLineDiffSelection_MarksSelectedLines(t *testing.T) {
+	parsed, _ := ParseUnifiedDiff(twoHunkDiff)
+	sel := &DiffSelection{Hunk: 1, StartLine: 1, EndLine: 1}
+	out := RenderLineDiffSelection(parsed, sel, 80, 0, 20, 0, nil, false)
+
+	var marked []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "┃") {
+			marked = append(marked, line)
+		}
+	}
+	if len(marked) != 1 || !strings.Contains(marked[0], "y") {
+		t.Errorf("expected only the +y line marked, got %q", marked)
+	}
+
+	if plain := RenderLineDiff(parsed, 80, 0, 20, 0, nil, false); strings.Contains(plain, "┃") {
+		t.Error("RenderLineDiff should not mark lines")
+	}
+}
+
+func TestRenderSideBySideSelection_MarksHunk(t *testing.T) {
+	parsed, _ := ParseUnifiedDiff(twoHunkDiff)
+	sel := &DiffSelection{Hunk: 0, StartLine: -1, EndLine: -1}
+	out := RenderSideBySideSelection(parsed, sel, 100, 0, 20, 0, nil, false)
+
+	marked := 0
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "┃") {
+			marked++
+		}
+	}
+	// First hunk renders as three rows: a, b|B, c
+	if marked != 3 {
+		t.Errorf("expected 3 marked rows, got %d", marked)
+	}
+}
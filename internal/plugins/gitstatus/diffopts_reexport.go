@@ -0,0 +1,24 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export diff option types from internal/git.
+type (
+	DiffOptions    = git.DiffOptions
+	WhitespaceMode = git.WhitespaceMode
+	DiffAlgorithm  = git.DiffAlgorithm
+)
+
+// Re-export diff option values.
+const (
+	WhitespaceShow     = git.WhitespaceShow
+	WhitespaceAll      = git.WhitespaceAll
+	WhitespaceChanges  = git.WhitespaceChanges
+	WhitespaceEOL      = git.WhitespaceEOL
+	AlgorithmDefault   = git.AlgorithmDefault
+	AlgorithmPatience  = git.AlgorithmPatience
+	AlgorithmHistogram = git.AlgorithmHistogram
+)
+
+// Re-export diff option functions.
+var StripDiffColor = git.StripDiffColor
@@ -1,6 +1,7 @@
 package gitstatus
 
 import (
+	"strings"
 	"time"
 
 	"github.com/atotto/clipboard"
@@ -19,11 +20,28 @@ func (p *Plugin) showErrorModal(title string, err error) {
 		detail = e.Output
 	case *RemoteError:
 		detail = e.Output
+	case *RebaseError:
+		detail = e.Output
+	case *CherryPickError:
+		detail = e.Output
+	case *RevertError:
+		detail = e.Output
+	case *ResetError:
+		detail = e.Output
+	case *TagError:
+		detail = e.Output
+	case *PatchError:
+		detail = e.Output
+	case *BisectError:
+		detail = e.Output
+	case *SigningError:
+		detail = strings.TrimSpace(e.Output) + "\n\n" + e.Hint()
 	default:
 		detail = err.Error()
 	}
 	p.errorTitle = title
 	p.errorDetail = detail
+	p.errorReturnMode = ViewModeStatus
 	p.clearErrorModal()
 	p.viewMode = ViewModeError
 }
@@ -119,7 +137,8 @@ func (p *Plugin) handleErrorModalMouse(m tea.MouseMsg) (plugin.Plugin, tea.Cmd)
 
 // dismissErrorModal closes the error modal and clears error state.
 func (p *Plugin) dismissErrorModal() (plugin.Plugin, tea.Cmd) {
-	p.viewMode = ViewModeStatus
+	p.viewMode = p.errorReturnMode
+	p.errorReturnMode = ViewModeStatus
 	p.errorTitle = ""
 	p.errorDetail = ""
 	p.errorModal = nil
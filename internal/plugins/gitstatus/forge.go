@@ -0,0 +1,66 @@
+package gitstatus
+
+import (
+	"os/exec"
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/forge"
+	"github.com/guyghost/sidecar/internal/msg"
+)
+
+// forgeHosts returns the custom forge hostnames from config.
+func (p *Plugin) forgeHosts() map[string]string {
+	if p.ctx == nil || p.ctx.Config == nil {
+		return nil
+	}
+	return p.ctx.Config.Forges.Hosts
+}
+
+// openInBrowser opens the URL in the default browser.
+func openInBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("cmd", "/c", "start", url)
+		case "linux":
+			cmd = exec.Command("xdg-open", url)
+		default:
+			return app.ToastMsg{Message: "Unsupported platform", Duration: 3 * time.Second, IsError: true}
+		}
+		if err := cmd.Start(); err != nil {
+			return app.ToastMsg{Message: "Failed to open browser: " + err.Error(), Duration: 3 * time.Second, IsError: true}
+		}
+		return nil
+	}
+}
+
+// openCommitInBrowser opens the current commit on the forge hosting the
+// origin remote.
+func (p *Plugin) openCommitInBrowser() tea.Cmd {
+	commit := p.getCurrentCommit()
+	if commit == nil {
+		return nil
+	}
+
+	remoteURL := forge.RemoteURL(p.repoRoot)
+	if remoteURL == "" {
+		return msg.ShowToast("No remote configured", 2*time.Second)
+	}
+
+	repo, ok := forge.ParseRemote(remoteURL, p.forgeHosts())
+	if !ok {
+		return msg.ShowToast("Remote has no web page", 2*time.Second)
+	}
+
+	f := forge.New(repo)
+	return tea.Batch(
+		openInBrowser(f.CommitURL(commit.Hash)),
+		msg.ShowToast("Opening in "+f.Name()+"...", 2*time.Second),
+	)
+}
@@ -1,72 +1,65 @@
 package gitstatus
 
 import (
-	"strings"
-
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// doCommit executes the git commit asynchronously.
+// doCommit executes the git commit asynchronously, streaming hook output
+// into the hook panel when commit hooks are installed.
 func (p *Plugin) doCommit(message string) tea.Cmd {
-	workDir := p.repoRoot
-	return func() tea.Msg {
-		hash, err := ExecuteCommit(workDir, message)
-		if err != nil {
-			return CommitErrorMsg{Err: err}
-		}
-		// Extract first line as subject
-		subject := strings.Split(message, "\n")[0]
-		return CommitSuccessMsg{Hash: hash, Subject: subject}
-	}
+	return p.commitWithHooks(message, CommitOptions{Sign: p.commitSign})
 }
 
 // doAmend executes git commit --amend asynchronously.
 func (p *Plugin) doAmend(message string) tea.Cmd {
-	workDir := p.repoRoot
-	return func() tea.Msg {
-		hash, err := ExecuteAmend(workDir, message)
-		if err != nil {
-			return CommitErrorMsg{Err: err}
-		}
-		subject := strings.Split(message, "\n")[0]
-		return CommitSuccessMsg{Hash: hash, Subject: subject}
-	}
+	return p.commitWithHooks(message, CommitOptions{Amend: true, Sign: p.commitSign})
 }
 
 // doPush executes a git push asynchronously.
 func (p *Plugin) doPush(force bool) tea.Cmd {
-	workDir := p.repoRoot
-	return func() tea.Msg {
-		output, err := ExecutePush(workDir, force)
-		if err != nil {
-			return PushErrorMsg{Err: err}
+	var capture func(string) (*UndoPoint, error)
+	if force {
+		capture = func(workDir string) (*UndoPoint, error) {
+			return CaptureForcePushUndo(workDir, GetRemoteName(workDir))
 		}
-		return PushSuccessMsg{Output: output}
 	}
+	// For new branches, set upstream automatically
+	return p.pushWithHooks(PushOptions{Force: force, SetUpstream: true}, capture)
 }
 
 // doPushForce executes a force push with lease.
 func (p *Plugin) doPushForce() tea.Cmd {
-	workDir := p.repoRoot
-	return func() tea.Msg {
-		output, err := ExecutePushForce(workDir)
-		if err != nil {
-			return PushErrorMsg{Err: err}
-		}
-		return PushSuccessMsg{Output: output}
-	}
+	return p.pushWithHooks(PushOptions{Force: true}, func(workDir string) (*UndoPoint, error) {
+		return CaptureForcePushUndo(workDir, GetRemoteName(workDir))
+	})
 }
 
 // doPushSetUpstream executes a push with upstream tracking.
 func (p *Plugin) doPushSetUpstream() tea.Cmd {
-	workDir := p.repoRoot
-	return func() tea.Msg {
-		output, err := ExecutePushSetUpstream(workDir)
-		if err != nil {
-			return PushErrorMsg{Err: err}
+	if p.pushStatus == nil || p.pushStatus.CurrentBranch == "" {
+		// Let git report the missing or detached branch
+		workDir := p.repoRoot
+		return func() tea.Msg {
+			output, err := ExecutePushSetUpstream(workDir)
+			if err != nil {
+				return PushErrorMsg{Err: err}
+			}
+			return PushSuccessMsg{Output: output}
 		}
-		return PushSuccessMsg{Output: output}
 	}
+	return p.pushWithHooks(PushOptions{Branch: p.pushStatus.CurrentBranch, SetUpstream: true}, nil)
+}
+
+// doPushTo pushes HEAD to branch on remote, optionally forcing with lease
+// or setting it as the upstream.
+func (p *Plugin) doPushTo(remote, branch string, force, setUpstream bool) tea.Cmd {
+	var capture func(string) (*UndoPoint, error)
+	if force {
+		capture = func(workDir string) (*UndoPoint, error) {
+			return CaptureForcePushToUndo(workDir, remote, branch)
+		}
+	}
+	return p.pushWithHooks(PushOptions{Remote: remote, Branch: branch, Force: force, SetUpstream: setUpstream}, capture)
 }
 
 // canPush returns true if there are commits that can be pushed.
@@ -161,15 +154,20 @@ func (p *Plugin) doPullAutostash() tea.Cmd {
 	}
 }
 
-// doAbortPull aborts the current merge or rebase.
+// doAbortPull aborts the current merge, rebase, cherry-pick or revert.
 func (p *Plugin) doAbortPull() tea.Cmd {
-	workDir := p.repoRoot
+	workDir := p.conflictDir()
 	conflictType := p.pullConflictType
 	return func() tea.Msg {
 		var err error
-		if conflictType == "rebase" {
+		switch conflictType {
+		case "rebase":
 			err = AbortRebase(workDir)
-		} else {
+		case commitOpCherryPick:
+			err = AbortCherryPick(workDir)
+		case commitOpRevert:
+			err = AbortRevert(workDir)
+		default:
 			err = AbortMerge(workDir)
 		}
 		if err != nil {
@@ -191,6 +189,7 @@ func (p *Plugin) canPull() bool {
 func (p *Plugin) doDiscard(entry *FileEntry) tea.Cmd {
 	workDir := p.repoRoot
 	return func() tea.Msg {
+		point, undoErr := CaptureDiscardUndo(workDir, entry.Path)
 		var err error
 		if entry.Status == StatusUntracked {
 			// Remove untracked file
@@ -205,6 +204,6 @@ func (p *Plugin) doDiscard(entry *FileEntry) tea.Cmd {
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
-		return RefreshDoneMsg{}
+		return recordUndo(workDir, point, undoErr, RefreshDoneMsg{})
 	}
 }
@@ -1,5 +1,7 @@
 package gitstatus
 
+import "strings"
+
 // GraphColumn tracks the state of one branch line in the commit graph.
 type GraphColumn struct {
 	CommitHash string // Hash of commit this column is tracking toward
@@ -12,9 +14,14 @@ type GraphLine struct {
 	Width int    // Total visual width of this line
 }
 
-// GraphState maintains state while computing graph for commit list.
+// GraphState maintains state while computing graph for commit list. It can
+// be kept between pages of history and resumed with Extend.
 type GraphState struct {
 	columns []GraphColumn // Active branch columns
+
+	rows      int    // Commits processed so far
+	firstHash string // First commit processed
+	lastHash  string // Most recent commit processed
 }
 
 // NewGraphState creates a new graph computation state.
@@ -51,6 +58,13 @@ func (g *GraphState) findOrCreateColumn(hash string) int {
 
 // updateColumns updates state after processing a commit.
 func (g *GraphState) updateColumns(commit *Commit, col int) {
+	// Other lanes that were heading to this commit join it here
+	for i := range g.columns {
+		if i != col && g.columns[i].Active && g.columns[i].CommitHash == commit.Hash {
+			g.columns[i].Active = false
+		}
+	}
+
 	if len(commit.ParentHashes) == 0 {
 		// Root commit - deactivate column
 		if col >= 0 && col < len(g.columns) {
@@ -144,12 +158,39 @@ func ComputeGraphForCommits(commits []*Commit) []GraphLine {
 	if len(commits) == 0 {
 		return nil
 	}
+	return NewGraphState().Extend(nil, commits)
+}
 
-	g := NewGraphState()
-	lines := make([]GraphLine, len(commits))
-	for i, commit := range commits {
-		lines[i] = g.ComputeGraphLine(commit)
+// Resumes reports whether commits starts with the commits already processed
+// by g, so that Extend only has to compute the rest.
+func (g *GraphState) Resumes(commits []*Commit) bool {
+	if g.rows == 0 {
+		return true
+	}
+	return len(commits) >= g.rows &&
+		commits[0].Hash == g.firstHash &&
+		commits[g.rows-1].Hash == g.lastHash
+}
+
+// Extend computes the graph lines of the commits g has not processed yet
+// and appends them to lines, the lines returned by earlier calls. commits
+// must be the list g was computed over with more commits appended; check
+// Resumes first.
+func (g *GraphState) Extend(lines []GraphLine, commits []*Commit) []GraphLine {
+	if g.rows >= len(commits) {
+		return lines
+	}
+	if lines == nil {
+		lines = make([]GraphLine, 0, len(commits))
+	}
+	for _, commit := range commits[g.rows:] {
+		lines = append(lines, g.ComputeGraphLine(commit))
 	}
+	if g.rows == 0 {
+		g.firstHash = commits[0].Hash
+	}
+	g.rows = len(commits)
+	g.lastHash = commits[len(commits)-1].Hash
 	return lines
 }
 
@@ -157,3 +198,27 @@ func ComputeGraphForCommits(commits []*Commit) []GraphLine {
 func (gl *GraphLine) String() string {
 	return string(gl.Chars)
 }
+
+// tagDecoration formats the tags pointing at a commit as a ref decoration
+// drawn after the hash in graph and history rows, e.g. "(v1.1, v1.0) ".
+// Returns "" when the commit has no tags.
+func tagDecoration(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(tags, ", ") + ") "
+}
+
+// updateCommitGraph brings the cached graph lines up to date with commits.
+// When commits extends the list the cache was built from, only the new
+// commits are computed; otherwise the graph is recomputed from scratch.
+func (p *Plugin) updateCommitGraph(commits []*Commit) {
+	if len(commits) == 0 {
+		p.commitGraph, p.commitGraphLines = nil, nil
+		return
+	}
+	if p.commitGraph == nil || len(p.commitGraphLines) != p.commitGraph.rows || !p.commitGraph.Resumes(commits) {
+		p.commitGraph, p.commitGraphLines = NewGraphState(), nil
+	}
+	p.commitGraphLines = p.commitGraph.Extend(p.commitGraphLines, commits)
+}
@@ -0,0 +1,89 @@
+package gitstatus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/guyghost/sidecar/internal/mouse"
+)
+
+// syntheticHistory returns n commits, newest first, spread round-robin over
+// the given number of branches. Each branch's commit has the next commit of
+// the same branch as parent, and every fifth trunk commit merges the head of
+// another branch, so lanes keep opening and joining like a busy repository.
+func syntheticHistory(n, branches int) []*Commit {
+	commits := make([]*Commit, n)
+	for i := range commits {
+		commits[i] = &Commit{
+			Hash:    fmt.Sprintf("%040x", i+1),
+			Subject: fmt.Sprintf("commit %d", i),
+			Pushed:  true,
+		}
+	}
+	for i, c := range commits {
+		if i+branches < n {
+			c.ParentHashes = []string{commits[i+branches].Hash}
+		}
+		lane := i % branches
+		if lane == 0 && branches > 1 && (i/branches)%5 == 0 {
+			other := i + 1 + (i/branches)%(branches-1)
+			if other < n && len(c.ParentHashes) > 0 {
+				c.ParentHashes = append(c.ParentHashes, commits[other].Hash)
+				c.IsMerge = true
+			}
+		}
+	}
+	return commits
+}
+
+// BenchmarkComputeGraph_Full measures computing the graph of a large history
+// in one pass.
+func BenchmarkComputeGraph_Full(b *testing.B) {
+	commits := syntheticHistory(100_000, 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ComputeGraphForCommits(commits)
+	}
+}
+
+// BenchmarkCommitGraph_Paged measures loading a history page by page, as the
+// commit list does while scrolling, with the graph resumed after each page
+// or recomputed from the top as before.
+func BenchmarkCommitGraph_Paged(b *testing.B) {
+	commits := syntheticHistory(10_000, 16)
+	b.Run("incremental", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p := &Plugin{}
+			for n := commitHistoryPageSize; n <= len(commits); n += commitHistoryPageSize {
+				p.updateCommitGraph(commits[:n])
+			}
+		}
+	})
+	b.Run("recompute", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for n := commitHistoryPageSize; n <= len(commits); n += commitHistoryPageSize {
+				ComputeGraphForCommits(commits[:n])
+			}
+		}
+	})
+}
+
+// BenchmarkRenderRecentCommits measures rendering the commit list with the
+// graph shown, deep into a large loaded history.
+func BenchmarkRenderRecentCommits(b *testing.B) {
+	commits := syntheticHistory(100_000, 16)
+	p := &Plugin{
+		tree:            &FileTree{},
+		sidebarWidth:    60,
+		mouseHandler:    mouse.NewHandler(),
+		recentCommits:   commits,
+		showCommitGraph: true,
+		commitScrollOff: 50_000,
+	}
+	p.updateCommitGraph(commits)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.mouseHandler.Clear()
+		_ = p.renderSidebar(40)
+	}
+}
@@ -272,3 +272,79 @@ func TestComputeGraph_BranchMergeBack(t *testing.T) {
 		t.Errorf("Merge line should have commit marker, got %q", mergeLine)
 	}
 }
+
+func TestGraphState_ExtendMatchesFullCompute(t *testing.T) {
+	commits := syntheticHistory(500, 6)
+	want := ComputeGraphForCommits(commits)
+
+	g := NewGraphState()
+	var lines []GraphLine
+	for n := 7; n < len(commits); n += 37 {
+		if !g.Resumes(commits) {
+			t.Fatalf("state after %d commits should resume the longer list", g.rows)
+		}
+		lines = g.Extend(lines, commits[:n])
+	}
+	lines = g.Extend(lines, commits)
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %d, want %d", len(lines), len(want))
+	}
+	for i := range want {
+		if lines[i].String() != want[i].String() {
+			t.Fatalf("line %d = %q, want %q", i, lines[i].String(), want[i].String())
+		}
+	}
+}
+
+func TestUpdateCommitGraph_ResumesOnlyAppendedHistory(t *testing.T) {
+	commits := syntheticHistory(200, 4)
+	p := &Plugin{}
+	p.updateCommitGraph(commits[:100])
+	state := p.commitGraph
+
+	p.updateCommitGraph(commits)
+	if p.commitGraph != state || len(p.commitGraphLines) != 200 {
+		t.Fatal("an appended page should resume the graph state")
+	}
+
+	// A new commit on top changes every row, so the graph starts over
+	head := &Commit{Hash: "head", ParentHashes: []string{commits[0].Hash}}
+	updated := append([]*Commit{head}, commits...)
+	p.updateCommitGraph(updated)
+	if p.commitGraph == state || len(p.commitGraphLines) != 201 {
+		t.Fatal("a changed history should be recomputed")
+	}
+	want := ComputeGraphForCommits(updated)
+	for i := range want {
+		if p.commitGraphLines[i].String() != want[i].String() {
+			t.Fatalf("line %d = %q, want %q", i, p.commitGraphLines[i].String(), want[i].String())
+		}
+	}
+
+	p.updateCommitGraph(nil)
+	if p.commitGraph != nil || p.commitGraphLines != nil {
+		t.Error("an empty list should clear the graph")
+	}
+}
+
+func TestRenderGraphLine_CutsToWidth(t *testing.T) {
+	p := &Plugin{}
+	gl := GraphLine{Chars: []rune("| | | | | | | * "), Width: 16}
+	if got := p.renderGraphLinePlain(gl, 12); got != "│ │ │ │ │ │ " {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestComputeGraph_BranchPointClosesLane(t *testing.T) {
+	// Two branches forked from base: once base is drawn, only one lane is left
+	commits := []*Commit{
+		{Hash: "a", ParentHashes: []string{"base"}},
+		{Hash: "b", ParentHashes: []string{"base"}},
+		{Hash: "base", ParentHashes: []string{"root"}},
+		{Hash: "root", ParentHashes: []string{}},
+	}
+	lines := ComputeGraphForCommits(commits)
+	if got := lines[3].String(); got != "*   " {
+		t.Errorf("root line = %q, want the second lane closed", got)
+	}
+}
@@ -1,6 +1,7 @@
 package gitstatus
 
 import (
+	"errors"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ type Commit struct {
 	Pushed       bool     // Whether this commit has been pushed to upstream
 	ParentHashes []string // Parent commit hashes (empty for root commits)
 	IsMerge      bool     // True if commit has multiple parents
+	MatchDiff    string   // Diff of the tracked lines, for line range searches
 }
 
 // CommitFile represents a file changed in a commit.
@@ -30,6 +32,9 @@ type CommitFile struct {
 	Status    FileStatus
 	Additions int
 	Deletions int
+	Binary    bool
+	Size      int64 // Blob size at the commit, for binary files
+	LFS       bool  // Stored in Git LFS by the current .gitattributes
 }
 
 // CommitStats holds aggregate commit statistics.
@@ -39,11 +44,20 @@ type CommitStats struct {
 	Deletions    int
 }
 
+// commitLogFormat prints a commit on one line as hash, short hash, author,
+// email, timestamp, subject and parents, separated by NULs. Signatures are
+// left out: verifying them runs the signing program for every commit, so
+// they are loaded only for the rows on screen.
+const commitLogFormat = "%H%x00%h%x00%an%x00%ae%x00%at%x00%s%x00%P"
+
+// historyTips are the revisions the history is listed from. During a bisect
+// HEAD is detached at the commit under test, so the bad end is added to keep
+// the whole range in view; the glob matches nothing otherwise.
+var historyTips = []string{"HEAD", "--glob=refs/bisect/bad"}
+
 // GetCommitHistory fetches recent commits.
 func GetCommitHistory(workDir string, limit int) ([]*Commit, error) {
-	// Format: hash\x00shorthash\x00author\x00email\x00timestamp\x00subject\x00parents
-	format := "%H%x00%h%x00%an%x00%ae%x00%at%x00%s%x00%P"
-	args := []string{"log", "--format=" + format, "-n", strconv.Itoa(limit)}
+	args := append([]string{"log", "--format=" + commitLogFormat, "-n", strconv.Itoa(limit)}, historyTips...)
 
 	cmd := exec.Command("git", args...)
 	cmd.Dir = workDir
@@ -52,91 +66,45 @@ func GetCommitHistory(workDir string, limit int) ([]*Commit, error) {
 		return nil, err
 	}
 
-	var commits []*Commit
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "\x00")
-		if len(parts) < 6 {
-			continue
-		}
-
-		timestamp, _ := strconv.ParseInt(parts[4], 10, 64)
-
-		// Parse parent hashes (space-separated in parts[6])
-		var parents []string
-		if len(parts) >= 7 && parts[6] != "" {
-			parents = strings.Split(parts[6], " ")
-		}
-
-		commits = append(commits, &Commit{
-			Hash:         parts[0],
-			ShortHash:    parts[1],
-			Author:       parts[2],
-			AuthorEmail:  parts[3],
-			Date:         time.Unix(timestamp, 0),
-			Subject:      parts[5],
-			ParentHashes: parents,
-			IsMerge:      len(parents) > 1,
-		})
-	}
-
-	return commits, nil
+	return parseLog(string(output)), nil
 }
 
 // GetCommitDetail fetches full commit info including file list.
 func GetCommitDetail(workDir, hash string) (*Commit, error) {
-	// Get commit metadata (%P = parent hashes for merge detection)
-	format := "%H%n%h%n%an%n%ae%n%at%n%P%n%s%n%b"
-	cmd := exec.Command("git", "show", "--format="+format, "-s", hash)
-	cmd.Dir = workDir
-	output, err := cmd.Output()
+	// Commit metadata comes from the shared cat-file process
+	reader := ObjectReaderFor(workDir)
+	obj, err := reader.ReadCommit(hash)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.SplitN(string(output), "\n", 8)
-	if len(lines) < 7 {
-		return nil, nil
-	}
-
-	timestamp, _ := strconv.ParseInt(strings.TrimSpace(lines[4]), 10, 64)
-
-	// Parse parent hashes (space-separated, empty for root commits)
-	var parents []string
-	if raw := strings.TrimSpace(lines[5]); raw != "" {
-		parents = strings.Fields(raw)
-	}
-
 	commit := &Commit{
-		Hash:         strings.TrimSpace(lines[0]),
-		ShortHash:    strings.TrimSpace(lines[1]),
-		Author:       strings.TrimSpace(lines[2]),
-		AuthorEmail:  strings.TrimSpace(lines[3]),
-		Date:         time.Unix(timestamp, 0),
-		Subject:      strings.TrimSpace(lines[6]),
-		ParentHashes: parents,
-		IsMerge:      len(parents) > 1,
-	}
-	if len(lines) > 7 {
-		commit.Body = strings.TrimSpace(lines[7])
+		Hash:         obj.Hash,
+		Author:       obj.Author.Name,
+		AuthorEmail:  obj.Author.Email,
+		Date:         obj.Author.When.Local(),
+		Subject:      obj.Subject(),
+		Body:         obj.Body(),
+		ParentHashes: obj.Parents,
+		IsMerge:      len(obj.Parents) > 1,
 	}
-
-	// Get file stats — for merge commits, diff against first parent to avoid empty combined diff
-	if commit.IsMerge && len(commit.ParentHashes) > 0 {
-		cmd = exec.Command("git", "diff", "--numstat", commit.ParentHashes[0], hash)
-	} else {
-		cmd = exec.Command("git", "show", "--numstat", "--format=", hash)
+	// Get the abbreviated hash, which git keeps unique, and file stats — for
+	// merge commits, diff against first parent to avoid empty combined diff
+	args := []string{"show", "--numstat", "--format=%h", hash}
+	if commit.IsMerge {
+		args = []string{"show", "-m", "--first-parent", "--numstat", "--format=%h", hash}
 	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = workDir
-	output, err = cmd.Output()
+	output, err := cmd.Output()
 	if err != nil {
+		commit.ShortHash = commit.Hash[:min(7, len(commit.Hash))]
 		return commit, nil // Return commit without files
 	}
 
-	fileLines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	shortHash, stats, _ := strings.Cut(string(output), "\n")
+	commit.ShortHash = strings.TrimSpace(shortHash)
+	fileLines := strings.Split(strings.TrimSpace(stats), "\n")
 	for _, line := range fileLines {
 		if line == "" {
 			continue
@@ -147,7 +115,8 @@ func GetCommitDetail(workDir, hash string) (*Commit, error) {
 		}
 
 		var adds, dels int
-		if parts[0] != "-" {
+		binary := parts[0] == "-"
+		if !binary {
 			adds, _ = strconv.Atoi(parts[0])
 		}
 		if parts[1] != "-" {
@@ -175,31 +144,57 @@ func GetCommitDetail(workDir, hash string) (*Commit, error) {
 			}
 		}
 
-		commit.Files = append(commit.Files, CommitFile{
+		file := CommitFile{
 			Path:      path,
 			OldPath:   oldPath,
 			Status:    status,
 			Additions: adds,
 			Deletions: dels,
-		})
+			Binary:    binary,
+		}
+		if binary {
+			// Line counts mean nothing for binaries; show the size instead.
+			// Deleted files have no blob at this commit and keep Size 0.
+			file.Size, _ = reader.BlobSize(commit.Hash, path)
+		}
+		commit.Files = append(commit.Files, file)
 
 		commit.Stats.FilesChanged++
 		commit.Stats.Additions += adds
 		commit.Stats.Deletions += dels
 	}
+	markLFSFiles(workDir, commit.Files)
 
 	return commit, nil
 }
 
+// markLFSFiles flags the commit files Git LFS stores.
+func markLFSFiles(workDir string, files []CommitFile) {
+	if len(files) == 0 {
+		return
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	tracked, err := LFSTrackedPaths(workDir, paths)
+	if err != nil {
+		return
+	}
+	for i := range files {
+		files[i].LFS = tracked[files[i].Path]
+	}
+}
+
 // GetCommitDiff returns the diff for a specific file in a commit.
 // For merge commits, parentHash should be the first parent so we diff against
 // it instead of using git show's combined diff (which is empty for clean merges).
-func GetCommitDiff(workDir, hash, path string, parentHash string) (string, error) {
+func GetCommitDiff(workDir, hash, path string, parentHash string, opts DiffOptions) (string, error) {
 	var args []string
 	if parentHash != "" {
-		args = []string{"diff", parentHash, hash, "--", path}
+		args = opts.Command("diff", parentHash, hash, "--", path)
 	} else {
-		args = []string{"show", hash, "--", path}
+		args = opts.Command("show", hash, "--", path)
 	}
 
 	cmd := exec.Command("git", args...)
@@ -218,8 +213,8 @@ func GetCommitDiff(workDir, hash, path string, parentHash string) (string, error
 }
 
 // GetCommitFullDiff returns the full diff for a commit.
-func GetCommitFullDiff(workDir, hash string) (string, error) {
-	cmd := exec.Command("git", "show", hash)
+func GetCommitFullDiff(workDir, hash string, opts DiffOptions) (string, error) {
+	cmd := exec.Command("git", opts.Command("show", hash)...)
 	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil {
@@ -255,8 +250,7 @@ func GetCommitHistoryWithPushStatus(workDir string, limit int) ([]*Commit, *Push
 // GetCommitHistoryWithOffset fetches commits starting from skip, up to limit.
 // Uses git log --skip=N to paginate through history.
 func GetCommitHistoryWithOffset(workDir string, limit, skip int) ([]*Commit, error) {
-	format := "%H%x00%h%x00%an%x00%ae%x00%at%x00%s%x00%P"
-	args := []string{"log", "--format=" + format, "-n", strconv.Itoa(limit), "--skip", strconv.Itoa(skip)}
+	args := append([]string{"log", "--format=" + commitLogFormat, "-n", strconv.Itoa(limit), "--skip", strconv.Itoa(skip)}, historyTips...)
 
 	cmd := exec.Command("git", args...)
 	cmd.Dir = workDir
@@ -265,38 +259,7 @@ func GetCommitHistoryWithOffset(workDir string, limit, skip int) ([]*Commit, err
 		return nil, err
 	}
 
-	var commits []*Commit
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "\x00")
-		if len(parts) < 6 {
-			continue
-		}
-
-		timestamp, _ := strconv.ParseInt(parts[4], 10, 64)
-
-		// Parse parent hashes (space-separated in parts[6])
-		var parents []string
-		if len(parts) >= 7 && parts[6] != "" {
-			parents = strings.Split(parts[6], " ")
-		}
-
-		commits = append(commits, &Commit{
-			Hash:         parts[0],
-			ShortHash:    parts[1],
-			Author:       parts[2],
-			AuthorEmail:  parts[3],
-			Date:         time.Unix(timestamp, 0),
-			Subject:      parts[5],
-			ParentHashes: parents,
-			IsMerge:      len(parents) > 1,
-		})
-	}
-
-	return commits, nil
+	return parseLog(string(output)), nil
 }
 
 // GetCommitHistoryWithPushStatusOffset fetches commits with offset and populates push status.
@@ -318,67 +281,146 @@ type HistoryFilterOpts struct {
 	Path   string // Filter by file path (-- <path>)
 	Limit  int
 	Skip   int
+
+	// Searches across the full history
+	Grep       string // Commit message (--grep)
+	Pickaxe    string // Commits changing the number of occurrences (-S)
+	DiffRegex  string // Commits adding or removing a matching line (-G)
+	LineRange  string // Line range or function history (-L), e.g. "10,20:main.go"
+	Regex      bool   // Grep and Pickaxe are extended regexes
+	IgnoreCase bool
 }
 
-// GetCommitHistoryFiltered fetches commits with filters applied.
-func GetCommitHistoryFiltered(workDir string, opts HistoryFilterOpts) ([]*Commit, error) {
-	format := "%H%x00%h%x00%an%x00%ae%x00%at%x00%s%x00%P"
+// logArgs returns the git log arguments for the filter options.
+func (o HistoryFilterOpts) logArgs(format string) []string {
 	args := []string{"log", "--format=" + format}
 
-	if opts.Author != "" {
-		args = append(args, "--author="+opts.Author)
+	if o.Author != "" {
+		args = append(args, "--author="+o.Author)
+	}
+	if o.Grep != "" {
+		args = append(args, "--grep="+o.Grep)
+		if o.Regex {
+			args = append(args, "--extended-regexp")
+		}
+	}
+	args = append(args, o.pickaxeArgs()...)
+	if o.IgnoreCase && (o.Author != "" || o.Grep != "") && o.Pickaxe == "" && o.DiffRegex == "" {
+		args = append(args, "--regexp-ignore-case")
+	}
+	if o.LineRange != "" {
+		args = append(args, "-L"+o.LineRange)
+	}
+
+	if o.Limit > 0 {
+		args = append(args, "-n", strconv.Itoa(o.Limit))
+	}
+	if o.Skip > 0 {
+		args = append(args, "--skip", strconv.Itoa(o.Skip))
+	}
+
+	// -L names its own file and cannot be combined with a pathspec
+	if o.Path != "" && o.LineRange == "" {
+		args = append(args, "--", o.Path)
 	}
+	return args
+}
 
-	if opts.Limit > 0 {
-		args = append(args, "-n", strconv.Itoa(opts.Limit))
+// pickaxeArgs returns the -S or -G arguments of the options.
+func (o HistoryFilterOpts) pickaxeArgs() []string {
+	var args []string
+	if o.Pickaxe != "" {
+		args = append(args, "-S"+o.Pickaxe)
+		if o.Regex {
+			args = append(args, "--pickaxe-regex")
+		}
+	}
+	if o.DiffRegex != "" {
+		args = append(args, "-G"+o.DiffRegex)
 	}
-	if opts.Skip > 0 {
-		args = append(args, "--skip", strconv.Itoa(opts.Skip))
+	if o.IgnoreCase && len(args) > 0 {
+		args = append(args, "--regexp-ignore-case")
 	}
+	return args
+}
 
-	if opts.Path != "" {
-		args = append(args, "--", opts.Path)
+// GetCommitHistoryFiltered fetches commits with filters applied. For a line
+// range search each commit's MatchDiff holds the diff of the tracked lines.
+func GetCommitHistoryFiltered(workDir string, opts HistoryFilterOpts) ([]*Commit, error) {
+	format := commitLogFormat
+	if opts.LineRange != "" {
+		format = "%x1e" + format
 	}
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command("git", opts.logArgs(format)...)
 	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
 		return nil, err
 	}
 
+	if opts.LineRange != "" {
+		return parseLineHistory(string(output)), nil
+	}
+
+	return parseLog(string(output)), nil
+}
+
+// parseLog parses the commit lines of a log printed with commitLogFormat.
+func parseLog(output string) []*Commit {
 	var commits []*Commit
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "\x00")
-		if len(parts) < 6 {
-			continue
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if c := parseLogLine(line); c != nil {
+			commits = append(commits, c)
 		}
+	}
+	return commits
+}
 
-		timestamp, _ := strconv.ParseInt(parts[4], 10, 64)
+// parseLogLine parses one commit line printed with commitLogFormat.
+func parseLogLine(line string) *Commit {
+	parts := strings.Split(line, "\x00")
+	if len(parts) < 6 {
+		return nil
+	}
 
-		// Parse parent hashes (space-separated in parts[6])
-		var parents []string
-		if len(parts) >= 7 && parts[6] != "" {
-			parents = strings.Split(parts[6], " ")
-		}
+	timestamp, _ := strconv.ParseInt(parts[4], 10, 64)
 
-		commits = append(commits, &Commit{
-			Hash:         parts[0],
-			ShortHash:    parts[1],
-			Author:       parts[2],
-			AuthorEmail:  parts[3],
-			Date:         time.Unix(timestamp, 0),
-			Subject:      parts[5],
-			ParentHashes: parents,
-			IsMerge:      len(parents) > 1,
-		})
+	// Parse parent hashes (space-separated in parts[6])
+	var parents []string
+	if len(parts) >= 7 && parts[6] != "" {
+		parents = strings.Split(parts[6], " ")
 	}
 
-	return commits, nil
+	return &Commit{
+		Hash:         parts[0],
+		ShortHash:    parts[1],
+		Author:       parts[2],
+		AuthorEmail:  parts[3],
+		Date:         time.Unix(timestamp, 0),
+		Subject:      parts[5],
+		ParentHashes: parents,
+		IsMerge:      len(parents) > 1,
+	}
+}
+
+// parseLineHistory parses `git log -L` output, where each record starts with
+// a record separator and the commit line is followed by the range's diff.
+func parseLineHistory(output string) []*Commit {
+	var commits []*Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		header, diff, _ := strings.Cut(record, "\n")
+		c := parseLogLine(header)
+		if c == nil {
+			continue
+		}
+		c.MatchDiff = strings.TrimSpace(diff)
+		commits = append(commits, c)
+	}
+	return commits
 }
 
 // GetCommitHistoryFilteredWithPushStatus fetches filtered commits and populates push status.
@@ -0,0 +1,632 @@
+package gitstatus
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	cherryPickTargetPrefix = "cherry-pick-target-" // List item ID prefix, followed by target index
+	cherryPickActionID     = "cherry-pick-action"  // Primary action (Enter key)
+
+	resetModePrefix = "reset-mode-" // List item ID prefix, followed by mode index
+	resetConfirmID  = "reset-confirm"
+	resetCancelID   = "reset-cancel"
+)
+
+// Commit history operations reported by CommitOpDoneMsg.
+const (
+	commitOpCherryPick = "cherry-pick"
+	commitOpRevert     = "revert"
+	commitOpReset      = "reset"
+)
+
+// commitOpTitles maps an operation to the error modal title for its failure.
+var commitOpTitles = map[string]string{
+	commitOpCherryPick: "Cherry-pick Failed",
+	commitOpRevert:     "Revert Failed",
+	commitOpReset:      "Reset Failed",
+}
+
+// resetModes lists the reset modes in the order shown in the confirm modal.
+var resetModes = []struct {
+	Mode  ResetMode
+	Label string
+}{
+	{ResetSoft, "Soft  - keep changes staged"},
+	{ResetMixed, "Mixed - keep changes unstaged"},
+	{ResetHard, "Hard  - discard all changes"},
+}
+
+// cherryPickTarget is a branch commits can be cherry-picked onto.
+type cherryPickTarget struct {
+	Label string
+	Dir   string // Worktree the branch is checked out in
+}
+
+// CherryPickTargetsLoadedMsg is sent when the worktrees for the cherry-pick picker load.
+type CherryPickTargetsLoadedMsg struct {
+	Epoch     uint64 // Epoch when request was issued (for stale detection)
+	Worktrees []WorktreeInfo
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CherryPickTargetsLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// ResetPreviewLoadedMsg is sent when the reset confirm preview loads.
+type ResetPreviewLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Target  *Commit
+	Preview *ResetPreview
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ResetPreviewLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// CommitOpDoneMsg is sent when a cherry-pick, revert or reset returns.
+type CommitOpDoneMsg struct {
+	Epoch     uint64   // Epoch when request was issued (for stale detection)
+	Op        string   // commitOpCherryPick, commitOpRevert or commitOpReset
+	Count     int      // Number of commits applied; 0 after continuing a stopped operation
+	Target    string   // Branch label or reset target shown in the toast
+	Dir       string   // Worktree the operation ran in
+	Conflicts []string // Conflicted files when the operation stopped
+	Err       error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CommitOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// toggleCommitMark marks or unmarks the commit under the cursor.
+func (p *Plugin) toggleCommitMark() {
+	commit := p.cursorCommit()
+	if commit == nil {
+		return
+	}
+	if p.markedCommits == nil {
+		p.markedCommits = make(map[string]bool)
+	}
+	if p.markedCommits[commit.Hash] {
+		delete(p.markedCommits, commit.Hash)
+	} else {
+		p.markedCommits[commit.Hash] = true
+	}
+}
+
+// cursorCommit returns the commit under the cursor, or nil.
+func (p *Plugin) cursorCommit() *Commit {
+	if !p.cursorOnCommit() {
+		return nil
+	}
+	commits := p.activeCommits()
+	if idx := p.selectedCommitIndex(); idx >= 0 && idx < len(commits) {
+		return commits[idx]
+	}
+	return nil
+}
+
+// selectedCommits returns the marked commits in list order (newest first),
+// or the commit under the cursor when nothing is marked.
+func (p *Plugin) selectedCommits() []*Commit {
+	if len(p.markedCommits) > 0 {
+		var commits []*Commit
+		for _, c := range p.activeCommits() {
+			if p.markedCommits[c.Hash] {
+				commits = append(commits, c)
+			}
+		}
+		if len(commits) > 0 {
+			return commits
+		}
+	}
+	if commit := p.cursorCommit(); commit != nil {
+		return []*Commit{commit}
+	}
+	return nil
+}
+
+// commitHashes returns the hashes of commits, reversed when oldestFirst is set.
+func commitHashes(commits []*Commit, oldestFirst bool) []string {
+	hashes := make([]string, len(commits))
+	for i, c := range commits {
+		if oldestFirst {
+			hashes[len(commits)-1-i] = c.Hash
+		} else {
+			hashes[i] = c.Hash
+		}
+	}
+	return hashes
+}
+
+// hasMergeCommit reports whether any of the commits is a merge.
+func hasMergeCommit(commits []*Commit) bool {
+	for _, c := range commits {
+		if c.IsMerge {
+			return true
+		}
+	}
+	return false
+}
+
+// openCherryPick loads the worktrees to offer as cherry-pick targets.
+func (p *Plugin) openCherryPick() tea.Cmd {
+	commits := p.selectedCommits()
+	if len(commits) == 0 {
+		return nil
+	}
+	if hasMergeCommit(commits) {
+		return appmsg.ShowToast("Cannot cherry-pick merge commits", 2*time.Second)
+	}
+	p.cherryPickCommits = commits
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		return CherryPickTargetsLoadedMsg{Epoch: epoch, Worktrees: GetWorktrees(workDir)}
+	}
+}
+
+// handleCherryPickTargets opens the target picker: the current branch first,
+// then the branches checked out in other worktrees.
+func (p *Plugin) handleCherryPickTargets(msg CherryPickTargetsLoadedMsg) {
+	if len(p.cherryPickCommits) == 0 {
+		return
+	}
+	current := "Current branch"
+	if p.pushStatus != nil && p.pushStatus.CurrentBranch != "" {
+		current += " (" + p.pushStatus.CurrentBranch + ")"
+	}
+	p.cherryPickTargets = []cherryPickTarget{{Label: current, Dir: p.repoRoot}}
+
+	root := canonicalPath(p.repoRoot)
+	for _, wt := range msg.Worktrees {
+		if wt.Branch == "" || canonicalPath(wt.Path) == root {
+			continue
+		}
+		p.cherryPickTargets = append(p.cherryPickTargets, cherryPickTarget{
+			Label: fmt.Sprintf("%s (%s)", wt.Branch, filepath.Base(wt.Path)),
+			Dir:   wt.Path,
+		})
+	}
+	p.cherryPickIdx = 0
+	p.cherryPickModal = nil
+	p.viewMode = ViewModeCherryPick
+}
+
+// canonicalPath resolves symlinks so worktree paths compare equal.
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// ensureCherryPickModal builds/rebuilds the cherry-pick target picker.
+func (p *Plugin) ensureCherryPickModal() {
+	modalW := ui.ModalWidthMedium
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < pullMenuMinWidth {
+		modalW = pullMenuMinWidth
+	}
+	if p.cherryPickModal != nil && p.cherryPickWidth == modalW {
+		return
+	}
+	p.cherryPickWidth = modalW
+
+	items := make([]modal.ListItem, len(p.cherryPickTargets))
+	for i, t := range p.cherryPickTargets {
+		items[i] = modal.ListItem{ID: fmt.Sprintf("%s%d", cherryPickTargetPrefix, i), Label: t.Label}
+	}
+	title := fmt.Sprintf("Cherry-pick %d commit(s)", len(p.cherryPickCommits))
+	if len(p.cherryPickCommits) == 1 {
+		title = "Cherry-pick " + shortHash(p.cherryPickCommits[0].Hash)
+	}
+
+	p.cherryPickModal = modal.New(title,
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(cherryPickActionID),
+	).
+		AddSection(modal.Text("Apply onto:")).
+		AddSection(modal.List("cherry-pick-targets", items, &p.cherryPickIdx, modal.WithMaxVisible(8)))
+}
+
+// renderCherryPick renders the cherry-pick target picker.
+func (p *Plugin) renderCherryPick() string {
+	background := p.renderThreePaneView()
+
+	p.ensureCherryPickModal()
+	if p.cherryPickModal == nil {
+		return background
+	}
+	modalContent := p.cherryPickModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// updateCherryPick handles key events in the cherry-pick target picker.
+func (p *Plugin) updateCherryPick(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureCherryPickModal()
+	if p.cherryPickModal == nil {
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.closeCherryPick()
+		return p, nil
+	}
+
+	action, cmd := p.cherryPickModal.HandleKey(msg)
+	if plug, opCmd, ok := p.cherryPickAction(action); ok {
+		return plug, opCmd
+	}
+	return p, cmd
+}
+
+// handleCherryPickMouse handles mouse events in the cherry-pick target picker.
+func (p *Plugin) handleCherryPickMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.cherryPickModal == nil {
+		return p, nil
+	}
+	action := p.cherryPickModal.HandleMouse(msg, p.mouseHandler)
+	if plug, cmd, ok := p.cherryPickAction(action); ok {
+		return plug, cmd
+	}
+	return p, nil
+}
+
+// cherryPickAction runs the picker action with the given ID.
+func (p *Plugin) cherryPickAction(action string) (plugin.Plugin, tea.Cmd, bool) {
+	switch {
+	case action == "cancel":
+		p.closeCherryPick()
+		return p, nil, true
+	case action == cherryPickActionID:
+		return p, p.doCherryPick(p.cherryPickIdx), true
+	case strings.HasPrefix(action, cherryPickTargetPrefix):
+		var idx int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(action, cherryPickTargetPrefix), "%d", &idx); err == nil {
+			return p, p.doCherryPick(idx), true
+		}
+	}
+	return p, nil, false
+}
+
+// closeCherryPick closes the target picker.
+func (p *Plugin) closeCherryPick() {
+	p.viewMode = ViewModeStatus
+	p.cherryPickCommits = nil
+	p.cherryPickTargets = nil
+	p.cherryPickIdx = 0
+	p.cherryPickModal = nil
+	p.cherryPickWidth = 0
+}
+
+// doCherryPick applies the picked commits, oldest first, onto the target at idx.
+func (p *Plugin) doCherryPick(idx int) tea.Cmd {
+	if idx < 0 || idx >= len(p.cherryPickTargets) {
+		return nil
+	}
+	target := p.cherryPickTargets[idx]
+	hashes := commitHashes(p.cherryPickCommits, true)
+	p.closeCherryPick()
+
+	epoch := p.ctx.Epoch
+	return func() tea.Msg {
+		_, err := CherryPick(target.Dir, hashes...)
+		return commitOpDoneMsg(epoch, commitOpCherryPick, len(hashes), target.Label, target.Dir, err)
+	}
+}
+
+// doRevert reverts the selected commits, newest first, on the current branch.
+func (p *Plugin) doRevert() tea.Cmd {
+	commits := p.selectedCommits()
+	if len(commits) == 0 {
+		return nil
+	}
+	if hasMergeCommit(commits) {
+		return appmsg.ShowToast("Cannot revert merge commits", 2*time.Second)
+	}
+	hashes := commitHashes(commits, false)
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		_, err := Revert(workDir, hashes...)
+		return commitOpDoneMsg(epoch, commitOpRevert, len(hashes), "", workDir, err)
+	}
+}
+
+// doContinueCommitOp continues a cherry-pick or revert stopped on conflicts.
+func (p *Plugin) doContinueCommitOp() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.conflictDir()
+	op := p.pullConflictType
+	return func() tea.Msg {
+		var err error
+		if op == commitOpRevert {
+			_, err = ContinueRevert(workDir)
+		} else {
+			_, err = ContinueCherryPick(workDir)
+		}
+		return commitOpDoneMsg(epoch, op, 0, "", workDir, err)
+	}
+}
+
+// commitOpDoneMsg builds a CommitOpDoneMsg, listing conflicts if the operation stopped.
+func commitOpDoneMsg(epoch uint64, op string, count int, target, dir string, err error) CommitOpDoneMsg {
+	msg := CommitOpDoneMsg{Epoch: epoch, Op: op, Count: count, Target: target, Dir: dir, Err: err}
+	if err != nil && IsConflictError(err) {
+		msg.Conflicts = GetConflictedFiles(dir)
+	}
+	return msg
+}
+
+// handleCommitOpDone shows the outcome of a cherry-pick, revert or reset.
+// Conflicts reuse the pull conflict modal.
+func (p *Plugin) handleCommitOpDone(msg CommitOpDoneMsg) tea.Cmd {
+	reload := tea.Batch(p.refresh(), p.loadRecentCommits())
+	if msg.Err != nil {
+		if len(msg.Conflicts) > 0 {
+			p.pullConflictType = msg.Op
+			p.pullConflictDir = msg.Dir
+			p.pullConflictFiles = msg.Conflicts
+			p.viewMode = ViewModePullConflict
+			p.clearPullConflictModal()
+			return reload
+		}
+		p.showErrorModal(commitOpTitles[msg.Op], msg.Err)
+		return reload
+	}
+
+	p.markedCommits = nil
+	p.pullConflictFiles = nil
+	p.pullConflictType = ""
+	p.pullConflictDir = ""
+
+	var toast string
+	switch {
+	case msg.Count == 0:
+		toast = strings.ToUpper(msg.Op[:1]) + msg.Op[1:] + " completed"
+	case msg.Op == commitOpCherryPick:
+		toast = fmt.Sprintf("Cherry-picked %d commit(s) onto %s", msg.Count, msg.Target)
+	case msg.Op == commitOpRevert:
+		toast = fmt.Sprintf("Reverted %d commit(s)", msg.Count)
+	default:
+		toast = "Reset to " + msg.Target
+	}
+	return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), reload)
+}
+
+// conflictDir returns the worktree the conflicted operation is running in.
+func (p *Plugin) conflictDir() string {
+	if p.pullConflictDir != "" {
+		return p.pullConflictDir
+	}
+	return p.repoRoot
+}
+
+// openReset loads the preview for resetting the current branch to the commit
+// under the cursor.
+func (p *Plugin) openReset() tea.Cmd {
+	return p.openResetTo(p.cursorCommit())
+}
+
+// openResetTo loads the preview for resetting the current branch to commit.
+func (p *Plugin) openResetTo(commit *Commit) tea.Cmd {
+	if commit == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		preview, err := GetResetPreview(workDir, commit.Hash)
+		return ResetPreviewLoadedMsg{Epoch: epoch, Target: commit, Preview: preview, Err: err}
+	}
+}
+
+// handleResetPreview opens the reset confirm modal. Mixed is selected by
+// default, matching git reset.
+func (p *Plugin) handleResetPreview(msg ResetPreviewLoadedMsg) {
+	if msg.Err != nil {
+		p.showErrorModal("Cannot Reset", msg.Err)
+		return
+	}
+	p.resetTarget = msg.Target
+	p.resetPreview = msg.Preview
+	p.resetModeIdx = 1
+	p.resetModal = nil
+	p.viewMode = ViewModeConfirmReset
+}
+
+// ensureResetModal builds/rebuilds the reset confirm modal.
+func (p *Plugin) ensureResetModal() {
+	if p.resetTarget == nil {
+		return
+	}
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.resetModal != nil && p.resetModalWidth == modalW {
+		return
+	}
+	p.resetModalWidth = modalW
+
+	items := make([]modal.ListItem, len(resetModes))
+	for i, m := range resetModes {
+		items[i] = modal.ListItem{ID: fmt.Sprintf("%s%d", resetModePrefix, i), Label: m.Label}
+	}
+	title := "Reset to " + shortHash(p.resetTarget.Hash)
+
+	p.resetModal = modal.New(title,
+		modal.WithWidth(modalW),
+		modal.WithVariant(modal.VariantDanger),
+		modal.WithPrimaryAction(resetConfirmID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text(p.resetTarget.Subject)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.List("reset-modes", items, &p.resetModeIdx, modal.WithMaxVisible(len(items)))).
+		AddSection(modal.Spacer()).
+		AddSection(p.resetLossSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Reset ", resetConfirmID, modal.BtnDanger()),
+			modal.Btn(" Cancel ", resetCancelID),
+		))
+}
+
+// resetLossSection lists what the selected reset mode will lose.
+func (p *Plugin) resetLossSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		preview := p.resetPreview
+		if preview == nil {
+			return modal.RenderedSection{}
+		}
+		var sb strings.Builder
+		if len(preview.Commits) == 0 {
+			sb.WriteString(styles.Muted.Render("No commits leave the branch."))
+		} else {
+			sb.WriteString(styles.Muted.Render(fmt.Sprintf("%d commit(s) will leave the branch:", len(preview.Commits))))
+			writeResetLines(&sb, preview.Commits, styles.StatusModified, contentWidth)
+		}
+
+		hard := resetModes[p.resetModeIdx].Mode == ResetHard
+		if hard && len(preview.ChangedFiles) > 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(styles.StatusDeleted.Render(fmt.Sprintf("Uncommitted changes in %d file(s) will be lost:", len(preview.ChangedFiles))))
+			writeResetLines(&sb, preview.ChangedFiles, styles.StatusDeleted, contentWidth)
+		}
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// writeResetLines writes up to 8 indented lines, summarising the rest.
+func writeResetLines(sb *strings.Builder, lines []string, style lipgloss.Style, width int) {
+	maxLines := 8
+	for i, line := range lines {
+		sb.WriteString("\n")
+		if i >= maxLines {
+			sb.WriteString(styles.Muted.Render(fmt.Sprintf("  ... and %d more", len(lines)-maxLines)))
+			break
+		}
+		if runes := []rune(line); width > 5 && len(runes) > width-2 {
+			line = string(runes[:width-3]) + "…"
+		}
+		sb.WriteString(style.Render("  " + line))
+	}
+}
+
+// renderConfirmReset renders the reset confirm modal.
+func (p *Plugin) renderConfirmReset() string {
+	background := p.renderThreePaneView()
+
+	p.ensureResetModal()
+	if p.resetModal == nil {
+		return background
+	}
+	modalContent := p.resetModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// updateConfirmReset handles key events in the reset confirm modal.
+func (p *Plugin) updateConfirmReset(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureResetModal()
+	if p.resetModal == nil {
+		p.closeReset()
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc", "q", "n":
+		p.closeReset()
+		return p, nil
+	case "s", "m", "h":
+		p.resetModeIdx = strings.Index("smh", msg.String())
+		return p, nil
+	}
+
+	action, cmd := p.resetModal.HandleKey(msg)
+	if plug, opCmd, ok := p.resetAction(action); ok {
+		return plug, opCmd
+	}
+	return p, cmd
+}
+
+// handleConfirmResetMouse handles mouse events in the reset confirm modal.
+func (p *Plugin) handleConfirmResetMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.resetModal == nil {
+		return p, nil
+	}
+	action := p.resetModal.HandleMouse(msg, p.mouseHandler)
+	if plug, cmd, ok := p.resetAction(action); ok {
+		return plug, cmd
+	}
+	return p, nil
+}
+
+// resetAction runs the reset modal action with the given ID. Picking a mode
+// from the list only selects it; the Reset button confirms.
+func (p *Plugin) resetAction(action string) (plugin.Plugin, tea.Cmd, bool) {
+	switch {
+	case action == "cancel" || action == resetCancelID:
+		p.closeReset()
+		return p, nil, true
+	case action == resetConfirmID:
+		return p, p.doReset(), true
+	case strings.HasPrefix(action, resetModePrefix):
+		var idx int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(action, resetModePrefix), "%d", &idx); err == nil && idx < len(resetModes) {
+			p.resetModeIdx = idx
+		}
+		return p, nil, true
+	}
+	return p, nil, false
+}
+
+// closeReset closes the reset confirm modal.
+func (p *Plugin) closeReset() {
+	p.viewMode = ViewModeStatus
+	p.resetTarget = nil
+	p.resetPreview = nil
+	p.resetModeIdx = 0
+	p.resetModal = nil
+	p.resetModalWidth = 0
+}
+
+// doReset resets the current branch to the confirmed target.
+func (p *Plugin) doReset() tea.Cmd {
+	if p.resetTarget == nil {
+		return nil
+	}
+	mode := resetModes[p.resetModeIdx].Mode
+	target := p.resetTarget
+	p.closeReset()
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		point, undoErr := CaptureResetUndo(workDir, mode)
+		err := Reset(workDir, mode, target.Hash)
+		label := fmt.Sprintf("%s (%s)", shortHash(target.Hash), mode)
+		result := CommitOpDoneMsg{Epoch: epoch, Op: commitOpReset, Count: 1, Target: label, Dir: workDir, Err: err}
+		if err != nil {
+			return result
+		}
+		return recordUndo(workDir, point, undoErr, result)
+	}
+}
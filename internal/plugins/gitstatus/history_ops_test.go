@@ -0,0 +1,165 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+	"github.com/guyghost/sidecar/internal/mouse"
+	"github.com/guyghost/sidecar/internal/plugin"
+)
+
+func newHistoryOpsPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	return &Plugin{
+		ctx:          &plugin.Context{},
+		hasRepo:      true,
+		repoRoot:     "/repo",
+		tree:         &FileTree{},
+		width:        100,
+		height:       30,
+		mouseHandler: mouse.NewHandler(),
+		recentCommits: []*Commit{
+			{Hash: "ccc0000000", Subject: "three"},
+			{Hash: "bbb0000000", Subject: "two"},
+			{Hash: "aaa0000000", Subject: "one"},
+		},
+	}
+}
+
+func TestSelectedCommits_MarksInListOrder(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+
+	p.cursor = 2
+	p.Update(runeKey(" "))
+	p.cursor = 0
+	p.Update(runeKey(" "))
+	p.cursor = 1
+
+	got := commitHashes(p.selectedCommits(), true)
+	if strings.Join(got, ",") != "aaa0000000,ccc0000000" {
+		t.Errorf("oldest-first hashes = %v", got)
+	}
+	p.sidebarWidth = 60
+	sidebar := p.renderSidebar(20)
+	if !strings.Contains(sidebar, "[2 selected]") || strings.Count(sidebar, "✓") != 2 {
+		t.Error("sidebar should show the marks and selection count")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(p.markedCommits) != 0 {
+		t.Error("esc should clear marks")
+	}
+	if got := p.selectedCommits(); len(got) != 1 || got[0].Subject != "two" {
+		t.Errorf("without marks the cursor commit is selected, got %v", got)
+	}
+}
+
+func TestOpenCherryPick_BlocksMerges(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.recentCommits[0].IsMerge = true
+	p.openCherryPick()
+	if p.cherryPickCommits != nil {
+		t.Error("merge commits should not open the picker")
+	}
+}
+
+func TestHandleCherryPickTargets(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.cursor = 1
+	p.openCherryPick()
+	p.handleCherryPickTargets(CherryPickTargetsLoadedMsg{Worktrees: []WorktreeInfo{
+		{Path: "/repo", Branch: "main", IsMain: true},
+		{Path: "/wt/feature", Branch: "feature"},
+		{Path: "/wt/detached"},
+	}})
+
+	if p.viewMode != ViewModeCherryPick || p.FocusContext() != keymap.ContextGitCherryPick {
+		t.Fatalf("expected cherry-pick picker, viewMode=%v", p.viewMode)
+	}
+	if len(p.cherryPickTargets) != 2 || p.cherryPickTargets[1].Dir != "/wt/feature" {
+		t.Fatalf("targets = %+v", p.cherryPickTargets)
+	}
+	if view := p.View(100, 30); !strings.Contains(view, "feature (feature)") {
+		t.Error("picker should list the other worktree's branch")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus || p.cherryPickTargets != nil {
+		t.Error("esc should close the picker")
+	}
+}
+
+func TestConfirmReset_ShowsLossForMode(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.handleResetPreview(ResetPreviewLoadedMsg{
+		Target:  p.recentCommits[2],
+		Preview: &ResetPreview{Commits: []string{"ccc0000 three", "bbb0000 two"}, ChangedFiles: []string{"dirty.go"}},
+	})
+	if p.viewMode != ViewModeConfirmReset || p.FocusContext() != keymap.ContextGitReset {
+		t.Fatalf("expected reset confirm, viewMode=%v", p.viewMode)
+	}
+
+	view := p.View(100, 30)
+	if !strings.Contains(view, "2 commit(s) will leave the branch") || strings.Contains(view, "dirty.go") {
+		t.Error("mixed reset should list commits but not uncommitted files")
+	}
+
+	p.Update(runeKey("h"))
+	if resetModes[p.resetModeIdx].Mode != ResetHard {
+		t.Fatalf("h should select hard, got %d", p.resetModeIdx)
+	}
+	if !strings.Contains(p.View(100, 30), "dirty.go") {
+		t.Error("hard reset should list uncommitted files that will be lost")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus || p.resetTarget != nil {
+		t.Error("esc should cancel the reset")
+	}
+}
+
+func TestHandleCommitOpDone_ConflictOpensModal(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.handleCommitOpDone(CommitOpDoneMsg{
+		Op:        commitOpCherryPick,
+		Dir:       "/wt/feature",
+		Conflicts: []string{"f.txt"},
+		Err:       &CherryPickError{Output: "CONFLICT (content)"},
+	})
+	if p.viewMode != ViewModePullConflict || p.pullConflictDir != "/wt/feature" {
+		t.Fatalf("expected conflict modal for worktree, viewMode=%v dir=%q", p.viewMode, p.pullConflictDir)
+	}
+	view := p.View(100, 30)
+	for _, want := range []string{"Cherry-pick produced conflicts", "f.txt", "Continue"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("conflict modal missing %q", want)
+		}
+	}
+
+	if _, cmd := p.Update(runeKey("c")); cmd == nil || p.viewMode != ViewModeStatus {
+		t.Error("c should continue the cherry-pick")
+	}
+}
+
+func TestHandleCommitOpDone_ErrorAndSuccess(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.markedCommits = map[string]bool{"aaa0000000": true}
+
+	p.handleCommitOpDone(CommitOpDoneMsg{Op: commitOpRevert, Err: &RevertError{Output: "error: bad revision"}})
+	if p.viewMode != ViewModeError || p.errorDetail != "error: bad revision" {
+		t.Errorf("expected error modal, viewMode=%v detail=%q", p.viewMode, p.errorDetail)
+	}
+	if len(p.markedCommits) != 1 {
+		t.Error("a failed operation should keep the marks")
+	}
+
+	p.viewMode = ViewModeStatus
+	if cmd := p.handleCommitOpDone(CommitOpDoneMsg{Op: commitOpRevert, Count: 1}); cmd == nil {
+		t.Error("expected toast and refresh")
+	}
+	if len(p.markedCommits) != 0 {
+		t.Error("a successful operation should clear the marks")
+	}
+}
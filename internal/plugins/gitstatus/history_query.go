@@ -0,0 +1,305 @@
+package gitstatus
+
+import (
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+)
+
+// HistorySearchMode selects what the history search modal searches.
+type HistorySearchMode int
+
+const (
+	SearchLoaded    HistorySearchMode = iota // Subject and author of loaded commits
+	SearchMessages                           // Commit messages across history (--grep)
+	SearchPickaxe                            // Changes in occurrences of a string (-S)
+	SearchDiffRegex                          // Added or removed lines matching a regex (-G)
+	SearchLineRange                          // History of a line range or function (-L)
+)
+
+// Label returns the short name shown in the search modal.
+func (m HistorySearchMode) Label() string {
+	switch m {
+	case SearchMessages:
+		return "messages"
+	case SearchPickaxe:
+		return "-S content"
+	case SearchDiffRegex:
+		return "-G changes"
+	case SearchLineRange:
+		return "-L lines"
+	}
+	return "loaded"
+}
+
+// next returns the mode tab switches to. Line range searches start from
+// the file browser, so the modal skips them.
+func (m HistorySearchMode) next() HistorySearchMode {
+	if m >= SearchDiffRegex {
+		return SearchLoaded
+	}
+	return m + 1
+}
+
+// HistoryQuery is a search git runs across the full history. Its results
+// replace the commit list like the author and path filters.
+type HistoryQuery struct {
+	Mode       HistorySearchMode
+	Text       string // Search text, or the -L argument for line ranges
+	Name       string // Shorter description of Text for the list header
+	Regex      bool
+	IgnoreCase bool
+}
+
+// apply adds the query to filter options.
+func (q *HistoryQuery) apply(opts *HistoryFilterOpts) {
+	if q == nil {
+		return
+	}
+	switch q.Mode {
+	case SearchMessages:
+		opts.Grep = q.Text
+	case SearchPickaxe:
+		opts.Pickaxe = q.Text
+	case SearchDiffRegex:
+		opts.DiffRegex = q.Text
+	case SearchLineRange:
+		opts.LineRange = q.Text
+	}
+	opts.Regex = q.Regex
+	opts.IgnoreCase = q.IgnoreCase
+}
+
+// Label describes the query for the commit list header.
+func (q *HistoryQuery) Label() string {
+	switch q.Mode {
+	case SearchMessages:
+		return "grep:" + q.Text
+	case SearchPickaxe:
+		return "-S:" + q.Text
+	case SearchDiffRegex:
+		return "-G:" + q.Text
+	case SearchLineRange:
+		if q.Name != "" {
+			return "-L:" + q.Name
+		}
+		return "-L:" + q.Text
+	}
+	return q.Text
+}
+
+// matchesContent reports whether q searches file contents rather than
+// commit messages, so matching files and hunks can be highlighted.
+func (q *HistoryQuery) matchesContent() bool {
+	return q != nil && (q.Mode == SearchPickaxe || q.Mode == SearchDiffRegex || q.Mode == SearchLineRange)
+}
+
+// lineMatcher returns a function reporting whether a changed line matches a
+// pickaxe or diff regex query, or nil for other queries.
+func (q *HistoryQuery) lineMatcher() func(string) bool {
+	if q == nil || (q.Mode != SearchPickaxe && q.Mode != SearchDiffRegex) {
+		return nil
+	}
+	if q.Mode == SearchDiffRegex || q.Regex {
+		expr := q.Text
+		if q.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil
+		}
+		return re.MatchString
+	}
+	if q.IgnoreCase {
+		text := strings.ToLower(q.Text)
+		return func(s string) bool { return strings.Contains(strings.ToLower(s), text) }
+	}
+	return func(s string) bool { return strings.Contains(s, q.Text) }
+}
+
+// LineRangeArg returns the -L argument for lines start..end of path, or for
+// the function funcName when it is set.
+func LineRangeArg(path string, start, end int, funcName string) string {
+	if funcName != "" {
+		// git takes the first function line matching the regex; requiring
+		// the parameter list (or type parameters) keeps "run" from matching
+		// "runAll"
+		return ":" + funcName + "[[(<]:" + path
+	}
+	if end < start {
+		start, end = end, start
+	}
+	return formatInt(start) + "," + formatInt(end) + ":" + path
+}
+
+// GetQueryMatchFiles returns the files of commit hash whose changes match a
+// pickaxe or diff regex search.
+func GetQueryMatchFiles(workDir, hash string, opts HistoryFilterOpts) ([]string, error) {
+	args := []string{"show", "--format=", "--name-only"}
+	args = append(args, opts.pickaxeArgs()...)
+	args = append(args, hash)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// historyFilterOpts returns the active history filters without paging.
+func (p *Plugin) historyFilterOpts() HistoryFilterOpts {
+	opts := HistoryFilterOpts{
+		Author: p.historyFilterAuthor,
+		Path:   p.historyFilterPath,
+	}
+	p.historyQuery.apply(&opts)
+	return opts
+}
+
+// runHistoryQuery replaces the commit list with the results of q.
+func (p *Plugin) runHistoryQuery(q *HistoryQuery) tea.Cmd {
+	p.historyQuery = q
+	p.historyFilterActive = true
+	if q.Mode == SearchLineRange {
+		// -L names its own file
+		p.historyFilterPath = ""
+	}
+	return p.loadFilteredCommits()
+}
+
+// clearHistoryFilters drops all history filters and searches, returning to
+// the full commit list.
+func (p *Plugin) clearHistoryFilters() {
+	p.historyFilterAuthor = ""
+	p.historyFilterPath = ""
+	p.historyQuery = nil
+	p.historyFilterActive = false
+	p.filteredCommits = nil
+	p.moreFilteredAvailable = false
+	p.previewMatchFiles = nil
+	// Recompute graph for unfiltered commits
+	if p.showCommitGraph {
+		p.updateCommitGraph(p.recentCommits)
+	}
+}
+
+// handleLineHistory shows the history of the lines picked in the file
+// browser.
+func (p *Plugin) handleLineHistory(msg filebrowser.LineHistoryMsg) tea.Cmd {
+	if p.inNoRepoMode() || msg.Path == "" {
+		return nil
+	}
+	p.historySearchMode = false
+	p.pathFilterMode = false
+	p.viewMode = ViewModeStatus
+	p.activePane = PaneSidebar
+	name := filepath.Base(msg.Path) + ":" + formatInt(msg.StartLine) + "-" + formatInt(msg.EndLine)
+	if msg.FuncName != "" {
+		name = msg.FuncName + "()"
+	}
+	return p.runHistoryQuery(&HistoryQuery{
+		Mode: SearchLineRange,
+		Text: LineRangeArg(msg.Path, msg.StartLine, msg.EndLine, msg.FuncName),
+		Name: name,
+	})
+}
+
+// queryMatchedFiles returns the files of c that match the active search.
+// Line range matches come from the diff captured by the search; others ask
+// git which files the pickaxe selects.
+func queryMatchedFiles(workDir, hash string, q *HistoryQuery, matchDiff string) map[string]bool {
+	if !q.matchesContent() {
+		return nil
+	}
+	matched := make(map[string]bool)
+	if q.Mode == SearchLineRange {
+		for _, f := range ParseMultiFileDiff(matchDiff).Files {
+			matched[f.FileName()] = true
+		}
+		return matched
+	}
+	var opts HistoryFilterOpts
+	q.apply(&opts)
+	files, err := GetQueryMatchFiles(workDir, hash, opts)
+	if err != nil {
+		return nil
+	}
+	for _, f := range files {
+		matched[f] = true
+	}
+	return matched
+}
+
+// markQueryHunks flags the hunks of a commit's diff for path that match the
+// active search. Line range searches flag hunks overlapping the tracked
+// lines; pickaxe and regex searches flag hunks with a matching changed line.
+func (p *Plugin) markQueryHunks(diff *ParsedDiff, path string) {
+	q := p.historyQuery
+	if diff == nil || !q.matchesContent() {
+		return
+	}
+	if q.Mode == SearchLineRange {
+		var ranges []Hunk
+		if c := p.findQueryCommit(p.diffCommit); c != nil {
+			for _, f := range ParseMultiFileDiff(c.MatchDiff).Files {
+				if f.FileName() == path && f.Diff != nil {
+					ranges = append(ranges, f.Diff.Hunks...)
+				}
+			}
+		}
+		for i := range diff.Hunks {
+			h := &diff.Hunks[i]
+			for _, r := range ranges {
+				if spansOverlap(h.NewStart, h.NewCount, r.NewStart, r.NewCount) {
+					h.Match = true
+					break
+				}
+			}
+		}
+		return
+	}
+	match := q.lineMatcher()
+	if match == nil {
+		return
+	}
+	for i := range diff.Hunks {
+		h := &diff.Hunks[i]
+		for _, line := range h.Lines {
+			if (line.Type.IsAdd() || line.Type.IsRemove()) && match(line.Content) {
+				h.Match = true
+				break
+			}
+		}
+	}
+}
+
+// spansOverlap reports whether two new-side hunk spans share a line. Pure
+// deletions still occupy the line they were removed at.
+func spansOverlap(startA, countA, startB, countB int) bool {
+	return startA < startB+max(countB, 1) && startB < startA+max(countA, 1)
+}
+
+// findQueryCommit returns the search result with the given hash.
+func (p *Plugin) findQueryCommit(hash string) *Commit {
+	if hash == "" {
+		return nil
+	}
+	for _, c := range p.filteredCommits {
+		if c.Hash == hash {
+			return c
+		}
+	}
+	return nil
+}
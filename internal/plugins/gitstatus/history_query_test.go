@@ -0,0 +1,178 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+)
+
+// newQueryRepo creates a repo whose main.go gains, renames and loses a
+// helper over four commits.
+func newQueryRepo(t *testing.T) string {
+	t.Helper()
+	dir, _ := initTestRepo(t)
+	commitFile(t, dir, "main.go", "package main\n\nfunc main() {\n}\n", "initial")
+	commitFile(t, dir, "main.go", "package main\n\nfunc main() {\n\thelper()\n}\n\nfunc helper() {\n}\n", "add helper")
+	commitFile(t, dir, "main.go", "package main\n\nfunc main() {\n\tHelper()\n}\n\nfunc Helper() {\n}\n", "export Helper")
+	commitFile(t, dir, "main.go", "package main\n\nfunc main() {\n}\n", "drop helper")
+	return dir
+}
+
+func commitSubjects(commits []*Commit) string {
+	var subjects []string
+	for _, c := range commits {
+		subjects = append(subjects, c.Subject)
+	}
+	return strings.Join(subjects, ",")
+}
+
+func TestGetCommitHistoryFiltered_Searches(t *testing.T) {
+	dir := newQueryRepo(t)
+
+	tests := []struct {
+		name string
+		opts HistoryFilterOpts
+		want string
+	}{
+		{"grep", HistoryFilterOpts{Grep: "helper"}, "drop helper,add helper"},
+		{"grep ignore case", HistoryFilterOpts{Grep: "helper", IgnoreCase: true}, "drop helper,export Helper,add helper"},
+		{"pickaxe", HistoryFilterOpts{Pickaxe: "helper()"}, "export Helper,add helper"},
+		{"pickaxe ignore case", HistoryFilterOpts{Pickaxe: "helper()", IgnoreCase: true}, "drop helper,add helper"},
+		{"diff regex", HistoryFilterOpts{DiffRegex: "[hH]elper\\(\\)"}, "drop helper,export Helper,add helper"},
+		{"paged", HistoryFilterOpts{DiffRegex: "elper", Limit: 1, Skip: 1}, "export Helper"},
+		{"function", HistoryFilterOpts{LineRange: LineRangeArg("main.go", 0, 0, "main")}, "drop helper,export Helper,add helper,initial"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := GetCommitHistoryFiltered(dir, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := commitSubjects(commits); got != tt.want {
+				t.Errorf("commits = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	commits, err := GetCommitHistoryFiltered(dir, HistoryFilterOpts{LineRange: "3,4:main.go", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || !strings.Contains(commits[0].MatchDiff, "-\tHelper()") {
+		t.Fatalf("line range commits = %+v", commits)
+	}
+
+	if _, err := GetCommitHistoryFiltered(dir, HistoryFilterOpts{LineRange: ":nosuch[[(<]:main.go"}); err == nil || !strings.Contains(err.Error(), "no match") {
+		t.Errorf("err = %v, want git's message", err)
+	}
+}
+
+func TestHistorySearch_FullHistoryMode(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.historySearchMode = true
+
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.historySearchState.Mode != SearchPickaxe {
+		t.Fatalf("mode = %v, want pickaxe", p.historySearchState.Mode)
+	}
+	for _, r := range "jk" {
+		p.Update(runeKey(string(r)))
+	}
+	if p.historySearchState.Query != "jk" || len(p.historySearchState.Matches) != 0 {
+		t.Errorf("j/k should type in a full history search, state = %+v", p.historySearchState)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || p.historySearchMode {
+		t.Fatal("enter should close the modal and run the search")
+	}
+	if !p.historyFilterActive || p.historyFilterOpts().Pickaxe != "jk" || !p.historyFilterOpts().IgnoreCase {
+		t.Errorf("filter opts = %+v", p.historyFilterOpts())
+	}
+
+	// A page loaded for different filters is dropped
+	p.Update(FilteredCommitsLoadedMsg{Commits: []*Commit{{Hash: "x"}}, Opts: HistoryFilterOpts{Grep: "jk"}})
+	if p.filteredCommits != nil {
+		t.Error("stale results should be ignored")
+	}
+
+	opts := p.historyFilterOpts()
+	opts.Limit = 2
+	p.Update(FilteredCommitsLoadedMsg{Commits: []*Commit{
+		{Hash: "aaa0000000", ShortHash: "aaa0000", Subject: "one"},
+		{Hash: "bbb0000000", ShortHash: "bbb0000", Subject: "two"},
+	}, Opts: opts})
+	if len(p.activeCommits()) != 2 || !p.hasMoreCommits() {
+		t.Fatalf("first page: %d commits, more = %v", len(p.activeCommits()), p.hasMoreCommits())
+	}
+	p.sidebarWidth = 40
+	y := 0
+	if !strings.Contains(p.renderRecentCommits(&y, 10), "-S:jk") {
+		t.Error("commit list header should show the search")
+	}
+
+	opts.Skip = 2
+	p.loadingMoreCommits = true
+	p.Update(FilteredCommitsLoadedMsg{Commits: []*Commit{{Hash: "ccc0000000", ShortHash: "ccc0000"}}, Opts: opts})
+	if len(p.activeCommits()) != 3 || p.hasMoreCommits() || p.loadingMoreCommits {
+		t.Errorf("after last page: %d commits, more = %v", len(p.activeCommits()), p.hasMoreCommits())
+	}
+
+	p.cursor = 0
+	p.Update(runeKey("F"))
+	if p.historyFilterActive || p.historyQuery != nil {
+		t.Error("F should clear the search")
+	}
+}
+
+func TestHandleLineHistory(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.historyFilterPath = "docs/"
+
+	if cmd := p.handleLineHistory(filebrowser.LineHistoryMsg{Path: "cmd/main.go", FuncName: "run"}); cmd == nil {
+		t.Fatal("expected the search to load")
+	}
+	opts := p.historyFilterOpts()
+	if opts.LineRange != ":run[[(<]:cmd/main.go" || opts.Path != "" {
+		t.Errorf("opts = %+v", opts)
+	}
+	if p.historyQuery.Label() != "-L:run()" {
+		t.Errorf("label = %q", p.historyQuery.Label())
+	}
+
+	if got := LineRangeArg("a.go", 20, 10, ""); got != "10,20:a.go" {
+		t.Errorf("LineRangeArg = %q", got)
+	}
+}
+
+func TestMarkQueryHunks(t *testing.T) {
+	diff := func() *ParsedDiff {
+		return &ParsedDiff{Hunks: []Hunk{
+			{NewStart: 1, NewCount: 3, Lines: []DiffLine{{Type: LineContext, Content: "helper()"}, {Type: LineAdd, Content: "other"}}},
+			{NewStart: 20, NewCount: 2, Lines: []DiffLine{{Type: LineRemove, Content: "\tHelper()"}}},
+		}}
+	}
+
+	p := newHistoryOpsPlugin(t)
+	p.historyQuery = &HistoryQuery{Mode: SearchPickaxe, Text: "helper()", IgnoreCase: true}
+	d := diff()
+	p.markQueryHunks(d, "main.go")
+	if d.Hunks[0].Match || !d.Hunks[1].Match {
+		t.Errorf("pickaxe should only mark hunks with a matching change, got %v %v", d.Hunks[0].Match, d.Hunks[1].Match)
+	}
+
+	p.historyQuery = &HistoryQuery{Mode: SearchLineRange, Text: "2,2:main.go"}
+	p.diffCommit = "abc"
+	p.filteredCommits = []*Commit{{Hash: "abc", MatchDiff: "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -2,1 +2,1 @@\n-a\n+b"}}
+	d = diff()
+	p.markQueryHunks(d, "main.go")
+	if !d.Hunks[0].Match || d.Hunks[1].Match {
+		t.Errorf("line range should mark the overlapping hunk, got %v %v", d.Hunks[0].Match, d.Hunks[1].Match)
+	}
+	if !strings.Contains(RenderLineDiff(d, 80, 0, 10, 0, nil, false), "@@ -0,0 +1,3 @@") {
+		t.Error("matched hunk header should still render")
+	}
+}
@@ -19,6 +19,7 @@ type HistorySearchState struct {
 	// Search options
 	UseRegex      bool
 	CaseSensitive bool
+	Mode          HistorySearchMode // What to search; modes past SearchLoaded run git on enter
 }
 
 // NewHistorySearchState creates a new search state.
@@ -36,9 +37,10 @@ func (s *HistorySearchState) Reset() {
 	s.Committed = false
 }
 
-// searchCommits filters commits by query (message or author).
+// searchCommits filters commits by query (message or author). Only the
+// loaded mode filters as you type; the others search when run.
 func (p *Plugin) searchCommits(query string, useRegex, caseSensitive bool) []*Commit {
-	if query == "" {
+	if query == "" || (p.historySearchState != nil && p.historySearchState.Mode != SearchLoaded) {
 		return nil
 	}
 
@@ -127,6 +129,7 @@ func (p *Plugin) renderHistorySearchModal(width int) string {
 
 	// Options bar
 	var opts []string
+	opts = append(opts, styles.BarChipActive.Render(state.Mode.Label()))
 	if state.UseRegex {
 		opts = append(opts, styles.BarChipActive.Render(".*"))
 	} else {
@@ -141,7 +144,10 @@ func (p *Plugin) renderHistorySearchModal(width int) string {
 	sb.WriteString("\n\n")
 
 	// Status line
-	if state.Query == "" {
+	if state.Mode != SearchLoaded {
+		sb.WriteString(styles.Muted.Render(historySearchModeHelp(state.Mode)))
+		sb.WriteString("\n")
+	} else if state.Query == "" {
 		sb.WriteString(styles.Muted.Render("Type to search commits..."))
 		sb.WriteString("\n")
 	} else if len(state.Matches) == 0 {
@@ -210,12 +216,29 @@ func (p *Plugin) renderHistorySearchModal(width int) string {
 
 	sb.WriteString("\n")
 	// Hint
-	sb.WriteString(styles.Muted.Render("j/k nav · enter select · alt+r regex · esc cancel"))
+	if state.Mode != SearchLoaded {
+		sb.WriteString(styles.Muted.Render("enter search · tab mode · alt+r regex · alt+c case · esc cancel"))
+	} else {
+		sb.WriteString(styles.Muted.Render("j/k nav · enter select · tab mode · alt+r regex · esc cancel"))
+	}
 
 	content := sb.String()
 	return styles.ModalBox.Width(modalWidth).Render(content)
 }
 
+// historySearchModeHelp explains what a full history search matches.
+func historySearchModeHelp(mode HistorySearchMode) string {
+	switch mode {
+	case SearchMessages:
+		return "Search commit messages across all history (--grep)"
+	case SearchPickaxe:
+		return "Find commits adding or removing the text (-S)"
+	case SearchDiffRegex:
+		return "Find commits changing lines that match the regex (-G)"
+	}
+	return ""
+}
+
 // formatInt converts int to string without importing strconv in view logic.
 func formatInt(n int) string {
 	if n == 0 {
@@ -243,6 +266,12 @@ func (p *Plugin) updateHistorySearch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 
 	key := msg.String()
 
+	// Full history searches have no match list to navigate
+	if state.Mode != SearchLoaded && (key == "j" || key == "k") {
+		state.Query += key
+		return p, nil
+	}
+
 	switch key {
 	case "esc":
 		// Cancel search, close modal, and clear search state
@@ -251,6 +280,21 @@ func (p *Plugin) updateHistorySearch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		return p, nil
 
 	case "enter":
+		if state.Mode != SearchLoaded {
+			// Run the search across the full history
+			p.historySearchMode = false
+			if state.Query == "" {
+				return p, nil
+			}
+			q := &HistoryQuery{
+				Mode:       state.Mode,
+				Text:       state.Query,
+				Regex:      state.UseRegex,
+				IgnoreCase: !state.CaseSensitive,
+			}
+			p.clearSearchState()
+			return p, p.runHistoryQuery(q)
+		}
 		// Select current match and jump to it
 		if len(state.Matches) > 0 {
 			state.Committed = true
@@ -262,6 +306,12 @@ func (p *Plugin) updateHistorySearch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		p.clearSearchState()
 		return p, nil
 
+	case "tab":
+		state.Mode = state.Mode.next()
+		state.Matches = p.searchCommits(state.Query, state.UseRegex, state.CaseSensitive)
+		state.Cursor = 0
+		return p, nil
+
 	case "j", "down", "ctrl+n":
 		// Navigate down in matches
 		if len(state.Matches) > 0 {
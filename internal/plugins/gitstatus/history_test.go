@@ -226,7 +226,7 @@ func TestGetCommitDiff_EmptyParentHash(t *testing.T) {
 	}
 	filePath := strings.Split(strings.TrimSpace(string(filesOut)), "\n")[0]
 
-	diff, err := GetCommitDiff(workDir, hash, filePath, "")
+	diff, err := GetCommitDiff(workDir, hash, filePath, "", DiffOptions{})
 	if err != nil {
 		t.Fatalf("GetCommitDiff(%q, %q, \"\"): %v", hash, filePath, err)
 	}
@@ -256,7 +256,7 @@ func TestGetCommitDiff_WithParentHash(t *testing.T) {
 	}
 	filePath := strings.Split(strings.TrimSpace(string(filesOut)), "\n")[0]
 
-	diff, err := GetCommitDiff(workDir, hash, filePath, parentHash)
+	diff, err := GetCommitDiff(workDir, hash, filePath, parentHash, DiffOptions{})
 	if err != nil {
 		t.Fatalf("GetCommitDiff(%q, %q, %q): %v", hash, filePath, parentHash, err)
 	}
@@ -274,7 +274,7 @@ func TestGetCommitDiff_NonExistentPath(t *testing.T) {
 	}
 	hash := strings.TrimSpace(string(out))
 
-	diff, err := GetCommitDiff(workDir, hash, "nonexistent/path/that/does/not/exist.xyz", "")
+	diff, err := GetCommitDiff(workDir, hash, "nonexistent/path/that/does/not/exist.xyz", "", DiffOptions{})
 	if err != nil {
 		t.Fatalf("GetCommitDiff with non-existent path returned error: %v", err)
 	}
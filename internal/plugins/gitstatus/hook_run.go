@@ -0,0 +1,490 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/mouse"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// Operations whose hooks stream into the output panel.
+const (
+	hookOpCommit = "commit"
+	hookOpPush   = "push"
+)
+
+const (
+	hookOutputLines  = 5000 // Output lines kept in the panel
+	hookProblemsRows = 6    // Problem rows shown before the list scrolls
+)
+
+// HookOutputMsg carries lines printed by git or its hooks.
+type HookOutputMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Lines []string
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m HookOutputMsg) GetEpoch() uint64 { return m.Epoch }
+
+// HookRunDoneMsg is sent when a streamed commit or push returns. Result is
+// the message the operation reports without the panel: a CommitSuccessMsg,
+// CommitErrorMsg, PushSuccessMsg or PushErrorMsg.
+type HookRunDoneMsg struct {
+	Epoch  uint64 // Epoch when request was issued (for stale detection)
+	Result tea.Msg
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m HookRunDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// HookRunTickMsg redraws the elapsed time of a hook run.
+type HookRunTickMsg struct{}
+
+// runHooked runs an operation that may trigger the named hooks. When none
+// is installed, run reports straight back as before; otherwise its output
+// streams into the hook output panel, where it can be cancelled.
+func (p *Plugin) runHooked(op string, hooks []string, run func(s *Stream) tea.Msg) tea.Cmd {
+	if !HasHook(p.repoRoot, hooks...) {
+		return func() tea.Msg { return run(nil) }
+	}
+	if p.hookRunCancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, 64)
+	if p.viewMode != ViewModeHookRun {
+		p.hookReturnMode = p.viewMode
+	}
+	p.viewMode = ViewModeHookRun
+	p.hookOp = op
+	p.hookRunCancel = cancel
+	p.hookRunEvents = events
+	p.hookRunStart = time.Now()
+	p.hookRunElapsed = 0
+	p.hookOutput = nil
+	p.hookScroll = 0
+	p.hookErr = ""
+	p.hookCancelled = false
+	p.hookLocations = nil
+	p.hookCursor = 0
+	p.hookConfirm = false
+
+	epoch := p.ctx.Epoch
+	go func() {
+		defer close(events)
+		w := &runOutputWriter{ctx: ctx, events: events, wrap: func(lines []string) tea.Msg {
+			return HookOutputMsg{Epoch: epoch, Lines: lines}
+		}}
+		result := run(&Stream{Ctx: ctx, Out: w})
+		w.flush()
+		events <- HookRunDoneMsg{Epoch: epoch, Result: result}
+	}()
+	return tea.Batch(waitRunEvents(events), hookRunTick())
+}
+
+// commitWithHooks commits message with opts, remembering both so the
+// commit can be retried from the panel.
+func (p *Plugin) commitWithHooks(message string, opts CommitOptions) tea.Cmd {
+	workDir := p.repoRoot
+	p.hookCommitMessage = message
+	p.hookCommitOpts = opts
+	p.hookRetry = func() tea.Cmd { return p.commitWithHooks(message, opts) }
+	return p.runHooked(hookOpCommit, CommitHooks, func(s *Stream) tea.Msg {
+		var point *UndoPoint
+		var undoErr error
+		if opts.Amend {
+			point, undoErr = CaptureAmendUndo(workDir)
+		}
+		hash, err := StreamCommit(s, workDir, message, opts)
+		if err != nil {
+			return CommitErrorMsg{Err: err}
+		}
+		// Extract first line as subject
+		subject := strings.Split(message, "\n")[0]
+		return recordUndo(workDir, point, undoErr, CommitSuccessMsg{Hash: hash, Subject: subject})
+	})
+}
+
+// pushWithHooks pushes with opts in the background. capture, if set,
+// captures an undo point before the push, recorded once it succeeds.
+func (p *Plugin) pushWithHooks(opts PushOptions, capture func(workDir string) (*UndoPoint, error)) tea.Cmd {
+	workDir := p.repoRoot
+	p.hookRetry = func() tea.Cmd { return p.pushWithHooks(opts, capture) }
+	return p.runHooked(hookOpPush, PushHooks, func(s *Stream) tea.Msg {
+		var point *UndoPoint
+		var undoErr error
+		if capture != nil {
+			point, undoErr = capture(workDir)
+		}
+		output, err := StreamPush(s, workDir, opts)
+		if err != nil {
+			return PushErrorMsg{Err: err}
+		}
+		return recordUndo(workDir, point, undoErr, PushSuccessMsg{Output: output})
+	})
+}
+
+// hookRunTick schedules the next elapsed time redraw.
+func hookRunTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return HookRunTickMsg{}
+	})
+}
+
+// handleHookOutput appends streamed lines and waits for more.
+func (p *Plugin) handleHookOutput(msg HookOutputMsg) tea.Cmd {
+	p.hookOutput = append(p.hookOutput, msg.Lines...)
+	if n := len(p.hookOutput); n > hookOutputLines {
+		p.hookOutput = p.hookOutput[n-hookOutputLines:]
+	}
+	if p.hookScroll > 0 {
+		// Keep a scrolled-back view on the same lines
+		p.hookScroll += len(msg.Lines)
+	}
+	return waitRunEvents(p.hookRunEvents)
+}
+
+// handleHookRunTick keeps the elapsed time ticking while a run lasts.
+func (p *Plugin) handleHookRunTick() tea.Cmd {
+	if p.hookRunCancel == nil {
+		return nil
+	}
+	return hookRunTick()
+}
+
+// handleHookRunDone closes the panel and reports success as usual, or
+// keeps the output on screen with the problems found in it.
+func (p *Plugin) handleHookRunDone(msg HookRunDoneMsg) (plugin.Plugin, tea.Cmd) {
+	if p.hookRunCancel != nil {
+		p.hookRunCancel()
+	}
+	p.hookRunCancel = nil
+	p.hookRunEvents = nil
+	p.hookRunElapsed = time.Since(p.hookRunStart)
+
+	var err error
+	switch r := msg.Result.(type) {
+	case CommitErrorMsg:
+		err = r.Err
+		p.commitInProgress = false
+	case PushErrorMsg:
+		err = r.Err
+		p.pushInProgress = false
+		p.pushPreservedCommitHash = ""
+	}
+	var sigErr *SigningError
+	switch {
+	case err == nil, errors.As(err, &sigErr):
+		// Success and signing failures take their usual route
+		p.viewMode = p.hookReturnMode
+		return p.Update(msg.Result)
+	case errors.Is(err, context.Canceled):
+		p.hookCancelled = true
+		p.hookLocations = FindFileLocations(p.repoRoot, p.hookOutput)
+		return p, tea.Batch(p.refresh(), appmsg.ShowToast("Cancelled git "+p.hookOp, 2*time.Second))
+	default:
+		p.hookErr = err.Error()
+		if p.hookOp == hookOpCommit {
+			p.commitError = "Commit hooks failed"
+		} else {
+			p.pushError = err.Error()
+		}
+	}
+	p.hookLocations = FindFileLocations(p.repoRoot, p.hookOutput)
+	p.hookCursor = 0
+	if len(p.hookLocations) > 0 {
+		p.scrollHookToLocation()
+	}
+	return p, tea.Batch(p.refresh(), p.loadRecentCommits())
+}
+
+// closeHookRun leaves the finished panel for the view it was opened from:
+// the commit modal, with its message, for a commit.
+func (p *Plugin) closeHookRun() {
+	p.viewMode = p.hookReturnMode
+	p.hookOutput = nil
+	p.hookLocations = nil
+	p.hookConfirm = false
+	p.hookRetry = nil
+}
+
+// updateHookRun handles key events in the hook output panel.
+func (p *Plugin) updateHookRun(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	key := msg.String()
+	if p.hookRunCancel != nil {
+		switch key {
+		case "x":
+			p.hookRunCancel()
+		case "ctrl+u", "K":
+			p.scrollHookOutput(p.hookOutputHeight() / 2)
+		case "ctrl+d", "J":
+			p.scrollHookOutput(-p.hookOutputHeight() / 2)
+		}
+		return p, nil
+	}
+
+	// Skipping the hooks is asked once more before it happens
+	if p.hookConfirm {
+		p.hookConfirm = false
+		if key == "y" {
+			opts := p.hookCommitOpts
+			opts.NoVerify = true
+			p.commitInProgress = true
+			p.commitError = ""
+			return p, p.commitWithHooks(p.hookCommitMessage, opts)
+		}
+		return p, nil
+	}
+
+	switch key {
+	case "esc", "q":
+		p.closeHookRun()
+	case "j", "down":
+		p.moveHookCursor(p.hookCursor + 1)
+	case "k", "up":
+		p.moveHookCursor(p.hookCursor - 1)
+	case "ctrl+u", "K":
+		p.scrollHookOutput(p.hookOutputHeight() / 2)
+	case "ctrl+d", "J":
+		p.scrollHookOutput(-p.hookOutputHeight() / 2)
+	case "enter", "o":
+		return p, p.openHookLocation(p.hookCursor)
+	case "r":
+		if p.hookRetry != nil {
+			if p.hookOp == hookOpCommit {
+				p.commitInProgress = true
+			} else {
+				p.pushInProgress = true
+			}
+			return p, p.hookRetry()
+		}
+	case "n":
+		if p.hookOp == hookOpCommit && !p.hookCommitOpts.NoVerify {
+			p.hookConfirm = true
+		}
+	}
+	return p, nil
+}
+
+// moveHookCursor selects the problem at idx and scrolls the output to it.
+func (p *Plugin) moveHookCursor(idx int) {
+	if len(p.hookLocations) == 0 {
+		return
+	}
+	p.hookCursor = max(0, min(idx, len(p.hookLocations)-1))
+	p.scrollHookToLocation()
+}
+
+// scrollHookToLocation brings the output line of the selected problem into
+// view.
+func (p *Plugin) scrollHookToLocation() {
+	loc := p.hookLocations[p.hookCursor]
+	height := p.hookOutputHeight()
+	end := len(p.hookOutput) - p.hookScroll // One past the last line shown
+	if loc.Output >= end || loc.Output < end-height {
+		p.hookScroll = max(0, len(p.hookOutput)-loc.Output-height/2)
+		p.scrollHookOutput(0)
+	}
+}
+
+// scrollHookOutput scrolls the output back by delta lines; 0 keeps the
+// tail in view as lines arrive.
+func (p *Plugin) scrollHookOutput(delta int) {
+	p.hookScroll += delta
+	p.hookScroll = max(0, min(p.hookScroll, len(p.hookOutput)-p.hookOutputHeight()))
+}
+
+// openHookLocation opens a problem's file at its line in the file browser.
+func (p *Plugin) openHookLocation(idx int) tea.Cmd {
+	if idx < 0 || idx >= len(p.hookLocations) {
+		return nil
+	}
+	loc := p.hookLocations[idx]
+	return tea.Batch(
+		app.FocusPlugin("file-browser"),
+		func() tea.Msg {
+			return filebrowser.NavigateToFileMsg{Path: loc.Path, Edit: true, LineNo: loc.Line - 1}
+		},
+	)
+}
+
+// handleHookRunMouse handles mouse events in the hook output panel.
+func (p *Plugin) handleHookRunMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	action := p.mouseHandler.HandleMouse(msg)
+	switch action.Type {
+	case mouse.ActionClick:
+		if action.Region == nil {
+			return p, nil
+		}
+		switch action.Region.ID {
+		case regionHookBack:
+			if p.hookRunCancel == nil {
+				p.closeHookRun()
+			}
+		case regionHookLocation:
+			if idx, ok := action.Region.Data.(int); ok {
+				p.moveHookCursor(idx)
+				return p, p.openHookLocation(idx)
+			}
+		}
+	case mouse.ActionScrollUp, mouse.ActionScrollDown:
+		p.scrollHookOutput(-action.Delta)
+	}
+	return p, nil
+}
+
+// hookProblemRows returns the rows given to the problem list.
+func (p *Plugin) hookProblemRows() int {
+	if len(p.hookLocations) == 0 {
+		return 0
+	}
+	return min(len(p.hookLocations), hookProblemsRows)
+}
+
+// hookOutputHeight returns the rows available to the output.
+func (p *Plugin) hookOutputHeight() int {
+	// Breadcrumb, status, separator and footer, plus the problems' heading
+	// and separator when there are any
+	rows := p.height - 2 - 4 - p.hookProblemRows()
+	if p.hookProblemRows() > 0 {
+		rows -= 2
+	}
+	return max(1, rows)
+}
+
+// renderHookRun renders the full-screen hook output panel: the streamed
+// output, then the file:line problems found in it once the run failed.
+func (p *Plugin) renderHookRun() string {
+	paneHeight := p.height - 2
+	contentWidth := max(20, p.width-4)
+	p.mouseHandler.Clear()
+	p.mouseHandler.HitMap.AddRect(regionHookRun, 0, 0, p.width, p.height, nil)
+
+	var sb strings.Builder
+	back := styles.Link.Render("← Back")
+	p.mouseHandler.HitMap.AddRect(regionHookBack, 2, 1, lipgloss.Width(back), 1, nil)
+	title := "Commit Hooks"
+	if p.hookOp == hookOpPush {
+		title = "Push Hooks"
+	}
+	sb.WriteString(back + styles.Muted.Render(" · ") + styles.Title.Render(title))
+	sb.WriteString("\n")
+	sb.WriteString(p.renderHookStatus(contentWidth))
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render(strings.Repeat("━", contentWidth)))
+	sb.WriteString("\n")
+
+	height := p.hookOutputHeight()
+	end := len(p.hookOutput) - p.hookScroll
+	start := max(0, end-height)
+	selected := -1
+	if len(p.hookLocations) > 0 {
+		selected = p.hookLocations[p.hookCursor].Output
+	}
+	var lines []string
+	for i := start; i < end; i++ {
+		line := ui.TruncateString(strings.ReplaceAll(p.hookOutput[i], "\t", "    "), contentWidth)
+		if i == selected {
+			lines = append(lines, styles.ListItemSelected.Render(line))
+			continue
+		}
+		lines = append(lines, styles.Body.Render(line))
+	}
+	if len(lines) == 0 && p.hookRunCancel != nil {
+		lines = append(lines, styles.Muted.Render("Waiting for output..."))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	sb.WriteString(strings.Join(lines, "\n"))
+	sb.WriteString("\n")
+
+	if rows := p.hookProblemRows(); rows > 0 {
+		y := 3 + height + 1
+		sb.WriteString(styles.Muted.Render(strings.Repeat("━", contentWidth)))
+		sb.WriteString("\n")
+		sb.WriteString(styles.Title.Render(fmt.Sprintf("Problems (%d)", len(p.hookLocations))))
+		sb.WriteString("\n")
+		first := 0
+		if p.hookCursor >= rows {
+			first = p.hookCursor - rows + 1
+		}
+		for i := first; i < first+rows; i++ {
+			sb.WriteString(renderHookLocation(p.hookLocations[i], contentWidth, i == p.hookCursor))
+			sb.WriteString("\n")
+			p.mouseHandler.HitMap.AddRect(regionHookLocation, 2, y+2+i-first, contentWidth, 1, i)
+		}
+	}
+	sb.WriteString(p.renderHookFooter(contentWidth))
+	return p.wrapDiffContent(sb.String(), paneHeight)
+}
+
+// renderHookStatus shows whether the run is going, failed or was
+// cancelled, and for how long it ran.
+func (p *Plugin) renderHookStatus(width int) string {
+	verb := "git commit"
+	if p.hookOp == hookOpPush {
+		verb = "git push"
+	} else if p.hookCommitOpts.NoVerify {
+		verb = "git commit --no-verify"
+	}
+	switch {
+	case p.hookRunCancel != nil:
+		elapsed := time.Since(p.hookRunStart).Truncate(time.Second)
+		return styles.StatusInProgress.Render(ui.TruncateString("Running "+verb+" · "+elapsed.String(), width))
+	case p.hookCancelled:
+		return styles.StatusModified.Render(ui.TruncateString("Cancelled "+verb+" after "+p.hookRunElapsed.Truncate(time.Second).String(), width))
+	}
+	status := verb + " failed after " + p.hookRunElapsed.Truncate(time.Second).String()
+	if first, _, _ := strings.Cut(strings.TrimSpace(p.hookErr), "\n"); first != "" && len(p.hookOutput) == 0 {
+		status += ": " + first
+	}
+	return styles.StatusDeleted.Render(ui.TruncateString(status, width))
+}
+
+// renderHookLocation renders one problem: its file and line, then the
+// output line it came from.
+func renderHookLocation(loc FileLocation, width int, selected bool) string {
+	where := fmt.Sprintf("%s:%d", loc.Path, loc.Line)
+	text := ui.TruncateString(loc.Text, max(0, width-lipgloss.Width(where)-2))
+	if selected {
+		line := where + "  " + text
+		if w := lipgloss.Width(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	return styles.Link.Render(where) + "  " + styles.Muted.Render(text)
+}
+
+// renderHookFooter shows the pending confirmation or the key hints.
+func (p *Plugin) renderHookFooter(width int) string {
+	switch {
+	case p.hookRunCancel != nil:
+		return styles.Muted.Render(ui.TruncateString("x cancel · ctrl+u/ctrl+d scroll", width))
+	case p.hookConfirm:
+		return styles.StatusDeleted.Render(ui.TruncateString("Commit without running the pre-commit and commit-msg hooks? y to confirm, any key to cancel", width))
+	}
+	hints := []string{}
+	if len(p.hookLocations) > 0 {
+		hints = append(hints, "j/k problem · enter open")
+	}
+	hints = append(hints, "r retry")
+	if p.hookOp == hookOpCommit && !p.hookCommitOpts.NoVerify {
+		hints = append(hints, "n commit --no-verify")
+	}
+	hints = append(hints, "esc close")
+	return styles.Muted.Render(ui.TruncateString(strings.Join(hints, " · "), width))
+}
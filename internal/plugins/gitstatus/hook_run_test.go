@@ -0,0 +1,121 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+)
+
+// newHookPlugin returns a plugin on a repo with a change to a.txt staged
+// and the given pre-commit hook installed.
+func newHookPlugin(t *testing.T, hook string) (*Plugin, func(args ...string) string) {
+	t.Helper()
+	p, git := newRepoPlugin(t)
+	path := filepath.Join(p.repoRoot, ".git", "hooks", "pre-commit")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+hook), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, p.repoRoot, "a.txt", "a \n")
+	git("add", "a.txt")
+	p.Update(p.refresh()())
+	p.initCommitTextarea()
+	p.viewMode = ViewModeCommit
+	return p, git
+}
+
+// drainHookRun feeds the run's output to the plugin until it finishes.
+func drainHookRun(p *Plugin) {
+	for p.hookRunCancel != nil {
+		p.Update(waitRunEvents(p.hookRunEvents)())
+	}
+}
+
+func TestHookRun_FailureLinksAndNoVerify(t *testing.T) {
+	p, git := newHookPlugin(t, "echo 'a.txt:1: trailing whitespace'\nexit 1\n")
+
+	cmd := p.doCommit("lint me")
+	if cmd == nil || p.viewMode != ViewModeHookRun || p.FocusContext() != keymap.ContextGitHookOutput {
+		t.Fatal("a commit with hooks should open the hook output")
+	}
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil || p.viewMode != ViewModeHookRun {
+		t.Fatal("esc should not leave a running hook")
+	}
+	drainHookRun(p)
+	if p.viewMode != ViewModeHookRun || p.commitInProgress || p.hookErr == "" {
+		t.Fatalf("a failed hook should keep its output open, err = %q", p.hookErr)
+	}
+	if len(p.hookLocations) != 1 || p.hookLocations[0].Path != "a.txt" || p.hookLocations[0].Line != 1 {
+		t.Fatalf("locations = %+v", p.hookLocations)
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "trailing whitespace") || !strings.Contains(view, "Problems (1)") {
+		t.Errorf("panel should show the output and its problems:\n%s", view)
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should open the problem")
+	}
+	var nav *filebrowser.NavigateToFileMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if m, ok := c().(filebrowser.NavigateToFileMsg); ok {
+			nav = &m
+		}
+	}
+	if nav == nil || nav.Path != "a.txt" || !nav.Edit || nav.LineNo != 0 {
+		t.Fatalf("navigate = %+v", nav)
+	}
+
+	p.Update(runeKey("n"))
+	if !p.hookConfirm {
+		t.Fatal("n should ask before skipping hooks")
+	}
+	_, cmd = p.Update(runeKey("y"))
+	if cmd == nil || !p.hookCommitOpts.NoVerify {
+		t.Fatal("y should commit with --no-verify")
+	}
+	drainHookRun(p)
+	if p.viewMode != ViewModeStatus {
+		t.Errorf("a successful commit should close the panel, mode = %v", p.viewMode)
+	}
+	if got := strings.TrimSpace(git("log", "-1", "--format=%s")); got != "lint me" {
+		t.Errorf("subject = %q", got)
+	}
+}
+
+func TestHookRun_Cancel(t *testing.T) {
+	p, git := newHookPlugin(t, "echo started\nsleep 30\n")
+
+	p.doCommit("slow")
+	for len(p.hookOutput) == 0 {
+		p.Update(waitRunEvents(p.hookRunEvents)())
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "Running git commit") {
+		t.Errorf("status should show the running commit:\n%s", view)
+	}
+	p.Update(runeKey("x"))
+	drainHookRun(p)
+	if !p.hookCancelled || p.commitInProgress {
+		t.Fatal("x should cancel the commit")
+	}
+	if got := strings.TrimSpace(git("log", "-1", "--format=%s")); got != "init" {
+		t.Errorf("cancelled commit landed: %q", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeCommit {
+		t.Error("esc should return to the commit modal")
+	}
+}
+
+func TestHookRun_NoHooksSkipsPanel(t *testing.T) {
+	p, _ := newRepoPlugin(t)
+	p.initCommitTextarea()
+	p.viewMode = ViewModeCommit
+	if cmd := p.doCommit("plain"); cmd == nil || p.viewMode != ViewModeCommit {
+		t.Error("without hooks the commit should run as before")
+	}
+}
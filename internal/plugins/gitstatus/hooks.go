@@ -0,0 +1,17 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export hook types from internal/git.
+type (
+	Stream       = git.Stream
+	FileLocation = git.FileLocation
+)
+
+// Re-export hook functions and hook names.
+var (
+	HasHook           = git.HasHook
+	FindFileLocations = git.FindFileLocations
+	CommitHooks       = git.CommitHooks
+	PushHooks         = git.PushHooks
+)
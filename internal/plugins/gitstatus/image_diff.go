@@ -0,0 +1,221 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/image"
+	"github.com/guyghost/sidecar/internal/styles"
+)
+
+const (
+	imageDiffGap         = 2  // Columns between the two images
+	imageDiffMinColWidth = 16 // Narrowest column worth drawing an image in
+	imageDiffMinHeight   = 3  // Fewest rows worth drawing an image in
+
+	// imageDiffCacheLimit bounds the image versions kept on disk, in bytes
+	imageDiffCacheLimit = 64 << 20
+)
+
+// imageDiffCache is a plugin's directory for versions of images read from
+// git. It is created private to the user when first needed, kept within
+// imageDiffCacheLimit, and removed on Stop. It is safe for concurrent use.
+type imageDiffCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// Dir returns the directory, creating it if needed, or "" if it cannot be
+// created.
+func (c *imageDiffCache) Dir() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir == "" {
+		dir, err := os.MkdirTemp("", "sidecar-image-diff-")
+		if err != nil {
+			return ""
+		}
+		c.dir = dir
+	}
+	return c.dir
+}
+
+// Remove deletes the directory and everything in it.
+func (c *imageDiffCache) Remove() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir != "" {
+		_ = os.RemoveAll(c.dir)
+		c.dir = ""
+	}
+}
+
+// imageCache returns the plugin's image version cache.
+func (p *Plugin) imageCache() *imageDiffCache {
+	if p.imageDiffCache == nil {
+		p.imageDiffCache = &imageDiffCache{}
+	}
+	return p.imageDiffCache
+}
+
+// imageSide is one version of an image in an image diff.
+type imageSide struct {
+	Path string // File to render: the worktree file or a cached blob
+	Info *image.Info
+}
+
+// ImageDiff holds both versions of a changed image. Old is nil for a new
+// image, New for a deleted one.
+type ImageDiff struct {
+	Old *imageSide
+	New *imageSide
+}
+
+// wantsImageDiff reports whether the diff of path is a binary image change.
+func wantsImageDiff(path string, status FileStatus, parsed *ParsedDiff) bool {
+	if parsed == nil || parsed.LFS != nil || !image.IsImageFile(path) {
+		return false
+	}
+	// Untracked files get a made-up diff that is not marked binary
+	return parsed.Binary || (status == StatusUntracked && len(parsed.Hunks) == 0)
+}
+
+// loadImageDiff reads both versions of the image at path: the index and
+// the worktree for unstaged changes, HEAD and the index for staged ones.
+// Versions from git are written to cache. It returns nil when neither can
+// be read.
+func loadImageDiff(workDir string, cache *imageDiffCache, path string, staged bool, status FileStatus) *ImageDiff {
+	fromFile := func(file string) *imageSide {
+		info, err := image.ReadInfo(file)
+		if err != nil {
+			return nil
+		}
+		return &imageSide{Path: file, Info: info}
+	}
+	fromGit := func(rev string) *imageSide {
+		dir := cache.Dir()
+		if dir == "" {
+			return nil
+		}
+		// Make room before adding; the versions about to be shown stay
+		_ = PruneBlobCache(dir, imageDiffCacheLimit)
+		file, err := CacheBlob(workDir, dir, rev, path)
+		if err != nil {
+			return nil
+		}
+		return fromFile(file)
+	}
+
+	d := &ImageDiff{}
+	switch {
+	case staged:
+		d.Old, d.New = fromGit("HEAD"), fromGit("")
+	case status == StatusUntracked:
+		d.New = fromFile(filepath.Join(workDir, path))
+	default:
+		d.Old, d.New = fromGit(""), fromFile(filepath.Join(workDir, path))
+	}
+	if d.Old == nil && d.New == nil {
+		return nil
+	}
+	return d
+}
+
+// renderImageDiff renders the two versions of an image side by side with
+// their dimensions and sizes. Without a terminal graphics protocol, or
+// without room for the images, only the metadata is compared.
+func (p *Plugin) renderImageDiff(d *ImageDiff, width, height int) string {
+	lines := []string{styles.Title.Render(" Image"), ""}
+	delta := imageDiffDelta(d)
+
+	graphics := p.imageRenderer != nil && p.imageRenderer.Protocol() != image.ProtocolNone
+	colWidth := (width - imageDiffGap) / 2
+	imgHeight := height - len(lines) - 2 - len(delta) // Column header and blank line
+	if graphics && colWidth >= imageDiffMinColWidth && imgHeight >= imageDiffMinHeight {
+		left := p.renderImageColumn("Before", d.Old, colWidth, imgHeight)
+		right := p.renderImageColumn("After", d.New, colWidth, imgHeight)
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Repeat(" ", imageDiffGap), right), "")
+		lines = append(lines, delta...)
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines,
+		styles.DiffRemove.Render(truncateLine(" - Before  "+describeImageSide(d.Old), width)),
+		styles.DiffAdd.Render(truncateLine(" + After   "+describeImageSide(d.New), width)),
+		"",
+	)
+	lines = append(lines, delta...)
+	if !graphics {
+		lines = append(lines, "", styles.Muted.Render(truncateLine(" No terminal graphics support; comparing metadata only", width)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderImageColumn renders one version of an image under a header with
+// its dimensions and size, padded to width.
+func (p *Plugin) renderImageColumn(label string, side *imageSide, width, height int) string {
+	header := styles.Subtitle.Render(truncateLine(" "+label+" · "+describeImageSide(side), width))
+	body := styles.Muted.Render(" (none)")
+	if side != nil {
+		result, err := p.imageRenderer.Render(side.Path, width, height)
+		switch {
+		case err != nil:
+			body = styles.Muted.Render(truncateLine(" Image error: "+err.Error(), width))
+		case result.IsFallback:
+			body = styles.Muted.Render(truncateLine(" "+result.Content, width))
+		default:
+			body = result.Content
+		}
+	}
+	return lipgloss.NewStyle().Width(width).Render(header + "\n" + body)
+}
+
+// describeImageSide formats an image's dimensions, format and size.
+func describeImageSide(side *imageSide) string {
+	if side == nil {
+		return "(none)"
+	}
+	info := side.Info
+	if !info.HasDimensions() {
+		return formatBlobSize(info.Size)
+	}
+	return fmt.Sprintf("%s %s, %s", formatImageDimensions(info), strings.ToUpper(info.Format), formatBlobSize(info.Size))
+}
+
+// formatImageDimensions formats an image's width and height as W×H.
+func formatImageDimensions(info *image.Info) string {
+	return fmt.Sprintf("%d×%d", info.Width, info.Height)
+}
+
+// imageDiffDelta describes how the dimensions and size of an image changed.
+func imageDiffDelta(d *ImageDiff) []string {
+	switch {
+	case d.Old == nil:
+		return []string{styles.Muted.Render(" New image")}
+	case d.New == nil:
+		return []string{styles.Muted.Render(" Deleted")}
+	}
+	var lines []string
+	oldInfo, newInfo := d.Old.Info, d.New.Info
+	if oldInfo.HasDimensions() && newInfo.HasDimensions() {
+		if oldInfo.Width == newInfo.Width && oldInfo.Height == newInfo.Height {
+			lines = append(lines, styles.Muted.Render(" Dimensions unchanged"))
+		} else {
+			lines = append(lines, styles.Muted.Render(fmt.Sprintf(" Dimensions %s → %s",
+				formatImageDimensions(oldInfo), formatImageDimensions(newInfo))))
+		}
+	}
+	switch delta := newInfo.Size - oldInfo.Size; {
+	case delta == 0:
+		lines = append(lines, styles.Muted.Render(" Size unchanged"))
+	case delta > 0:
+		lines = append(lines, styles.Muted.Render(" Size +"+formatBlobSize(delta)))
+	default:
+		lines = append(lines, styles.Muted.Render(" Size -"+formatBlobSize(-delta)))
+	}
+	return lines
+}
@@ -0,0 +1,161 @@
+package gitstatus
+
+import (
+	stdimage "image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guyghost/sidecar/internal/image"
+)
+
+// writePNG writes a w by h PNG to the plugin's repo.
+func writePNG(t *testing.T, p *Plugin, name string, w, h int) {
+	t.Helper()
+	img := stdimage.NewRGBA(stdimage.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{uint8(40 * x), uint8(40 * y), 200, 255})
+		}
+	}
+	f, err := os.Create(filepath.Join(p.repoRoot, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// loadEntryDiff selects path and loads its inline diff.
+func loadEntryDiff(t *testing.T, p *Plugin, path string) {
+	t.Helper()
+	p.Update(p.refresh()())
+	selectEntry(t, p, path)
+	p.forceNextDiffReload = true
+	p.Update(p.autoLoadDiff()())
+	if p.diffPaneImage == nil {
+		t.Fatalf("%s should load as an image diff", path)
+	}
+}
+
+func TestImageDiff_Metadata(t *testing.T) {
+	p, git := newRepoPlugin(t)
+	p.imageRenderer = image.NewWithProtocol(image.ProtocolNone)
+	writePNG(t, p, "logo.png", 2, 2)
+	git("add", "logo.png")
+	git("commit", "-q", "-m", "logo")
+	writePNG(t, p, "logo.png", 6, 4)
+
+	loadEntryDiff(t, p, "logo.png")
+	view := p.View(120, 30)
+	for _, want := range []string{"2×2 PNG", "6×4 PNG", "Dimensions 2×2 → 6×4", "Size +", "metadata only"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("missing %q in:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Binary file differs") {
+		t.Errorf("image changes should not show the binary placeholder:\n%s", view)
+	}
+
+	// Staged: HEAD against the index, which the worktree no longer matches
+	git("add", "logo.png")
+	writePNG(t, p, "logo.png", 3, 3)
+	p.Update(p.refresh()())
+	for i, entry := range p.tree.AllEntries() {
+		if entry.Path == "logo.png" && entry.Staged {
+			p.cursor = i
+		}
+	}
+	p.forceNextDiffReload = true
+	p.Update(p.autoLoadDiff()())
+	if d := p.diffPaneImage; d == nil || d.Old.Info.Width != 2 || d.New.Info.Width != 6 {
+		t.Fatalf("staged image diff = %+v", d)
+	}
+
+	// The full-screen diff gets the same comparison
+	msg := p.loadDiff("logo.png", false, StatusModified)().(DiffLoadedMsg)
+	if msg.Image == nil || msg.Image.Old.Info.Width != 6 || msg.Image.New.Info.Width != 3 {
+		t.Fatalf("full diff image = %+v", msg.Image)
+	}
+}
+
+func TestImageDiff_NewImage(t *testing.T) {
+	p, _ := newRepoPlugin(t)
+	p.imageRenderer = image.NewWithProtocol(image.ProtocolNone)
+	writePNG(t, p, "new.png", 5, 5)
+
+	loadEntryDiff(t, p, "new.png")
+	if p.diffPaneImage.Old != nil {
+		t.Errorf("an untracked image has no old version: %+v", p.diffPaneImage.Old)
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "5×5 PNG") || !strings.Contains(view, "New image") {
+		t.Errorf("view:\n%s", view)
+	}
+}
+
+func TestImageDiff_SideBySide(t *testing.T) {
+	p, git := newRepoPlugin(t)
+	p.imageRenderer = image.NewWithProtocol(image.ProtocolKitty)
+	writePNG(t, p, "logo.png", 4, 4)
+	git("add", "logo.png")
+	git("commit", "-q", "-m", "logo")
+	writePNG(t, p, "logo.png", 8, 8)
+
+	loadEntryDiff(t, p, "logo.png")
+	view := p.View(160, 40)
+	for _, want := range []string{"Before · 4×4 PNG", "After · 8×8 PNG", "Dimensions 4×4 → 8×8"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("missing %q in:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "metadata only") {
+		t.Errorf("a graphics terminal should draw the images:\n%s", view)
+	}
+}
+
+func TestImageDiff_PrivateCacheRemovedOnStop(t *testing.T) {
+	p, git := newRepoPlugin(t)
+	p.imageRenderer = image.NewWithProtocol(image.ProtocolNone)
+	writePNG(t, p, "logo.png", 2, 2)
+	git("add", "logo.png")
+	git("commit", "-q", "-m", "logo")
+	writePNG(t, p, "logo.png", 6, 4)
+
+	loadEntryDiff(t, p, "logo.png")
+	cache := p.imageCache().Dir()
+	if filepath.Dir(p.diffPaneImage.Old.Path) != cache {
+		t.Fatalf("old version %s should be cached in %s", p.diffPaneImage.Old.Path, cache)
+	}
+	if fi, err := os.Stat(cache); err != nil || fi.Mode().Perm() != 0700 {
+		t.Fatalf("cache dir mode = %v, %v; want private", fi.Mode().Perm(), err)
+	}
+
+	p.Stop()
+	if _, err := os.Stat(cache); !os.IsNotExist(err) {
+		t.Errorf("Stop should remove the cache dir, stat = %v", err)
+	}
+}
+
+func TestWantsImageDiff(t *testing.T) {
+	binary := &ParsedDiff{Binary: true}
+	if !wantsImageDiff("a.png", StatusModified, binary) {
+		t.Error("binary png changes are image diffs")
+	}
+	if wantsImageDiff("a.bin", StatusModified, binary) {
+		t.Error("only image files get image diffs")
+	}
+	if wantsImageDiff("a.png", StatusModified, &ParsedDiff{}) {
+		t.Error("a tracked file needs a binary diff")
+	}
+	if !wantsImageDiff("a.png", StatusUntracked, &ParsedDiff{}) {
+		t.Error("untracked images have no binary marker")
+	}
+	if wantsImageDiff("a.png", StatusModified, &ParsedDiff{Binary: true, LFS: &LFSChange{}}) {
+		t.Error("LFS pointers keep their own rendering")
+	}
+}
@@ -0,0 +1,17 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export Git LFS types from internal/git.
+type (
+	LFSPointer = git.LFSPointer
+	LFSChange  = git.LFSChange
+	LFSStatus  = git.LFSStatus
+)
+
+// Re-export Git LFS functions.
+var (
+	LFSPatterns     = git.LFSPatterns
+	LFSTrackedPaths = git.LFSTrackedPaths
+	GetLFSStatus    = git.GetLFSStatus
+)
@@ -0,0 +1,162 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/config"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+// newLFSPlugin returns a plugin on a repo whose .gitattributes stores
+// *.psd in Git LFS, with a 1MB large file limit and two untracked 2MB
+// files: art.psd and dump.bin.
+func newLFSPlugin(t *testing.T) (*Plugin, func(args ...string) string) {
+	t.Helper()
+	p, git := newRepoPlugin(t)
+	commitFile(t, p.repoRoot, ".gitattributes", "*.psd filter=lfs diff=lfs merge=lfs -text\n", "track psd")
+	writeTestFile(t, p.repoRoot, "art.psd", strings.Repeat("x", 2<<20))
+	writeTestFile(t, p.repoRoot, "dump.bin", strings.Repeat("x", 2<<20))
+
+	p.ctx.Config = config.Default()
+	p.ctx.Config.Plugins.GitStatus.LargeFileWarnMB = 1
+	p.Update(p.refresh()())
+	return p, git
+}
+
+// selectEntry moves the cursor to the status entry for path.
+func selectEntry(t *testing.T, p *Plugin, path string) *FileEntry {
+	t.Helper()
+	for i, entry := range p.tree.AllEntries() {
+		if entry.Path == path {
+			p.cursor = i
+			return entry
+		}
+	}
+	t.Fatalf("no entry for %s", path)
+	return nil
+}
+
+func TestLFS_BadgeAndLargeFileWarning(t *testing.T) {
+	p, git := newLFSPlugin(t)
+	if psd := selectEntry(t, p, "art.psd"); !psd.LFS {
+		t.Fatal("art.psd should be marked as stored in LFS")
+	}
+	if bin := selectEntry(t, p, "dump.bin"); bin.LFS {
+		t.Fatal("dump.bin is not stored in LFS")
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "art.psd LFS") || strings.Contains(view, "dump.bin LFS") {
+		t.Errorf("only LFS files should get the badge:\n%s", view)
+	}
+
+	// An LFS file stages straight away
+	selectEntry(t, p, "art.psd")
+	_, cmd := p.Update(runeKey("s"))
+	if p.viewMode != ViewModeStatus || cmd == nil {
+		t.Fatal("staging an LFS file should not ask")
+	}
+	runBatch(p, cmd)
+
+	selectEntry(t, p, "dump.bin")
+	p.Update(runeKey("s"))
+	if p.viewMode != ViewModeConfirmLargeFiles || p.FocusContext() != keymap.ContextGitLargeFiles {
+		t.Fatal("staging a large file outside LFS should ask first")
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "dump.bin") || !strings.Contains(view, "2.0MB") {
+		t.Errorf("modal should list the file and its size:\n%s", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus || strings.Contains(git("diff", "--cached", "--name-only"), "dump.bin") {
+		t.Fatal("esc should cancel without staging")
+	}
+
+	p.Update(runeKey("S"))
+	if p.viewMode != ViewModeConfirmLargeFiles || len(p.largeFiles) != 1 {
+		t.Fatalf("stage all should ask about dump.bin alone, got %+v", p.largeFiles)
+	}
+	_, cmd = p.Update(runeKey("y"))
+	runBatch(p, cmd)
+	if got := git("diff", "--cached", "--name-only"); !strings.Contains(got, "dump.bin") {
+		t.Errorf("y should stage the files, staged:\n%s", got)
+	}
+}
+
+func TestLFS_NestedAttributes(t *testing.T) {
+	p, _ := newRepoPlugin(t)
+	commitFile(t, p.repoRoot, "assets/.gitattributes", "*.psd filter=lfs diff=lfs merge=lfs -text\n", "track psd in assets")
+	commitFile(t, p.repoRoot, "assets/art.psd", "v1\n", "add art")
+	writeTestFile(t, p.repoRoot, "assets/art.psd", strings.Repeat("x", 2<<20))
+
+	p.ctx.Config = config.Default()
+	p.ctx.Config.Plugins.GitStatus.LargeFileWarnMB = 1
+	p.Update(p.refresh()())
+	if psd := selectEntry(t, p, "assets/art.psd"); !psd.LFS {
+		t.Fatal("a nested .gitattributes should mark assets/art.psd as stored in LFS")
+	}
+	if _, cmd := p.Update(runeKey("s")); cmd == nil || p.viewMode != ViewModeStatus {
+		t.Error("staging an LFS file should not ask")
+	}
+}
+
+func TestLFS_WarningDisabled(t *testing.T) {
+	p, _ := newLFSPlugin(t)
+	p.ctx.Config.Plugins.GitStatus.LargeFileWarnMB = 0
+	selectEntry(t, p, "dump.bin")
+	if _, cmd := p.Update(runeKey("s")); cmd == nil || p.viewMode != ViewModeStatus {
+		t.Error("a zero limit should turn the warning off")
+	}
+}
+
+func TestLFS_PointerDiff(t *testing.T) {
+	raw := `diff --git a/art.psd b/art.psd
+--- a/art.psd
++++ b/art.psd
+@@ -1,3 +1,3 @@
+ version https://git-lfs.github.com/spec/v1
+-oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+-size 1048576
++oid sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
++size 3145728
+`
+	parsed, err := ParseUnifiedDiff(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{
+		RenderLineDiff(parsed, 80, 0, 20, 0, nil, false),
+		RenderSideBySide(parsed, 80, 0, 20, 0, nil, false),
+	} {
+		for _, want := range []string{"Git LFS object", "4d7a214614ab", "1.0MB", "9f86d081884c", "3.0MB", "Size +2.0MB"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("missing %q in:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "version https") {
+			t.Errorf("pointer text should not be shown:\n%s", out)
+		}
+	}
+
+	p := &Plugin{diffHunksEnabled: true, parsedDiff: parsed}
+	if p.diffHunksAvailable() {
+		t.Error("pointer diffs should not offer hunk staging")
+	}
+}
+
+func TestLFS_Diagnostics(t *testing.T) {
+	p, _ := newLFSPlugin(t)
+	if d := p.Diagnostics(); len(d) != 2 || d[1].ID != "git-lfs" || d[1].Status != "unknown" {
+		t.Fatalf("diagnostics before loading = %+v", d)
+	}
+	p.Update(p.loadLFSStatus()())
+	d := p.Diagnostics()[1]
+	if p.lfsStatus.Installed {
+		if d.Status == "unknown" || !strings.Contains(d.Detail, "1 pattern") {
+			t.Errorf("diagnostic = %+v", d)
+		}
+		return
+	}
+	if d.Status != "warn" || !strings.Contains(d.Detail, "git-lfs not installed") {
+		t.Errorf("a repo with LFS patterns but no git-lfs should warn, got %+v", d)
+	}
+}
@@ -0,0 +1,104 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+)
+
+// lfsBadge follows the path of files stored in Git LFS.
+const lfsBadge = " LFS"
+
+// LFSStatusLoadedMsg carries the repository's Git LFS status.
+type LFSStatusLoadedMsg struct {
+	Epoch  uint64 // Epoch when request was issued (for stale detection)
+	Status *LFSStatus
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m LFSStatusLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// loadLFSStatus checks git-lfs and what the repository stores in it, for
+// the diagnostics.
+func (p *Plugin) loadLFSStatus() tea.Cmd {
+	workDir := p.repoRoot
+	epoch := p.ctx.Epoch
+	return func() tea.Msg {
+		return LFSStatusLoadedMsg{Epoch: epoch, Status: GetLFSStatus(workDir)}
+	}
+}
+
+// lfsDiagnostic summarises the Git LFS status for the diagnostics modal.
+func (p *Plugin) lfsDiagnostic() plugin.Diagnostic {
+	s := p.lfsStatus
+	switch {
+	case s == nil:
+		return plugin.Diagnostic{ID: "git-lfs", Status: "unknown", Detail: "Not checked yet"}
+	case !s.InUse():
+		detail := "Not used by this repository"
+		if !s.Installed {
+			detail += " (git-lfs not installed)"
+		}
+		return plugin.Diagnostic{ID: "git-lfs", Status: "off", Detail: detail}
+	case !s.Installed:
+		return plugin.Diagnostic{ID: "git-lfs", Status: "warn",
+			Detail: fmt.Sprintf("git-lfs not installed; files matching %s are pointers", formatPatternCount(len(s.Patterns)))}
+	}
+	version := "git-lfs"
+	if fields := strings.Fields(s.Version); len(fields) > 0 {
+		version = strings.Replace(fields[0], "/", " ", 1) // "git-lfs/3.4.0"
+	}
+	detail := fmt.Sprintf("%s, %s, %s", version, formatPatternCount(len(s.Patterns)), formatFileCount(s.Files))
+	if s.Missing > 0 {
+		return plugin.Diagnostic{ID: "git-lfs", Status: "warn",
+			Detail: fmt.Sprintf("%s; %d not downloaded (git lfs pull)", detail, s.Missing)}
+	}
+	return plugin.Diagnostic{ID: "git-lfs", Status: "ok", Detail: detail}
+}
+
+// formatPatternCount formats a number of .gitattributes patterns.
+func formatPatternCount(n int) string {
+	if n == 1 {
+		return "1 pattern"
+	}
+	return fmt.Sprintf("%d patterns", n)
+}
+
+// formatFileCount formats a number of files.
+func formatFileCount(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
+
+// renderLFSChange renders the object IDs and sizes of a Git LFS file in
+// place of the diff of its pointer file.
+func renderLFSChange(change *LFSChange, width int) string {
+	row := func(sign string, ptr *LFSPointer) string {
+		line := fmt.Sprintf(" %s %s  %s", sign, ptr.ShortOID(), formatBlobSize(ptr.Size))
+		return truncateLine(line, width)
+	}
+	lines := []string{styles.Title.Render(" Git LFS object"), ""}
+	switch {
+	case change.Old == nil:
+		lines = append(lines, styles.DiffAdd.Render(row("+", change.New)), "", styles.Muted.Render(" New file"))
+	case change.New == nil:
+		lines = append(lines, styles.DiffRemove.Render(row("-", change.Old)), "", styles.Muted.Render(" Deleted"))
+	default:
+		lines = append(lines, styles.DiffRemove.Render(row("-", change.Old)), styles.DiffAdd.Render(row("+", change.New)), "")
+		delta := change.New.Size - change.Old.Size
+		switch {
+		case change.Old.OID == change.New.OID:
+			lines = append(lines, styles.Muted.Render(" Content unchanged"))
+		case delta >= 0:
+			lines = append(lines, styles.Muted.Render(" Size +"+formatBlobSize(delta)))
+		default:
+			lines = append(lines, styles.Muted.Render(" Size -"+formatBlobSize(-delta)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -17,6 +17,15 @@ const (
 	regionDiffModal    = "diff-modal"    // Full-screen diff view
 	regionDiffBack     = "diff-back"     // Back button in diff breadcrumb
 	regionCommitButton = "commit-button" // Commit modal button
+	regionCompare      = "compare"       // Full-screen compare view
+	regionCompareBack  = "compare-back"  // Back button in compare breadcrumb
+	regionCompareTab   = "compare-tab"   // Commits/Files/Diff tab
+	regionStashes      = "stashes"       // Full-screen stash browser
+	regionStashesBack  = "stashes-back"  // Back button in stash browser breadcrumb
+	regionStashItem    = "stash-item"    // Entry in the stash list
+	regionHookRun      = "hook-run"      // Full-screen hook output
+	regionHookBack     = "hook-back"     // Back button in hook output breadcrumb
+	regionHookLocation = "hook-location" // file:line problem below the output
 )
 
 // handleMouse processes mouse events in the status view.
@@ -164,19 +173,7 @@ func (p *Plugin) handleMouseDoubleClick(action mouse.MouseAction) (*Plugin, tea.
 		if !p.cursorOnCommit() {
 			entries := p.tree.AllEntries()
 			if p.cursor < len(entries) {
-				entry := entries[p.cursor]
-				p.diffReturnMode = p.viewMode
-				p.viewMode = ViewModeDiff
-				p.diffFile = entry.Path
-				p.diffCommit = ""
-				p.diffCommitSubject = ""
-				p.diffCommitShortHash = ""
-				p.diffScroll = 0
-				p.diffLoaded = false
-				if entry.IsFolder {
-					return p, p.loadFullFolderDiff(entry)
-				}
-				return p, p.loadDiff(entry.Path, entry.Staged, entry.Status)
+				return p, p.openFileDiff(entries[p.cursor])
 			}
 		}
 		return p, nil
@@ -198,6 +195,7 @@ func (p *Plugin) handleMouseDoubleClick(action mouse.MouseAction) (*Plugin, tea.
 				if p.previewCommit.IsMerge && len(p.previewCommit.ParentHashes) > 0 {
 					parentHash = p.previewCommit.ParentHashes[0]
 				}
+				p.diffCommitParent = parentHash
 				return p, p.loadCommitFileDiff(p.previewCommit.Hash, file.Path, parentHash)
 			}
 		}
@@ -248,14 +246,8 @@ func (p *Plugin) scrollSidebar(delta int) (*Plugin, tea.Cmd) {
 		p.cursor = newCursor
 		p.ensureCursorVisible()
 		if p.cursorOnCommit() {
-			commitIdx := p.selectedCommitIndex()
-			p.ensureCommitVisible(commitIdx)
-			// Trigger load-more when within 3 commits of end
-			var loadMoreCmd tea.Cmd
-			if !p.historyFilterActive && p.moreCommitsAvailable && commitIdx >= len(p.recentCommits)-3 && !p.loadingMoreCommits {
-				loadMoreCmd = p.loadMoreCommits()
-			}
-			return p, tea.Batch(p.autoLoadCommitPreview(), loadMoreCmd)
+			p.ensureCommitVisible(p.selectedCommitIndex())
+			return p, tea.Batch(p.autoLoadCommitPreview(), p.prefetchCommits())
 		}
 		return p, p.autoLoadDiff()
 	}
@@ -480,6 +472,12 @@ func (p *Plugin) handlePullConflictMouse(msg tea.MouseMsg) (*Plugin, tea.Cmd) {
 	case pullConflictAbortID:
 		plug, cmd := p.abortPullConflict()
 		return plug.(*Plugin), cmd
+	case pullConflictContinueID:
+		plug, cmd := p.continuePullConflict()
+		return plug.(*Plugin), cmd
+	case pullConflictResolveID:
+		plug, cmd := p.resolvePullConflict()
+		return plug.(*Plugin), cmd
 	case "cancel", pullConflictDismissID:
 		plug, cmd := p.dismissPullConflict()
 		return plug.(*Plugin), cmd
@@ -0,0 +1,30 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export patch export and apply types from internal/git.
+type (
+	PatchPreview    = git.PatchPreview
+	PatchCommit     = git.PatchCommit
+	PatchFile       = git.PatchFile
+	PatchHunk       = git.PatchHunk
+	PatchApplyState = git.PatchApplyState
+)
+
+// Re-export patch apply states.
+const (
+	PatchApplies   = git.PatchApplies
+	PatchMerges    = git.PatchMerges
+	PatchConflicts = git.PatchConflicts
+	PatchFails     = git.PatchFails
+)
+
+// Re-export patch export and apply functions.
+var (
+	FormatPatches = git.FormatPatches
+	StagedPatch   = git.StagedPatch
+	WorktreePatch = git.WorktreePatch
+	PreviewPatch  = git.PreviewPatch
+	ImportPatch   = git.ImportPatch
+	ErrEmptyPatch = git.ErrEmptyPatch
+)
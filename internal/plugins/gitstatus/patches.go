@@ -0,0 +1,669 @@
+package gitstatus
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/config"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	patchSourcePrefix = "patch-source-" // List item ID prefix, followed by source index
+	patchExportPathID = "patch-export-path"
+	patchExportSaveID = "patch-export-save"
+	patchExportCopyID = "patch-export-copy"
+
+	patchApplyPathID  = "patch-apply-path"
+	patchApplyLoadID  = "patch-apply-load"
+	patchApplyPasteID = "patch-apply-paste"
+	patchApplyID      = "patch-apply"
+
+	patchClipboard = "clipboard" // Source label for patches pasted from the clipboard
+)
+
+// Patch operations reported by PatchOpDoneMsg.
+const (
+	patchOpSave  = "save"
+	patchOpCopy  = "copy"
+	patchOpApply = "apply"
+)
+
+// patchSource is something the export modal can write as a patch.
+type patchSource struct {
+	Label  string
+	Name   string   // Default file name, without extension
+	Hashes []string // Commits to export, oldest first; empty for a diff
+	Staged bool     // Export the staged changes
+	Dir    string   // Worktree whose uncommitted changes are exported
+}
+
+// PatchWorktreesLoadedMsg is sent when the worktrees offered for export load.
+type PatchWorktreesLoadedMsg struct {
+	Epoch     uint64 // Epoch when request was issued (for stale detection)
+	Worktrees []WorktreeInfo
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m PatchWorktreesLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// PatchPreviewLoadedMsg is sent when a patch to apply has been read and
+// checked against the repository.
+type PatchPreviewLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Source  string // File path, or patchClipboard
+	Patch   string
+	Preview *PatchPreview
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m PatchPreviewLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// PatchOpDoneMsg is sent when a patch export or apply returns.
+type PatchOpDoneMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Op      string // patchOpSave, patchOpCopy or patchOpApply
+	Target  string // File written, for saves
+	Commits int    // Commits exported or applied; 0 for a plain diff
+	Files   int    // Files changed by an applied plain diff
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m PatchOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// patchFileName turns s into a file name in the style of format-patch.
+func patchFileName(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.Trim(sb.String(), "-.")
+	if len(name) > 52 {
+		name = strings.TrimRight(name[:52], "-.")
+	}
+	if name == "" {
+		name = "changes"
+	}
+	return name
+}
+
+// openPatchExport opens the export modal. Selected commits are offered
+// when the cursor is in the commit list, then the staged changes and the
+// uncommitted changes of each worktree.
+func (p *Plugin) openPatchExport() tea.Cmd {
+	branch := "changes"
+	if p.pushStatus != nil && p.pushStatus.CurrentBranch != "" {
+		branch = p.pushStatus.CurrentBranch
+	}
+
+	p.patchSources = nil
+	if p.cursorOnCommit() {
+		commits := p.selectedCommits()
+		if hasMergeCommit(commits) {
+			return appmsg.ShowToast("Cannot export merge commits", 2*time.Second)
+		}
+		switch len(commits) {
+		case 0:
+		case 1:
+			p.patchSources = append(p.patchSources, patchSource{
+				Label:  "Commit " + shortHash(commits[0].Hash) + " " + commits[0].Subject,
+				Name:   patchFileName(commits[0].Subject),
+				Hashes: commitHashes(commits, true),
+			})
+		default:
+			p.patchSources = append(p.patchSources, patchSource{
+				Label:  fmt.Sprintf("%d selected commits", len(commits)),
+				Name:   patchFileName(branch) + "-commits",
+				Hashes: commitHashes(commits, true),
+			})
+		}
+	}
+	p.patchSources = append(p.patchSources,
+		patchSource{Label: "Staged changes", Name: patchFileName(branch) + "-staged", Staged: true},
+		patchSource{Label: "Uncommitted changes (" + branch + ")", Name: patchFileName(branch), Dir: p.repoRoot},
+	)
+
+	p.patchSourceIdx = 0
+	p.patchExportPath = textinput.New()
+	p.patchExportPath.CharLimit = 500
+	p.patchExportErr = ""
+	p.patchBusy = ""
+	p.patchExportModal = nil
+	p.setPatchExportPlaceholder()
+	p.viewMode = ViewModePatchExport
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		return PatchWorktreesLoadedMsg{Epoch: epoch, Worktrees: GetWorktrees(workDir)}
+	}
+}
+
+// handlePatchWorktrees offers the uncommitted changes of other worktrees.
+func (p *Plugin) handlePatchWorktrees(msg PatchWorktreesLoadedMsg) {
+	if p.viewMode != ViewModePatchExport {
+		return
+	}
+	root := canonicalPath(p.repoRoot)
+	for _, wt := range msg.Worktrees {
+		if canonicalPath(wt.Path) == root {
+			continue
+		}
+		name := wt.Branch
+		if name == "" {
+			name = filepath.Base(wt.Path)
+		}
+		p.patchSources = append(p.patchSources, patchSource{
+			Label: fmt.Sprintf("Uncommitted changes in %s (%s)", name, filepath.Base(wt.Path)),
+			Name:  patchFileName(name),
+			Dir:   wt.Path,
+		})
+	}
+	p.patchExportModal = nil
+}
+
+// closePatchExport closes the export modal.
+func (p *Plugin) closePatchExport() {
+	p.viewMode = ViewModeStatus
+	p.patchSources = nil
+	p.patchExportErr = ""
+	p.patchBusy = ""
+	p.patchExportModal = nil
+	p.patchExportWidth = 0
+}
+
+// setPatchExportPlaceholder suggests a file name for the selected source.
+func (p *Plugin) setPatchExportPlaceholder() {
+	if p.patchSourceIdx < 0 || p.patchSourceIdx >= len(p.patchSources) {
+		return
+	}
+	p.patchExportPath.Placeholder = "~/" + p.patchSources[p.patchSourceIdx].Name + ".patch"
+}
+
+// resolvePatchPath expands ~ and makes path relative to the repository root.
+func (p *Plugin) resolvePatchPath(path string) string {
+	path = config.ExpandPath(strings.TrimSpace(path))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.repoRoot, path)
+	}
+	return path
+}
+
+// ensurePatchExportModal builds/rebuilds the export modal.
+func (p *Plugin) ensurePatchExportModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.patchExportModal != nil && p.patchExportWidth == modalW {
+		return
+	}
+	p.patchExportWidth = modalW
+
+	items := make([]modal.ListItem, len(p.patchSources))
+	for i, s := range p.patchSources {
+		items[i] = modal.ListItem{ID: fmt.Sprintf("%s%d", patchSourcePrefix, i), Label: ui.TruncateString(s.Label, modalW-8)}
+	}
+	p.patchExportModal = modal.New("Export Patch",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(patchExportSaveID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.List("patch-sources", items, &p.patchSourceIdx, modal.WithMaxVisible(6))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(patchExportPathID, "File", &p.patchExportPath)).
+		AddSection(p.patchStatusSection(&p.patchExportErr)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Save ", patchExportSaveID),
+			modal.Btn(" Copy ", patchExportCopyID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// patchStatusSection shows the operation in flight or the last error.
+func (p *Plugin) patchStatusSection(errText *string) modal.Section {
+	return modal.When(func() bool { return p.patchBusy != "" || *errText != "" }, modal.Custom(
+		func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+			if p.patchBusy != "" {
+				return modal.RenderedSection{Content: styles.StatusInProgress.Render(p.patchBusy)}
+			}
+			return modal.RenderedSection{Content: styles.StatusDeleted.Render(strings.TrimSpace(*errText))}
+		}, nil))
+}
+
+// updatePatchExport handles key events in the export modal.
+func (p *Plugin) updatePatchExport(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensurePatchExportModal()
+	action, cmd := p.patchExportModal.HandleKey(msg)
+	p.setPatchExportPlaceholder()
+	return p.patchExportAction(action, cmd)
+}
+
+// handlePatchExportMouse handles mouse events in the export modal.
+func (p *Plugin) handlePatchExportMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.patchExportModal == nil {
+		return p, nil
+	}
+	action := p.patchExportModal.HandleMouse(msg, p.mouseHandler)
+	var idx int
+	if _, err := fmt.Sscanf(action, patchSourcePrefix+"%d", &idx); err == nil {
+		p.patchSourceIdx = idx
+		p.setPatchExportPlaceholder()
+		return p, nil
+	}
+	return p.patchExportAction(action, nil)
+}
+
+// patchExportAction runs the export modal action returned by the modal.
+func (p *Plugin) patchExportAction(action string, cmd tea.Cmd) (plugin.Plugin, tea.Cmd) {
+	switch {
+	case action == patchExportSaveID || strings.HasPrefix(action, patchSourcePrefix):
+		return p, p.doExportPatch(false)
+	case action == patchExportCopyID:
+		return p, p.doExportPatch(true)
+	case action == "cancel":
+		p.closePatchExport()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// doExportPatch writes the selected source as a patch to the chosen file,
+// or to the clipboard.
+func (p *Plugin) doExportPatch(toClipboard bool) tea.Cmd {
+	if p.patchBusy != "" || p.patchSourceIdx < 0 || p.patchSourceIdx >= len(p.patchSources) {
+		return nil
+	}
+	src := p.patchSources[p.patchSourceIdx]
+	path := p.patchExportPath.Value()
+	if strings.TrimSpace(path) == "" {
+		path = p.patchExportPath.Placeholder
+	}
+	path = p.resolvePatchPath(path)
+	p.patchExportErr = ""
+	p.patchBusy = "Exporting " + strings.ToLower(src.Label[:1]) + src.Label[1:] + "..."
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		var patch string
+		var err error
+		switch {
+		case len(src.Hashes) > 0:
+			patch, err = FormatPatches(workDir, src.Hashes)
+		case src.Staged:
+			patch, err = StagedPatch(workDir)
+		default:
+			patch, err = WorktreePatch(src.Dir)
+		}
+		if toClipboard {
+			if err == nil {
+				err = clipboard.WriteAll(patch)
+			}
+			return PatchOpDoneMsg{Epoch: epoch, Op: patchOpCopy, Commits: len(src.Hashes), Err: err}
+		}
+		if err == nil {
+			err = os.WriteFile(path, []byte(patch), 0644)
+		}
+		return PatchOpDoneMsg{Epoch: epoch, Op: patchOpSave, Target: path, Commits: len(src.Hashes), Err: err}
+	}
+}
+
+// renderPatchExport renders the export modal over the status view.
+func (p *Plugin) renderPatchExport() string {
+	background := p.renderThreePaneView()
+	p.ensurePatchExportModal()
+	modalContent := p.patchExportModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// openPatchApply opens the apply modal with an empty file path.
+func (p *Plugin) openPatchApply() tea.Cmd {
+	p.patchApplyPath = textinput.New()
+	p.patchApplyPath.Placeholder = "~/changes.patch"
+	p.patchApplyPath.CharLimit = 500
+	p.patchApplyPath.Focus()
+	p.patchText = ""
+	p.patchSource = ""
+	p.patchPreview = nil
+	p.patchApplyErr = ""
+	p.patchBusy = ""
+	p.patchApplyModal = nil
+	p.viewMode = ViewModePatchApply
+	return textinput.Blink
+}
+
+// closePatchApply closes the apply modal.
+func (p *Plugin) closePatchApply() {
+	p.viewMode = ViewModeStatus
+	p.patchText = ""
+	p.patchPreview = nil
+	p.patchApplyErr = ""
+	p.patchBusy = ""
+	p.patchApplyModal = nil
+	p.patchApplyWidth = 0
+}
+
+// loadPatchFile reads the patch file named in the apply modal and checks it.
+func (p *Plugin) loadPatchFile() tea.Cmd {
+	if strings.TrimSpace(p.patchApplyPath.Value()) == "" {
+		p.patchApplyErr = "Patch file is required"
+		return nil
+	}
+	path := p.resolvePatchPath(p.patchApplyPath.Value())
+	return p.loadPatchPreview(path, func() (string, error) {
+		data, err := os.ReadFile(path)
+		return string(data), err
+	})
+}
+
+// pastePatch checks the patch on the clipboard.
+func (p *Plugin) pastePatch() tea.Cmd {
+	return p.loadPatchPreview(patchClipboard, clipboard.ReadAll)
+}
+
+// loadPatchPreview reads a patch with read and previews it against the
+// repository.
+func (p *Plugin) loadPatchPreview(source string, read func() (string, error)) tea.Cmd {
+	if p.patchBusy != "" {
+		return nil
+	}
+	p.patchApplyErr = ""
+	p.patchBusy = "Checking patch..."
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		patch, err := read()
+		if err != nil {
+			return PatchPreviewLoadedMsg{Epoch: epoch, Source: source, Err: err}
+		}
+		preview, err := PreviewPatch(workDir, patch)
+		return PatchPreviewLoadedMsg{Epoch: epoch, Source: source, Patch: patch, Preview: preview, Err: err}
+	}
+}
+
+// handlePatchPreviewLoaded shows the preview and moves focus to Apply.
+func (p *Plugin) handlePatchPreviewLoaded(msg PatchPreviewLoadedMsg) {
+	if p.viewMode != ViewModePatchApply {
+		return
+	}
+	p.patchBusy = ""
+	if msg.Err != nil {
+		p.patchText = ""
+		p.patchPreview = nil
+		if errors.Is(msg.Err, ErrEmptyPatch) {
+			p.patchApplyErr = "No changes found in " + msg.Source
+		} else {
+			p.patchApplyErr = msg.Err.Error()
+		}
+		return
+	}
+	p.patchText = msg.Patch
+	p.patchSource = msg.Source
+	p.patchPreview = msg.Preview
+	p.patchApplyErr = ""
+	p.ensurePatchApplyModal()
+	p.patchApplyModal.SetFocus(patchApplyID)
+}
+
+// ensurePatchApplyModal builds/rebuilds the apply modal.
+func (p *Plugin) ensurePatchApplyModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.patchApplyModal != nil && p.patchApplyWidth == modalW {
+		return
+	}
+	p.patchApplyWidth = modalW
+
+	p.patchApplyModal = modal.New("Apply Patch",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(patchApplyLoadID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.InputWithLabel(patchApplyPathID, "Patch file", &p.patchApplyPath)).
+		AddSection(p.patchStatusSection(&p.patchApplyErr)).
+		AddSection(modal.When(func() bool { return p.patchPreview != nil }, p.patchPreviewSection())).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Apply ", patchApplyID),
+			modal.Btn(" Load ", patchApplyLoadID),
+			modal.Btn(" Paste ", patchApplyPasteID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// patchPreviewSection lists the commits, files and hunks the patch changes
+// and how each would apply.
+func (p *Plugin) patchPreviewSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		pv := p.patchPreview
+		if pv == nil {
+			return modal.RenderedSection{}
+		}
+		kind := "diff · git apply --3way"
+		if pv.Mailbox {
+			kind = fmt.Sprintf("%d commit(s) · git am --3way", len(pv.Commits))
+		}
+		source := p.patchSource
+		if source != patchClipboard {
+			source = filepath.Base(source)
+		}
+		lines := []string{"", styles.Muted.Render(ui.TruncateString(source+" · "+kind, contentWidth))}
+
+		for _, c := range pv.Commits {
+			if pv.Mailbox {
+				lines = append(lines, styles.Title.Render(ui.TruncateString(c.Subject, contentWidth)))
+			}
+			for _, f := range c.Files {
+				lines = append(lines, renderPatchFileLine(f, contentWidth))
+				if f.State == PatchApplies {
+					continue
+				}
+				for _, h := range f.Hunks {
+					lines = append(lines, renderPatchHunkLine(h, contentWidth))
+				}
+			}
+		}
+
+		// Keep the buttons on screen for long patches
+		maxLines := p.height - 16
+		if maxLines < 4 {
+			maxLines = 4
+		}
+		if len(lines) > maxLines {
+			more := len(lines) - maxLines + 1
+			lines = append(lines[:maxLines-1], styles.Muted.Render(fmt.Sprintf("… %d more lines", more)))
+		}
+
+		summary := fmt.Sprintf("All %d file change(s) apply cleanly", pv.Files())
+		style := styles.StatusStaged
+		if n := pv.Failures(); n > 0 {
+			summary = fmt.Sprintf("%d of %d file change(s) need attention", n, pv.Files())
+			style = styles.StatusDeleted
+		}
+		lines = append(lines, "", style.Render(summary))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderPatchFileLine renders a file of the patch preview: state, path,
+// line counts and, unless it applies cleanly, why not.
+func renderPatchFileLine(f *PatchFile, width int) string {
+	icon, style, note := "✓", styles.StatusStaged, ""
+	switch f.State {
+	case PatchMerges:
+		icon, style, note = "↻", styles.StatusModified, "applies with a 3-way merge"
+	case PatchConflicts:
+		icon, style, note = "!", styles.StatusModified, "conflicts: "+f.Reason
+	case PatchFails:
+		icon, style, note = "✗", styles.StatusDeleted, f.Reason
+	}
+
+	path := f.Path
+	switch {
+	case f.OldPath != "":
+		path = f.OldPath + " → " + f.Path
+	case f.New:
+		path += " (new)"
+	case f.Deleted:
+		path += " (deleted)"
+	}
+	stats := fmt.Sprintf("+%d -%d", f.Additions, f.Deletions)
+	if f.Binary {
+		stats = "binary"
+	}
+
+	line := style.Render(icon) + " " + styles.Body.Render(ui.TruncateString(path, width/2)) + "  " + styles.Muted.Render(stats)
+	if note != "" {
+		line += "  " + style.Render(note)
+	}
+	return ui.TruncateString(line, width)
+}
+
+// renderPatchHunkLine renders a hunk of a file that does not apply cleanly.
+func renderPatchHunkLine(h PatchHunk, width int) string {
+	if h.Err == "" {
+		return "    " + styles.StatusStaged.Render("✓") + " " + styles.Muted.Render(ui.TruncateString(h.Header, width-6))
+	}
+	return "    " + styles.StatusDeleted.Render("✗") + " " + styles.Muted.Render(ui.TruncateString(h.Header, width/2)) +
+		"  " + styles.StatusDeleted.Render(h.Err)
+}
+
+// updatePatchApply handles key events in the apply modal.
+func (p *Plugin) updatePatchApply(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensurePatchApplyModal()
+	action, cmd := p.patchApplyModal.HandleKey(msg)
+	if plug, opCmd, ok := p.patchApplyAction(action); ok {
+		return plug, opCmd
+	}
+	return p, cmd
+}
+
+// handlePatchApplyMouse handles mouse events in the apply modal.
+func (p *Plugin) handlePatchApplyMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.patchApplyModal == nil {
+		return p, nil
+	}
+	plug, cmd, _ := p.patchApplyAction(p.patchApplyModal.HandleMouse(msg, p.mouseHandler))
+	return plug, cmd
+}
+
+// patchApplyAction runs an apply modal action; ok is false for actions it
+// does not handle.
+func (p *Plugin) patchApplyAction(action string) (plugin.Plugin, tea.Cmd, bool) {
+	switch action {
+	case patchApplyLoadID:
+		return p, p.loadPatchFile(), true
+	case patchApplyPasteID:
+		return p, p.pastePatch(), true
+	case patchApplyID:
+		return p, p.doApplyPatch(), true
+	case "cancel":
+		p.closePatchApply()
+		return p, nil, true
+	}
+	return p, nil, false
+}
+
+// doApplyPatch applies the previewed patch.
+func (p *Plugin) doApplyPatch() tea.Cmd {
+	if p.patchBusy != "" {
+		return nil
+	}
+	if p.patchPreview == nil {
+		p.patchApplyErr = "Load a patch file or paste one first"
+		return nil
+	}
+	patch := p.patchText
+	commits := 0
+	if p.patchPreview.Mailbox {
+		commits = len(p.patchPreview.Commits)
+	}
+	files := p.patchPreview.Files()
+	p.patchBusy = "Applying patch..."
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		err := ImportPatch(workDir, patch)
+		return PatchOpDoneMsg{Epoch: epoch, Op: patchOpApply, Commits: commits, Files: files, Err: err}
+	}
+}
+
+// renderPatchApply renders the apply modal over the status view.
+func (p *Plugin) renderPatchApply() string {
+	background := p.renderThreePaneView()
+	p.ensurePatchApplyModal()
+	modalContent := p.patchApplyModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// handlePatchOpDone reports an export or apply. Export errors stay in the
+// modal so the path can be fixed; a failed apply shows git's output.
+func (p *Plugin) handlePatchOpDone(msg PatchOpDoneMsg) tea.Cmd {
+	p.patchBusy = ""
+	if msg.Op == patchOpApply {
+		p.closePatchApply()
+		reload := tea.Batch(p.refresh(), p.loadRecentCommits())
+		if msg.Err != nil {
+			p.showErrorModal("Apply Patch Failed", msg.Err)
+			return reload
+		}
+		toast := fmt.Sprintf("Applied patch to %d file(s)", msg.Files)
+		if msg.Commits > 0 {
+			toast = fmt.Sprintf("Applied %d commit(s)", msg.Commits)
+		}
+		return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), reload)
+	}
+
+	if msg.Err != nil {
+		if p.viewMode == ViewModePatchExport {
+			if errors.Is(msg.Err, ErrEmptyPatch) {
+				p.patchExportErr = "Nothing to export"
+			} else {
+				p.patchExportErr = msg.Err.Error()
+			}
+			return nil
+		}
+		p.showErrorModal("Export Patch Failed", msg.Err)
+		return nil
+	}
+	p.closePatchExport()
+	what := "patch"
+	if msg.Commits > 0 {
+		what = fmt.Sprintf("%d commit(s)", msg.Commits)
+	}
+	if msg.Op == patchOpCopy {
+		return appmsg.ShowToast("Copied "+what+" to clipboard", 2*time.Second)
+	}
+	return appmsg.ShowToast("Saved "+what+" to "+msg.Target, 2*time.Second)
+}
@@ -0,0 +1,99 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+func TestPatchExportAndApply(t *testing.T) {
+	p, _ := newRemotesPlugin(t)
+	dir := p.repoRoot
+	git := gitRunner(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\nmore\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "a.txt")
+	out := filepath.Join(t.TempDir(), "staged.patch")
+
+	_, cmd := p.Update(runeKey("e"))
+	if p.viewMode != ViewModePatchExport || p.FocusContext() != keymap.ContextGitPatchExport || cmd == nil {
+		t.Fatal("e should open the export modal")
+	}
+	p.Update(cmd())
+	if p.patchSources[0].Label != "Staged changes" || !strings.HasSuffix(p.patchExportPath.Placeholder, "main-staged.patch") {
+		t.Fatalf("sources = %+v, placeholder %q", p.patchSources, p.patchExportPath.Placeholder)
+	}
+	p.View(120, 30)
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(p, out)
+	p.View(120, 30)
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should export the patch")
+	}
+	p.Update(cmd())
+	if p.viewMode != ViewModeStatus {
+		t.Fatalf("export failed: %s", p.patchExportErr)
+	}
+	if data, err := os.ReadFile(out); err != nil || !strings.Contains(string(data), "+more") {
+		t.Fatalf("exported patch = %q, %v", data, err)
+	}
+
+	git("reset", "-q", "--hard")
+	p.Update(runeKey("I"))
+	if p.viewMode != ViewModePatchApply || !p.ConsumesTextInput() {
+		t.Fatal("I should open the apply modal")
+	}
+	typeText(p, out)
+	p.View(120, 30)
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should load the patch")
+	}
+	p.Update(cmd())
+	if view := p.View(120, 30); !strings.Contains(view, "a.txt") || !strings.Contains(view, "All 1 file change(s) apply cleanly") {
+		t.Errorf("preview should list a.txt:\n%s", view)
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter after loading should apply the patch")
+	}
+	p.Update(cmd())
+	if p.viewMode != ViewModeStatus {
+		t.Fatalf("apply failed: %s", p.errorDetail)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "a.txt")); string(data) != "one\nmore\n" {
+		t.Errorf("a.txt = %q", data)
+	}
+}
+
+func TestPatchApply_ReportsFailingHunks(t *testing.T) {
+	p, _ := newRemotesPlugin(t)
+	patch := "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-uno\n+dos\n"
+	path := filepath.Join(t.TempDir(), "bad.patch")
+	if err := os.WriteFile(path, []byte(patch), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p.openPatchApply()
+	p.patchApplyPath.SetValue(path)
+	p.Update(p.loadPatchFile()())
+	view := p.View(120, 30)
+	for _, want := range []string{"✗ a.txt", "patch does not apply", "1 of 1 file change(s) need attention", "lines 1-1 no longer match"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview lacks %q:\n%s", want, view)
+		}
+	}
+
+	p.patchApplyPath.SetValue(filepath.Join(t.TempDir(), "missing.patch"))
+	p.Update(p.loadPatchFile()())
+	if p.patchPreview != nil || !strings.Contains(p.patchApplyErr, "no such file") {
+		t.Errorf("missing file: preview %v, err %q", p.patchPreview, p.patchApplyErr)
+	}
+}
@@ -1,6 +1,8 @@
 package gitstatus
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,9 +11,12 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/adapter"
 	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/image"
 	"github.com/guyghost/sidecar/internal/keymap"
 	"github.com/guyghost/sidecar/internal/modal"
 	"github.com/guyghost/sidecar/internal/mouse"
@@ -20,6 +25,7 @@ import (
 	"github.com/guyghost/sidecar/internal/state"
 	"github.com/guyghost/sidecar/internal/styles"
 	"github.com/guyghost/sidecar/internal/ui"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
 )
 
 const (
@@ -32,16 +38,38 @@ const (
 type ViewMode int
 
 const (
-	ViewModeStatus          ViewMode = iota // Current file list (three-pane layout)
-	ViewModeDiff                            // Full-screen diff view
-	ViewModeCommit                          // Commit message editor
-	ViewModePushMenu                        // Push options popup menu
-	ViewModePullMenu                        // Pull options popup menu
-	ViewModeConfirmDiscard                  // Confirm discard changes modal
-	ViewModeBranchPicker                    // Branch selection modal
-	ViewModeConfirmStashPop                 // Confirm stash pop modal
-	ViewModePullConflict                    // Pull conflict resolution modal
-	ViewModeError                           // Generic error modal for git operation failures
+	ViewModeStatus            ViewMode = iota // Current file list (three-pane layout)
+	ViewModeDiff                              // Full-screen diff view
+	ViewModeCommit                            // Commit message editor
+	ViewModePushMenu                          // Push options popup menu
+	ViewModePullMenu                          // Pull options popup menu
+	ViewModeConfirmDiscard                    // Confirm discard changes modal
+	ViewModeBranchPicker                      // Branch selection modal
+	ViewModeConfirmStashPop                   // Confirm stash pop modal
+	ViewModePullConflict                      // Pull conflict resolution modal
+	ViewModeError                             // Generic error modal for git operation failures
+	ViewModeRebase                            // Interactive rebase todo editor
+	ViewModeRebaseStopped                     // Rebase stopped for edit or conflicts
+	ViewModeCherryPick                        // Cherry-pick target picker
+	ViewModeConfirmReset                      // Confirm reset modal
+	ViewModeConflicts                         // Three-way conflict resolver
+	ViewModeTags                              // Tag list and management
+	ViewModeCreateTag                         // Create tag modal
+	ViewModeRelease                           // Changes since a tag, for release notes
+	ViewModeReflog                            // Reflog browser
+	ViewModeComparePick                       // Pick two refs to compare
+	ViewModeCompare                           // Branch and range comparison
+	ViewModeSubmodules                        // Submodule list and actions
+	ViewModeRemotes                           // Remote list and management
+	ViewModeRemoteEdit                        // Add or edit remote modal
+	ViewModePatchExport                       // Export commits or changes as a patch
+	ViewModePatchApply                        // Preview and apply a patch
+	ViewModeBisectStart                       // Choose the good and bad ends of a bisect
+	ViewModeBisect                            // Bisect progress and marking
+	ViewModeStashes                           // Stash list with diff preview
+	ViewModeStashPush                         // Stash chosen files modal
+	ViewModeHookRun                           // Commit or push hook output
+	ViewModeConfirmLargeFiles                 // Confirm staging large files outside Git LFS
 )
 
 // FocusPane represents which pane is active in the three-pane view.
@@ -54,6 +82,10 @@ const (
 
 const commitHistoryPageSize = 50
 
+// commitPrefetchMargin is how many commits before the end of the loaded
+// history the next page starts loading.
+const commitPrefetchMargin = commitHistoryPageSize / 2
+
 // Plugin implements the git status plugin.
 type Plugin struct {
 	ctx                *plugin.Context
@@ -85,6 +117,7 @@ type Plugin struct {
 	diffPaneScroll      int          // Vertical scroll for inline diff
 	diffPaneHorizScroll int          // Horizontal scroll for inline diff
 	diffPaneParsedDiff  *ParsedDiff  // Parsed diff for inline view
+	diffPaneImage       *ImageDiff   // Both versions of an image for inline view
 	diffPaneViewMode    DiffViewMode // Unified or side-by-side for inline diff
 
 	// Commit preview state (for three-pane view when on commit)
@@ -98,15 +131,24 @@ type Plugin struct {
 	diffScroll          int
 	diffRaw             string       // Raw diff before delta processing
 	diffCommit          string       // Commit hash if viewing commit diff
+	diffCommitParent    string       // Parent diffed against for merge commits
 	diffCommitSubject   string       // Subject of commit being diffed (for breadcrumb)
 	diffCommitShortHash string       // Short hash of commit being diffed (for breadcrumb)
 	diffViewMode        DiffViewMode // Line or side-by-side
 	diffHorizOff        int          // Horizontal scroll for side-by-side
 	parsedDiff          *ParsedDiff  // Parsed diff for enhanced rendering
+	diffImage           *ImageDiff   // Both versions of an image, when the diff is one
 	diffReturnMode      ViewMode     // View mode to return to on esc
 	diffLoaded          bool         // True once diff load completes (distinguishes loading vs empty)
 	diffWrapEnabled     bool         // Wrap long lines instead of truncating
 	diffBackWidth       int          // Width of back button for hit region (set during render)
+	diffStaged          bool         // Diff shows staged changes (index vs HEAD)
+	diffFileStatus      FileStatus   // Status of the file being diffed
+	diffHunksEnabled    bool         // Hunk/line staging allowed for this diff
+	diffHunkCursor      int          // Selected hunk index for staging
+	diffLineMode        bool         // Line selection within the selected hunk
+	diffLineAnchor      int          // Line selection anchor (index into hunk lines)
+	diffLineCursor      int          // Line selection cursor (index into hunk lines)
 
 	// Push status state
 	pushStatus              *PushStatus
@@ -118,7 +160,13 @@ type Plugin struct {
 	pushMenuFocus           int       // 0=push, 1=force, 2=upstream
 	pushMenuModal           *modal.Modal
 	pushMenuModalWidth      int
-	pushPreservedCommitHash string // Hash of selected commit when push started
+	pushMenuRemotes         []string        // Remotes the push menu's picker cycles through
+	pushMenuRemote          string          // Remote the push menu pushes to; empty until loaded
+	pushMenuPrimary         string          // Remote the default push actions use
+	pushMenuCurrent         string          // Branch checked out, pushed to its namesake by default
+	pushMenuBranch          textinput.Model // Branch on the remote the push menu pushes to
+	pushMenuTarget          *PushStatus     // HEAD against the chosen remote branch; nil while loading
+	pushPreservedCommitHash string          // Hash of selected commit when push started
 
 	// Pull menu state
 	pullMenuReturnMode ViewMode     // Mode to return to when pull menu closes
@@ -128,7 +176,8 @@ type Plugin struct {
 
 	// Pull conflict state
 	pullConflictFiles []string // Conflicted files from failed pull
-	pullConflictType  string   // "merge" or "rebase"
+	pullConflictType  string   // "merge", "rebase", "cherry-pick" or "revert"
+	pullConflictDir   string   // Worktree with the conflicts; empty means repoRoot
 	pullConflictModal *modal.Modal
 	pullConflictWidth int
 
@@ -145,14 +194,21 @@ type Plugin struct {
 	commitError           string
 	commitInProgress      bool
 	commitAmend           bool // true when amending last commit
-	commitButtonFocus     bool // true when button is focused instead of textarea
-	commitButtonHover     bool // true when mouse is hovering over button
+	commitSign            bool // true to sign with -S; saved per project
+	signing               SigningConfig
+	composer              *commitComposer // Conventional commit fields; nil for free text
+	commitButtonFocus     bool            // true when button is focused instead of textarea
+	commitButtonHover     bool            // true when mouse is hovering over button
 	commitModal           *modal.Modal
 	commitModalWidthCache int
 
 	// Mouse support
 	mouseHandler *mouse.Handler
 
+	// Terminal graphics for image diffs
+	imageRenderer  *image.Renderer
+	imageDiffCache *imageDiffCache // Image versions read from git
+
 	// Error modal state
 	errorModal       *modal.Modal
 	errorModalWidth  int
@@ -160,16 +216,223 @@ type Plugin struct {
 	errorTitle       string // e.g. "Push Failed", "Fetch Failed"
 	errorDetail      string // full git command output
 	errorOfferPull   bool   // true when push was rejected due to remote ahead
+	errorReturnMode  ViewMode
 
 	// Discard confirm state
 	discardFile       *FileEntry   // File being confirmed for discard
 	discardReturnMode ViewMode     // Mode to return to when modal closes
 	discardModal      *modal.Modal // Modal instance for discard confirmation
+	discardHunk       bool         // Confirming discard of the selected hunk/lines in diff view
+
+	// Interactive rebase state
+	rebaseBase        string           // Base commit; empty rebases from the root
+	rebaseItems       []RebaseTodoItem // Todo list, oldest first
+	rebaseOriginal    []string         // Hashes in their original order
+	rebaseCursor      int              // Selected todo item
+	rebaseError       string           // Validation error shown in the editor
+	rebaseRunning     bool             // Rebase command in flight
+	rebaseModal       *modal.Modal
+	rebaseModalWidth  int
+	rebaseRewording   bool           // Reword message modal is open
+	rebaseMessage     textarea.Model // Message being edited for reword
+	rebaseRewordModal *modal.Modal
+	rebaseProgress    *RebaseProgress // State of the stopped rebase
+	rebaseStopModal   *modal.Modal
+	rebaseStopWidth   int
+
+	// Commit history operations state
+	markedCommits     map[string]bool    // Commits selected with space, by hash
+	cherryPickCommits []*Commit          // Commits being cherry-picked, newest first
+	cherryPickTargets []cherryPickTarget // Current branch, then other worktrees
+	cherryPickIdx     int
+	cherryPickModal   *modal.Modal
+	cherryPickWidth   int
+	resetTarget       *Commit       // Commit the branch will be reset to
+	resetPreview      *ResetPreview // Commits and files the reset affects
+	resetModeIdx      int           // Index into resetModes
+	resetModal        *modal.Modal
+	resetModalWidth   int
+
+	// Conflict resolver state
+	conflictResolver *conflict.Resolver
+
+	// Signatures of listed commits, verified as they come into view
+	signatures        map[string]CommitSignature // By commit hash
+	signaturesPending map[string]bool            // Being verified, or failed to
+
+	// Tag state
+	tags           []*Tag              // Local tags, newest first
+	tagsByHash     map[string][]string // Tag names by target commit, for decorations
+	latestTag      string              // Most recent tag reachable from HEAD
+	sinceLatestTag int                 // Commits on HEAD since latestTag
+	tagRemote      string              // Remote the tags panel pushes to and lists
+	remoteTags     map[string]string   // Remote tag name -> commit; nil until loaded
+	remoteTagsErr  string
+	tagList        []*Tag // Local and remote tags shown in the tags panel
+	tagCursor      int
+	tagConfirm     string // "d" or "D" while a delete awaits confirmation
+	tagBusy        string // Remote operation in flight, shown in the tags panel
+	tagsModal      *modal.Modal
+	tagsModalWidth int
+
+	// Create tag modal state
+	createTagReturnMode ViewMode
+	createTagTarget     *Commit // nil tags HEAD
+	createTagName       textinput.Model
+	createTagMessage    textarea.Model
+	createTagSign       bool
+	createTagErr        string
+	createTagModal      *modal.Modal
+	createTagWidth      int
+
+	// Release view state
+	releaseSummary *ReleaseSummary
+	releaseModal   *modal.Modal
+	releaseWidth   int
+
+	// Reflog browser state
+	reflogRefs       []string // HEAD and local branches
+	reflogRefIdx     int
+	reflogEntries    []*ReflogEntry
+	reflogCursor     int
+	reflogPreview    *ReflogPreview // Tree at the selected entry; nil while loading
+	reflogErr        string
+	reflogBranching  bool // Naming a branch to create at the selected entry
+	reflogBranchName textinput.Model
+	reflogModal      *modal.Modal
+	reflogModalWidth int
+
+	// Compare state
+	compareInputs    [2]textinput.Model // Base, then compare side
+	compareField     int                // Focused input
+	compareRefs      []CompareRef       // Picker suggestions
+	compareSuggest   int                // Highlighted suggestion, -1 for none
+	comparePickErr   string
+	compareRunning   bool
+	comparePickModal *modal.Modal
+	comparePickWidth int
+	comparison       *Comparison
+	compareDiff      *MultiFileDiff
+	compareTab       int
+	compareCursor    int // Row in the commits or files tab
+	compareScroll    int // Line in the diff tab
+
+	// Submodule state
+	submoduleSummary     *SubmoduleSummary // Shown in place of the diff of a selected submodule
+	submodules           []*Submodule      // Listed in the submodules panel
+	submodulesLoaded     bool
+	submodulesErr        string
+	submoduleCursor      int
+	submoduleBusy        string // Operation in flight, shown in the submodules panel
+	submodulesModal      *modal.Modal
+	submodulesModalWidth int
+	submoduleStack       []submoduleFrame // Repositories entered to reach this one, outermost first
+	submoduleVisits      uint64           // Submodules entered since the root, for their epochs
+
+	// Remotes panel state
+	remotes             []*Remote // Listed in the remotes panel
+	remotesLoaded       bool
+	remotesErr          string
+	remoteCursor        int
+	remoteBusy          string // Fetch in flight, shown in the remotes panel
+	remoteConfirmRemove bool   // d pressed; y removes the selected remote
+	remotesModal        *modal.Modal
+	remotesModalWidth   int
+	remoteEditOld       string // Remote being edited; empty when adding one
+	remoteEditName      textinput.Model
+	remoteEditURL       textinput.Model
+	remoteEditErr       string
+	remoteEditModal     *modal.Modal
+	remoteEditWidth     int
+
+	// Patch export and apply state
+	patchSources     []patchSource // Offered in the export modal
+	patchSourceIdx   int
+	patchExportPath  textinput.Model
+	patchExportErr   string
+	patchExportModal *modal.Modal
+	patchExportWidth int
+	patchApplyPath   textinput.Model
+	patchText        string        // Patch being previewed
+	patchSource      string        // File the patch was read from, or "clipboard"
+	patchPreview     *PatchPreview // Nil until a patch is loaded
+	patchApplyErr    string
+	patchApplyModal  *modal.Modal
+	patchApplyWidth  int
+	patchBusy        string // Export, check or apply in flight
+
+	// Bisect state
+	bisect           *BisectState // Nil or inactive when no bisect is running
+	bisectBad        textinput.Model
+	bisectGood       textinput.Model
+	bisectCmd        textinput.Model // Test command for git bisect run
+	bisectLastCmd    string
+	bisectStartErr   string
+	bisectStartModal *modal.Modal
+	bisectStartWidth int
+	bisectEditingCmd bool // r pressed in the panel; the command input has focus
+	bisectBusy       string
+	bisectErr        string
+	bisectOutput     []string // Run output, or the last step's report
+	bisectRunCancel  context.CancelFunc
+	bisectRunEvents  <-chan tea.Msg
+	bisectRunStart   time.Time
+	bisectRunElapsed time.Duration // Length of the finished run
+	bisectCulprit    *Commit
+	bisectSession    *adapter.Session // Agent session the culprit was made in
+	bisectModal      *modal.Modal
+	bisectModalWidth int
 
 	// Stash pop confirm state
 	stashPopItem  *Stash       // Stash being confirmed for pop
 	stashPopModal *modal.Modal // Modal instance for stash pop confirmation
 
+	// Stash browser state
+	stashes        []*Stash
+	stashesLoaded  bool
+	stashCursor    int
+	stashDiff      *MultiFileDiff // Diff of the selected stash, untracked files included
+	stashScroll    int
+	stashErr       string
+	stashConfirm   string // Operation awaiting y: stashOpPop, stashOpDrop or stashOpCheckout
+	stashInput     string // stashOpBranch or stashOpRename while a name is typed
+	stashInputText textinput.Model
+
+	// Large file staging confirm state
+	largeFiles          []largeFile
+	largeFileStage      func() tea.Cmd // Stages the files once confirmed
+	largeFileReturnMode ViewMode
+	largeFileModal      *modal.Modal
+
+	// Git LFS status, for diagnostics
+	lfsStatus *LFSStatus
+
+	// Hook run state
+	hookOp            string // hookOpCommit or hookOpPush
+	hookRunCancel     context.CancelFunc
+	hookRunEvents     <-chan tea.Msg
+	hookRunStart      time.Time
+	hookRunElapsed    time.Duration // Length of the finished run
+	hookOutput        []string
+	hookScroll        int // Lines scrolled back from the end of the output
+	hookErr           string
+	hookCancelled     bool
+	hookLocations     []FileLocation // file:line problems found in the output
+	hookCursor        int
+	hookConfirm       bool // n pressed; y commits without hooks
+	hookReturnMode    ViewMode
+	hookCommitMessage string
+	hookCommitOpts    CommitOptions
+	hookRetry         func() tea.Cmd
+
+	// Stash modal state
+	stashPushMsg    textinput.Model
+	stashPushFiles  []*stashPushFile
+	stashPushCursor int
+	stashPushErr    string
+	stashPushModal  *modal.Modal
+	stashPushWidth  int
+
 	// Syntax highlighting
 	syntaxHighlighter     *SyntaxHighlighter // Cached highlighter for current file
 	syntaxHighlighterFile string             // File the highlighter was created for
@@ -193,12 +456,20 @@ type Plugin struct {
 	historySearchState *HistorySearchState
 	historySearchMode  bool // True when search modal is open
 
+	// Diff options menu (W in diff views); nil when closed
+	diffOptionsMenu *DiffOptionsMenu
+
 	// History filter state
 	historyFilterActive bool   // True when any filter is active
 	historyFilterAuthor string // Filter by author name/email
 	historyFilterPath   string // Filter by file path
 	filteredCommits     []*Commit
 
+	// Search across the full history (pickaxe, --grep, -L); nil when none
+	historyQuery          *HistoryQuery
+	moreFilteredAvailable bool            // More pages of filtered commits to load
+	previewMatchFiles     map[string]bool // Files of the previewed commit matching historyQuery
+
 	// Path filter input state
 	pathFilterMode  bool   // True when path input modal is open
 	pathFilterInput string // Current path input
@@ -206,6 +477,7 @@ type Plugin struct {
 	// Commit graph display state
 	showCommitGraph  bool        // True when graph column is displayed
 	commitGraphLines []GraphLine // Cached graph computation
+	commitGraph      *GraphState // Lane state after the last cached line, resumed as pages load
 
 	// Truncation cache to eliminate ANSI parser allocation churn
 	truncateCache *ui.TruncateCache
@@ -230,6 +502,7 @@ func New() *Plugin {
 		sidebarRestore: PaneSidebar,
 		mouseHandler:   mouse.NewHandler(),
 		truncateCache:  ui.NewTruncateCache(1000), // Cache up to 1000 truncations
+		imageRenderer:  image.New(),               // Detect terminal graphics protocol once
 	}
 }
 
@@ -263,6 +536,7 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 	// Preserve resources that are expensive to recreate or have no project-specific state
 	mouseHandler := p.mouseHandler
 	truncateCache := p.truncateCache
+	imageRenderer := p.imageRenderer
 	width, height := p.width, p.height
 
 	// Reset ALL state by zeroing the struct, then restore preserved fields
@@ -270,6 +544,7 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 	*p = Plugin{
 		mouseHandler:   mouseHandler,
 		truncateCache:  truncateCache,
+		imageRenderer:  imageRenderer,
 		width:          width,
 		height:         height,
 		sidebarVisible: true,
@@ -303,6 +578,7 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 	p.hasRepo = true
 	p.repoRoot = root
 	p.tree = NewFileTree(root)
+	p.commitSign = state.GetSignCommits(p.signingProject())
 
 	return nil
 }
@@ -316,6 +592,7 @@ func (p *Plugin) Start() tea.Cmd {
 		p.refresh(),
 		p.startWatcher(),
 		p.loadRecentCommits(),
+		p.loadLFSStatus(),
 	)
 }
 
@@ -324,10 +601,28 @@ func (p *Plugin) Stop() {
 	if p.watcher != nil {
 		p.watcher.Stop()
 	}
+	if p.bisectRunCancel != nil {
+		p.bisectRunCancel()
+	}
+	if p.hookRunCancel != nil {
+		p.hookRunCancel()
+	}
+	// Readers are also opened for worktrees, submodules and undo
+	CloseObjectReaders()
+	if p.imageDiffCache != nil {
+		p.imageDiffCache.Remove()
+	}
 }
 
-// Update handles messages.
+// Update handles messages, then verifies the signatures of any commits the
+// message brought into view.
 func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
+	next, cmd := p.update(msg)
+	return next, tea.Batch(cmd, p.loadVisibleSignatures())
+}
+
+// update handles messages.
+func (p *Plugin) update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if p.inNoRepoMode() {
@@ -340,6 +635,9 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		if p.pathFilterMode {
 			return p.updatePathFilter(msg)
 		}
+		if p.diffOptionsMenu != nil {
+			return p.updateDiffOptions(msg)
+		}
 		switch p.viewMode {
 		case ViewModeStatus:
 			return p.updateStatus(msg)
@@ -361,10 +659,54 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p.updateBranchPicker(msg)
 		case ViewModeError:
 			return p.updateErrorModal(msg)
+		case ViewModeRebase:
+			return p.updateRebase(msg)
+		case ViewModeRebaseStopped:
+			return p.updateRebaseStopped(msg)
+		case ViewModeCherryPick:
+			return p.updateCherryPick(msg)
+		case ViewModeConfirmReset:
+			return p.updateConfirmReset(msg)
+		case ViewModeConflicts:
+			return p.updateConflictResolver(msg)
+		case ViewModeTags:
+			return p.updateTags(msg)
+		case ViewModeCreateTag:
+			return p.updateCreateTag(msg)
+		case ViewModeRelease:
+			return p.updateRelease(msg)
+		case ViewModeReflog:
+			return p.updateReflog(msg)
+		case ViewModeComparePick:
+			return p.updateComparePick(msg)
+		case ViewModeCompare:
+			return p.updateCompare(msg)
+		case ViewModeSubmodules:
+			return p.updateSubmodules(msg)
+		case ViewModeRemotes:
+			return p.updateRemotes(msg)
+		case ViewModeRemoteEdit:
+			return p.updateRemoteEdit(msg)
+		case ViewModePatchExport:
+			return p.updatePatchExport(msg)
+		case ViewModePatchApply:
+			return p.updatePatchApply(msg)
+		case ViewModeBisectStart:
+			return p.updateBisectStart(msg)
+		case ViewModeBisect:
+			return p.updateBisect(msg)
+		case ViewModeStashes:
+			return p.updateStashes(msg)
+		case ViewModeStashPush:
+			return p.updateStashPush(msg)
+		case ViewModeHookRun:
+			return p.updateHookRun(msg)
+		case ViewModeConfirmLargeFiles:
+			return p.updateConfirmLargeFiles(msg)
 		}
 
 	case tea.MouseMsg:
-		if p.inNoRepoMode() {
+		if p.inNoRepoMode() || p.diffOptionsMenu != nil {
 			return p, nil
 		}
 		// Handle mouse events based on view mode
@@ -389,13 +731,56 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p.handleStashPopMouse(msg)
 		case ViewModeError:
 			return p.handleErrorModalMouse(msg)
+		case ViewModeRebase:
+			return p.handleRebaseMouse(msg)
+		case ViewModeRebaseStopped:
+			return p.handleRebaseStoppedMouse(msg)
+		case ViewModeCherryPick:
+			return p.handleCherryPickMouse(msg)
+		case ViewModeConfirmReset:
+			return p.handleConfirmResetMouse(msg)
+		case ViewModeTags:
+			return p.handleTagsMouse(msg)
+		case ViewModeCreateTag:
+			return p.handleCreateTagMouse(msg)
+		case ViewModeRelease:
+			return p.handleReleaseMouse(msg)
+		case ViewModeReflog:
+			return p.handleReflogMouse(msg)
+		case ViewModeComparePick:
+			return p.handleComparePickMouse(msg)
+		case ViewModeCompare:
+			return p.handleCompareMouse(msg)
+		case ViewModeSubmodules:
+			return p.handleSubmodulesMouse(msg)
+		case ViewModeRemotes:
+			return p.handleRemotesMouse(msg)
+		case ViewModeRemoteEdit:
+			return p.handleRemoteEditMouse(msg)
+		case ViewModePatchExport:
+			return p.handlePatchExportMouse(msg)
+		case ViewModePatchApply:
+			return p.handlePatchApplyMouse(msg)
+		case ViewModeBisectStart:
+			return p.handleBisectStartMouse(msg)
+		case ViewModeBisect:
+			return p.handleBisectMouse(msg)
+		case ViewModeStashes:
+			return p.handleStashesMouse(msg)
+		case ViewModeStashPush:
+			return p.handleStashPushMouse(msg)
+		case ViewModeHookRun:
+			return p.handleHookRunMouse(msg)
+		case ViewModeConfirmLargeFiles:
+			return p.handleLargeFilesMouse(msg)
 		}
 
 	case app.RefreshMsg:
 		if p.inNoRepoMode() {
 			return p, p.detectRepo()
 		}
-		return p, p.refresh()
+		// The global undo may have moved HEAD
+		return p, tea.Batch(p.refresh(), p.loadRecentCommits())
 
 	case app.PluginFocusedMsg:
 		if p.inNoRepoMode() {
@@ -403,7 +788,12 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		}
 		// Refresh data when navigating to this plugin
 		p.lastRefresh = time.Now()
-		return p, tea.Batch(p.refresh(), p.loadRecentCommits())
+		var reloadConflicts tea.Cmd
+		if p.viewMode == ViewModeConflicts && p.conflictResolver != nil {
+			// Pick up edits made in the inline editor
+			reloadConflicts = p.conflictResolver.Reload()
+		}
+		return p, tea.Batch(p.refresh(), p.loadRecentCommits(), reloadConflicts)
 
 	case WatchStartedMsg:
 		if p.inNoRepoMode() {
@@ -451,9 +841,250 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.diffContent = msg.Content
 		p.diffRaw = msg.Raw
 		p.diffLoaded = true
+		p.diffImage = msg.Image
 		// Always parse diff for built-in rendering (even if delta is available)
 		// This allows toggling between delta and built-in rendering at runtime
 		p.parsedDiff, _ = ParseUnifiedDiff(msg.Raw)
+		if p.diffCommit != "" {
+			p.markQueryHunks(p.parsedDiff, p.diffFile)
+		}
+		p.clampDiffHunkCursor()
+		return p, nil
+
+	case HunkOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleHunkOpDone(msg)
+
+	case RebaseCommitsLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleRebaseCommitsLoaded(msg)
+
+	case RebaseResultMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleRebaseResult(msg)
+
+	case CherryPickTargetsLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleCherryPickTargets(msg)
+		return p, nil
+
+	case ResetPreviewLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleResetPreview(msg)
+		return p, nil
+
+	case CommitOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleCommitOpDone(msg)
+
+	case conflict.LoadedMsg, conflict.StagedMsg, conflict.EditReadyMsg, conflict.ContinuedMsg:
+		return p.updateConflictResolver(msg)
+
+	case RemoteTagsLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleRemoteTags(msg)
+		return p, nil
+
+	case TagOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleTagOpDone(msg)
+
+	case SubmoduleSummaryLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleSubmoduleSummary(msg)
+		return p, nil
+
+	case SubmodulesLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleSubmodulesLoaded(msg)
+		return p, nil
+
+	case SubmoduleOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleSubmoduleOpDone(msg)
+
+	case RemotesLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleRemotesLoaded(msg)
+		return p, nil
+
+	case RemoteOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleRemoteOpDone(msg)
+
+	case PatchWorktreesLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handlePatchWorktrees(msg)
+		return p, nil
+
+	case PatchPreviewLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handlePatchPreviewLoaded(msg)
+		return p, nil
+
+	case PatchOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handlePatchOpDone(msg)
+
+	case BisectOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleBisectOpDone(msg)
+
+	case BisectRunOutputMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleBisectRunOutput(msg)
+
+	case BisectRunTickMsg:
+		return p, p.handleBisectRunTick()
+
+	case BisectRunDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleBisectRunDone(msg)
+
+	case LFSStatusLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.lfsStatus = msg.Status
+		return p, nil
+
+	case HookOutputMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleHookOutput(msg)
+
+	case HookRunTickMsg:
+		return p, p.handleHookRunTick()
+
+	case SignaturesLoadedMsg:
+		p.handleSignaturesLoaded(msg)
+		return p, nil
+
+	case UndoWarningMsg:
+		return p.handleUndoWarning(msg)
+
+	case HookRunDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p.handleHookRunDone(msg)
+
+	case BisectCulpritLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleBisectCulpritLoaded(msg)
+		return p, nil
+
+	case StashesLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleStashesLoaded(msg)
+
+	case StashDiffLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleStashDiffLoaded(msg)
+		return p, nil
+
+	case StashOpDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleStashOpDone(msg)
+
+	case PushTargetLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handlePushTargetLoaded(msg)
+		return p, nil
+
+	case ReleaseSummaryLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleReleaseSummary(msg)
+		return p, nil
+
+	case ReflogLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleReflogLoaded(msg)
+
+	case ReflogPreviewLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleReflogPreview(msg)
+		return p, nil
+
+	case ReflogBranchDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleReflogBranchDone(msg)
+
+	case OpenCompareMsg:
+		return p, p.handleOpenCompare(msg)
+
+	case filebrowser.LineHistoryMsg:
+		return p, p.handleLineHistory(msg)
+
+	case CompareRefsLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleCompareRefsLoaded(msg)
+		return p, nil
+
+	case CompareLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleCompareLoaded(msg)
 		return p, nil
 
 	case CommitSuccessMsg:
@@ -465,10 +1096,23 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.commitError = ""
 		return p, p.refresh()
 
+	case CommitTemplateLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleCommitTemplateLoaded(msg)
+
 	case CommitErrorMsg:
 		// Commit failed, show error and keep message for retry
-		p.commitError = msg.Err.Error()
 		p.commitInProgress = false
+		var sigErr *SigningError
+		if errors.As(msg.Err, &sigErr) {
+			p.commitError = ""
+			p.showErrorModal("Commit Signing Failed", msg.Err)
+			p.errorReturnMode = ViewModeCommit
+			return p, nil
+		}
+		p.commitError = msg.Err.Error()
 		return p, nil
 
 	case InlineDiffLoadedMsg:
@@ -478,6 +1122,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		// Only update if this is still the selected file
 		if msg.File == p.selectedDiffFile {
 			p.diffPaneParsedDiff = msg.Parsed
+			p.diffPaneImage = msg.Image
 			// Clamp scroll to new content length (diff may have shrunk after stage/unstage)
 			if p.diffPaneParsedDiff != nil {
 				lines := countParsedDiffLines(p.diffPaneParsedDiff)
@@ -522,9 +1167,12 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.recentCommits = mergeRecentCommits(p.recentCommits, msg.Commits)
 		p.pushStatus = msg.PushStatus
 		PopulatePushStatus(p.recentCommits, p.pushStatus)
-		// Recompute graph for new commits
-		if p.showCommitGraph && len(p.recentCommits) > 0 {
-			p.commitGraphLines = ComputeGraphForCommits(p.recentCommits)
+		p.setTags(msg.Tags, msg.LatestTag, msg.SinceLatestTag)
+		p.signing = msg.Signing
+		p.bisect = msg.Bisect
+		// Refresh the graph; it is only recomputed if the history changed
+		if p.showCommitGraph {
+			p.updateCommitGraph(p.activeCommits())
 		}
 		if prevCommitHash != "" {
 			if idx := indexOfCommitHash(p.recentCommits, prevCommitHash); idx >= 0 {
@@ -554,12 +1202,11 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				p.moreCommitsAvailable = false
 			}
 			p.recentCommits = append(p.recentCommits, msg.Commits...)
-			// Recompute entire graph when commits are added
+			// Only the new page needs graph lines
 			if p.showCommitGraph {
-				commits := p.activeCommits()
-				p.commitGraphLines = ComputeGraphForCommits(commits)
+				p.updateCommitGraph(p.activeCommits())
 			}
-			return p, p.ensureCommitListFilled()
+			return p, tea.Batch(p.ensureCommitListFilled(), p.prefetchCommits())
 		}
 		p.moreCommitsAvailable = false
 		return p, nil
@@ -568,14 +1215,32 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		if plugin.IsStale(p.ctx, msg) {
 			return p, nil // Ignore stale message from previous project
 		}
+		appending := msg.Opts.Skip > 0
+		if appending {
+			p.loadingMoreCommits = false
+		}
+		if !p.historyFilterActive || !msg.matches(p.historyFilterOpts()) {
+			return p, nil // Filters changed while loading
+		}
+		if msg.Err != nil {
+			p.moreFilteredAvailable = false
+			p.showErrorModal("History Search Failed", msg.Err)
+			return p, nil
+		}
+		p.moreFilteredAvailable = len(msg.Commits) >= msg.Opts.Limit
+		if appending {
+			p.filteredCommits = append(p.filteredCommits, msg.Commits...)
+			if p.showCommitGraph {
+				p.updateCommitGraph(p.filteredCommits)
+			}
+			return p, tea.Batch(p.ensureCommitListFilled(), p.prefetchCommits())
+		}
 		if msg.Commits != nil {
 			p.filteredCommits = msg.Commits
 			p.pushStatus = msg.PushStatus
 			// Recompute graph for filtered commits
-			if p.showCommitGraph && len(p.filteredCommits) > 0 {
-				p.commitGraphLines = ComputeGraphForCommits(p.filteredCommits)
-			} else if len(p.filteredCommits) == 0 {
-				p.commitGraphLines = nil // Clear graph cache
+			if p.showCommitGraph || len(p.filteredCommits) == 0 {
+				p.updateCommitGraph(p.filteredCommits)
 			}
 			// Reset cursor to first commit when filter applied
 			entries := p.tree.AllEntries()
@@ -583,8 +1248,10 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				p.cursor = len(entries)
 				p.commitScrollOff = 0
 			}
+		} else {
+			p.filteredCommits = []*Commit{}
 		}
-		return p, nil
+		return p, tea.Batch(p.autoLoadCommitPreview(), p.ensureCommitListFilled())
 
 	case CommitStatsLoadedMsg:
 		if plugin.IsStale(p.ctx, msg) {
@@ -612,6 +1279,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		}
 		// Commit preview loaded for right pane (in status view)
 		p.previewCommit = msg.Commit
+		p.previewMatchFiles = msg.MatchFiles
 		p.previewCommitCursor = 0
 		p.previewCommitScroll = 0
 		// Copy stats to the commit in the list for inline display
@@ -638,7 +1306,11 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.pushSuccessTime = time.Now()
 		// Refresh to show updated push status
 		// Note: pushPreservedCommitHash will be used by RecentCommitsLoadedMsg to restore cursor
-		return p, tea.Batch(p.refresh(), p.loadRecentCommits(), p.clearPushSuccessAfterDelay())
+		cmds := []tea.Cmd{p.refresh(), p.loadRecentCommits(), p.clearPushSuccessAfterDelay()}
+		if p.viewMode == ViewModeRemotes {
+			cmds = append(cmds, p.loadRemotes())
+		}
+		return p, tea.Batch(cmds...)
 
 	case PushErrorMsg:
 		p.pushInProgress = false
@@ -751,6 +1423,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	case PullAbortedMsg:
 		p.pullConflictFiles = nil
 		p.pullConflictType = ""
+		p.pullConflictDir = ""
 		p.pullError = ""
 		return p, tea.Batch(p.refresh(), p.loadRecentCommits())
 
@@ -834,8 +1507,9 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 
 // CommitPreviewLoadedMsg is sent when commit preview is loaded.
 type CommitPreviewLoadedMsg struct {
-	Epoch  uint64 // Epoch when request was issued (for stale detection)
-	Commit *Commit
+	Epoch      uint64 // Epoch when request was issued (for stale detection)
+	Commit     *Commit
+	MatchFiles map[string]bool // Files matching the active history search
 }
 
 // GetEpoch implements plugin.EpochMessage.
@@ -868,12 +1542,7 @@ func (p *Plugin) View(width, height int) string {
 	} else {
 		switch p.viewMode {
 		case ViewModeDiff:
-			// Use two-pane layout when sidebar is visible, otherwise full-width diff
-			if p.sidebarVisible {
-				content = p.renderDiffTwoPane()
-			} else {
-				content = p.renderDiffModal()
-			}
+			content = p.renderDiffView()
 		case ViewModeCommit:
 			content = p.renderCommitModal()
 		case ViewModePushMenu:
@@ -890,6 +1559,50 @@ func (p *Plugin) View(width, height int) string {
 			content = p.renderBranchPicker()
 		case ViewModeError:
 			content = p.renderErrorModal()
+		case ViewModeRebase:
+			content = p.renderRebase()
+		case ViewModeRebaseStopped:
+			content = p.renderRebaseStopped()
+		case ViewModeCherryPick:
+			content = p.renderCherryPick()
+		case ViewModeConfirmReset:
+			content = p.renderConfirmReset()
+		case ViewModeConflicts:
+			content = p.renderConflicts()
+		case ViewModeTags:
+			content = p.renderTags()
+		case ViewModeCreateTag:
+			content = p.renderCreateTag()
+		case ViewModeRelease:
+			content = p.renderRelease()
+		case ViewModeSubmodules:
+			content = p.renderSubmodules()
+		case ViewModeRemotes:
+			content = p.renderRemotes()
+		case ViewModeRemoteEdit:
+			content = p.renderRemoteEdit()
+		case ViewModePatchExport:
+			content = p.renderPatchExport()
+		case ViewModePatchApply:
+			content = p.renderPatchApply()
+		case ViewModeBisectStart:
+			content = p.renderBisectStart()
+		case ViewModeBisect:
+			content = p.renderBisect()
+		case ViewModeStashes:
+			content = p.renderStashes()
+		case ViewModeStashPush:
+			content = p.renderStashPush()
+		case ViewModeHookRun:
+			content = p.renderHookRun()
+		case ViewModeConfirmLargeFiles:
+			content = p.renderConfirmLargeFiles()
+		case ViewModeReflog:
+			content = p.renderReflog()
+		case ViewModeComparePick:
+			content = p.renderComparePick()
+		case ViewModeCompare:
+			content = p.renderCompare()
 		default:
 			// Use three-pane layout for status view
 			content = p.renderThreePaneView()
@@ -905,6 +1618,9 @@ func (p *Plugin) View(width, height int) string {
 		modal := p.renderPathFilterModal(width)
 		content = ui.OverlayModal(content, modal, width, height)
 	}
+	if p.diffOptionsMenu != nil {
+		content = ui.OverlayModal(content, p.diffOptionsMenu.Render(width), width, height)
+	}
 
 	// Constrain output to allocated height to prevent header scrolling off-screen.
 	// MaxHeight truncates content that exceeds the allocated space.
@@ -941,9 +1657,20 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "stash", Name: "Stash", Description: "Stash changes", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
 		{ID: "stash-pop", Name: "Pop", Description: "Pop latest stash", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
 		{ID: "stash-apply", Name: "Apply", Description: "Apply latest stash", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
+		{ID: "show-stashes", Name: "Stashes", Description: "Browse, apply and manage stashes", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
 		{ID: "open-in-file-browser", Name: "Browse", Description: "Open file in file browser", Category: plugin.CategoryNavigation, Context: "git-status", Priority: 4},
-		{ID: "open-in-github", Name: "GitHub", Description: "Open commit in GitHub", Category: plugin.CategoryActions, Context: "git-status", Priority: 4},
+		{ID: "open-in-github", Name: "Web", Description: "Open commit in browser", Category: plugin.CategoryActions, Context: "git-status", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-status", Priority: 5},
+		{ID: "rebase", Name: "Rebase", Description: "Resume an in-progress rebase", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "show-tags", Name: "Tags", Description: "List and manage tags", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "show-reflog", Name: "Reflog", Description: "Browse the reflog and restore lost commits", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "compare", Name: "Compare", Description: "Compare two branches or refs", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "show-submodules", Name: "Submodules", Description: "List, init and update submodules", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "show-remotes", Name: "Remotes", Description: "Manage, fetch and push to remotes", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "export-patch", Name: "Export", Description: "Export changes as a patch file", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "apply-patch", Name: "Apply patch", Description: "Preview and apply a patch file", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "bisect", Name: "Bisect", Description: "Show the bisect in progress", Category: plugin.CategoryGit, Context: "git-status", Priority: 5},
+		{ID: "leave-submodule", Name: "Up", Description: "Return to the parent repository", Category: plugin.CategoryNavigation, Context: "git-status", Priority: 5},
 		// git-status-commits context (recent commits in sidebar)
 		{ID: "view-commit", Name: "View", Description: "View commit details", Category: plugin.CategoryView, Context: "git-status-commits", Priority: 1},
 		{ID: "push", Name: "Push", Description: "Push commits to remote", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 2},
@@ -957,8 +1684,17 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "prev-match", Name: "Prev", Description: "Previous search match", Category: plugin.CategoryNavigation, Context: "git-status-commits", Priority: 4},
 		{ID: "yank-commit", Name: "Yank", Description: "Copy commit as markdown", Category: plugin.CategoryActions, Context: "git-status-commits", Priority: 3},
 		{ID: "yank-id", Name: "YankID", Description: "Copy commit ID", Category: plugin.CategoryActions, Context: "git-status-commits", Priority: 3},
-		{ID: "open-in-github", Name: "GitHub", Description: "Open commit in GitHub", Category: plugin.CategoryActions, Context: "git-status-commits", Priority: 3},
+		{ID: "open-in-github", Name: "Web", Description: "Open commit in browser", Category: plugin.CategoryActions, Context: "git-status-commits", Priority: 3},
 		{ID: "toggle-graph", Name: "Graph", Description: "Toggle commit graph display", Category: plugin.CategoryView, Context: "git-status-commits", Priority: 2},
+		{ID: "rebase", Name: "Rebase", Description: "Interactive rebase from this commit", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 3},
+		{ID: "mark-commit", Name: "Mark", Description: "Select commit for cherry-pick/revert", Category: plugin.CategoryEdit, Context: "git-status-commits", Priority: 3},
+		{ID: "cherry-pick", Name: "Pick", Description: "Cherry-pick selected commits", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 3},
+		{ID: "revert-commit", Name: "Revert", Description: "Revert selected commits", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
+		{ID: "reset-to-commit", Name: "Reset", Description: "Reset branch to this commit", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
+		{ID: "tag-commit", Name: "Tag", Description: "Create a tag on this commit", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
+		{ID: "show-tags", Name: "Tags", Description: "List and manage tags", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
+		{ID: "export-patch", Name: "Export", Description: "Export selected commits with format-patch", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
+		{ID: "bisect", Name: "Bisect", Description: "Find the commit that introduced a bug", Category: plugin.CategoryGit, Context: "git-status-commits", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-status-commits", Priority: 5},
 		// git-history-search context (commit search modal)
 		{ID: "select", Name: "Select", Description: "Jump to selected match", Category: plugin.CategoryActions, Context: "git-history-search", Priority: 1},
@@ -966,6 +1702,7 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "navigate", Name: "Nav", Description: "Move through matches", Category: plugin.CategoryNavigation, Context: "git-history-search", Priority: 2},
 		{ID: "toggle-regex", Name: "Regex", Description: "Toggle regex mode", Category: plugin.CategoryView, Context: "git-history-search", Priority: 3},
 		{ID: "toggle-case", Name: "Case", Description: "Toggle case sensitivity", Category: plugin.CategoryView, Context: "git-history-search", Priority: 3},
+		{ID: "cycle-mode", Name: "Mode", Description: "Search loaded commits, messages, -S or -G across history", Category: plugin.CategorySearch, Context: "git-history-search", Priority: 2},
 		// git-path-filter context (path filter modal)
 		{ID: "apply-filter", Name: "Apply", Description: "Apply path filter", Category: plugin.CategorySearch, Context: "git-path-filter", Priority: 1},
 		{ID: "cancel", Name: "Cancel", Description: "Close path filter", Category: plugin.CategoryActions, Context: "git-path-filter", Priority: 1},
@@ -974,12 +1711,14 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "back", Name: "Back", Description: "Return to sidebar", Category: plugin.CategoryNavigation, Context: "git-commit-preview", Priority: 1},
 		{ID: "yank-commit", Name: "Yank", Description: "Copy commit as markdown", Category: plugin.CategoryActions, Context: "git-commit-preview", Priority: 3},
 		{ID: "yank-id", Name: "YankID", Description: "Copy commit ID", Category: plugin.CategoryActions, Context: "git-commit-preview", Priority: 3},
-		{ID: "open-in-github", Name: "GitHub", Description: "Open commit in GitHub", Category: plugin.CategoryActions, Context: "git-commit-preview", Priority: 3},
+		{ID: "open-in-github", Name: "Web", Description: "Open commit in browser", Category: plugin.CategoryActions, Context: "git-commit-preview", Priority: 3},
 		{ID: "open-in-file-browser", Name: "Browse", Description: "Open file in file browser", Category: plugin.CategoryNavigation, Context: "git-commit-preview", Priority: 3},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-commit-preview", Priority: 4},
+		{ID: "diff-options", Name: "Options", Description: "Diff options for commit diffs", Category: plugin.CategoryView, Context: "git-commit-preview", Priority: 4},
 		// git-status-diff context (inline diff pane)
 		{ID: "toggle-diff-view", Name: "View", Description: "Toggle unified/split diff view", Category: plugin.CategoryView, Context: "git-status-diff", Priority: 2},
 		{ID: "toggle-wrap", Name: "Wrap", Description: "Toggle line wrapping", Category: plugin.CategoryView, Context: "git-status-diff", Priority: 3},
+		{ID: "diff-options", Name: "Options", Description: "Whitespace, context and move detection", Category: plugin.CategoryView, Context: "git-status-diff", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-status-diff", Priority: 3},
 		// git-diff context
 		{ID: "close-diff", Name: "Close", Description: "Close diff view", Category: plugin.CategoryView, Context: "git-diff", Priority: 1},
@@ -987,15 +1726,29 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-diff", Priority: 2},
 		{ID: "toggle-diff-view", Name: "View", Description: "Toggle unified/split diff view", Category: plugin.CategoryView, Context: "git-diff", Priority: 3},
 		{ID: "toggle-wrap", Name: "Wrap", Description: "Toggle line wrapping", Category: plugin.CategoryView, Context: "git-diff", Priority: 3},
+		{ID: "diff-options", Name: "Options", Description: "Whitespace, context and move detection", Category: plugin.CategoryView, Context: "git-diff", Priority: 4},
 		{ID: "open-in-file-browser", Name: "Browse", Description: "Open file in file browser", Category: plugin.CategoryNavigation, Context: "git-diff", Priority: 4},
+		{ID: "stage-hunk", Name: "Stage", Description: "Stage selected hunk or lines", Category: plugin.CategoryGit, Context: "git-diff", Priority: 2},
+		{ID: "unstage-hunk", Name: "Unstage", Description: "Unstage selected hunk or lines", Category: plugin.CategoryGit, Context: "git-diff", Priority: 2},
+		{ID: "discard-hunk", Name: "Discard", Description: "Discard selected hunk or lines", Category: plugin.CategoryGit, Context: "git-diff", Priority: 3},
+		{ID: "stash-hunk", Name: "Stash", Description: "Stash selected hunk or lines", Category: plugin.CategoryGit, Context: "git-diff", Priority: 3},
+		{ID: "next-hunk", Name: "Hunk", Description: "Select next/previous hunk", Category: plugin.CategoryNavigation, Context: "git-diff", Priority: 3},
+		{ID: "select-lines", Name: "Lines", Description: "Select lines within hunk", Category: plugin.CategoryEdit, Context: "git-diff", Priority: 4},
 		// git-commit context
 		{ID: "execute-commit", Name: "Commit", Description: "Create commit with message", Category: plugin.CategoryGit, Context: "git-commit", Priority: 1},
 		{ID: "cancel", Name: "Cancel", Description: "Cancel commit", Category: plugin.CategoryActions, Context: "git-commit", Priority: 1},
+		{ID: "toggle-sign", Name: "Sign", Description: "Toggle signing the commit with -S", Category: plugin.CategoryGit, Context: "git-commit", Priority: 3},
+		{ID: "toggle-composer", Name: "Composer", Description: "Toggle the conventional commit composer", Category: plugin.CategoryGit, Context: "git-commit", Priority: 3},
 		// git-push-menu context
 		{ID: "push", Name: "Push", Description: "Push to remote", Category: plugin.CategoryGit, Context: "git-push-menu", Priority: 1},
 		{ID: "force-push", Name: "Force", Description: "Force push", Category: plugin.CategoryGit, Context: "git-push-menu", Priority: 1},
 		{ID: "push-upstream", Name: "Upstream", Description: "Push & set upstream", Category: plugin.CategoryGit, Context: "git-push-menu", Priority: 1},
+		{ID: "next-remote", Name: "Remote", Description: "Push to the next remote", Category: plugin.CategoryGit, Context: "git-push-menu", Priority: 2},
+		{ID: "edit-push-branch", Name: "Branch", Description: "Choose the remote branch to push to", Category: plugin.CategoryGit, Context: "git-push-menu", Priority: 2},
 		{ID: "cancel", Name: "Cancel", Description: "Cancel", Category: plugin.CategoryNavigation, Context: "git-push-menu", Priority: 2},
+		// git-push-branch context (push menu branch input)
+		{ID: "push", Name: "Push", Description: "Push to the chosen branch", Category: plugin.CategoryGit, Context: "git-push-branch", Priority: 1},
+		{ID: "cancel", Name: "Back", Description: "Back to push actions", Category: plugin.CategoryNavigation, Context: "git-push-branch", Priority: 2},
 		// git-pull-menu context
 		{ID: "pull-merge", Name: "Merge", Description: "Pull with merge", Category: plugin.CategoryGit, Context: "git-pull-menu", Priority: 1},
 		{ID: "pull-rebase", Name: "Rebase", Description: "Pull with rebase", Category: plugin.CategoryGit, Context: "git-pull-menu", Priority: 1},
@@ -1004,14 +1757,149 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "cancel", Name: "Cancel", Description: "Cancel", Category: plugin.CategoryNavigation, Context: "git-pull-menu", Priority: 2},
 		// git-pull-conflict context
 		{ID: "abort-pull", Name: "Abort", Description: "Abort merge/rebase", Category: plugin.CategoryGit, Context: "git-pull-conflict", Priority: 1},
+		{ID: "resolve-conflicts", Name: "Resolve", Description: "Open the conflict resolver", Category: plugin.CategoryGit, Context: "git-pull-conflict", Priority: 1},
+		{ID: "continue-conflict", Name: "Continue", Description: "Continue cherry-pick/revert", Category: plugin.CategoryGit, Context: "git-pull-conflict", Priority: 1},
 		{ID: "dismiss", Name: "Dismiss", Description: "Dismiss and resolve manually", Category: plugin.CategoryNavigation, Context: "git-pull-conflict", Priority: 2},
 		// git-error context (error modal)
 		{ID: "pull-from-error", Name: "Pull", Description: "Pull from remote", Category: plugin.CategoryGit, Context: "git-error", Priority: 1},
 		{ID: "dismiss", Name: "Dismiss", Description: "Dismiss error", Category: plugin.CategoryNavigation, Context: "git-error", Priority: 1},
 		{ID: "yank-error", Name: "Yank", Description: "Copy error to clipboard", Category: plugin.CategoryActions, Context: "git-error", Priority: 2},
+		// git-large-files context (staging large files outside Git LFS)
+		{ID: "confirm-stage", Name: "Stage", Description: "Stage the large files anyway", Category: plugin.CategoryGit, Context: "git-large-files", Priority: 1},
+		{ID: "dismiss", Name: "Cancel", Description: "Cancel staging", Category: plugin.CategoryNavigation, Context: "git-large-files", Priority: 2},
 		// git-stash-pop context (stash pop confirmation modal)
 		{ID: "confirm-pop", Name: "Pop", Description: "Confirm stash pop", Category: plugin.CategoryGit, Context: "git-stash-pop", Priority: 1},
 		{ID: "dismiss", Name: "Cancel", Description: "Cancel stash pop", Category: plugin.CategoryNavigation, Context: "git-stash-pop", Priority: 2},
+		// git-rebase context (interactive rebase editor)
+		{ID: "start-rebase", Name: "Start", Description: "Run the rebase", Category: plugin.CategoryGit, Context: "git-rebase", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Close without rebasing", Category: plugin.CategoryNavigation, Context: "git-rebase", Priority: 1},
+		{ID: "rebase-squash", Name: "Squash", Description: "Squash into previous commit", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 2},
+		{ID: "rebase-fixup", Name: "Fixup", Description: "Fixup into previous commit", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 2},
+		{ID: "rebase-reword", Name: "Reword", Description: "Edit commit message", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 2},
+		{ID: "rebase-drop", Name: "Drop", Description: "Drop commit", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 2},
+		{ID: "rebase-edit", Name: "Edit", Description: "Stop to amend commit", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 3},
+		{ID: "rebase-pick", Name: "Pick", Description: "Keep commit as is", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 3},
+		{ID: "move-down", Name: "Move", Description: "Move commit down/up", Category: plugin.CategoryEdit, Context: "git-rebase", Priority: 3},
+		// git-rebase-reword context (reword message modal)
+		{ID: "save-reword", Name: "Save", Description: "Save new commit message", Category: plugin.CategoryEdit, Context: "git-rebase-reword", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Discard message changes", Category: plugin.CategoryNavigation, Context: "git-rebase-reword", Priority: 1},
+		// git-rebase-stopped context (rebase stopped for edit or conflicts)
+		{ID: "continue-rebase", Name: "Continue", Description: "Continue the rebase", Category: plugin.CategoryGit, Context: "git-rebase-stopped", Priority: 1},
+		{ID: "resolve-conflicts", Name: "Resolve", Description: "Open the conflict resolver", Category: plugin.CategoryGit, Context: "git-rebase-stopped", Priority: 1},
+		{ID: "skip-rebase", Name: "Skip", Description: "Skip the current commit", Category: plugin.CategoryGit, Context: "git-rebase-stopped", Priority: 2},
+		{ID: "abort-rebase", Name: "Abort", Description: "Abort and restore the branch", Category: plugin.CategoryGit, Context: "git-rebase-stopped", Priority: 2},
+		{ID: "dismiss", Name: "Dismiss", Description: "Resolve from the status view", Category: plugin.CategoryNavigation, Context: "git-rebase-stopped", Priority: 3},
+		// git-cherry-pick context (cherry-pick target picker)
+		{ID: "select", Name: "Pick", Description: "Cherry-pick onto selected branch", Category: plugin.CategoryGit, Context: "git-cherry-pick", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel", Category: plugin.CategoryNavigation, Context: "git-cherry-pick", Priority: 2},
+		// git-reset context (reset confirmation modal)
+		{ID: "confirm-reset", Name: "Reset", Description: "Reset with selected mode", Category: plugin.CategoryGit, Context: "git-reset", Priority: 1},
+		{ID: "reset-mode", Name: "Mode", Description: "Select soft/mixed/hard", Category: plugin.CategoryEdit, Context: "git-reset", Priority: 2},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel reset", Category: plugin.CategoryNavigation, Context: "git-reset", Priority: 2},
+		// git-conflicts context (three-way conflict resolver)
+		{ID: "take-ours", Name: "Ours", Description: "Keep our side of the hunk", Category: plugin.CategoryEdit, Context: "git-conflicts", Priority: 1},
+		{ID: "take-theirs", Name: "Theirs", Description: "Keep their side of the hunk", Category: plugin.CategoryEdit, Context: "git-conflicts", Priority: 1},
+		{ID: "take-both", Name: "Both", Description: "Keep both sides, ours first", Category: plugin.CategoryEdit, Context: "git-conflicts", Priority: 2},
+		{ID: "stage-resolved", Name: "Stage", Description: "Stage the resolved file", Category: plugin.CategoryGit, Context: "git-conflicts", Priority: 1},
+		{ID: "continue-conflict", Name: "Continue", Description: "Continue the merge or rebase", Category: plugin.CategoryGit, Context: "git-conflicts", Priority: 1},
+		{ID: "edit-conflict", Name: "Edit", Description: "Edit the merged file", Category: plugin.CategoryEdit, Context: "git-conflicts", Priority: 2},
+		{ID: "abort-conflict", Name: "Abort", Description: "Abort the operation", Category: plugin.CategoryGit, Context: "git-conflicts", Priority: 3},
+		{ID: "cancel", Name: "Close", Description: "Close the resolver", Category: plugin.CategoryNavigation, Context: "git-conflicts", Priority: 2},
+		// git-tags context (tags panel)
+		{ID: "new-tag", Name: "New", Description: "Create a tag on HEAD", Category: plugin.CategoryGit, Context: "git-tags", Priority: 1},
+		{ID: "push-tag", Name: "Push", Description: "Push tag to remote", Category: plugin.CategoryGit, Context: "git-tags", Priority: 1},
+		{ID: "release-notes", Name: "Changes", Description: "Summarize changes since tag", Category: plugin.CategoryView, Context: "git-tags", Priority: 2},
+		{ID: "delete-tag", Name: "Delete", Description: "Delete local tag", Category: plugin.CategoryGit, Context: "git-tags", Priority: 2},
+		{ID: "delete-remote-tag", Name: "Delete remote", Description: "Delete tag from remote", Category: plugin.CategoryGit, Context: "git-tags", Priority: 3},
+		{ID: "cancel", Name: "Close", Description: "Close tags", Category: plugin.CategoryNavigation, Context: "git-tags", Priority: 2},
+		// git-submodules context (submodules panel)
+		{ID: "enter-submodule", Name: "Open", Description: "Show the submodule's status", Category: plugin.CategoryNavigation, Context: "git-submodules", Priority: 1},
+		{ID: "init-submodule", Name: "Init", Description: "Clone and check out the submodule", Category: plugin.CategoryGit, Context: "git-submodules", Priority: 1},
+		{ID: "update-submodule", Name: "Update", Description: "Check out the recorded commit", Category: plugin.CategoryGit, Context: "git-submodules", Priority: 2},
+		{ID: "stage-submodule", Name: "Stage", Description: "Stage the checked-out commit as the new pointer", Category: plugin.CategoryGit, Context: "git-submodules", Priority: 2},
+		{ID: "cancel", Name: "Close", Description: "Close submodules", Category: plugin.CategoryNavigation, Context: "git-submodules", Priority: 3},
+		// git-remotes context (remotes panel)
+		{ID: "fetch-remote", Name: "Fetch", Description: "Fetch the remote with --prune", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 1},
+		{ID: "push-to-remote", Name: "Push", Description: "Push to the remote", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 1},
+		{ID: "fetch-all", Name: "Fetch all", Description: "Fetch every remote with --prune", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 2},
+		{ID: "add-remote", Name: "Add", Description: "Add a remote", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 2},
+		{ID: "edit-remote", Name: "Edit", Description: "Rename the remote or change its URL", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 3},
+		{ID: "remove-remote", Name: "Remove", Description: "Remove the remote", Category: plugin.CategoryGit, Context: "git-remotes", Priority: 3},
+		{ID: "cancel", Name: "Close", Description: "Close remotes", Category: plugin.CategoryNavigation, Context: "git-remotes", Priority: 3},
+		// git-remote-edit context (add/edit remote modal)
+		{ID: "save-remote", Name: "Save", Description: "Save the remote", Category: plugin.CategoryGit, Context: "git-remote-edit", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel editing the remote", Category: plugin.CategoryActions, Context: "git-remote-edit", Priority: 1},
+		// git-patch-export context (export patch modal)
+		{ID: "save-patch", Name: "Save", Description: "Write the patch to the file", Category: plugin.CategoryGit, Context: "git-patch-export", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel the export", Category: plugin.CategoryActions, Context: "git-patch-export", Priority: 1},
+		// git-patch-apply context (apply patch modal)
+		{ID: "load-patch", Name: "Load", Description: "Read and check the patch file", Category: plugin.CategoryGit, Context: "git-patch-apply", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Close without applying", Category: plugin.CategoryActions, Context: "git-patch-apply", Priority: 1},
+		// git-bisect-start context (choosing the good and bad commits)
+		{ID: "start-bisect", Name: "Start", Description: "Start bisecting between the commits", Category: plugin.CategoryGit, Context: "git-bisect-start", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel the bisect", Category: plugin.CategoryActions, Context: "git-bisect-start", Priority: 1},
+		// git-bisect context (bisect panel)
+		{ID: "bisect-good", Name: "Good", Description: "Mark the checked-out commit good", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 1},
+		{ID: "bisect-bad", Name: "Bad", Description: "Mark the checked-out commit bad", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 1},
+		{ID: "bisect-skip", Name: "Skip", Description: "Skip a commit that cannot be tested", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 2},
+		{ID: "bisect-run", Name: "Run", Description: "Run a test command at each step", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 2},
+		{ID: "cancel-run", Name: "Stop", Description: "Cancel the running test command", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 3},
+		{ID: "show-culprit", Name: "Culprit", Description: "Show the first bad commit", Category: plugin.CategoryView, Context: "git-bisect", Priority: 3},
+		{ID: "reset-bisect", Name: "End", Description: "End the bisect and restore the branch", Category: plugin.CategoryGit, Context: "git-bisect", Priority: 3},
+		{ID: "cancel", Name: "Hide", Description: "Hide the bisect panel", Category: plugin.CategoryNavigation, Context: "git-bisect", Priority: 4},
+		// git-bisect-run context (test command input)
+		{ID: "run-bisect", Name: "Run", Description: "Run git bisect run with the command", Category: plugin.CategoryGit, Context: "git-bisect-run", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Back to the bisect panel", Category: plugin.CategoryNavigation, Context: "git-bisect-run", Priority: 1},
+		// git-stashes context (stash browser)
+		{ID: "apply-stash", Name: "Apply", Description: "Apply the selected stash", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 1},
+		{ID: "pop-stash", Name: "Pop", Description: "Apply and drop the selected stash", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 1},
+		{ID: "drop-stash", Name: "Drop", Description: "Drop the selected stash", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 2},
+		{ID: "checkout-stash-file", Name: "Check out", Description: "Check out the shown file from the stash", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 2},
+		{ID: "stash-branch", Name: "Branch", Description: "Create a branch from the stash", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 3},
+		{ID: "rename-stash", Name: "Rename", Description: "Change the stash message", Category: plugin.CategoryEdit, Context: "git-stashes", Priority: 3},
+		{ID: "new-stash", Name: "New", Description: "Stash chosen files", Category: plugin.CategoryGit, Context: "git-stashes", Priority: 3},
+		{ID: "toggle-diff-view", Name: "View", Description: "Toggle unified/side-by-side diff", Category: plugin.CategoryView, Context: "git-stashes", Priority: 4},
+		{ID: "cancel", Name: "Close", Description: "Close the stash browser", Category: plugin.CategoryNavigation, Context: "git-stashes", Priority: 4},
+		// git-stash-input context (branch name or stash message)
+		{ID: "confirm", Name: "Save", Description: "Create the branch or rename the stash", Category: plugin.CategoryGit, Context: "git-stash-input", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Back to the stash browser", Category: plugin.CategoryNavigation, Context: "git-stash-input", Priority: 1},
+		// git-stash-push context (stash chosen files)
+		{ID: "stash-files", Name: "Stash", Description: "Stash the ticked files", Category: plugin.CategoryGit, Context: "git-stash-push", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Back to the stash browser", Category: plugin.CategoryNavigation, Context: "git-stash-push", Priority: 1},
+		// git-hook-output context (commit or push hook output)
+		{ID: "cancel-hook-run", Name: "Cancel", Description: "Stop the running hooks", Category: plugin.CategoryGit, Context: "git-hook-output", Priority: 1},
+		{ID: "open-hook-location", Name: "Open", Description: "Open the selected problem in the file browser", Category: plugin.CategoryNavigation, Context: "git-hook-output", Priority: 1},
+		{ID: "retry-hook-run", Name: "Retry", Description: "Run the commit or push again", Category: plugin.CategoryGit, Context: "git-hook-output", Priority: 2},
+		{ID: "commit-no-verify", Name: "No verify", Description: "Commit without running hooks", Category: plugin.CategoryGit, Context: "git-hook-output", Priority: 3},
+		{ID: "cancel", Name: "Close", Description: "Close the hook output", Category: plugin.CategoryNavigation, Context: "git-hook-output", Priority: 4},
+		// git-create-tag context (create tag modal)
+		{ID: "create-tag", Name: "Create", Description: "Create the tag", Category: plugin.CategoryGit, Context: "git-create-tag", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel tag creation", Category: plugin.CategoryActions, Context: "git-create-tag", Priority: 1},
+		// git-release context (changes since a tag)
+		{ID: "yank-release-notes", Name: "Yank", Description: "Copy release notes as markdown", Category: plugin.CategoryActions, Context: "git-release", Priority: 1},
+		{ID: "cancel", Name: "Back", Description: "Return to tags", Category: plugin.CategoryNavigation, Context: "git-release", Priority: 2},
+		// git-reflog context (reflog browser)
+		{ID: "reset-to-entry", Name: "Reset", Description: "Reset the current branch to this entry", Category: plugin.CategoryGit, Context: "git-reflog", Priority: 1},
+		{ID: "branch-at-entry", Name: "Branch", Description: "Create a branch at this entry", Category: plugin.CategoryGit, Context: "git-reflog", Priority: 1},
+		{ID: "next-ref", Name: "Ref", Description: "Show the next ref's reflog", Category: plugin.CategoryNavigation, Context: "git-reflog", Priority: 2},
+		{ID: "cancel", Name: "Close", Description: "Close reflog", Category: plugin.CategoryNavigation, Context: "git-reflog", Priority: 2},
+		// git-reflog-branch context (naming a branch at a reflog entry)
+		{ID: "create-branch", Name: "Create", Description: "Create the branch", Category: plugin.CategoryGit, Context: "git-reflog-branch", Priority: 1},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel branch creation", Category: plugin.CategoryActions, Context: "git-reflog-branch", Priority: 1},
+		// git-compare-pick context (choosing refs to compare)
+		{ID: "run-compare", Name: "Compare", Description: "Compare the chosen refs", Category: plugin.CategoryGit, Context: "git-compare-pick", Priority: 1},
+		{ID: "switch-side", Name: "Side", Description: "Switch between base and compare", Category: plugin.CategoryNavigation, Context: "git-compare-pick", Priority: 2},
+		{ID: "cancel", Name: "Cancel", Description: "Cancel compare", Category: plugin.CategoryActions, Context: "git-compare-pick", Priority: 2},
+		// git-compare context (comparison of two refs)
+		{ID: "next-tab", Name: "Tab", Description: "Switch between commits, files and diff", Category: plugin.CategoryNavigation, Context: "git-compare", Priority: 1},
+		{ID: "toggle-diff-view", Name: "View", Description: "Toggle unified/side-by-side diff", Category: plugin.CategoryView, Context: "git-compare", Priority: 2},
+		{ID: "swap-sides", Name: "Swap", Description: "Swap base and compare", Category: plugin.CategoryGit, Context: "git-compare", Priority: 2},
+		{ID: "change-refs", Name: "Refs", Description: "Pick different refs", Category: plugin.CategoryGit, Context: "git-compare", Priority: 3},
+		{ID: "cancel", Name: "Close", Description: "Close compare", Category: plugin.CategoryNavigation, Context: "git-compare", Priority: 3},
+		// git-diff-options context
+		{ID: "next-value", Name: "Change", Description: "Next value", Category: plugin.CategoryActions, Context: "git-diff-options", Priority: 1},
+		{ID: "reset-options", Name: "Reset", Description: "Restore git's defaults", Category: plugin.CategoryActions, Context: "git-diff-options", Priority: 2},
+		{ID: "close", Name: "Close", Description: "Close diff options", Category: plugin.CategoryNavigation, Context: "git-diff-options", Priority: 3},
 	}
 }
 
@@ -1026,6 +1914,9 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 	if p.pathFilterMode {
 		return keymap.ContextGitPathFilter
 	}
+	if p.diffOptionsMenu != nil {
+		return keymap.ContextGitDiffOptions
+	}
 
 	switch p.viewMode {
 	case ViewModeDiff:
@@ -1033,6 +1924,9 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 	case ViewModeCommit:
 		return keymap.ContextGitCommit
 	case ViewModePushMenu:
+		if p.pushMenuBranchFocused() {
+			return keymap.ContextGitPushBranch
+		}
 		return keymap.ContextGitPushMenu
 	case ViewModePullMenu:
 		return keymap.ContextGitPullMenu
@@ -1042,6 +1936,62 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 		return keymap.ContextGitError
 	case ViewModeConfirmStashPop:
 		return keymap.ContextGitStashPop
+	case ViewModeRebase:
+		if p.rebaseRewording {
+			return keymap.ContextGitRebaseReword
+		}
+		return keymap.ContextGitRebase
+	case ViewModeRebaseStopped:
+		return keymap.ContextGitRebaseStopped
+	case ViewModeCherryPick:
+		return keymap.ContextGitCherryPick
+	case ViewModeConfirmReset:
+		return keymap.ContextGitReset
+	case ViewModeConflicts:
+		return keymap.ContextGitConflicts
+	case ViewModeTags:
+		return keymap.ContextGitTags
+	case ViewModeCreateTag:
+		return keymap.ContextGitCreateTag
+	case ViewModeRelease:
+		return keymap.ContextGitRelease
+	case ViewModeReflog:
+		if p.reflogBranching {
+			return keymap.ContextGitReflogBranch
+		}
+		return keymap.ContextGitReflog
+	case ViewModeComparePick:
+		return keymap.ContextGitComparePick
+	case ViewModeCompare:
+		return keymap.ContextGitCompare
+	case ViewModeSubmodules:
+		return keymap.ContextGitSubmodules
+	case ViewModeRemotes:
+		return keymap.ContextGitRemotes
+	case ViewModeRemoteEdit:
+		return keymap.ContextGitRemoteEdit
+	case ViewModePatchExport:
+		return keymap.ContextGitPatchExport
+	case ViewModePatchApply:
+		return keymap.ContextGitPatchApply
+	case ViewModeBisectStart:
+		return keymap.ContextGitBisectStart
+	case ViewModeBisect:
+		if p.bisectEditingCmd {
+			return keymap.ContextGitBisectRun
+		}
+		return keymap.ContextGitBisect
+	case ViewModeStashes:
+		if p.stashInput != "" {
+			return keymap.ContextGitStashInput
+		}
+		return keymap.ContextGitStashes
+	case ViewModeStashPush:
+		return keymap.ContextGitStashPush
+	case ViewModeHookRun:
+		return keymap.ContextGitHookOutput
+	case ViewModeConfirmLargeFiles:
+		return keymap.ContextGitLargeFiles
 	default:
 		if p.activePane == PaneDiff {
 			// Commit preview pane has different context than file diff pane
@@ -1061,7 +2011,12 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 // ConsumesTextInput reports whether the plugin is currently in a mode where
 // printable keys should be treated as text input.
 func (p *Plugin) ConsumesTextInput() bool {
-	return p.viewMode == ViewModeCommit || p.historySearchMode || p.pathFilterMode
+	return p.viewMode == ViewModeCommit || p.viewMode == ViewModeCreateTag || p.historySearchMode || p.pathFilterMode ||
+		(p.viewMode == ViewModeRebase && p.rebaseRewording) || (p.viewMode == ViewModeReflog && p.reflogBranching) || p.viewMode == ViewModeComparePick ||
+		p.viewMode == ViewModeRemoteEdit || p.pushMenuBranchFocused() ||
+		p.viewMode == ViewModePatchExport || p.viewMode == ViewModePatchApply ||
+		p.viewMode == ViewModeBisectStart || (p.viewMode == ViewModeBisect && p.bisectEditingCmd) ||
+		(p.viewMode == ViewModeStashes && p.stashInput != "") || p.viewMode == ViewModeStashPush
 }
 
 // Diagnostics returns plugin health info.
@@ -1078,6 +2033,7 @@ func (p *Plugin) Diagnostics() []plugin.Diagnostic {
 	}
 	return []plugin.Diagnostic{
 		{ID: "git-status", Status: status, Detail: detail},
+		p.lfsDiagnostic(),
 	}
 }
 
@@ -1199,9 +2155,10 @@ type WatchEventMsg struct{}
 type WatchStartedMsg struct{ Watcher *Watcher }
 type ErrorMsg struct{ Err error }
 type DiffLoadedMsg struct {
-	Epoch   uint64 // Epoch when request was issued (for stale detection)
-	Content string // Rendered content (may be from delta)
-	Raw     string // Raw diff for built-in rendering
+	Epoch   uint64     // Epoch when request was issued (for stale detection)
+	Content string     // Rendered content (may be from delta)
+	Raw     string     // Raw diff for built-in rendering
+	Image   *ImageDiff // Both versions of a changed image, if it is one
 }
 
 // GetEpoch implements plugin.EpochMessage.
@@ -1221,6 +2178,7 @@ type InlineDiffLoadedMsg struct {
 	File   string
 	Raw    string
 	Parsed *ParsedDiff
+	Image  *ImageDiff // Both versions of a changed image, if it is one
 }
 
 // GetEpoch implements plugin.EpochMessage.
@@ -1228,9 +2186,14 @@ func (m InlineDiffLoadedMsg) GetEpoch() uint64 { return m.Epoch }
 
 // RecentCommitsLoadedMsg is sent when recent commits are loaded for sidebar.
 type RecentCommitsLoadedMsg struct {
-	Epoch      uint64 // Epoch when request was issued (for stale detection)
-	Commits    []*Commit
-	PushStatus *PushStatus
+	Epoch          uint64 // Epoch when request was issued (for stale detection)
+	Commits        []*Commit
+	PushStatus     *PushStatus
+	Tags           []*Tag // Local tags, newest first
+	LatestTag      string // Most recent tag reachable from HEAD
+	SinceLatestTag int    // Commits on HEAD since LatestTag
+	Signing        SigningConfig
+	Bisect         *BisectState
 }
 
 // GetEpoch implements plugin.EpochMessage.
@@ -1251,6 +2214,15 @@ type FilteredCommitsLoadedMsg struct {
 	Epoch      uint64 // Epoch when request was issued (for stale detection)
 	Commits    []*Commit
 	PushStatus *PushStatus
+	Opts       HistoryFilterOpts // Filters and page the commits were loaded with
+	Err        error
+}
+
+// matches reports whether the message was loaded for the filters opts.
+func (m FilteredCommitsLoadedMsg) matches(opts HistoryFilterOpts) bool {
+	loaded := m.Opts
+	loaded.Limit, loaded.Skip = 0, 0
+	return loaded == opts
 }
 
 // GetEpoch implements plugin.EpochMessage.
@@ -1366,6 +2338,7 @@ func (p *Plugin) initCommitTextarea() {
 	p.commitButtonHover = false
 	p.commitModal = nil
 	p.commitModalWidthCache = 0
+	p.composer = nil
 }
 
 // clearPushSuccessAfterDelay returns a command that clears the push success indicator after 3 seconds.
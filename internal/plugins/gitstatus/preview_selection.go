@@ -76,6 +76,11 @@ func (p *Plugin) autoLoadDiff() tea.Cmd {
 	if entry.IsFolder {
 		return p.loadFolderDiff(entry)
 	}
+	// Submodules show their state and log instead of a diff
+	if entry.Submodule != nil {
+		p.diffPaneParsedDiff = nil
+		return p.loadSubmoduleSummary(entry.Path)
+	}
 
 	return p.loadInlineDiff(entry.Path, entry.Staged, entry.Status)
 }
@@ -19,10 +19,19 @@ const (
 	pullMenuModalWidth = 50 // Default modal width
 	pullMenuMinWidth   = 20 // Minimum modal width
 
-	pullConflictAbortID   = "pull-conflict-abort"
-	pullConflictDismissID = "pull-conflict-dismiss"
+	pullConflictAbortID    = "pull-conflict-abort"
+	pullConflictContinueID = "pull-conflict-continue"
+	pullConflictDismissID  = "pull-conflict-dismiss"
 )
 
+// pullConflictLabels names the operation that produced conflicts.
+var pullConflictLabels = map[string]string{
+	"merge":            "Merge",
+	"rebase":           "Rebase",
+	commitOpCherryPick: "Cherry-pick",
+	commitOpRevert:     "Revert",
+}
+
 // ensurePullModal builds/rebuilds the pull menu modal.
 func (p *Plugin) ensurePullModal() {
 	modalW := pullMenuModalWidth
@@ -81,11 +90,25 @@ func (p *Plugin) ensurePullConflictModal() {
 	}
 	p.pullConflictWidth = modalW
 
+	primary := pullConflictAbortID
+	buttons := []modal.ButtonDef{
+		modal.Btn(" Abort ", pullConflictAbortID, modal.BtnDanger()),
+		modal.Btn(" Dismiss ", pullConflictDismissID),
+	}
+	if p.conflictContinuable() {
+		primary = pullConflictContinueID
+		buttons = append([]modal.ButtonDef{modal.Btn(" Continue ", pullConflictContinueID)}, buttons...)
+	}
+	if len(p.pullConflictFiles) > 0 {
+		primary = pullConflictResolveID
+		buttons = append([]modal.ButtonDef{modal.Btn(" Resolve ", pullConflictResolveID)}, buttons...)
+	}
+
 	p.pullConflictModal = modal.New("Conflicts",
 		modal.WithWidth(modalW),
 		modal.WithVariant(modal.VariantDanger),
 		modal.WithHints(false),
-		modal.WithPrimaryAction(pullConflictAbortID),
+		modal.WithPrimaryAction(primary),
 	).
 		AddSection(p.pullConflictSummarySection()).
 		AddSection(modal.Spacer()).
@@ -93,10 +116,13 @@ func (p *Plugin) ensurePullConflictModal() {
 		AddSection(modal.Spacer()).
 		AddSection(p.pullConflictResolutionSection()).
 		AddSection(modal.Spacer()).
-		AddSection(modal.Buttons(
-			modal.Btn(" Abort ", pullConflictAbortID, modal.BtnDanger()),
-			modal.Btn(" Dismiss ", pullConflictDismissID),
-		))
+		AddSection(modal.Buttons(buttons...))
+}
+
+// conflictContinuable reports whether the conflicted operation can be
+// continued from the modal once files are resolved and staged.
+func (p *Plugin) conflictContinuable() bool {
+	return p.pullConflictType == commitOpCherryPick || p.pullConflictType == commitOpRevert
 }
 
 // renderPullConflict renders the pull conflict resolution modal.
@@ -114,9 +140,9 @@ func (p *Plugin) renderPullConflict() string {
 
 func (p *Plugin) pullConflictSummarySection() modal.Section {
 	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
-		conflictLabel := "Merge"
-		if p.pullConflictType == "rebase" {
-			conflictLabel = "Rebase"
+		conflictLabel, ok := pullConflictLabels[p.pullConflictType]
+		if !ok {
+			conflictLabel = "Merge"
 		}
 		content := styles.Muted.Render(fmt.Sprintf("%s produced conflicts in %d file(s):", conflictLabel, len(p.pullConflictFiles)))
 		return modal.RenderedSection{Content: content}
@@ -147,7 +173,11 @@ func (p *Plugin) pullConflictFilesSection() modal.Section {
 
 func (p *Plugin) pullConflictResolutionSection() modal.Section {
 	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
-		content := styles.Muted.Render("Resolve conflicts in your editor, then commit.")
+		hint := "Resolve conflicts (r) or fix them in your editor, then commit."
+		if p.conflictContinuable() {
+			hint = "Resolve conflicts (r) and stage the files, then continue."
+		}
+		content := styles.Muted.Render(hint)
 		return modal.RenderedSection{Content: content}
 	}, nil)
 }
@@ -4,8 +4,9 @@ import "github.com/guyghost/sidecar/internal/git"
 
 // Re-export push types from internal/git for backward compatibility.
 type (
-	PushStatus = git.PushStatus
-	PushError  = git.PushError
+	PushStatus  = git.PushStatus
+	PushError   = git.PushError
+	PushOptions = git.PushOptions
 )
 
 // Re-export push functions.
@@ -14,7 +15,12 @@ var (
 	ExecutePush            = git.ExecutePush
 	ExecutePushForce       = git.ExecutePushForce
 	ExecutePushSetUpstream = git.ExecutePushSetUpstream
+	ExecutePushTo          = git.ExecutePushTo
+	StreamPush             = git.StreamPush
+	GetPushStatusFor       = git.GetPushStatusFor
+	GetPushTarget          = git.GetPushTarget
 	GetRemoteName          = git.GetRemoteName
+	GetRemoteNames         = git.GetRemoteNames
 	HasRemote              = git.HasRemote
 	ParsePushOutput        = git.ParsePushOutput
 	IsPushRejectedError    = git.IsPushRejectedError
@@ -1,6 +1,12 @@
 package gitstatus
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/modal"
 	"github.com/guyghost/sidecar/internal/styles"
 	"github.com/guyghost/sidecar/internal/ui"
@@ -11,10 +17,106 @@ const (
 	pushMenuOptionForce    = "push-menu-force"
 	pushMenuOptionUpstream = "push-menu-upstream"
 	pushMenuActionID       = "push-menu-action"
+	pushMenuListID         = "push-options"
+	pushMenuBranchID       = "push-menu-branch"
 
 	pushMenuMinWidth = 20
 )
 
+// PushTargetLoadedMsg is sent when the push menu's remotes and the state
+// of HEAD against the chosen remote branch are read.
+type PushTargetLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Remotes []string
+	Primary string // Remote the default push actions use
+	Remote  string
+	Branch  string
+	Status  *PushStatus
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m PushTargetLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openPushMenu opens the push menu aimed at branch on remote, or at the
+// current branch's push target when remote is empty.
+func (p *Plugin) openPushMenu(remote, branch string) tea.Cmd {
+	p.pushMenuReturnMode = p.viewMode
+	p.viewMode = ViewModePushMenu
+	p.pushMenuFocus = 0
+	p.pushMenuRemote = remote
+	p.pushMenuTarget = nil
+	p.pushMenuBranch = textinput.New()
+	p.pushMenuBranch.Placeholder = "branch"
+	p.pushMenuBranch.CharLimit = 200
+	p.pushMenuBranch.SetValue(branch)
+	p.clearPushMenuModal()
+	return p.loadPushTarget(remote, branch)
+}
+
+// loadPushTarget lists the remotes and compares HEAD with branch on remote.
+// An empty remote resolves to the current branch's push target.
+func (p *Plugin) loadPushTarget(remote, branch string) tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		if remote == "" {
+			remote, branch = GetPushTarget(workDir)
+		}
+		return PushTargetLoadedMsg{
+			Epoch:   epoch,
+			Remotes: GetRemoteNames(workDir),
+			Primary: GetRemoteName(workDir),
+			Remote:  remote,
+			Branch:  branch,
+			Status:  GetPushStatusFor(workDir, remote+"/"+branch),
+		}
+	}
+}
+
+// handlePushTargetLoaded shows the push target unless the menu has closed
+// or moved on to another remote or branch.
+func (p *Plugin) handlePushTargetLoaded(msg PushTargetLoadedMsg) {
+	if p.viewMode != ViewModePushMenu {
+		return
+	}
+	if p.pushMenuRemote == "" {
+		p.pushMenuRemote = msg.Remote
+		p.pushMenuBranch.SetValue(msg.Branch)
+	} else if msg.Remote != p.pushMenuRemote || msg.Branch != strings.TrimSpace(p.pushMenuBranch.Value()) {
+		return
+	}
+	p.pushMenuRemotes = msg.Remotes
+	p.pushMenuPrimary = msg.Primary
+	p.pushMenuCurrent = msg.Status.CurrentBranch
+	p.pushMenuTarget = msg.Status
+}
+
+// cyclePushRemote moves the push menu's remote picker by delta.
+func (p *Plugin) cyclePushRemote(delta int) tea.Cmd {
+	n := len(p.pushMenuRemotes)
+	if n < 2 {
+		return nil
+	}
+	idx := slices.Index(p.pushMenuRemotes, p.pushMenuRemote)
+	p.pushMenuRemote = p.pushMenuRemotes[((idx+delta)%n+n)%n]
+	p.pushMenuTarget = nil
+	return p.loadPushTarget(p.pushMenuRemote, strings.TrimSpace(p.pushMenuBranch.Value()))
+}
+
+// pushMenuBranchFocused reports whether the push menu's branch input has
+// focus.
+func (p *Plugin) pushMenuBranchFocused() bool {
+	return p.viewMode == ViewModePushMenu && p.pushMenuModal != nil && p.pushMenuModal.FocusedID() == pushMenuBranchID
+}
+
+// pushMenuCustomTarget reports whether the push menu aims somewhere other
+// than the current branch's namesake on the primary remote, which the
+// default push actions use.
+func (p *Plugin) pushMenuCustomTarget() bool {
+	branch := strings.TrimSpace(p.pushMenuBranch.Value())
+	return p.pushMenuRemote != "" && (p.pushMenuRemote != p.pushMenuPrimary || branch != p.pushMenuCurrent)
+}
+
 func (p *Plugin) ensurePushMenuModal() {
 	modalW := ui.ModalWidthMedium
 	if modalW > p.width-4 {
@@ -30,7 +132,7 @@ func (p *Plugin) ensurePushMenuModal() {
 	p.pushMenuModalWidth = modalW
 
 	items := []modal.ListItem{
-		{ID: pushMenuOptionPush, Label: "Push to origin"},
+		{ID: pushMenuOptionPush, Label: "Push"},
 		{ID: pushMenuOptionForce, Label: "Force push (--force-with-lease)"},
 		{ID: pushMenuOptionUpstream, Label: "Push & set upstream (-u)"},
 	}
@@ -40,7 +142,11 @@ func (p *Plugin) ensurePushMenuModal() {
 		modal.WithPrimaryAction(pushMenuActionID),
 		modal.WithHints(false),
 	).
-		AddSection(modal.List("push-options", items, &p.pushMenuFocus, modal.WithMaxVisible(len(items)))).
+		AddSection(p.pushMenuTargetSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.List(pushMenuListID, items, &p.pushMenuFocus, modal.WithMaxVisible(len(items)))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(pushMenuBranchID, "Branch", &p.pushMenuBranch)).
 		AddSection(modal.Spacer()).
 		AddSection(p.pushMenuHintsSection())
 }
@@ -50,11 +156,50 @@ func (p *Plugin) clearPushMenuModal() {
 	p.pushMenuModalWidth = 0
 }
 
+// pushMenuTargetSection shows the chosen remote and how HEAD compares to
+// the chosen branch on it.
+func (p *Plugin) pushMenuTargetSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if p.pushMenuRemote == "" {
+			return modal.RenderedSection{Content: styles.Muted.Render("Loading remotes...")}
+		}
+		remote := styles.Body.Render(p.pushMenuRemote)
+		if len(p.pushMenuRemotes) > 1 {
+			remote = styles.Muted.Render("‹ ") + remote + styles.Muted.Render(" ›")
+		}
+		lines := []string{styles.Muted.Render("Remote  ") + remote}
+
+		branch := strings.TrimSpace(p.pushMenuBranch.Value())
+		ref := p.pushMenuRemote + "/" + branch
+		var state string
+		switch ps := p.pushMenuTarget; {
+		case branch == "":
+			state = styles.Muted.Render("Enter a branch to push to")
+		case ps == nil:
+			state = styles.Muted.Render("Comparing with " + ref + "...")
+		case !ps.HasUpstream:
+			state = styles.StatusUntracked.Render(ref + " does not exist yet")
+		case ps.Ahead == 0 && ps.Behind == 0:
+			state = styles.StatusStaged.Render("Up to date with " + ref)
+		default:
+			style := styles.StatusModified
+			if ps.NeedsForce() {
+				style = styles.StatusDeleted
+			}
+			state = style.Render("↑"+strconv.Itoa(ps.Ahead)+" ↓"+strconv.Itoa(ps.Behind)) + styles.Muted.Render(" against "+ref)
+		}
+		lines = append(lines, styles.Muted.Render("        ")+state)
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
 func (p *Plugin) pushMenuHintsSection() modal.Section {
 	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
-		return modal.RenderedSection{
-			Content: styles.Muted.Render("p/f/u shortcut · Enter to select · Esc to cancel"),
+		hints := "p/f/u shortcut · ←/→ remote · b branch · Enter to select · Esc to cancel"
+		if focusID == pushMenuBranchID {
+			hints = "Enter to push · Esc back to actions"
 		}
+		return modal.RenderedSection{Content: styles.Muted.Render(hints)}
 	}, nil)
 }
 
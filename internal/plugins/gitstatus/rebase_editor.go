@@ -0,0 +1,832 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	rebaseStartID      = "rebase-start"
+	rebaseMessageID    = "rebase-message"
+	rebaseRewordSaveID = "rebase-reword-save"
+	rebaseContinueID   = "rebase-continue"
+	rebaseSkipID       = "rebase-skip"
+	rebaseAbortID      = "rebase-abort"
+)
+
+// Rebase operations reported by RebaseResultMsg.
+const (
+	rebaseOpStart    = "start"
+	rebaseOpContinue = "continue"
+	rebaseOpSkip     = "skip"
+	rebaseOpAbort    = "abort"
+	rebaseOpResume   = "resume" // Reopened a rebase that was already stopped
+)
+
+// rebaseOpTitles maps an operation to the error modal title for its failure.
+var rebaseOpTitles = map[string]string{
+	rebaseOpStart:    "Rebase Failed",
+	rebaseOpContinue: "Continue Failed",
+	rebaseOpSkip:     "Skip Failed",
+	rebaseOpAbort:    "Abort Failed",
+	rebaseOpResume:   "Rebase Failed",
+}
+
+// RebaseCommitsLoadedMsg is sent when the commits for the rebase editor load.
+type RebaseCommitsLoadedMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Base  string // Base commit; empty rebases from the root
+	Items []RebaseTodoItem
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m RebaseCommitsLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// RebaseResultMsg is sent when a rebase command returns.
+type RebaseResultMsg struct {
+	Epoch    uint64 // Epoch when request was issued (for stale detection)
+	Op       string // rebaseOpStart, rebaseOpContinue, ...
+	Result   *RebaseResult
+	Progress *RebaseProgress // Set when the rebase stopped
+	Err      error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m RebaseResultMsg) GetEpoch() uint64 { return m.Epoch }
+
+// rebaseResultMsg builds a RebaseResultMsg, reading progress if the rebase stopped.
+func rebaseResultMsg(epoch uint64, workDir, op string, result *RebaseResult, err error) RebaseResultMsg {
+	msg := RebaseResultMsg{Epoch: epoch, Op: op, Result: result, Err: err}
+	if err == nil && result != nil && result.Stopped {
+		msg.Progress = GetRebaseProgress(workDir)
+	}
+	return msg
+}
+
+// openRebase loads the rebase editor for the selected commit and everything
+// after it. If a rebase is already in progress, the stopped view opens instead.
+func (p *Plugin) openRebase() tea.Cmd {
+	var commit *Commit
+	if p.cursorOnCommit() {
+		commits := p.activeCommits()
+		if idx := p.selectedCommitIndex(); idx >= 0 && idx < len(commits) {
+			commit = commits[idx]
+		}
+	}
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		if progress := GetRebaseProgress(workDir); progress != nil {
+			result := &RebaseResult{Stopped: true, Conflicts: progress.Conflicts}
+			return RebaseResultMsg{Epoch: epoch, Op: rebaseOpResume, Result: result, Progress: progress}
+		}
+		if commit == nil {
+			return nil
+		}
+		base := ""
+		if len(commit.ParentHashes) > 0 {
+			base = commit.ParentHashes[0]
+		}
+		items, err := GetRebaseCommits(workDir, base)
+		return RebaseCommitsLoadedMsg{Epoch: epoch, Base: base, Items: items, Err: err}
+	}
+}
+
+// handleRebaseCommitsLoaded opens the editor with the loaded todo.
+func (p *Plugin) handleRebaseCommitsLoaded(msg RebaseCommitsLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		p.showErrorModal("Cannot Rebase", msg.Err)
+		return nil
+	}
+	p.rebaseBase = msg.Base
+	p.rebaseItems = msg.Items
+	p.rebaseOriginal = make([]string, len(msg.Items))
+	for i, item := range msg.Items {
+		p.rebaseOriginal[i] = item.Hash
+	}
+	p.rebaseCursor = 0
+	p.rebaseError = ""
+	p.rebaseRunning = false
+	p.rebaseRewording = false
+	p.rebaseModal = nil
+	p.viewMode = ViewModeRebase
+	return nil
+}
+
+// handleRebaseResult shows the outcome of a rebase command.
+func (p *Plugin) handleRebaseResult(msg RebaseResultMsg) tea.Cmd {
+	reload := tea.Batch(p.refresh(), p.loadRecentCommits())
+	if msg.Err != nil {
+		p.clearRebaseEditor()
+		p.showErrorModal(rebaseOpTitles[msg.Op], msg.Err)
+		return reload
+	}
+
+	if msg.Result != nil && msg.Result.Stopped {
+		var toast tea.Cmd
+		// Continuing with unresolved conflicts leaves the rebase where it was
+		if msg.Op == rebaseOpContinue && msg.Progress != nil && p.rebaseProgress != nil &&
+			msg.Progress.Step == p.rebaseProgress.Step && len(msg.Progress.Conflicts) > 0 {
+			toast = func() tea.Msg {
+				return app.ToastMsg{Message: "Resolve and stage conflicts before continuing", Duration: 3 * time.Second, IsError: true}
+			}
+		}
+		p.clearRebaseEditor()
+		p.rebaseProgress = msg.Progress
+		if p.rebaseProgress == nil {
+			p.rebaseProgress = &RebaseProgress{Conflicts: msg.Result.Conflicts}
+		}
+		p.rebaseStopModal = nil
+		p.viewMode = ViewModeRebaseStopped
+		return tea.Batch(toast, reload)
+	}
+
+	p.clearRebaseEditor()
+	p.rebaseProgress = nil
+	p.rebaseStopModal = nil
+	p.viewMode = ViewModeStatus
+	toast := "Rebase complete"
+	if msg.Op == rebaseOpAbort {
+		toast = "Rebase aborted"
+	}
+	return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), reload)
+}
+
+// clearRebaseEditor resets the editor state.
+func (p *Plugin) clearRebaseEditor() {
+	p.rebaseBase = ""
+	p.rebaseItems = nil
+	p.rebaseOriginal = nil
+	p.rebaseCursor = 0
+	p.rebaseError = ""
+	p.rebaseRunning = false
+	p.rebaseRewording = false
+	p.rebaseModal = nil
+	p.rebaseModalWidth = 0
+	p.rebaseRewordModal = nil
+}
+
+// closeRebaseEditor discards the todo and returns to the status view.
+func (p *Plugin) closeRebaseEditor() {
+	p.clearRebaseEditor()
+	p.viewMode = ViewModeStatus
+}
+
+// updateRebase handles key events in the rebase editor.
+func (p *Plugin) updateRebase(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.rebaseRewording {
+		return p.updateRebaseReword(msg)
+	}
+	p.ensureRebaseModal()
+	if p.rebaseRunning {
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		p.closeRebaseEditor()
+		return p, nil
+	case "j", "down":
+		p.moveRebaseCursor(1)
+		return p, nil
+	case "k", "up":
+		p.moveRebaseCursor(-1)
+		return p, nil
+	case "g":
+		p.rebaseCursor = 0
+		return p, nil
+	case "G":
+		p.rebaseCursor = len(p.rebaseItems) - 1
+		return p, nil
+	case "J", "shift+down":
+		p.moveRebaseItem(1)
+		return p, nil
+	case "K", "shift+up":
+		p.moveRebaseItem(-1)
+		return p, nil
+	case "p":
+		p.setRebaseAction(RebasePick)
+		return p, nil
+	case "e":
+		p.setRebaseAction(RebaseEdit)
+		return p, nil
+	case "s":
+		p.setRebaseAction(RebaseSquash)
+		return p, nil
+	case "f":
+		p.setRebaseAction(RebaseFixup)
+		return p, nil
+	case "d":
+		p.setRebaseAction(RebaseDrop)
+		return p, nil
+	case "r":
+		p.openRebaseReword()
+		return p, nil
+	case " ":
+		p.cycleRebaseAction()
+		return p, nil
+	case "enter":
+		return p, p.startRebase()
+	}
+
+	action, cmd := p.rebaseModal.HandleKey(msg)
+	switch action {
+	case rebaseStartID:
+		return p, p.startRebase()
+	case "cancel":
+		p.closeRebaseEditor()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// moveRebaseCursor moves the selection by delta.
+func (p *Plugin) moveRebaseCursor(delta int) {
+	next := p.rebaseCursor + delta
+	if next < 0 || next >= len(p.rebaseItems) {
+		return
+	}
+	p.rebaseCursor = next
+}
+
+// moveRebaseItem moves the selected commit by delta, reordering the todo.
+func (p *Plugin) moveRebaseItem(delta int) {
+	next := p.rebaseCursor + delta
+	if next < 0 || next >= len(p.rebaseItems) {
+		return
+	}
+	p.rebaseItems[p.rebaseCursor], p.rebaseItems[next] = p.rebaseItems[next], p.rebaseItems[p.rebaseCursor]
+	p.rebaseCursor = next
+	p.rebaseError = ""
+}
+
+// setRebaseAction sets the action of the selected commit.
+func (p *Plugin) setRebaseAction(action RebaseAction) {
+	if p.rebaseCursor >= len(p.rebaseItems) {
+		return
+	}
+	item := &p.rebaseItems[p.rebaseCursor]
+	item.Action = action
+	if action != RebaseReword {
+		item.Message = ""
+	}
+	p.rebaseError = ""
+}
+
+// cycleRebaseAction advances the selected commit to the next action.
+func (p *Plugin) cycleRebaseAction() {
+	if p.rebaseCursor >= len(p.rebaseItems) {
+		return
+	}
+	current := p.rebaseItems[p.rebaseCursor].Action
+	next := RebaseActions[0]
+	for i, a := range RebaseActions {
+		if a == current {
+			next = RebaseActions[(i+1)%len(RebaseActions)]
+			break
+		}
+	}
+	p.setRebaseAction(next)
+}
+
+// rebaseTodoChanged reports whether the todo differs from a plain replay.
+func (p *Plugin) rebaseTodoChanged() bool {
+	for i, item := range p.rebaseItems {
+		if item.Action != RebasePick || i >= len(p.rebaseOriginal) || item.Hash != p.rebaseOriginal[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// startRebase validates the todo and runs the rebase asynchronously.
+func (p *Plugin) startRebase() tea.Cmd {
+	if p.rebaseRunning || len(p.rebaseItems) == 0 {
+		return nil
+	}
+	if err := ValidateRebaseTodo(p.rebaseItems); err != nil {
+		p.rebaseError = err.Error()
+		return nil
+	}
+	if !p.rebaseTodoChanged() {
+		p.rebaseError = "Nothing to change; edit the todo or press esc"
+		return nil
+	}
+
+	p.rebaseRunning = true
+	p.rebaseError = ""
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	base := p.rebaseBase
+	items := append([]RebaseTodoItem(nil), p.rebaseItems...)
+	return func() tea.Msg {
+		result, err := StartInteractiveRebase(workDir, base, items)
+		return rebaseResultMsg(epoch, workDir, rebaseOpStart, result, err)
+	}
+}
+
+// rebaseModalWidthFor returns the editor modal width for the screen.
+func (p *Plugin) rebaseModalWidthFor() int {
+	w := 72
+	if w > p.width-8 {
+		w = p.width - 8
+	}
+	if w < 40 {
+		w = 40
+	}
+	return w
+}
+
+// ensureRebaseModal builds/rebuilds the rebase editor modal.
+func (p *Plugin) ensureRebaseModal() {
+	modalW := p.rebaseModalWidthFor()
+	if p.rebaseModal != nil && p.rebaseModalWidth == modalW {
+		return
+	}
+	p.rebaseModalWidth = modalW
+
+	p.rebaseModal = modal.New("Interactive Rebase",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+		modal.WithPrimaryAction(rebaseStartID),
+	).
+		AddSection(p.rebaseHeaderSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.rebaseListSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.rebaseStatusSection()).
+		AddSection(p.rebaseHintsSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Start ", rebaseStartID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+func (p *Plugin) rebaseHeaderSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		onto := "from the root commit"
+		if p.rebaseBase != "" {
+			onto = "onto " + shortHash(p.rebaseBase)
+		}
+		content := styles.Muted.Render(fmt.Sprintf("%d commit(s) %s, oldest first", len(p.rebaseItems), onto))
+		return modal.RenderedSection{Content: content}
+	}, nil)
+}
+
+func (p *Plugin) rebaseListSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		maxVisible := p.rebaseMaxVisible()
+		start := 0
+		if p.rebaseCursor >= maxVisible {
+			start = p.rebaseCursor - maxVisible + 1
+		}
+		end := start + maxVisible
+		if end > len(p.rebaseItems) {
+			end = len(p.rebaseItems)
+		}
+
+		var sb strings.Builder
+		for i := start; i < end; i++ {
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(renderRebaseItem(p.rebaseItems[i], i == p.rebaseCursor, contentWidth))
+		}
+		if len(p.rebaseItems) > maxVisible {
+			sb.WriteString("\n" + styles.Muted.Render(fmt.Sprintf("  %d/%d commits", p.rebaseCursor+1, len(p.rebaseItems))))
+		}
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// rebaseActionStyle returns the style used for an action label.
+func rebaseActionStyle(action RebaseAction) lipgloss.Style {
+	switch action {
+	case RebaseReword:
+		return styles.StatusModified
+	case RebaseEdit:
+		return styles.StatusInProgress
+	case RebaseSquash, RebaseFixup:
+		return styles.StatusUntracked
+	case RebaseDrop:
+		return styles.StatusDeleted
+	default:
+		return styles.Body
+	}
+}
+
+// renderRebaseItem renders one todo line: action, short hash and subject.
+func renderRebaseItem(item RebaseTodoItem, selected bool, width int) string {
+	marker := " "
+	if item.Action == RebaseReword && item.Message != "" {
+		marker = "✎"
+	}
+	subject := item.Subject
+	if item.Message != "" {
+		subject = strings.SplitN(item.Message, "\n", 2)[0]
+	}
+
+	if selected {
+		line := fmt.Sprintf("> %-6s %s %s %s", item.Action, item.ShortHash, marker, subject)
+		return styles.ListItemSelected.Render(truncateStyledLine(line, width))
+	}
+
+	subjectStyle := styles.Body
+	if item.Action == RebaseDrop {
+		subjectStyle = styles.Muted.Strikethrough(true)
+	}
+	line := "  " + rebaseActionStyle(item.Action).Render(fmt.Sprintf("%-6s", item.Action)) + " " +
+		styles.Muted.Render(item.ShortHash) + " " + marker + " " + subjectStyle.Render(subject)
+	return truncateStyledLine(line, width)
+}
+
+func (p *Plugin) rebaseStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		switch {
+		case p.rebaseRunning:
+			return modal.RenderedSection{Content: styles.StatusInProgress.Render("Rebasing...") + "\n"}
+		case p.rebaseError != "":
+			return modal.RenderedSection{Content: styles.StatusDeleted.Render(p.rebaseError) + "\n"}
+		}
+		return modal.RenderedSection{}
+	}, nil)
+}
+
+func (p *Plugin) rebaseHintsSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		content := styles.Muted.Render("p pick  r reword  e edit  s squash  f fixup  d drop  space cycle") + "\n" +
+			styles.Muted.Render("J/K move  enter start  esc cancel")
+		return modal.RenderedSection{Content: content}
+	}, nil)
+}
+
+func (p *Plugin) rebaseMaxVisible() int {
+	maxVisible := 15
+	if p.height-16 < maxVisible {
+		maxVisible = p.height - 16
+	}
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+	return maxVisible
+}
+
+// openRebaseReword opens the message editor for the selected commit,
+// prefilled with its pending or current message.
+func (p *Plugin) openRebaseReword() {
+	if p.rebaseCursor >= len(p.rebaseItems) {
+		return
+	}
+	item := p.rebaseItems[p.rebaseCursor]
+	message := item.Message
+	if message == "" {
+		message = GetCommitMessage(p.repoRoot, item.Hash)
+	}
+
+	p.rebaseMessage = textarea.New()
+	p.rebaseMessage.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(styles.TextSecondary)
+	p.rebaseMessage.CharLimit = 0
+	textareaWidth := p.rebaseModalWidthFor() - 8
+	if textareaWidth < 30 {
+		textareaWidth = 30
+	}
+	p.rebaseMessage.SetWidth(textareaWidth)
+	p.rebaseMessage.SetHeight(6)
+	p.rebaseMessage.SetValue(message)
+	p.rebaseMessage.Focus()
+	p.rebaseRewording = true
+	p.rebaseRewordModal = nil
+}
+
+// ensureRebaseRewordModal builds the reword message modal.
+func (p *Plugin) ensureRebaseRewordModal() {
+	if p.rebaseRewordModal != nil || p.rebaseCursor >= len(p.rebaseItems) {
+		return
+	}
+	item := p.rebaseItems[p.rebaseCursor]
+	p.rebaseRewordModal = modal.New("Reword "+item.ShortHash,
+		modal.WithWidth(p.rebaseModalWidthFor()),
+		modal.WithPrimaryAction(rebaseRewordSaveID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text(styles.Muted.Render(item.Subject))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Textarea(rebaseMessageID, &p.rebaseMessage, 6)).
+		AddSection(modal.Text(styles.Muted.Render("ctrl+s to save, esc to cancel"))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Save ", rebaseRewordSaveID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// updateRebaseReword handles key events in the reword message modal.
+func (p *Plugin) updateRebaseReword(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureRebaseRewordModal()
+	if p.rebaseRewordModal == nil {
+		p.rebaseRewording = false
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+s":
+		p.saveRebaseReword()
+		return p, nil
+	case "esc":
+		p.cancelRebaseReword()
+		return p, nil
+	}
+
+	focusID := p.rebaseRewordModal.FocusedID()
+	action, cmd := p.rebaseRewordModal.HandleKey(msg)
+	// Enter in the textarea inserts a newline rather than saving
+	if action == rebaseRewordSaveID && focusID == rebaseMessageID {
+		return p, cmd
+	}
+	switch action {
+	case rebaseRewordSaveID:
+		p.saveRebaseReword()
+		return p, nil
+	case "cancel":
+		p.cancelRebaseReword()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// saveRebaseReword marks the selected commit for reword with the new message.
+func (p *Plugin) saveRebaseReword() {
+	message := strings.TrimSpace(p.rebaseMessage.Value())
+	if message == "" {
+		p.rebaseError = "Commit message cannot be empty"
+		p.cancelRebaseReword()
+		return
+	}
+	p.setRebaseAction(RebaseReword)
+	p.rebaseItems[p.rebaseCursor].Message = message
+	p.cancelRebaseReword()
+}
+
+// cancelRebaseReword closes the reword modal.
+func (p *Plugin) cancelRebaseReword() {
+	p.rebaseRewording = false
+	p.rebaseRewordModal = nil
+	p.rebaseMessage.Blur()
+}
+
+// renderRebase renders the rebase editor over the status view.
+func (p *Plugin) renderRebase() string {
+	background := p.renderThreePaneView()
+
+	p.ensureRebaseModal()
+	content := ui.OverlayModal(background, p.rebaseModal.Render(p.width, p.height, p.mouseHandler), p.width, p.height)
+	if !p.rebaseRewording {
+		return content
+	}
+	p.ensureRebaseRewordModal()
+	if p.rebaseRewordModal == nil {
+		return content
+	}
+	return ui.OverlayModal(content, p.rebaseRewordModal.Render(p.width, p.height, p.mouseHandler), p.width, p.height)
+}
+
+// handleRebaseMouse handles mouse events in the rebase editor.
+func (p *Plugin) handleRebaseMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.rebaseRewording {
+		if p.rebaseRewordModal == nil {
+			return p, nil
+		}
+		switch p.rebaseRewordModal.HandleMouse(msg, p.mouseHandler) {
+		case rebaseRewordSaveID:
+			p.saveRebaseReword()
+		case "cancel":
+			p.cancelRebaseReword()
+		}
+		return p, nil
+	}
+
+	if p.rebaseModal == nil || p.rebaseRunning {
+		return p, nil
+	}
+	switch p.rebaseModal.HandleMouse(msg, p.mouseHandler) {
+	case rebaseStartID:
+		return p, p.startRebase()
+	case "cancel":
+		p.closeRebaseEditor()
+	}
+	return p, nil
+}
+
+// ensureRebaseStopModal builds/rebuilds the stopped rebase modal.
+func (p *Plugin) ensureRebaseStopModal() {
+	if p.rebaseProgress == nil {
+		return
+	}
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.rebaseStopModal != nil && p.rebaseStopWidth == modalW {
+		return
+	}
+	p.rebaseStopWidth = modalW
+
+	variant := modal.VariantDefault
+	primary := rebaseContinueID
+	buttons := []modal.ButtonDef{
+		modal.Btn(" Continue ", rebaseContinueID),
+		modal.Btn(" Skip ", rebaseSkipID),
+		modal.Btn(" Abort ", rebaseAbortID, modal.BtnDanger()),
+	}
+	if len(p.rebaseProgress.Conflicts) > 0 {
+		variant = modal.VariantDanger
+		primary = rebaseResolveID
+		buttons = append([]modal.ButtonDef{modal.Btn(" Resolve ", rebaseResolveID)}, buttons...)
+	}
+	p.rebaseStopModal = modal.New("Rebase Stopped",
+		modal.WithWidth(modalW),
+		modal.WithVariant(variant),
+		modal.WithHints(false),
+		modal.WithPrimaryAction(primary),
+	).
+		AddSection(p.rebaseStopSummarySection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.rebaseStopFilesSection()).
+		AddSection(modal.Buttons(buttons...))
+}
+
+func (p *Plugin) rebaseStopSummarySection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		progress := p.rebaseProgress
+		var lines []string
+		if branch := strings.TrimPrefix(progress.HeadName, "refs/heads/"); branch != "" {
+			lines = append(lines, styles.Subtitle.Render("Rebasing "+branch))
+		}
+		step := ""
+		if progress.Total > 0 {
+			step = fmt.Sprintf("Step %d of %d", progress.Step, progress.Total)
+		}
+		if progress.StoppedHash != "" {
+			if step != "" {
+				step += " · "
+			}
+			step += "stopped at " + shortHash(progress.StoppedHash)
+		}
+		if step != "" {
+			lines = append(lines, styles.Muted.Render(step))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+func (p *Plugin) rebaseStopFilesSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		conflicts := p.rebaseProgress.Conflicts
+		if len(conflicts) == 0 {
+			hint := styles.Muted.Render("Amend the commit (A) or make further commits, then continue.")
+			return modal.RenderedSection{Content: hint + "\n"}
+		}
+
+		var sb strings.Builder
+		sb.WriteString(styles.Muted.Render(fmt.Sprintf("Conflicts in %d file(s):", len(conflicts))))
+		maxFiles := 8
+		for i, f := range conflicts {
+			sb.WriteString("\n")
+			if i >= maxFiles {
+				sb.WriteString(styles.Muted.Render(fmt.Sprintf("  ... and %d more", len(conflicts)-maxFiles)))
+				break
+			}
+			sb.WriteString(styles.StatusModified.Render("  U " + f))
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(styles.Muted.Render("Resolve the conflicts (r) and stage the files, then continue."))
+		sb.WriteString("\n")
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// renderRebaseStopped renders the stopped rebase modal over the status view.
+func (p *Plugin) renderRebaseStopped() string {
+	background := p.renderThreePaneView()
+
+	p.ensureRebaseStopModal()
+	if p.rebaseStopModal == nil {
+		return background
+	}
+	modalContent := p.rebaseStopModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// updateRebaseStopped handles key events in the stopped rebase modal.
+func (p *Plugin) updateRebaseStopped(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureRebaseStopModal()
+	if p.rebaseStopModal == nil {
+		return p.dismissRebaseStopped()
+	}
+
+	switch msg.String() {
+	case "c":
+		return p, p.doRebaseOp(rebaseOpContinue)
+	case "s":
+		return p, p.doRebaseOp(rebaseOpSkip)
+	case "r":
+		return p.resolveRebaseConflicts()
+	case "a":
+		return p, p.doRebaseOp(rebaseOpAbort)
+	case "esc", "q":
+		return p.dismissRebaseStopped()
+	}
+
+	action, cmd := p.rebaseStopModal.HandleKey(msg)
+	if plug, opCmd, ok := p.rebaseStopAction(action); ok {
+		return plug, opCmd
+	}
+	return p, cmd
+}
+
+// handleRebaseStoppedMouse handles mouse events in the stopped rebase modal.
+func (p *Plugin) handleRebaseStoppedMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.rebaseStopModal == nil {
+		return p, nil
+	}
+	action := p.rebaseStopModal.HandleMouse(msg, p.mouseHandler)
+	if plug, cmd, ok := p.rebaseStopAction(action); ok {
+		return plug, cmd
+	}
+	return p, nil
+}
+
+// rebaseStopAction runs the stopped modal action with the given ID.
+func (p *Plugin) rebaseStopAction(action string) (plugin.Plugin, tea.Cmd, bool) {
+	switch action {
+	case rebaseContinueID:
+		return p, p.doRebaseOp(rebaseOpContinue), true
+	case rebaseSkipID:
+		return p, p.doRebaseOp(rebaseOpSkip), true
+	case rebaseResolveID:
+		plug, cmd := p.resolveRebaseConflicts()
+		return plug, cmd, true
+	case rebaseAbortID:
+		return p, p.doRebaseOp(rebaseOpAbort), true
+	case "cancel":
+		plug, cmd := p.dismissRebaseStopped()
+		return plug, cmd, true
+	}
+	return p, nil, false
+}
+
+// dismissRebaseStopped closes the modal, leaving the rebase in progress so
+// conflicts can be resolved from the status view.
+func (p *Plugin) dismissRebaseStopped() (plugin.Plugin, tea.Cmd) {
+	p.viewMode = ViewModeStatus
+	p.rebaseStopModal = nil
+	p.rebaseStopWidth = 0
+	return p, tea.Batch(
+		appmsg.ShowToast("Rebase in progress (R to resume)", 3*time.Second),
+		p.refresh(),
+	)
+}
+
+// doRebaseOp continues, skips or aborts the stopped rebase asynchronously.
+func (p *Plugin) doRebaseOp(op string) tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		switch op {
+		case rebaseOpContinue:
+			result, err := ContinueRebase(workDir)
+			return rebaseResultMsg(epoch, workDir, op, result, err)
+		case rebaseOpSkip:
+			result, err := SkipRebase(workDir)
+			return rebaseResultMsg(epoch, workDir, op, result, err)
+		default:
+			err := AbortRebase(workDir)
+			return rebaseResultMsg(epoch, workDir, op, &RebaseResult{}, err)
+		}
+	}
+}
+
+// shortHash abbreviates a full commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,166 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+	"github.com/guyghost/sidecar/internal/mouse"
+	"github.com/guyghost/sidecar/internal/plugin"
+)
+
+func newRebasePlugin(t *testing.T) *Plugin {
+	t.Helper()
+	p := &Plugin{
+		ctx:          &plugin.Context{},
+		hasRepo:      true,
+		tree:         &FileTree{},
+		width:        100,
+		height:       30,
+		mouseHandler: mouse.NewHandler(),
+	}
+	p.handleRebaseCommitsLoaded(RebaseCommitsLoadedMsg{
+		Base: "base000",
+		Items: []RebaseTodoItem{
+			{Action: RebasePick, Hash: "aaa", ShortHash: "aaa", Subject: "one"},
+			{Action: RebasePick, Hash: "bbb", ShortHash: "bbb", Subject: "two"},
+			{Action: RebasePick, Hash: "ccc", ShortHash: "ccc", Subject: "three"},
+		},
+	})
+	return p
+}
+
+func rebaseTodo(p *Plugin) string {
+	var parts []string
+	for _, item := range p.rebaseItems {
+		parts = append(parts, string(item.Action)+" "+item.Subject)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestRebaseEditor_ReorderAndActions(t *testing.T) {
+	p := newRebasePlugin(t)
+	if p.viewMode != ViewModeRebase || p.FocusContext() != keymap.ContextGitRebase {
+		t.Fatalf("expected rebase editor, viewMode=%v", p.viewMode)
+	}
+
+	p.Update(runeKey("J"))
+	if p.rebaseCursor != 1 || rebaseTodo(p) != "pick two, pick one, pick three" {
+		t.Fatalf("J should move commit down: cursor=%d todo=%s", p.rebaseCursor, rebaseTodo(p))
+	}
+	p.Update(runeKey("j"))
+	p.Update(runeKey("f"))
+	p.Update(runeKey("K"))
+	p.Update(runeKey("k"))
+	p.Update(runeKey("d"))
+	if got := rebaseTodo(p); got != "drop two, fixup three, pick one" {
+		t.Errorf("todo = %s", got)
+	}
+
+	p.Update(runeKey(" "))
+	if p.rebaseItems[0].Action != RebasePick {
+		t.Errorf("space should cycle drop back to pick, got %s", p.rebaseItems[0].Action)
+	}
+}
+
+func TestRebaseEditor_ValidatesBeforeStart(t *testing.T) {
+	p := newRebasePlugin(t)
+
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || p.rebaseError == "" {
+		t.Error("an unchanged todo should not start a rebase")
+	}
+
+	p.Update(runeKey("s"))
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("squashing the first commit should not start a rebase")
+	}
+	if !strings.Contains(p.rebaseError, "squash") {
+		t.Errorf("rebaseError = %q", p.rebaseError)
+	}
+
+	p.Update(runeKey("p"))
+	if p.rebaseError != "" {
+		t.Error("changing an action should clear the error")
+	}
+}
+
+func TestRebaseEditor_Reword(t *testing.T) {
+	p := newRebasePlugin(t)
+	p.Update(runeKey("j"))
+	p.Update(runeKey("r"))
+	if !p.rebaseRewording || !p.ConsumesTextInput() || p.FocusContext() != keymap.ContextGitRebaseReword {
+		t.Fatal("r should open the reword editor")
+	}
+
+	// Keys go to the textarea, not the todo list
+	p.rebaseMessage.SetValue("")
+	p.Update(runeKey("d"))
+	if p.rebaseItems[1].Action != RebasePick {
+		t.Error("typing in the reword editor should not change actions")
+	}
+	p.rebaseMessage.SetValue("Two, reworded")
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	item := p.rebaseItems[1]
+	if p.rebaseRewording || item.Action != RebaseReword || item.Message != "Two, reworded" {
+		t.Errorf("unexpected item after save: %+v", item)
+	}
+	if !strings.Contains(p.View(100, 30), "Two, reworded") {
+		t.Error("editor should show the new subject")
+	}
+
+	p.Update(runeKey("p"))
+	if p.rebaseItems[1].Message != "" {
+		t.Error("switching back to pick should discard the message")
+	}
+}
+
+func TestRebaseEditor_EscCloses(t *testing.T) {
+	p := newRebasePlugin(t)
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus || p.rebaseItems != nil {
+		t.Error("esc should close the editor and discard the todo")
+	}
+}
+
+func TestHandleRebaseResult_Stopped(t *testing.T) {
+	p := newRebasePlugin(t)
+	p.handleRebaseResult(RebaseResultMsg{
+		Op:       rebaseOpStart,
+		Result:   &RebaseResult{Stopped: true, Conflicts: []string{"f.txt"}},
+		Progress: &RebaseProgress{Step: 2, Total: 3, HeadName: "refs/heads/main", StoppedHash: "0123456789", Conflicts: []string{"f.txt"}},
+	})
+	if p.viewMode != ViewModeRebaseStopped || p.FocusContext() != keymap.ContextGitRebaseStopped {
+		t.Fatalf("expected stopped view, viewMode=%v", p.viewMode)
+	}
+	if p.rebaseItems != nil {
+		t.Error("editor state should be cleared once the rebase runs")
+	}
+
+	view := p.View(100, 30)
+	for _, want := range []string{"Rebasing main", "Step 2 of 3", "0123456", "f.txt"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("stopped view missing %q", want)
+		}
+	}
+
+	if _, cmd := p.Update(runeKey("c")); cmd == nil {
+		t.Error("c should continue the rebase")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus {
+		t.Error("esc should dismiss the stopped modal")
+	}
+}
+
+func TestHandleRebaseResult_Complete(t *testing.T) {
+	p := newRebasePlugin(t)
+	p.rebaseProgress = &RebaseProgress{Step: 1, Total: 2}
+	p.viewMode = ViewModeRebaseStopped
+	p.handleRebaseResult(RebaseResultMsg{Op: rebaseOpContinue, Result: &RebaseResult{}})
+	if p.viewMode != ViewModeStatus || p.rebaseProgress != nil {
+		t.Error("a finished rebase should return to the status view")
+	}
+}
@@ -0,0 +1,34 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export rebase types from internal/git.
+type (
+	RebaseAction   = git.RebaseAction
+	RebaseTodoItem = git.RebaseTodoItem
+	RebaseResult   = git.RebaseResult
+	RebaseProgress = git.RebaseProgress
+	RebaseError    = git.RebaseError
+)
+
+// Re-export rebase actions.
+const (
+	RebasePick   = git.RebasePick
+	RebaseReword = git.RebaseReword
+	RebaseEdit   = git.RebaseEdit
+	RebaseSquash = git.RebaseSquash
+	RebaseFixup  = git.RebaseFixup
+	RebaseDrop   = git.RebaseDrop
+)
+
+// Re-export rebase functions.
+var (
+	RebaseActions          = git.RebaseActions
+	GetRebaseCommits       = git.GetRebaseCommits
+	ValidateRebaseTodo     = git.ValidateRebaseTodo
+	StartInteractiveRebase = git.StartInteractiveRebase
+	ContinueRebase         = git.ContinueRebase
+	SkipRebase             = git.SkipRebase
+	GetRebaseProgress      = git.GetRebaseProgress
+	GetCommitMessage       = git.GetCommitMessage
+)
@@ -0,0 +1,500 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	reflogItemPrefix = "reflog-item-" // List item ID prefix, followed by entry index
+	reflogLimit      = 200            // Entries loaded per ref
+)
+
+// UndoWarningMsg wraps the result of an operation that succeeded without
+// saving its undo point.
+type UndoWarningMsg struct {
+	Msg tea.Msg // The operation's usual result
+	Err error
+}
+
+// recordUndo saves point, captured before an operation that succeeded, and
+// returns result, wrapped in an UndoWarningMsg if capturing or saving the
+// point failed.
+func recordUndo(workDir string, point *UndoPoint, captureErr error, result tea.Msg) tea.Msg {
+	err := captureErr
+	if err == nil {
+		err = RecordUndo(workDir, point)
+	}
+	if err != nil {
+		return UndoWarningMsg{Msg: result, Err: err}
+	}
+	return result
+}
+
+// handleUndoWarning handles the wrapped result as usual and warns that
+// ctrl+y cannot reverse the operation.
+func (p *Plugin) handleUndoWarning(msg UndoWarningMsg) (plugin.Plugin, tea.Cmd) {
+	next, cmd := p.Update(msg.Msg)
+	return next, tea.Batch(cmd, appmsg.ShowToast("No undo point saved: "+msg.Err.Error(), 3*time.Second))
+}
+
+// ReflogLoadedMsg is sent when a ref's reflog is listed.
+type ReflogLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Ref     string
+	Refs    []string // HEAD and local branches
+	Entries []*ReflogEntry
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ReflogLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// ReflogPreviewLoadedMsg is sent when the tree at a reflog entry is described.
+type ReflogPreviewLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Hash    string
+	Preview *ReflogPreview
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ReflogPreviewLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// ReflogBranchDoneMsg is sent when a branch is created at a reflog entry.
+type ReflogBranchDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Name  string
+	Hash  string
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ReflogBranchDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openReflog opens the reflog browser on HEAD.
+func (p *Plugin) openReflog() tea.Cmd {
+	p.viewMode = ViewModeReflog
+	p.reflogRefs = []string{"HEAD"}
+	p.reflogRefIdx = 0
+	p.reflogEntries = nil
+	p.reflogCursor = 0
+	p.reflogErr = ""
+	p.reflogPreview = nil
+	p.reflogBranching = false
+	p.reflogModal = nil
+	return p.loadReflog("HEAD")
+}
+
+// reflogRef returns the ref whose reflog is shown.
+func (p *Plugin) reflogRef() string {
+	if p.reflogRefIdx >= 0 && p.reflogRefIdx < len(p.reflogRefs) {
+		return p.reflogRefs[p.reflogRefIdx]
+	}
+	return "HEAD"
+}
+
+// loadReflog lists the reflog of ref and the refs that can be browsed.
+func (p *Plugin) loadReflog(ref string) tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		entries, err := GetReflog(workDir, ref, reflogLimit)
+		return ReflogLoadedMsg{Epoch: epoch, Ref: ref, Refs: GetReflogRefs(workDir), Entries: entries, Err: err}
+	}
+}
+
+// handleReflogLoaded shows the loaded reflog and previews its first entry.
+func (p *Plugin) handleReflogLoaded(msg ReflogLoadedMsg) tea.Cmd {
+	if p.viewMode != ViewModeReflog {
+		return nil
+	}
+	p.reflogRefs = msg.Refs
+	p.reflogRefIdx = 0
+	for i, ref := range msg.Refs {
+		if ref == msg.Ref {
+			p.reflogRefIdx = i
+		}
+	}
+	p.reflogEntries = msg.Entries
+	p.reflogCursor = 0
+	p.reflogPreview = nil
+	p.reflogErr = ""
+	if msg.Err != nil {
+		p.reflogErr = "No reflog for " + msg.Ref
+	}
+	p.reflogModal = nil
+	return p.loadReflogPreview()
+}
+
+// selectedReflogEntry returns the entry under the cursor, or nil.
+func (p *Plugin) selectedReflogEntry() *ReflogEntry {
+	if p.reflogCursor >= 0 && p.reflogCursor < len(p.reflogEntries) {
+		return p.reflogEntries[p.reflogCursor]
+	}
+	return nil
+}
+
+// loadReflogPreview describes the tree at the selected entry.
+func (p *Plugin) loadReflogPreview() tea.Cmd {
+	entry := p.selectedReflogEntry()
+	if entry == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	hash := entry.Hash
+	return func() tea.Msg {
+		preview, err := GetReflogPreview(workDir, hash)
+		return ReflogPreviewLoadedMsg{Epoch: epoch, Hash: hash, Preview: preview, Err: err}
+	}
+}
+
+// handleReflogPreview stores the preview if it is still for the selected entry.
+func (p *Plugin) handleReflogPreview(msg ReflogPreviewLoadedMsg) {
+	entry := p.selectedReflogEntry()
+	if entry == nil || entry.Hash != msg.Hash || msg.Err != nil {
+		return
+	}
+	p.reflogPreview = msg.Preview
+}
+
+// moveReflogCursor moves the cursor to idx and loads its preview.
+func (p *Plugin) moveReflogCursor(idx int) tea.Cmd {
+	if idx >= len(p.reflogEntries) {
+		idx = len(p.reflogEntries) - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	if idx == p.reflogCursor {
+		return nil
+	}
+	p.reflogCursor = idx
+	p.reflogPreview = nil
+	return p.loadReflogPreview()
+}
+
+// switchReflogRef shows the reflog of the next (delta 1) or previous ref.
+func (p *Plugin) switchReflogRef(delta int) tea.Cmd {
+	if len(p.reflogRefs) < 2 {
+		return nil
+	}
+	idx := (p.reflogRefIdx + delta + len(p.reflogRefs)) % len(p.reflogRefs)
+	p.reflogRefIdx = idx
+	return p.loadReflog(p.reflogRefs[idx])
+}
+
+// closeReflog closes the reflog browser.
+func (p *Plugin) closeReflog() {
+	p.viewMode = ViewModeStatus
+	p.reflogEntries = nil
+	p.reflogPreview = nil
+	p.reflogBranching = false
+	p.reflogModal = nil
+	p.reflogModalWidth = 0
+}
+
+// updateReflog handles key events in the reflog browser.
+func (p *Plugin) updateReflog(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.reflogBranching {
+		return p.updateReflogBranch(msg)
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.closeReflog()
+	case "j", "down":
+		return p, p.moveReflogCursor(p.reflogCursor + 1)
+	case "k", "up":
+		return p, p.moveReflogCursor(p.reflogCursor - 1)
+	case "g":
+		return p, p.moveReflogCursor(0)
+	case "G":
+		return p, p.moveReflogCursor(len(p.reflogEntries) - 1)
+	case "tab", "]":
+		return p, p.switchReflogRef(1)
+	case "shift+tab", "[":
+		return p, p.switchReflogRef(-1)
+	case "enter", "r":
+		return p, p.resetToReflogEntry()
+	case "b":
+		if p.selectedReflogEntry() != nil {
+			p.reflogBranchName = textinput.New()
+			p.reflogBranchName.Placeholder = "rescue-branch"
+			p.reflogBranchName.CharLimit = 100
+			p.reflogBranchName.Focus()
+			p.reflogBranching = true
+			p.reflogErr = ""
+			return p, textinput.Blink
+		}
+	}
+	return p, nil
+}
+
+// updateReflogBranch handles key events while naming a branch to create at
+// the selected entry.
+func (p *Plugin) updateReflogBranch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		p.reflogBranching = false
+		p.reflogErr = ""
+		return p, nil
+	case "enter":
+		return p, p.doReflogBranch()
+	}
+	var cmd tea.Cmd
+	p.reflogBranchName, cmd = p.reflogBranchName.Update(msg)
+	return p, cmd
+}
+
+// handleReflogMouse handles mouse events in the reflog browser.
+func (p *Plugin) handleReflogMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.reflogModal == nil {
+		return p, nil
+	}
+	action := p.reflogModal.HandleMouse(msg, p.mouseHandler)
+	if action == "cancel" {
+		p.closeReflog()
+		return p, nil
+	}
+	if idx, err := strconv.Atoi(strings.TrimPrefix(action, reflogItemPrefix)); err == nil && strings.HasPrefix(action, reflogItemPrefix) {
+		return p, p.moveReflogCursor(idx)
+	}
+	return p, nil
+}
+
+// resetToReflogEntry opens the reset confirm flow for the selected entry.
+func (p *Plugin) resetToReflogEntry() tea.Cmd {
+	entry := p.selectedReflogEntry()
+	if entry == nil {
+		return nil
+	}
+	subject := entry.Action + ": " + entry.Message
+	if p.reflogPreview != nil {
+		subject = p.reflogPreview.Subject
+	}
+	return p.openResetTo(&Commit{Hash: entry.Hash, ShortHash: entry.ShortHash(), Subject: subject})
+}
+
+// doReflogBranch creates the named branch at the selected entry.
+func (p *Plugin) doReflogBranch() tea.Cmd {
+	entry := p.selectedReflogEntry()
+	if entry == nil {
+		return nil
+	}
+	name := strings.TrimSpace(p.reflogBranchName.Value())
+	if name == "" {
+		p.reflogErr = "Branch name is required"
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	hash := entry.Hash
+	return func() tea.Msg {
+		err := CreateBranchAt(workDir, name, hash)
+		return ReflogBranchDoneMsg{Epoch: epoch, Name: name, Hash: hash, Err: err}
+	}
+}
+
+// handleReflogBranchDone reports the created branch, keeping the name input
+// open on failure.
+func (p *Plugin) handleReflogBranchDone(msg ReflogBranchDoneMsg) tea.Cmd {
+	if msg.Err != nil {
+		p.reflogErr = msg.Err.Error()
+		return nil
+	}
+	p.reflogBranching = false
+	p.reflogErr = ""
+	toast := appmsg.ShowToast("Created "+msg.Name+" at "+shortHash(msg.Hash), 2*time.Second)
+	if p.viewMode != ViewModeReflog {
+		return toast
+	}
+	return tea.Batch(toast, p.loadReflog(p.reflogRef()))
+}
+
+// ensureReflogModal builds/rebuilds the reflog browser.
+func (p *Plugin) ensureReflogModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.reflogModal != nil && p.reflogModalWidth == modalW {
+		return
+	}
+	p.reflogModalWidth = modalW
+
+	p.reflogModal = modal.New("Reflog",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.reflogRefsSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.reflogListSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.reflogPreviewSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.reflogStatusSection())
+}
+
+// reflogRefsSection shows the browsable refs with the current one highlighted.
+func (p *Plugin) reflogRefsSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		parts := make([]string, len(p.reflogRefs))
+		for i, ref := range p.reflogRefs {
+			if i == p.reflogRefIdx {
+				parts[i] = styles.StatusModified.Render("[" + ref + "]")
+			} else {
+				parts[i] = styles.Muted.Render(ref)
+			}
+		}
+		return modal.RenderedSection{Content: ansi.Truncate(strings.Join(parts, " "), contentWidth, "…")}
+	}, nil)
+}
+
+// reflogListSection renders the entries with the cursor kept in view.
+func (p *Plugin) reflogListSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if len(p.reflogEntries) == 0 {
+			msg := "Loading reflog..."
+			if p.reflogErr != "" && !p.reflogBranching {
+				msg = p.reflogErr
+			}
+			return modal.RenderedSection{Content: styles.Muted.Render(msg)}
+		}
+
+		maxVisible := p.branchPickerMaxVisible() - 6
+		if maxVisible < 3 {
+			maxVisible = 3
+		}
+		start := 0
+		if p.reflogCursor >= maxVisible {
+			start = p.reflogCursor - maxVisible + 1
+		}
+		end := start + maxVisible
+		if end > len(p.reflogEntries) {
+			end = len(p.reflogEntries)
+		}
+
+		var sb strings.Builder
+		focusables := make([]modal.FocusableInfo, 0, end-start)
+		for i := start; i < end; i++ {
+			itemID := fmt.Sprintf("%s%d", reflogItemPrefix, i)
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(renderReflogLine(p.reflogEntries[i], contentWidth, i == p.reflogCursor || itemID == hoverID))
+			focusables = append(focusables, modal.FocusableInfo{
+				ID:      itemID,
+				OffsetY: i - start,
+				Width:   contentWidth,
+				Height:  1,
+			})
+		}
+		if len(p.reflogEntries) > maxVisible {
+			sb.WriteString("\n" + styles.Muted.Render(fmt.Sprintf("%d/%d entries", p.reflogCursor+1, len(p.reflogEntries))))
+		}
+		return modal.RenderedSection{Content: sb.String(), Focusables: focusables}
+	}, nil)
+}
+
+// renderReflogLine renders one entry: hash, relative time, operation and message.
+func renderReflogLine(e *ReflogEntry, width int, selected bool) string {
+	date := ""
+	if !e.Date.IsZero() {
+		date = RelativeTime(e.Date)
+	}
+	action := e.Action
+	if action == "" {
+		action = "-"
+	}
+	prefix := fmt.Sprintf("%s  %-8s  ", e.ShortHash(), ui.TruncateString(date, 8))
+	rest := action + "  " + e.Message
+	restW := width - ansi.StringWidth(prefix)
+	if restW < 0 {
+		restW = 0
+	}
+	rest = ui.TruncateString(rest, restW)
+
+	if selected {
+		line := prefix + rest
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	action = ui.TruncateString(action, restW)
+	message := ""
+	if msgW := restW - ansi.StringWidth(action) - 2; msgW > 0 {
+		message = "  " + ui.TruncateString(e.Message, msgW)
+	}
+	return styles.Code.Render(e.ShortHash()) + "  " + styles.Muted.Render(fmt.Sprintf("%-8s", ui.TruncateString(date, 8))) + "  " +
+		styles.StatusModified.Render(action) + styles.Body.Render(message)
+}
+
+// reflogPreviewSection describes the commit at the selected entry and the
+// files that differ from HEAD.
+func (p *Plugin) reflogPreviewSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		preview := p.reflogPreview
+		if preview == nil {
+			return modal.RenderedSection{}
+		}
+		var sb strings.Builder
+		sb.WriteString(styles.Title.Render(ui.TruncateString(preview.Subject, contentWidth)))
+		meta := preview.Author
+		if !preview.Date.IsZero() {
+			meta += " · " + RelativeTime(preview.Date)
+		}
+		sb.WriteString("\n" + styles.Muted.Render(ui.TruncateString(meta, contentWidth)))
+		if len(preview.Changes) == 0 {
+			sb.WriteString("\n" + styles.Muted.Render("Same tree as HEAD"))
+		} else {
+			sb.WriteString("\n" + styles.Muted.Render(fmt.Sprintf("%d file(s) differ from HEAD:", len(preview.Changes))))
+			changes := make([]string, len(preview.Changes))
+			for i, c := range preview.Changes {
+				changes[i] = strings.ReplaceAll(c, "\t", " ")
+			}
+			writeResetLines(&sb, changes, styles.Body, contentWidth)
+		}
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// reflogStatusSection shows the branch name input, errors and key hints.
+func (p *Plugin) reflogStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var lines []string
+		if p.reflogBranching {
+			lines = append(lines, "New branch: "+p.reflogBranchName.View())
+			if p.reflogErr != "" {
+				lines = append(lines, styles.StatusDeleted.Render(strings.TrimSpace(p.reflogErr)))
+			}
+			lines = append(lines, styles.Muted.Render("enter create · esc cancel"))
+			return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+		}
+		lines = append(lines, styles.Muted.Render("enter reset to entry · b branch here · tab switch ref · esc close"))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderReflog renders the reflog browser over the status view.
+func (p *Plugin) renderReflog() string {
+	background := p.renderThreePaneView()
+	p.ensureReflogModal()
+	modalContent := p.reflogModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
@@ -0,0 +1,27 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export reflog and undo types from internal/git.
+type (
+	ReflogEntry   = git.ReflogEntry
+	ReflogError   = git.ReflogError
+	ReflogPreview = git.ReflogPreview
+	UndoPoint     = git.UndoPoint
+	UndoError     = git.UndoError
+)
+
+// Re-export reflog and undo functions.
+var (
+	GetReflogRefs          = git.GetReflogRefs
+	GetReflog              = git.GetReflog
+	GetReflogPreview       = git.GetReflogPreview
+	CreateBranchAt         = git.CreateBranchAt
+	CaptureDiscardUndo     = git.CaptureDiscardUndo
+	CaptureAmendUndo       = git.CaptureAmendUndo
+	CaptureResetUndo       = git.CaptureResetUndo
+	CaptureForcePushUndo   = git.CaptureForcePushUndo
+	CaptureForcePushToUndo = git.CaptureForcePushToUndo
+	CaptureStashDropUndo   = git.CaptureStashDropUndo
+	RecordUndo             = git.RecordUndo
+)
@@ -0,0 +1,143 @@
+package gitstatus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/git"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+func newReflogPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	p := newHistoryOpsPlugin(t)
+	p.Update(runeKey("H"))
+	if p.viewMode != ViewModeReflog || p.FocusContext() != keymap.ContextGitReflog {
+		t.Fatalf("H should open the reflog, viewMode=%v", p.viewMode)
+	}
+	p.Update(ReflogLoadedMsg{
+		Ref:  "HEAD",
+		Refs: []string{"HEAD", "main"},
+		Entries: []*ReflogEntry{
+			{Hash: "bbb0000000", Selector: "HEAD@{0}", Action: "reset", Message: "moving to HEAD~1", Date: time.Now().Add(-time.Minute)},
+			{Hash: "ddd0000000", Selector: "HEAD@{1}", Action: "commit (amend)", Message: "lost work", Date: time.Now().Add(-time.Hour)},
+		},
+	})
+	return p
+}
+
+func TestReflog_ListsEntriesAndPreview(t *testing.T) {
+	p := newReflogPlugin(t)
+	view := p.View(120, 40)
+	for _, want := range []string{"[HEAD]", "main", "bbb0000", "reset", "moving to HEAD~1", "commit (amend)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("reflog missing %q", want)
+		}
+	}
+
+	p.Update(runeKey("j"))
+	// A preview for an entry no longer selected is dropped
+	p.Update(ReflogPreviewLoadedMsg{Hash: "bbb0000000", Preview: &ReflogPreview{Subject: "stale"}})
+	if p.reflogPreview != nil {
+		t.Error("stale preview should be ignored")
+	}
+	p.Update(ReflogPreviewLoadedMsg{Hash: "ddd0000000", Preview: &ReflogPreview{
+		Subject: "lost work",
+		Author:  "Ada",
+		Changes: []string{"M\tmain.go"},
+	}})
+	view = p.View(120, 40)
+	for _, want := range []string{"Ada", "1 file(s) differ from HEAD", "M main.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview missing %q", want)
+		}
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus {
+		t.Error("esc should close the reflog")
+	}
+}
+
+func TestReflog_ResetUsesConfirmFlow(t *testing.T) {
+	p := newReflogPlugin(t)
+	p.Update(runeKey("j"))
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should load the reset preview")
+	}
+	p.Update(ResetPreviewLoadedMsg{
+		Target:  &Commit{Hash: "ddd0000000", Subject: "lost work"},
+		Preview: &ResetPreview{},
+	})
+	if p.viewMode != ViewModeConfirmReset || p.resetTarget.Hash != "ddd0000000" {
+		t.Errorf("expected reset confirm for ddd0000, viewMode=%v", p.viewMode)
+	}
+}
+
+func TestReflog_BranchAtEntry(t *testing.T) {
+	p := newReflogPlugin(t)
+	p.Update(runeKey("b"))
+	if !p.reflogBranching || !p.ConsumesTextInput() || p.FocusContext() != keymap.ContextGitReflogBranch {
+		t.Fatal("b should prompt for a branch name")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.reflogErr != "Branch name is required" {
+		t.Errorf("empty name error = %q", p.reflogErr)
+	}
+	for _, r := range "rescue" {
+		p.Update(runeKey(string(r)))
+	}
+	if p.reflogBranchName.Value() != "rescue" {
+		t.Errorf("typed name = %q", p.reflogBranchName.Value())
+	}
+
+	p.Update(ReflogBranchDoneMsg{Name: "rescue", Hash: "bbb0000000", Err: &BranchError{Output: "a branch named 'rescue' already exists", Err: errors.New("exit 128")}})
+	if !p.reflogBranching || !strings.Contains(p.View(120, 40), "already exists") {
+		t.Error("a failed branch should keep the prompt open with the error")
+	}
+	p.Update(ReflogBranchDoneMsg{Name: "rescue", Hash: "bbb0000000"})
+	if p.reflogBranching || p.viewMode != ViewModeReflog {
+		t.Error("a created branch should close the prompt and stay in the reflog")
+	}
+}
+
+func TestUndoPoint_RecordedOnlyOnSuccess(t *testing.T) {
+	p, run := newRepoPlugin(t)
+	head := strings.TrimSpace(run("rev-parse", "HEAD"))
+
+	// A reset that fails leaves nothing for ctrl+y to replay
+	p.resetTarget = &Commit{Hash: strings.Repeat("0", 40)}
+	if msg := p.doReset()().(CommitOpDoneMsg); msg.Err == nil {
+		t.Fatal("reset to a missing commit should fail")
+	}
+	if point := git.LastUndoPoint(p.repoRoot); point != nil {
+		t.Fatalf("failed reset recorded %+v", point)
+	}
+
+	p.resetTarget = &Commit{Hash: head}
+	if msg := p.doReset()().(CommitOpDoneMsg); msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+	if point := git.LastUndoPoint(p.repoRoot); point == nil || point.Op != git.UndoReset {
+		t.Fatalf("successful reset recorded %+v", point)
+	}
+}
+
+func TestUndoWarning_ShowsToast(t *testing.T) {
+	p, _ := newRepoPlugin(t)
+	msg := recordUndo(p.repoRoot, nil, errors.New("no HEAD"), RefreshDoneMsg{})
+	warning, ok := msg.(UndoWarningMsg)
+	if !ok {
+		t.Fatalf("msg = %T, want UndoWarningMsg", msg)
+	}
+	if _, cmd := p.Update(warning); cmd == nil {
+		t.Error("the warning should show a toast")
+	}
+	if msg := recordUndo(p.repoRoot, nil, nil, RefreshDoneMsg{}); msg != (RefreshDoneMsg{}) {
+		t.Errorf("without an error the result passes through, got %T", msg)
+	}
+}
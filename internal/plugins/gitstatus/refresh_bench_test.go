@@ -0,0 +1,89 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newBenchRepo creates a repo with files and a short history.
+func newBenchRepo(b *testing.B) string {
+	b.Helper()
+	dir, git := initTestRepo(b)
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 10; j++ {
+			path := filepath.Join(dir, fmt.Sprintf("pkg%d/file%d.go", j, i))
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				b.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(strings.Repeat(fmt.Sprintf("line %d\n", i), 40)), 0o644); err != nil {
+				b.Fatal(err)
+			}
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", fmt.Sprintf("change %d", i))
+	}
+	return dir
+}
+
+// BenchmarkFileTreeRefresh_Clean measures the status refresh of a clean
+// worktree, the common case when many worktrees are polled.
+func BenchmarkFileTreeRefresh_Clean(b *testing.B) {
+	tree := NewFileTree(newBenchRepo(b))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := tree.Refresh(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFileTreeRefresh_Dirty measures the status refresh with staged
+// and unstaged changes, which also loads diff stats.
+func BenchmarkFileTreeRefresh_Dirty(b *testing.B) {
+	dir := newBenchRepo(b)
+	if err := os.WriteFile(filepath.Join(dir, "pkg0/file0.go"), []byte("changed\n"), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	gitRunner(b, dir)("add", "pkg0/file0.go")
+	if err := os.WriteFile(filepath.Join(dir, "pkg1/file1.go"), []byte("changed\n"), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	tree := NewFileTree(dir)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := tree.Refresh(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetCommitHistoryWithPushStatus measures the history page loaded
+// on every refresh.
+func BenchmarkGetCommitHistoryWithPushStatus(b *testing.B) {
+	dir := newBenchRepo(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := GetCommitHistoryWithPushStatus(dir, commitHistoryPageSize); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetCommitDetail measures loading a commit preview.
+func BenchmarkGetCommitDetail(b *testing.B) {
+	dir := newBenchRepo(b)
+	defer CloseObjectReader(dir)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetCommitDetail(dir, "HEAD~2"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,11 +3,21 @@ package gitstatus
 import "github.com/guyghost/sidecar/internal/git"
 
 // Re-export remote types from internal/git for backward compatibility.
-type RemoteError = git.RemoteError
+type (
+	RemoteError = git.RemoteError
+	Remote      = git.Remote
+)
 
 // Re-export remote functions.
 var (
 	ExecuteFetch         = git.ExecuteFetch
+	FetchRemote          = git.FetchRemote
+	GetRemotes           = git.GetRemotes
+	GetRemoteBranches    = git.GetRemoteBranches
+	AddRemote            = git.AddRemote
+	RenameRemote         = git.RenameRemote
+	SetRemoteURL         = git.SetRemoteURL
+	RemoveRemote         = git.RemoveRemote
 	ExecutePull          = git.ExecutePull
 	ExecutePullRebase    = git.ExecutePullRebase
 	ExecutePullFFOnly    = git.ExecutePullFFOnly
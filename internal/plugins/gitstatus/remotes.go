@@ -0,0 +1,561 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	remoteItemPrefix   = "remote-item-" // List item ID prefix, followed by remote index
+	remoteEditNameID   = "remote-edit-name"
+	remoteEditURLID    = "remote-edit-url"
+	remoteEditActionID = "remote-edit-save"
+)
+
+// Remote operations reported by RemoteOpDoneMsg.
+const (
+	remoteOpAdd    = "add"
+	remoteOpEdit   = "edit"
+	remoteOpRemove = "remove"
+	remoteOpFetch  = "fetch"
+)
+
+// remoteOpTitles maps a remote operation to the error modal title for its
+// failure.
+var remoteOpTitles = map[string]string{
+	remoteOpAdd:    "Add Remote Failed",
+	remoteOpEdit:   "Edit Remote Failed",
+	remoteOpRemove: "Remove Remote Failed",
+	remoteOpFetch:  "Fetch Failed",
+}
+
+// RemotesLoadedMsg is sent when the remotes panel's list is read.
+type RemotesLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Remotes []*Remote
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m RemotesLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// RemoteOpDoneMsg is sent when a remote add, edit, remove or fetch returns.
+type RemoteOpDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Op    string // remoteOpAdd, remoteOpEdit, remoteOpRemove or remoteOpFetch
+	Name  string // Remote operated on; empty for a fetch of all remotes
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m RemoteOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openRemotes opens the remotes panel.
+func (p *Plugin) openRemotes() tea.Cmd {
+	p.viewMode = ViewModeRemotes
+	p.remoteCursor = 0
+	p.remoteBusy = ""
+	p.remoteConfirmRemove = false
+	p.remotesErr = ""
+	p.remotesLoaded = false
+	p.remotesModal = nil
+	return p.loadRemotes()
+}
+
+// loadRemotes lists the repository's remotes.
+func (p *Plugin) loadRemotes() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		remotes, err := GetRemotes(workDir)
+		return RemotesLoadedMsg{Epoch: epoch, Remotes: remotes, Err: err}
+	}
+}
+
+// handleRemotesLoaded fills the remotes panel.
+func (p *Plugin) handleRemotesLoaded(msg RemotesLoadedMsg) {
+	p.remotesLoaded = true
+	p.remotes = msg.Remotes
+	p.remotesErr = ""
+	if msg.Err != nil {
+		p.remotesErr = msg.Err.Error()
+	}
+	if p.remoteCursor >= len(p.remotes) {
+		p.remoteCursor = len(p.remotes) - 1
+	}
+	if p.remoteCursor < 0 {
+		p.remoteCursor = 0
+	}
+}
+
+// closeRemotes closes the remotes panel.
+func (p *Plugin) closeRemotes() {
+	p.viewMode = ViewModeStatus
+	p.remoteConfirmRemove = false
+	p.remotesModal = nil
+	p.remotesModalWidth = 0
+}
+
+// selectedRemote returns the remote under the cursor in the panel.
+func (p *Plugin) selectedRemote() *Remote {
+	if p.remoteCursor >= 0 && p.remoteCursor < len(p.remotes) {
+		return p.remotes[p.remoteCursor]
+	}
+	return nil
+}
+
+// updateRemotes handles key events in the remotes panel.
+func (p *Plugin) updateRemotes(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	key := msg.String()
+
+	// A pending remove takes y to confirm; any other key cancels it
+	if p.remoteConfirmRemove {
+		p.remoteConfirmRemove = false
+		if key == "y" {
+			return p, p.doRemoveRemote()
+		}
+		return p, nil
+	}
+
+	switch key {
+	case "esc", "q":
+		p.closeRemotes()
+	case "j", "down":
+		if p.remoteCursor < len(p.remotes)-1 {
+			p.remoteCursor++
+		}
+	case "k", "up":
+		if p.remoteCursor > 0 {
+			p.remoteCursor--
+		}
+	case "g":
+		p.remoteCursor = 0
+	case "G":
+		if len(p.remotes) > 0 {
+			p.remoteCursor = len(p.remotes) - 1
+		}
+	case "a":
+		return p, p.openRemoteEdit(nil)
+	case "e":
+		if r := p.selectedRemote(); r != nil {
+			return p, p.openRemoteEdit(r)
+		}
+	case "d":
+		if p.selectedRemote() != nil && p.remoteBusy == "" {
+			p.remoteConfirmRemove = true
+		}
+	case "f":
+		if r := p.selectedRemote(); r != nil {
+			return p, p.doFetchRemote(r.Name)
+		}
+	case "F":
+		if len(p.remotes) > 0 {
+			return p, p.doFetchRemote("")
+		}
+	case "p", "enter":
+		if r := p.selectedRemote(); r != nil && !p.pushInProgress {
+			branch := ""
+			if p.pushStatus != nil {
+				branch = p.pushStatus.CurrentBranch
+			}
+			return p, p.openPushMenu(r.Name, branch)
+		}
+	case "r":
+		return p, p.loadRemotes()
+	}
+	return p, nil
+}
+
+// handleRemotesMouse handles mouse events in the remotes panel.
+func (p *Plugin) handleRemotesMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.remotesModal == nil {
+		return p, nil
+	}
+	action := p.remotesModal.HandleMouse(msg, p.mouseHandler)
+	if action == "cancel" {
+		p.closeRemotes()
+		return p, nil
+	}
+	if idx, err := strconv.Atoi(strings.TrimPrefix(action, remoteItemPrefix)); err == nil && strings.HasPrefix(action, remoteItemPrefix) {
+		p.remoteCursor = idx
+	}
+	return p, nil
+}
+
+// doFetchRemote fetches name with --prune, or every remote when name is
+// empty.
+func (p *Plugin) doFetchRemote(name string) tea.Cmd {
+	if p.remoteBusy != "" {
+		return nil
+	}
+	if name == "" {
+		p.remoteBusy = "Fetching all remotes..."
+	} else {
+		p.remoteBusy = "Fetching " + name + "..."
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		_, err := FetchRemote(workDir, name, true)
+		return RemoteOpDoneMsg{Epoch: epoch, Op: remoteOpFetch, Name: name, Err: err}
+	}
+}
+
+// doRemoveRemote removes the selected remote.
+func (p *Plugin) doRemoveRemote() tea.Cmd {
+	r := p.selectedRemote()
+	if r == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	name := r.Name
+	return func() tea.Msg {
+		err := RemoveRemote(workDir, name)
+		return RemoteOpDoneMsg{Epoch: epoch, Op: remoteOpRemove, Name: name, Err: err}
+	}
+}
+
+// handleRemoteOpDone shows the outcome of a remote operation and reloads
+// the remotes and push status.
+func (p *Plugin) handleRemoteOpDone(msg RemoteOpDoneMsg) tea.Cmd {
+	p.remoteBusy = ""
+	if msg.Err != nil {
+		if (msg.Op == remoteOpAdd || msg.Op == remoteOpEdit) && p.viewMode == ViewModeRemoteEdit {
+			// Keep the modal open so the name or URL can be fixed
+			p.remoteEditErr = msg.Err.Error()
+			return nil
+		}
+		p.showErrorModal(remoteOpTitles[msg.Op], msg.Err)
+		return tea.Batch(p.loadRemotes(), p.refresh())
+	}
+
+	var toast string
+	switch msg.Op {
+	case remoteOpAdd:
+		toast = "Added remote " + msg.Name
+		p.closeRemoteEdit()
+	case remoteOpEdit:
+		toast = "Updated remote " + msg.Name
+		p.closeRemoteEdit()
+	case remoteOpRemove:
+		toast = "Removed remote " + msg.Name
+	case remoteOpFetch:
+		toast = "Fetched " + msg.Name
+		if msg.Name == "" {
+			toast = "Fetched all remotes"
+		}
+	}
+	return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), p.loadRemotes(), p.refresh(), p.loadRecentCommits())
+}
+
+// ensureRemotesModal builds/rebuilds the remotes panel.
+func (p *Plugin) ensureRemotesModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.remotesModal != nil && p.remotesModalWidth == modalW {
+		return
+	}
+	p.remotesModalWidth = modalW
+
+	p.remotesModal = modal.New("Remotes",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.remotesListSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.remotesStatusSection())
+}
+
+// remotesListSection renders the remote list with the cursor kept in view.
+func (p *Plugin) remotesListSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if !p.remotesLoaded {
+			return modal.RenderedSection{Content: styles.Muted.Render("Loading remotes...")}
+		}
+		if len(p.remotes) == 0 {
+			return modal.RenderedSection{Content: styles.Muted.Render("No remotes. Press a to add one.")}
+		}
+
+		maxVisible := p.branchPickerMaxVisible()
+		start := 0
+		if p.remoteCursor >= maxVisible {
+			start = p.remoteCursor - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(p.remotes))
+
+		nameW, stateW := 0, 0
+		for _, r := range p.remotes {
+			nameW = max(nameW, ansi.StringWidth(r.Name))
+			stateW = max(stateW, ansi.StringWidth(remoteStateText(r)))
+		}
+		nameW = min(nameW, 20)
+		stateW = min(stateW, 32)
+
+		var sb strings.Builder
+		focusables := make([]modal.FocusableInfo, 0, end-start)
+		for i := start; i < end; i++ {
+			itemID := fmt.Sprintf("%s%d", remoteItemPrefix, i)
+			line := renderRemoteLine(p.remotes[i], nameW, stateW, contentWidth, i == p.remoteCursor || itemID == hoverID)
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(line)
+			focusables = append(focusables, modal.FocusableInfo{
+				ID:      itemID,
+				OffsetY: i - start,
+				Width:   contentWidth,
+				Height:  1,
+			})
+		}
+		if len(p.remotes) > maxVisible {
+			sb.WriteString("\n\n" + styles.Muted.Render(fmt.Sprintf("%d/%d remotes", p.remoteCursor+1, len(p.remotes))))
+		}
+		return modal.RenderedSection{Content: sb.String(), Focusables: focusables}
+	}, nil)
+}
+
+// remoteStateText describes how HEAD compares to the remote's branch.
+func remoteStateText(r *Remote) string {
+	if r.Branch == "" {
+		return "not pushed"
+	}
+	var parts []string
+	if r.Ahead > 0 {
+		parts = append(parts, "↑"+strconv.Itoa(r.Ahead))
+	}
+	if r.Behind > 0 {
+		parts = append(parts, "↓"+strconv.Itoa(r.Behind))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "✓")
+	}
+	return strings.Join(parts, " ") + " " + r.Branch
+}
+
+// renderRemoteLine renders one remote: its name, how HEAD compares to it,
+// and its fetch URL.
+func renderRemoteLine(r *Remote, nameW, stateW, width int, selected bool) string {
+	name := ui.TruncateString(r.Name, nameW)
+	name += strings.Repeat(" ", nameW-ansi.StringWidth(name))
+	state := ui.TruncateString(remoteStateText(r), stateW)
+	state += strings.Repeat(" ", stateW-ansi.StringWidth(state))
+	prefix := name + "  " + state + "  "
+	url := ui.TruncateString(r.FetchURL, max(width-ansi.StringWidth(prefix), 0))
+
+	if selected {
+		line := prefix + url
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	stateStyle := styles.Muted
+	switch {
+	case r.Branch != "" && r.Ahead > 0 && r.Behind > 0:
+		stateStyle = styles.StatusModified
+	case r.Branch != "" && r.Ahead == 0 && r.Behind == 0:
+		stateStyle = styles.StatusStaged
+	}
+	return styles.Body.Render(name) + "  " + stateStyle.Render(state) + "  " + styles.Muted.Render(url)
+}
+
+// remotesStatusSection shows the selected remote's URLs, pending
+// confirmations, operation progress and key hints.
+func (p *Plugin) remotesStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var lines []string
+		r := p.selectedRemote()
+		if r != nil {
+			tracking := "no local branches track " + r.Name
+			if r.Tracking == 1 {
+				tracking = "1 local branch tracks " + r.Name
+			} else if r.Tracking > 1 {
+				tracking = fmt.Sprintf("%d local branches track %s", r.Tracking, r.Name)
+			}
+			lines = append(lines, styles.Muted.Render(tracking))
+			if r.PushURL != r.FetchURL {
+				lines = append(lines, styles.Muted.Render(ui.TruncateString("push: "+r.PushURL, contentWidth)))
+			}
+		}
+		switch {
+		case p.remoteConfirmRemove && r != nil:
+			lines = append(lines, styles.StatusDeleted.Render("Remove remote "+r.Name+" and its remote-tracking branches? y to confirm, any key to cancel"))
+		case p.remoteBusy != "":
+			lines = append(lines, styles.StatusInProgress.Render(p.remoteBusy))
+		case p.remotesErr != "":
+			lines = append(lines, styles.StatusDeleted.Render(ui.TruncateString(p.remotesErr, contentWidth)))
+		}
+		lines = append(lines, styles.Muted.Render("a add · e edit · d remove · f fetch · F fetch all · p push · esc close"))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderRemotes renders the remotes panel over the status view.
+func (p *Plugin) renderRemotes() string {
+	background := p.renderThreePaneView()
+	p.ensureRemotesModal()
+	modalContent := p.remotesModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// openRemoteEdit opens the remote modal to edit r, or to add a remote when
+// r is nil.
+func (p *Plugin) openRemoteEdit(r *Remote) tea.Cmd {
+	p.remoteEditOld = ""
+	p.remoteEditName = textinput.New()
+	p.remoteEditName.Placeholder = "upstream"
+	p.remoteEditName.CharLimit = 100
+	p.remoteEditURL = textinput.New()
+	p.remoteEditURL.Placeholder = "git@github.com:owner/repo.git"
+	p.remoteEditURL.CharLimit = 500
+	if r != nil {
+		p.remoteEditOld = r.Name
+		p.remoteEditName.SetValue(r.Name)
+		p.remoteEditURL.SetValue(r.FetchURL)
+	}
+	p.remoteEditName.Focus()
+	p.remoteEditErr = ""
+	p.remoteEditModal = nil
+	p.viewMode = ViewModeRemoteEdit
+	return textinput.Blink
+}
+
+// closeRemoteEdit returns from the remote modal to the remotes panel.
+func (p *Plugin) closeRemoteEdit() {
+	p.viewMode = ViewModeRemotes
+	p.remoteEditErr = ""
+	p.remoteEditModal = nil
+	p.remoteEditWidth = 0
+}
+
+// ensureRemoteEditModal builds/rebuilds the remote modal.
+func (p *Plugin) ensureRemoteEditModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.remoteEditModal != nil && p.remoteEditWidth == modalW {
+		return
+	}
+	p.remoteEditWidth = modalW
+
+	title, action := "Add Remote", " Add "
+	if p.remoteEditOld != "" {
+		title, action = "Edit Remote "+p.remoteEditOld, " Save "
+	}
+	p.remoteEditModal = modal.New(title,
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(remoteEditActionID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.InputWithLabel(remoteEditNameID, "Name", &p.remoteEditName)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(remoteEditURLID, "URL", &p.remoteEditURL)).
+		AddSection(modal.When(func() bool { return p.remoteEditErr != "" }, modal.Custom(
+			func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+				return modal.RenderedSection{Content: styles.StatusDeleted.Render(strings.TrimSpace(p.remoteEditErr))}
+			}, nil))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(action, remoteEditActionID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// updateRemoteEdit handles key events in the remote modal.
+func (p *Plugin) updateRemoteEdit(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureRemoteEditModal()
+	action, cmd := p.remoteEditModal.HandleKey(msg)
+	switch action {
+	case remoteEditActionID:
+		return p, p.doSaveRemote()
+	case "cancel":
+		p.closeRemoteEdit()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handleRemoteEditMouse handles mouse events in the remote modal.
+func (p *Plugin) handleRemoteEditMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.remoteEditModal == nil {
+		return p, nil
+	}
+	switch p.remoteEditModal.HandleMouse(msg, p.mouseHandler) {
+	case remoteEditActionID:
+		return p, p.doSaveRemote()
+	case "cancel":
+		p.closeRemoteEdit()
+	}
+	return p, nil
+}
+
+// doSaveRemote adds the remote described by the remote modal, or renames
+// and re-points the remote being edited.
+func (p *Plugin) doSaveRemote() tea.Cmd {
+	name := strings.TrimSpace(p.remoteEditName.Value())
+	url := strings.TrimSpace(p.remoteEditURL.Value())
+	if name == "" {
+		p.remoteEditErr = "Remote name is required"
+		return nil
+	}
+	if url == "" {
+		p.remoteEditErr = "Remote URL is required"
+		return nil
+	}
+	p.remoteEditErr = ""
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	old := p.remoteEditOld
+	if old == "" {
+		return func() tea.Msg {
+			err := AddRemote(workDir, name, url)
+			return RemoteOpDoneMsg{Epoch: epoch, Op: remoteOpAdd, Name: name, Err: err}
+		}
+	}
+	oldURL := ""
+	for _, r := range p.remotes {
+		if r.Name == old {
+			oldURL = r.FetchURL
+		}
+	}
+	return func() tea.Msg {
+		var err error
+		if name != old {
+			err = RenameRemote(workDir, old, name)
+		}
+		if err == nil && url != oldURL {
+			err = SetRemoteURL(workDir, name, url)
+		}
+		return RemoteOpDoneMsg{Epoch: epoch, Op: remoteOpEdit, Name: name, Err: err}
+	}
+}
+
+// renderRemoteEdit renders the remote modal over the status view.
+func (p *Plugin) renderRemoteEdit() string {
+	background := p.renderThreePaneView()
+	p.ensureRemoteEditModal()
+	modalContent := p.remoteEditModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
@@ -0,0 +1,162 @@
+package gitstatus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+// newRemotesPlugin returns a plugin on a clone of origin with a local
+// commit, and the path of a second bare repository not yet added.
+func newRemotesPlugin(t *testing.T) (p *Plugin, spare string) {
+	t.Helper()
+	origin, _ := initTestRepo(t, "--bare")
+	spare, _ = initTestRepo(t, "--bare")
+	dir, git := initTestRepo(t)
+	commitFile(t, dir, "a.txt", "one\n", "one")
+	git("remote", "add", "origin", origin)
+	git("push", "-q", "-u", "origin", "main")
+	commitFile(t, dir, "b.txt", "two\n", "two")
+
+	p = newTestPlugin(t, dir)
+	p.pushStatus = GetPushStatus(dir)
+	return p, spare
+}
+
+// typeText sends s to the plugin one key at a time, rendering in between
+// so focused inputs receive the keys. A modal only learns its focus order
+// on its first render, so it is rendered once more up front.
+func typeText(p *Plugin, s string) {
+	p.View(120, 30)
+	for _, r := range s {
+		p.View(120, 30)
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestRemotes_AddFetchAndRemove(t *testing.T) {
+	p, spare := newRemotesPlugin(t)
+
+	_, cmd := p.Update(runeKey("E"))
+	if p.viewMode != ViewModeRemotes || p.FocusContext() != keymap.ContextGitRemotes || cmd == nil {
+		t.Fatal("E should open the remotes panel")
+	}
+	p.Update(cmd())
+	if view := p.View(120, 30); !strings.Contains(view, "origin") || !strings.Contains(view, "↑1 origin/main") {
+		t.Errorf("panel should show origin one commit ahead:\n%s", view)
+	}
+
+	p.Update(runeKey("a"))
+	if p.viewMode != ViewModeRemoteEdit || !p.ConsumesTextInput() {
+		t.Fatal("a should open the add remote modal")
+	}
+	typeText(p, "upstream")
+	p.View(120, 30)
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(p, spare)
+	p.View(120, 30)
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should add the remote")
+	}
+	_, cmd = p.Update(cmd())
+	if p.viewMode != ViewModeRemotes {
+		t.Fatalf("an added remote should return to the panel, viewMode=%v (%s)", p.viewMode, p.remoteEditErr)
+	}
+	p.Update(p.loadRemotes()())
+	if len(p.remotes) != 2 || p.remotes[1].Name != "upstream" || p.remotes[1].FetchURL != spare {
+		t.Fatalf("remotes = %+v", p.remotes)
+	}
+
+	p.Update(runeKey("j"))
+	_, cmd = p.Update(runeKey("f"))
+	if cmd == nil || p.remoteBusy == "" {
+		t.Fatal("f should fetch the selected remote")
+	}
+	msg := cmd().(RemoteOpDoneMsg)
+	if msg.Err != nil || msg.Name != "upstream" {
+		t.Fatalf("fetch = %+v", msg)
+	}
+	p.Update(msg)
+
+	p.Update(runeKey("d"))
+	if !strings.Contains(p.View(120, 30), "Remove remote upstream") {
+		t.Error("d should ask for confirmation")
+	}
+	_, cmd = p.Update(runeKey("y"))
+	if msg := cmd().(RemoteOpDoneMsg); msg.Err != nil || msg.Op != remoteOpRemove {
+		t.Fatalf("remove = %+v", msg)
+	}
+	if names := GetRemoteNames(p.repoRoot); len(names) != 1 || names[0] != "origin" {
+		t.Errorf("remotes after remove = %q", names)
+	}
+}
+
+func TestPushMenu_PushesToChosenRemoteAndBranch(t *testing.T) {
+	p, spare := newRemotesPlugin(t)
+	if err := AddRemote(p.repoRoot, "fork", spare); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cmd := p.Update(runeKey("P"))
+	if p.viewMode != ViewModePushMenu || cmd == nil {
+		t.Fatal("P should open the push menu")
+	}
+	p.Update(cmd())
+	if p.pushMenuRemote != "origin" || p.pushMenuBranch.Value() != "main" || p.pushMenuCustomTarget() {
+		t.Fatalf("default target = %s/%s", p.pushMenuRemote, p.pushMenuBranch.Value())
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "↑1 ↓0 against origin/main") {
+		t.Errorf("menu should compare HEAD with origin/main:\n%s", view)
+	}
+
+	_, cmd = p.Update(runeKey("l"))
+	if p.pushMenuRemote != "fork" || cmd == nil {
+		t.Fatalf("l should pick the next remote, got %q", p.pushMenuRemote)
+	}
+	p.Update(cmd())
+	if view := p.View(120, 30); !strings.Contains(view, "fork/main does not exist yet") {
+		t.Errorf("menu should note the missing branch:\n%s", view)
+	}
+
+	p.Update(runeKey("b"))
+	if p.FocusContext() != keymap.ContextGitPushBranch || !p.ConsumesTextInput() {
+		t.Fatal("b should focus the branch input")
+	}
+	p.View(120, 30)
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(p, "topic")
+	if p.pushMenuBranch.Value() != "topic" || !p.pushMenuCustomTarget() {
+		t.Fatalf("branch = %q", p.pushMenuBranch.Value())
+	}
+
+	p.View(120, 30)
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.viewMode != ViewModeStatus || cmd == nil {
+		t.Fatal("enter in the branch input should push")
+	}
+	if msg, ok := cmd().(PushSuccessMsg); !ok {
+		t.Fatalf("push failed: %+v", msg)
+	}
+	if ps := GetPushStatusFor(p.repoRoot, "fork/topic"); !ps.HasUpstream || ps.Ahead != 0 {
+		t.Errorf("fork/topic should match HEAD: %+v", ps)
+	}
+	if ps := GetPushStatus(p.repoRoot); ps.UpstreamBranch != "origin/main" {
+		t.Errorf("a plain push elsewhere should keep the upstream, got %q", ps.UpstreamBranch)
+	}
+}
+
+func TestPushMenu_DropsStaleTarget(t *testing.T) {
+	p, _ := newRemotesPlugin(t)
+	p.openPushMenu("origin", "main")
+	p.pushMenuBranch.SetValue("other")
+	p.Update(PushTargetLoadedMsg{Epoch: 3, Remote: "origin", Branch: "main", Status: &PushStatus{HasUpstream: true}})
+	if p.pushMenuTarget != nil {
+		t.Error("a result for a branch no longer chosen should be ignored")
+	}
+}
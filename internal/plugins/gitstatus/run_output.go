@@ -0,0 +1,65 @@
+package gitstatus
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// waitRunEvents waits for the next output or the end of a streamed run.
+func waitRunEvents(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// runOutputWriter turns the output of a streamed run into messages, one per
+// write of complete lines, wrapped by wrap.
+type runOutputWriter struct {
+	ctx     context.Context
+	events  chan<- tea.Msg
+	wrap    func(lines []string) tea.Msg
+	partial string
+}
+
+func (w *runOutputWriter) Write(b []byte) (int, error) {
+	text := w.partial + string(b)
+	idx := strings.LastIndexByte(text, '\n')
+	if idx < 0 {
+		w.partial = text
+		return len(b), nil
+	}
+	w.partial = text[idx+1:]
+	w.send(splitOutputLines(text[:idx]))
+	return len(b), nil
+}
+
+// flush sends a last line that did not end in a newline.
+func (w *runOutputWriter) flush() {
+	if w.partial != "" {
+		w.send([]string{w.partial})
+		w.partial = ""
+	}
+}
+
+func (w *runOutputWriter) send(lines []string) {
+	select {
+	case w.events <- w.wrap(lines):
+	case <-w.ctx.Done():
+	}
+}
+
+// splitOutputLines splits command output into lines, dropping carriage
+// returns and a trailing newline.
+func splitOutputLines(output string) []string {
+	output = strings.TrimRight(strings.ReplaceAll(output, "\r", ""), "\n")
+	if output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
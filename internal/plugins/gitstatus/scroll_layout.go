@@ -68,12 +68,34 @@ func (p *Plugin) clampCommitScroll() {
 }
 
 
+// hasMoreCommits reports whether another page of the active commit list
+// (filtered or not) can be loaded.
+func (p *Plugin) hasMoreCommits() bool {
+	if p.historyFilterActive {
+		return p.moreFilteredAvailable
+	}
+	return p.moreCommitsAvailable
+}
+
+// prefetchCommits loads the next page of history in the background once
+// the selected commit is within commitPrefetchMargin of the last one loaded,
+// so scrolling rarely reaches the end of the list before the page arrives.
+func (p *Plugin) prefetchCommits() tea.Cmd {
+	if p.loadingMoreCommits || !p.hasMoreCommits() || !p.cursorOnCommit() {
+		return nil
+	}
+	if p.selectedCommitIndex() < len(p.activeCommits())-commitPrefetchMargin {
+		return nil
+	}
+	return p.loadMoreCommits()
+}
+
 func (p *Plugin) ensureCommitListFilled() tea.Cmd {
-	if p.historyFilterActive || p.loadingMoreCommits || !p.moreCommitsAvailable {
+	if p.loadingMoreCommits || !p.hasMoreCommits() {
 		return nil
 	}
 	visibleCommits := p.visibleCommitCount()
-	if visibleCommits < 1 || len(p.recentCommits) >= visibleCommits {
+	if visibleCommits < 1 || len(p.activeCommits()) >= visibleCommits {
 		return nil
 	}
 	return p.loadMoreCommits()
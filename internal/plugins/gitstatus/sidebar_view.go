@@ -121,11 +121,18 @@ func (p *Plugin) renderSidebar(visibleHeight int) string {
 
 	// Header with branch name (truncated to fit sidebar)
 	header := styles.Title.Render("Git")
+	// Inside a submodule, the path entered from the root repository
+	crumbLen := 0
+	if crumb := p.submoduleBreadcrumb(); crumb != "" {
+		crumb = ui.TruncateString(crumb, max(p.sidebarWidth/2, 8))
+		crumbLen = lipgloss.Width(crumb) + 1
+		header += " " + styles.StatusModified.Render(crumb)
+	}
 	if p.pushStatus != nil {
 		if p.pushStatus.CurrentBranch != "" {
 			branch := p.pushStatus.CurrentBranch
 			// "Git " = 4 chars, leave 4 for padding = max branch length is sidebarWidth - 8
-			maxLen := p.sidebarWidth - 8
+			maxLen := p.sidebarWidth - 8 - crumbLen
 			if maxLen > 0 && len(branch) > maxLen {
 				branch = branch[:maxLen-1] + "…"
 			}
@@ -364,22 +371,40 @@ func (p *Plugin) renderSidebarEntry(entry *FileEntry, selected bool, maxWidth in
 		return styles.ListItemNormal.Render(fmt.Sprintf("%s %s%s %s", status, indicator, displayName, styles.Muted.Render(countStr)))
 	}
 
+	// Submodules name what changed inside them after the path
+	suffix := ""
+	if entry.Submodule != nil {
+		label := entry.Submodule.Label()
+		if label == "" {
+			label = "submodule"
+		}
+		suffix = " (" + label + ")"
+	}
+
+	badge := ""
+	if entry.LFS {
+		badge = lfsBadge
+	}
+
 	// Path - truncate if needed
 	path := entry.Path
 	availableWidth := maxWidth - 2 // status + space
+	if len(path)+len(suffix)+len(badge) > availableWidth {
+		suffix, badge = "", ""
+	}
 	if len(path) > availableWidth && availableWidth > 3 {
 		path = "…" + path[len(path)-availableWidth+1:]
 	}
 
 	if selected {
-		plainLine := fmt.Sprintf("%s %s", string(entry.Status), path)
+		plainLine := fmt.Sprintf("%s %s%s%s", string(entry.Status), path, suffix, badge)
 		if len(plainLine) < maxWidth {
 			plainLine += strings.Repeat(" ", maxWidth-len(plainLine))
 		}
 		return styles.ListItemSelected.Render(plainLine)
 	}
 
-	return styles.ListItemNormal.Render(fmt.Sprintf("%s %s", status, path))
+	return styles.ListItemNormal.Render(fmt.Sprintf("%s %s%s%s", status, path, styles.Muted.Render(suffix), styles.StatusInProgress.Render(badge)))
 }
 
 // renderRecentCommits renders the recent commits section in the sidebar.
@@ -398,6 +423,9 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 		if p.historyFilterPath != "" {
 			filterParts = append(filterParts, "path:"+truncateStr(p.historyFilterPath, 10))
 		}
+		if p.historyQuery != nil {
+			filterParts = append(filterParts, truncateStr(p.historyQuery.Label(), 20))
+		}
 		if len(filterParts) > 0 {
 			header = fmt.Sprintf("Commits %s", styles.StatusModified.Render("["+strings.Join(filterParts, ", ")+"]"))
 		}
@@ -407,10 +435,30 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 			header = fmt.Sprintf("Recent Commits %s", styles.StatusModified.Render(status))
 		}
 	}
+	// Latest tag and how far HEAD has moved past it
+	if !p.historyFilterActive && p.latestTag != "" {
+		tagInfo := p.latestTag
+		if p.sinceLatestTag > 0 {
+			tagInfo += fmt.Sprintf("+%d", p.sinceLatestTag)
+		}
+		header += " " + styles.Muted.Render(tagInfo)
+	}
 	// Add graph indicator if enabled
 	if p.showCommitGraph {
 		header += " " + styles.Muted.Render("[graph]")
 	}
+	if n := len(p.markedCommits); n > 0 {
+		header += " " + styles.StatusStaged.Render(fmt.Sprintf("[%d selected]", n))
+	}
+	if p.bisect != nil && p.bisect.Active {
+		badge := "[bisect]"
+		if p.bisect.Done() {
+			badge = "[bisect: found]"
+		} else if p.bisect.Candidates != nil {
+			badge = fmt.Sprintf("[bisect: %d left]", p.bisect.Remaining)
+		}
+		header += " " + styles.StatusInProgress.Render(badge)
+	}
 	headerLine := styles.Title.Render(header)
 	headerWidth := p.sidebarWidth - 4
 	if headerWidth > 0 {
@@ -435,19 +483,6 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 		return sb.String()
 	}
 
-	// Calculate graph column width if graph is shown
-	graphWidth := 0
-	if p.showCommitGraph && len(p.commitGraphLines) > 0 {
-		for _, gl := range p.commitGraphLines {
-			if gl.Width > graphWidth {
-				graphWidth = gl.Width
-			}
-		}
-		if graphWidth > 12 {
-			graphWidth = 12 // Cap graph width to prevent overflow
-		}
-	}
-
 	// Cursor selection: cursor indexes files first, then commits
 	fileCount := len(p.tree.AllEntries())
 	maxWidth := p.sidebarWidth - 5
@@ -468,6 +503,19 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 		endIdx = len(commits)
 	}
 	var commitsSB strings.Builder
+	sigColumn := p.showSignatureColumn(commits[startIdx:endIdx])
+
+	// Graph column width fits the visible rows only, so rendering cost does
+	// not grow with the loaded history
+	graphWidth := 0
+	if p.showCommitGraph {
+		for i := startIdx; i < endIdx && i < len(p.commitGraphLines); i++ {
+			graphWidth = max(graphWidth, p.commitGraphLines[i].Width)
+		}
+		if graphWidth > 12 {
+			graphWidth = 12 // Cap graph width to prevent overflow
+		}
+	}
 
 	for i := startIdx; i < endIdx; i++ {
 		commit := commits[i]
@@ -482,32 +530,62 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 			graphVisualWidth = graphWidth
 		}
 
-		// Push indicator: ↑ for unpushed, nothing for pushed
+		// Push indicator: ↑ for unpushed, nothing for pushed; ✓ marks a selected commit.
+		// During a bisect the commit's verdict takes the push indicator's place.
+		marked := p.markedCommits[commit.Hash]
+		bisectMark, bisectPlain := p.bisectIndicator(commit.Hash)
 		var indicator string
-		if !commit.Pushed {
+		if marked {
+			indicator = styles.StatusStaged.Render("✓") + " "
+		} else if bisectMark != "" {
+			indicator = bisectMark + " "
+		} else if !commit.Pushed {
 			indicator = styles.StatusModified.Render("↑") + " "
 		} else {
 			indicator = "  " // Two spaces to align with indicator
 		}
 
-		// Format: "[graph] ↑ abc1234 commit message..."
+		// Format: "[graph] ↑ abc1234 ✓ commit message..."
 		hash := styles.Code.Render(commit.Hash[:7])
 		msgWidth := maxWidth - 12 - graphVisualWidth // indicator + hash + space + graph
+		var sigMark, sigPlain string
+		if sigColumn {
+			mark, style := signatureMark(p.commitSignature(commit))
+			sigMark, sigPlain = style.Render(mark)+" ", mark+" "
+			msgWidth -= 2
+		}
 		if msgWidth < 10 {
 			msgWidth = 10
 		}
+		// Tag decoration shares the message width, ahead of the subject
+		deco := tagDecoration(p.tagsByHash[commit.Hash])
 		// Truncate commit message (rune-safe for Unicode)
-		msg := commit.Subject
+		msg := deco + commit.Subject
 		if runes := []rune(msg); len(runes) > msgWidth && msgWidth > 3 {
 			msg = string(runes[:msgWidth-1]) + "…"
 		}
+		styledMsg := msg
+		if n := len([]rune(deco)); n > 0 {
+			runes := []rune(msg)
+			if n > len(runes) {
+				n = len(runes)
+			}
+			styledMsg = styles.StatusModified.Render(string(runes[:n])) + string(runes[n:])
+		}
+		if p.outsideBisectRange(commit.Hash) {
+			styledMsg = styles.Muted.Render(msg)
+		}
 
 		// Register hit region for this commit with ABSOLUTE index
 		p.mouseHandler.HitMap.AddRect(regionCommit, 1, *currentY, p.sidebarWidth-3, 1, i)
 
 		if selected {
 			plainIndicator := "  "
-			if !commit.Pushed {
+			if marked {
+				plainIndicator = "✓ "
+			} else if bisectPlain != "" {
+				plainIndicator = bisectPlain + " "
+			} else if !commit.Pushed {
 				plainIndicator = "↑ "
 			}
 			// For selected lines, include graph prefix without styling (will be styled by selection)
@@ -515,7 +593,7 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 			if graphStr != "" {
 				graphPlain = p.renderGraphLinePlain(p.commitGraphLines[i], graphWidth)
 			}
-			plainLine := fmt.Sprintf("%s%s%s %s", graphPlain, plainIndicator, commit.Hash[:7], msg)
+			plainLine := fmt.Sprintf("%s%s%s %s%s", graphPlain, plainIndicator, commit.Hash[:7], sigPlain, msg)
 			// Pad to full width
 			lineWidth := lipgloss.Width(plainLine)
 			if lineWidth < maxWidth {
@@ -523,7 +601,7 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 			}
 			commitsSB.WriteString(styles.ListItemSelected.Render(plainLine))
 		} else {
-			line := fmt.Sprintf("%s%s%s %s", graphStr, indicator, hash, msg)
+			line := fmt.Sprintf("%s%s%s %s%s", graphStr, indicator, hash, sigMark, styledMsg)
 			lineWidth := lipgloss.Width(line)
 			if lineWidth < maxWidth {
 				line += strings.Repeat(" ", maxWidth-lineWidth)
@@ -554,7 +632,7 @@ func (p *Plugin) renderRecentCommits(currentY *int, maxVisible int) string {
 func (p *Plugin) renderGraphLine(gl GraphLine, width int) string {
 	var sb strings.Builder
 	commitStyle := lipgloss.NewStyle().Foreground(styles.Accent)
-	for _, ch := range gl.Chars {
+	for _, ch := range graphChars(gl, width) {
 		switch ch {
 		case '*':
 			sb.WriteString(commitStyle.Render("●"))
@@ -582,7 +660,7 @@ func (p *Plugin) renderGraphLine(gl GraphLine, width int) string {
 // renderGraphLinePlain formats a GraphLine to a plain fixed-width string (for selected items).
 func (p *Plugin) renderGraphLinePlain(gl GraphLine, width int) string {
 	var sb strings.Builder
-	for _, ch := range gl.Chars {
+	for _, ch := range graphChars(gl, width) {
 		switch ch {
 		case '*':
 			sb.WriteRune('●')
@@ -606,6 +684,15 @@ func (p *Plugin) renderGraphLinePlain(gl GraphLine, width int) string {
 	return result
 }
 
+// graphChars returns the characters of gl that fit in width columns; lanes
+// past the capped graph column are cut rather than pushing the row wider.
+func graphChars(gl GraphLine, width int) []rune {
+	if len(gl.Chars) > width {
+		return gl.Chars[:width]
+	}
+	return gl.Chars
+}
+
 // renderDiffPane renders the right diff pane.
 func (p *Plugin) renderDiffPane(visibleHeight int) string {
 	// If previewing a commit, render commit preview instead of diff
@@ -663,6 +750,14 @@ func (p *Plugin) renderDiffPane(visibleHeight int) string {
 		return sb.String()
 	}
 
+	if p.selectedSubmoduleEntry() != nil {
+		lines := p.submoduleSummaryLines(diffWidth)
+		start := min(p.diffPaneScroll, max(len(lines)-1, 0))
+		end := min(start+max(visibleHeight-2, 1), len(lines))
+		sb.WriteString(strings.Join(lines[start:end], "\n"))
+		return sb.String()
+	}
+
 	if p.diffPaneParsedDiff == nil {
 		sb.WriteString(styles.Muted.Render("Loading diff..."))
 		return sb.String()
@@ -677,7 +772,9 @@ func (p *Plugin) renderDiffPane(visibleHeight int) string {
 	// Render diff based on view mode
 	highlighter := p.getHighlighter(p.selectedDiffFile)
 	var diffContent string
-	if p.diffPaneViewMode == DiffViewSideBySide {
+	if p.diffPaneImage != nil {
+		diffContent = p.renderImageDiff(p.diffPaneImage, diffWidth, contentHeight)
+	} else if p.diffPaneViewMode == DiffViewSideBySide {
 		diffContent = RenderSideBySide(p.diffPaneParsedDiff, diffWidth, p.diffPaneScroll, contentHeight, p.diffPaneHorizScroll, highlighter, p.diffWrapEnabled)
 	} else {
 		diffContent = RenderLineDiff(p.diffPaneParsedDiff, diffWidth, p.diffPaneScroll, contentHeight, p.diffPaneHorizScroll, highlighter, p.diffWrapEnabled)
@@ -732,6 +829,9 @@ func (p *Plugin) renderCommitPreview(visibleHeight int) string {
 	// Header with styled commit hash
 	sb.WriteString(styles.Title.Render("Commit "))
 	sb.WriteString(hashBadge.Render(c.ShortHash))
+	for _, name := range p.tagsByHash[c.Hash] {
+		sb.WriteString(" " + styles.StatusModified.Render(name))
+	}
 	sb.WriteString("\n\n")
 	currentY += 2 // header line + blank line from \n\n
 
@@ -748,8 +848,12 @@ func (p *Plugin) renderCommitPreview(visibleHeight int) string {
 	// Date with icon-like prefix
 	sb.WriteString(labelStyle.Render("󰃰 ")) // Calendar icon
 	sb.WriteString(styles.Muted.Render(RelativeTime(c.Date)))
+	sb.WriteString("\n")
+	currentY++
+
+	sb.WriteString(signatureSummary(p.commitSignature(c), maxWidth))
 	sb.WriteString("\n\n")
-	currentY += 2 // date + blank line
+	currentY += 2 // signature + blank line
 
 	// Subject in bold
 	subject := c.Subject
@@ -839,6 +943,10 @@ func (p *Plugin) renderCommitPreview(visibleHeight int) string {
 			p.mouseHandler.HitMap.AddRect(regionCommitFile, diffPaneX, currentY, p.diffPaneWidth-2, 1, i)
 
 			line := p.renderCommitPreviewFile(file, selected, maxWidth-4)
+			if p.previewMatchFiles[file.Path] {
+				// File changed by the active history search
+				line += " " + styles.StatusModified.Render("◆")
+			}
 			sb.WriteString(line)
 			sb.WriteString("\n")
 			currentY++
@@ -866,22 +974,30 @@ func (p *Plugin) renderCommitPreviewFile(file CommitFile, selected bool, maxWidt
 	}
 	status := statusStyle.Render(string(file.Status))
 
+	badge := ""
+	if file.LFS {
+		badge = lfsBadge
+	}
+
 	// Path - truncate if needed
 	path := file.Path
 	pathWidth := maxWidth - 4 // status + spacing
+	if len(path)+len(badge) > pathWidth {
+		badge = ""
+	}
 	if len(path) > pathWidth && pathWidth > 3 {
 		path = "…" + path[len(path)-pathWidth+1:]
 	}
 
 	if selected {
-		plainLine := fmt.Sprintf("%s %s", string(file.Status), path)
+		plainLine := fmt.Sprintf("%s %s%s", string(file.Status), path, badge)
 		if len(plainLine) < maxWidth {
 			plainLine += strings.Repeat(" ", maxWidth-len(plainLine))
 		}
 		return styles.ListItemSelected.Render(plainLine)
 	}
 
-	return styles.ListItemNormal.Render(fmt.Sprintf("%s %s", status, path))
+	return styles.ListItemNormal.Render(fmt.Sprintf("%s %s%s", status, path, styles.StatusInProgress.Render(badge)))
 }
 
 // truncateStr truncates a string to maxLen characters with ellipsis.
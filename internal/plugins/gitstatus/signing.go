@@ -0,0 +1,156 @@
+package gitstatus
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/state"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// signingProject returns the directory the sign-commits preference is saved
+// under: the project root, so worktrees and submodules share it.
+func (p *Plugin) signingProject() string {
+	if p.ctx != nil && p.ctx.ProjectRoot != "" {
+		return p.ctx.ProjectRoot
+	}
+	return p.repoRoot
+}
+
+// toggleCommitSign flips whether commits are made with -S and saves the
+// choice for the project.
+func (p *Plugin) toggleCommitSign() {
+	p.commitSign = !p.commitSign
+	_ = state.SetSignCommits(p.signingProject(), p.commitSign)
+	p.commitModal = nil
+	p.commitModalWidthCache = 0
+}
+
+// signingExpected reports whether new commits are signed, by git config or
+// the project preference.
+func (p *Plugin) signingExpected() bool {
+	return p.commitSign || p.signing.Enabled
+}
+
+// showSignatureColumn reports whether history rows carry a signature mark:
+// when signing is expected or any listed commit is signed.
+func (p *Plugin) showSignatureColumn(commits []*Commit) bool {
+	if p.signingExpected() {
+		return true
+	}
+	for _, c := range commits {
+		if p.commitSignature(c).Signed() {
+			return true
+		}
+	}
+	return false
+}
+
+// commitSignature returns the verified signature of c, or one that is not
+// Checked if it has not been loaded yet.
+func (p *Plugin) commitSignature(c *Commit) CommitSignature {
+	return p.signatures[c.Hash]
+}
+
+// SignaturesLoadedMsg carries the verified signatures of the commits in
+// Hashes.
+type SignaturesLoadedMsg struct {
+	Epoch      uint64
+	Hashes     []string
+	Signatures map[string]CommitSignature
+	Err        error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m SignaturesLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// loadVisibleSignatures verifies the signatures of the history rows on
+// screen and the previewed commit that have not been checked, so the
+// signing program runs only for commits the user can see.
+func (p *Plugin) loadVisibleSignatures() tea.Cmd {
+	if !p.hasRepo || p.ctx == nil {
+		return nil
+	}
+	var visible []*Commit
+	if commits := p.activeCommits(); len(commits) > 0 {
+		start := max(p.commitScrollOff, 0)
+		if end := min(start+p.visibleCommitCount(), len(commits)); start < end {
+			visible = append(visible, commits[start:end]...)
+		}
+	}
+	if p.previewCommit != nil {
+		visible = append(visible, p.previewCommit)
+	}
+
+	var hashes []string
+	for _, c := range visible {
+		if _, ok := p.signatures[c.Hash]; ok || p.signaturesPending[c.Hash] {
+			continue
+		}
+		if p.signaturesPending == nil {
+			p.signaturesPending = make(map[string]bool)
+		}
+		p.signaturesPending[c.Hash] = true
+		hashes = append(hashes, c.Hash)
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		sigs, err := GetCommitSignatures(workDir, hashes...)
+		return SignaturesLoadedMsg{Epoch: epoch, Hashes: hashes, Signatures: sigs, Err: err}
+	}
+}
+
+// handleSignaturesLoaded stores verified signatures. Hashes that failed stay
+// pending so they are not retried on every update.
+func (p *Plugin) handleSignaturesLoaded(msg SignaturesLoadedMsg) {
+	if plugin.IsStale(p.ctx, msg) || msg.Err != nil {
+		return
+	}
+	if p.signatures == nil {
+		p.signatures = make(map[string]CommitSignature)
+	}
+	for _, hash := range msg.Hashes {
+		if sig, ok := msg.Signatures[hash]; ok {
+			p.signatures[hash] = sig
+			delete(p.signaturesPending, hash)
+		}
+	}
+}
+
+// signatureMark returns the history row glyph for sig and its style. A
+// signature still being verified has a blank mark.
+func signatureMark(sig CommitSignature) (string, lipgloss.Style) {
+	switch {
+	case !sig.Checked():
+		return " ", styles.Muted
+	case sig.Good():
+		return "✓", styles.StatusStaged
+	case sig.Bad():
+		return "✗", styles.StatusDeleted
+	case sig.UnknownKey():
+		return "?", styles.StatusModified
+	}
+	return "·", styles.Muted
+}
+
+// signatureSummary describes sig for the commit preview, within width.
+func signatureSummary(sig CommitSignature, width int) string {
+	if !sig.Checked() {
+		return styles.Muted.Render(ui.TruncateString("Checking signature…", width))
+	}
+	mark, style := signatureMark(sig)
+	text := sig.Label()
+	if sig.Signer != "" {
+		text += " · " + sig.Signer
+	}
+	if sig.Key != "" {
+		text += " · " + sig.Key
+	}
+	return style.Render(mark+" ") + styles.Muted.Render(ui.TruncateString(text, width-2))
+}
@@ -0,0 +1,111 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/state"
+)
+
+func TestSignatures_LoadedForVisibleRows(t *testing.T) {
+	p, git := newRepoPlugin(t)
+	for i := 0; i < 40; i++ {
+		git("commit", "-q", "--allow-empty", "-m", fmt.Sprintf("c%d", i))
+	}
+
+	_, cmd := p.Update(p.loadRecentCommits()())
+	if cmd == nil {
+		t.Fatal("loading history should verify the rows on screen")
+	}
+	msgs := []tea.Msg{cmd()}
+	for len(msgs) > 0 {
+		msg := msgs[0]
+		msgs = msgs[1:]
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				if c != nil {
+					msgs = append(msgs, c())
+				}
+			}
+			continue
+		}
+		if loaded, ok := msg.(SignaturesLoadedMsg); ok {
+			p.Update(loaded)
+		}
+	}
+
+	if n := len(p.signatures); n == 0 || n > p.visibleCommitCount() {
+		t.Fatalf("verified %d commits, want those on screen (%d)", n, p.visibleCommitCount())
+	}
+	if first := p.commitSignature(p.recentCommits[0]); !first.Checked() || first.Signed() {
+		t.Errorf("first row signature = %+v, want checked and unsigned", first)
+	}
+	if last := p.recentCommits[len(p.recentCommits)-1]; p.commitSignature(last).Checked() {
+		t.Error("commits below the fold should not be verified")
+	}
+	if cmd := p.loadVisibleSignatures(); cmd != nil {
+		t.Error("checked commits should not be verified again")
+	}
+}
+
+func TestSignatures_MarkHistoryRows(t *testing.T) {
+	p := newHistoryOpsPlugin(t)
+	p.sidebarWidth = 60
+	if strings.Contains(p.renderSidebar(20), "· one") {
+		t.Error("rows should not carry marks when nothing is signed")
+	}
+
+	p.signatures = map[string]CommitSignature{
+		"ccc0000000": {Status: "G", Signer: "dev"},
+		"bbb0000000": {Status: "R"},
+		"aaa0000000": {Status: "N"},
+	}
+	sidebar := p.renderSidebar(20)
+	for _, want := range []string{"✓ three", "✗ two", "· one"} {
+		if !strings.Contains(sidebar, want) {
+			t.Errorf("sidebar missing %q", want)
+		}
+	}
+
+	if got := signatureSummary(p.commitSignature(p.recentCommits[0]), 60); !strings.Contains(got, "good · dev") {
+		t.Errorf("preview summary = %q", got)
+	}
+}
+
+func TestCommitModal_SignToggleAndFailure(t *testing.T) {
+	if err := state.InitWithDir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	p := newHistoryOpsPlugin(t)
+	p.ctx = &plugin.Context{ProjectRoot: "/project"}
+	p.viewMode = ViewModeCommit
+	p.initCommitTextarea()
+	p.commitMessage.SetValue("feat: signed")
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if !p.commitSign || !state.GetSignCommits("/project") {
+		t.Fatal("ctrl+g should turn signing on for the project")
+	}
+	if !strings.Contains(p.View(100, 30), "Sign commit") {
+		t.Error("modal should show the sign toggle")
+	}
+
+	p.commitInProgress = true
+	p.Update(CommitErrorMsg{Err: &SigningError{Format: "ssh", NoAgent: true, Output: "error: Couldn't get agent socket?\n"}})
+	if p.viewMode != ViewModeError || !strings.Contains(p.errorDetail, "ssh-agent") {
+		t.Fatalf("signing failure should open the error modal, detail = %q", p.errorDetail)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeCommit || p.commitMessage.Value() != "feat: signed" {
+		t.Error("dismissing should return to the commit modal with the message")
+	}
+
+	p.signing.Enabled = true
+	p.commitModal = nil
+	if strings.Contains(p.View(100, 30), "Sign commit") {
+		t.Error("configured signing replaces the toggle")
+	}
+}
@@ -19,4 +19,11 @@ var (
 	StashPopRef               = git.StashPopRef
 	StashApply                = git.StashApply
 	StashDrop                 = git.StashDrop
+	GetStashDiff              = git.GetStashDiff
+	StashCheckoutFile         = git.StashCheckoutFile
+	StashBranch               = git.StashBranch
+	StashRename               = git.StashRename
+	StashPushPaths            = git.StashPushPaths
+	StashPushPatch            = git.StashPushPatch
+	StashHunk                 = git.StashHunk
 )
@@ -0,0 +1,824 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/guyghost/sidecar/internal/modal"
+	"github.com/guyghost/sidecar/internal/mouse"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/state"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+// Stash operations reported by StashOpDoneMsg.
+const (
+	stashOpApply    = "apply"
+	stashOpPop      = "pop"
+	stashOpDrop     = "drop"
+	stashOpRename   = "rename"
+	stashOpBranch   = "branch"
+	stashOpCheckout = "checkout"
+	stashOpPush     = "push"
+)
+
+// stashOpTitles maps a stash operation to the error modal title for its
+// failure.
+var stashOpTitles = map[string]string{
+	stashOpApply:    "Apply Stash Failed",
+	stashOpPop:      "Pop Stash Failed",
+	stashOpDrop:     "Drop Stash Failed",
+	stashOpCheckout: "Check Out File Failed",
+}
+
+const (
+	stashPushMsgID    = "stash-push-message"
+	stashPushFilesID  = "stash-push-files"
+	stashPushActionID = "stash-push"
+	stashPushMaxFiles = 10 // File rows shown in the stash modal before scrolling
+)
+
+// StashesLoadedMsg is sent when the stash browser's list is read.
+type StashesLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Stashes []*Stash
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m StashesLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// StashDiffLoadedMsg is sent when the diff of a stash is read.
+type StashDiffLoadedMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Ref   string
+	Diff  string
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m StashDiffLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// StashOpDoneMsg is sent when an operation on a stash entry returns.
+type StashOpDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Op    string // One of the stashOp constants
+	Ref   string // Stash operated on; empty for a push
+	Name  string // Branch, message or file the operation used
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m StashOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// stashPushFile is a changed file offered by the stash modal.
+type stashPushFile struct {
+	Path      string
+	Status    FileStatus
+	Untracked bool
+	Checked   bool
+}
+
+// openStashes opens the stash browser.
+func (p *Plugin) openStashes() tea.Cmd {
+	p.viewMode = ViewModeStashes
+	p.stashesLoaded = false
+	p.stashCursor = 0
+	p.stashDiff = nil
+	p.stashScroll = 0
+	p.stashErr = ""
+	p.stashConfirm = ""
+	p.stashInput = ""
+	return p.loadStashes()
+}
+
+// loadStashes lists the stash entries.
+func (p *Plugin) loadStashes() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		list, err := GetStashList(workDir)
+		var stashes []*Stash
+		if list != nil {
+			stashes = list.Stashes
+		}
+		return StashesLoadedMsg{Epoch: epoch, Stashes: stashes, Err: err}
+	}
+}
+
+// handleStashesLoaded fills the stash browser and previews the selected entry.
+func (p *Plugin) handleStashesLoaded(msg StashesLoadedMsg) tea.Cmd {
+	if p.viewMode != ViewModeStashes && p.viewMode != ViewModeStashPush {
+		return nil
+	}
+	p.stashesLoaded = true
+	p.stashes = msg.Stashes
+	p.stashErr = ""
+	if msg.Err != nil {
+		p.stashErr = msg.Err.Error()
+	}
+	if p.stashCursor >= len(p.stashes) {
+		p.stashCursor = len(p.stashes) - 1
+	}
+	if p.stashCursor < 0 {
+		p.stashCursor = 0
+	}
+	p.stashDiff = nil
+	p.stashScroll = 0
+	return p.loadStashDiff()
+}
+
+// selectedStash returns the stash under the cursor, or nil.
+func (p *Plugin) selectedStash() *Stash {
+	if p.stashCursor >= 0 && p.stashCursor < len(p.stashes) {
+		return p.stashes[p.stashCursor]
+	}
+	return nil
+}
+
+// loadStashDiff reads the diff of the selected stash.
+func (p *Plugin) loadStashDiff() tea.Cmd {
+	s := p.selectedStash()
+	if s == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	ref := s.Ref
+	return func() tea.Msg {
+		diff, err := GetStashDiff(workDir, ref)
+		return StashDiffLoadedMsg{Epoch: epoch, Ref: ref, Diff: diff, Err: err}
+	}
+}
+
+// handleStashDiffLoaded shows the diff if it is still for the selected stash.
+func (p *Plugin) handleStashDiffLoaded(msg StashDiffLoadedMsg) {
+	s := p.selectedStash()
+	if s == nil || s.Ref != msg.Ref {
+		return
+	}
+	if msg.Err != nil {
+		p.stashErr = msg.Err.Error()
+		return
+	}
+	p.stashDiff = ParseMultiFileDiff(msg.Diff)
+	p.stashScroll = 0
+}
+
+// moveStashCursor selects the stash at idx and loads its diff.
+func (p *Plugin) moveStashCursor(idx int) tea.Cmd {
+	if idx >= len(p.stashes) {
+		idx = len(p.stashes) - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	if idx == p.stashCursor {
+		return nil
+	}
+	p.stashCursor = idx
+	p.stashDiff = nil
+	p.stashScroll = 0
+	p.stashConfirm = ""
+	return p.loadStashDiff()
+}
+
+// closeStashes closes the stash browser.
+func (p *Plugin) closeStashes() {
+	p.viewMode = ViewModeStatus
+	p.stashes = nil
+	p.stashDiff = nil
+	p.stashConfirm = ""
+	p.stashInput = ""
+}
+
+// stashListRows returns the number of rows given to the stash list.
+func (p *Plugin) stashListRows() int {
+	rows := max(3, (p.height-2)/4)
+	if len(p.stashes) < rows {
+		rows = max(1, len(p.stashes))
+	}
+	return rows
+}
+
+// stashDiffHeight returns the rows available to the stash diff.
+func (p *Plugin) stashDiffHeight() int {
+	// Breadcrumb, separator, summary and footer around the list and diff
+	return max(1, p.height-2-4-p.stashListRows())
+}
+
+// scrollStashDiff scrolls the stash diff by delta rows.
+func (p *Plugin) scrollStashDiff(delta int) {
+	p.stashScroll += delta
+	if maxScroll := p.stashDiff.TotalLines() - p.stashDiffHeight(); p.stashScroll > maxScroll {
+		p.stashScroll = maxScroll
+	}
+	if p.stashScroll < 0 {
+		p.stashScroll = 0
+	}
+}
+
+// stashFile returns the path of the file shown at the top of the stash
+// diff, or "" when there is none.
+func (p *Plugin) stashFile() string {
+	if p.stashDiff.FileCount() == 0 {
+		return ""
+	}
+	return p.stashDiff.Files[currentDiffFile(p.stashDiff, p.stashScroll)].FileName()
+}
+
+// updateStashes handles key events in the stash browser.
+func (p *Plugin) updateStashes(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.stashInput != "" {
+		return p.updateStashInput(msg)
+	}
+	key := msg.String()
+
+	// A pending pop, drop or checkout takes y to confirm; any other key cancels it
+	if p.stashConfirm != "" {
+		op := p.stashConfirm
+		p.stashConfirm = ""
+		if key == "y" {
+			return p, p.doStashOp(op)
+		}
+		return p, nil
+	}
+
+	s := p.selectedStash()
+	switch key {
+	case "esc", "q":
+		p.closeStashes()
+	case "j", "down":
+		return p, p.moveStashCursor(p.stashCursor + 1)
+	case "k", "up":
+		return p, p.moveStashCursor(p.stashCursor - 1)
+	case "g":
+		return p, p.moveStashCursor(0)
+	case "G":
+		return p, p.moveStashCursor(len(p.stashes) - 1)
+	case "J":
+		p.scrollStashDiff(1)
+	case "K":
+		p.scrollStashDiff(-1)
+	case "ctrl+d":
+		p.scrollStashDiff(p.stashDiffHeight() / 2)
+	case "ctrl+u":
+		p.scrollStashDiff(-p.stashDiffHeight() / 2)
+	case "]":
+		p.stashScroll = jumpDiffFile(p.stashDiff, p.stashScroll, 1)
+	case "[":
+		p.stashScroll = jumpDiffFile(p.stashDiff, p.stashScroll, -1)
+	case "v":
+		if p.diffViewMode == DiffViewUnified {
+			p.diffViewMode = DiffViewSideBySide
+			_ = state.SetGitDiffMode("side-by-side")
+		} else {
+			p.diffViewMode = DiffViewUnified
+			_ = state.SetGitDiffMode("unified")
+		}
+		p.scrollStashDiff(0)
+	case "a":
+		if s != nil {
+			return p, p.doStashOp(stashOpApply)
+		}
+	case "p":
+		if s != nil {
+			p.stashConfirm = stashOpPop
+		}
+	case "d":
+		if s != nil {
+			p.stashConfirm = stashOpDrop
+		}
+	case "o":
+		if s != nil && p.stashFile() != "" {
+			p.stashConfirm = stashOpCheckout
+		}
+	case "b", "r":
+		if s != nil {
+			return p, p.openStashInput(key)
+		}
+	case "n":
+		return p, p.openStashPush()
+	}
+	return p, nil
+}
+
+// openStashInput starts typing a branch name ("b") or a new message ("r")
+// for the selected stash.
+func (p *Plugin) openStashInput(key string) tea.Cmd {
+	p.stashInputText = textinput.New()
+	p.stashInputText.CharLimit = 200
+	if key == "b" {
+		p.stashInput = stashOpBranch
+		p.stashInputText.Placeholder = "branch-name"
+	} else {
+		p.stashInput = stashOpRename
+		p.stashInputText.SetValue(p.selectedStash().Message)
+	}
+	p.stashInputText.Focus()
+	p.stashErr = ""
+	return textinput.Blink
+}
+
+// updateStashInput handles key events while a branch name or stash
+// message is typed.
+func (p *Plugin) updateStashInput(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		p.stashInput = ""
+		p.stashErr = ""
+		return p, nil
+	case "enter":
+		if strings.TrimSpace(p.stashInputText.Value()) == "" {
+			p.stashErr = "Branch name is required"
+			if p.stashInput == stashOpRename {
+				p.stashErr = "Message is required"
+			}
+			return p, nil
+		}
+		return p, p.doStashOp(p.stashInput)
+	}
+	var cmd tea.Cmd
+	p.stashInputText, cmd = p.stashInputText.Update(msg)
+	return p, cmd
+}
+
+// handleStashesMouse handles mouse events in the stash browser.
+func (p *Plugin) handleStashesMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	action := p.mouseHandler.HandleMouse(msg)
+	switch action.Type {
+	case mouse.ActionClick:
+		if action.Region == nil {
+			return p, nil
+		}
+		switch action.Region.ID {
+		case regionStashesBack:
+			p.closeStashes()
+		case regionStashItem:
+			if idx, ok := action.Region.Data.(int); ok {
+				return p, p.moveStashCursor(idx)
+			}
+		}
+	case mouse.ActionScrollUp, mouse.ActionScrollDown:
+		p.scrollStashDiff(action.Delta)
+	}
+	return p, nil
+}
+
+// doStashOp runs op on the selected stash.
+func (p *Plugin) doStashOp(op string) tea.Cmd {
+	s := p.selectedStash()
+	if s == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	ref := s.Ref
+	name := strings.TrimSpace(p.stashInputText.Value())
+	if op == stashOpCheckout {
+		name = p.stashFile()
+	}
+	return func() tea.Msg {
+		var point *UndoPoint
+		var undoErr, err error
+		switch op {
+		case stashOpApply:
+			err = StashApply(workDir, ref)
+		case stashOpPop:
+			err = StashPopRef(workDir, ref)
+		case stashOpDrop:
+			point, undoErr = CaptureStashDropUndo(workDir, ref)
+			err = StashDrop(workDir, ref)
+		case stashOpRename:
+			err = StashRename(workDir, ref, name)
+		case stashOpBranch:
+			err = StashBranch(workDir, name, ref)
+		case stashOpCheckout:
+			// Save the working copy so the global undo can bring it back
+			point, undoErr = CaptureDiscardUndo(workDir, name)
+			err = StashCheckoutFile(workDir, ref, name)
+		}
+		result := StashOpDoneMsg{Epoch: epoch, Op: op, Ref: ref, Name: name, Err: err}
+		if err != nil {
+			return result
+		}
+		return recordUndo(workDir, point, undoErr, result)
+	}
+}
+
+// handleStashOpDone reports the outcome of a stash operation and reloads
+// the stash list and status. Branch and rename failures stay in the input
+// so the name can be fixed.
+func (p *Plugin) handleStashOpDone(msg StashOpDoneMsg) tea.Cmd {
+	if msg.Err != nil {
+		switch {
+		case msg.Op == stashOpPush && p.viewMode == ViewModeStashPush:
+			p.stashPushErr = msg.Err.Error()
+			return nil
+		case msg.Op == stashOpBranch || msg.Op == stashOpRename:
+			p.stashErr = msg.Err.Error()
+			return nil
+		}
+		title := stashOpTitles[msg.Op]
+		if title == "" {
+			title = "Stash Failed"
+		}
+		p.showErrorModal(title, msg.Err)
+		return tea.Batch(p.refresh(), p.loadRecentCommits())
+	}
+
+	p.stashInput = ""
+	p.stashErr = ""
+	var toast string
+	switch msg.Op {
+	case stashOpApply:
+		toast = "Applied " + msg.Ref
+	case stashOpPop:
+		toast = "Popped " + msg.Ref
+	case stashOpDrop:
+		toast = "Dropped " + msg.Ref + " · ctrl+y to undo"
+	case stashOpRename:
+		toast = "Renamed stash to " + msg.Name
+		p.stashCursor = 0
+	case stashOpBranch:
+		toast = "Created " + msg.Name + " from " + msg.Ref
+	case stashOpCheckout:
+		toast = "Checked out " + msg.Name + " from " + msg.Ref
+	case stashOpPush:
+		toast = "Stashed " + msg.Name
+		p.closeStashPush()
+		p.stashCursor = 0
+	}
+	cmds := []tea.Cmd{appmsg.ShowToast(toast, 2*time.Second), p.refresh(), p.loadRecentCommits()}
+	if p.viewMode == ViewModeStashes {
+		cmds = append(cmds, p.loadStashes())
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderStashes renders the full-screen stash browser: the stash list above
+// the diff of the selected entry.
+func (p *Plugin) renderStashes() string {
+	paneHeight := p.height - 2
+	contentWidth := max(20, p.width-4)
+	p.mouseHandler.Clear()
+	p.mouseHandler.HitMap.AddRect(regionStashes, 0, 0, p.width, p.height, nil)
+
+	var sb strings.Builder
+	back := styles.Link.Render("← Back")
+	p.mouseHandler.HitMap.AddRect(regionStashesBack, 2, 1, lipgloss.Width(back), 1, nil)
+	viewModeStr := "unified"
+	if p.diffViewMode == DiffViewSideBySide {
+		viewModeStr = "side-by-side"
+	}
+	sb.WriteString(back + styles.Muted.Render(" · ") + styles.Title.Render(fmt.Sprintf("Stashes (%d)", len(p.stashes))) + " " + styles.Muted.Render("["+viewModeStr+"]"))
+	sb.WriteString("\n")
+
+	listRows := p.stashListRows()
+	switch {
+	case !p.stashesLoaded:
+		sb.WriteString(styles.Muted.Render("Loading stashes..."))
+	case len(p.stashes) == 0:
+		sb.WriteString(styles.Muted.Render("No stashes. Press n to stash some changes."))
+	default:
+		start := 0
+		if p.stashCursor >= listRows {
+			start = p.stashCursor - listRows + 1
+		}
+		end := min(start+listRows, len(p.stashes))
+		for i := start; i < end; i++ {
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(renderStashLine(p.stashes[i], contentWidth, i == p.stashCursor))
+			p.mouseHandler.HitMap.AddRect(regionStashItem, 2, 2+i-start, contentWidth, 1, i)
+		}
+	}
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render(strings.Repeat("━", contentWidth)))
+	sb.WriteString("\n")
+
+	height := p.stashDiffHeight()
+	s := p.selectedStash()
+	switch {
+	case s == nil:
+		sb.WriteString(strings.Repeat("\n", height))
+	case p.stashDiff == nil:
+		sb.WriteString(styles.Muted.Render("Loading diff...") + strings.Repeat("\n", height))
+	default:
+		sb.WriteString(p.renderStashSummary(s, contentWidth))
+		sb.WriteString("\n")
+		diff := RenderMultiFileDiff(p.stashDiff, p.diffViewMode, contentWidth, p.stashScroll, height, 0, false)
+		lines := strings.Split(diff, "\n")
+		for i, line := range lines {
+			if lipgloss.Width(line) > contentWidth {
+				lines[i] = truncateStyledLine(line, contentWidth-3) + "..."
+			}
+		}
+		for len(lines) < height {
+			lines = append(lines, "")
+		}
+		sb.WriteString(strings.Join(lines[:height], "\n"))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(p.renderStashFooter(contentWidth))
+	return p.wrapDiffContent(sb.String(), paneHeight)
+}
+
+// renderStashLine renders one stash: ref, age, branch and message.
+func renderStashLine(s *Stash, width int, selected bool) string {
+	date := ""
+	if !s.Date.IsZero() {
+		date = RelativeTime(s.Date)
+	}
+	branch := ui.TruncateString(s.Branch, 20)
+	prefix := fmt.Sprintf("%-10s  %-8s  ", s.Ref, ui.TruncateString(date, 8))
+	message := ui.TruncateString(s.Message, max(0, width-ansi.StringWidth(prefix)-ansi.StringWidth(branch)-2))
+
+	if selected {
+		line := prefix + branch + "  " + message
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	return styles.Code.Render(fmt.Sprintf("%-10s", s.Ref)) + "  " + styles.Muted.Render(fmt.Sprintf("%-8s", ui.TruncateString(date, 8))) + "  " +
+		styles.StatusModified.Render(branch) + "  " + styles.Body.Render(message)
+}
+
+// renderStashSummary describes the selected stash's diff and the file at
+// the top of the view.
+func (p *Plugin) renderStashSummary(s *Stash, width int) string {
+	adds, dels := 0, 0
+	for _, f := range p.stashDiff.Files {
+		adds += f.Additions
+		dels += f.Deletions
+	}
+	summary := fmt.Sprintf("%s · %d file(s) ", s.Ref, p.stashDiff.FileCount())
+	if file := p.stashFile(); file != "" {
+		summary = fmt.Sprintf("%s · file %d/%d %s ", s.Ref, currentDiffFile(p.stashDiff, p.stashScroll)+1, p.stashDiff.FileCount(), file)
+	}
+	stat := styles.DiffAdd.Render(fmt.Sprintf("+%d", adds)) + " " + styles.DiffRemove.Render(fmt.Sprintf("-%d", dels))
+	return styles.Muted.Render(ui.TruncateString(summary, width-lipgloss.Width(stat)-1)) + stat
+}
+
+// renderStashFooter shows the name input, pending confirmations, errors
+// or key hints.
+func (p *Plugin) renderStashFooter(width int) string {
+	s := p.selectedStash()
+	switch {
+	case p.stashInput != "":
+		label := "New branch: "
+		if p.stashInput == stashOpRename {
+			label = "Message: "
+		}
+		p.stashInputText.Width = max(10, width/2)
+		line := label + p.stashInputText.View()
+		if p.stashErr != "" {
+			return line + "  " + styles.StatusDeleted.Render(ui.TruncateString(strings.TrimSpace(p.stashErr), width/2-4))
+		}
+		return line + "  " + styles.Muted.Render("enter save · esc cancel")
+	case p.stashConfirm != "" && s != nil:
+		prompt := map[string]string{
+			stashOpPop:      "Pop " + s.Ref + " onto the working tree?",
+			stashOpDrop:     "Drop " + s.Ref + "?",
+			stashOpCheckout: "Check out " + p.stashFile() + " from " + s.Ref + " over your working copy?",
+		}[p.stashConfirm]
+		return styles.StatusDeleted.Render(ui.TruncateString(prompt+" y to confirm, any key to cancel", width))
+	case p.stashErr != "":
+		return styles.StatusDeleted.Render(ui.TruncateString(strings.TrimSpace(p.stashErr), width))
+	}
+	return styles.Muted.Render(ui.TruncateString("a apply · p pop · d drop · o check out file · b branch · r rename · n new · ]/[ file · esc close", width))
+}
+
+// openStashPush opens the stash modal with every changed file ticked.
+func (p *Plugin) openStashPush() tea.Cmd {
+	p.stashPushFiles = nil
+	for _, group := range [][]*FileEntry{p.tree.Staged, p.tree.Modified, p.tree.Untracked} {
+		for _, e := range group {
+			if e.Status == StatusUnmerged || p.hasStashPushFile(e.Path) {
+				continue
+			}
+			p.stashPushFiles = append(p.stashPushFiles, &stashPushFile{
+				Path:      e.Path,
+				Status:    e.Status,
+				Untracked: e.Status == StatusUntracked,
+				Checked:   true,
+			})
+		}
+	}
+	if len(p.stashPushFiles) == 0 {
+		return appmsg.ShowToast("No changes to stash", 2*time.Second)
+	}
+	p.stashPushMsg = textinput.New()
+	p.stashPushMsg.Placeholder = "optional message"
+	p.stashPushMsg.CharLimit = 200
+	p.stashPushMsg.Focus()
+	p.stashPushCursor = 0
+	p.stashPushErr = ""
+	p.stashPushModal = nil
+	p.viewMode = ViewModeStashPush
+	return textinput.Blink
+}
+
+// hasStashPushFile reports whether path is already offered by the modal.
+func (p *Plugin) hasStashPushFile(path string) bool {
+	for _, f := range p.stashPushFiles {
+		if f.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// closeStashPush returns from the stash modal to the stash browser.
+func (p *Plugin) closeStashPush() {
+	p.viewMode = ViewModeStashes
+	p.stashPushModal = nil
+	p.stashPushWidth = 0
+	p.stashPushErr = ""
+}
+
+// ensureStashPushModal builds/rebuilds the stash modal.
+func (p *Plugin) ensureStashPushModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.stashPushModal != nil && p.stashPushWidth == modalW {
+		return
+	}
+	p.stashPushWidth = modalW
+
+	p.stashPushModal = modal.New("Stash Changes",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(stashPushActionID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.InputWithLabel(stashPushMsgID, "Message", &p.stashPushMsg)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Custom(p.renderStashPushFiles, p.updateStashPushFiles)).
+		AddSection(modal.When(func() bool { return p.stashPushErr != "" }, modal.Custom(
+			func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+				return modal.RenderedSection{Content: styles.StatusDeleted.Render(strings.TrimSpace(p.stashPushErr))}
+			}, nil))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Stash ", stashPushActionID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// renderStashPushFiles lists the changed files with their checkboxes.
+func (p *Plugin) renderStashPushFiles(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+	focused := focusID == stashPushFilesID
+	start := 0
+	if p.stashPushCursor >= stashPushMaxFiles {
+		start = p.stashPushCursor - stashPushMaxFiles + 1
+	}
+	end := min(start+stashPushMaxFiles, len(p.stashPushFiles))
+
+	checked := 0
+	for _, f := range p.stashPushFiles {
+		if f.Checked {
+			checked++
+		}
+	}
+	lines := []string{styles.Body.Render(fmt.Sprintf("Files (%d of %d)", checked, len(p.stashPushFiles)))}
+	for i := start; i < end; i++ {
+		f := p.stashPushFiles[i]
+		box := "[ ]"
+		if f.Checked {
+			box = "[x]"
+		}
+		line := box + " " + string(f.Status) + " " + ui.TruncateString(f.Path, contentWidth-7)
+		if focused && i == p.stashPushCursor {
+			line += strings.Repeat(" ", max(0, contentWidth-ansi.StringWidth(line)))
+			lines = append(lines, styles.ListItemSelected.Render(line))
+			continue
+		}
+		lines = append(lines, styles.Body.Render(line))
+	}
+	hint := "tab to pick files"
+	if focused {
+		hint = "space toggle · a all/none · enter stash"
+	}
+	lines = append(lines, styles.Muted.Render(hint))
+	return modal.RenderedSection{
+		Content: strings.Join(lines, "\n"),
+		Focusables: []modal.FocusableInfo{{
+			ID:      stashPushFilesID,
+			OffsetY: 1,
+			Width:   contentWidth,
+			Height:  end - start,
+		}},
+	}
+}
+
+// updateStashPushFiles moves the file cursor and toggles files while the
+// list is focused.
+func (p *Plugin) updateStashPushFiles(msg tea.Msg, focusID string) (string, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || focusID != stashPushFilesID {
+		return "", nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if p.stashPushCursor < len(p.stashPushFiles)-1 {
+			p.stashPushCursor++
+		}
+	case "k", "up":
+		if p.stashPushCursor > 0 {
+			p.stashPushCursor--
+		}
+	case " ", "space":
+		if p.stashPushCursor < len(p.stashPushFiles) {
+			f := p.stashPushFiles[p.stashPushCursor]
+			f.Checked = !f.Checked
+		}
+	case "a":
+		all := true
+		for _, f := range p.stashPushFiles {
+			all = all && f.Checked
+		}
+		for _, f := range p.stashPushFiles {
+			f.Checked = !all
+		}
+	}
+	return "", nil
+}
+
+// updateStashPush handles key events in the stash modal.
+func (p *Plugin) updateStashPush(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureStashPushModal()
+	action, cmd := p.stashPushModal.HandleKey(msg)
+	switch action {
+	case stashPushActionID:
+		return p, p.doStashPushFiles()
+	case "cancel":
+		p.closeStashPush()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handleStashPushMouse handles mouse events in the stash modal.
+func (p *Plugin) handleStashPushMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.stashPushModal == nil {
+		return p, nil
+	}
+	switch p.stashPushModal.HandleMouse(msg, p.mouseHandler) {
+	case stashPushActionID:
+		return p, p.doStashPushFiles()
+	case "cancel":
+		p.closeStashPush()
+	}
+	return p, nil
+}
+
+// doStashPushFiles stashes the ticked files with the typed message.
+func (p *Plugin) doStashPushFiles() tea.Cmd {
+	var paths []string
+	untracked := false
+	for _, f := range p.stashPushFiles {
+		if f.Checked {
+			paths = append(paths, f.Path)
+			untracked = untracked || f.Untracked
+		}
+	}
+	if len(paths) == 0 {
+		p.stashPushErr = "Tick at least one file"
+		return nil
+	}
+	p.stashPushErr = ""
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	message := strings.TrimSpace(p.stashPushMsg.Value())
+	name := fmt.Sprintf("%d file(s)", len(paths))
+	if len(paths) == 1 {
+		name = paths[0]
+	}
+	return func() tea.Msg {
+		err := StashPushPaths(workDir, message, untracked, paths)
+		return StashOpDoneMsg{Epoch: epoch, Op: stashOpPush, Name: name, Err: err}
+	}
+}
+
+// renderStashPush renders the stash modal over the stash browser.
+func (p *Plugin) renderStashPush() string {
+	background := p.renderStashes()
+	p.ensureStashPushModal()
+	modalContent := p.stashPushModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
@@ -0,0 +1,199 @@
+package gitstatus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+// newStashPlugin returns a plugin on newRepoPlugin's repo with, when
+// withStashes is set, two stashes: "first" changing a.txt with an
+// untracked new.txt, and "second" changing b.txt.
+func newStashPlugin(t *testing.T, withStashes bool) (*Plugin, func(args ...string) string) {
+	t.Helper()
+	p, git := newRepoPlugin(t)
+	if withStashes {
+		dir := p.repoRoot
+		a, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, dir, "a.txt", strings.Replace(string(a), "line 1\n", "stashed a\n", 1))
+		writeTestFile(t, dir, "new.txt", "fresh\n")
+		git("stash", "push", "-q", "--include-untracked", "-m", "first")
+		writeTestFile(t, dir, "b.txt", "stashed b\n")
+		git("stash", "push", "-q", "-m", "second")
+		p.Update(p.refresh()())
+	}
+	return p, git
+}
+
+// openStashBrowser presses w and loads the list and the first stash's diff.
+func openStashBrowser(t *testing.T, p *Plugin) {
+	t.Helper()
+	_, cmd := p.Update(runeKey("w"))
+	if p.viewMode != ViewModeStashes || p.FocusContext() != keymap.ContextGitStashes || cmd == nil {
+		t.Fatal("w should open the stash browser")
+	}
+	// An empty list has no diff to load
+	if _, cmd = p.Update(cmd()); cmd != nil {
+		p.Update(cmd())
+	}
+}
+
+func TestStashes_BrowseCheckoutAndDrop(t *testing.T) {
+	p, _ := newStashPlugin(t, true)
+	openStashBrowser(t, p)
+	if len(p.stashes) != 2 || p.stashDiff.FileCount() != 1 {
+		t.Fatalf("stashes = %d, diff files = %d", len(p.stashes), p.stashDiff.FileCount())
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "second") || !strings.Contains(view, "stashed b") {
+		t.Errorf("browser should list stashes and preview the newest:\n%s", view)
+	}
+
+	// The older stash shows its untracked file next to the tracked change
+	_, cmd := p.Update(runeKey("j"))
+	p.Update(cmd())
+	if view := p.View(120, 30); !strings.Contains(view, "new.txt") || !strings.Contains(view, "stashed a") {
+		t.Errorf("preview should include untracked files:\n%s", view)
+	}
+
+	p.Update(runeKey("o"))
+	if p.stashConfirm != stashOpCheckout {
+		t.Fatal("o should ask to confirm the checkout")
+	}
+	_, cmd = p.Update(runeKey("y"))
+	runBatch(p, func() tea.Msg { return tea.BatchMsg{cmd} })
+	data, _ := os.ReadFile(filepath.Join(p.repoRoot, "a.txt"))
+	if !strings.HasPrefix(string(data), "stashed a\n") {
+		t.Errorf("a.txt = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(p.repoRoot, "new.txt")); !os.IsNotExist(err) {
+		t.Error("checkout should only restore the file at the top of the diff")
+	}
+
+	p.Update(runeKey("d"))
+	if _, cmd := p.Update(runeKey("x")); cmd != nil || p.stashConfirm != "" {
+		t.Fatal("any key other than y should cancel the drop")
+	}
+	p.Update(runeKey("d"))
+	_, cmd = p.Update(runeKey("y"))
+	runBatch(p, cmd)
+	runBatch(p, p.loadStashes())
+	if len(p.stashes) != 1 || p.stashes[0].Message != "second" || p.viewMode != ViewModeStashes {
+		t.Fatalf("drop should leave the browser with one stash, got %+v", p.stashes)
+	}
+}
+
+func TestStashes_RenameAndBranch(t *testing.T) {
+	p, git := newStashPlugin(t, true)
+	openStashBrowser(t, p)
+
+	p.Update(runeKey("r"))
+	if p.FocusContext() != keymap.ContextGitStashInput || !p.ConsumesTextInput() || p.stashInputText.Value() != "second" {
+		t.Fatal("r should edit the stash message")
+	}
+	p.stashInputText.SetValue("")
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || p.stashErr == "" {
+		t.Fatal("an empty message should be refused")
+	}
+	p.stashInputText.SetValue("renamed")
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runBatch(p, cmd)
+	runBatch(p, p.loadStashes())
+	if p.stashInput != "" || len(p.stashes) != 2 || p.stashes[0].Message != "renamed" {
+		t.Fatalf("stashes = %+v", p.stashes)
+	}
+
+	p.Update(runeKey("b"))
+	p.stashInputText.SetValue("rescued")
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runBatch(p, cmd)
+	if got := strings.TrimSpace(git("branch", "--show-current")); got != "rescued" {
+		t.Errorf("branch = %q", got)
+	}
+	if got := git("stash", "list"); strings.Count(got, "\n") != 1 {
+		t.Errorf("branch should drop the stash:\n%s", got)
+	}
+}
+
+func TestStashes_PushSelectedFiles(t *testing.T) {
+	p, git := newStashPlugin(t, false)
+	if err := os.WriteFile(filepath.Join(p.repoRoot, "a.txt"), []byte("changed a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(p.repoRoot, "b.txt"), []byte("changed b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p.Update(p.refresh()())
+	openStashBrowser(t, p)
+
+	p.Update(runeKey("n"))
+	if p.viewMode != ViewModeStashPush || p.FocusContext() != keymap.ContextGitStashPush || len(p.stashPushFiles) != 2 {
+		t.Fatalf("n should open the stash modal with both files, got %d", len(p.stashPushFiles))
+	}
+	typeText(p, "keep b")
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.View(120, 30)
+	p.Update(runeKey(" "))
+	if p.stashPushFiles[0].Path != "a.txt" || p.stashPushFiles[0].Checked {
+		t.Fatal("space should untick the file under the cursor")
+	}
+	if view := p.View(120, 30); !strings.Contains(view, "Files (1 of 2)") {
+		t.Errorf("modal should count ticked files:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p.View(120, 30)
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("the Stash button should push the stash")
+	}
+	runBatch(p, func() tea.Msg { return tea.BatchMsg{cmd} })
+	if p.viewMode != ViewModeStashes {
+		t.Error("a push should return to the browser")
+	}
+	if got := git("status", "--porcelain"); got != " M a.txt\n" {
+		t.Errorf("status = %q", got)
+	}
+	if got := git("stash", "list"); !strings.Contains(got, "keep b") {
+		t.Errorf("stash list = %q", got)
+	}
+}
+
+func TestStashes_StashHunkFromDiff(t *testing.T) {
+	p, git := newStashPlugin(t, false)
+	data, _ := os.ReadFile(filepath.Join(p.repoRoot, "a.txt"))
+	content := strings.Replace(string(data), "line 2\n", "line 2 first\n", 1)
+	content = strings.Replace(content, "line 18\n", "line 18 second\n", 1)
+	if err := os.WriteFile(filepath.Join(p.repoRoot, "a.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	raw := git("diff", "a.txt")
+	parsed, err := ParseUnifiedDiff(raw)
+	if err != nil || len(parsed.Hunks) != 2 {
+		t.Fatalf("hunks: %v", err)
+	}
+	p.viewMode = ViewModeDiff
+	p.diffFile, p.diffRaw, p.diffContent = "a.txt", raw, raw
+	p.diffLoaded, p.diffHunksEnabled = true, true
+	p.parsedDiff = parsed
+	p.diffHunkCursor = 1
+
+	_, cmd := p.Update(runeKey("z"))
+	if cmd == nil {
+		t.Fatal("z should stash the hunk")
+	}
+	p.Update(cmd())
+	data, _ = os.ReadFile(filepath.Join(p.repoRoot, "a.txt"))
+	if !strings.Contains(string(data), "line 2 first") || strings.Contains(string(data), "line 18 second") {
+		t.Errorf("only the second hunk should leave the file:\n%s", data)
+	}
+	if got := git("stash", "show", "-p", "stash@{0}"); !strings.Contains(got, "+line 18 second") || strings.Contains(got, "first") {
+		t.Errorf("stash should hold the hunk alone:\n%s", got)
+	}
+}
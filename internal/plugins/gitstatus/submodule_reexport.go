@@ -0,0 +1,20 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export submodule types from internal/git.
+type (
+	Submodule       = git.Submodule
+	SubmoduleCommit = git.SubmoduleCommit
+	SubmoduleLog    = git.SubmoduleLog
+	SubmoduleError  = git.SubmoduleError
+)
+
+// Re-export submodule functions.
+var (
+	GetSubmodules   = git.GetSubmodules
+	GetSubmodule    = git.GetSubmodule
+	GetSubmoduleLog = git.GetSubmoduleLog
+	InitSubmodule   = git.InitSubmodule
+	UpdateSubmodule = git.UpdateSubmodule
+)
@@ -0,0 +1,584 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const submoduleItemPrefix = "submodule-item-" // List item ID prefix, followed by submodule index
+
+// submoduleLogLimit caps each side of the log shown for a moved submodule.
+const submoduleLogLimit = 50
+
+// submoduleEpochStep separates the epochs of repositories entered from the
+// same root, so results still in flight for the repository being left are
+// dropped like after a project switch.
+const submoduleEpochStep = 1 << 32
+
+// Submodule operations reported by SubmoduleOpDoneMsg.
+const (
+	submoduleOpInit   = "init"
+	submoduleOpUpdate = "update"
+	submoduleOpStage  = "stage"
+)
+
+// submoduleOpTitles maps a submodule operation to the error modal title for
+// its failure.
+var submoduleOpTitles = map[string]string{
+	submoduleOpInit:   "Init Submodule Failed",
+	submoduleOpUpdate: "Update Submodule Failed",
+	submoduleOpStage:  "Stage Submodule Failed",
+}
+
+// SubmoduleSummary describes a submodule in place of its diff.
+type SubmoduleSummary struct {
+	Submodule *Submodule
+	Log       *SubmoduleLog // nil when the log could not be read
+	LogErr    string
+}
+
+// submoduleFrame is a repository the view entered a submodule from.
+type submoduleFrame struct {
+	ctx  *plugin.Context
+	path string // Submodule path within that repository
+}
+
+// SubmoduleSummaryLoadedMsg is sent when the selected submodule's state is
+// read.
+type SubmoduleSummaryLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Path    string
+	Summary *SubmoduleSummary
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m SubmoduleSummaryLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// SubmodulesLoadedMsg is sent when the submodules panel's list is read.
+type SubmodulesLoadedMsg struct {
+	Epoch      uint64 // Epoch when request was issued (for stale detection)
+	Submodules []*Submodule
+	Err        error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m SubmodulesLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// SubmoduleOpDoneMsg is sent when a submodule init, update or stage returns.
+type SubmoduleOpDoneMsg struct {
+	Epoch uint64 // Epoch when request was issued (for stale detection)
+	Op    string // submoduleOpInit, submoduleOpUpdate or submoduleOpStage
+	Path  string
+	Err   error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m SubmoduleOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// loadSubmoduleSummary reads the state of the submodule at path and the
+// commits between its recorded and checked-out commits.
+func (p *Plugin) loadSubmoduleSummary(path string) tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		sub, err := GetSubmodule(workDir, path)
+		if err != nil {
+			return SubmoduleSummaryLoadedMsg{Epoch: epoch, Path: path, Err: err}
+		}
+		summary := &SubmoduleSummary{Submodule: sub}
+		if summary.Log, err = GetSubmoduleLog(workDir, sub, submoduleLogLimit); err != nil {
+			summary.LogErr = err.Error()
+		}
+		return SubmoduleSummaryLoadedMsg{Epoch: epoch, Path: path, Summary: summary}
+	}
+}
+
+// handleSubmoduleSummary stores the summary if its submodule is still
+// selected.
+func (p *Plugin) handleSubmoduleSummary(msg SubmoduleSummaryLoadedMsg) {
+	if msg.Path != p.selectedDiffFile {
+		return
+	}
+	if msg.Err != nil {
+		p.submoduleSummary = &SubmoduleSummary{Submodule: &Submodule{Path: msg.Path}, LogErr: msg.Err.Error()}
+		return
+	}
+	p.submoduleSummary = msg.Summary
+}
+
+// selectedSubmoduleEntry returns the file entry under the cursor if it is a
+// submodule.
+func (p *Plugin) selectedSubmoduleEntry() *FileEntry {
+	entries := p.tree.AllEntries()
+	if p.cursor < 0 || p.cursor >= len(entries) || entries[p.cursor].Submodule == nil {
+		return nil
+	}
+	return entries[p.cursor]
+}
+
+// submoduleSummaryLines renders the summary of the submodule selected in the
+// file list: where its pointer and checkout are, whether it is dirty, and
+// the commits between them.
+func (p *Plugin) submoduleSummaryLines(width int) []string {
+	s := p.submoduleSummary
+	if s == nil || s.Submodule.Path != p.selectedDiffFile {
+		return []string{styles.Muted.Render("Loading submodule...")}
+	}
+	sub := s.Submodule
+	if sub.Recorded == "" && s.LogErr != "" {
+		return []string{styles.StatusDeleted.Render(s.LogErr)}
+	}
+
+	label := func(name string) string { return styles.Muted.Render(fmt.Sprintf("%-10s", name)) }
+	var lines []string
+	lines = append(lines, label("Recorded")+styles.Code.Render(shortSubmoduleHash(sub.Recorded))+styles.Muted.Render("  index"))
+	if sub.PointerStaged() && sub.Committed != "" {
+		lines = append(lines, label("Committed")+styles.Code.Render(shortSubmoduleHash(sub.Committed))+styles.Muted.Render("  HEAD"))
+	}
+	if sub.Initialized {
+		checkout := styles.Code.Render(shortSubmoduleHash(sub.Checkout))
+		if sub.PointerChanged() {
+			checkout += "  " + styles.StatusModified.Render("moved")
+		}
+		lines = append(lines, label("Checkout")+checkout)
+	} else {
+		lines = append(lines, label("Checkout")+styles.Muted.Render("not initialized"))
+	}
+	lines = append(lines, label("State")+submoduleStateText(sub))
+
+	if s.Log != nil && (len(s.Log.Ahead) > 0 || len(s.Log.Behind) > 0) {
+		lines = append(lines, "", styles.Subtitle.Render(fmt.Sprintf("Checkout is %d ahead, %d behind the recorded commit", len(s.Log.Ahead), len(s.Log.Behind))))
+		for _, c := range s.Log.Ahead {
+			lines = append(lines, styles.DiffAdd.Render("+ ")+styles.Code.Render(c.ShortHash())+" "+ui.TruncateString(c.Subject, width-10))
+		}
+		for _, c := range s.Log.Behind {
+			lines = append(lines, styles.DiffRemove.Render("- ")+styles.Code.Render(c.ShortHash())+" "+ui.TruncateString(c.Subject, width-10))
+		}
+	} else if s.LogErr != "" {
+		lines = append(lines, "", styles.StatusDeleted.Render(ui.TruncateString(s.LogErr, width)))
+	}
+
+	hints := "enter open · M submodules"
+	if sub.PointerChanged() {
+		hints = "enter open · s stage pointer · M submodules"
+	}
+	lines = append(lines, "", styles.Muted.Render(hints))
+	return lines
+}
+
+// submoduleStateText describes a submodule's working tree.
+func submoduleStateText(sub *Submodule) string {
+	var parts []string
+	if sub.Conflicted {
+		parts = append(parts, styles.StatusDeleted.Render("conflicted"))
+	}
+	if sub.Modified {
+		parts = append(parts, styles.StatusModified.Render("modified"))
+	}
+	if sub.Untracked {
+		parts = append(parts, styles.StatusUntracked.Render("untracked files"))
+	}
+	if len(parts) == 0 {
+		if !sub.Initialized {
+			return styles.Muted.Render("-")
+		}
+		return styles.StatusStaged.Render("clean")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortSubmoduleHash abbreviates hash, showing a dash when it is unknown.
+func shortSubmoduleHash(hash string) string {
+	switch {
+	case hash == "":
+		return "-------"
+	case len(hash) > 7:
+		return hash[:7]
+	}
+	return hash
+}
+
+// openSubmodules opens the submodules panel.
+func (p *Plugin) openSubmodules() tea.Cmd {
+	p.viewMode = ViewModeSubmodules
+	p.submoduleCursor = 0
+	p.submoduleBusy = ""
+	p.submodulesErr = ""
+	p.submodulesLoaded = false
+	p.submodulesModal = nil
+	return p.loadSubmodules()
+}
+
+// loadSubmodules lists the repository's submodules.
+func (p *Plugin) loadSubmodules() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		subs, err := GetSubmodules(workDir)
+		return SubmodulesLoadedMsg{Epoch: epoch, Submodules: subs, Err: err}
+	}
+}
+
+// handleSubmodulesLoaded fills the submodules panel.
+func (p *Plugin) handleSubmodulesLoaded(msg SubmodulesLoadedMsg) {
+	p.submodulesLoaded = true
+	p.submodules = msg.Submodules
+	p.submodulesErr = ""
+	if msg.Err != nil {
+		p.submodulesErr = msg.Err.Error()
+	}
+	if p.submoduleCursor >= len(p.submodules) {
+		p.submoduleCursor = len(p.submodules) - 1
+	}
+	if p.submoduleCursor < 0 {
+		p.submoduleCursor = 0
+	}
+}
+
+// closeSubmodules closes the submodules panel.
+func (p *Plugin) closeSubmodules() {
+	p.viewMode = ViewModeStatus
+	p.submodulesModal = nil
+	p.submodulesModalWidth = 0
+}
+
+// selectedSubmodule returns the submodule under the cursor in the panel.
+func (p *Plugin) selectedSubmodule() *Submodule {
+	if p.submoduleCursor >= 0 && p.submoduleCursor < len(p.submodules) {
+		return p.submodules[p.submoduleCursor]
+	}
+	return nil
+}
+
+// updateSubmodules handles key events in the submodules panel.
+func (p *Plugin) updateSubmodules(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		p.closeSubmodules()
+	case "j", "down":
+		if p.submoduleCursor < len(p.submodules)-1 {
+			p.submoduleCursor++
+		}
+	case "k", "up":
+		if p.submoduleCursor > 0 {
+			p.submoduleCursor--
+		}
+	case "g":
+		p.submoduleCursor = 0
+	case "G":
+		if len(p.submodules) > 0 {
+			p.submoduleCursor = len(p.submodules) - 1
+		}
+	case "enter":
+		if sub := p.selectedSubmodule(); sub != nil {
+			return p, p.enterSubmodule(sub.Path)
+		}
+	case "i":
+		return p, p.doSubmoduleOp(submoduleOpInit)
+	case "u":
+		return p, p.doSubmoduleOp(submoduleOpUpdate)
+	case "s":
+		return p, p.doSubmoduleOp(submoduleOpStage)
+	case "r":
+		return p, p.loadSubmodules()
+	}
+	return p, nil
+}
+
+// handleSubmodulesMouse handles mouse events in the submodules panel.
+func (p *Plugin) handleSubmodulesMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.submodulesModal == nil {
+		return p, nil
+	}
+	action := p.submodulesModal.HandleMouse(msg, p.mouseHandler)
+	if action == "cancel" {
+		p.closeSubmodules()
+		return p, nil
+	}
+	if idx, err := strconv.Atoi(strings.TrimPrefix(action, submoduleItemPrefix)); err == nil && strings.HasPrefix(action, submoduleItemPrefix) {
+		if idx == p.submoduleCursor {
+			if sub := p.selectedSubmodule(); sub != nil {
+				return p, p.enterSubmodule(sub.Path)
+			}
+		}
+		p.submoduleCursor = idx
+	}
+	return p, nil
+}
+
+// doSubmoduleOp runs op on the selected submodule.
+func (p *Plugin) doSubmoduleOp(op string) tea.Cmd {
+	sub := p.selectedSubmodule()
+	if sub == nil || p.submoduleBusy != "" {
+		return nil
+	}
+	switch op {
+	case submoduleOpInit:
+		if sub.Initialized {
+			return appmsg.ShowToast(sub.Path+" is already initialized", 2*time.Second)
+		}
+		p.submoduleBusy = "Initializing " + sub.Path + "..."
+	case submoduleOpUpdate:
+		if !sub.Initialized {
+			return appmsg.ShowToast(sub.Path+" is not initialized (i to init)", 2*time.Second)
+		}
+		p.submoduleBusy = "Updating " + sub.Path + "..."
+	case submoduleOpStage:
+		if !sub.PointerChanged() {
+			return appmsg.ShowToast("Pointer of "+sub.Path+" is up to date", 2*time.Second)
+		}
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	tree := p.tree
+	path := sub.Path
+	return func() tea.Msg {
+		var err error
+		switch op {
+		case submoduleOpInit:
+			err = InitSubmodule(workDir, path)
+		case submoduleOpUpdate:
+			err = UpdateSubmodule(workDir, path)
+		case submoduleOpStage:
+			err = tree.StageFile(path)
+		}
+		return SubmoduleOpDoneMsg{Epoch: epoch, Op: op, Path: path, Err: err}
+	}
+}
+
+// handleSubmoduleOpDone shows the outcome of a submodule operation and
+// reloads the submodules and status.
+func (p *Plugin) handleSubmoduleOpDone(msg SubmoduleOpDoneMsg) tea.Cmd {
+	p.submoduleBusy = ""
+	p.forceNextDiffReload = true
+	if msg.Err != nil {
+		p.showErrorModal(submoduleOpTitles[msg.Op], msg.Err)
+		return tea.Batch(p.loadSubmodules(), p.refresh())
+	}
+	var toast string
+	switch msg.Op {
+	case submoduleOpInit:
+		toast = "Initialized " + msg.Path
+	case submoduleOpUpdate:
+		toast = "Checked out the recorded commit of " + msg.Path
+	case submoduleOpStage:
+		toast = "Staged new pointer for " + msg.Path
+	}
+	return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), p.loadSubmodules(), p.refresh())
+}
+
+// enterSubmodule replaces the view with the status of the submodule at
+// path. backspace returns to the repository it was entered from.
+func (p *Plugin) enterSubmodule(path string) tea.Cmd {
+	if cmd := p.repoSwitchBlocked(); cmd != nil {
+		return cmd
+	}
+	dir := filepath.Join(p.repoRoot, path)
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return appmsg.ShowToast(path+" is not initialized (i in submodules to init)", 2*time.Second)
+	}
+	n := len(p.submoduleStack)
+	stack := append(p.submoduleStack[:n:n], submoduleFrame{ctx: p.ctx, path: path})
+	visits := p.submoduleVisits + 1
+	sub := *p.ctx
+	sub.WorkDir = dir
+	sub.Epoch = stack[0].ctx.Epoch + visits*submoduleEpochStep
+	return p.switchRepo(&sub, stack, visits)
+}
+
+// leaveSubmodule returns to the repository the current submodule was
+// entered from.
+func (p *Plugin) leaveSubmodule() tea.Cmd {
+	n := len(p.submoduleStack)
+	if n == 0 {
+		return nil
+	}
+	if cmd := p.repoSwitchBlocked(); cmd != nil {
+		return cmd
+	}
+	frame := p.submoduleStack[n-1]
+	return p.switchRepo(frame.ctx, p.submoduleStack[:n-1], p.submoduleVisits)
+}
+
+// repoSwitchBlocked returns a toast when a bisect or hook run is going in
+// the current repository: switching would lose track of it while it keeps
+// changing the worktree. It returns nil when switching is fine.
+func (p *Plugin) repoSwitchBlocked() tea.Cmd {
+	if p.bisectRunCancel != nil || p.hookRunCancel != nil {
+		return appmsg.ShowToast("Finish or cancel the run first", 2*time.Second)
+	}
+	return nil
+}
+
+// switchRepo restarts the plugin on the repository of ctx, keeping the
+// stack of repositories entered to reach it. Unlike Stop it leaves the
+// shared object readers and the image cache for the next repository.
+func (p *Plugin) switchRepo(ctx *plugin.Context, stack []submoduleFrame, visits uint64) tea.Cmd {
+	if p.watcher != nil {
+		p.watcher.Stop()
+	}
+	cache := p.imageDiffCache
+	_ = p.Init(ctx)
+	p.imageDiffCache = cache
+	p.submoduleStack = stack
+	p.submoduleVisits = visits
+	return p.Start()
+}
+
+// submoduleBreadcrumb returns the submodule path from the root repository
+// to the current one, or "" at the root.
+func (p *Plugin) submoduleBreadcrumb() string {
+	if len(p.submoduleStack) == 0 {
+		return ""
+	}
+	parts := make([]string, len(p.submoduleStack))
+	for i, f := range p.submoduleStack {
+		parts[i] = f.path
+	}
+	return strings.Join(parts, " › ")
+}
+
+// ensureSubmodulesModal builds/rebuilds the submodules panel.
+func (p *Plugin) ensureSubmodulesModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.submodulesModal != nil && p.submodulesModalWidth == modalW {
+		return
+	}
+	p.submodulesModalWidth = modalW
+
+	p.submodulesModal = modal.New("Submodules",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.submodulesListSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.submodulesStatusSection())
+}
+
+// submodulesListSection renders the submodule list with the cursor kept in
+// view.
+func (p *Plugin) submodulesListSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if !p.submodulesLoaded {
+			return modal.RenderedSection{Content: styles.Muted.Render("Loading submodules...")}
+		}
+		if len(p.submodules) == 0 {
+			return modal.RenderedSection{Content: styles.Muted.Render("No submodules in this repository.")}
+		}
+
+		maxVisible := p.branchPickerMaxVisible()
+		start := 0
+		if p.submoduleCursor >= maxVisible {
+			start = p.submoduleCursor - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(p.submodules))
+
+		pathW := 0
+		for _, s := range p.submodules {
+			pathW = max(pathW, ansi.StringWidth(s.Path))
+		}
+		pathW = min(pathW, 32)
+
+		var sb strings.Builder
+		focusables := make([]modal.FocusableInfo, 0, end-start)
+		for i := start; i < end; i++ {
+			itemID := fmt.Sprintf("%s%d", submoduleItemPrefix, i)
+			line := renderSubmoduleLine(p.submodules[i], pathW, contentWidth, i == p.submoduleCursor || itemID == hoverID)
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(line)
+			focusables = append(focusables, modal.FocusableInfo{
+				ID:      itemID,
+				OffsetY: i - start,
+				Width:   contentWidth,
+				Height:  1,
+			})
+		}
+		if len(p.submodules) > maxVisible {
+			sb.WriteString("\n\n" + styles.Muted.Render(fmt.Sprintf("%d/%d submodules", p.submoduleCursor+1, len(p.submodules))))
+		}
+		return modal.RenderedSection{Content: sb.String(), Focusables: focusables}
+	}, nil)
+}
+
+// renderSubmoduleLine renders one submodule: its state, path, recorded and
+// checked-out commits, and working tree. ● marks a moved checkout, ○ an
+// uninitialized submodule.
+func renderSubmoduleLine(s *Submodule, pathW, width int, selected bool) string {
+	kind := "◇"
+	switch {
+	case !s.Initialized:
+		kind = "○"
+	case s.PointerChanged():
+		kind = "●"
+	}
+	path := ui.TruncateString(s.Path, pathW)
+	path += strings.Repeat(" ", pathW-ansi.StringWidth(path))
+	commits := shortSubmoduleHash(s.Recorded)
+	if s.PointerChanged() {
+		commits += " → " + shortSubmoduleHash(s.Checkout)
+	}
+	prefix := fmt.Sprintf("%s %s  %s  ", kind, path, commits)
+	state := ansi.Strip(submoduleStateText(s))
+	if !s.Initialized {
+		state = "not initialized"
+	}
+	state = ui.TruncateString(state, max(width-ansi.StringWidth(prefix), 0))
+
+	if selected {
+		line := prefix + state
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	kindStyle := styles.Muted
+	if s.PointerChanged() {
+		kindStyle = styles.StatusModified
+	}
+	return kindStyle.Render(kind) + " " + styles.Body.Render(path) + "  " +
+		styles.Code.Render(commits) + "  " + styles.Muted.Render(state)
+}
+
+// submodulesStatusSection shows operation progress, errors and key hints.
+func (p *Plugin) submodulesStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var lines []string
+		switch {
+		case p.submoduleBusy != "":
+			lines = append(lines, styles.StatusInProgress.Render(p.submoduleBusy))
+		case p.submodulesErr != "":
+			lines = append(lines, styles.StatusDeleted.Render(ui.TruncateString(p.submodulesErr, contentWidth)))
+		}
+		lines = append(lines, styles.Muted.Render("enter open · i init · u update · s stage pointer · r refresh · esc close"))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderSubmodules renders the submodules panel over the status view.
+func (p *Plugin) renderSubmodules() string {
+	background := p.renderThreePaneView()
+	p.ensureSubmodulesModal()
+	modalContent := p.submodulesModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
@@ -0,0 +1,148 @@
+package gitstatus
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+)
+
+// newSubmodulePlugin returns a plugin on a superproject whose submodule
+// "lib" has a commit past the recorded one.
+func newSubmodulePlugin(t *testing.T) *Plugin {
+	t.Helper()
+	// Local clones need the file transport, which git disables for
+	// submodules by default
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	lib, _ := initTestRepo(t)
+	commitFile(t, lib, "lib.txt", "v1\n", "lib: one")
+	dir, git := initTestRepo(t)
+	commitFile(t, dir, "main.txt", "main\n", "initial")
+	git("submodule", "add", "-q", lib, "lib")
+	git("commit", "-q", "-m", "add lib")
+	commitFile(t, filepath.Join(dir, "lib"), "lib.txt", "v2\n", "lib: two")
+
+	p := newTestPlugin(t, dir)
+	if err := p.tree.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestSubmodule_SummaryInDiffPane(t *testing.T) {
+	p := newSubmodulePlugin(t)
+	entry := p.selectedSubmoduleEntry()
+	if entry == nil || entry.Path != "lib" || !entry.Submodule.CommitChanged {
+		t.Fatalf("expected lib as a moved submodule, entries = %+v", p.tree.AllEntries())
+	}
+
+	cmd := p.autoLoadDiff()
+	if cmd == nil {
+		t.Fatal("expected the submodule summary to load")
+	}
+	p.Update(cmd())
+	p.diffPaneWidth = 80
+	pane := p.renderDiffPane(20)
+	for _, want := range []string{"Recorded", "moved", "1 ahead, 0 behind", "lib: two", "stage pointer"} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("diff pane missing %q:\n%s", want, pane)
+		}
+	}
+}
+
+func TestSubmodule_PanelStagesPointer(t *testing.T) {
+	p := newSubmodulePlugin(t)
+
+	_, cmd := p.Update(runeKey("M"))
+	if p.viewMode != ViewModeSubmodules || cmd == nil {
+		t.Fatal("M should open the submodules panel")
+	}
+	p.Update(cmd())
+	if !strings.Contains(p.View(120, 30), "lib") {
+		t.Error("panel should list lib")
+	}
+
+	_, cmd = p.Update(runeKey("s"))
+	if cmd == nil {
+		t.Fatal("s should stage the moved pointer")
+	}
+	if msg := cmd().(SubmoduleOpDoneMsg); msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+	sub, err := GetSubmodule(p.repoRoot, "lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.PointerStaged() || sub.PointerChanged() {
+		t.Errorf("pointer should be staged, got %+v", sub)
+	}
+}
+
+func TestSubmodule_EnterAndLeave(t *testing.T) {
+	p := newSubmodulePlugin(t)
+	root := p.ctx
+	rootDir := p.repoRoot
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if filepath.Base(p.repoRoot) != "lib" || len(p.submoduleStack) != 1 {
+		t.Fatalf("enter should open lib, repoRoot = %q", p.repoRoot)
+	}
+	if p.ctx == root || p.ctx.Epoch == root.Epoch || root.WorkDir == p.ctx.WorkDir {
+		t.Error("the submodule should get its own context and epoch")
+	}
+	p.sidebarWidth = 40
+	if !strings.Contains(p.renderSidebar(20), "lib") {
+		t.Error("header should show where the view is")
+	}
+
+	// Results for the superproject are dropped
+	p.Update(SubmodulesLoadedMsg{Epoch: root.Epoch, Submodules: []*Submodule{{Path: "x"}}})
+	if p.submodules != nil {
+		t.Error("stale superproject results should be ignored")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if p.ctx != root || p.repoRoot != rootDir || len(p.submoduleStack) != 0 {
+		t.Errorf("backspace should return to the superproject, repoRoot = %q", p.repoRoot)
+	}
+	if cmd := p.leaveSubmodule(); cmd != nil {
+		t.Error("the root repository has nowhere to go back to")
+	}
+}
+
+func TestSubmodule_SwitchBlockedDuringRun(t *testing.T) {
+	p := newSubmodulePlugin(t)
+	rootDir := p.repoRoot
+	cancelled := false
+	p.bisectRunCancel = func() { cancelled = true }
+	cache := p.imageCache()
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.repoRoot != rootDir || cancelled {
+		t.Fatalf("entering a submodule during a bisect run should be refused, repoRoot = %q, cancelled = %v", p.repoRoot, cancelled)
+	}
+	if toast, ok := p.repoSwitchBlocked()().(appmsg.ToastMsg); !ok || !strings.Contains(toast.Message, "cancel the run first") {
+		t.Errorf("expected a toast explaining why, got %+v", toast)
+	}
+
+	p.bisectRunCancel = nil
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(p.submoduleStack) != 1 {
+		t.Fatal("enter should open lib once the run is over")
+	}
+	if p.imageDiffCache != cache {
+		t.Error("switching repositories should keep the image cache")
+	}
+
+	p.hookRunCancel = func() { cancelled = true }
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if len(p.submoduleStack) != 1 || cancelled {
+		t.Error("leaving a submodule during a hook run should be refused")
+	}
+	p.hookRunCancel = nil
+}
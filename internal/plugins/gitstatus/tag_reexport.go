@@ -0,0 +1,25 @@
+package gitstatus
+
+import "github.com/guyghost/sidecar/internal/git"
+
+// Re-export tag types from internal/git.
+type (
+	Tag            = git.Tag
+	TagError       = git.TagError
+	ReleaseSummary = git.ReleaseSummary
+)
+
+// Re-export tag functions.
+var (
+	GetTags              = git.GetTags
+	GetRemoteTags        = git.GetRemoteTags
+	MergeRemoteTags      = git.MergeRemoteTags
+	TagsByCommit         = git.TagsByCommit
+	ValidateTagName      = git.ValidateTagName
+	CreateTag            = git.CreateTag
+	DeleteTag            = git.DeleteTag
+	DeleteRemoteTag      = git.DeleteRemoteTag
+	PushTag              = git.PushTag
+	GetLatestTagDistance = git.GetLatestTagDistance
+	GetReleaseSummary    = git.GetReleaseSummary
+)
@@ -0,0 +1,762 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/guyghost/sidecar/internal/modal"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/styles"
+	"github.com/guyghost/sidecar/internal/ui"
+)
+
+const (
+	tagItemPrefix = "tag-item-" // List item ID prefix, followed by tag index
+
+	createTagNameID    = "create-tag-name"
+	createTagMessageID = "create-tag-message"
+	createTagSignID    = "create-tag-sign"
+	createTagActionID  = "create-tag"
+
+	releaseCopyID = "release-copy"
+)
+
+// Tag operations reported by TagOpDoneMsg.
+const (
+	tagOpCreate       = "create"
+	tagOpDelete       = "delete"
+	tagOpDeleteRemote = "delete-remote"
+	tagOpPush         = "push"
+)
+
+// tagOpTitles maps a tag operation to the error modal title for its failure.
+var tagOpTitles = map[string]string{
+	tagOpCreate:       "Create Tag Failed",
+	tagOpDelete:       "Delete Tag Failed",
+	tagOpDeleteRemote: "Delete Remote Tag Failed",
+	tagOpPush:         "Push Tag Failed",
+}
+
+// RemoteTagsLoadedMsg is sent when the remote's tags are listed.
+type RemoteTagsLoadedMsg struct {
+	Epoch  uint64 // Epoch when request was issued (for stale detection)
+	Remote string // Empty when no remote is configured
+	Tags   map[string]string
+	Err    error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m RemoteTagsLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// TagOpDoneMsg is sent when a tag create, delete or push returns.
+type TagOpDoneMsg struct {
+	Epoch  uint64 // Epoch when request was issued (for stale detection)
+	Op     string // tagOpCreate, tagOpDelete, tagOpDeleteRemote or tagOpPush
+	Name   string
+	Hash   string // Target commit, for pushed tags
+	Remote string
+	Err    error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m TagOpDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// ReleaseSummaryLoadedMsg is sent when the changes since a tag are summarized.
+type ReleaseSummaryLoadedMsg struct {
+	Epoch   uint64 // Epoch when request was issued (for stale detection)
+	Summary *ReleaseSummary
+	Err     error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ReleaseSummaryLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// setTags stores the local tags loaded with the recent commits.
+func (p *Plugin) setTags(tags []*Tag, latest string, since int) {
+	p.tags = tags
+	p.tagsByHash = TagsByCommit(tags)
+	p.latestTag = latest
+	p.sinceLatestTag = since
+	p.rebuildTagList()
+}
+
+// rebuildTagList merges the local and remote tags shown in the tags panel.
+func (p *Plugin) rebuildTagList() {
+	if p.remoteTags == nil {
+		p.tagList = append([]*Tag(nil), p.tags...)
+	} else {
+		p.tagList = MergeRemoteTags(p.tags, p.remoteTags)
+	}
+	if p.tagCursor >= len(p.tagList) {
+		p.tagCursor = len(p.tagList) - 1
+	}
+	if p.tagCursor < 0 {
+		p.tagCursor = 0
+	}
+}
+
+// selectedTag returns the tag under the cursor in the tags panel, or nil.
+func (p *Plugin) selectedTag() *Tag {
+	if p.tagCursor >= 0 && p.tagCursor < len(p.tagList) {
+		return p.tagList[p.tagCursor]
+	}
+	return nil
+}
+
+// openTags opens the tags panel and lists the remote's tags in the background.
+func (p *Plugin) openTags() tea.Cmd {
+	p.viewMode = ViewModeTags
+	p.tagCursor = 0
+	p.tagConfirm = ""
+	p.tagBusy = ""
+	p.remoteTags = nil
+	p.remoteTagsErr = ""
+	p.tagsModal = nil
+	p.rebuildTagList()
+	return tea.Batch(p.loadRemoteTags(), p.loadRecentCommits())
+}
+
+// loadRemoteTags lists the tags on the primary remote.
+func (p *Plugin) loadRemoteTags() tea.Cmd {
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		remote := GetRemoteName(workDir)
+		if remote == "" {
+			return RemoteTagsLoadedMsg{Epoch: epoch}
+		}
+		tags, err := GetRemoteTags(workDir, remote)
+		return RemoteTagsLoadedMsg{Epoch: epoch, Remote: remote, Tags: tags, Err: err}
+	}
+}
+
+// handleRemoteTags merges the remote's tags into the tags panel.
+func (p *Plugin) handleRemoteTags(msg RemoteTagsLoadedMsg) {
+	p.tagRemote = msg.Remote
+	switch {
+	case msg.Remote == "":
+		p.remoteTagsErr = "No remote configured"
+	case msg.Err != nil:
+		p.remoteTagsErr = "Could not list remote tags"
+	default:
+		p.remoteTags = msg.Tags
+		p.remoteTagsErr = ""
+	}
+	p.rebuildTagList()
+}
+
+// closeTags closes the tags panel.
+func (p *Plugin) closeTags() {
+	p.viewMode = ViewModeStatus
+	p.tagConfirm = ""
+	p.remoteTags = nil
+	p.tagsModal = nil
+	p.tagsModalWidth = 0
+	p.rebuildTagList()
+}
+
+// updateTags handles key events in the tags panel.
+func (p *Plugin) updateTags(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	key := msg.String()
+
+	// A pending delete takes y to confirm; any other key cancels it
+	if p.tagConfirm != "" {
+		op := p.tagConfirm
+		p.tagConfirm = ""
+		if key == "y" {
+			return p, p.doDeleteTag(op)
+		}
+		return p, nil
+	}
+
+	switch key {
+	case "esc", "q":
+		p.closeTags()
+	case "j", "down":
+		if p.tagCursor < len(p.tagList)-1 {
+			p.tagCursor++
+		}
+	case "k", "up":
+		if p.tagCursor > 0 {
+			p.tagCursor--
+		}
+	case "g":
+		p.tagCursor = 0
+	case "G":
+		if len(p.tagList) > 0 {
+			p.tagCursor = len(p.tagList) - 1
+		}
+	case "n":
+		return p, p.openCreateTag(nil)
+	case "p":
+		return p, p.doPushTag()
+	case "d":
+		if tag := p.selectedTag(); tag != nil && tag.Local {
+			p.tagConfirm = tagOpDelete
+		}
+	case "D":
+		if tag := p.selectedTag(); tag != nil && tag.Remote && p.tagRemote != "" && p.tagBusy == "" {
+			p.tagConfirm = tagOpDeleteRemote
+		}
+	case "enter":
+		if tag := p.selectedTag(); tag != nil {
+			return p, p.openRelease(tag)
+		}
+	}
+	return p, nil
+}
+
+// handleTagsMouse handles mouse events in the tags panel.
+func (p *Plugin) handleTagsMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.tagsModal == nil {
+		return p, nil
+	}
+	action := p.tagsModal.HandleMouse(msg, p.mouseHandler)
+	if action == "cancel" {
+		p.closeTags()
+		return p, nil
+	}
+	if idx, err := strconv.Atoi(strings.TrimPrefix(action, tagItemPrefix)); err == nil && strings.HasPrefix(action, tagItemPrefix) {
+		if idx == p.tagCursor {
+			if tag := p.selectedTag(); tag != nil {
+				return p, p.openRelease(tag)
+			}
+		}
+		p.tagCursor = idx
+	}
+	return p, nil
+}
+
+// doDeleteTag deletes the selected tag locally or from the remote.
+func (p *Plugin) doDeleteTag(op string) tea.Cmd {
+	tag := p.selectedTag()
+	if tag == nil {
+		return nil
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	name := tag.Name
+	remote := p.tagRemote
+	if op == tagOpDeleteRemote {
+		p.tagBusy = "Deleting " + name + " from " + remote + "..."
+	}
+	return func() tea.Msg {
+		var err error
+		if op == tagOpDeleteRemote {
+			err = DeleteRemoteTag(workDir, remote, name)
+		} else {
+			err = DeleteTag(workDir, name)
+		}
+		return TagOpDoneMsg{Epoch: epoch, Op: op, Name: name, Remote: remote, Err: err}
+	}
+}
+
+// doPushTag pushes the selected local tag to the remote.
+func (p *Plugin) doPushTag() tea.Cmd {
+	tag := p.selectedTag()
+	if tag == nil || !tag.Local || p.tagBusy != "" {
+		return nil
+	}
+	if p.tagRemote == "" {
+		return appmsg.ShowToast("No remote configured", 2*time.Second)
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	name, hash := tag.Name, tag.Hash
+	remote := p.tagRemote
+	p.tagBusy = "Pushing " + name + " to " + remote + "..."
+	return func() tea.Msg {
+		err := PushTag(workDir, remote, name)
+		return TagOpDoneMsg{Epoch: epoch, Op: tagOpPush, Name: name, Hash: hash, Remote: remote, Err: err}
+	}
+}
+
+// handleTagOpDone shows the outcome of a tag operation and reloads the tags.
+func (p *Plugin) handleTagOpDone(msg TagOpDoneMsg) tea.Cmd {
+	p.tagBusy = ""
+	if msg.Err != nil {
+		if msg.Op == tagOpCreate && p.viewMode == ViewModeCreateTag {
+			// Keep the modal open so the name or message can be fixed
+			p.createTagErr = msg.Err.Error()
+			return nil
+		}
+		p.showErrorModal(tagOpTitles[msg.Op], msg.Err)
+		return nil
+	}
+
+	var toast string
+	switch msg.Op {
+	case tagOpCreate:
+		toast = "Created tag " + msg.Name
+		p.closeCreateTag()
+	case tagOpDelete:
+		toast = "Deleted tag " + msg.Name
+		kept := p.tags[:0:0]
+		for _, t := range p.tags {
+			if t.Name != msg.Name {
+				kept = append(kept, t)
+			}
+		}
+		p.tags = kept
+	case tagOpDeleteRemote:
+		toast = "Deleted " + msg.Name + " from " + msg.Remote
+		delete(p.remoteTags, msg.Name)
+	case tagOpPush:
+		toast = "Pushed " + msg.Name + " to " + msg.Remote
+		if p.remoteTags != nil {
+			p.remoteTags[msg.Name] = msg.Hash
+		}
+	}
+	p.rebuildTagList()
+	return tea.Batch(appmsg.ShowToast(toast, 2*time.Second), p.loadRecentCommits())
+}
+
+// ensureTagsModal builds/rebuilds the tags panel.
+func (p *Plugin) ensureTagsModal() {
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.tagsModal != nil && p.tagsModalWidth == modalW {
+		return
+	}
+	p.tagsModalWidth = modalW
+
+	p.tagsModal = modal.New("Tags",
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.tagsListSection()).
+		AddSection(modal.Spacer()).
+		AddSection(p.tagsStatusSection())
+}
+
+// tagsListSection renders the tag list with the cursor kept in view.
+func (p *Plugin) tagsListSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if len(p.tagList) == 0 {
+			return modal.RenderedSection{Content: styles.Muted.Render("No tags. Press n to tag HEAD.")}
+		}
+
+		maxVisible := p.branchPickerMaxVisible()
+		start := 0
+		if p.tagCursor >= maxVisible {
+			start = p.tagCursor - maxVisible + 1
+		}
+		end := start + maxVisible
+		if end > len(p.tagList) {
+			end = len(p.tagList)
+		}
+
+		nameW := 0
+		for _, t := range p.tagList {
+			if w := ansi.StringWidth(t.Name); w > nameW {
+				nameW = w
+			}
+		}
+		if nameW > 24 {
+			nameW = 24
+		}
+
+		var sb strings.Builder
+		focusables := make([]modal.FocusableInfo, 0, end-start)
+		for i := start; i < end; i++ {
+			itemID := fmt.Sprintf("%s%d", tagItemPrefix, i)
+			line := p.renderTagLine(p.tagList[i], nameW, contentWidth, i == p.tagCursor || itemID == hoverID)
+			if i > start {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(line)
+			focusables = append(focusables, modal.FocusableInfo{
+				ID:      itemID,
+				OffsetY: i - start,
+				Width:   contentWidth,
+				Height:  1,
+			})
+		}
+		if len(p.tagList) > maxVisible {
+			sb.WriteString("\n\n" + styles.Muted.Render(fmt.Sprintf("%d/%d tags", p.tagCursor+1, len(p.tagList))))
+		}
+		return modal.RenderedSection{Content: sb.String(), Focusables: focusables}
+	}, nil)
+}
+
+// renderTagLine renders one tag: kind, name, target, where it exists,
+// date and subject. ◆ marks annotated tags, ◇ lightweight ones and ○ tags
+// that only exist on the remote.
+func (p *Plugin) renderTagLine(t *Tag, nameW, width int, selected bool) string {
+	kind := "◇"
+	switch {
+	case !t.Local:
+		kind = "○"
+	case t.Annotated:
+		kind = "◆"
+	}
+	where := ""
+	if t.Local {
+		where += "L"
+	} else {
+		where += " "
+	}
+	switch {
+	case t.Remote:
+		where += "R"
+	case p.remoteTags == nil && p.remoteTagsErr == "":
+		where += "?"
+	default:
+		where += " "
+	}
+	date := ""
+	if !t.Date.IsZero() {
+		date = RelativeTime(t.Date)
+	}
+
+	name := ui.TruncateString(t.Name, nameW)
+	name += strings.Repeat(" ", nameW-ansi.StringWidth(name))
+	prefix := fmt.Sprintf("%s %s  %s  %s  ", kind, name, t.ShortHash(), where)
+	rest := strings.TrimSpace(date + "  " + t.Subject)
+	restW := width - ansi.StringWidth(prefix)
+	if restW < 0 {
+		restW = 0
+	}
+	rest = ui.TruncateString(rest, restW)
+
+	if selected {
+		line := prefix + rest
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		return styles.ListItemSelected.Render(line)
+	}
+	kindStyle := styles.Muted
+	if t.Annotated {
+		kindStyle = styles.StatusModified
+	}
+	return kindStyle.Render(kind) + " " + styles.Body.Render(name) + "  " +
+		styles.Code.Render(t.ShortHash()) + "  " + styles.Muted.Render(where) + "  " + styles.Muted.Render(rest)
+}
+
+// tagsStatusSection shows pending confirmations, remote status and key hints.
+func (p *Plugin) tagsStatusSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		var lines []string
+		tag := p.selectedTag()
+		switch {
+		case p.tagConfirm == tagOpDelete && tag != nil:
+			lines = append(lines, styles.StatusDeleted.Render("Delete local tag "+tag.Name+"? y to confirm, any key to cancel"))
+		case p.tagConfirm == tagOpDeleteRemote && tag != nil:
+			lines = append(lines, styles.StatusDeleted.Render("Delete "+tag.Name+" from "+p.tagRemote+"? y to confirm, any key to cancel"))
+		case p.tagBusy != "":
+			lines = append(lines, styles.StatusInProgress.Render(p.tagBusy))
+		case p.remoteTagsErr != "":
+			lines = append(lines, styles.Muted.Render(p.remoteTagsErr))
+		case p.remoteTags == nil:
+			lines = append(lines, styles.Muted.Render("Checking remote tags..."))
+		}
+		lines = append(lines, styles.Muted.Render("n new · p push · d delete · D delete remote · enter changes since · esc close"))
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil)
+}
+
+// renderTags renders the tags panel over the status view.
+func (p *Plugin) renderTags() string {
+	background := p.renderThreePaneView()
+	p.ensureTagsModal()
+	modalContent := p.tagsModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// openCreateTag opens the create tag modal for target, or HEAD when nil.
+func (p *Plugin) openCreateTag(target *Commit) tea.Cmd {
+	p.createTagReturnMode = p.viewMode
+	p.createTagTarget = target
+	p.createTagName = textinput.New()
+	p.createTagName.Placeholder = "v1.2.0"
+	p.createTagName.CharLimit = 100
+	p.createTagName.Focus()
+	p.createTagMessage = textarea.New()
+	p.createTagMessage.Placeholder = "Message (leave empty for a lightweight tag)"
+	p.createTagMessage.ShowLineNumbers = false
+	p.createTagMessage.SetHeight(3)
+	p.createTagSign = false
+	p.createTagErr = ""
+	p.createTagModal = nil
+	p.viewMode = ViewModeCreateTag
+	return textinput.Blink
+}
+
+// closeCreateTag closes the create tag modal.
+func (p *Plugin) closeCreateTag() {
+	p.viewMode = p.createTagReturnMode
+	p.createTagTarget = nil
+	p.createTagErr = ""
+	p.createTagModal = nil
+	p.createTagWidth = 0
+}
+
+// ensureCreateTagModal builds/rebuilds the create tag modal.
+func (p *Plugin) ensureCreateTagModal() {
+	modalW := ui.ModalWidthLarge
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.createTagModal != nil && p.createTagWidth == modalW {
+		return
+	}
+	p.createTagWidth = modalW
+
+	target := "HEAD"
+	if p.createTagTarget != nil {
+		target = shortHash(p.createTagTarget.Hash) + " " + p.createTagTarget.Subject
+	}
+
+	p.createTagModal = modal.New("Create Tag",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(createTagActionID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text(styles.Muted.Render("On " + ui.TruncateString(target, modalW-10)))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.InputWithLabel(createTagNameID, "Name", &p.createTagName)).
+		AddSection(modal.Spacer()).
+		AddSection(modal.TextareaWithLabel(createTagMessageID, "Message", &p.createTagMessage, 3)).
+		AddSection(modal.Checkbox(createTagSignID, "Sign with GPG (annotated)", &p.createTagSign)).
+		AddSection(modal.When(func() bool { return p.createTagErr != "" }, modal.Custom(
+			func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+				return modal.RenderedSection{Content: styles.StatusDeleted.Render(strings.TrimSpace(p.createTagErr))}
+			}, nil))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Create ", createTagActionID),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+}
+
+// updateCreateTag handles key events in the create tag modal.
+func (p *Plugin) updateCreateTag(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureCreateTagModal()
+
+	focusID := p.createTagModal.FocusedID()
+	action, cmd := p.createTagModal.HandleKey(msg)
+	// Enter in the message inserts a newline rather than creating the tag
+	if action == createTagActionID && focusID == createTagMessageID {
+		return p, cmd
+	}
+	switch action {
+	case createTagActionID:
+		return p, p.doCreateTag()
+	case "cancel":
+		p.closeCreateTag()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handleCreateTagMouse handles mouse events in the create tag modal.
+func (p *Plugin) handleCreateTagMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.createTagModal == nil {
+		return p, nil
+	}
+	switch p.createTagModal.HandleMouse(msg, p.mouseHandler) {
+	case createTagActionID:
+		return p, p.doCreateTag()
+	case "cancel":
+		p.closeCreateTag()
+	}
+	return p, nil
+}
+
+// doCreateTag creates the tag described by the create tag modal.
+func (p *Plugin) doCreateTag() tea.Cmd {
+	name := strings.TrimSpace(p.createTagName.Value())
+	if name == "" {
+		p.createTagErr = "Tag name is required"
+		return nil
+	}
+	target := ""
+	if p.createTagTarget != nil {
+		target = p.createTagTarget.Hash
+	}
+	message := strings.TrimSpace(p.createTagMessage.Value())
+	sign := p.createTagSign
+	p.createTagErr = ""
+
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	return func() tea.Msg {
+		err := CreateTag(workDir, name, target, message, sign)
+		return TagOpDoneMsg{Epoch: epoch, Op: tagOpCreate, Name: name, Err: err}
+	}
+}
+
+// renderCreateTag renders the create tag modal over the status view.
+func (p *Plugin) renderCreateTag() string {
+	background := p.renderThreePaneView()
+	p.ensureCreateTagModal()
+	modalContent := p.createTagModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// openRelease summarizes the commits on HEAD since tag.
+func (p *Plugin) openRelease(tag *Tag) tea.Cmd {
+	if !tag.Local {
+		return appmsg.ShowToast("Fetch "+tag.Name+" to compare against it", 2*time.Second)
+	}
+	epoch := p.ctx.Epoch
+	workDir := p.repoRoot
+	name := tag.Name
+	return func() tea.Msg {
+		summary, err := GetReleaseSummary(workDir, name)
+		return ReleaseSummaryLoadedMsg{Epoch: epoch, Summary: summary, Err: err}
+	}
+}
+
+// handleReleaseSummary opens the release view.
+func (p *Plugin) handleReleaseSummary(msg ReleaseSummaryLoadedMsg) {
+	if msg.Err != nil {
+		p.showErrorModal("Cannot Summarize Changes", msg.Err)
+		return
+	}
+	p.releaseSummary = msg.Summary
+	p.releaseModal = nil
+	p.viewMode = ViewModeRelease
+}
+
+// closeRelease returns from the release view to the tags panel.
+func (p *Plugin) closeRelease() {
+	p.viewMode = ViewModeTags
+	p.releaseSummary = nil
+	p.releaseModal = nil
+	p.releaseWidth = 0
+}
+
+// ensureReleaseModal builds/rebuilds the release view.
+func (p *Plugin) ensureReleaseModal() {
+	if p.releaseSummary == nil {
+		return
+	}
+	modalW := ui.ModalWidthLarge + 20
+	if modalW > p.width-4 {
+		modalW = p.width - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if p.releaseModal != nil && p.releaseWidth == modalW {
+		return
+	}
+	p.releaseWidth = modalW
+
+	p.releaseModal = modal.New("Changes since "+p.releaseSummary.Tag,
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(p.releaseSection()).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Copy Markdown ", releaseCopyID),
+			modal.Btn(" Close ", "cancel"),
+		))
+}
+
+// releaseSection renders the summary stats, commits and authors.
+func (p *Plugin) releaseSection() modal.Section {
+	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		s := p.releaseSummary
+		if len(s.Commits) == 0 {
+			return modal.RenderedSection{Content: styles.Muted.Render("No commits since " + s.Tag)}
+		}
+
+		var sb strings.Builder
+		stats := fmt.Sprintf("%d commit(s) · %d author(s) · %d file(s) ", len(s.Commits), len(s.Authors), s.FilesChanged)
+		sb.WriteString(styles.Muted.Render(stats))
+		sb.WriteString(styles.DiffAdd.Render(fmt.Sprintf("+%d", s.Insertions)) + " ")
+		sb.WriteString(styles.DiffRemove.Render(fmt.Sprintf("-%d", s.Deletions)))
+		sb.WriteString("\n")
+		for _, c := range s.Commits {
+			hash, subject, _ := strings.Cut(c, " ")
+			sb.WriteString("\n" + styles.Code.Render(hash) + " " + ui.TruncateString(subject, contentWidth-len(hash)-1))
+		}
+		sb.WriteString("\n\n" + styles.Muted.Render(ui.TruncateString("Authors: "+strings.Join(s.Authors, ", "), contentWidth)))
+		return modal.RenderedSection{Content: sb.String()}
+	}, nil)
+}
+
+// updateRelease handles key events in the release view.
+func (p *Plugin) updateRelease(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureReleaseModal()
+	if p.releaseModal == nil {
+		p.closeRelease()
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.closeRelease()
+		return p, nil
+	case "y":
+		return p, p.copyReleaseNotes()
+	case "j", "down":
+		p.releaseModal.ScrollBy(1)
+		return p, nil
+	case "k", "up":
+		p.releaseModal.ScrollBy(-1)
+		return p, nil
+	}
+	action, cmd := p.releaseModal.HandleKey(msg)
+	return p, tea.Batch(cmd, p.releaseAction(action))
+}
+
+// handleReleaseMouse handles mouse events in the release view.
+func (p *Plugin) handleReleaseMouse(msg tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.releaseModal == nil {
+		return p, nil
+	}
+	return p, p.releaseAction(p.releaseModal.HandleMouse(msg, p.mouseHandler))
+}
+
+// releaseAction runs the release view button with the given ID.
+func (p *Plugin) releaseAction(action string) tea.Cmd {
+	switch action {
+	case releaseCopyID:
+		return p.copyReleaseNotes()
+	case "cancel":
+		p.closeRelease()
+	}
+	return nil
+}
+
+// copyReleaseNotes copies the release summary as Markdown.
+func (p *Plugin) copyReleaseNotes() tea.Cmd {
+	if p.releaseSummary == nil {
+		return nil
+	}
+	if err := clipboard.WriteAll(p.releaseSummary.Markdown()); err != nil {
+		return appmsg.ShowToast("Copy failed: "+err.Error(), 2*time.Second)
+	}
+	return appmsg.ShowToast("Yanked release notes", 2*time.Second)
+}
+
+// renderRelease renders the release view over the status view.
+func (p *Plugin) renderRelease() string {
+	background := p.renderThreePaneView()
+	p.ensureReleaseModal()
+	if p.releaseModal == nil {
+		return background
+	}
+	modalContent := p.releaseModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
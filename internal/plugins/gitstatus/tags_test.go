@@ -0,0 +1,144 @@
+package gitstatus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/keymap"
+)
+
+func newTagsPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	p := newHistoryOpsPlugin(t)
+	p.setTags([]*Tag{
+		{Name: "v2", Hash: "ccc0000000", Annotated: true, Subject: "Release 2", Local: true},
+		{Name: "v1", Hash: "aaa0000000", Subject: "one", Local: true},
+	}, "v2", 0)
+	return p
+}
+
+func TestTags_DecorateHistory(t *testing.T) {
+	p := newTagsPlugin(t)
+	p.sinceLatestTag = 3
+	p.sidebarWidth = 60
+
+	sidebar := p.renderSidebar(20)
+	for _, want := range []string{"v2+3", "(v2) three", "(v1) one"} {
+		if !strings.Contains(sidebar, want) {
+			t.Errorf("sidebar missing %q", want)
+		}
+	}
+	if strings.Contains(sidebar, "(v1) two") || strings.Contains(sidebar, ") two") {
+		t.Error("untagged commits should not be decorated")
+	}
+
+	p.showCommitGraph = true
+	p.commitGraphLines = ComputeGraphForCommits(p.recentCommits)
+	if sidebar := p.renderSidebar(20); !strings.Contains(sidebar, "(v2) three") {
+		t.Error("graph rows should carry tag decorations")
+	}
+}
+
+func TestTags_PanelMergesRemoteAndConfirmsDelete(t *testing.T) {
+	p := newTagsPlugin(t)
+	p.Update(runeKey("T"))
+	if p.viewMode != ViewModeTags || p.FocusContext() != keymap.ContextGitTags {
+		t.Fatalf("expected tags panel, viewMode=%v", p.viewMode)
+	}
+	if view := p.View(100, 30); !strings.Contains(view, "Checking remote tags") {
+		t.Error("panel should show that remote tags are loading")
+	}
+
+	p.Update(RemoteTagsLoadedMsg{Remote: "origin", Tags: map[string]string{"v1": "aaa0000000", "v0": "fff0000000"}})
+	if len(p.tagList) != 3 || p.tagList[2].Name != "v0" || p.tagList[2].Local {
+		t.Fatalf("remote-only tag should be listed last, got %d tags", len(p.tagList))
+	}
+	view := p.View(120, 30)
+	for _, want := range []string{"◆ v2", "◇ v1", "○ v0", "LR"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel missing %q", want)
+		}
+	}
+
+	// v2 is local only, so it cannot be deleted from the remote
+	p.Update(runeKey("D"))
+	if p.tagConfirm != "" {
+		t.Error("D on a local-only tag should be ignored")
+	}
+	p.Update(runeKey("j"))
+	p.Update(runeKey("D"))
+	if !strings.Contains(p.View(120, 30), "Delete v1 from origin?") {
+		t.Fatal("D should ask to confirm the remote delete")
+	}
+	if _, cmd := p.Update(runeKey("n")); cmd != nil || p.tagConfirm != "" {
+		t.Error("any key but y should cancel the delete")
+	}
+
+	p.Update(TagOpDoneMsg{Op: tagOpDeleteRemote, Name: "v1", Remote: "origin"})
+	if p.tagList[1].Name != "v1" || p.tagList[1].Remote {
+		t.Error("v1 should no longer be marked as on the remote")
+	}
+	p.Update(TagOpDoneMsg{Op: tagOpDelete, Name: "v2"})
+	if len(p.tags) != 1 || p.tags[0].Name != "v1" {
+		t.Errorf("v2 should be removed from the local tags, got %d", len(p.tags))
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeStatus {
+		t.Error("esc should close the panel")
+	}
+}
+
+func TestCreateTag_OnCommit(t *testing.T) {
+	p := newTagsPlugin(t)
+	p.cursor = 1
+	p.Update(runeKey("a"))
+	if p.viewMode != ViewModeCreateTag || !p.ConsumesTextInput() {
+		t.Fatalf("a should open the create tag modal, viewMode=%v", p.viewMode)
+	}
+	if view := p.View(100, 30); !strings.Contains(view, "Create Tag") || !strings.Contains(view, "bbb0000 two") {
+		t.Error("modal should name the target commit")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.createTagErr != "Tag name is required" {
+		t.Errorf("empty name error = %q", p.createTagErr)
+	}
+
+	p.Update(TagOpDoneMsg{Op: tagOpCreate, Name: "v3", Err: &TagError{Output: "tag 'v3' already exists", Err: errors.New("exit 128")}})
+	if p.viewMode != ViewModeCreateTag || !strings.Contains(p.View(100, 30), "already exists") {
+		t.Error("a failed create should keep the modal open with the error")
+	}
+	p.Update(TagOpDoneMsg{Op: tagOpCreate, Name: "v3"})
+	if p.viewMode != ViewModeStatus {
+		t.Error("a created tag should close the modal")
+	}
+}
+
+func TestRelease_ShowsSummary(t *testing.T) {
+	p := newTagsPlugin(t)
+	p.viewMode = ViewModeTags
+	p.Update(ReleaseSummaryLoadedMsg{Summary: &ReleaseSummary{
+		Tag:          "v1",
+		Commits:      []string{"ccc0000 three", "bbb0000 two"},
+		Authors:      []string{"Ada"},
+		FilesChanged: 2,
+		Insertions:   5,
+		Deletions:    1,
+	}})
+	if p.viewMode != ViewModeRelease || p.FocusContext() != keymap.ContextGitRelease {
+		t.Fatalf("expected release view, viewMode=%v", p.viewMode)
+	}
+	view := p.View(120, 30)
+	for _, want := range []string{"Changes since v1", "2 commit(s)", "+5", "three", "Authors: Ada"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("release view missing %q", want)
+		}
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.viewMode != ViewModeTags {
+		t.Error("esc should return to the tags panel")
+	}
+}
@@ -0,0 +1,94 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guyghost/sidecar/internal/mouse"
+	"github.com/guyghost/sidecar/internal/plugin"
+)
+
+// gitRunner returns a function that runs git in dir as a test identity and
+// returns its output, failing t if git fails.
+func gitRunner(t testing.TB, dir string) func(args ...string) string {
+	return func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=T", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v (%s)", args, err, out)
+		}
+		return string(out)
+	}
+}
+
+// initTestRepo creates a repository on branch main in a new temp dir,
+// passing initArgs such as "--bare" to git init, and returns its path and
+// a runner. The identity is configured too, for commits the plugin makes.
+func initTestRepo(t testing.TB, initArgs ...string) (string, func(args ...string) string) {
+	t.Helper()
+	dir := t.TempDir()
+	git := gitRunner(t, dir)
+	git(append([]string{"init", "-q", "-b", "main"}, initArgs...)...)
+	git("config", "user.name", "T")
+	git("config", "user.email", "t@example.com")
+	return dir, git
+}
+
+// writeTestFile writes content to name in dir, creating its directories.
+func writeTestFile(t testing.TB, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// commitFile writes content to name in the repository at dir and commits
+// all changes with msg.
+func commitFile(t testing.TB, dir, name, content, msg string) {
+	t.Helper()
+	writeTestFile(t, dir, name, content)
+	git := gitRunner(t, dir)
+	git("add", "-A")
+	git("commit", "-q", "-m", msg)
+}
+
+// newTestPlugin returns a plugin initialised on dir, with a mouse handler
+// and a 120x30 view. It is stopped when the test ends.
+func newTestPlugin(t testing.TB, dir string) *Plugin {
+	t.Helper()
+	p := New()
+	p.mouseHandler = mouse.NewHandler()
+	p.width, p.height = 120, 30
+	if err := p.Init(&plugin.Context{WorkDir: dir, Epoch: 3}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.Stop)
+	return p
+}
+
+// newRepoPlugin returns a plugin, with its status loaded, on a repo with
+// a 20-line a.txt and b.txt committed, and a git runner for the repo.
+func newRepoPlugin(t *testing.T) (*Plugin, func(args ...string) string) {
+	t.Helper()
+	dir, git := initTestRepo(t)
+	var lines strings.Builder
+	for i := 1; i <= 20; i++ {
+		fmt.Fprintf(&lines, "line %d\n", i)
+	}
+	writeTestFile(t, dir, "a.txt", lines.String())
+	commitFile(t, dir, "b.txt", "b\n", "init")
+
+	p := newTestPlugin(t, dir)
+	p.Update(p.refresh()())
+	return p, git
+}
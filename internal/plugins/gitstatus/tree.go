@@ -35,8 +35,45 @@ type FileEntry struct {
 	OldPath    string // For renames
 	DiffStats  DiffStats
 	IsExpanded bool
-	IsFolder   bool         // True if this represents an untracked folder
-	Children   []*FileEntry // Files within this folder (when IsFolder is true)
+	IsFolder   bool            // True if this represents an untracked folder
+	Children   []*FileEntry    // Files within this folder (when IsFolder is true)
+	Submodule  *SubmoduleState // Set when the entry is a submodule
+	LFS        bool            // Stored in Git LFS by .gitattributes
+}
+
+// SubmoduleState is the porcelain v2 summary of a changed submodule.
+type SubmoduleState struct {
+	CommitChanged bool // Checked-out commit differs from the recorded one
+	Modified      bool // Tracked changes inside the submodule
+	Untracked     bool // Untracked files inside the submodule
+}
+
+// parseSubmoduleState parses the <sub> field of a porcelain v2 entry:
+// "N..." for a regular file, "S<c><m><u>" for a submodule.
+func parseSubmoduleState(sub string) *SubmoduleState {
+	if len(sub) != 4 || sub[0] != 'S' {
+		return nil
+	}
+	return &SubmoduleState{
+		CommitChanged: sub[1] == 'C',
+		Modified:      sub[2] == 'M',
+		Untracked:     sub[3] == 'U',
+	}
+}
+
+// Label summarizes the submodule state for the file list.
+func (s *SubmoduleState) Label() string {
+	var parts []string
+	if s.CommitChanged {
+		parts = append(parts, "new commits")
+	}
+	if s.Modified {
+		parts = append(parts, "modified")
+	}
+	if s.Untracked {
+		parts = append(parts, "untracked")
+	}
+	return strings.Join(parts, ", ")
 }
 
 // DiffStats holds the addition/deletion counts.
@@ -77,6 +114,7 @@ func (t *FileTree) Refresh() error {
 
 	// Get diff stats for all files
 	_ = temp.loadDiffStats() // Non-fatal: continue without stats
+	temp.markLFS()
 
 	// Group untracked files by folder
 	temp.groupUntrackedFolders()
@@ -161,7 +199,8 @@ func (t *FileTree) parseOrdinaryEntry(line string) *FileEntry {
 	path := fields[8]
 
 	entry := &FileEntry{
-		Path: path,
+		Path:      path,
+		Submodule: parseSubmoduleState(fields[2]),
 	}
 
 	// X = index status, Y = worktree status
@@ -199,9 +238,10 @@ func (t *FileTree) parseRenamedEntry(line string) *FileEntry {
 	path := fields[9]
 
 	entry := &FileEntry{
-		Path:   path,
-		Status: StatusRenamed,
-		Staged: true,
+		Path:      path,
+		Status:    StatusRenamed,
+		Staged:    true,
+		Submodule: parseSubmoduleState(fields[2]),
 	}
 
 	// Check if there are also worktree changes
@@ -221,9 +261,10 @@ func (t *FileTree) parseUnmergedEntry(line string) *FileEntry {
 	}
 
 	return &FileEntry{
-		Path:     fields[10],
-		Status:   StatusUnmerged,
-		Unstaged: true,
+		Path:      fields[10],
+		Status:    StatusUnmerged,
+		Unstaged:  true,
+		Submodule: parseSubmoduleState(fields[2]),
 	}
 }
 
@@ -238,22 +279,32 @@ func (t *FileTree) addEntry(entry *FileEntry) {
 		// File has both staged and unstaged changes
 		// Add a copy to modified list
 		modEntry := &FileEntry{
-			Path:     entry.Path,
-			Status:   entry.Status,
-			Unstaged: true,
+			Path:      entry.Path,
+			Status:    entry.Status,
+			Unstaged:  true,
+			Submodule: entry.Submodule,
 		}
 		t.Modified = append(t.Modified, modEntry)
 	}
 }
 
-// loadDiffStats loads +/- counts for all files.
+// numstatLine matches git diff --numstat output: <additions>\t<deletions>\t<path>
+var numstatLine = regexp.MustCompile(`^(\d+|-)\t(\d+|-)\t(.+)$`)
+
+// loadDiffStats loads +/- counts for all files. A side with no changes is
+// skipped, so a clean tree costs no diff at all.
 func (t *FileTree) loadDiffStats() error {
 	// Get stats for staged changes
-	if err := t.loadDiffStatsFor(true); err != nil {
-		return err
+	if len(t.Staged) > 0 {
+		if err := t.loadDiffStatsFor(true); err != nil {
+			return err
+		}
 	}
 
 	// Get stats for unstaged changes
+	if len(t.Modified) == 0 {
+		return nil
+	}
 	return t.loadDiffStatsFor(false)
 }
 
@@ -273,10 +324,8 @@ func (t *FileTree) loadDiffStatsFor(staged bool) error {
 
 	// Parse numstat output: <additions>\t<deletions>\t<path>
 	scanner := bufio.NewScanner(bytes.NewReader(output))
-	re := regexp.MustCompile(`^(\d+|-)\t(\d+|-)\t(.+)$`)
-
 	for scanner.Scan() {
-		matches := re.FindStringSubmatch(scanner.Text())
+		matches := numstatLine.FindStringSubmatch(scanner.Text())
 		if len(matches) != 4 {
 			continue
 		}
@@ -309,6 +358,28 @@ func (t *FileTree) loadDiffStatsFor(staged bool) error {
 	return nil
 }
 
+// markLFS flags the entries Git LFS stores, going by their filter
+// attribute so patterns in nested .gitattributes files count too.
+func (t *FileTree) markLFS() {
+	var entries []*FileEntry
+	var paths []string
+	for _, group := range [][]*FileEntry{t.Staged, t.Modified, t.Untracked} {
+		for _, entry := range group {
+			if entry.Submodule == nil {
+				entries = append(entries, entry)
+				paths = append(paths, entry.Path)
+			}
+		}
+	}
+	tracked, err := LFSTrackedPaths(t.workDir, paths)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		entry.LFS = tracked[entry.Path]
+	}
+}
+
 // TotalCount returns the total number of changed files.
 func (t *FileTree) TotalCount() int {
 	return len(t.Staged) + len(t.Modified) + len(t.Untracked)
@@ -424,6 +424,35 @@ func TestParseStatus_RenamedFile(t *testing.T) {
 	}
 }
 
+func TestParseStatus_Submodules(t *testing.T) {
+	tree := &FileTree{}
+
+	output := []byte("1 MM SCM. 160000 160000 160000 abc def lib\x00" +
+		"1 .M S.MU 160000 160000 160000 abc abc vendor/dep\x00" +
+		"1 .M N... 100644 100644 100644 abc abc main.go\x00")
+
+	if err := tree.parseStatus(output); err != nil {
+		t.Fatalf("parseStatus error: %v", err)
+	}
+	if len(tree.Staged) != 1 || len(tree.Modified) != 3 {
+		t.Fatalf("staged = %d, modified = %d", len(tree.Staged), len(tree.Modified))
+	}
+
+	lib := tree.Staged[0].Submodule
+	if lib == nil || !lib.CommitChanged || !lib.Modified || lib.Untracked {
+		t.Errorf("lib = %+v", lib)
+	}
+	if tree.Modified[0].Submodule != lib {
+		t.Error("the unstaged copy should keep the submodule state")
+	}
+	if got := tree.Modified[2].Submodule.Label(); got != "modified, untracked" {
+		t.Errorf("vendor/dep label = %q", got)
+	}
+	if tree.Modified[1].Submodule != nil {
+		t.Error("regular files are not submodules")
+	}
+}
+
 func TestAddEntry(t *testing.T) {
 	tree := &FileTree{}
 
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/app"
 	appmsg "github.com/guyghost/sidecar/internal/msg"
@@ -45,15 +46,8 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.cursor++
 			p.ensureCursorVisible()
 			if p.cursorOnCommit() {
-				commitIdx := p.selectedCommitIndex()
-				p.ensureCommitVisible(commitIdx)
-				// Trigger load-more when within 3 commits of end (only for unfiltered view)
-				var loadMoreCmd tea.Cmd
-				commits := p.activeCommits()
-				if !p.historyFilterActive && p.moreCommitsAvailable && commitIdx >= len(commits)-3 && !p.loadingMoreCommits {
-					loadMoreCmd = p.loadMoreCommits()
-				}
-				return p, tea.Batch(p.autoLoadCommitPreview(), loadMoreCmd)
+				p.ensureCommitVisible(p.selectedCommitIndex())
+				return p, tea.Batch(p.autoLoadCommitPreview(), p.prefetchCommits())
 			}
 			return p, p.autoLoadDiff()
 		}
@@ -85,14 +79,8 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.cursor = totalItems - 1
 			p.ensureCursorVisible()
 			if p.cursorOnCommit() {
-				commitIdx := p.selectedCommitIndex()
-				p.ensureCommitVisible(commitIdx)
-				// Trigger load-more when jumping to end
-				var loadMoreCmd tea.Cmd
-				if p.moreCommitsAvailable && commitIdx >= len(p.recentCommits)-3 && !p.loadingMoreCommits {
-					loadMoreCmd = p.loadMoreCommits()
-				}
-				return p, tea.Batch(p.autoLoadCommitPreview(), loadMoreCmd)
+				p.ensureCommitVisible(p.selectedCommitIndex())
+				return p, tea.Batch(p.autoLoadCommitPreview(), p.prefetchCommits())
 			}
 			return p, p.autoLoadDiff()
 		}
@@ -135,35 +123,9 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			if !entry.Staged {
 				stagedCount := len(p.tree.Staged)
 				totalEntries := len(entries)
-
-				// Handle folder entries - stage all children
-				if entry.IsFolder {
-					var firstErr error
-					for _, child := range entry.Children {
-						if err := p.tree.StageFile(child.Path); err != nil && firstErr == nil {
-							firstErr = err
-						}
-					}
-					if firstErr != nil {
-						return p, func() tea.Msg {
-							return app.ToastMsg{Message: "Stage failed: " + firstErr.Error(), Duration: 3 * time.Second, IsError: true}
-						}
-					}
-				} else {
-					if err := p.tree.StageFile(entry.Path); err != nil {
-						return p, func() tea.Msg {
-							return app.ToastMsg{Message: "Stage failed: " + err.Error(), Duration: 3 * time.Second, IsError: true}
-						}
-					}
-				}
-				// After staging, move cursor to first unstaged file position
-				newFirstUnstaged := stagedCount + 1
-				if newFirstUnstaged < totalEntries {
-					p.cursor = newFirstUnstaged
-				} else {
-					p.cursor = totalEntries - 1
-				}
-				return p, tea.Batch(p.refresh(), p.loadRecentCommits())
+				return p, p.stageChecked([]*FileEntry{entry}, func() tea.Cmd {
+					return p.stageEntry(entry, stagedCount, totalEntries)
+				})
 			}
 		}
 
@@ -181,21 +143,13 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		}
 
 	case "d":
+		// Submodules have no file diff; show their status instead
+		if entry := p.selectedSubmoduleEntry(); entry != nil {
+			return p, p.enterSubmodule(entry.Path)
+		}
 		// Open full-screen diff view for files
 		if !p.cursorOnCommit() && len(entries) > 0 && p.cursor < len(entries) {
-			entry := entries[p.cursor]
-			p.diffReturnMode = p.viewMode
-			p.viewMode = ViewModeDiff
-			p.diffFile = entry.Path
-			p.diffCommit = ""
-			p.diffCommitSubject = ""
-			p.diffCommitShortHash = ""
-			p.diffScroll = 0
-			p.diffLoaded = false
-			if entry.IsFolder {
-				return p, p.loadFullFolderDiff(entry)
-			}
-			return p, p.loadDiff(entry.Path, entry.Staged, entry.Status)
+			return p, p.openFileDiff(entries[p.cursor])
 		}
 		// For commits, focus the preview pane (same as l/right)
 		if p.cursorOnCommit() && p.previewCommit != nil {
@@ -218,21 +172,28 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 				// Reload diff for this folder
 				return p, p.autoLoadDiff()
 			}
+			if entry.Submodule != nil {
+				return p, p.enterSubmodule(entry.Path)
+			}
 			return p, p.openFile(entry.Path)
 		}
 
 	case "r":
 		p.pushError = "" // Clear any stale push error
-		return p, tea.Batch(p.refresh(), p.loadRecentCommits())
+		return p, tea.Batch(p.refresh(), p.loadRecentCommits(), p.loadLFSStatus())
+
+	case "R":
+		// Interactive rebase from the selected commit, or resume one in progress
+		return p, p.openRebase()
+
+	case "B":
+		// Start a bisect from the selected commits, or show the one in progress
+		return p, p.openBisect()
 
 	case "S":
 		// Stage all files
-		if err := p.tree.StageAll(); err != nil {
-			return p, func() tea.Msg {
-				return app.ToastMsg{Message: "Stage all failed: " + err.Error(), Duration: 3 * time.Second, IsError: true}
-			}
-		}
-		return p, tea.Batch(p.refresh(), p.loadRecentCommits())
+		unstaged := append(append([]*FileEntry{}, p.tree.Modified...), p.tree.Untracked...)
+		return p, p.stageChecked(unstaged, p.stageAllFiles)
 
 	case "U":
 		// Unstage all files
@@ -265,7 +226,10 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		if p.tree.HasStagedFiles() {
 			p.viewMode = ViewModeCommit
 			p.initCommitTextarea()
-			return p, nil
+			if state.GetCommitComposer() {
+				p.openComposer()
+			}
+			return p, p.loadCommitTemplate()
 		}
 
 	case "A":
@@ -276,16 +240,16 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.initCommitTextarea()
 			msg := getLastCommitMessage(p.repoRoot)
 			p.commitMessage.SetValue(msg)
+			if state.GetCommitComposer() {
+				p.openComposer()
+			}
 			return p, nil
 		}
 
 	case "P":
 		// Open push menu (following lazygit convention)
 		if p.canPush() && !p.pushInProgress {
-			p.pushMenuReturnMode = p.viewMode
-			p.viewMode = ViewModePushMenu
-			p.pushMenuFocus = 0
-			p.clearPushMenuModal()
+			return p, p.openPushMenu("", "")
 		}
 
 	case "y":
@@ -301,9 +265,9 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		}
 
 	case "o":
-		// Open commit in GitHub (when on commit in sidebar)
+		// Open commit on the forge (when on commit in sidebar)
 		if p.cursorOnCommit() {
-			return p, p.openCommitInGitHub()
+			return p, p.openCommitInBrowser()
 		}
 
 	case "D":
@@ -334,6 +298,9 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		// Apply latest stash (non-destructive, stash entry preserved)
 		return p, p.doStashApply()
 
+	case "w":
+		return p, p.openStashes()
+
 	case "b":
 		// Open branch picker
 		p.branchReturnMode = p.viewMode
@@ -366,14 +333,7 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	case "F":
 		// Clear all history filters
 		if p.historyFilterActive {
-			p.historyFilterAuthor = ""
-			p.historyFilterPath = ""
-			p.historyFilterActive = false
-			p.filteredCommits = nil
-			// Recompute graph for unfiltered commits
-			if p.showCommitGraph && len(p.recentCommits) > 0 {
-				p.commitGraphLines = ComputeGraphForCommits(p.recentCommits)
-			}
+			p.clearHistoryFilters()
 		}
 
 	case "p":
@@ -416,11 +376,59 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		}
 
 	case "esc":
-		// ESC clears search state (if any active search)
+		// ESC clears search state (if any active search), then commit marks
 		if p.historySearchState != nil && p.historySearchState.Committed {
 			p.clearSearchState()
 			return p, nil
 		}
+		if len(p.markedCommits) > 0 {
+			p.markedCommits = nil
+			return p, nil
+		}
+
+	case " ":
+		// Mark commit for cherry-pick/revert
+		p.toggleCommitMark()
+		return p, nil
+
+	case "C":
+		return p, p.openCherryPick()
+
+	case "t":
+		return p, p.doRevert()
+
+	case "X":
+		return p, p.openReset()
+
+	case "a":
+		// Tag the commit under the cursor
+		if commit := p.cursorCommit(); commit != nil {
+			return p, p.openCreateTag(commit)
+		}
+
+	case "T":
+		return p, p.openTags()
+
+	case "H":
+		return p, p.openReflog()
+
+	case "=":
+		return p, p.openComparePicker("", "")
+
+	case "M":
+		return p, p.openSubmodules()
+
+	case "E":
+		return p, p.openRemotes()
+
+	case "e":
+		return p, p.openPatchExport()
+
+	case "I":
+		return p, p.openPatchApply()
+
+	case "backspace":
+		return p, p.leaveSubmodule()
 
 	case "v":
 		// Toggle commit graph display (only when on commits)
@@ -428,8 +436,7 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.showCommitGraph = !p.showCommitGraph
 			_ = state.SetGitGraphEnabled(p.showCommitGraph)
 			if p.showCommitGraph {
-				commits := p.activeCommits()
-				p.commitGraphLines = ComputeGraphForCommits(commits)
+				p.updateCommitGraph(p.activeCommits())
 			}
 		}
 		return p, nil
@@ -446,6 +453,9 @@ func (p *Plugin) updateStatusDiffPane(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	}
 
 	switch msg.String() {
+	case "W":
+		p.openDiffOptions()
+
 	case "esc":
 		// Restore sidebar if hidden, then return to it
 		if !p.sidebarVisible {
@@ -544,16 +554,7 @@ func (p *Plugin) updateStatusDiffPane(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		// Open full-screen diff view for current file
 		entries := p.tree.AllEntries()
 		if len(entries) > 0 && p.cursor < len(entries) {
-			entry := entries[p.cursor]
-			p.diffReturnMode = p.viewMode
-			p.viewMode = ViewModeDiff
-			p.diffFile = entry.Path
-			p.diffCommit = ""
-			p.diffCommitSubject = ""
-			p.diffCommitShortHash = ""
-			p.diffScroll = 0
-			p.diffLoaded = false
-			return p, p.loadDiff(entry.Path, entry.Staged, entry.Status)
+			return p, p.openFileDiff(entries[p.cursor])
 		}
 	}
 
@@ -568,6 +569,9 @@ func (p *Plugin) updateCommitPreviewPane(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd
 	}
 
 	switch msg.String() {
+	case "W":
+		p.openDiffOptions()
+
 	case "esc", "h", "left":
 		// Return to sidebar
 		p.activePane = PaneSidebar
@@ -611,6 +615,7 @@ func (p *Plugin) updateCommitPreviewPane(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd
 			if c.IsMerge && len(c.ParentHashes) > 0 {
 				parentHash = c.ParentHashes[0]
 			}
+			p.diffCommitParent = parentHash
 			return p, p.loadCommitFileDiff(c.Hash, file.Path, parentHash)
 		}
 
@@ -633,8 +638,8 @@ func (p *Plugin) updateCommitPreviewPane(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd
 		return p, p.copyCommitIDToClipboard()
 
 	case "o":
-		// Open commit in GitHub
-		return p, p.openCommitInGitHub()
+		// Open commit on the forge
+		return p, p.openCommitInBrowser()
 
 	case "b":
 		// Open selected file in file browser
@@ -652,13 +657,18 @@ func (p *Plugin) closeDiffView() {
 	p.diffContent = ""
 	p.diffRaw = ""
 	p.parsedDiff = nil
+	p.diffImage = nil
 	p.diffLoaded = false
 	p.diffHorizOff = 0
 	p.diffCommit = ""
+	p.diffCommitParent = ""
 	p.diffCommitSubject = ""
 	p.diffCommitShortHash = ""
 	p.diffFile = ""
 	p.diffBackWidth = 0
+	p.diffHunksEnabled = false
+	p.diffHunkCursor = 0
+	p.diffLineMode = false
 	p.viewMode = p.diffReturnMode
 	if p.diffReturnMode == ViewModeStatus && p.previewCommit != nil {
 		p.activePane = PaneDiff
@@ -667,10 +677,53 @@ func (p *Plugin) closeDiffView() {
 
 // updateDiff handles key events in the diff view.
 func (p *Plugin) updateDiff(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	// Line selection mode: j/k extend the selection, esc leaves the mode
+	if p.diffLineMode && p.diffHunksAvailable() {
+		switch msg.String() {
+		case "esc", "V":
+			p.diffLineMode = false
+			return p, nil
+		case "j", "down":
+			p.moveDiffLineCursor(1)
+			return p, nil
+		case "k", "up":
+			p.moveDiffLineCursor(-1)
+			return p, nil
+		}
+	}
+
 	switch msg.String() {
 	case "esc", "q":
 		p.closeDiffView()
 
+	case "n":
+		p.moveDiffHunk(1)
+
+	case "N":
+		p.moveDiffHunk(-1)
+
+	case "V":
+		p.toggleDiffLineMode()
+
+	case "s":
+		return p, p.doHunkOp(hunkOpStage)
+
+	case "u":
+		return p, p.doHunkOp(hunkOpUnstage)
+
+	case "z":
+		return p, p.doHunkOp(hunkOpStash)
+
+	case "D":
+		// Discard selected hunk/lines (confirm modal)
+		if p.diffSelection() != nil && !p.diffStaged {
+			p.discardFile = &FileEntry{Path: p.diffFile, Status: p.diffFileStatus}
+			p.discardHunk = true
+			p.discardReturnMode = p.viewMode
+			p.viewMode = ViewModeConfirmDiscard
+			p.buildDiscardModal()
+		}
+
 	case "j", "down":
 		p.diffScroll++
 
@@ -746,6 +799,9 @@ func (p *Plugin) updateDiff(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.diffScroll = 0
 		}
 
+	case "W":
+		p.openDiffOptions()
+
 	case "O":
 		// Open file in file browser
 		if p.diffFile != "" {
@@ -781,6 +837,16 @@ func (p *Plugin) updateCommit(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			}
 		}
 		return p, nil
+
+	case "ctrl+g":
+		if !p.signing.Enabled {
+			p.toggleCommitSign()
+		}
+		return p, nil
+
+	case "ctrl+t":
+		p.toggleComposer()
+		return p, nil
 	}
 
 	wasAmend := p.commitAmend
@@ -792,7 +858,7 @@ func (p *Plugin) updateCommit(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		p.commitModalWidthCache = 0
 	}
 
-	if action == commitActionID && focusID == commitMessageID {
+	if action == commitActionID && commitTextFocused(focusID) {
 		return p, cmd
 	}
 
@@ -813,9 +879,12 @@ func (p *Plugin) updateCommit(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 
 // tryCommit attempts to execute the commit (or amend) if message is valid.
 func (p *Plugin) tryCommit() tea.Cmd {
-	message := strings.TrimSpace(p.commitMessage.Value())
+	message := p.commitMessageText()
 	if message == "" {
 		p.commitError = "Commit message cannot be empty"
+		if p.composer != nil {
+			p.commitError = "Commit subject cannot be empty"
+		}
 		return nil
 	}
 	p.commitInProgress = true
@@ -832,6 +901,10 @@ func (p *Plugin) updatePushMenu(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		return p, nil
 	}
 
+	if p.pushMenuModal.FocusedID() == pushMenuBranchID {
+		return p.updatePushMenuBranch(msg)
+	}
+
 	// Direct-execution shortcuts
 	switch msg.String() {
 	case "p":
@@ -840,6 +913,13 @@ func (p *Plugin) updatePushMenu(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		return p.executePushMenuAction(1)
 	case "u":
 		return p.executePushMenuAction(2)
+	case "left", "h":
+		return p, p.cyclePushRemote(-1)
+	case "right", "l":
+		return p, p.cyclePushRemote(1)
+	case "b":
+		p.pushMenuModal.SetFocus(pushMenuBranchID)
+		return p, textinput.Blink
 	}
 
 	switch msg.String() {
@@ -870,6 +950,25 @@ func (p *Plugin) updatePushMenu(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	return p, cmd
 }
 
+// updatePushMenuBranch handles key events while the push menu's branch
+// input has focus, comparing HEAD with each branch name typed.
+func (p *Plugin) updatePushMenuBranch(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if msg.String() == "esc" {
+		p.pushMenuModal.SetFocus(pushMenuListID)
+		return p, nil
+	}
+	before := p.pushMenuBranch.Value()
+	action, cmd := p.pushMenuModal.HandleKey(msg)
+	if action == pushMenuActionID {
+		return p.executePushMenuAction(p.pushMenuFocus)
+	}
+	if branch := p.pushMenuBranch.Value(); branch != before {
+		p.pushMenuTarget = nil
+		return p, tea.Batch(cmd, p.loadPushTarget(p.pushMenuRemote, strings.TrimSpace(branch)))
+	}
+	return p, cmd
+}
+
 // updatePullMenu handles key events in the pull menu.
 func (p *Plugin) updatePullMenu(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	p.ensurePullModal()
@@ -955,6 +1054,14 @@ func (p *Plugin) updatePullConflict(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	case "a":
 		// Abort merge/rebase
 		return p.abortPullConflict()
+	case "c":
+		if p.conflictContinuable() {
+			return p.continuePullConflict()
+		}
+	case "r":
+		if len(p.pullConflictFiles) > 0 {
+			return p.resolvePullConflict()
+		}
 	case "esc", "q":
 		// Dismiss modal (conflicts remain, user resolves manually)
 		return p.dismissPullConflict()
@@ -964,12 +1071,23 @@ func (p *Plugin) updatePullConflict(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	switch action {
 	case pullConflictAbortID:
 		return p.abortPullConflict()
+	case pullConflictContinueID:
+		return p.continuePullConflict()
+	case pullConflictResolveID:
+		return p.resolvePullConflict()
 	case "cancel", pullConflictDismissID:
 		return p.dismissPullConflict()
 	}
 	return p, cmd
 }
 
+// continuePullConflict continues a cherry-pick or revert once conflicts are staged.
+func (p *Plugin) continuePullConflict() (plugin.Plugin, tea.Cmd) {
+	p.viewMode = ViewModeStatus
+	p.clearPullConflictModal()
+	return p, p.doContinueCommitOp()
+}
+
 func (p *Plugin) abortPullConflict() (plugin.Plugin, tea.Cmd) {
 	p.viewMode = ViewModeStatus
 	p.clearPullConflictModal()
@@ -979,12 +1097,58 @@ func (p *Plugin) abortPullConflict() (plugin.Plugin, tea.Cmd) {
 func (p *Plugin) dismissPullConflict() (plugin.Plugin, tea.Cmd) {
 	p.viewMode = ViewModeStatus
 	p.pullConflictFiles = nil
+	p.pullConflictDir = ""
 	p.clearPullConflictModal()
 	return p, p.refresh()
 }
 
+// stageEntry stages a file, or all files of an untracked folder, then moves
+// the cursor to the first unstaged file of the entries listed before.
+func (p *Plugin) stageEntry(entry *FileEntry, stagedCount, totalEntries int) tea.Cmd {
+	// Handle folder entries - stage all children
+	if entry.IsFolder {
+		var firstErr error
+		for _, child := range entry.Children {
+			if err := p.tree.StageFile(child.Path); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if firstErr != nil {
+			return func() tea.Msg {
+				return app.ToastMsg{Message: "Stage failed: " + firstErr.Error(), Duration: 3 * time.Second, IsError: true}
+			}
+		}
+	} else {
+		if err := p.tree.StageFile(entry.Path); err != nil {
+			return func() tea.Msg {
+				return app.ToastMsg{Message: "Stage failed: " + err.Error(), Duration: 3 * time.Second, IsError: true}
+			}
+		}
+	}
+	// After staging, move cursor to first unstaged file position
+	newFirstUnstaged := stagedCount + 1
+	if newFirstUnstaged < totalEntries {
+		p.cursor = newFirstUnstaged
+	} else {
+		p.cursor = totalEntries - 1
+	}
+	return tea.Batch(p.refresh(), p.loadRecentCommits())
+}
+
+// stageAllFiles stages all modified and untracked files.
+func (p *Plugin) stageAllFiles() tea.Cmd {
+	if err := p.tree.StageAll(); err != nil {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "Stage all failed: " + err.Error(), Duration: 3 * time.Second, IsError: true}
+		}
+	}
+	return tea.Batch(p.refresh(), p.loadRecentCommits())
+}
+
 // executePushMenuAction executes the push menu action at the given index.
 func (p *Plugin) executePushMenuAction(idx int) (plugin.Plugin, tea.Cmd) {
+	custom := p.pushMenuCustomTarget()
+	remote, branch := p.pushMenuRemote, strings.TrimSpace(p.pushMenuBranch.Value())
 	p.viewMode = p.pushMenuReturnMode
 	p.pushInProgress = true
 	p.pushError = ""
@@ -1002,6 +1166,9 @@ func (p *Plugin) executePushMenuAction(idx int) (plugin.Plugin, tea.Cmd) {
 		}
 	}
 
+	if custom {
+		return p, p.doPushTo(remote, branch, idx == 1, idx == 2)
+	}
 	switch idx {
 	case 0:
 		return p, p.doPush(false)
@@ -1042,12 +1209,15 @@ func (p *Plugin) updateConfirmDiscard(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 // confirmDiscard executes the discard and closes the modal.
 func (p *Plugin) confirmDiscard() (plugin.Plugin, tea.Cmd) {
 	var cmd tea.Cmd
-	if p.discardFile != nil {
+	if p.discardHunk {
+		cmd = p.doHunkOp(hunkOpDiscard)
+	} else if p.discardFile != nil {
 		cmd = p.doDiscard(p.discardFile)
 	}
 	p.viewMode = p.discardReturnMode
 	p.discardFile = nil
 	p.discardModal = nil
+	p.discardHunk = false
 	return p, cmd
 }
 
@@ -1056,5 +1226,6 @@ func (p *Plugin) cancelDiscard() (plugin.Plugin, tea.Cmd) {
 	p.viewMode = p.discardReturnMode
 	p.discardFile = nil
 	p.discardModal = nil
+	p.discardHunk = false
 	return p, nil
 }
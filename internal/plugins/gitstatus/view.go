@@ -1,14 +1,25 @@
 package gitstatus
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/state"
 	"github.com/guyghost/sidecar/internal/styles"
 	"github.com/guyghost/sidecar/internal/ui"
 )
 
 
+// renderDiffView renders the full-screen diff, using the two-pane layout
+// when the sidebar is visible.
+func (p *Plugin) renderDiffView() string {
+	if p.sidebarVisible {
+		return p.renderDiffTwoPane()
+	}
+	return p.renderDiffModal()
+}
+
 // renderDiffModal renders the diff modal with panel border.
 func (p *Plugin) renderDiffModal() string {
 	// Calculate dimensions accounting for panel border (2) + padding (2)
@@ -67,23 +78,25 @@ func (p *Plugin) renderDiffModal() string {
 		}
 
 		highlighter := p.getHighlighter(p.diffFile)
-		if p.diffViewMode == DiffViewSideBySide {
+		if p.diffImage != nil {
+			sb.WriteString(p.renderImageDiff(p.diffImage, contentWidth, visibleLines))
+		} else if p.diffViewMode == DiffViewSideBySide {
 			parsed := p.parsedDiff
 			if parsed == nil {
 				parsed, _ = ParseUnifiedDiff(p.diffRaw)
 			}
 			if parsed != nil {
-				sb.WriteString(RenderSideBySide(parsed, contentWidth, p.diffScroll, visibleLines, p.diffHorizOff, highlighter, p.diffWrapEnabled))
+				sb.WriteString(RenderSideBySideSelection(parsed, p.diffSelection(), contentWidth, p.diffScroll, visibleLines, p.diffHorizOff, highlighter, p.diffWrapEnabled))
 			} else {
 				sb.WriteString(styles.Muted.Render("Unable to parse diff for side-by-side view"))
 			}
 		} else {
 			// Unified view
 			if p.parsedDiff != nil {
-				sb.WriteString(RenderLineDiff(p.parsedDiff, contentWidth, p.diffScroll, visibleLines, p.diffHorizOff, highlighter, p.diffWrapEnabled))
+				sb.WriteString(RenderLineDiffSelection(p.parsedDiff, p.diffSelection(), contentWidth, p.diffScroll, visibleLines, p.diffHorizOff, highlighter, p.diffWrapEnabled))
 			} else {
 				// Fall back to raw diff rendering
-				lines := strings.Split(p.diffRaw, "\n")
+				lines := strings.Split(StripDiffColor(p.diffRaw), "\n")
 				start := p.diffScroll
 				if start >= len(lines) {
 					start = 0
@@ -214,17 +227,19 @@ func (p *Plugin) renderFullDiffContent(visibleHeight int) string {
 	// Render diff based on view mode
 	highlighter := p.getHighlighter(p.diffFile)
 	var diffContent string
-	if p.diffViewMode == DiffViewSideBySide {
+	if p.diffImage != nil {
+		diffContent = p.renderImageDiff(p.diffImage, diffWidth, contentHeight)
+	} else if p.diffViewMode == DiffViewSideBySide {
 		parsed := p.parsedDiff
 		if parsed == nil {
 			parsed, _ = ParseUnifiedDiff(p.diffRaw)
 		}
 		if parsed != nil {
-			diffContent = RenderSideBySide(parsed, diffWidth, p.diffScroll, contentHeight, p.diffHorizOff, highlighter, p.diffWrapEnabled)
+			diffContent = RenderSideBySideSelection(parsed, p.diffSelection(), diffWidth, p.diffScroll, contentHeight, p.diffHorizOff, highlighter, p.diffWrapEnabled)
 		}
 	} else {
 		if p.parsedDiff != nil {
-			diffContent = RenderLineDiff(p.parsedDiff, diffWidth, p.diffScroll, contentHeight, p.diffHorizOff, highlighter, p.diffWrapEnabled)
+			diffContent = RenderLineDiffSelection(p.parsedDiff, p.diffSelection(), diffWidth, p.diffScroll, contentHeight, p.diffHorizOff, highlighter, p.diffWrapEnabled)
 		}
 	}
 
@@ -283,6 +298,15 @@ func (p *Plugin) renderDiffBreadcrumb(maxWidth int, scrollIndicator string) (str
 	if p.diffViewMode == DiffViewSideBySide {
 		viewModeStr = "side-by-side"
 	}
+	if p.diffHunksAvailable() {
+		viewModeStr += fmt.Sprintf(" · hunk %d/%d", p.diffHunkCursor+1, len(p.parsedDiff.Hunks))
+		if p.diffLineMode {
+			viewModeStr += " · lines"
+		}
+	}
+	if opts := state.GetDiffOptions(p.diffOptionsView()).Summary(); opts != "" {
+		viewModeStr += " · " + opts
+	}
 	modePart := styles.Muted.Render("[" + viewModeStr + "]")
 	modeWidth := lipgloss.Width(modePart) + lipgloss.Width(scrollIndicator)
 
@@ -361,9 +361,18 @@ func (p *Plugin) StartAgent(wt *Worktree, agentType AgentType) tea.Cmd {
 	return p.StartAgentWithOptions(wt, agentType, false, nil)
 }
 
+// agentRegistry returns the agents configured for the plugin, or the
+// built-ins before Init.
+func (p *Plugin) agentRegistry() *AgentRegistry {
+	if p.registry == nil {
+		return DefaultAgentRegistry()
+	}
+	return p.registry
+}
+
 // getAgentCommand returns the command to start an agent.
-func getAgentCommand(agentType AgentType) string {
-	if cmd, ok := AgentCommands[agentType]; ok {
+func (p *Plugin) getAgentCommand(agentType AgentType) string {
+	if cmd := p.agentRegistry().Command(agentType); cmd != "" {
 		return cmd
 	}
 	return "claude" // Default to claude
@@ -372,11 +381,11 @@ func getAgentCommand(agentType AgentType) string {
 // buildAgentCommand builds the agent command with optional skip permissions and task context.
 // If there's task context, it writes a launcher script to avoid shell escaping issues.
 func (p *Plugin) buildAgentCommand(agentType AgentType, wt *Worktree, skipPerms bool, prompt *Prompt) string {
-	baseCmd := getAgentCommand(agentType)
+	baseCmd := p.getAgentCommand(agentType)
 
 	// Apply skip permissions flag if requested
 	if skipPerms {
-		if flag := SkipPermissionsFlags[agentType]; flag != "" {
+		if flag := p.agentRegistry().SkipPermsFlag(agentType); flag != "" {
 			baseCmd = baseCmd + " " + flag
 		}
 	}
@@ -428,42 +437,39 @@ if ! command -v node &>/dev/null; then
 fi
 `
 
+	spec := p.agentRegistry().Get(agentType)
+	if spec == nil {
+		spec = &AgentSpec{PromptStyle: PromptArg}
+	}
+
 	// Use a heredoc with quoted delimiter to prevent ALL shell expansion.
 	// This safely handles backticks, $variables, quotes, newlines, etc.
-	// The prompt is embedded directly in the script, not read from a file.
-	var script string
-	switch agentType {
-	case AgentAider:
-		// aider uses --message flag
-		script = fmt.Sprintf(`#!/bin/bash
-%s
-%s --message "$(cat <<'SIDECAR_PROMPT_EOF'
+	var run string
+	switch spec.PromptStyle {
+	case PromptStdin:
+		run = fmt.Sprintf(`%s <<'SIDECAR_PROMPT_EOF'
 %s
-SIDECAR_PROMPT_EOF
-)"
-rm -f %q
-`, shellSetup, baseCmd, prompt, launcherFile)
-	case AgentOpenCode:
-		// opencode uses 'run' subcommand
-		script = fmt.Sprintf(`#!/bin/bash
-%s
-%s run "$(cat <<'SIDECAR_PROMPT_EOF'
+SIDECAR_PROMPT_EOF`, baseCmd, prompt)
+	case PromptFile:
+		// The agent reads the prompt from a file, removed once it exits. It
+		// lives outside the worktree so the agent cannot commit it.
+		promptFile, err := writeAgentPromptFile(prompt)
+		if err != nil {
+			return "", err
+		}
+		run = fmt.Sprintf("%s %s\nrm -f %q", baseCmd, spec.promptArgs(fmt.Sprintf("%q", promptFile)), promptFile)
+	default:
+		// The prompt is embedded directly in the script, not read from a file
+		run = baseCmd + " " + spec.promptArgs(fmt.Sprintf(`"$(cat <<'SIDECAR_PROMPT_EOF'
 %s
 SIDECAR_PROMPT_EOF
-)"
-rm -f %q
-`, shellSetup, baseCmd, prompt, launcherFile)
-	default:
-		// Most agents (claude, codex, gemini, cursor) take prompt as positional argument
-		script = fmt.Sprintf(`#!/bin/bash
+)"`, prompt))
+	}
+	script := fmt.Sprintf(`#!/bin/bash
 %s
-%s "$(cat <<'SIDECAR_PROMPT_EOF'
 %s
-SIDECAR_PROMPT_EOF
-)"
 rm -f %q
-`, shellSetup, baseCmd, prompt, launcherFile)
-	}
+`, shellSetup, run, launcherFile)
 
 	if err := os.WriteFile(launcherFile, []byte(script), 0700); err != nil {
 		return "", err
@@ -710,6 +716,7 @@ func (p *Plugin) handlePollAgent(worktreeName string) tea.Cmd {
 	sessionName := wt.Agent.TmuxSession
 	wtPath := wt.Path
 	agentType := wt.Agent.Type
+	agentSpec := p.agentRegistry().Get(agentType)
 	maxBytes := p.tmuxCaptureMaxBytes
 	outputBuf := wt.Agent.OutputBuf
 	currentStatus := wt.Status
@@ -807,7 +814,7 @@ func (p *Plugin) handlePollAgent(worktreeName string) tea.Cmd {
 		status := currentStatus
 		waitingFor := ""
 		if !interactiveCapture {
-			status = detectStatus(output)
+			status = detectAgentStatus(agentSpec, output)
 			if status == StatusWaiting {
 				waitingFor = extractPrompt(output)
 			}
@@ -1010,6 +1017,48 @@ func tailUTF8Safe(s string, n int) string {
 	return s[start:]
 }
 
+// writeAgentPromptFile writes prompt to a new private temp file and
+// returns its path.
+func writeAgentPromptFile(prompt string) (string, error) {
+	f, err := os.CreateTemp("", "sidecar-prompt-*.md")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(prompt + "\n"); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// detectAgentStatus determines agent status from captured output, checking
+// the agent's own patterns before the common ones. spec may be nil.
+func detectAgentStatus(spec *AgentSpec, output string) WorktreeStatus {
+	if spec != nil {
+		textLower := strings.ToLower(tailUTF8Safe(output, statusCheckBytes))
+		for _, check := range []struct {
+			patterns []string
+			status   WorktreeStatus
+		}{
+			{spec.Status.Waiting, StatusWaiting},
+			{spec.Status.Done, StatusDone},
+			{spec.Status.Error, StatusError},
+		} {
+			for _, pattern := range check.patterns {
+				if pattern != "" && strings.Contains(textLower, strings.ToLower(pattern)) {
+					return check.status
+				}
+			}
+		}
+	}
+	return detectStatus(output)
+}
+
 // detectStatus determines agent status from captured output.
 // Optimized to avoid unnecessary string allocations.
 func detectStatus(output string) WorktreeStatus {
@@ -0,0 +1,292 @@
+package workspace
+
+import (
+	"strings"
+
+	"github.com/guyghost/sidecar/internal/config"
+)
+
+// PromptStyle is how an agent receives the prompt it starts with.
+type PromptStyle string
+
+const (
+	PromptArg   PromptStyle = "arg"   // Command-line argument
+	PromptStdin PromptStyle = "stdin" // Standard input
+	PromptFile  PromptStyle = "file"  // File whose path is a command-line argument
+)
+
+// Placeholders in agent command templates.
+const (
+	promptPlaceholder  = "{{prompt}}"
+	sessionPlaceholder = "{{session}}"
+)
+
+// StatusPatterns are output snippets that reveal an agent's status. They
+// are matched case-insensitively, before the patterns common to all agents.
+type StatusPatterns struct {
+	Waiting []string
+	Done    []string
+	Error   []string
+}
+
+// AgentSpec describes a coding agent that workspaces and shells can run.
+type AgentSpec struct {
+	ID          AgentType
+	Name        string      // Shown in agent pickers
+	Abbrev      string      // Short label for shell entries
+	Command     string      // Launch command, e.g. "cursor-agent"
+	SkipPerms   string      // Flag that auto-approves all actions; empty if none
+	PromptStyle PromptStyle // How the starting prompt is passed
+	PromptArgs  string      // Arguments for arg and file styles; {{prompt}} is the prompt or file path
+	Resume      string      // Resume command; {{session}} is the session ID
+	Status      StatusPatterns
+	AdapterID   string // Conversation adapter that reads the agent's sessions
+	Hidden      bool   // Launchable but left out of agent pickers
+}
+
+// ResumeCommand returns the command that resumes sessionID, or "" when
+// the agent cannot resume sessions.
+func (s *AgentSpec) ResumeCommand(sessionID string) string {
+	if s.Resume == "" || sessionID == "" {
+		return ""
+	}
+	return strings.ReplaceAll(s.Resume, sessionPlaceholder, sessionID)
+}
+
+// promptArgs returns the arguments that pass value, the prompt or the
+// prompt file's path, already quoted for the shell.
+func (s *AgentSpec) promptArgs(value string) string {
+	args := s.PromptArgs
+	if args == "" {
+		args = promptPlaceholder
+	}
+	if !strings.Contains(args, promptPlaceholder) {
+		args += " " + promptPlaceholder
+	}
+	return strings.Replace(args, promptPlaceholder, value, 1)
+}
+
+// DefaultAgents returns the built-in agents in picker order.
+func DefaultAgents() []AgentSpec {
+	return []AgentSpec{
+		{
+			ID: AgentClaude, Name: "Claude Code", Abbrev: "Claude", Command: "claude",
+			SkipPerms: "--dangerously-skip-permissions", PromptStyle: PromptArg,
+			Resume: "claude --resume {{session}}", AdapterID: "claude-code",
+		},
+		{
+			ID: AgentCodex, Name: "Codex CLI", Abbrev: "Codex", Command: "codex",
+			SkipPerms: "--dangerously-bypass-approvals-and-sandbox", PromptStyle: PromptArg,
+			Resume: "codex resume {{session}}", AdapterID: "codex",
+		},
+		{
+			ID: AgentGemini, Name: "Gemini CLI", Abbrev: "Gemini", Command: "gemini",
+			SkipPerms: "--yolo", PromptStyle: PromptArg,
+			Resume: "gemini --resume {{session}}", AdapterID: "gemini-cli",
+		},
+		{
+			ID: AgentCursor, Name: "Cursor Agent", Abbrev: "Cursor", Command: "cursor-agent",
+			SkipPerms: "-f", PromptStyle: PromptArg,
+			Resume: "cursor-agent --resume {{session}}", AdapterID: "cursor-cli",
+		},
+		{
+			ID: AgentOpenCode, Name: "OpenCode", Abbrev: "OpenCode", Command: "opencode",
+			PromptStyle: PromptArg, PromptArgs: "run {{prompt}}",
+			Resume: "opencode --continue -s {{session}}", AdapterID: "opencode",
+		},
+		// Hidden: Aider for worktrees that chose it before, Amp to resume its sessions
+		{
+			ID: AgentAider, Name: "Aider", Abbrev: "Aider", Command: "aider",
+			SkipPerms: "--yes", PromptStyle: PromptArg, PromptArgs: "--message {{prompt}}",
+			Hidden: true,
+		},
+		{
+			ID: "amp", Name: "Amp", Abbrev: "Amp", Command: "amp",
+			PromptStyle: PromptArg, Resume: "amp --resume {{session}}", AdapterID: "amp",
+			Hidden: true,
+		},
+	}
+}
+
+// AgentRegistry holds the agents workspaces and shells can run: the
+// built-ins, changed and extended by the config.
+type AgentRegistry struct {
+	agents []*AgentSpec
+	byID   map[AgentType]*AgentSpec
+}
+
+// defaultAgentRegistry serves callers without a config.
+var defaultAgentRegistry = NewAgentRegistry(nil)
+
+// DefaultAgentRegistry returns a registry of the built-in agents.
+func DefaultAgentRegistry() *AgentRegistry {
+	return defaultAgentRegistry
+}
+
+// AgentRegistryFromConfig returns the registry for cfg's agents. A nil cfg
+// gives the built-ins.
+func AgentRegistryFromConfig(cfg *config.Config) *AgentRegistry {
+	if cfg == nil || len(cfg.Plugins.Workspace.Agents) == 0 {
+		return defaultAgentRegistry
+	}
+	return NewAgentRegistry(cfg.Plugins.Workspace.Agents)
+}
+
+// NewAgentRegistry builds a registry from the built-in agents and configs.
+// A config with a built-in ID replaces the fields it sets; any other ID
+// adds an agent after the built-ins, provided it has a command.
+func NewAgentRegistry(configs []config.AgentConfig) *AgentRegistry {
+	r := &AgentRegistry{byID: make(map[AgentType]*AgentSpec)}
+	for _, spec := range DefaultAgents() {
+		r.add(spec)
+	}
+	for _, c := range configs {
+		id := AgentType(c.ID)
+		if id == AgentNone || id == AgentShell {
+			continue // Reserved for "no agent" and project shells
+		}
+		spec, ok := r.byID[id]
+		if !ok {
+			if c.Command == "" {
+				continue
+			}
+			spec = r.add(AgentSpec{ID: id, Name: c.ID, Abbrev: c.ID, PromptStyle: PromptArg})
+		}
+		spec.apply(c)
+	}
+	return r
+}
+
+// add appends spec to the registry and returns the stored copy.
+func (r *AgentRegistry) add(spec AgentSpec) *AgentSpec {
+	s := &spec
+	r.agents = append(r.agents, s)
+	r.byID[s.ID] = s
+	return s
+}
+
+// apply sets the fields c sets.
+func (s *AgentSpec) apply(c config.AgentConfig) {
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&s.Name, c.Name)
+	set(&s.Abbrev, c.Abbrev)
+	set(&s.Command, c.Command)
+	set(&s.SkipPerms, c.SkipPermissions)
+	set(&s.PromptArgs, c.PromptArgs)
+	set(&s.Resume, c.ResumeCommand)
+	set(&s.AdapterID, c.AdapterID)
+	if c.PromptStyle != "" {
+		s.PromptStyle = PromptStyle(c.PromptStyle)
+	}
+	if c.WaitingPatterns != nil {
+		s.Status.Waiting = c.WaitingPatterns
+	}
+	if c.DonePatterns != nil {
+		s.Status.Done = c.DonePatterns
+	}
+	if c.ErrorPatterns != nil {
+		s.Status.Error = c.ErrorPatterns
+	}
+	if c.Hidden != nil {
+		s.Hidden = *c.Hidden
+	}
+}
+
+// Get returns the agent with id, or nil.
+func (r *AgentRegistry) Get(id AgentType) *AgentSpec {
+	return r.byID[id]
+}
+
+// Order returns the agents offered when creating a worktree, with
+// AgentNone last.
+func (r *AgentRegistry) Order() []AgentType {
+	return append(r.visible(), AgentNone)
+}
+
+// ShellOrder returns the agents offered when creating a shell, with
+// AgentNone first since shells default to no agent.
+func (r *AgentRegistry) ShellOrder() []AgentType {
+	return append([]AgentType{AgentNone}, r.visible()...)
+}
+
+// visible returns the IDs of the agents not hidden from pickers.
+func (r *AgentRegistry) visible() []AgentType {
+	var ids []AgentType
+	for _, s := range r.agents {
+		if !s.Hidden {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+// DisplayName returns the name shown for id, which may be AgentNone or
+// AgentShell. Unknown IDs are shown as they are.
+func (r *AgentRegistry) DisplayName(id AgentType) string {
+	switch id {
+	case AgentNone:
+		return "None (attach only)"
+	case AgentShell:
+		return "Project Shell"
+	}
+	if s := r.byID[id]; s != nil && s.Name != "" {
+		return s.Name
+	}
+	return string(id)
+}
+
+// Abbrev returns the short label for id, or id itself when it has none.
+func (r *AgentRegistry) Abbrev(id AgentType) string {
+	if s := r.byID[id]; s != nil && s.Abbrev != "" {
+		return s.Abbrev
+	}
+	return string(id)
+}
+
+// Command returns the launch command for id, or "" for an unknown agent.
+func (r *AgentRegistry) Command(id AgentType) string {
+	if s := r.byID[id]; s != nil {
+		return s.Command
+	}
+	return ""
+}
+
+// SkipPermsFlag returns the auto-approve flag for id, or "" if it has none.
+func (r *AgentRegistry) SkipPermsFlag(id AgentType) string {
+	if s := r.byID[id]; s != nil {
+		return s.SkipPerms
+	}
+	return ""
+}
+
+// ForAdapter returns the first agent, in picker order, whose sessions the
+// conversation adapter adapterID reads.
+func (r *AgentRegistry) ForAdapter(adapterID string) (*AgentSpec, bool) {
+	if adapterID == "" {
+		return nil, false
+	}
+	for _, s := range r.agents {
+		if s.AdapterID == adapterID {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// ResumeCommand returns the command that resumes a session of the
+// conversation adapter adapterID, or "" when no agent can.
+func (r *AgentRegistry) ResumeCommand(adapterID, sessionID string) string {
+	if adapterID == "" {
+		return ""
+	}
+	for _, s := range r.agents {
+		if s.AdapterID == adapterID && s.Resume != "" {
+			return s.ResumeCommand(sessionID)
+		}
+	}
+	return ""
+}
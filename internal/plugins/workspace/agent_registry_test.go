@@ -0,0 +1,149 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/guyghost/sidecar/internal/config"
+)
+
+func TestDefaultAgentRegistry(t *testing.T) {
+	r := DefaultAgentRegistry()
+
+	wantOrder := []AgentType{AgentClaude, AgentCodex, AgentGemini, AgentCursor, AgentOpenCode, AgentNone}
+	if got := r.Order(); !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("Order() = %v, want %v", got, wantOrder)
+	}
+	if got := r.ShellOrder(); got[0] != AgentNone || len(got) != len(wantOrder) {
+		t.Errorf("ShellOrder() = %v, want AgentNone first", got)
+	}
+
+	// Hidden agents can still be started
+	if got := r.Command(AgentAider); got != "aider" {
+		t.Errorf("Command(aider) = %q, want aider", got)
+	}
+	if got := r.DisplayName(AgentNone); got != "None (attach only)" {
+		t.Errorf("DisplayName(none) = %q", got)
+	}
+	if got := r.Abbrev("unknown"); got != "unknown" {
+		t.Errorf("Abbrev(unknown) = %q, want the ID", got)
+	}
+	if got := r.ResumeCommand("amp", "T-1"); got != "amp --resume T-1" {
+		t.Errorf("ResumeCommand(amp) = %q", got)
+	}
+	if got := r.ResumeCommand("", "T-1"); got != "" {
+		t.Errorf("ResumeCommand with no adapter = %q, want empty", got)
+	}
+}
+
+func TestNewAgentRegistry_Config(t *testing.T) {
+	hidden := true
+	r := NewAgentRegistry([]config.AgentConfig{
+		{ID: "claude", Command: "/opt/bin/claude", WaitingPatterns: []string{"Ready>"}},
+		{ID: "gemini", Hidden: &hidden},
+		{
+			ID: "goose", Name: "Goose", Command: "goose run", PromptStyle: "file",
+			PromptArgs: "--instructions {{prompt}}", ResumeCommand: "goose session -r {{session}}",
+			AdapterID: "goose",
+		},
+		{ID: "nocommand"},
+		{ID: "shell", Command: "bash"},
+	})
+
+	claude := r.Get(AgentClaude)
+	if claude.Command != "/opt/bin/claude" || claude.SkipPerms != "--dangerously-skip-permissions" {
+		t.Errorf("override should keep unset fields: %+v", claude)
+	}
+	wantOrder := []AgentType{AgentClaude, AgentCodex, AgentCursor, AgentOpenCode, "goose", AgentNone}
+	if got := r.Order(); !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("Order() = %v, want %v", got, wantOrder)
+	}
+	if r.Get("nocommand") != nil {
+		t.Error("a new agent needs a command")
+	}
+	if r.Get(AgentShell) != nil {
+		t.Error("reserved IDs cannot be agents")
+	}
+	if got := r.ResumeCommand("goose", "s1"); got != "goose session -r s1" {
+		t.Errorf("ResumeCommand(goose) = %q", got)
+	}
+	if spec, ok := r.ForAdapter("goose"); !ok || spec.ID != "goose" {
+		t.Errorf("ForAdapter(goose) = %v, %v", spec, ok)
+	}
+
+	if got := detectAgentStatus(claude, "output\nready> "); got != StatusWaiting {
+		t.Errorf("agent pattern: status = %v, want waiting", got)
+	}
+	if got := detectAgentStatus(claude, "error: boom"); got != StatusError {
+		t.Errorf("common pattern: status = %v, want error", got)
+	}
+}
+
+func TestAgentRegistryFromConfig(t *testing.T) {
+	if AgentRegistryFromConfig(nil) != DefaultAgentRegistry() {
+		t.Error("nil config should give the built-ins")
+	}
+	cfg := config.Default()
+	cfg.Plugins.Workspace.Agents = []config.AgentConfig{{ID: "codex", Name: "Codex"}}
+	if got := AgentRegistryFromConfig(cfg).DisplayName(AgentCodex); got != "Codex" {
+		t.Errorf("DisplayName(codex) = %q, want Codex", got)
+	}
+}
+
+func TestWriteAgentLauncher_PromptStyles(t *testing.T) {
+	p := &Plugin{registry: NewAgentRegistry([]config.AgentConfig{
+		{ID: "piped", Command: "piped", PromptStyle: "stdin"},
+		{ID: "filed", Command: "filed", PromptStyle: "file", PromptArgs: "--task {{prompt}} --go"},
+	})}
+	dir := t.TempDir()
+	prompt := "Fix `$HOME` handling"
+
+	readLauncher := func() string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, ".sidecar-start.sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	if _, err := p.writeAgentLauncher(dir, "piped", "piped", prompt); err != nil {
+		t.Fatal(err)
+	}
+	if script := readLauncher(); !strings.Contains(script, "piped <<'SIDECAR_PROMPT_EOF'\n"+prompt+"\nSIDECAR_PROMPT_EOF\n") {
+		t.Errorf("stdin launcher should pipe the prompt:\n%s", script)
+	}
+
+	if _, err := p.writeAgentLauncher(dir, "filed", "filed", prompt); err != nil {
+		t.Fatal(err)
+	}
+	script := readLauncher()
+	match := regexp.MustCompile(`filed --task "([^"]+)" --go`).FindStringSubmatch(script)
+	if match == nil {
+		t.Fatalf("file launcher should pass the prompt file:\n%s", script)
+	}
+	promptFile := match[1]
+	t.Cleanup(func() { _ = os.Remove(promptFile) })
+	if strings.HasPrefix(promptFile, dir) {
+		t.Errorf("prompt file %s should be outside the worktree, where the agent cannot commit it", promptFile)
+	}
+	data, err := os.ReadFile(promptFile)
+	if err != nil || strings.TrimSpace(string(data)) != prompt {
+		t.Fatalf("prompt file = %q, %v", data, err)
+	}
+	if !strings.Contains(script, fmt.Sprintf("rm -f %q", promptFile)) {
+		t.Errorf("launcher should remove the prompt file:\n%s", script)
+	}
+
+	if _, err := p.writeAgentLauncher(dir, AgentOpenCode, "opencode", prompt); err != nil {
+		t.Fatal(err)
+	}
+	if script := readLauncher(); !strings.Contains(script, `opencode run "$(cat <<'SIDECAR_PROMPT_EOF'`) {
+		t.Errorf("arg launcher should keep the prompt arguments:\n%s", script)
+	}
+}
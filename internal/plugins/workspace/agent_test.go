@@ -142,7 +142,7 @@ func TestGetAgentCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(string(tt.agentType), func(t *testing.T) {
-			result := getAgentCommand(tt.agentType)
+			result := (&Plugin{}).getAgentCommand(tt.agentType)
 			if result != tt.expected {
 				t.Errorf("getAgentCommand(%q) = %q, want %q", tt.agentType, result, tt.expected)
 			}
@@ -586,7 +586,7 @@ func TestBuildAgentCommand(t *testing.T) {
 			result := p.buildAgentCommand(tt.agentType, wt, tt.skipPerms, nil)
 
 			// Check base command
-			baseCmd := getAgentCommand(tt.agentType)
+			baseCmd := p.getAgentCommand(tt.agentType)
 			if !strings.HasPrefix(result, baseCmd) {
 				t.Errorf("command should start with %q, got %q", baseCmd, result)
 			}
@@ -598,10 +598,8 @@ func TestBuildAgentCommand(t *testing.T) {
 				}
 			} else if tt.skipPerms {
 				// If skipPerms but no wantFlag, ensure no flag was added
-				for agent, flag := range SkipPermissionsFlags {
-					if agent == tt.agentType && flag != "" {
-						t.Errorf("command should not contain flag for %s when wantFlag is empty", tt.agentType)
-					}
+				if DefaultAgentRegistry().SkipPermsFlag(tt.agentType) != "" {
+					t.Errorf("command should not contain flag for %s when wantFlag is empty", tt.agentType)
 				}
 			}
 		})
@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
 )
 
 // openInBrowser opens the URL in the default browser.
@@ -40,3 +41,17 @@ func (p *Plugin) openInGitTab(wt *Worktree) tea.Cmd {
 		app.FocusPlugin("git-status"),
 	)
 }
+
+// openCompare opens the git compare view for the worktree's branch against
+// the branch it was created from. Branches are shared between worktrees, so
+// the comparison runs from the current repo without switching.
+func (p *Plugin) openCompare(wt *Worktree) tea.Cmd {
+	if wt == nil || wt.Branch == "" {
+		return nil
+	}
+	base := resolveBaseBranch(wt)
+	return tea.Sequence(
+		app.FocusPlugin("git-status"),
+		func() tea.Msg { return gitstatus.OpenCompareMsg{Base: base, Head: wt.Branch} },
+	)
+}
@@ -81,6 +81,23 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "cancel", Name: "Cancel", Description: "Close file picker", Context: "workspace-file-picker", Priority: 1},
 			{ID: "select", Name: "Jump", Description: "Jump to selected file", Context: "workspace-file-picker", Priority: 2},
 		}
+	case ViewModeDiffOptions:
+		return []plugin.Command{
+			{ID: "next-value", Name: "Change", Description: "Next value", Context: "workspace-diff-options", Priority: 1},
+			{ID: "reset-options", Name: "Reset", Description: "Restore git's defaults", Context: "workspace-diff-options", Priority: 2},
+			{ID: "close", Name: "Close", Description: "Close diff options", Context: "workspace-diff-options", Priority: 3},
+		}
+	case ViewModeConflicts:
+		return []plugin.Command{
+			{ID: "take-ours", Name: "Ours", Description: "Keep our side of the hunk", Context: "workspace-conflicts", Priority: 1},
+			{ID: "take-theirs", Name: "Theirs", Description: "Keep their side of the hunk", Context: "workspace-conflicts", Priority: 1},
+			{ID: "stage-resolved", Name: "Stage", Description: "Stage the resolved file", Context: "workspace-conflicts", Priority: 1},
+			{ID: "continue-conflict", Name: "Continue", Description: "Continue the merge or rebase", Context: "workspace-conflicts", Priority: 1},
+			{ID: "take-both", Name: "Both", Description: "Keep both sides, ours first", Context: "workspace-conflicts", Priority: 2},
+			{ID: "edit-conflict", Name: "Edit", Description: "Edit the merged file", Context: "workspace-conflicts", Priority: 2},
+			{ID: "cancel", Name: "Close", Description: "Back to the merge workflow", Context: "workspace-conflicts", Priority: 2},
+			{ID: "abort-conflict", Name: "Abort", Description: "Abort the operation", Context: "workspace-conflicts", Priority: 3},
+		}
 	default:
 		// View toggle label changes based on current mode
 		viewToggleName := "Kanban"
@@ -109,6 +126,7 @@ func (p *Plugin) Commands() []plugin.Command {
 						diffViewName = "Unified"
 					}
 					cmds = append(cmds, plugin.Command{ID: "toggle-diff-view", Name: diffViewName, Description: "Toggle unified/side-by-side diff", Context: "workspace-preview", Priority: 5})
+					cmds = append(cmds, plugin.Command{ID: "diff-options", Name: "Options", Description: "Whitespace, context and move detection", Context: "workspace-preview", Priority: 9})
 					// Add file navigation commands when viewing diff with multiple files
 					if p.multiFileDiff != nil && len(p.multiFileDiff.Files) > 1 {
 						cmds = append(cmds,
@@ -217,6 +235,7 @@ func (p *Plugin) Commands() []plugin.Command {
 				plugin.Command{ID: "push", Name: "Push", Description: "Push branch to remote", Context: keymap.ContextWorkspaceList, Priority: 6},
 				plugin.Command{ID: "merge-workflow", Name: "Merge", Description: "Start merge workflow", Context: keymap.ContextWorkspaceList, Priority: 7},
 				plugin.Command{ID: "open-in-git", Name: "Git", Description: "Open in Git tab", Context: keymap.ContextWorkspaceList, Priority: 16},
+				plugin.Command{ID: "compare-branch", Name: "Compare", Description: "Compare branch with its base", Context: keymap.ContextWorkspaceList, Priority: 16},
 			)
 			// Task linking
 			if wt.TaskID != "" {
@@ -265,6 +284,10 @@ func (p *Plugin) FocusContext() keymap.FocusContext {
 		return keymap.ContextWorkspaceFetchPR
 	case ViewModeFilePicker:
 		return keymap.ContextWorkspaceFilePicker
+	case ViewModeConflicts:
+		return keymap.ContextWorkspaceConflicts
+	case ViewModeDiffOptions:
+		return keymap.ContextWorkspaceDiffOptions
 	default:
 		if p.activePane == PanePreview {
 			return keymap.ContextWorkspacePreview
@@ -0,0 +1,81 @@
+package workspace
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/app"
+	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugins/filebrowser"
+	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
+)
+
+// openConflictResolver opens the three-way resolver over the merge modal
+// for conflicts left by a rebase or merge resolution of the base branch.
+func (p *Plugin) openConflictResolver(fallback gitstatus.ConflictOp, files []string) tea.Cmd {
+	workDir := p.ctx.WorkDir
+	op := gitstatus.GetConflictOp(workDir)
+	if op == gitstatus.ConflictOpNone {
+		op = fallback
+	}
+	p.conflictResolver = conflict.New(workDir, op, files)
+	p.viewMode = ViewModeConflicts
+	return p.conflictResolver.Init()
+}
+
+// updateConflictResolver forwards a message to the resolver and acts on
+// the result.
+func (p *Plugin) updateConflictResolver(msg tea.Msg) tea.Cmd {
+	r := p.conflictResolver
+	if r == nil {
+		return nil
+	}
+	action, cmd := r.Update(msg)
+	switch action {
+	case conflict.ActionClose:
+		p.closeConflictResolver()
+		return cmd
+	case conflict.ActionEdit:
+		path, line := r.EditPath(), r.EditLine()
+		return tea.Batch(
+			app.FocusPlugin("file-browser"),
+			func() tea.Msg {
+				return filebrowser.NavigateToFileMsg{Path: path, Edit: true, LineNo: line}
+			},
+		)
+	case conflict.ActionDone:
+		p.closeConflictResolver()
+		if p.mergeState != nil && p.mergeState.CleanupResults != nil {
+			results := p.mergeState.CleanupResults
+			results.PullSuccess = true
+			results.PullError = nil
+			results.BranchDiverged = false
+			results.PullErrorSummary = ""
+			results.PullErrorFull = ""
+		}
+		return appmsg.ShowToast("Conflicts resolved, "+string(r.Op())+" complete", 2*time.Second)
+	case conflict.ActionAborted:
+		p.closeConflictResolver()
+		if p.mergeState != nil && p.mergeState.CleanupResults != nil {
+			p.mergeState.CleanupResults.PullErrorSummary = string(r.Op()) + " aborted, local branch restored"
+		}
+		return appmsg.ShowToast(string(r.Op())+" aborted", 2*time.Second)
+	}
+	return cmd
+}
+
+// closeConflictResolver returns to the merge workflow modal.
+func (p *Plugin) closeConflictResolver() {
+	p.conflictResolver = nil
+	p.viewMode = ViewModeMerge
+	p.clearMergeModal()
+}
+
+// renderConflictResolver renders the full-screen conflict resolver.
+func (p *Plugin) renderConflictResolver(width, height int) string {
+	if p.conflictResolver == nil {
+		return p.renderListView(width, height)
+	}
+	return p.conflictResolver.View(width, height)
+}
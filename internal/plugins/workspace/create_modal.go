@@ -55,11 +55,13 @@ func (p *Plugin) ensureCreateModal() {
 	}
 	p.createModalWidth = modalW
 
-	items := make([]modal.ListItem, len(AgentTypeOrder))
-	for i, at := range AgentTypeOrder {
+	registry := p.agentRegistry()
+	agentOrder := registry.Order()
+	items := make([]modal.ListItem, len(agentOrder))
+	for i, at := range agentOrder {
 		items[i] = modal.ListItem{
 			ID:    createIndexedID(createAgentItemPrefix, i),
-			Label: AgentDisplayNames[at],
+			Label: registry.DisplayName(at),
 		}
 	}
 
@@ -119,11 +121,12 @@ func (p *Plugin) normalizeCreateFocus() {
 }
 
 func (p *Plugin) syncCreateAgentIdx() {
-	if p.createAgentIdx < 0 || p.createAgentIdx >= len(AgentTypeOrder) {
+	agentOrder := p.agentRegistry().Order()
+	if p.createAgentIdx < 0 || p.createAgentIdx >= len(agentOrder) {
 		p.createAgentIdx = p.agentTypeIndex(p.createAgentType)
 		return
 	}
-	if AgentTypeOrder[p.createAgentIdx] != p.createAgentType {
+	if agentOrder[p.createAgentIdx] != p.createAgentType {
 		p.createAgentIdx = p.agentTypeIndex(p.createAgentType)
 	}
 }
@@ -477,7 +480,7 @@ func (p *Plugin) createSkipPermissionsHintSection() modal.Section {
 			return modal.RenderedSection{}
 		}
 		if p.shouldShowSkipPermissions() {
-			flag := SkipPermissionsFlags[p.createAgentType]
+			flag := p.agentRegistry().SkipPermsFlag(p.createAgentType)
 			return modal.RenderedSection{Content: dimText(fmt.Sprintf("      (Adds %s)", flag))}
 		}
 		return modal.RenderedSection{Content: dimText("  Skip permissions not available for this agent")}
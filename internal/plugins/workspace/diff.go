@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
+	"github.com/guyghost/sidecar/internal/state"
 )
 
 // loadSelectedDiff returns a command to load diff for the selected worktree.
@@ -28,8 +30,9 @@ func (p *Plugin) loadSelectedDiff() tea.Cmd {
 // loadDiff returns a command to load diff for a worktree.
 func (p *Plugin) loadDiff(path, name string) tea.Cmd {
 	epoch := p.ctx.Epoch // Capture epoch for stale detection
+	opts := state.GetDiffOptions(state.DiffViewWorkspace)
 	return func() tea.Msg {
-		content, raw, err := getDiff(path)
+		content, raw, err := getDiff(path, opts)
 		if err != nil {
 			return DiffErrorMsg{WorkspaceName: name, Err: err}
 		}
@@ -38,23 +41,23 @@ func (p *Plugin) loadDiff(path, name string) tea.Cmd {
 }
 
 // getDiff returns the diff for a worktree.
-func getDiff(workdir string) (content, raw string, err error) {
+func getDiff(workdir string, opts gitstatus.DiffOptions) (content, raw string, err error) {
 	// Get combined staged and unstaged diff
-	cmd := exec.Command("git", "diff", "HEAD")
+	cmd := exec.Command("git", opts.Command("diff", "HEAD")...)
 	cmd.Dir = workdir
 	output, err := cmd.Output()
 	if err != nil {
 		// No HEAD yet, try just staged/unstaged
-		cmd = exec.Command("git", "diff")
+		cmd = exec.Command("git", opts.Command("diff")...)
 		cmd.Dir = workdir
 		output, _ = cmd.Output()
 	}
 
 	raw = string(output)
 
-	// For now, content is same as raw
-	// Later can add syntax highlighting or delta processing
-	content = raw
+	// Raw keeps the colors marking moved lines for the parser; content is
+	// the plain diff
+	content = gitstatus.StripDiffColor(raw)
 
 	return content, raw, nil
 }
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
 )
 
 func TestMergeBaseHashValidation(t *testing.T) {
@@ -267,3 +269,42 @@ func TestGetWorktreeCommits_WithRemoteTracking(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDiff_AppliesOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+	run("init")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test")
+
+	testFile := filepath.Join(tmpDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("a b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "test.txt")
+	run("commit", "-m", "initial")
+	if err := os.WriteFile(testFile, []byte("a   b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, raw, err := getDiff(tmpDir, gitstatus.DiffOptions{})
+	if err != nil || !strings.Contains(raw, "+a   b") {
+		t.Fatalf("default diff = %q, %v", raw, err)
+	}
+	content, raw, err := getDiff(tmpDir, gitstatus.DiffOptions{Whitespace: gitstatus.WhitespaceChanges, ColorMoved: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(gitstatus.StripDiffColor(raw), "+a   b") {
+		t.Errorf("-b diff should hide the whitespace change, got %q", raw)
+	}
+	if strings.Contains(content, "\x1b[") {
+		t.Errorf("content should be uncolored, got %q", content)
+	}
+}
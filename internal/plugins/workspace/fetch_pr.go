@@ -1,8 +1,6 @@
 package workspace
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -11,37 +9,47 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/forge"
 )
 
-// fetchPRList runs gh pr list and returns open PRs.
+// fetchPRList lists open PRs through the forge hosting origin.
 func (p *Plugin) fetchPRList() tea.Cmd {
 	workDir := p.ctx.WorkDir
+	hosts := p.forgeHosts()
 	return func() tea.Msg {
-		cmd := exec.Command("gh", "pr", "list",
-			"--json", "number,title,headRefName,url,isDraft,createdAt,author",
-			"--limit", "30",
-		)
-		cmd.Dir = workDir
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-		output, err := cmd.Output()
+		f, err := forge.Detect(workDir, hosts)
 		if err != nil {
-			errMsg := strings.TrimSpace(stderr.String())
-			if errMsg == "" {
-				errMsg = err.Error()
-			}
-			return FetchPRListMsg{Err: fmt.Errorf("gh pr list: %s", errMsg)}
+			return FetchPRListMsg{Err: err}
 		}
-
-		var prs []PRListItem
-		if err := json.Unmarshal(output, &prs); err != nil {
-			return FetchPRListMsg{Err: fmt.Errorf("parse pr list: %w", err)}
+		mrs, err := f.ListMergeRequests(workDir, 30)
+		if err != nil {
+			return FetchPRListMsg{Err: err}
 		}
 
+		prs := make([]PRListItem, len(mrs))
+		for i, mr := range mrs {
+			prs[i] = PRListItem{
+				Number:    mr.Number,
+				Title:     mr.Title,
+				Branch:    mr.Branch,
+				Author:    prAuthor{Login: mr.Author},
+				URL:       mr.URL,
+				CreatedAt: mr.CreatedAt,
+				IsDraft:   mr.Draft,
+			}
+		}
 		return FetchPRListMsg{PRs: prs}
 	}
 }
 
+// forgeHosts returns the custom forge hostnames from config.
+func (p *Plugin) forgeHosts() map[string]string {
+	if p.ctx.Config == nil {
+		return nil
+	}
+	return p.ctx.Config.Forges.Hosts
+}
+
 // fetchAndCreateWorktree fetches a PR branch and creates a worktree from it.
 func (p *Plugin) fetchAndCreateWorktree(pr PRListItem) tea.Cmd {
 	workDir := p.ctx.WorkDir
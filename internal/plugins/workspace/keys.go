@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	appmsg "github.com/guyghost/sidecar/internal/msg"
+	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
 	"github.com/guyghost/sidecar/internal/state"
 )
 
@@ -44,6 +45,10 @@ func (p *Plugin) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return p.handleFilePickerKeys(msg)
 	case ViewModeInteractive:
 		return p.handleInteractiveKeys(msg)
+	case ViewModeConflicts:
+		return p.updateConflictResolver(msg)
+	case ViewModeDiffOptions:
+		return p.handleDiffOptionsKeys(msg)
 	}
 	return nil
 }
@@ -68,8 +73,10 @@ func (p *Plugin) handleTypeSelectorKeys(msg tea.KeyMsg) tea.Cmd {
 
 	// Sync agent type when agent index changes (td-f42a86)
 	// No need to rebuild modal - When sections handle visibility dynamically
-	if p.typeSelectorAgentIdx != prevAgentIdx && p.typeSelectorAgentIdx >= 0 && p.typeSelectorAgentIdx < len(ShellAgentOrder) {
-		p.typeSelectorAgentType = ShellAgentOrder[p.typeSelectorAgentIdx]
+	if p.typeSelectorAgentIdx != prevAgentIdx {
+		if shellOrder := p.agentRegistry().ShellOrder(); p.typeSelectorAgentIdx >= 0 && p.typeSelectorAgentIdx < len(shellOrder) {
+			p.typeSelectorAgentType = shellOrder[p.typeSelectorAgentIdx]
+		}
 	}
 
 	switch action {
@@ -707,6 +714,12 @@ func (p *Plugin) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 		if p.activePane == PanePreview && p.previewTab == PreviewTabDiff {
 			return p.openFilePicker()
 		}
+	case "W":
+		// Diff options (when in preview pane on diff tab)
+		if p.activePane == PanePreview && p.previewTab == PreviewTabDiff {
+			p.diffOptionsMenu = gitstatus.NewDiffOptionsMenu(state.DiffViewWorkspace)
+			p.viewMode = ViewModeDiffOptions
+		}
 	case "r":
 		return func() tea.Msg { return RefreshMsg{} }
 	case "i":
@@ -880,6 +893,9 @@ func (p *Plugin) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 		if wt != nil {
 			return p.openInGitTab(wt)
 		}
+	case "=":
+		// Compare the selected worktree's branch with its base branch
+		return p.openCompare(p.selectedWorktree())
 	default:
 		// Unhandled key in preview pane - flash to indicate attach is needed
 		// Only flash if there's something to attach to (shell or worktree with agent)
@@ -1035,8 +1051,8 @@ func (p *Plugin) handleCreateKeys(msg tea.KeyMsg) tea.Cmd {
 
 	wasAgentIdx := p.createAgentIdx
 	action, cmd := p.createModal.HandleKey(msg)
-	if p.createAgentIdx != wasAgentIdx && p.createAgentIdx < len(AgentTypeOrder) {
-		p.createAgentType = AgentTypeOrder[p.createAgentIdx]
+	if agentOrder := p.agentRegistry().Order(); p.createAgentIdx != wasAgentIdx && p.createAgentIdx < len(agentOrder) {
+		p.createAgentType = agentOrder[p.createAgentIdx]
 		p.syncCreateModalFocus()
 	}
 
@@ -1087,8 +1103,7 @@ func (p *Plugin) shouldShowSkipPermissions() bool {
 	if p.createAgentType == AgentNone {
 		return false
 	}
-	flag := SkipPermissionsFlags[p.createAgentType]
-	return flag != ""
+	return p.agentRegistry().SkipPermsFlag(p.createAgentType) != ""
 }
 
 // shouldShowShellSkipPerms returns true if the selected shell agent supports skip permissions.
@@ -1097,11 +1112,11 @@ func (p *Plugin) shouldShowShellSkipPerms() bool {
 	if p.typeSelectorAgentType == AgentNone {
 		return false
 	}
-	return SkipPermissionsFlags[p.typeSelectorAgentType] != ""
+	return p.agentRegistry().SkipPermsFlag(p.typeSelectorAgentType) != ""
 }
 
 func (p *Plugin) agentTypeIndex(agentType AgentType) int {
-	for i, at := range AgentTypeOrder {
+	for i, at := range p.agentRegistry().Order() {
 		if at == agentType {
 			return i
 		}
@@ -1494,6 +1509,20 @@ func (p *Plugin) handleFilePickerKeys(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// handleDiffOptionsKeys handles keys in the diff options menu, reloading
+// the diff when an option changes.
+func (p *Plugin) handleDiffOptionsKeys(msg tea.KeyMsg) tea.Cmd {
+	changed, done := p.diffOptionsMenu.HandleKey(msg.String())
+	if done {
+		p.diffOptionsMenu = nil
+		p.viewMode = ViewModeList
+	}
+	if changed {
+		return p.loadSelectedDiff()
+	}
+	return nil
+}
+
 // openFilePicker opens the file picker modal.
 func (p *Plugin) openFilePicker() tea.Cmd {
 	if p.multiFileDiff == nil || len(p.multiFileDiff.Files) <= 1 {
@@ -1,7 +1,7 @@
 package workspace
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -10,8 +10,10 @@ import (
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/guyghost/sidecar/internal/app"
+	"github.com/guyghost/sidecar/internal/forge"
 	"github.com/guyghost/sidecar/internal/msg"
 	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
+	"github.com/guyghost/sidecar/internal/state"
 )
 
 // MergeWorkflowStep represents the current step in the merge workflow.
@@ -216,8 +218,10 @@ func (p *Plugin) checkUncommittedChanges(wt *Worktree) tea.Cmd {
 	}
 }
 
-// stageAllAndCommit stages all changes and commits with the given message.
+// stageAllAndCommit stages all changes and commits with the given message,
+// signing it if the project asks for signed commits.
 func (p *Plugin) stageAllAndCommit(wt *Worktree, message string) tea.Cmd {
+	sign := state.GetSignCommits(p.ctx.ProjectRoot)
 	return func() tea.Msg {
 		tree := gitstatus.NewFileTree(wt.Path)
 		if tree == nil {
@@ -236,7 +240,7 @@ func (p *Plugin) stageAllAndCommit(wt *Worktree, message string) tea.Cmd {
 		}
 
 		// Execute commit
-		hash, err := gitstatus.ExecuteCommit(wt.Path, message)
+		hash, err := gitstatus.ExecuteCommit(wt.Path, message, sign)
 		if err != nil {
 			return MergeCommitDoneMsg{
 				WorkspaceName: wt.Name,
@@ -331,114 +335,65 @@ func (p *Plugin) pushForMerge(wt *Worktree) tea.Cmd {
 	}
 }
 
-// parseExistingPRURL extracts the PR URL from a "PR already exists" error message.
-// Returns the URL and true if found, empty string and false otherwise.
-func parseExistingPRURL(output string) (string, bool) {
-	// Error format: "a pull request for branch X into branch Y already exists: <URL>: exit status 1"
-	const marker = "already exists:"
-	idx := strings.Index(output, marker)
-	if idx == -1 {
-		return "", false
-	}
-
-	// Extract URL after marker
-	rest := strings.TrimSpace(output[idx+len(marker):])
-
-	// Find the URL - it starts with http and ends before ": exit" or end of string
-	if !strings.HasPrefix(rest, "http") {
-		return "", false
-	}
-
-	// Find where URL ends - look for ": exit" pattern which follows the URL
-	endIdx := strings.Index(rest, ": exit")
-	if endIdx == -1 {
-		// No ": exit" suffix, URL goes to end (trim whitespace)
-		endIdx = strings.IndexAny(rest, " \t\n")
-		if endIdx == -1 {
-			endIdx = len(rest)
-		}
-	}
-
-	url := strings.TrimSpace(rest[:endIdx])
-	if url == "" {
-		return "", false
-	}
-	return url, true
-}
-
-// createPR creates a pull request using gh CLI.
+// createPR opens a pull request through the forge hosting origin.
 func (p *Plugin) createPR(wt *Worktree, title, body, targetBranch string) tea.Cmd {
+	hosts := p.forgeHosts()
 	return func() tea.Msg {
-		// Build gh pr create command
-		args := []string{"pr", "create",
-			"--title", title,
-			"--body", body,
-			"--base", targetBranch,
+		f, err := forge.Detect(wt.Path, hosts)
+		if err != nil {
+			return MergeStepCompleteMsg{
+				WorkspaceName: wt.Name,
+				Step:          MergeStepCreatePR,
+				Err:           err,
+			}
 		}
 
-		cmd := exec.Command("gh", args...)
-		cmd.Dir = wt.Path
-		output, err := cmd.CombinedOutput()
-
+		prURL, err := f.CreateMergeRequest(wt.Path, forge.NewMergeRequest{
+			Title: title,
+			Body:  body,
+			Base:  targetBranch,
+		})
 		if err != nil {
 			// Check if PR already exists
-			outputStr := string(output)
-			if existingURL, found := parseExistingPRURL(outputStr); found {
+			var exists *forge.ExistsError
+			if errors.As(err, &exists) {
 				return MergeStepCompleteMsg{
-					WorkspaceName:    wt.Name,
+					WorkspaceName:   wt.Name,
 					Step:            MergeStepCreatePR,
-					Data:            existingURL,
+					Data:            exists.URL,
 					ExistingPRFound: true,
 				}
 			}
 			return MergeStepCompleteMsg{
 				WorkspaceName: wt.Name,
-				Step:         MergeStepCreatePR,
-				Err:          fmt.Errorf("gh pr create: %s: %w", strings.TrimSpace(outputStr), err),
+				Step:          MergeStepCreatePR,
+				Err:           err,
 			}
 		}
 
-		// Output should contain the PR URL
-		prURL := strings.TrimSpace(string(output))
-
 		return MergeStepCompleteMsg{
 			WorkspaceName: wt.Name,
-			Step:         MergeStepCreatePR,
-			Data:         prURL,
+			Step:          MergeStepCreatePR,
+			Data:          prURL,
 		}
 	}
 }
 
-// checkPRMerged checks if a PR has been merged using gh CLI.
+// checkPRMerged checks if the worktree's PR has been merged.
 func (p *Plugin) checkPRMerged(wt *Worktree) tea.Cmd {
+	hosts := p.forgeHosts()
 	return func() tea.Msg {
-		// Use gh pr view to check status
-		cmd := exec.Command("gh", "pr", "view", "--json", "state,mergedAt")
-		cmd.Dir = wt.Path
-		output, err := cmd.Output()
-
+		f, err := forge.Detect(wt.Path, hosts)
 		if err != nil {
-			return CheckPRMergedMsg{
-				WorkspaceName: wt.Name,
-				Merged:       false,
-				Err:          err,
-			}
-		}
-
-		// Parse JSON response
-		var prStatus struct {
-			State    string `json:"state"`
-			MergedAt string `json:"mergedAt"`
+			return CheckPRMergedMsg{WorkspaceName: wt.Name, Err: err}
 		}
-
-		merged := false
-		if err := json.Unmarshal(output, &prStatus); err == nil {
-			merged = prStatus.MergedAt != "" || prStatus.State == "MERGED"
+		state, err := f.MergeRequestState(wt.Path)
+		if err != nil {
+			return CheckPRMergedMsg{WorkspaceName: wt.Name, Err: err}
 		}
-
 		return CheckPRMergedMsg{
 			WorkspaceName: wt.Name,
-			Merged:       merged,
+			Merged:        state == forge.MergeStateMerged,
 		}
 	}
 }
@@ -655,6 +610,7 @@ type RebaseResolutionMsg struct {
 	Branch       string
 	Success      bool
 	Err          error
+	Conflicts    []string // Conflicted files when the rebase stopped
 }
 
 // MergeResolutionMsg signals result of merge resolution attempt.
@@ -663,6 +619,7 @@ type MergeResolutionMsg struct {
 	Branch       string
 	Success      bool
 	Err          error
+	Conflicts    []string // Conflicted files when the merge stopped
 }
 
 // executeRebaseResolution performs git pull --rebase to resolve diverged branches.
@@ -686,6 +643,7 @@ func (p *Plugin) executeRebaseResolution() tea.Cmd {
 				Branch:       branch,
 				Success:      false,
 				Err:          fmt.Errorf("rebase failed: %s", strings.TrimSpace(string(output))),
+				Conflicts:    gitstatus.GetConflictedFiles(workDir),
 			}
 		}
 
@@ -718,6 +676,7 @@ func (p *Plugin) executeMergeResolution() tea.Cmd {
 				Branch:       branch,
 				Success:      false,
 				Err:          fmt.Errorf("merge failed: %s", strings.TrimSpace(string(output))),
+				Conflicts:    gitstatus.GetConflictedFiles(workDir),
 			}
 		}
 
@@ -3,8 +3,17 @@ package workspace
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/guyghost/sidecar/internal/config"
+	"github.com/guyghost/sidecar/internal/keymap"
+	"github.com/guyghost/sidecar/internal/plugin"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
 )
 
 func TestMergeWorkflowStepString(t *testing.T) {
@@ -255,64 +264,6 @@ func TestDeleteDoneMsgWarnings(t *testing.T) {
 	}
 }
 
-func TestParseExistingPRURL(t *testing.T) {
-	tests := []struct {
-		name      string
-		output    string
-		wantURL   string
-		wantFound bool
-	}{
-		{
-			name:      "standard error with PR URL",
-			output:    `a pull request for branch "workspace-improvements" into branch "main" already exists: https://github.com/guyghost/sidecar/pull/30: exit status 1`,
-			wantURL:   "https://github.com/guyghost/sidecar/pull/30",
-			wantFound: true,
-		},
-		{
-			name:      "error without exit status suffix",
-			output:    `a pull request for branch "feature" into branch "main" already exists: https://github.com/owner/repo/pull/123`,
-			wantURL:   "https://github.com/owner/repo/pull/123",
-			wantFound: true,
-		},
-		{
-			name:      "different error message",
-			output:    `GraphQL: Could not resolve to a Repository with the name 'owner/repo'.`,
-			wantURL:   "",
-			wantFound: false,
-		},
-		{
-			name:      "empty output",
-			output:    ``,
-			wantURL:   "",
-			wantFound: false,
-		},
-		{
-			name:      "already exists but no URL",
-			output:    `a pull request already exists: `,
-			wantURL:   "",
-			wantFound: false,
-		},
-		{
-			name:      "URL with trailing newline",
-			output:    "a pull request already exists: https://github.com/o/r/pull/1\n",
-			wantURL:   "https://github.com/o/r/pull/1",
-			wantFound: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotURL, gotFound := parseExistingPRURL(tt.output)
-			if gotURL != tt.wantURL {
-				t.Errorf("parseExistingPRURL() url = %q, want %q", gotURL, tt.wantURL)
-			}
-			if gotFound != tt.wantFound {
-				t.Errorf("parseExistingPRURL() found = %v, want %v", gotFound, tt.wantFound)
-			}
-		})
-	}
-}
-
 func TestSummarizeGitError(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -422,3 +373,77 @@ func TestSummarizeGitError(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeResolutionConflictsOpenResolver(t *testing.T) {
+	p := &Plugin{
+		ctx:      &plugin.Context{WorkDir: t.TempDir()},
+		viewMode: ViewModeMerge,
+		mergeState: &MergeWorkflowState{
+			Worktree:       &Worktree{Name: "test"},
+			Step:           MergeStepDone,
+			CleanupResults: &CleanupResults{BranchDiverged: true, BaseBranch: "main"},
+		},
+	}
+
+	p.Update(MergeResolutionMsg{
+		WorkspaceName: "test",
+		Branch:        "main",
+		Err:           fmt.Errorf("merge failed: CONFLICT (content): Merge conflict in f.txt"),
+		Conflicts:     []string{"f.txt"},
+	})
+	if p.viewMode != ViewModeConflicts || p.conflictResolver == nil {
+		t.Fatalf("viewMode = %v, want ViewModeConflicts", p.viewMode)
+	}
+	if p.FocusContext() != keymap.ContextWorkspaceConflicts {
+		t.Errorf("FocusContext() = %v", p.FocusContext())
+	}
+
+	// Continuing to completion returns to the merge modal with the pull fixed
+	p.Update(conflict.ContinuedMsg{WorkDir: p.ctx.WorkDir})
+	if p.viewMode != ViewModeMerge || p.conflictResolver != nil {
+		t.Errorf("viewMode = %v, want ViewModeMerge", p.viewMode)
+	}
+	if results := p.mergeState.CleanupResults; !results.PullSuccess || results.BranchDiverged {
+		t.Errorf("cleanup results = %+v", results)
+	}
+}
+
+func TestCreatePR_DispatchesToConfiguredForge(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLI is a shell script")
+	}
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "feature"},
+		{"remote", "add", "origin", "git@code.example.com:team/app.git"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	// A fake glab first on PATH; gh must not be called
+	bin := t.TempDir()
+	glab := "#!/bin/sh\necho 'https://code.example.com/team/app/-/merge_requests/8'\n"
+	if err := os.WriteFile(filepath.Join(bin, "glab"), []byte(glab), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cfg := config.Default()
+	cfg.Forges.Hosts["code.example.com"] = "gitlab"
+	p := &Plugin{ctx: &plugin.Context{WorkDir: repo, Config: cfg}}
+
+	msg := p.createPR(&Worktree{Name: "feature", Path: repo}, "Add feature", "", "main")().(MergeStepCompleteMsg)
+	if msg.Err != nil {
+		t.Fatalf("Err = %v", msg.Err)
+	}
+	if msg.Data != "https://code.example.com/team/app/-/merge_requests/8" {
+		t.Errorf("Data = %q, want the merge request URL", msg.Data)
+	}
+}
@@ -232,7 +232,7 @@ type cursorPositionMsg struct {
 // Triggers a fresh poll so captured content reflects the new width/wrapping.
 type paneResizedMsg struct{}
 
-// FetchPRListMsg delivers the list of open PRs from gh CLI.
+// FetchPRListMsg delivers the list of open PRs from the forge CLI.
 type FetchPRListMsg struct {
 	PRs []PRListItem
 	Err error
@@ -257,7 +257,7 @@ type PRListItem struct {
 	IsDraft   bool      `json:"isDraft"`
 }
 
-// prAuthor represents the author field from gh pr list --json.
+// prAuthor identifies the author of a PR.
 type prAuthor struct {
 	Login string `json:"login"`
 }
@@ -80,6 +80,11 @@ func (p *Plugin) handleMouse(msg tea.MouseMsg) tea.Cmd {
 		return p.handleCommitForMergeModalMouse(msg)
 	}
 
+	if p.viewMode == ViewModeConflicts {
+		// The conflict resolver is keyboard-driven
+		return nil
+	}
+
 	action := p.mouseHandler.HandleMouse(msg)
 
 	switch action.Type {
@@ -159,9 +164,9 @@ func (p *Plugin) handleCreateModalMouse(msg tea.MouseMsg) tea.Cmd {
 		p.syncCreateModalFocus()
 		return nil
 	}
-	if idx, ok := parseIndexedID(createAgentItemPrefix, action); ok && idx < len(AgentTypeOrder) {
+	if idx, ok := parseIndexedID(createAgentItemPrefix, action); ok && idx < len(p.agentRegistry().Order()) {
 		p.createAgentIdx = idx
-		p.createAgentType = AgentTypeOrder[idx]
+		p.createAgentType = p.agentRegistry().Order()[idx]
 		p.createFocus = 4
 		p.syncCreateModalFocus()
 		return nil
@@ -647,8 +652,8 @@ func (p *Plugin) handleMouseClick(action mouse.MouseAction) tea.Cmd {
 	case regionCreateAgentOption:
 		// Click on agent option
 		if idx, ok := action.Region.Data.(int); ok {
-			if idx >= 0 && idx < len(AgentTypeOrder) {
-				p.createAgentType = AgentTypeOrder[idx]
+			if agentOrder := p.agentRegistry().Order(); idx >= 0 && idx < len(agentOrder) {
+				p.createAgentType = agentOrder[idx]
 			}
 		}
 	case regionCreateCheckbox:
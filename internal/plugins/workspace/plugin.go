@@ -16,6 +16,7 @@ import (
 	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
 	"github.com/guyghost/sidecar/internal/state"
 	"github.com/guyghost/sidecar/internal/ui"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
 )
 
 const (
@@ -108,6 +109,7 @@ type Plugin struct {
 	// Worktree state
 	worktrees []*Worktree
 	agents    map[string]*Agent
+	registry  *AgentRegistry // Agents that can be started, from the config
 
 	// Session tracking for safe cleanup
 	managedSessions map[string]bool
@@ -165,6 +167,9 @@ type Plugin struct {
 	// File picker modal state (gf command)
 	filePickerIdx int // Selected file index in picker
 
+	// Diff options menu (W on the diff tab)
+	diffOptionsMenu *gitstatus.DiffOptionsMenu
+
 	// Commit status header for diff view
 	commitStatusList     []CommitStatusInfo
 	commitStatusWorktree string // Name of worktree for cached status
@@ -178,7 +183,7 @@ type Plugin struct {
 	createTaskID          string
 	createTaskTitle       string    // Title of selected task for display
 	createAgentType       AgentType // Selected agent type (default: AgentClaude)
-	createAgentIdx        int       // Selected agent index in AgentTypeOrder
+	createAgentIdx        int       // Selected agent index in the registry order
 	createSkipPermissions bool      // Skip permissions checkbox
 	createFocus           int       // 0=name, 1=base, 2=prompt, 3=task, 4=agent, 5=skipPerms, 6=create, 7=cancel
 	createButtonHover     int       // 0=none, 1=create, 2=cancel
@@ -232,6 +237,9 @@ type Plugin struct {
 	mergeModalWidth int               // Cached width for rebuild detection
 	mergeModalStep  MergeWorkflowStep // Cached step for rebuild detection
 
+	// Conflict resolver for merge/rebase resolution of the base branch
+	conflictResolver *conflict.Resolver
+
 	// Commit-before-merge state
 	mergeCommitState         *MergeCommitState
 	mergeCommitMessageInput  textinput.Model
@@ -306,12 +314,12 @@ type Plugin struct {
 	pendingResumeWorktree string // Worktree name to enter interactive mode after agent starts
 
 	// Fetch PR modal state
-	fetchPRItems        []PRListItem // PRs from gh pr list
+	fetchPRItems        []PRListItem // Open PRs from the forge CLI
 	fetchPRFilter       string       // Filter text
 	fetchPRCursor       int          // Selected index in filtered list
 	fetchPRScrollOffset int          // Scroll offset for PR list
-	fetchPRLoading      bool         // True while gh pr list is running
-	fetchPRError        string       // Error message from gh CLI
+	fetchPRLoading      bool         // True while the PR list is loading
+	fetchPRError        string       // Error message from the forge CLI
 	fetchPRModal        *modal.Modal // Modal instance
 	fetchPRModalWidth   int          // Cached width for rebuild detection
 
@@ -376,6 +384,7 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 	if ctx.Config != nil && ctx.Config.Plugins.Workspace.TmuxCaptureMaxBytes > 0 {
 		p.tmuxCaptureMaxBytes = ctx.Config.Plugins.Workspace.TmuxCaptureMaxBytes
 	}
+	p.registry = AgentRegistryFromConfig(ctx.Config)
 
 	// Reset agent-related state for clean reinit (important for project switching)
 	// Without this, reconnectAgents() won't run again after switching projects
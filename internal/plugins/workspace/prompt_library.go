@@ -0,0 +1,197 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PlaceholderKind identifies what a placeholder suggestion replaces.
+type PlaceholderKind string
+
+const (
+	PlaceholderTicket PlaceholderKind = "ticket" // Task/issue identifier
+	PlaceholderPath   PlaceholderKind = "path"   // File or directory path
+)
+
+// PlaceholderSuggestion is a literal in a prompt body that can be turned
+// into a template placeholder.
+type PlaceholderSuggestion struct {
+	Kind        PlaceholderKind
+	Literal     string // Text found in the body
+	Placeholder string // Replacement template text
+}
+
+var (
+	// taskIDPatterns match td task IDs, Jira-style keys and GitHub issue refs.
+	taskIDPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\btd-[0-9a-f]{4,}\b`),
+		regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`),
+		regexp.MustCompile(`(?:^|\s)(#[0-9]+)\b`),
+	}
+
+	// pathPattern matches slash-separated paths and bare file names with a
+	// common source extension. URLs are filtered out separately.
+	pathPattern = regexp.MustCompile(`(?:\.{0,2}/)?(?:[\w.-]+/)+[\w.-]+|\b[\w-]+\.(?:go|ts|tsx|js|jsx|py|rs|rb|java|kt|swift|c|h|cpp|md|json|ya?ml|toml|sh|sql)\b`)
+)
+
+// SuggestPlaceholders finds task IDs and paths in body that could become
+// placeholders. Task IDs map to {{ticket}}; paths map to {{path || '...'}}
+// so the original value is kept as the fallback. Results are deduplicated
+// and ordered by first appearance.
+func SuggestPlaceholders(body string) []PlaceholderSuggestion {
+	type match struct {
+		start, end int
+		s          PlaceholderSuggestion
+	}
+	var matches []match
+	var pathSpans [][2]int
+
+	for _, loc := range pathPattern.FindAllStringIndex(body, -1) {
+		literal := body[loc[0]:loc[1]]
+		if isURLAt(body, loc[0]) || !strings.ContainsAny(literal, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			continue
+		}
+		// Quotes would break the fallback syntax
+		if strings.Contains(literal, "'") {
+			continue
+		}
+		pathSpans = append(pathSpans, [2]int{loc[0], loc[1]})
+		matches = append(matches, match{loc[0], loc[1], PlaceholderSuggestion{
+			Kind:        PlaceholderPath,
+			Literal:     literal,
+			Placeholder: "{{path || '" + literal + "'}}",
+		}})
+	}
+
+	inPath := func(start, end int) bool {
+		for _, span := range pathSpans {
+			if start < span[1] && end > span[0] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, re := range taskIDPatterns {
+		for _, loc := range re.FindAllStringSubmatchIndex(body, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			if inPath(start, end) {
+				continue
+			}
+			matches = append(matches, match{start, end, PlaceholderSuggestion{
+				Kind:        PlaceholderTicket,
+				Literal:     body[start:end],
+				Placeholder: "{{ticket}}",
+			}})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+
+	seen := make(map[string]bool)
+	var result []PlaceholderSuggestion
+	for _, m := range matches {
+		if seen[m.s.Literal] {
+			continue
+		}
+		seen[m.s.Literal] = true
+		result = append(result, m.s)
+	}
+	return result
+}
+
+// isURLAt reports whether the match starting at idx is part of a URL.
+func isURLAt(body string, idx int) bool {
+	start := strings.LastIndexAny(body[:idx], " \t\n") + 1
+	word := body[start:]
+	return strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://")
+}
+
+// ApplyPlaceholders replaces each suggestion's literal with its placeholder.
+// Longer literals win when one contains another.
+func ApplyPlaceholders(body string, suggestions []PlaceholderSuggestion) string {
+	if len(suggestions) == 0 {
+		return body
+	}
+	sorted := append([]PlaceholderSuggestion(nil), suggestions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Literal) > len(sorted[j].Literal)
+	})
+	pairs := make([]string, 0, len(sorted)*2)
+	for _, s := range sorted {
+		pairs = append(pairs, s.Literal, s.Placeholder)
+	}
+	return strings.NewReplacer(pairs...).Replace(body)
+}
+
+// SavePrompt adds or replaces (by name) a prompt in the config.json in dir,
+// preserving all other config fields. The directory is created if needed.
+func SavePrompt(dir string, prompt Prompt) error {
+	if strings.TrimSpace(prompt.Name) == "" {
+		return fmt.Errorf("prompt name is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "config.json")
+
+	// Read existing config as raw JSON to preserve unknown fields
+	raw := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	var prompts []Prompt
+	if existing, ok := raw["prompts"]; ok {
+		if err := json.Unmarshal(existing, &prompts); err != nil {
+			return fmt.Errorf("parse prompts in %s: %w", path, err)
+		}
+	}
+
+	replaced := false
+	for i := range prompts {
+		if prompts[i].Name == prompt.Name {
+			prompts[i] = prompt
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		prompts = append(prompts, prompt)
+	}
+
+	promptsData, err := json.Marshal(prompts)
+	if err != nil {
+		return err
+	}
+	raw["prompts"] = promptsData
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// PromptConfigDir returns the config directory LoadPrompts reads for the
+// given source ("global" or "project").
+func PromptConfigDir(globalConfigDir, projectDir, source string) string {
+	if source == "project" {
+		return filepath.Join(projectDir, ".sidecar")
+	}
+	return globalConfigDir
+}
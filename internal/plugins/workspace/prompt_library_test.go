@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSuggestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []PlaceholderSuggestion
+	}{
+		{
+			name: "td task id",
+			body: "Start work on td-aa4136 and close it",
+			want: []PlaceholderSuggestion{
+				{Kind: PlaceholderTicket, Literal: "td-aa4136", Placeholder: "{{ticket}}"},
+			},
+		},
+		{
+			name: "jira key and github issue",
+			body: "Fix PROJ-42, see #17",
+			want: []PlaceholderSuggestion{
+				{Kind: PlaceholderTicket, Literal: "PROJ-42", Placeholder: "{{ticket}}"},
+				{Kind: PlaceholderTicket, Literal: "#17", Placeholder: "{{ticket}}"},
+			},
+		},
+		{
+			name: "paths",
+			body: "Refactor internal/app/model.go and update README.md",
+			want: []PlaceholderSuggestion{
+				{Kind: PlaceholderPath, Literal: "internal/app/model.go", Placeholder: "{{path || 'internal/app/model.go'}}"},
+				{Kind: PlaceholderPath, Literal: "README.md", Placeholder: "{{path || 'README.md'}}"},
+			},
+		},
+		{
+			name: "task id inside path not suggested separately",
+			body: "Read docs/td-abc123.md",
+			want: []PlaceholderSuggestion{
+				{Kind: PlaceholderPath, Literal: "docs/td-abc123.md", Placeholder: "{{path || 'docs/td-abc123.md'}}"},
+			},
+		},
+		{
+			name: "urls and numbers ignored",
+			body: "See https://example.com/a/b on 2024/01/02",
+		},
+		{
+			name: "duplicates collapsed",
+			body: "td-1234 then td-1234 again",
+			want: []PlaceholderSuggestion{
+				{Kind: PlaceholderTicket, Literal: "td-1234", Placeholder: "{{ticket}}"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestPlaceholders(tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SuggestPlaceholders(%q) = %+v, want %+v", tt.body, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("suggestion %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPlaceholders(t *testing.T) {
+	body := "Fix td-abc123 in internal/app/model.go, then td-abc123 again"
+	got := ApplyPlaceholders(body, SuggestPlaceholders(body))
+	want := "Fix {{ticket}} in {{path || 'internal/app/model.go'}}, then {{ticket}} again"
+	if got != want {
+		t.Errorf("ApplyPlaceholders() = %q, want %q", got, want)
+	}
+
+	// Expanding the result restores the path and inserts the ticket
+	if expanded := ExpandPromptTemplate(got, "td-999"); expanded != "Fix td-999 in internal/app/model.go, then td-999 again" {
+		t.Errorf("ExpandPromptTemplate() = %q", expanded)
+	}
+
+	if got := ApplyPlaceholders(body, nil); got != body {
+		t.Errorf("ApplyPlaceholders with no suggestions changed body: %q", got)
+	}
+}
+
+func TestSavePrompt_NewFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested")
+	prompt := Prompt{Name: "Review", TicketMode: TicketRequired, Body: "Review {{ticket}}"}
+
+	if err := SavePrompt(dir, prompt); err != nil {
+		t.Fatalf("SavePrompt() error: %v", err)
+	}
+
+	prompts := loadPromptsFromDir(dir, "global")
+	if len(prompts) != 1 || prompts[0].Name != "Review" || prompts[0].Body != "Review {{ticket}}" {
+		t.Errorf("unexpected prompts after save: %+v", prompts)
+	}
+}
+
+func TestSavePrompt_PreservesConfigAndReplacesByName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	existing := `{"theme": "dark", "prompts": [{"name": "A", "body": "old"}, {"name": "B", "body": "keep"}]}`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SavePrompt(dir, Prompt{Name: "A", TicketMode: TicketNone, Body: "new"}); err != nil {
+		t.Fatalf("SavePrompt() error: %v", err)
+	}
+	if err := SavePrompt(dir, Prompt{Name: "C", TicketMode: TicketNone, Body: "added"}); err != nil {
+		t.Fatalf("SavePrompt() error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON written: %v", err)
+	}
+	if string(raw["theme"]) != `"dark"` {
+		t.Errorf("theme not preserved: %s", raw["theme"])
+	}
+
+	prompts := loadPromptsFromDir(dir, "project")
+	if len(prompts) != 3 {
+		t.Fatalf("expected 3 prompts, got %d", len(prompts))
+	}
+	if prompts[0].Body != "new" || prompts[1].Body != "keep" || prompts[2].Name != "C" {
+		t.Errorf("unexpected prompts: %+v", prompts)
+	}
+}
+
+func TestSavePrompt_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if err := SavePrompt(dir, Prompt{Name: "  "}); err == nil {
+		t.Error("expected error for empty name")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SavePrompt(dir, Prompt{Name: "A"}); err == nil {
+		t.Error("expected error for invalid existing config")
+	}
+}
+
+func TestPromptConfigDir(t *testing.T) {
+	if got := PromptConfigDir("/home/u/.config/sidecar", "/repo", "project"); got != filepath.Join("/repo", ".sidecar") {
+		t.Errorf("project dir = %q", got)
+	}
+	if got := PromptConfigDir("/home/u/.config/sidecar", "/repo", "global"); got != "/home/u/.config/sidecar" {
+		t.Errorf("global dir = %q", got)
+	}
+}
@@ -104,6 +104,18 @@ func TestExpandPromptTemplate(t *testing.T) {
 			taskID:   "",
 			expected: "Work on  or fallback",
 		},
+		{
+			name:     "named placeholder uses fallback",
+			body:     "Fix {{ticket}} in {{path || 'internal/app/model.go'}}",
+			taskID:   "td-123",
+			expected: "Fix td-123 in internal/app/model.go",
+		},
+		{
+			name:     "named placeholder without fallback left as-is",
+			body:     "Look at {{path}}",
+			taskID:   "td-123",
+			expected: "Look at {{path}}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -640,9 +640,10 @@ func (p *Plugin) recreateOrphanedShell(idx int) tea.Cmd {
 // startAgentInShell sends an agent command to an existing shell's tmux session.
 // td-21a2d8: Called after shell is created when an agent was selected.
 func (p *Plugin) startAgentInShell(tmuxName string, agentType AgentType, skipPerms bool) tea.Cmd {
+	registry := p.agentRegistry()
 	return func() tea.Msg {
 		// Get the base command for this agent type
-		baseCmd := AgentCommands[agentType]
+		baseCmd := registry.Command(agentType)
 		if baseCmd == "" {
 			return ShellAgentErrorMsg{
 				TmuxName: tmuxName,
@@ -652,7 +653,7 @@ func (p *Plugin) startAgentInShell(tmuxName string, agentType AgentType, skipPer
 
 		// Add skip permissions flag if enabled
 		if skipPerms {
-			if flag := SkipPermissionsFlags[agentType]; flag != "" {
+			if flag := registry.SkipPermsFlag(agentType); flag != "" {
 				baseCmd = baseCmd + " " + flag
 			}
 		}
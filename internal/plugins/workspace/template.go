@@ -7,11 +7,15 @@ import (
 // ticketPattern matches {{ticket}} or {{ticket || 'fallback text'}}
 var ticketPattern = regexp.MustCompile(`\{\{ticket(?:\s*\|\|\s*'([^']*)')?\}\}`)
 
+// namedPlaceholderPattern matches {{name || 'fallback text'}} for any name.
+var namedPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\|\|\s*'([^']*)'\s*\}\}`)
+
 // ExpandPromptTemplate expands template variables in a prompt body.
 // - {{ticket}} expands to taskID (returns empty if taskID is empty)
 // - {{ticket || 'default'}} expands to taskID, or 'default' if taskID is empty
+// - {{name || 'default'}} expands to 'default' for any other name
 func ExpandPromptTemplate(body, taskID string) string {
-	return ticketPattern.ReplaceAllStringFunc(body, func(match string) string {
+	body = ticketPattern.ReplaceAllStringFunc(body, func(match string) string {
 		submatch := ticketPattern.FindStringSubmatch(match)
 
 		if taskID != "" {
@@ -26,4 +30,7 @@ func ExpandPromptTemplate(body, taskID string) string {
 		// No fallback, return empty
 		return ""
 	})
+
+	// Other named placeholders have no value source yet; use their fallback
+	return namedPlaceholderPattern.ReplaceAllString(body, "$2")
 }
@@ -38,6 +38,8 @@ const (
 	ViewModeFilePicker                     // Diff file picker modal
 	ViewModeInteractive                    // Interactive mode (tmux input passthrough)
 	ViewModeFetchPR                        // Fetch remote PR modal
+	ViewModeConflicts                      // Three-way conflict resolver
+	ViewModeDiffOptions                    // Diff options menu over the diff tab
 )
 
 // FocusPane represents which pane is active in the split view.
@@ -132,68 +134,6 @@ const (
 	AgentShell    AgentType = "shell"    // Project shell (not an AI agent)
 )
 
-// SkipPermissionsFlags maps agent types to their skip-permissions CLI flags.
-var SkipPermissionsFlags = map[AgentType]string{
-	AgentClaude:   "--dangerously-skip-permissions",
-	AgentCodex:    "--dangerously-bypass-approvals-and-sandbox",
-	AgentAider:    "--yes",
-	AgentGemini:   "--yolo",
-	AgentCursor:   "-f",
-	AgentOpenCode: "", // No known flag
-}
-
-// AgentDisplayNames provides human-readable names for agent types.
-var AgentDisplayNames = map[AgentType]string{
-	AgentNone:     "None (attach only)",
-	AgentClaude:   "Claude Code",
-	AgentCodex:    "Codex CLI",
-	AgentGemini:   "Gemini CLI",
-	AgentCursor:   "Cursor Agent",
-	AgentOpenCode: "OpenCode",
-	AgentShell:    "Project Shell",
-}
-
-// shellAgentAbbreviations provides short labels for agent types in shell entries.
-// td-a29b76: Used to show agent type in sidebar without taking too much space.
-var shellAgentAbbreviations = map[AgentType]string{
-	AgentClaude:   "Claude",
-	AgentCodex:    "Codex",
-	AgentGemini:   "Gemini",
-	AgentCursor:   "Cursor",
-	AgentOpenCode: "OpenCode",
-}
-
-// AgentCommands maps agent types to their CLI commands.
-var AgentCommands = map[AgentType]string{
-	AgentClaude:   "claude",
-	AgentCodex:    "codex",
-	AgentAider:    "aider", // Not in UI, but supported for backward compat
-	AgentGemini:   "gemini",
-	AgentCursor:   "cursor-agent",
-	AgentOpenCode: "opencode",
-}
-
-// AgentTypeOrder defines the order of agents in selection UI.
-var AgentTypeOrder = []AgentType{
-	AgentClaude,
-	AgentCodex,
-	AgentGemini,
-	AgentCursor,
-	AgentOpenCode,
-	AgentNone,
-}
-
-// ShellAgentOrder defines agent order for shell creation (None first as default).
-// td-a902fe: shells default to no agent, so "None" is first.
-var ShellAgentOrder = []AgentType{
-	AgentNone,
-	AgentClaude,
-	AgentCodex,
-	AgentGemini,
-	AgentCursor,
-	AgentOpenCode,
-}
-
 // kanbanCardData stores column and row for Kanban card hit regions.
 type kanbanCardData struct {
 	col int
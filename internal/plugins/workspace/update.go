@@ -11,6 +11,7 @@ import (
 	app "github.com/guyghost/sidecar/internal/app"
 	"github.com/guyghost/sidecar/internal/plugin"
 	"github.com/guyghost/sidecar/internal/plugins/gitstatus"
+	"github.com/guyghost/sidecar/internal/ui/conflict"
 )
 
 // Update handles messages.
@@ -29,6 +30,10 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		return p, p.resizeSelectedPaneCmd()
 
 	case app.PluginFocusedMsg:
+		if p.viewMode == ViewModeConflicts && p.conflictResolver != nil {
+			// Pick up edits made in the inline editor
+			return p, p.conflictResolver.Reload()
+		}
 		if p.focused {
 			// Poll shell or selected agent when plugin gains focus
 			if shell := p.getSelectedShell(); shell != nil {
@@ -1230,6 +1235,9 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				p.mergeState.CleanupResults.PullErrorSummary = summary
 				p.mergeState.CleanupResults.PullErrorFull = full
 				p.mergeState.CleanupResults.BranchDiverged = diverged
+				if len(msg.Conflicts) > 0 {
+					cmds = append(cmds, p.openConflictResolver(gitstatus.ConflictOpRebase, msg.Conflicts))
+				}
 			}
 		}
 
@@ -1249,9 +1257,17 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				p.mergeState.CleanupResults.PullErrorSummary = summary
 				p.mergeState.CleanupResults.PullErrorFull = full
 				p.mergeState.CleanupResults.BranchDiverged = diverged
+				if len(msg.Conflicts) > 0 {
+					cmds = append(cmds, p.openConflictResolver(gitstatus.ConflictOpMerge, msg.Conflicts))
+				}
 			}
 		}
 
+	case conflict.LoadedMsg, conflict.StagedMsg, conflict.EditReadyMsg, conflict.ContinuedMsg:
+		if cmd := p.updateConflictResolver(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
 	case reconnectedAgentsMsg:
 		// After reconnecting to existing sessions, detect orphaned worktrees
 		// (worktrees with .sidecar-agent file but no tmux session)
@@ -79,9 +79,17 @@ func (p *Plugin) View(width, height int) string {
 		return p.renderRenameShellModal(width, height)
 	case ViewModeFetchPR:
 		return p.renderFetchPRModal(width, height)
+	case ViewModeConflicts:
+		return p.renderConflictResolver(width, height)
 	case ViewModeFilePicker:
 		background := p.renderListView(width, height)
 		return p.renderFilePickerModal(background)
+	case ViewModeDiffOptions:
+		background := p.renderListView(width, height)
+		if p.diffOptionsMenu == nil {
+			return background
+		}
+		return ui.OverlayModal(background, p.diffOptionsMenu.Render(width), width, height)
 	default:
 		return p.renderListView(width, height)
 	}
@@ -692,20 +700,14 @@ func (p *Plugin) renderShellEntryForSession(shell *ShellSession, selected bool,
 	if shell.IsOrphaned {
 		// td-f88fdd: Orphaned shell - show "offline" status
 		if shell.ChosenAgent != AgentNone && shell.ChosenAgent != "" {
-			agentAbbrev := shellAgentAbbreviations[shell.ChosenAgent]
-			if agentAbbrev == "" {
-				agentAbbrev = string(shell.ChosenAgent)
-			}
+			agentAbbrev := p.agentRegistry().Abbrev(shell.ChosenAgent)
 			statusText = fmt.Sprintf("%s · offline", agentAbbrev)
 		} else {
 			statusText = "shell · offline"
 		}
 	} else if shell.ChosenAgent != AgentNone && shell.ChosenAgent != "" {
 		// Show agent type abbreviation
-		agentAbbrev := shellAgentAbbreviations[shell.ChosenAgent]
-		if agentAbbrev == "" {
-			agentAbbrev = string(shell.ChosenAgent)
-		}
+		agentAbbrev := p.agentRegistry().Abbrev(shell.ChosenAgent)
 		if shell.Agent != nil {
 			statusText = fmt.Sprintf("%s · running", agentAbbrev)
 		} else {
@@ -1181,11 +1181,13 @@ func (p *Plugin) ensureTypeSelectorModal() {
 	p.typeSelectorNameInput.Placeholder = p.nextShellDisplayName()
 
 	// Build agent list items for shell (td-a902fe)
-	agentItems := make([]modal.ListItem, len(ShellAgentOrder))
-	for i, at := range ShellAgentOrder {
+	registry := p.agentRegistry()
+	shellOrder := registry.ShellOrder()
+	agentItems := make([]modal.ListItem, len(shellOrder))
+	for i, at := range shellOrder {
 		agentItems[i] = modal.ListItem{
 			ID:    typeSelectorAgentItemPfx + string(at),
-			Label: AgentDisplayNames[at],
+			Label: registry.DisplayName(at),
 		}
 	}
 
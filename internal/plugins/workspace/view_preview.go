@@ -399,10 +399,7 @@ func (p *Plugin) renderOrphanedMessage(agentType AgentType) string {
 	lines = append(lines, "")
 
 	// Show previously running agent
-	agentName := AgentDisplayNames[agentType]
-	if agentName == "" {
-		agentName = string(agentType)
-	}
+	agentName := p.agentRegistry().DisplayName(agentType)
 	lines = append(lines, dimText(fmt.Sprintf("Previously running: %s", agentName)))
 	lines = append(lines, "")
 
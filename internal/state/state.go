@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/guyghost/sidecar/internal/git"
 )
 
 // State holds persistent user preferences.
@@ -13,6 +15,10 @@ type State struct {
 	WorkspaceDiffMode string `json:"workspaceDiffMode,omitempty"` // "unified" or "side-by-side"
 	GitGraphEnabled   bool   `json:"gitGraphEnabled,omitempty"`   // Show commit graph in sidebar
 	LineWrapEnabled   bool   `json:"lineWrapEnabled,omitempty"`   // Wrap long lines instead of truncating
+	CommitComposer    bool   `json:"commitComposer,omitempty"`    // Compose conventional commits field by field
+
+	// Diff options keyed by view (DiffViewGitStatus, DiffViewCommit, DiffViewWorkspace)
+	DiffOptions map[string]git.DiffOptions `json:"diffOptions,omitempty"`
 
 	// Pane width preferences (percentage of total width, 0 = use default)
 	FileBrowserTreeWidth   int `json:"fileBrowserTreeWidth,omitempty"`
@@ -26,6 +32,9 @@ type State struct {
 	Notes        map[string]NotesState       `json:"notes,omitempty"`
 	ActivePlugin map[string]string           `json:"activePlugin,omitempty"`
 
+	// Projects whose commits are always signed with -S (keyed by project root)
+	SignCommits map[string]bool `json:"signCommits,omitempty"`
+
 	// Worktree state: maps main repo path -> last active worktree path
 	LastWorktreePath map[string]string `json:"lastWorktreePath,omitempty"`
 }
@@ -171,6 +180,41 @@ func SetWorkspaceDiffMode(mode string) error {
 	return Save()
 }
 
+// Views with their own diff options.
+const (
+	DiffViewGitStatus = "git-status" // Working tree and staged diffs
+	DiffViewCommit    = "commit"     // Commit preview and commit file diffs
+	DiffViewWorkspace = "workspace"  // Workspace diff tab
+)
+
+// GetDiffOptions returns the saved diff options for view.
+func GetDiffOptions(view string) git.DiffOptions {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return git.DiffOptions{}
+	}
+	return current.DiffOptions[view]
+}
+
+// SetDiffOptions saves the diff options for view.
+func SetDiffOptions(view string, opts git.DiffOptions) error {
+	mu.Lock()
+	if current == nil {
+		current = &State{}
+	}
+	if opts.IsDefault() {
+		delete(current.DiffOptions, view)
+	} else {
+		if current.DiffOptions == nil {
+			current.DiffOptions = make(map[string]git.DiffOptions)
+		}
+		current.DiffOptions[view] = opts
+	}
+	mu.Unlock()
+	return Save()
+}
+
 // GetGitGraphEnabled returns whether the commit graph is enabled.
 func GetGitGraphEnabled() bool {
 	mu.RLock()
@@ -192,6 +236,28 @@ func SetGitGraphEnabled(enabled bool) error {
 	return Save()
 }
 
+// GetCommitComposer returns whether the commit modal uses the conventional
+// commit composer.
+func GetCommitComposer() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return false
+	}
+	return current.CommitComposer
+}
+
+// SetCommitComposer saves the commit composer preference.
+func SetCommitComposer(enabled bool) error {
+	mu.Lock()
+	if current == nil {
+		current = &State{}
+	}
+	current.CommitComposer = enabled
+	mu.Unlock()
+	return Save()
+}
+
 // GetLineWrapEnabled returns whether line wrapping is enabled.
 func GetLineWrapEnabled() bool {
 	mu.RLock()
@@ -373,6 +439,36 @@ func SetActivePlugin(workdir, pluginID string) error {
 	return Save()
 }
 
+// GetSignCommits reports whether commits in the project at workdir are
+// always signed.
+func GetSignCommits(workdir string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return false
+	}
+	return current.SignCommits[workdir]
+}
+
+// SetSignCommits sets whether commits in the project at workdir are always
+// signed.
+func SetSignCommits(workdir string, sign bool) error {
+	mu.Lock()
+	if current == nil {
+		current = &State{}
+	}
+	if sign {
+		if current.SignCommits == nil {
+			current.SignCommits = make(map[string]bool)
+		}
+		current.SignCommits[workdir] = true
+	} else {
+		delete(current.SignCommits, workdir)
+	}
+	mu.Unlock()
+	return Save()
+}
+
 // GetLastWorktreePath returns the last active worktree path for a main repo.
 func GetLastWorktreePath(mainRepoPath string) string {
 	mu.RLock()
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+
+	"github.com/guyghost/sidecar/internal/git"
 )
 
 func TestInit(t *testing.T) {
@@ -754,3 +756,76 @@ func TestSetLineWrapEnabled_InitializesNilState(t *testing.T) {
 		t.Errorf("LineWrapEnabled = %v, want true", current.LineWrapEnabled)
 	}
 }
+
+func TestSetDiffOptions_PerView(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalPath := path
+	originalCurrent := current
+	defer func() {
+		path = originalPath
+		current = originalCurrent
+	}()
+
+	stateFile := filepath.Join(tmpDir, "state.json")
+	path = stateFile
+	current = nil
+
+	opts := git.DiffOptions{Whitespace: git.WhitespaceAll, Context: 10, ColorMoved: true}
+	if err := SetDiffOptions(DiffViewWorkspace, opts); err != nil {
+		t.Fatalf("SetDiffOptions() failed: %v", err)
+	}
+	if got := GetDiffOptions(DiffViewWorkspace); got != opts {
+		t.Errorf("GetDiffOptions(workspace) = %+v, want %+v", got, opts)
+	}
+	if got := GetDiffOptions(DiffViewGitStatus); !got.IsDefault() {
+		t.Errorf("GetDiffOptions(git-status) = %+v, want defaults", got)
+	}
+
+	// Verify saved to disk
+	if err := Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetDiffOptions(DiffViewWorkspace); got != opts {
+		t.Errorf("loaded options = %+v, want %+v", got, opts)
+	}
+
+	// Resetting to defaults drops the entry
+	if err := SetDiffOptions(DiffViewWorkspace, git.DiffOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := current.DiffOptions[DiffViewWorkspace]; ok {
+		t.Error("default options should not be stored")
+	}
+}
+
+func TestSetSignCommits(t *testing.T) {
+	originalPath := path
+	originalCurrent := current
+	defer func() {
+		path = originalPath
+		current = originalCurrent
+	}()
+
+	path = filepath.Join(t.TempDir(), "state.json")
+	current = nil
+
+	if GetSignCommits("/repo") {
+		t.Error("signing should be off by default")
+	}
+	if err := SetSignCommits("/repo", true); err != nil {
+		t.Fatalf("SetSignCommits() failed: %v", err)
+	}
+	if err := Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !GetSignCommits("/repo") || GetSignCommits("/other") {
+		t.Error("signing should be saved for /repo only")
+	}
+
+	if err := SetSignCommits("/repo", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := current.SignCommits["/repo"]; ok {
+		t.Error("turning signing off should drop the entry")
+	}
+}
@@ -0,0 +1,5 @@
+// Package conflict provides the three-way merge conflict resolver shared by
+// the git status and workspace plugins. It shows base, ours and theirs for
+// each conflicted hunk, stages resolved files and continues the merge,
+// rebase, cherry-pick or revert that stopped.
+package conflict
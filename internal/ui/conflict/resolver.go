@@ -0,0 +1,680 @@
+package conflict
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/guyghost/sidecar/internal/git"
+	"github.com/guyghost/sidecar/internal/styles"
+)
+
+// Action tells the host plugin what to do after the resolver handles a message.
+type Action int
+
+const (
+	ActionNone    Action = iota
+	ActionClose          // Close the resolver; conflicts may remain
+	ActionEdit           // Open EditPath at EditLine in the inline editor
+	ActionDone           // The operation was continued to completion
+	ActionAborted        // The operation was aborted
+)
+
+// LoadedMsg is sent when a conflicted file loads.
+type LoadedMsg struct {
+	WorkDir string
+	Path    string
+	File    *git.ConflictFile
+	Err     error
+}
+
+// StagedMsg is sent when a resolved file has been written and staged.
+type StagedMsg struct {
+	WorkDir string
+	Path    string
+	Err     error
+}
+
+// EditReadyMsg is sent when the merged file has been written for editing.
+type EditReadyMsg struct {
+	WorkDir string
+	Path    string
+	Line    int // 0-indexed line of the selected hunk
+	Err     error
+}
+
+// ContinuedMsg is sent when continuing or aborting the operation returns.
+type ContinuedMsg struct {
+	WorkDir string
+	Aborted bool
+	Next    []string // Files conflicting in the next step of a rebase or sequence
+	Err     error
+}
+
+// Resolver is an embeddable three-way conflict resolver. Hosts forward key
+// presses and the resolver's own messages to Update and act on the returned
+// Action.
+type Resolver struct {
+	workDir  string
+	op       git.ConflictOp
+	files    []string
+	staged   map[string]bool
+	fileIdx  int
+	file     *git.ConflictFile
+	hunkIdx  int
+	busy     bool   // Command in flight
+	status   string // Informational message
+	err      string // Last error
+	editPath string
+	editLine int
+}
+
+// New creates a resolver for the conflicted files of op in workDir. Call
+// Init to load the first file.
+func New(workDir string, op git.ConflictOp, files []string) *Resolver {
+	return &Resolver{
+		workDir: workDir,
+		op:      op,
+		files:   files,
+		staged:  make(map[string]bool),
+	}
+}
+
+// Init loads the first conflicted file.
+func (r *Resolver) Init() tea.Cmd {
+	return r.load()
+}
+
+// WorkDir returns the working tree the resolver operates on.
+func (r *Resolver) WorkDir() string { return r.workDir }
+
+// Op returns the operation being resolved.
+func (r *Resolver) Op() git.ConflictOp { return r.op }
+
+// EditPath returns the file to open for ActionEdit, relative to WorkDir.
+func (r *Resolver) EditPath() string { return r.editPath }
+
+// EditLine returns the 0-indexed line to open for ActionEdit.
+func (r *Resolver) EditLine() int { return r.editLine }
+
+// Reload reloads the current file, picking up edits made outside the resolver.
+func (r *Resolver) Reload() tea.Cmd {
+	if r.busy {
+		return nil
+	}
+	return r.load()
+}
+
+// load reads the current file's stages asynchronously.
+func (r *Resolver) load() tea.Cmd {
+	if r.fileIdx >= len(r.files) {
+		return nil
+	}
+	workDir, path := r.workDir, r.files[r.fileIdx]
+	r.busy = true
+	return func() tea.Msg {
+		f, err := git.LoadConflictFile(workDir, path)
+		return LoadedMsg{WorkDir: workDir, Path: path, File: f, Err: err}
+	}
+}
+
+// Update handles key presses and resolver messages.
+func (r *Resolver) Update(msg tea.Msg) (Action, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return r.handleKey(msg)
+
+	case LoadedMsg:
+		if msg.WorkDir != r.workDir || r.fileIdx >= len(r.files) || msg.Path != r.files[r.fileIdx] {
+			return ActionNone, nil
+		}
+		r.busy = false
+		if msg.Err != nil {
+			r.err = msg.Err.Error()
+			r.file = nil
+			return ActionNone, nil
+		}
+		r.file = msg.File
+		if hunks := r.file.Hunks(); r.hunkIdx >= len(hunks) {
+			r.hunkIdx = 0
+		}
+
+	case StagedMsg:
+		if msg.WorkDir != r.workDir {
+			return ActionNone, nil
+		}
+		r.busy = false
+		if msg.Err != nil {
+			r.err = msg.Err.Error()
+			return ActionNone, nil
+		}
+		r.staged[msg.Path] = true
+		if next := r.nextUnstaged(); next >= 0 {
+			r.selectFile(next)
+			r.status = "Staged " + msg.Path
+			return ActionNone, r.load()
+		}
+		r.status = "All conflicts resolved. Press c to continue the " + string(r.op) + "."
+
+	case EditReadyMsg:
+		if msg.WorkDir != r.workDir {
+			return ActionNone, nil
+		}
+		r.busy = false
+		if msg.Err != nil {
+			r.err = msg.Err.Error()
+			return ActionNone, nil
+		}
+		r.editPath = msg.Path
+		r.editLine = msg.Line
+		return ActionEdit, nil
+
+	case ContinuedMsg:
+		if msg.WorkDir != r.workDir {
+			return ActionNone, nil
+		}
+		r.busy = false
+		if msg.Err != nil {
+			r.err = msg.Err.Error()
+			return ActionNone, nil
+		}
+		if msg.Aborted {
+			return ActionAborted, nil
+		}
+		if len(msg.Next) == 0 {
+			return ActionDone, nil
+		}
+		// The next commit of a rebase or sequence conflicts too
+		r.files = msg.Next
+		r.staged = make(map[string]bool)
+		r.selectFile(0)
+		r.status = fmt.Sprintf("Continued; the next step conflicts in %d file(s)", len(msg.Next))
+		return ActionNone, r.load()
+	}
+	return ActionNone, nil
+}
+
+// handleKey handles key presses in the resolver.
+func (r *Resolver) handleKey(msg tea.KeyMsg) (Action, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" || key == "q" {
+		return ActionClose, nil
+	}
+	if r.busy {
+		return ActionNone, nil
+	}
+	r.err = ""
+
+	switch key {
+	case "j", "down", "n":
+		r.moveHunk(1)
+	case "k", "up", "N":
+		r.moveHunk(-1)
+	case "tab", "l", "right":
+		r.selectFile((r.fileIdx + 1) % max(len(r.files), 1))
+		return ActionNone, r.load()
+	case "shift+tab", "h", "left":
+		r.selectFile((r.fileIdx - 1 + len(r.files)) % max(len(r.files), 1))
+		return ActionNone, r.load()
+	case "o":
+		r.choose(git.ChoiceOurs)
+	case "t":
+		r.choose(git.ChoiceTheirs)
+	case "b":
+		r.choose(git.ChoiceBoth)
+	case "B":
+		r.choose(git.ChoiceBothTheirs)
+	case "x":
+		r.choose(git.ChoiceNone)
+	case "O":
+		r.chooseAll(git.ChoiceOurs)
+	case "T":
+		r.chooseAll(git.ChoiceTheirs)
+	case "e":
+		return ActionNone, r.edit()
+	case "r":
+		return ActionNone, r.load()
+	case "s", "enter":
+		return ActionNone, r.stage()
+	case "c":
+		return ActionNone, r.continueOp()
+	case "A":
+		return ActionNone, r.abortOp()
+	}
+	return ActionNone, nil
+}
+
+// selectFile switches to the file at idx, clearing per-file state.
+func (r *Resolver) selectFile(idx int) {
+	if idx < 0 || idx >= len(r.files) {
+		return
+	}
+	r.fileIdx = idx
+	r.file = nil
+	r.hunkIdx = 0
+	r.status = ""
+}
+
+// nextUnstaged returns the index of the next file still to stage, or -1.
+func (r *Resolver) nextUnstaged() int {
+	for i := range r.files {
+		idx := (r.fileIdx + 1 + i) % len(r.files)
+		if !r.staged[r.files[idx]] {
+			return idx
+		}
+	}
+	return -1
+}
+
+// moveHunk moves the hunk selection, clamped to the file's hunks.
+func (r *Resolver) moveHunk(delta int) {
+	if r.file == nil {
+		return
+	}
+	n := len(r.file.Hunks())
+	r.hunkIdx += delta
+	if r.hunkIdx >= n {
+		r.hunkIdx = n - 1
+	}
+	if r.hunkIdx < 0 {
+		r.hunkIdx = 0
+	}
+}
+
+// choose applies a choice to the selected hunk, or to the whole file for
+// binary and delete conflicts, then moves to the next unresolved hunk.
+func (r *Resolver) choose(choice git.ConflictChoice) {
+	if r.file == nil || r.file.Edited || r.staged[r.file.Path] {
+		return
+	}
+	if r.file.WholeFile() {
+		if choice == git.ChoiceOurs || choice == git.ChoiceTheirs || choice == git.ChoiceNone {
+			r.file.FileChoice = choice
+		}
+		return
+	}
+	hunks := r.file.Hunks()
+	if r.hunkIdx >= len(hunks) {
+		return
+	}
+	hunks[r.hunkIdx].Choice = choice
+	if choice == git.ChoiceNone {
+		return
+	}
+	for i := r.hunkIdx + 1; i < len(hunks); i++ {
+		if hunks[i].Choice == git.ChoiceNone {
+			r.hunkIdx = i
+			return
+		}
+	}
+}
+
+// chooseAll applies a choice to every hunk of the current file.
+func (r *Resolver) chooseAll(choice git.ConflictChoice) {
+	if r.file == nil || r.file.Edited || r.staged[r.file.Path] {
+		return
+	}
+	if r.file.WholeFile() {
+		r.file.FileChoice = choice
+		return
+	}
+	for _, h := range r.file.Hunks() {
+		h.Choice = choice
+	}
+}
+
+// stage writes the resolved file and stages it.
+func (r *Resolver) stage() tea.Cmd {
+	f := r.file
+	if f == nil || r.staged[f.Path] {
+		return nil
+	}
+	if !f.Resolved() {
+		r.err = "Resolve every hunk first, or press e to edit the file"
+		return nil
+	}
+	workDir := r.workDir
+	r.busy = true
+	return func() tea.Msg {
+		return StagedMsg{WorkDir: workDir, Path: f.Path, Err: git.ResolveConflictFile(workDir, f)}
+	}
+}
+
+// edit writes the merged file with the choices made so far, so the editor
+// opens on the same hunks the resolver shows.
+func (r *Resolver) edit() tea.Cmd {
+	f := r.file
+	if f == nil || f.WholeFile() || r.staged[f.Path] {
+		return nil
+	}
+	workDir := r.workDir
+	line := f.HunkLine(r.hunkIdx)
+	r.busy = true
+	return func() tea.Msg {
+		var err error
+		if !f.Edited {
+			fullPath := filepath.Join(workDir, f.Path)
+			mode := os.FileMode(0o644)
+			if info, statErr := os.Stat(fullPath); statErr == nil {
+				mode = info.Mode().Perm()
+			}
+			err = os.WriteFile(fullPath, []byte(f.Content()), mode)
+		}
+		return EditReadyMsg{WorkDir: workDir, Path: f.Path, Line: line, Err: err}
+	}
+}
+
+// continueOp continues the operation once every file is staged.
+func (r *Resolver) continueOp() tea.Cmd {
+	remaining := 0
+	for _, f := range r.files {
+		if !r.staged[f] {
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		r.err = fmt.Sprintf("%d file(s) still conflicted", remaining)
+		return nil
+	}
+	workDir, op := r.workDir, r.op
+	r.busy = true
+	r.status = "Continuing " + string(op) + "..."
+	return func() tea.Msg {
+		next, err := git.ContinueConflictOp(workDir, op)
+		return ContinuedMsg{WorkDir: workDir, Next: next, Err: err}
+	}
+}
+
+// abortOp aborts the operation, restoring the branch.
+func (r *Resolver) abortOp() tea.Cmd {
+	workDir, op := r.workDir, r.op
+	r.busy = true
+	r.status = "Aborting " + string(op) + "..."
+	return func() tea.Msg {
+		err := git.AbortConflictOp(workDir, op)
+		return ContinuedMsg{WorkDir: workDir, Aborted: true, Err: err}
+	}
+}
+
+// sideLabels returns the column titles for ours and theirs. During a rebase
+// "ours" is the branch being rebased onto.
+func (r *Resolver) sideLabels() (ours, theirs string) {
+	switch r.op {
+	case git.ConflictOpRebase:
+		return "Ours (upstream)", "Theirs (your commit)"
+	case git.ConflictOpCherryPick:
+		return "Ours (HEAD)", "Theirs (picked)"
+	case git.ConflictOpRevert:
+		return "Ours (HEAD)", "Theirs (revert)"
+	}
+	return "Ours (HEAD)", "Theirs (incoming)"
+}
+
+// View renders the resolver as a bordered panel of the given outer size.
+func (r *Resolver) View(width, height int) string {
+	contentW := width - 4
+	if contentW < 30 {
+		contentW = 30
+	}
+	innerH := height - 2
+	if innerH < 10 {
+		innerH = 10
+	}
+
+	var sb strings.Builder
+	title := styles.Title.Render("Resolve Conflicts")
+	if r.op != git.ConflictOpNone {
+		title += styles.Muted.Render(" · " + string(r.op))
+	}
+	sb.WriteString(title)
+	sb.WriteString("\n")
+	sb.WriteString(r.renderFileTabs(contentW))
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render(strings.Repeat("━", contentW)))
+	sb.WriteString("\n")
+
+	footer := r.renderFooter(contentW)
+	bodyH := innerH - 3 - lipgloss.Height(footer) - 1
+	sb.WriteString(r.renderBody(contentW, bodyH))
+	sb.WriteString("\n")
+	sb.WriteString(footer)
+
+	return styles.RenderPanel(sb.String(), width, height, true)
+}
+
+// renderFileTabs renders the conflicted files with their state.
+func (r *Resolver) renderFileTabs(width int) string {
+	var parts []string
+	for i, f := range r.files {
+		name := filepath.Base(f)
+		switch {
+		case r.staged[f]:
+			parts = append(parts, styles.StatusStaged.Render("✓ "+name))
+		case i == r.fileIdx:
+			parts = append(parts, styles.StatusModified.Bold(true).Render("● "+name))
+		default:
+			parts = append(parts, styles.Muted.Render("○ "+name))
+		}
+	}
+	line := strings.Join(parts, "  ")
+	if lipgloss.Width(line) > width {
+		line = fmt.Sprintf("File %d of %d: %s", r.fileIdx+1, len(r.files), r.currentPath())
+		line = truncate(line, width)
+	}
+	return line
+}
+
+// currentPath returns the selected file path.
+func (r *Resolver) currentPath() string {
+	if r.fileIdx < len(r.files) {
+		return r.files[r.fileIdx]
+	}
+	return ""
+}
+
+// renderBody renders the selected hunk's three versions and its result.
+func (r *Resolver) renderBody(width, height int) string {
+	path := r.currentPath()
+	f := r.file
+	switch {
+	case path == "":
+		return styles.Muted.Render("No conflicted files.")
+	case r.staged[path]:
+		return styles.StatusStaged.Render("✓ "+path+" is resolved and staged.") + padLines(height-1)
+	case f == nil && r.busy:
+		return styles.Muted.Render("Loading "+path+"...") + padLines(height-1)
+	case f == nil:
+		return styles.Muted.Render("Could not load "+path+".") + padLines(height-1)
+	case f.Edited:
+		return styles.StatusStaged.Render(path+": no conflict markers left.") + "\n" +
+			styles.Muted.Render("Press s to stage the file as edited.") + padLines(height-2)
+	case f.WholeFile():
+		return r.renderWholeFile(f, height)
+	}
+
+	hunks := f.Hunks()
+	if len(hunks) == 0 {
+		return styles.Muted.Render(path+": no conflicted hunks. Press s to stage.") + padLines(height-1)
+	}
+	h := hunks[r.hunkIdx]
+	resolved := 0
+	for _, hk := range hunks {
+		if hk.Choice != git.ChoiceNone {
+			resolved++
+		}
+	}
+	header := fmt.Sprintf("%s · hunk %d of %d · %d resolved", path, r.hunkIdx+1, len(hunks), resolved)
+	if h.Choice != git.ChoiceNone {
+		header += " · " + styles.StatusStaged.Render(choiceLabel(h.Choice))
+	}
+
+	resultLines := h.Lines()
+	resultH := min(len(resultLines), 6) + 1
+	if h.Choice == git.ChoiceNone {
+		resultH = 0
+	}
+	colH := height - 2 - resultH
+	if colH < 3 {
+		colH = 3
+	}
+
+	oursLabel, theirsLabel := r.sideLabels()
+	colW := (width - 6) / 3
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderColumn(oursLabel, h.Ours, colW, colH, h.Choice == git.ChoiceOurs || isBoth(h.Choice)),
+		renderSeparator(colH),
+		renderColumn("Base", h.Base, colW, colH, false),
+		renderSeparator(colH),
+		renderColumn(theirsLabel, h.Theirs, colW, colH, h.Choice == git.ChoiceTheirs || isBoth(h.Choice)),
+	)
+
+	var sb strings.Builder
+	sb.WriteString(truncate(header, width))
+	sb.WriteString("\n\n")
+	sb.WriteString(columns)
+	if resultH > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(styles.Subtitle.Render("Result"))
+		for i, l := range resultLines {
+			if i >= 6 {
+				break
+			}
+			sb.WriteString("\n")
+			sb.WriteString(styles.StatusStaged.Render(truncate(displayLine(l), width)))
+		}
+	}
+	return sb.String()
+}
+
+// renderWholeFile renders a binary or delete/modify conflict.
+func (r *Resolver) renderWholeFile(f *git.ConflictFile, height int) string {
+	oursLabel, theirsLabel := r.sideLabels()
+	var desc string
+	switch {
+	case f.OursDeleted:
+		desc = fmt.Sprintf("Deleted in %s, modified in %s.", oursLabel, theirsLabel)
+	case f.TheirsDeleted:
+		desc = fmt.Sprintf("Modified in %s, deleted in %s.", oursLabel, theirsLabel)
+	default:
+		desc = "Binary file: pick one side."
+	}
+	lines := []string{
+		f.Path,
+		"",
+		styles.StatusModified.Render(desc),
+		styles.Muted.Render("o keeps ours, t keeps theirs; a deleted side removes the file."),
+	}
+	if f.FileChoice != git.ChoiceNone {
+		lines = append(lines, "", styles.StatusStaged.Render("Choice: "+choiceLabel(f.FileChoice)))
+	}
+	return strings.Join(lines, "\n") + padLines(height-len(lines))
+}
+
+// renderFooter renders status, errors and key hints.
+func (r *Resolver) renderFooter(width int) string {
+	var lines []string
+	if r.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Error).Render(truncate(firstLine(r.err), width)))
+	} else if r.status != "" {
+		lines = append(lines, styles.Muted.Render(truncate(r.status, width)))
+	} else {
+		lines = append(lines, "")
+	}
+	hints := "o ours  t theirs  b both  x clear  O/T all  e edit  s stage  c continue  A abort  tab file  esc close"
+	lines = append(lines, styles.Muted.Render(truncate(hints, width)))
+	return strings.Join(lines, "\n")
+}
+
+// renderColumn renders one side of a hunk with a title and fixed height.
+func renderColumn(title string, lines []string, width, height int, chosen bool) string {
+	titleStyle := styles.Subtitle
+	if chosen {
+		title = "✓ " + title
+		titleStyle = styles.StatusStaged.Bold(true)
+	}
+	out := []string{titleStyle.Render(pad(truncate(title, width), width))}
+	for i := 0; i < height-1; i++ {
+		switch {
+		case i < len(lines) && i == height-2 && len(lines) > height-1:
+			out = append(out, styles.Muted.Render(pad(fmt.Sprintf("… %d more", len(lines)-i), width)))
+		case i < len(lines):
+			out = append(out, pad(truncate(displayLine(lines[i]), width), width))
+		case i == 0:
+			out = append(out, styles.Muted.Render(pad("(empty)", width)))
+		default:
+			out = append(out, strings.Repeat(" ", width))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderSeparator renders a vertical column separator.
+func renderSeparator(height int) string {
+	return styles.Muted.Render(strings.TrimSuffix(strings.Repeat(" │ \n", height), "\n"))
+}
+
+// choiceLabel describes a choice for display.
+func choiceLabel(c git.ConflictChoice) string {
+	switch c {
+	case git.ChoiceOurs:
+		return "ours"
+	case git.ChoiceTheirs:
+		return "theirs"
+	case git.ChoiceBoth:
+		return "both (ours first)"
+	case git.ChoiceBothTheirs:
+		return "both (theirs first)"
+	}
+	return "unresolved"
+}
+
+func isBoth(c git.ConflictChoice) bool {
+	return c == git.ChoiceBoth || c == git.ChoiceBothTheirs
+}
+
+// displayLine strips the line terminator and expands tabs.
+func displayLine(line string) string {
+	return strings.ReplaceAll(strings.TrimRight(line, "\r\n"), "\t", "    ")
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// truncate shortens s to width runes, ending with an ellipsis.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)) > width-1 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// pad right-pads s with spaces to width.
+func pad(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// padLines returns n newlines, used to keep the footer in place.
+func padLines(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("\n", n)
+}
@@ -0,0 +1,132 @@
+package conflict
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/guyghost/sidecar/internal/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// newMergeConflict leaves a repo with a stopped merge conflicting in f.txt.
+func newMergeConflict(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "f.txt"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "config", "user.name", "Test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	write("one\ntwo\nthree\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "base")
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	write("one\nTHEIRS\nthree\n")
+	runGit(t, dir, "commit", "-q", "-am", "theirs")
+	runGit(t, dir, "checkout", "-q", "main")
+	write("one\nOURS\nthree\n")
+	runGit(t, dir, "commit", "-q", "-am", "ours")
+
+	cmd := exec.Command("git", "merge", "side")
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected merge to conflict")
+	}
+	return dir
+}
+
+// run executes cmd and feeds its message back into the resolver.
+func run(t *testing.T, r *Resolver, cmd tea.Cmd) Action {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	action, next := r.Update(cmd())
+	if next != nil {
+		return run(t, r, next)
+	}
+	return action
+}
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestResolver_ResolveStageAndContinue(t *testing.T) {
+	dir := newMergeConflict(t)
+	r := New(dir, git.GetConflictOp(dir), git.GetConflictedFiles(dir))
+	run(t, r, r.Init())
+
+	view := r.View(120, 30)
+	for _, want := range []string{"Resolve Conflicts", "merge", "Ours (HEAD)", "Base", "Theirs (incoming)", "OURS", "two", "THEIRS"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	if _, cmd := r.Update(key("s")); cmd != nil || r.err == "" {
+		t.Error("staging an unresolved file should be refused")
+	}
+	if _, cmd := r.Update(key("c")); cmd != nil || r.err == "" {
+		t.Error("continuing with conflicted files should be refused")
+	}
+
+	r.Update(key("t"))
+	if !strings.Contains(r.View(120, 30), "Result") {
+		t.Error("a chosen hunk should show its result")
+	}
+	_, cmd := r.Update(key("s"))
+	run(t, r, cmd)
+	if !r.staged["f.txt"] {
+		t.Fatalf("f.txt should be staged, err=%q", r.err)
+	}
+
+	_, cmd = r.Update(key("c"))
+	if action := run(t, r, cmd); action != ActionDone {
+		t.Fatalf("continue action = %v, err=%q", action, r.err)
+	}
+	if git.GetConflictOp(dir) != git.ConflictOpNone {
+		t.Error("merge should be concluded")
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "f.txt"))
+	if string(got) != "one\nTHEIRS\nthree\n" {
+		t.Errorf("merged file = %q", got)
+	}
+}
+
+func TestResolver_EditWritesChoices(t *testing.T) {
+	dir := newMergeConflict(t)
+	r := New(dir, git.ConflictOpMerge, []string{"f.txt"})
+	run(t, r, r.Init())
+
+	_, cmd := r.Update(key("e"))
+	if action := run(t, r, cmd); action != ActionEdit {
+		t.Fatalf("edit action = %v, err=%q", action, r.err)
+	}
+	if r.EditPath() != "f.txt" || r.EditLine() != 1 {
+		t.Errorf("edit target = %s:%d", r.EditPath(), r.EditLine())
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "f.txt"))
+	if !strings.Contains(string(got), "||||||| base") {
+		t.Errorf("edited file should carry diff3 markers, got %q", got)
+	}
+
+	if action, _ := r.Update(tea.KeyMsg{Type: tea.KeyEsc}); action != ActionClose {
+		t.Error("esc should close the resolver")
+	}
+}